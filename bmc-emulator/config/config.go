@@ -0,0 +1,164 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package config holds the run time configuration of the BMC emulator
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+)
+
+const (
+	// ConfigFilePathEnv is the environment variable holding the path of the configuration file
+	ConfigFilePathEnv = "BMC_EMULATOR_CONFIG_FILE_PATH"
+
+	defaultPowerTransitionInSeconds      = 2
+	defaultTaskDurationInSeconds         = 10
+	defaultEventDeliveryTimeoutInSeconds = 10
+	defaultErrorStatusCode               = 500
+)
+
+// Config is the configuration of the emulator
+type Config struct {
+	// RootCACertificatePath is the CA certificate used to verify the event destinations,
+	// the system CA certificates are used when it is not set
+	RootCACertificatePath         string    `json:"RootCACertificatePath"`
+	EventDeliveryTimeoutInSeconds int       `json:"EventDeliveryTimeoutInSeconds"`
+	BMCs                          []BMCConf `json:"BMCs"`
+}
+
+// BMCConf is the configuration of one emulated BMC
+type BMCConf struct {
+	// Name identifies the BMC in the logs
+	Name string `json:"Name"`
+	// ListenAddress is the host:port at which the BMC serves its Redfish service
+	ListenAddress string `json:"ListenAddress"`
+	// MockupPath is the directory of the DMTF Redfish mockup served by the BMC
+	MockupPath      string `json:"MockupPath"`
+	UserName        string `json:"UserName"`
+	Password        string `json:"Password"`
+	CertificatePath string `json:"CertificatePath"`
+	PrivateKeyPath  string `json:"PrivateKeyPath"`
+	// PowerTransitionInSeconds is the time taken by a system to power on or off
+	PowerTransitionInSeconds *int `json:"PowerTransitionInSeconds"`
+	// TaskDurationInSeconds is the time taken by a firmware update task
+	TaskDurationInSeconds *int `json:"TaskDurationInSeconds"`
+	// FaultInjection holds the faults injected at start up, they are changed
+	// at run time through /emulator/v1/FaultInjection
+	FaultInjection FaultConf `json:"FaultInjection"`
+}
+
+// FaultConf holds the faults injected in the Redfish requests served by a BMC
+type FaultConf struct {
+	// LatencyInMilliseconds is added to every response
+	LatencyInMilliseconds int `json:"LatencyInMilliseconds"`
+	// LatencyJitterInMilliseconds is the maximum random latency added on top of LatencyInMilliseconds
+	LatencyJitterInMilliseconds int `json:"LatencyJitterInMilliseconds"`
+	// ErrorRatePercent is the share of the requests answered with ErrorStatusCode
+	ErrorRatePercent int `json:"ErrorRatePercent"`
+	ErrorStatusCode  int `json:"ErrorStatusCode"`
+	// SessionDropRatePercent is the share of the requests of a session which end the session
+	SessionDropRatePercent int `json:"SessionDropRatePercent"`
+}
+
+// Load reads the configuration file set in BMC_EMULATOR_CONFIG_FILE_PATH
+func Load() (*Config, error) {
+	configFilePath := os.Getenv(ConfigFilePathEnv)
+	if configFilePath == "" {
+		return nil, fmt.Errorf("no value set to environment variable %s", ConfigFilePathEnv)
+	}
+	return LoadFile(configFilePath)
+}
+
+// LoadFile reads and validates the configuration file at configFilePath
+func LoadFile(configFilePath string) (*Config, error) {
+	data, err := ioutil.ReadFile(configFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the config file: %v", err)
+	}
+	var conf Config
+	if err := json.Unmarshal(data, &conf); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the config file: %v", err)
+	}
+	if err := conf.validate(); err != nil {
+		return nil, err
+	}
+	return &conf, nil
+}
+
+func (conf *Config) validate() error {
+	if len(conf.BMCs) == 0 {
+		return fmt.Errorf("no BMC configured in BMCs")
+	}
+	if conf.EventDeliveryTimeoutInSeconds <= 0 {
+		conf.EventDeliveryTimeoutInSeconds = defaultEventDeliveryTimeoutInSeconds
+	}
+	addresses := make(map[string]bool)
+	for i := range conf.BMCs {
+		bmc := &conf.BMCs[i]
+		if bmc.Name == "" {
+			bmc.Name = bmc.ListenAddress
+		}
+		if _, _, err := net.SplitHostPort(bmc.ListenAddress); err != nil {
+			return fmt.Errorf("invalid ListenAddress %q of the BMC %s: %v", bmc.ListenAddress, bmc.Name, err)
+		}
+		if addresses[bmc.ListenAddress] {
+			return fmt.Errorf("ListenAddress %s is used by more than one BMC", bmc.ListenAddress)
+		}
+		addresses[bmc.ListenAddress] = true
+		switch {
+		case bmc.MockupPath == "":
+			return fmt.Errorf("no value set for MockupPath of the BMC %s", bmc.Name)
+		case bmc.UserName == "" || bmc.Password == "":
+			return fmt.Errorf("no value set for UserName or Password of the BMC %s", bmc.Name)
+		case bmc.CertificatePath == "" || bmc.PrivateKeyPath == "":
+			return fmt.Errorf("no value set for CertificatePath or PrivateKeyPath of the BMC %s", bmc.Name)
+		}
+		if bmc.PowerTransitionInSeconds == nil || *bmc.PowerTransitionInSeconds < 0 {
+			value := defaultPowerTransitionInSeconds
+			bmc.PowerTransitionInSeconds = &value
+		}
+		if bmc.TaskDurationInSeconds == nil || *bmc.TaskDurationInSeconds < 0 {
+			value := defaultTaskDurationInSeconds
+			bmc.TaskDurationInSeconds = &value
+		}
+		if err := bmc.FaultInjection.Validate(); err != nil {
+			return fmt.Errorf("invalid FaultInjection of the BMC %s: %v", bmc.Name, err)
+		}
+	}
+	return nil
+}
+
+// Validate checks the faults and sets the default error status code
+func (faults *FaultConf) Validate() error {
+	switch {
+	case faults.LatencyInMilliseconds < 0 || faults.LatencyJitterInMilliseconds < 0:
+		return fmt.Errorf("latency must not be negative")
+	case faults.ErrorRatePercent < 0 || faults.ErrorRatePercent > 100:
+		return fmt.Errorf("ErrorRatePercent must be between 0 and 100")
+	case faults.SessionDropRatePercent < 0 || faults.SessionDropRatePercent > 100:
+		return fmt.Errorf("SessionDropRatePercent must be between 0 and 100")
+	}
+	if faults.ErrorStatusCode == 0 {
+		faults.ErrorStatusCode = defaultErrorStatusCode
+	}
+	if faults.ErrorStatusCode < 500 || faults.ErrorStatusCode > 599 {
+		return fmt.Errorf("ErrorStatusCode must be a 5xx status code")
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeConfig(t *testing.T, data string) string {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := ioutil.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadFile(t *testing.T) {
+	conf, err := LoadFile("config.json")
+	if err != nil {
+		t.Fatalf("sample config should load, got: %v", err)
+	}
+	if len(conf.BMCs) != 1 || conf.BMCs[0].FaultInjection.ErrorStatusCode != 500 {
+		t.Errorf("unexpected sample config: %+v", conf)
+	}
+
+	conf, err = LoadFile(writeConfig(t, `{"BMCs":[{"ListenAddress":":8443","MockupPath":"m","UserName":"u","Password":"p","CertificatePath":"c","PrivateKeyPath":"k"}]}`))
+	if err != nil {
+		t.Fatalf("minimal config should load, got: %v", err)
+	}
+	bmc := conf.BMCs[0]
+	if bmc.Name != ":8443" || *bmc.PowerTransitionInSeconds != defaultPowerTransitionInSeconds ||
+		*bmc.TaskDurationInSeconds != defaultTaskDurationInSeconds || bmc.FaultInjection.ErrorStatusCode != defaultErrorStatusCode ||
+		conf.EventDeliveryTimeoutInSeconds != defaultEventDeliveryTimeoutInSeconds {
+		t.Errorf("defaults not set: %+v", conf)
+	}
+
+	conf, err = LoadFile(writeConfig(t, `{"BMCs":[{"ListenAddress":":8443","MockupPath":"m","UserName":"u","Password":"p","CertificatePath":"c","PrivateKeyPath":"k","PowerTransitionInSeconds":0}]}`))
+	if err != nil || *conf.BMCs[0].PowerTransitionInSeconds != 0 {
+		t.Errorf("zero power transition should be kept, got: %v", err)
+	}
+
+	invalid := map[string]string{
+		"no BMC":             `{"BMCs":[]}`,
+		"invalid address":    `{"BMCs":[{"ListenAddress":"8443","MockupPath":"m","UserName":"u","Password":"p","CertificatePath":"c","PrivateKeyPath":"k"}]}`,
+		"duplicated address": `{"BMCs":[{"ListenAddress":":1","MockupPath":"m","UserName":"u","Password":"p","CertificatePath":"c","PrivateKeyPath":"k"},{"ListenAddress":":1","MockupPath":"m","UserName":"u","Password":"p","CertificatePath":"c","PrivateKeyPath":"k"}]}`,
+		"no mockup":          `{"BMCs":[{"ListenAddress":":1","UserName":"u","Password":"p","CertificatePath":"c","PrivateKeyPath":"k"}]}`,
+		"no credentials":     `{"BMCs":[{"ListenAddress":":1","MockupPath":"m","CertificatePath":"c","PrivateKeyPath":"k"}]}`,
+		"no certificate":     `{"BMCs":[{"ListenAddress":":1","MockupPath":"m","UserName":"u","Password":"p"}]}`,
+		"invalid error rate": `{"BMCs":[{"ListenAddress":":1","MockupPath":"m","UserName":"u","Password":"p","CertificatePath":"c","PrivateKeyPath":"k","FaultInjection":{"ErrorRatePercent":101}}]}`,
+		"invalid error code": `{"BMCs":[{"ListenAddress":":1","MockupPath":"m","UserName":"u","Password":"p","CertificatePath":"c","PrivateKeyPath":"k","FaultInjection":{"ErrorStatusCode":404}}]}`,
+		"malformed":          `{"BMCs":`,
+	}
+	for name, data := range invalid {
+		if _, err := LoadFile(writeConfig(t, data)); err == nil {
+			t.Errorf("%s: config should be rejected", name)
+		}
+	}
+	if _, err := LoadFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("missing config file should be rejected")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	os.Unsetenv(ConfigFilePathEnv)
+	if _, err := Load(); err == nil {
+		t.Error("Load should fail without " + ConfigFilePathEnv)
+	}
+	os.Setenv(ConfigFilePathEnv, "config.json")
+	defer os.Unsetenv(ConfigFilePathEnv)
+	if _, err := Load(); err != nil {
+		t.Errorf("Load failed: %v", err)
+	}
+}
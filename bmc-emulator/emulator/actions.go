@@ -0,0 +1,278 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package emulator
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/ODIM-Project/ODIM/bmc-emulator/mockup"
+)
+
+// response is the response of an action
+type response struct {
+	status int
+	header map[string]string
+	body   interface{}
+}
+
+// actionHandler performs an action on the resource at uri, info is the
+// definition of the action in the Actions property of the resource
+type actionHandler func(b *BMC, uri string, resource mockup.Resource, info, params map[string]interface{}) (*response, error)
+
+var actionHandlers = map[string]actionHandler{
+	"ComputerSystem.Reset":       (*BMC).resetSystem,
+	"Manager.Reset":              (*BMC).resetManager,
+	"Bios.ResetBios":             (*BMC).resetBios,
+	"VirtualMedia.InsertMedia":   (*BMC).insertMedia,
+	"VirtualMedia.EjectMedia":    (*BMC).ejectMedia,
+	"UpdateService.SimpleUpdate": (*BMC).simpleUpdate,
+}
+
+// defaultResetTypes are the reset types of the reset actions without allowable values
+var defaultResetTypes = []interface{}{"On", "ForceOff", "GracefulShutdown", "GracefulRestart", "ForceRestart", "Nmi", "ForceOn", "PushPowerButton", "PowerCycle"}
+
+func (b *BMC) doAction(w http.ResponseWriter, r *http.Request, uri, action string) {
+	var params map[string]interface{}
+	if err := readRequest(r, &params); err != nil {
+		writeRequestError(w, err)
+		return
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	resource, ok := b.store.Get(uri)
+	if !ok {
+		writeError(w, http.StatusNotFound, "ResourceNotFound", "Resource", uri)
+		return
+	}
+	actions, _ := resource["Actions"].(map[string]interface{})
+	info, ok := actions["#"+action].(map[string]interface{})
+	handler, supported := actionHandlers[action]
+	if !ok || !supported {
+		writeError(w, http.StatusBadRequest, "ActionNotSupported", action)
+		return
+	}
+	resp, err := handler(b, uri, resource, info, params)
+	if err != nil {
+		writeRequestError(w, err)
+		return
+	}
+	for name, value := range resp.header {
+		w.Header().Set(name, value)
+	}
+	writeJSON(w, resp.status, resp.body)
+}
+
+func successResponse() *response {
+	return &response{status: http.StatusOK, body: successBody()}
+}
+
+// stringParam returns the string parameter of an action, checked against its allowable values
+func stringParam(info, params map[string]interface{}, name string, required bool) (string, error) {
+	value, ok := params[name]
+	if !ok || value == nil {
+		if required {
+			return "", newRequestError(http.StatusBadRequest, "PropertyMissing", name)
+		}
+		return "", nil
+	}
+	s, ok := value.(string)
+	if !ok {
+		return "", newRequestError(http.StatusBadRequest, "PropertyValueFormatError", fmt.Sprint(value), name)
+	}
+	if allowable, ok := info[name+"@Redfish.AllowableValues"].([]interface{}); ok && !contains(allowable, s) {
+		return "", newRequestError(http.StatusBadRequest, "PropertyValueNotInList", s, name)
+	}
+	return s, nil
+}
+
+func boolParam(params map[string]interface{}, name string, defaultValue bool) (bool, error) {
+	value, ok := params[name]
+	if !ok || value == nil {
+		return defaultValue, nil
+	}
+	v, ok := value.(bool)
+	if !ok {
+		return false, newRequestError(http.StatusBadRequest, "PropertyValueFormatError", fmt.Sprint(value), name)
+	}
+	return v, nil
+}
+
+// resetSystem changes the power state of the system. The power state goes through
+// PoweringOn or PoweringOff for the power transition time of the BMC.
+func (b *BMC) resetSystem(uri string, system mockup.Resource, info, params map[string]interface{}) (*response, error) {
+	if _, ok := info["ResetType@Redfish.AllowableValues"]; !ok {
+		info = map[string]interface{}{"ResetType@Redfish.AllowableValues": defaultResetTypes}
+	}
+	resetType, err := stringParam(info, params, "ResetType", true)
+	if err != nil {
+		return nil, err
+	}
+	powerState, _ := system["PowerState"].(string)
+	on := powerState == "On" || powerState == "PoweringOn"
+	var states []string
+	switch resetType {
+	case "On", "ForceOn":
+		if !on {
+			states = []string{"On"}
+		}
+	case "ForceOff", "GracefulShutdown":
+		if on {
+			states = []string{"Off"}
+		}
+	case "GracefulRestart", "ForceRestart", "PowerCycle":
+		states = []string{"On"}
+		if on {
+			states = []string{"Off", "On"}
+		}
+	case "PushPowerButton":
+		states = []string{"On"}
+		if on {
+			states = []string{"Off"}
+		}
+	case "Nmi":
+	default:
+		return nil, newRequestError(http.StatusBadRequest, "PropertyValueNotInList", resetType, "ResetType")
+	}
+	b.logger.Printf("reset %s with %s", uri, resetType)
+	if len(states) > 0 {
+		b.powerGeneration[uri]++
+		b.changePowerState(uri, b.powerGeneration[uri], states)
+	}
+	return successResponse(), nil
+}
+
+// changePowerState takes the system through states, the change is dropped when the system is reset again
+func (b *BMC) changePowerState(uri string, generation int, states []string) {
+	system, ok := b.store.Get(uri)
+	if !ok || len(states) == 0 || b.powerGeneration[uri] != generation {
+		return
+	}
+	state := states[0]
+	system["PowerState"] = "Powering" + state
+	b.after(b.powerTransition, func() {
+		if b.powerGeneration[uri] != generation {
+			return
+		}
+		system["PowerState"] = state
+		b.setChassisPowerState(system, state)
+		if state == "On" {
+			// the pending BIOS settings are applied at boot
+			b.applySettings(uri)
+		}
+		b.logger.Printf("%s is powered %s", uri, strings.ToLower(state))
+		b.emit(powerEvent(uri, state))
+		b.changePowerState(uri, generation, states[1:])
+	})
+}
+
+func (b *BMC) setChassisPowerState(system mockup.Resource, state string) {
+	links, _ := system["Links"].(map[string]interface{})
+	chassis, _ := links["Chassis"].([]interface{})
+	for _, link := range chassis {
+		link, _ := link.(map[string]interface{})
+		oid, _ := link["@odata.id"].(string)
+		if resource, ok := b.store.Get(oid); ok {
+			if _, ok := resource["PowerState"]; ok {
+				resource["PowerState"] = state
+			}
+		}
+	}
+}
+
+// resetManager restarts the BMC, which closes all its sessions
+func (b *BMC) resetManager(uri string, manager mockup.Resource, info, params map[string]interface{}) (*response, error) {
+	resetType, err := stringParam(info, params, "ResetType", false)
+	if err != nil {
+		return nil, err
+	}
+	if resetType == "" {
+		resetType = "GracefulRestart"
+	}
+	b.logger.Printf("reset %s with %s, closing all the sessions", uri, resetType)
+	b.closeAllSessions()
+	return successResponse(), nil
+}
+
+// resetBios records the default attributes of the BIOS as its pending settings
+func (b *BMC) resetBios(uri string, bios mockup.Resource, info, params map[string]interface{}) (*response, error) {
+	for settingsURI, targetURI := range b.settingsOf {
+		if targetURI != uri {
+			continue
+		}
+		settings, _ := b.store.Get(settingsURI)
+		settings["Attributes"] = copyMap(b.biosDefaults[uri])
+		b.logger.Println("recorded the default attributes in " + settingsURI)
+		b.emit(resourceChangedEvent(settingsURI))
+	}
+	return successResponse(), nil
+}
+
+// insertMedia connects the image to the virtual media
+func (b *BMC) insertMedia(uri string, media mockup.Resource, info, params map[string]interface{}) (*response, error) {
+	image, err := stringParam(info, params, "Image", true)
+	if err != nil {
+		return nil, err
+	}
+	imageURL, err := url.Parse(image)
+	if err != nil || imageURL.Scheme == "" || imageURL.Host == "" {
+		return nil, newRequestError(http.StatusBadRequest, "PropertyValueFormatError", image, "Image")
+	}
+	transferProtocol, err := stringParam(info, params, "TransferProtocolType", false)
+	if err != nil {
+		return nil, err
+	}
+	if transferProtocol == "" {
+		transferProtocol = strings.ToUpper(imageURL.Scheme)
+	}
+	inserted, err := boolParam(params, "Inserted", true)
+	if err != nil {
+		return nil, err
+	}
+	writeProtected, err := boolParam(params, "WriteProtected", true)
+	if err != nil {
+		return nil, err
+	}
+	if media["Inserted"] == true {
+		return nil, newRequestError(http.StatusConflict, "ResourceInUse")
+	}
+	media["Image"] = image
+	media["ImageName"] = path.Base(imageURL.Path)
+	media["Inserted"] = inserted
+	media["WriteProtected"] = writeProtected
+	media["ConnectedVia"] = "URI"
+	media["TransferProtocolType"] = transferProtocol
+	b.logger.Println("inserted " + image + " in " + uri)
+	b.emit(resourceChangedEvent(uri))
+	return successResponse(), nil
+}
+
+// ejectMedia disconnects the image of the virtual media
+func (b *BMC) ejectMedia(uri string, media mockup.Resource, info, params map[string]interface{}) (*response, error) {
+	if media["Inserted"] != true {
+		return successResponse(), nil
+	}
+	media["Image"] = nil
+	media["ImageName"] = ""
+	media["Inserted"] = false
+	media["ConnectedVia"] = "NotConnected"
+	media["TransferProtocolType"] = nil
+	b.logger.Println("ejected the media of " + uri)
+	b.emit(resourceChangedEvent(uri))
+	return successResponse(), nil
+}
@@ -0,0 +1,179 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package emulator
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+const (
+	testResetSystem = testSystem + "/Actions/ComputerSystem.Reset"
+	testChassis     = "/redfish/v1/Chassis/1"
+	testLogEntries  = testSystem + "/LogServices/Log/Entries"
+	testBios        = testSystem + "/Bios"
+	testBiosSetting = testBios + "/Settings"
+	testMedia       = "/redfish/v1/Managers/1/VirtualMedia/CD1"
+)
+
+func TestResetSystem(t *testing.T) {
+	b := newTestBMC(t)
+	entries := len(b.store.Members(testLogEntries))
+
+	rec := do(b, http.MethodPost, testResetSystem, map[string]string{"ResetType": "ForceOff"}, true)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("reset should succeed, got %d %s", rec.Code, rec.Body.String())
+	}
+	if state := getProperty(b, testSystem, "PowerState"); state != "Off" {
+		t.Errorf("system should be powered off, got %v", state)
+	}
+	if state := getProperty(b, testChassis, "PowerState"); state != "Off" {
+		t.Errorf("chassis should be powered off, got %v", state)
+	}
+	if members := b.store.Members(testLogEntries); len(members) != entries+1 {
+		t.Errorf("power off should be logged, got %v", members)
+	} else if id := getProperty(b, members[entries], "MessageId"); id != "Emulator.1.0.ServerPoweredOff" {
+		t.Errorf("unexpected log entry %v", id)
+	}
+
+	// a system powered off stays off, and is powered on by a restart
+	do(b, http.MethodPost, testResetSystem, map[string]string{"ResetType": "GracefulShutdown"}, true)
+	if members := b.store.Members(testLogEntries); len(members) != entries+1 {
+		t.Errorf("system powered off should not be powered off again, got %v", members)
+	}
+	do(b, http.MethodPost, testResetSystem, map[string]string{"ResetType": "ForceRestart"}, true)
+	if state := getProperty(b, testSystem, "PowerState"); state != "On" {
+		t.Errorf("system should be powered on, got %v", state)
+	}
+	do(b, http.MethodPost, testResetSystem, map[string]string{"ResetType": "PowerCycle"}, true)
+	if members := b.store.Members(testLogEntries); len(members) != entries+4 {
+		t.Errorf("power cycle should power the system off and on, got %v", members)
+	}
+
+	for _, params := range []map[string]interface{}{
+		{},
+		{"ResetType": "Suspend"},
+		{"ResetType": true},
+	} {
+		if rec := do(b, http.MethodPost, testResetSystem, params, true); rec.Code != http.StatusBadRequest {
+			t.Errorf("reset with %v should be rejected, got %d", params, rec.Code)
+		}
+	}
+	if rec := do(b, http.MethodPost, testSystem+"/Actions/ComputerSystem.SetDefaultBootOrder", nil, true); rec.Code != http.StatusBadRequest || messageID(t, rec) != "Base.1.8.ActionNotSupported" {
+		t.Errorf("unknown action should not be supported, got %d", rec.Code)
+	}
+}
+
+func TestPowerTransition(t *testing.T) {
+	b := newTestBMC(t)
+	b.powerTransition = 50 * time.Millisecond
+	do(b, http.MethodPost, testResetSystem, map[string]string{"ResetType": "ForceOff"}, true)
+	if state := getProperty(b, testSystem, "PowerState"); state != "PoweringOff" {
+		t.Errorf("system should be powering off, got %v", state)
+	}
+	// the power on drops the pending power off
+	do(b, http.MethodPost, testResetSystem, map[string]string{"ResetType": "On"}, true)
+	do(b, http.MethodPost, testResetSystem, map[string]string{"ResetType": "ForceRestart"}, true)
+	deadline := time.Now().Add(5 * time.Second)
+	for getProperty(b, testSystem, "PowerState") != "On" && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if state := getProperty(b, testSystem, "PowerState"); state != "On" {
+		t.Errorf("system should be powered on, got %v", state)
+	}
+}
+
+func TestBiosSettings(t *testing.T) {
+	b := newTestBMC(t)
+	rec := do(b, http.MethodPatch, testBiosSetting, map[string]interface{}{
+		"Attributes": map[string]interface{}{"BootMode": "LegacyBios", "ProcCoreDisable": 2},
+	}, true)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("settings should be recorded, got %d %s", rec.Code, rec.Body.String())
+	}
+	for _, tc := range []struct {
+		patch map[string]interface{}
+		want  string
+	}{
+		{map[string]interface{}{"Attributes": map[string]interface{}{"Unknown": 1}}, "Base.1.8.PropertyUnknown"},
+		{map[string]interface{}{"Attributes": map[string]interface{}{"ProcCoreDisable": "2"}}, "Base.1.8.PropertyValueFormatError"},
+		{map[string]interface{}{"AttributeRegistry": "x"}, "Base.1.8.PropertyUnknown"},
+		{map[string]interface{}{"Attributes": "BootMode"}, "Base.1.8.PropertyValueFormatError"},
+	} {
+		rec := do(b, http.MethodPatch, testBiosSetting, tc.patch, true)
+		if rec.Code != http.StatusBadRequest || messageID(t, rec) != tc.want {
+			t.Errorf("patch %v: want %s, got %d %s", tc.patch, tc.want, rec.Code, rec.Body.String())
+		}
+	}
+	attributes := getProperty(b, testBios, "Attributes").(map[string]interface{})
+	if attributes["BootMode"] != "Uefi" {
+		t.Error("settings should not be applied before the restart of the system")
+	}
+
+	do(b, http.MethodPost, testResetSystem, map[string]string{"ResetType": "ForceRestart"}, true)
+	attributes = getProperty(b, testBios, "Attributes").(map[string]interface{})
+	if attributes["BootMode"] != "LegacyBios" || attributes["ProcCoreDisable"] != float64(2) {
+		t.Errorf("settings should be applied at the restart of the system, got %v", attributes)
+	}
+	if pending := getProperty(b, testBiosSetting, "Attributes").(map[string]interface{}); len(pending) != 0 {
+		t.Errorf("applied settings should be cleared, got %v", pending)
+	}
+
+	rec = do(b, http.MethodPost, testBios+"/Actions/Bios.ResetBios", nil, true)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("BIOS reset should succeed, got %d %s", rec.Code, rec.Body.String())
+	}
+	do(b, http.MethodPost, testResetSystem, map[string]string{"ResetType": "ForceRestart"}, true)
+	attributes = getProperty(b, testBios, "Attributes").(map[string]interface{})
+	if attributes["BootMode"] != "Uefi" {
+		t.Errorf("default settings should be applied at the restart of the system, got %v", attributes)
+	}
+}
+
+func TestVirtualMedia(t *testing.T) {
+	b := newTestBMC(t)
+	insert := testMedia + "/Actions/VirtualMedia.InsertMedia"
+	for _, params := range []map[string]interface{}{
+		{},
+		{"Image": "ubuntu.iso"},
+		{"Image": "http://images/ubuntu.iso", "Inserted": "yes"},
+	} {
+		if rec := do(b, http.MethodPost, insert, params, true); rec.Code != http.StatusBadRequest {
+			t.Errorf("insert with %v should be rejected, got %d", params, rec.Code)
+		}
+	}
+
+	rec := do(b, http.MethodPost, insert, map[string]interface{}{"Image": "http://images/ubuntu.iso"}, true)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("insert should succeed, got %d %s", rec.Code, rec.Body.String())
+	}
+	if getProperty(b, testMedia, "Inserted") != true || getProperty(b, testMedia, "ImageName") != "ubuntu.iso" ||
+		getProperty(b, testMedia, "TransferProtocolType") != "HTTP" {
+		t.Error("media should be inserted")
+	}
+	rec = do(b, http.MethodPost, insert, map[string]interface{}{"Image": "http://images/rhel.iso"}, true)
+	if rec.Code != http.StatusConflict || messageID(t, rec) != "Base.1.8.ResourceInUse" {
+		t.Errorf("insert in a media in use should be rejected, got %d", rec.Code)
+	}
+
+	rec = do(b, http.MethodPost, testMedia+"/Actions/VirtualMedia.EjectMedia", nil, true)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("eject should succeed, got %d %s", rec.Code, rec.Body.String())
+	}
+	if getProperty(b, testMedia, "Inserted") != false || getProperty(b, testMedia, "Image") != nil {
+		t.Error("media should be ejected")
+	}
+}
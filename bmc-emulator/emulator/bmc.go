@@ -0,0 +1,336 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package emulator serves a DMTF Redfish mockup as a BMC. The emulated BMC changes the
+// state of its resources on the Redfish actions it supports, runs its firmware updates
+// as tasks, posts events to its subscribers, and injects faults in its responses.
+package emulator
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/bmc-emulator/config"
+	"github.com/ODIM-Project/ODIM/bmc-emulator/mockup"
+)
+
+const (
+	sessionServiceURI = mockup.ServiceRootURI + "/SessionService"
+	sessionsURI       = sessionServiceURI + "/Sessions"
+	eventServiceURI   = mockup.ServiceRootURI + "/EventService"
+	subscriptionsURI  = eventServiceURI + "/Subscriptions"
+	taskServiceURI    = mockup.ServiceRootURI + "/TaskService"
+	tasksURI          = taskServiceURI + "/Tasks"
+	taskMonitorsURI   = taskServiceURI + "/TaskMonitors"
+
+	// eventDeliveryQueueSize is the number of events waiting for their delivery,
+	// the events emitted when the queue is full are dropped
+	eventDeliveryQueueSize = 1000
+)
+
+// BMC is an emulated BMC serving the resources of a mockup
+type BMC struct {
+	name          string
+	address       string
+	userName      string
+	password      string
+	certFile      string
+	keyFile       string
+	logger        *log.Logger
+	eventClient   *http.Client
+	eventDelivery chan delivery
+
+	// mutex guards all the fields below
+	mutex  sync.Mutex
+	store  *mockup.Store
+	random *rand.Rand
+	faults config.FaultConf
+	// sessions holds the sessions by token
+	sessions map[string]*session
+	// tasks holds the tasks by ID
+	tasks map[string]*task
+	// powerGeneration is increased at every reset of a system, to drop the
+	// pending power transitions of the previous resets
+	powerGeneration map[string]int
+	// settingsOf holds the URI of the resource a settings object applies to, by settings object URI
+	settingsOf map[string]string
+	// biosDefaults holds the attributes of the BIOS resources loaded from the mockup, by URI
+	biosDefaults    map[string]map[string]interface{}
+	eventID         int
+	powerTransition time.Duration
+	taskDuration    time.Duration
+}
+
+// New returns a BMC serving the resources of store. The events of the BMC are posted with eventClient.
+func New(conf config.BMCConf, store *mockup.Store, eventClient *http.Client) *BMC {
+	b := &BMC{
+		name:            conf.Name,
+		address:         conf.ListenAddress,
+		userName:        conf.UserName,
+		password:        conf.Password,
+		certFile:        conf.CertificatePath,
+		keyFile:         conf.PrivateKeyPath,
+		logger:          log.New(os.Stdout, "["+conf.Name+"] ", log.LstdFlags),
+		eventClient:     eventClient,
+		eventDelivery:   make(chan delivery, eventDeliveryQueueSize),
+		store:           store,
+		random:          rand.New(rand.NewSource(time.Now().UnixNano())),
+		faults:          conf.FaultInjection,
+		sessions:        make(map[string]*session),
+		tasks:           make(map[string]*task),
+		powerGeneration: make(map[string]int),
+		settingsOf:      make(map[string]string),
+		biosDefaults:    make(map[string]map[string]interface{}),
+	}
+	if conf.PowerTransitionInSeconds != nil {
+		b.powerTransition = time.Duration(*conf.PowerTransitionInSeconds) * time.Second
+	}
+	if conf.TaskDurationInSeconds != nil {
+		b.taskDuration = time.Duration(*conf.TaskDurationInSeconds) * time.Second
+	}
+	b.prepareStore()
+	go b.deliverEvents()
+	return b
+}
+
+// ListenAndServe serves the Redfish service of the BMC over TLS at its listen address
+func (b *BMC) ListenAndServe() error {
+	server := &http.Server{
+		Addr:      b.address,
+		Handler:   b,
+		TLSConfig: &tls.Config{MinVersion: tls.VersionTLS12},
+	}
+	b.logger.Println("serving the Redfish service at " + b.address)
+	return server.ListenAndServeTLS(b.certFile, b.keyFile)
+}
+
+// prepareStore adds the services the emulator relies on when the mockup does not have them,
+// and the settings objects of the BIOS resources
+func (b *BMC) prepareStore() {
+	root, _ := b.store.Get(mockup.ServiceRootURI)
+	services := []struct {
+		property, uri, typ, name, collectionProperty, collectionURI, collectionType string
+	}{
+		{"SessionService", sessionServiceURI, "#SessionService.v1_1_8.SessionService", "Session Service", "Sessions", sessionsURI, "Session"},
+		{"EventService", eventServiceURI, "#EventService.v1_7_0.EventService", "Event Service", "Subscriptions", subscriptionsURI, "EventDestination"},
+		{"TaskService", taskServiceURI, "#TaskService.v1_1_5.TaskService", "Task Service", "Tasks", tasksURI, "Task"},
+	}
+	for _, service := range services {
+		resource, ok := b.store.Get(service.uri)
+		if !ok {
+			resource = mockup.Resource{
+				"@odata.type":    service.typ,
+				"Id":             service.property,
+				"Name":           service.name,
+				"ServiceEnabled": true,
+			}
+			b.store.Put(service.uri, resource)
+			if root != nil {
+				root[service.property] = map[string]interface{}{"@odata.id": service.uri}
+			}
+		}
+		if _, ok := b.store.Get(service.collectionURI); !ok {
+			resource[service.collectionProperty] = map[string]interface{}{"@odata.id": service.collectionURI}
+			b.store.Put(service.collectionURI, mockup.Resource{
+				"@odata.type":         "#" + service.collectionType + "Collection." + service.collectionType + "Collection",
+				"Name":                service.name + " Collection",
+				"Members":             []interface{}{},
+				"Members@odata.count": 0,
+			})
+		}
+	}
+
+	for _, uri := range b.store.URIs() {
+		resource, _ := b.store.Get(uri)
+		if !strings.HasSuffix(uri, "/Bios") {
+			continue
+		}
+		attributes, ok := resource["Attributes"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		b.biosDefaults[uri] = copyMap(attributes)
+		settings, _ := resource["@Redfish.Settings"].(map[string]interface{})
+		settingsURI := mockup.Link(settings, "SettingsObject")
+		if settingsURI == "" {
+			settingsURI = uri + "/Settings"
+			resource["@Redfish.Settings"] = map[string]interface{}{
+				"@odata.type":    "#Settings.v1_3_0.Settings",
+				"SettingsObject": map[string]interface{}{"@odata.id": settingsURI},
+			}
+		}
+		if _, ok := b.store.Get(settingsURI); !ok {
+			b.store.Put(settingsURI, mockup.Resource{
+				"@odata.type": resource["@odata.type"],
+				"Id":          "Settings",
+				"Name":        "BIOS Configuration Pending Settings",
+				"Attributes":  map[string]interface{}{},
+			})
+		}
+		b.settingsOf[settingsURI] = uri
+	}
+}
+
+// ServeHTTP serves the Redfish requests, and the requests of the emulator API under /emulator/v1
+func (b *BMC) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	uri := mockup.NormalizeURI(r.URL.Path)
+	if strings.HasPrefix(uri, adminURI) {
+		b.serveAdmin(w, r, uri)
+		return
+	}
+	w.Header().Set("OData-Version", "4.0")
+	if b.injectFaults(w) {
+		return
+	}
+	switch {
+	case uri == "/redfish" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]string{"v1": mockup.ServiceRootURI + "/"})
+	case uri == mockup.ServiceRootURI && r.Method == http.MethodGet:
+		b.getResource(w, uri)
+	case uri == sessionsURI && r.Method == http.MethodPost:
+		b.createSession(w, r)
+	default:
+		if !b.authenticate(w, r) {
+			return
+		}
+		b.serveResource(w, r, uri)
+	}
+}
+
+func (b *BMC) serveResource(w http.ResponseWriter, r *http.Request, uri string) {
+	switch r.Method {
+	case http.MethodGet:
+		if strings.HasPrefix(uri, taskMonitorsURI+"/") {
+			b.getTaskMonitor(w, uri)
+			return
+		}
+		b.getResource(w, uri)
+	case http.MethodPatch:
+		b.patchResource(w, r, uri)
+	case http.MethodPost:
+		if i := strings.Index(uri, "/Actions/"); i > 0 {
+			b.doAction(w, r, uri[:i], uri[i+len("/Actions/"):])
+			return
+		}
+		if uri == subscriptionsURI {
+			b.createSubscription(w, r)
+			return
+		}
+		b.methodNotAllowed(w, r, uri)
+	case http.MethodDelete:
+		switch {
+		case strings.HasPrefix(uri, sessionsURI+"/"):
+			b.deleteSession(w, uri)
+		case strings.HasPrefix(uri, subscriptionsURI+"/"):
+			b.deleteSubscription(w, uri)
+		case strings.HasPrefix(uri, taskMonitorsURI+"/"):
+			b.cancelTask(w, uri)
+		default:
+			b.methodNotAllowed(w, r, uri)
+		}
+	default:
+		b.methodNotAllowed(w, r, uri)
+	}
+}
+
+func (b *BMC) methodNotAllowed(w http.ResponseWriter, r *http.Request, uri string) {
+	b.mutex.Lock()
+	_, ok := b.store.Get(uri)
+	b.mutex.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "ResourceNotFound", "Resource", uri)
+		return
+	}
+	writeError(w, http.StatusMethodNotAllowed, "GeneralError")
+}
+
+func (b *BMC) getResource(w http.ResponseWriter, uri string) {
+	b.mutex.Lock()
+	resource, ok := b.store.Get(uri)
+	var data []byte
+	var err error
+	if ok {
+		data, err = json.Marshal(resource)
+	}
+	b.mutex.Unlock()
+	switch {
+	case !ok:
+		writeError(w, http.StatusNotFound, "ResourceNotFound", "Resource", uri)
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, "InternalError")
+	default:
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	}
+}
+
+// readRequest decodes the JSON body of the request into body, an empty body is accepted
+func readRequest(r *http.Request, body interface{}) error {
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return newRequestError(http.StatusBadRequest, "MalformedJSON")
+	}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, body); err != nil {
+		return newRequestError(http.StatusBadRequest, "MalformedJSON")
+	}
+	return nil
+}
+
+// nextID returns the lowest numeric ID not used by a member of the collection
+func (b *BMC) nextID(collectionURI string) string {
+	for i := len(b.store.Members(collectionURI)) + 1; ; i++ {
+		id := strconv.Itoa(i)
+		if _, ok := b.store.Get(collectionURI + "/" + id); !ok {
+			return id
+		}
+	}
+}
+
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	data, _ := json.Marshal(m)
+	var c map[string]interface{}
+	json.Unmarshal(data, &c)
+	return c
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+// after runs f with the mutex held after delay, or at once when delay is zero.
+// It is called with the mutex held.
+func (b *BMC) after(delay time.Duration, f func()) {
+	if delay <= 0 {
+		f()
+		return
+	}
+	time.AfterFunc(delay, func() {
+		b.mutex.Lock()
+		defer b.mutex.Unlock()
+		f()
+	})
+}
@@ -0,0 +1,234 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package emulator
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/bmc-emulator/config"
+	"github.com/ODIM-Project/ODIM/bmc-emulator/mockup"
+)
+
+const (
+	testUserName = "admin"
+	testPassword = "password"
+	testSystem   = "/redfish/v1/Systems/1"
+)
+
+// newTestBMC returns a BMC serving the sample mockup, with no power transition and no task duration
+func newTestBMC(t *testing.T) *BMC {
+	return newTestBMCWithClient(t, http.DefaultClient)
+}
+
+func newTestBMCWithClient(t *testing.T, eventClient *http.Client) *BMC {
+	store, err := mockup.Load("../mockups/simple")
+	if err != nil {
+		t.Fatal(err)
+	}
+	zero := 0
+	b := New(config.BMCConf{
+		Name:                     "test",
+		ListenAddress:            "localhost:0",
+		UserName:                 testUserName,
+		Password:                 testPassword,
+		PowerTransitionInSeconds: &zero,
+		TaskDurationInSeconds:    &zero,
+		FaultInjection:           config.FaultConf{ErrorStatusCode: http.StatusInternalServerError},
+	}, store, eventClient)
+	b.logger = log.New(ioutil.Discard, "", 0)
+	return b
+}
+
+// do sends the request to the BMC with basic authentication, when auth is set
+func do(b *BMC, method, uri string, body interface{}, auth bool) *httptest.ResponseRecorder {
+	var data []byte
+	if body != nil {
+		data, _ = json.Marshal(body)
+	}
+	req := httptest.NewRequest(method, uri, bytes.NewReader(data))
+	if auth {
+		req.SetBasicAuth(testUserName, testPassword)
+	}
+	rec := httptest.NewRecorder()
+	b.ServeHTTP(rec, req)
+	return rec
+}
+
+func decode(t *testing.T, rec *httptest.ResponseRecorder) map[string]interface{} {
+	var body map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to decode %q: %v", rec.Body.String(), err)
+	}
+	return body
+}
+
+// messageID returns the message ID of an error response
+func messageID(t *testing.T, rec *httptest.ResponseRecorder) string {
+	body := decode(t, rec)
+	info := body["error"].(map[string]interface{})["@Message.ExtendedInfo"].([]interface{})
+	return info[0].(map[string]interface{})["MessageId"].(string)
+}
+
+func getProperty(b *BMC, uri, property string) interface{} {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	resource, _ := b.store.Get(uri)
+	return resource[property]
+}
+
+func TestServiceRoot(t *testing.T) {
+	b := newTestBMC(t)
+	rec := do(b, http.MethodGet, "/redfish/v1/", nil, false)
+	if rec.Code != http.StatusOK || decode(t, rec)["UUID"] != "92384634-2938-2342-8820-489239905423" {
+		t.Errorf("service root should be served without authentication, got %d", rec.Code)
+	}
+	if rec.Header().Get("OData-Version") != "4.0" {
+		t.Error("OData-Version header missing")
+	}
+	rec = do(b, http.MethodGet, "/redfish", nil, false)
+	if rec.Code != http.StatusOK || decode(t, rec)["v1"] != "/redfish/v1/" {
+		t.Errorf("unexpected /redfish response: %d %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	b := newTestBMC(t)
+	if rec := do(b, http.MethodGet, testSystem, nil, false); rec.Code != http.StatusUnauthorized {
+		t.Errorf("request without credentials should be rejected, got %d", rec.Code)
+	}
+	req := httptest.NewRequest(http.MethodGet, testSystem, nil)
+	req.SetBasicAuth(testUserName, "wrong")
+	rec := httptest.NewRecorder()
+	b.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized || messageID(t, rec) != "Base.1.8.NoValidSession" {
+		t.Errorf("request with wrong credentials should be rejected, got %d", rec.Code)
+	}
+	if rec := do(b, http.MethodGet, testSystem, nil, true); rec.Code != http.StatusOK {
+		t.Errorf("request with basic authentication should be served, got %d", rec.Code)
+	}
+	if rec := do(b, http.MethodGet, "/redfish/v1/Systems/2", nil, true); rec.Code != http.StatusNotFound {
+		t.Errorf("missing resource should not be found, got %d", rec.Code)
+	}
+	if rec := do(b, http.MethodPut, testSystem, nil, true); rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("PUT should not be allowed, got %d", rec.Code)
+	}
+}
+
+func TestSessions(t *testing.T) {
+	b := newTestBMC(t)
+	rec := do(b, http.MethodPost, sessionsURI, map[string]string{"UserName": testUserName, "Password": "wrong"}, false)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("session with wrong credentials should be rejected, got %d", rec.Code)
+	}
+	if rec := do(b, http.MethodPost, sessionsURI, map[string]string{"UserName": testUserName}, false); rec.Code != http.StatusBadRequest {
+		t.Errorf("session without password should be rejected, got %d", rec.Code)
+	}
+	rec = do(b, http.MethodPost, sessionsURI, map[string]string{"UserName": testUserName, "Password": testPassword}, false)
+	token, location := rec.Header().Get("X-Auth-Token"), rec.Header().Get("Location")
+	if rec.Code != http.StatusCreated || token == "" || location != sessionsURI+"/1" {
+		t.Fatalf("session should be created, got %d %v", rec.Code, rec.Header())
+	}
+	if members := b.store.Members(sessionsURI); len(members) != 1 {
+		t.Errorf("session should be a member of the sessions, got %v", members)
+	}
+
+	get := func(token string) int {
+		req := httptest.NewRequest(http.MethodGet, testSystem, nil)
+		req.Header.Set("X-Auth-Token", token)
+		rec := httptest.NewRecorder()
+		b.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	if code := get(token); code != http.StatusOK {
+		t.Errorf("request with the session token should be served, got %d", code)
+	}
+	if code := get("unknown"); code != http.StatusUnauthorized {
+		t.Errorf("request with an unknown token should be rejected, got %d", code)
+	}
+
+	// the sessions expire after the session timeout
+	b.mutex.Lock()
+	b.sessions[token].lastUsed = time.Now().Add(-31 * time.Minute)
+	b.mutex.Unlock()
+	if code := get(token); code != http.StatusUnauthorized {
+		t.Errorf("expired session should be rejected, got %d", code)
+	}
+	if members := b.store.Members(sessionsURI); len(members) != 0 {
+		t.Errorf("expired session should be removed, got %v", members)
+	}
+
+	rec = do(b, http.MethodPost, sessionsURI, map[string]string{"UserName": testUserName, "Password": testPassword}, false)
+	token, location = rec.Header().Get("X-Auth-Token"), rec.Header().Get("Location")
+	if rec := do(b, http.MethodDelete, location, nil, true); rec.Code != http.StatusNoContent {
+		t.Errorf("session should be deleted, got %d", rec.Code)
+	}
+	if code := get(token); code != http.StatusUnauthorized {
+		t.Errorf("deleted session should be rejected, got %d", code)
+	}
+	if rec := do(b, http.MethodDelete, location, nil, true); rec.Code != http.StatusNotFound {
+		t.Errorf("deleted session should not be found, got %d", rec.Code)
+	}
+}
+
+func TestPatchResource(t *testing.T) {
+	b := newTestBMC(t)
+	rec := do(b, http.MethodPatch, testSystem, map[string]interface{}{
+		"AssetTag": "rack-1",
+	}, true)
+	if rec.Code != http.StatusBadRequest || messageID(t, rec) != "Base.1.8.PropertyUnknown" {
+		t.Errorf("unknown property should be rejected, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	rec = do(b, http.MethodPatch, testSystem, map[string]interface{}{
+		"Boot": map[string]interface{}{"BootSourceOverrideTarget": "Pxe", "BootSourceOverrideEnabled": "Once"},
+	}, true)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("patch should succeed, got %d %s", rec.Code, rec.Body.String())
+	}
+	boot := getProperty(b, testSystem, "Boot").(map[string]interface{})
+	if boot["BootSourceOverrideTarget"] != "Pxe" || boot["BootSourceOverrideMode"] != "UEFI" {
+		t.Errorf("boot should be merged, got %v", boot)
+	}
+
+	for body, want := range map[string]string{
+		`{"Boot":{"BootSourceOverrideTarget":"Floppy"}}`: "Base.1.8.PropertyValueNotInList",
+		`{"PowerState":"Off"}`:                           "Base.1.8.PropertyNotWritable",
+		`{"@odata.id":"/redfish/v1/Systems/2"}`:          "Base.1.8.PropertyNotWritable",
+		`{"HostName":false}`:                             "Base.1.8.PropertyValueFormatError",
+		`{"Boot":{"BootOrder":"Boot0002"}}`:              "Base.1.8.PropertyValueFormatError",
+	} {
+		var patch map[string]interface{}
+		json.Unmarshal([]byte(body), &patch)
+		rec := do(b, http.MethodPatch, testSystem, patch, true)
+		if rec.Code != http.StatusBadRequest || messageID(t, rec) != want {
+			t.Errorf("patch %s: want %s, got %d %s", body, want, rec.Code, rec.Body.String())
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodPatch, testSystem, bytes.NewReader([]byte("{")))
+	req.SetBasicAuth(testUserName, testPassword)
+	rec = httptest.NewRecorder()
+	b.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest || messageID(t, rec) != "Base.1.8.MalformedJSON" {
+		t.Errorf("malformed body should be rejected, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,362 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package emulator
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/bmc-emulator/mockup"
+)
+
+// emulatorRegistry is the registry of the power events of the emulator, odimra
+// updates the power state of a system on its ServerPoweredOn and ServerPoweredOff messages
+const emulatorRegistry = "Emulator.1.0."
+
+// event is an event emitted by the BMC
+type event struct {
+	eventType string
+	messageID string
+	message   string
+	args      []string
+	severity  string
+	origin    string
+}
+
+// delivery is an event posted to a subscriber
+type delivery struct {
+	destination string
+	payload     []byte
+}
+
+func powerEvent(uri, state string) event {
+	return event{
+		eventType: "Alert",
+		messageID: emulatorRegistry + "ServerPowered" + state,
+		message:   "The server is powered " + strings.ToLower(state) + ".",
+		severity:  "OK",
+		origin:    uri,
+	}
+}
+
+func resourceChangedEvent(uri string) event {
+	return event{
+		eventType: "ResourceUpdated",
+		messageID: "ResourceEvent.1.0.ResourceChanged",
+		message:   "One or more resource properties have changed.",
+		severity:  "OK",
+		origin:    uri,
+	}
+}
+
+func updateEvent(uri, image string) event {
+	return event{
+		eventType: "ResourceUpdated",
+		messageID: "Update.1.0.UpdateSuccessful",
+		message:   fmt.Sprintf("Device '%s' successfully updated with '%s'.", uri, image),
+		args:      []string{uri, image},
+		severity:  "OK",
+		origin:    uri,
+	}
+}
+
+// taskMessages holds the message and the severity of the TaskEvent registry messages
+var taskMessages = map[string][2]string{
+	"TaskStarted":         {"The task with Id '%1' has started.", "OK"},
+	"TaskProgressChanged": {"The task with Id '%1' has changed to progress %2 percent complete.", "OK"},
+	"TaskCompletedOK":     {"The task with Id '%1' has completed.", "OK"},
+	"TaskCancelled":       {"The task with Id '%1' has been cancelled.", "Warning"},
+}
+
+func taskEvent(uri, messageKey string, args ...string) event {
+	return event{
+		eventType: "StatusChange",
+		messageID: "TaskEvent.1.0." + messageKey,
+		message:   formatMessage(taskMessages[messageKey][0], args),
+		args:      args,
+		severity:  taskMessages[messageKey][1],
+		origin:    uri,
+	}
+}
+
+func taskMessage(messageKey string, args ...string) map[string]interface{} {
+	return map[string]interface{}{
+		"MessageId":   "TaskEvent.1.0." + messageKey,
+		"Message":     formatMessage(taskMessages[messageKey][0], args),
+		"MessageArgs": args,
+		"Severity":    taskMessages[messageKey][1],
+	}
+}
+
+// NewEventClient returns the client posting the events to the subscribers. The destinations
+// are verified with the CA certificate at rootCAPath, or with the system CA certificates.
+func NewEventClient(rootCAPath string, timeout time.Duration) (*http.Client, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if rootCAPath != "" {
+		caCert, err := ioutil.ReadFile(rootCAPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the root CA certificate: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no certificate found in %s", rootCAPath)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+	}, nil
+}
+
+type subscriptionRequest struct {
+	Destination     string        `json:"Destination"`
+	EventTypes      []string      `json:"EventTypes"`
+	Context         string        `json:"Context"`
+	Protocol        string        `json:"Protocol"`
+	MessageIds      []string      `json:"MessageIds"`
+	OriginResources []interface{} `json:"OriginResources"`
+}
+
+func (b *BMC) createSubscription(w http.ResponseWriter, r *http.Request) {
+	var req subscriptionRequest
+	if err := readRequest(r, &req); err != nil {
+		writeRequestError(w, err)
+		return
+	}
+	if req.Destination == "" {
+		writeError(w, http.StatusBadRequest, "PropertyMissing", "Destination")
+		return
+	}
+	destination, err := url.Parse(req.Destination)
+	if err != nil || (destination.Scheme != "https" && destination.Scheme != "http") || destination.Host == "" {
+		writeError(w, http.StatusBadRequest, "PropertyValueFormatError", req.Destination, "Destination")
+		return
+	}
+	if req.Protocol == "" {
+		req.Protocol = "Redfish"
+	}
+	if req.Protocol != "Redfish" {
+		writeError(w, http.StatusBadRequest, "PropertyValueNotInList", req.Protocol, "Protocol")
+		return
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	service, _ := b.store.Get(eventServiceURI)
+	if supported, ok := service["EventTypesForSubscription"].([]interface{}); ok {
+		for _, eventType := range req.EventTypes {
+			if !contains(supported, eventType) {
+				writeError(w, http.StatusBadRequest, "PropertyValueNotInList", eventType, "EventTypes")
+				return
+			}
+		}
+	}
+	originResources := []interface{}{}
+	for _, origin := range req.OriginResources {
+		// the origin resources are links, or URIs for the older clients
+		if uri, ok := origin.(string); ok {
+			origin = map[string]interface{}{"@odata.id": uri}
+		}
+		originResources = append(originResources, origin)
+	}
+	id := b.nextID(subscriptionsURI)
+	uri := subscriptionsURI + "/" + id
+	resource := mockup.Resource{
+		"@odata.type":      "#EventDestination.v1_7_0.EventDestination",
+		"Id":               id,
+		"Name":             "Event Subscription " + id,
+		"Destination":      req.Destination,
+		"EventTypes":       stringsToList(req.EventTypes),
+		"Context":          req.Context,
+		"Protocol":         req.Protocol,
+		"SubscriptionType": "RedfishEvent",
+		"MessageIds":       stringsToList(req.MessageIds),
+		"OriginResources":  originResources,
+	}
+	b.store.Put(uri, resource)
+	b.store.AddMember(subscriptionsURI, uri)
+	b.logger.Println("created the event subscription " + uri + " for " + req.Destination)
+	w.Header().Set("Location", uri)
+	writeJSON(w, http.StatusCreated, resource)
+}
+
+func stringsToList(values []string) []interface{} {
+	list := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		list = append(list, value)
+	}
+	return list
+}
+
+func (b *BMC) deleteSubscription(w http.ResponseWriter, uri string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if _, ok := b.store.Get(uri); !ok {
+		writeError(w, http.StatusNotFound, "ResourceNotFound", "EventDestination", uri)
+		return
+	}
+	b.store.Delete(uri)
+	b.store.RemoveMember(subscriptionsURI, uri)
+	b.logger.Println("deleted the event subscription " + uri)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// emit queues the event for the subscribers it matches. The Alert events of
+// a system are also added to the log of the system. It is called with the mutex held.
+func (b *BMC) emit(e event) {
+	timestamp := now()
+	if e.eventType == "Alert" {
+		b.addLogEntry(e, timestamp)
+	}
+	for _, uri := range b.store.Members(subscriptionsURI) {
+		subscription, ok := b.store.Get(uri)
+		if !ok || !subscriptionMatches(subscription, e) {
+			continue
+		}
+		b.eventID++
+		id := strconv.Itoa(b.eventID)
+		payload, err := json.Marshal(map[string]interface{}{
+			"@odata.type": "#Event.v1_4_0.Event",
+			"Id":          id,
+			"Name":        "Event Array",
+			"Context":     subscription["Context"],
+			"Events": []interface{}{
+				map[string]interface{}{
+					"MemberId":          "0",
+					"EventType":         e.eventType,
+					"EventId":           id,
+					"EventTimestamp":    timestamp,
+					"Severity":          e.severity,
+					"Message":           e.message,
+					"MessageId":         e.messageID,
+					"MessageArgs":       stringsToList(e.args),
+					"OriginOfCondition": map[string]interface{}{"@odata.id": e.origin},
+				},
+			},
+		})
+		if err != nil {
+			continue
+		}
+		destination, _ := subscription["Destination"].(string)
+		select {
+		case b.eventDelivery <- delivery{destination: destination, payload: payload}:
+		default:
+			b.logger.Println("event delivery queue is full, dropped the event " + e.messageID + " of " + e.origin)
+		}
+	}
+}
+
+// subscriptionMatches returns true when the event matches the event types,
+// the message IDs and the origin resources of the subscription
+func subscriptionMatches(subscription mockup.Resource, e event) bool {
+	if eventTypes, _ := subscription["EventTypes"].([]interface{}); len(eventTypes) > 0 && !contains(eventTypes, e.eventType) {
+		return false
+	}
+	if messageIDs, _ := subscription["MessageIds"].([]interface{}); len(messageIDs) > 0 {
+		matched := false
+		for _, id := range messageIDs {
+			id, _ := id.(string)
+			if id == e.messageID || messageKey(id) == messageKey(e.messageID) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if origins, _ := subscription["OriginResources"].([]interface{}); len(origins) > 0 {
+		matched := false
+		for _, origin := range origins {
+			link, _ := origin.(map[string]interface{})
+			uri, _ := link["@odata.id"].(string)
+			uri = mockup.NormalizeURI(uri)
+			if uri != "" && (e.origin == uri || strings.HasPrefix(e.origin, uri+"/")) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// messageKey returns the registry and the message name of a message ID, without the registry version
+func messageKey(messageID string) string {
+	parts := strings.Split(messageID, ".")
+	return parts[0] + "." + parts[len(parts)-1]
+}
+
+// addLogEntry adds the event to the first log service of the system at the origin of the event
+func (b *BMC) addLogEntry(e event, timestamp string) {
+	system, ok := b.store.Get(e.origin)
+	if !ok {
+		return
+	}
+	logServices := b.store.Members(mockup.Link(system, "LogServices"))
+	if len(logServices) == 0 {
+		return
+	}
+	logService, _ := b.store.Get(logServices[0])
+	entriesURI := mockup.Link(logService, "Entries")
+	if _, ok := b.store.Get(entriesURI); !ok {
+		return
+	}
+	id := b.nextID(entriesURI)
+	uri := entriesURI + "/" + id
+	b.store.Put(uri, mockup.Resource{
+		"@odata.type": "#LogEntry.v1_9_0.LogEntry",
+		"Id":          id,
+		"Name":        "Log Entry " + id,
+		"EntryType":   "Event",
+		"Severity":    e.severity,
+		"Created":     timestamp,
+		"Message":     e.message,
+		"MessageId":   e.messageID,
+		"MessageArgs": stringsToList(e.args),
+		"Links": map[string]interface{}{
+			"OriginOfCondition": map[string]interface{}{"@odata.id": e.origin},
+		},
+	})
+	b.store.AddMember(entriesURI, uri)
+}
+
+// deliverEvents posts the queued events to their destinations, in the order they were emitted
+func (b *BMC) deliverEvents() {
+	for d := range b.eventDelivery {
+		resp, err := b.eventClient.Post(d.destination, "application/json", bytes.NewReader(d.payload))
+		if err != nil {
+			b.logger.Println("failed to post an event to " + d.destination + ": " + err.Error())
+			continue
+		}
+		ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			b.logger.Printf("posting an event to %s returned the status code %d", d.destination, resp.StatusCode)
+		}
+	}
+}
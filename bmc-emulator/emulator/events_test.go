@@ -0,0 +1,113 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package emulator
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/bmc-emulator/mockup"
+)
+
+func TestEventSubscription(t *testing.T) {
+	received := make(chan map[string]interface{}, 10)
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		data, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(data, &payload)
+		received <- payload
+	}))
+	defer ts.Close()
+	b := newTestBMCWithClient(t, ts.Client())
+
+	for _, params := range []map[string]interface{}{
+		{},
+		{"Destination": "ftp://odim/events"},
+		{"Destination": ts.URL, "Protocol": "SNMPv2c"},
+		{"Destination": ts.URL, "EventTypes": []string{"MetricReport"}},
+	} {
+		if rec := do(b, http.MethodPost, subscriptionsURI, params, true); rec.Code != http.StatusBadRequest {
+			t.Errorf("subscription with %v should be rejected, got %d", params, rec.Code)
+		}
+	}
+	rec := do(b, http.MethodPost, subscriptionsURI, map[string]interface{}{
+		"Destination":     ts.URL,
+		"Context":         "odim",
+		"EventTypes":      []string{"Alert"},
+		"OriginResources": []string{testSystem},
+	}, true)
+	location := rec.Header().Get("Location")
+	if rec.Code != http.StatusCreated || location != subscriptionsURI+"/1" {
+		t.Fatalf("subscription should be created, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	// the patch of the system does not match the event types of the subscription
+	do(b, http.MethodPatch, testSystem, map[string]interface{}{"HostName": "web484"}, true)
+	do(b, http.MethodPost, testResetSystem, map[string]string{"ResetType": "ForceOff"}, true)
+	select {
+	case payload := <-received:
+		events := payload["Events"].([]interface{})
+		e := events[0].(map[string]interface{})
+		if payload["Context"] != "odim" || e["MessageId"] != "Emulator.1.0.ServerPoweredOff" ||
+			e["OriginOfCondition"].(map[string]interface{})["@odata.id"] != testSystem {
+			t.Errorf("unexpected event %v", payload)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("event should be delivered")
+	}
+
+	if rec := do(b, http.MethodDelete, location, nil, true); rec.Code != http.StatusNoContent {
+		t.Fatalf("subscription should be deleted, got %d", rec.Code)
+	}
+	if rec := do(b, http.MethodDelete, location, nil, true); rec.Code != http.StatusNotFound {
+		t.Errorf("deleted subscription should not be found, got %d", rec.Code)
+	}
+	do(b, http.MethodPost, testResetSystem, map[string]string{"ResetType": "On"}, true)
+	select {
+	case payload := <-received:
+		t.Errorf("event should not be delivered after the deletion of the subscription, got %v", payload)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestSubscriptionMatches(t *testing.T) {
+	e := powerEvent(testSystem, "On")
+	tests := []struct {
+		name         string
+		subscription mockup.Resource
+		want         bool
+	}{
+		{"no filter", mockup.Resource{}, true},
+		{"event type", mockup.Resource{"EventTypes": []interface{}{"StatusChange", "Alert"}}, true},
+		{"other event type", mockup.Resource{"EventTypes": []interface{}{"StatusChange"}}, false},
+		{"message ID", mockup.Resource{"MessageIds": []interface{}{"Emulator.1.0.ServerPoweredOn"}}, true},
+		{"message ID of another version", mockup.Resource{"MessageIds": []interface{}{"Emulator.1.2.ServerPoweredOn"}}, true},
+		{"other message ID", mockup.Resource{"MessageIds": []interface{}{"Emulator.1.0.ServerPoweredOff"}}, false},
+		{"origin", mockup.Resource{"OriginResources": []interface{}{map[string]interface{}{"@odata.id": "/redfish/v1/Systems/1/"}}}, true},
+		{"parent origin", mockup.Resource{"OriginResources": []interface{}{map[string]interface{}{"@odata.id": "/redfish/v1/Systems"}}}, true},
+		{"other origin", mockup.Resource{"OriginResources": []interface{}{map[string]interface{}{"@odata.id": "/redfish/v1/Systems/11"}}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := subscriptionMatches(tt.subscription, e); got != tt.want {
+				t.Errorf("subscriptionMatches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package emulator
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// adminURI is the root of the emulator API, which changes the behaviour of the BMC at run time
+	adminURI          = "/emulator/v1"
+	faultInjectionURI = adminURI + "/FaultInjection"
+	dropSessionsURI   = adminURI + "/Actions/DropSessions"
+)
+
+// injectFaults delays the response, and answers the request with an error at the
+// error rate of the BMC. It returns true when the request was answered.
+func (b *BMC) injectFaults(w http.ResponseWriter) bool {
+	b.mutex.Lock()
+	faults := b.faults
+	latency := time.Duration(faults.LatencyInMilliseconds) * time.Millisecond
+	if faults.LatencyJitterInMilliseconds > 0 {
+		latency += time.Duration(b.random.Intn(faults.LatencyJitterInMilliseconds+1)) * time.Millisecond
+	}
+	fail := faults.ErrorRatePercent > 0 && b.random.Intn(100) < faults.ErrorRatePercent
+	b.mutex.Unlock()
+
+	if latency > 0 {
+		time.Sleep(latency)
+	}
+	if !fail {
+		return false
+	}
+	b.logger.Printf("fault injection: answered a request with the status code %d", faults.ErrorStatusCode)
+	if faults.ErrorStatusCode == http.StatusServiceUnavailable {
+		w.Header().Set("Retry-After", "1")
+		writeError(w, faults.ErrorStatusCode, "ServiceTemporarilyUnavailable", "1")
+		return true
+	}
+	writeError(w, faults.ErrorStatusCode, "InternalError")
+	return true
+}
+
+// serveAdmin serves the emulator API, authenticated with the credentials of the BMC
+func (b *BMC) serveAdmin(w http.ResponseWriter, r *http.Request, uri string) {
+	userName, password, ok := r.BasicAuth()
+	if !ok || userName != b.userName || password != b.password {
+		w.Header().Set("WWW-Authenticate", `Basic realm="BMC Emulator"`)
+		writeError(w, http.StatusUnauthorized, "NoValidSession")
+		return
+	}
+	switch {
+	case uri == faultInjectionURI && r.Method == http.MethodGet:
+		b.mutex.Lock()
+		faults := b.faults
+		b.mutex.Unlock()
+		writeJSON(w, http.StatusOK, faults)
+	case uri == faultInjectionURI && r.Method == http.MethodPatch:
+		b.patchFaults(w, r)
+	case uri == dropSessionsURI && r.Method == http.MethodPost:
+		b.mutex.Lock()
+		count := len(b.sessions)
+		b.closeAllSessions()
+		b.mutex.Unlock()
+		b.logger.Println("fault injection: dropped " + strconv.Itoa(count) + " sessions")
+		w.WriteHeader(http.StatusNoContent)
+	case uri == faultInjectionURI || uri == dropSessionsURI:
+		writeError(w, http.StatusMethodNotAllowed, "GeneralError")
+	default:
+		writeError(w, http.StatusNotFound, "ResourceNotFound", "Resource", uri)
+	}
+}
+
+// patchFaults changes the faults given in the request, the other faults are kept
+func (b *BMC) patchFaults(w http.ResponseWriter, r *http.Request) {
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "MalformedJSON")
+		return
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	faults := b.faults
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&faults); err != nil {
+		writeError(w, http.StatusBadRequest, "MalformedJSON")
+		return
+	}
+	if err := faults.Validate(); err != nil {
+		body := errorBody("GeneralError")
+		body["error"].(map[string]interface{})["message"] = err.Error()
+		writeJSON(w, http.StatusBadRequest, body)
+		return
+	}
+	b.faults = faults
+	b.logger.Printf("fault injection: changed the faults to %+v", faults)
+	writeJSON(w, http.StatusOK, faults)
+}
@@ -0,0 +1,98 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package emulator
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestInjectFaults(t *testing.T) {
+	b := newTestBMC(t)
+	b.faults.ErrorRatePercent = 100
+	rec := do(b, http.MethodGet, testSystem, nil, true)
+	if rec.Code != http.StatusInternalServerError || messageID(t, rec) != "Base.1.8.InternalError" {
+		t.Errorf("request should fail, got %d", rec.Code)
+	}
+	b.faults.ErrorStatusCode = http.StatusServiceUnavailable
+	rec = do(b, http.MethodGet, testSystem, nil, true)
+	if rec.Code != http.StatusServiceUnavailable || rec.Header().Get("Retry-After") != "1" {
+		t.Errorf("request should fail with a retry after, got %d %v", rec.Code, rec.Header())
+	}
+	// the emulator API is not affected by the faults
+	if rec := do(b, http.MethodGet, faultInjectionURI, nil, true); rec.Code != http.StatusOK {
+		t.Errorf("emulator API should not fail, got %d", rec.Code)
+	}
+}
+
+func TestFaultInjectionAPI(t *testing.T) {
+	b := newTestBMC(t)
+	if rec := do(b, http.MethodGet, faultInjectionURI, nil, false); rec.Code != http.StatusUnauthorized {
+		t.Errorf("emulator API should require authentication, got %d", rec.Code)
+	}
+	rec := do(b, http.MethodPatch, faultInjectionURI, map[string]interface{}{"LatencyInMilliseconds": 5, "ErrorRatePercent": 20}, true)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("faults should be changed, got %d %s", rec.Code, rec.Body.String())
+	}
+	body := decode(t, do(b, http.MethodGet, faultInjectionURI, nil, true))
+	if body["LatencyInMilliseconds"] != float64(5) || body["ErrorRatePercent"] != float64(20) || body["ErrorStatusCode"] != float64(500) {
+		t.Errorf("unexpected faults %v", body)
+	}
+
+	for _, patch := range []map[string]interface{}{
+		{"ErrorRatePercent": 101},
+		{"ErrorStatusCode": 404},
+		{"SessionDropRatePercent": -1},
+		{"Latency": 5},
+	} {
+		if rec := do(b, http.MethodPatch, faultInjectionURI, patch, true); rec.Code != http.StatusBadRequest {
+			t.Errorf("patch %v should be rejected, got %d", patch, rec.Code)
+		}
+	}
+	if b.faults.ErrorRatePercent != 20 {
+		t.Errorf("rejected patch should not change the faults, got %+v", b.faults)
+	}
+	if rec := do(b, http.MethodPost, faultInjectionURI, nil, true); rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST should not be allowed, got %d", rec.Code)
+	}
+	if rec := do(b, http.MethodGet, adminURI+"/Unknown", nil, true); rec.Code != http.StatusNotFound {
+		t.Errorf("unknown resource should not be found, got %d", rec.Code)
+	}
+}
+
+func TestDropSessions(t *testing.T) {
+	b := newTestBMC(t)
+	login := func() string {
+		rec := do(b, http.MethodPost, sessionsURI, map[string]string{"UserName": testUserName, "Password": testPassword}, false)
+		return rec.Header().Get("X-Auth-Token")
+	}
+	login()
+	login()
+	if rec := do(b, http.MethodPost, dropSessionsURI, nil, true); rec.Code != http.StatusNoContent {
+		t.Fatalf("sessions should be dropped, got %d", rec.Code)
+	}
+	if len(b.sessions) != 0 || len(b.store.Members(sessionsURI)) != 0 {
+		t.Error("all the sessions should be closed")
+	}
+
+	b.faults.SessionDropRatePercent = 100
+	token := login()
+	if b.useSession(token) {
+		t.Error("session should be dropped")
+	}
+	if len(b.sessions) != 0 {
+		t.Error("dropped session should be closed")
+	}
+}
@@ -0,0 +1,120 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package emulator
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const baseRegistry = "Base.1.8."
+
+// baseMessages holds the message and the severity of the Base registry messages returned by the emulator
+var baseMessages = map[string][2]string{
+	"Success":                       {"Successfully Completed Request", "OK"},
+	"Created":                       {"The resource has been created successfully.", "OK"},
+	"GeneralError":                  {"A general error has occurred. See Resolution for information on how to resolve the error.", "Critical"},
+	"InternalError":                 {"The request failed due to an internal service error.  The service is still operational.", "Critical"},
+	"ServiceTemporarilyUnavailable": {"The service is temporarily unavailable.  Retry in %1 seconds.", "Critical"},
+	"ResourceNotFound":              {"The requested resource of type %1 named '%2' was not found.", "Critical"},
+	"NoValidSession":                {"There is no valid session established with the implementation.", "Critical"},
+	"MalformedJSON":                 {"The request body submitted was malformed JSON and could not be parsed by the receiving service.", "Critical"},
+	"ActionNotSupported":            {"The action %1 is not supported by the resource.", "Critical"},
+	"PropertyUnknown":               {"The property %1 is not in the list of valid properties for the resource.", "Warning"},
+	"PropertyMissing":               {"The property %1 is a required property and must be included in the request.", "Warning"},
+	"PropertyNotWritable":           {"The property %1 is a read only property and cannot be assigned a value.", "Warning"},
+	"PropertyValueNotInList":        {"The value '%1' for the property %2 is not in the list of acceptable values.", "Warning"},
+	"PropertyValueFormatError":      {"The value '%1' for the property %2 is of a different format than the property can accept.", "Warning"},
+	"ResourceInUse":                 {"The change to the requested resource failed because the resource is in use or in transition.", "Warning"},
+}
+
+// requestError is a request rejected with a Base registry message
+type requestError struct {
+	status    int
+	messageID string
+	args      []string
+}
+
+func (e *requestError) Error() string {
+	return formatMessage(baseMessages[e.messageID][0], e.args)
+}
+
+func newRequestError(status int, messageID string, args ...string) *requestError {
+	return &requestError{status: status, messageID: messageID, args: args}
+}
+
+func formatMessage(message string, args []string) string {
+	for i := len(args); i > 0; i-- {
+		message = strings.ReplaceAll(message, "%"+strconv.Itoa(i), args[i-1])
+	}
+	return message
+}
+
+// extendedInfo returns the @Message.ExtendedInfo entry of a Base registry message
+func extendedInfo(messageID string, args ...string) map[string]interface{} {
+	message := baseMessages[messageID]
+	info := map[string]interface{}{
+		"@odata.type": "#Message.v1_1_1.Message",
+		"MessageId":   baseRegistry + messageID,
+		"Message":     formatMessage(message[0], args),
+		"Severity":    message[1],
+	}
+	if len(args) > 0 {
+		info["MessageArgs"] = args
+	}
+	return info
+}
+
+// successBody is the body of the actions completed successfully
+func successBody() map[string]interface{} {
+	return map[string]interface{}{
+		"@Message.ExtendedInfo": []interface{}{extendedInfo("Success")},
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		status = http.StatusInternalServerError
+		data, _ = json.Marshal(errorBody("InternalError"))
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(data)
+}
+
+func errorBody(messageID string, args ...string) map[string]interface{} {
+	return map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":                  baseRegistry + "GeneralError",
+			"message":               baseMessages["GeneralError"][0],
+			"@Message.ExtendedInfo": []interface{}{extendedInfo(messageID, args...)},
+		},
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, messageID string, args ...string) {
+	writeJSON(w, status, errorBody(messageID, args...))
+}
+
+func writeRequestError(w http.ResponseWriter, err error) {
+	if reqErr, ok := err.(*requestError); ok {
+		writeError(w, reqErr.status, reqErr.messageID, reqErr.args...)
+		return
+	}
+	writeError(w, http.StatusInternalServerError, "InternalError")
+}
@@ -0,0 +1,189 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package emulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ODIM-Project/ODIM/bmc-emulator/mockup"
+)
+
+// readOnlyProperties are the properties a PATCH request cannot change
+var readOnlyProperties = map[string]bool{
+	"Id":         true,
+	"Actions":    true,
+	"Members":    true,
+	"Status":     true,
+	"PowerState": true,
+	"UUID":       true,
+}
+
+func (b *BMC) patchResource(w http.ResponseWriter, r *http.Request, uri string) {
+	var patch map[string]interface{}
+	if err := readRequest(r, &patch); err != nil {
+		writeRequestError(w, err)
+		return
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	resource, ok := b.store.Get(uri)
+	if !ok {
+		writeError(w, http.StatusNotFound, "ResourceNotFound", "Resource", uri)
+		return
+	}
+	var err error
+	if target, ok := b.settingsOf[uri]; ok {
+		err = b.patchSettings(uri, resource, target, patch)
+	} else if err = validatePatch(resource, patch, ""); err == nil {
+		mergePatch(resource, patch)
+		b.logger.Println("patched " + uri)
+		b.emit(resourceChangedEvent(uri))
+	}
+	if err != nil {
+		writeRequestError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resource)
+}
+
+// patchSettings records the attributes of a settings object, they are applied to the target
+// resource at the next power on or restart of the system
+func (b *BMC) patchSettings(uri string, settings mockup.Resource, targetURI string, patch map[string]interface{}) error {
+	target, _ := b.store.Get(targetURI)
+	current, _ := target["Attributes"].(map[string]interface{})
+	for property := range patch {
+		if property != "Attributes" {
+			return newRequestError(http.StatusBadRequest, "PropertyUnknown", property)
+		}
+	}
+	attributes, ok := patch["Attributes"].(map[string]interface{})
+	if !ok {
+		return newRequestError(http.StatusBadRequest, "PropertyValueFormatError", fmt.Sprint(patch["Attributes"]), "Attributes")
+	}
+	if err := validatePatch(current, attributes, "Attributes/"); err != nil {
+		return err
+	}
+	pending, _ := settings["Attributes"].(map[string]interface{})
+	if pending == nil {
+		pending = make(map[string]interface{})
+		settings["Attributes"] = pending
+	}
+	for name, value := range attributes {
+		pending[name] = value
+	}
+	b.logger.Printf("recorded %d pending attributes in %s", len(attributes), uri)
+	b.emit(resourceChangedEvent(uri))
+	return nil
+}
+
+// applySettings applies the pending attributes of the settings objects of the resources of the system
+func (b *BMC) applySettings(systemURI string) {
+	for settingsURI, targetURI := range b.settingsOf {
+		if !strings.HasPrefix(targetURI, systemURI+"/") {
+			continue
+		}
+		settings, _ := b.store.Get(settingsURI)
+		pending, _ := settings["Attributes"].(map[string]interface{})
+		if len(pending) == 0 {
+			continue
+		}
+		target, _ := b.store.Get(targetURI)
+		attributes, _ := target["Attributes"].(map[string]interface{})
+		if attributes == nil {
+			attributes = make(map[string]interface{})
+			target["Attributes"] = attributes
+		}
+		for name, value := range pending {
+			attributes[name] = value
+		}
+		settings["Attributes"] = map[string]interface{}{}
+		if redfishSettings, ok := target["@Redfish.Settings"].(map[string]interface{}); ok {
+			redfishSettings["Time"] = now()
+		}
+		b.logger.Printf("applied %d pending attributes to %s", len(pending), targetURI)
+		b.emit(resourceChangedEvent(targetURI))
+	}
+}
+
+// validatePatch checks the properties of patch against the resource, prefix is the path of the
+// resource in the patched resource
+func validatePatch(resource, patch map[string]interface{}, prefix string) error {
+	for property, value := range patch {
+		if readOnlyProperties[property] || strings.Contains(property, "@") {
+			return newRequestError(http.StatusBadRequest, "PropertyNotWritable", prefix+property)
+		}
+		current, ok := resource[property]
+		if !ok {
+			return newRequestError(http.StatusBadRequest, "PropertyUnknown", prefix+property)
+		}
+		if current != nil && value != nil && jsonKind(current) != jsonKind(value) {
+			return newRequestError(http.StatusBadRequest, "PropertyValueFormatError", fmt.Sprint(value), prefix+property)
+		}
+		if object, ok := value.(map[string]interface{}); ok {
+			if currentObject, ok := current.(map[string]interface{}); ok {
+				if err := validatePatch(currentObject, object, prefix+property+"/"); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if allowable, ok := resource[property+"@Redfish.AllowableValues"].([]interface{}); ok && !contains(allowable, value) {
+			return newRequestError(http.StatusBadRequest, "PropertyValueNotInList", fmt.Sprint(value), prefix+property)
+		}
+	}
+	return nil
+}
+
+// mergePatch applies patch to the resource, the objects are merged
+func mergePatch(resource, patch map[string]interface{}) {
+	for property, value := range patch {
+		object, isObject := value.(map[string]interface{})
+		currentObject, isCurrentObject := resource[property].(map[string]interface{})
+		if isObject && isCurrentObject {
+			mergePatch(currentObject, object)
+			continue
+		}
+		resource[property] = value
+	}
+}
+
+// jsonKind returns the JSON type of a decoded value
+func jsonKind(value interface{}) string {
+	switch value.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64, json.Number, int:
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return "null"
+}
+
+func contains(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if fmt.Sprint(v) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
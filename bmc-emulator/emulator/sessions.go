@@ -0,0 +1,174 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package emulator
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/bmc-emulator/mockup"
+)
+
+const defaultSessionTimeout = 30 * time.Minute
+
+// session is a Redfish session opened with the BMC
+type session struct {
+	uri      string
+	lastUsed time.Time
+}
+
+type sessionRequest struct {
+	UserName string `json:"UserName"`
+	Password string `json:"Password"`
+}
+
+func (b *BMC) createSession(w http.ResponseWriter, r *http.Request) {
+	var req sessionRequest
+	if err := readRequest(r, &req); err != nil {
+		writeRequestError(w, err)
+		return
+	}
+	switch {
+	case req.UserName == "":
+		writeError(w, http.StatusBadRequest, "PropertyMissing", "UserName")
+		return
+	case req.Password == "":
+		writeError(w, http.StatusBadRequest, "PropertyMissing", "Password")
+		return
+	case req.UserName != b.userName || req.Password != b.password:
+		writeError(w, http.StatusUnauthorized, "NoValidSession")
+		return
+	}
+	token, err := newToken()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "InternalError")
+		return
+	}
+
+	b.mutex.Lock()
+	id := b.nextID(sessionsURI)
+	uri := sessionsURI + "/" + id
+	resource := mockup.Resource{
+		"@odata.type": "#Session.v1_3_0.Session",
+		"Id":          id,
+		"Name":        "User Session",
+		"UserName":    req.UserName,
+	}
+	b.store.Put(uri, resource)
+	b.store.AddMember(sessionsURI, uri)
+	b.sessions[token] = &session{uri: uri, lastUsed: time.Now()}
+	data, _ := json.Marshal(resource)
+	b.mutex.Unlock()
+
+	b.logger.Println("created the session " + uri)
+	w.Header().Set("X-Auth-Token", token)
+	w.Header().Set("Location", uri)
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(data)
+}
+
+func newToken() (string, error) {
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
+
+// authenticate checks the session token or the basic authentication of the request,
+// the rejected requests are answered
+func (b *BMC) authenticate(w http.ResponseWriter, r *http.Request) bool {
+	if token := r.Header.Get("X-Auth-Token"); token != "" {
+		if b.useSession(token) {
+			return true
+		}
+		writeError(w, http.StatusUnauthorized, "NoValidSession")
+		return false
+	}
+	userName, password, ok := r.BasicAuth()
+	if ok && userName == b.userName && password == b.password {
+		return true
+	}
+	w.Header().Set("WWW-Authenticate", `Basic realm="BMC Emulator"`)
+	writeError(w, http.StatusUnauthorized, "NoValidSession")
+	return false
+}
+
+// useSession returns true when the session of token is valid. The expired sessions,
+// and the sessions dropped by the fault injection, are closed.
+func (b *BMC) useSession(token string) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	s, ok := b.sessions[token]
+	if !ok {
+		return false
+	}
+	if time.Since(s.lastUsed) > b.sessionTimeout() {
+		b.logger.Println("the session " + s.uri + " expired")
+		b.closeSession(token)
+		return false
+	}
+	if b.faults.SessionDropRatePercent > 0 && b.random.Intn(100) < b.faults.SessionDropRatePercent {
+		b.logger.Println("fault injection: dropped the session " + s.uri)
+		b.closeSession(token)
+		return false
+	}
+	s.lastUsed = time.Now()
+	return true
+}
+
+// sessionTimeout returns the SessionTimeout of the session service
+func (b *BMC) sessionTimeout() time.Duration {
+	service, _ := b.store.Get(sessionServiceURI)
+	if timeout, ok := service["SessionTimeout"].(json.Number); ok {
+		if seconds, err := timeout.Int64(); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return defaultSessionTimeout
+}
+
+func (b *BMC) closeSession(token string) {
+	s := b.sessions[token]
+	delete(b.sessions, token)
+	b.store.Delete(s.uri)
+	b.store.RemoveMember(sessionsURI, s.uri)
+}
+
+// closeAllSessions closes all the sessions, like a BMC restart does
+func (b *BMC) closeAllSessions() {
+	for token := range b.sessions {
+		b.closeSession(token)
+	}
+}
+
+func (b *BMC) deleteSession(w http.ResponseWriter, uri string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	for token, s := range b.sessions {
+		if strings.EqualFold(s.uri, uri) {
+			b.closeSession(token)
+			b.logger.Println("deleted the session " + uri)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "ResourceNotFound", "Session", uri)
+}
@@ -0,0 +1,241 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package emulator
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/ODIM-Project/ODIM/bmc-emulator/mockup"
+)
+
+// imageVersionPattern matches the version in the name of a firmware image, like 2.50 in bmc-2.50.bin
+var imageVersionPattern = regexp.MustCompile(`\d+(\.\d+)+`)
+
+// task is a task run by the BMC, followed through its task monitor
+type task struct {
+	id         string
+	uri        string
+	monitorURI string
+	done       bool
+	// result is the response of the task monitor once the task is done
+	result *response
+}
+
+func (b *BMC) newTask(name string) *task {
+	id := b.nextID(tasksURI)
+	t := &task{
+		id:         id,
+		uri:        tasksURI + "/" + id,
+		monitorURI: taskMonitorsURI + "/" + id,
+	}
+	b.store.Put(t.uri, mockup.Resource{
+		"@odata.type":     "#Task.v1_5_1.Task",
+		"Id":              id,
+		"Name":            name,
+		"TaskState":       "New",
+		"TaskStatus":      "OK",
+		"StartTime":       now(),
+		"PercentComplete": 0,
+		"TaskMonitor":     t.monitorURI,
+		"Messages":        []interface{}{},
+	})
+	b.store.AddMember(tasksURI, t.uri)
+	b.tasks[id] = t
+	return t
+}
+
+// simpleUpdate starts a task updating the firmware of the targets with the image. The
+// version of the targets is set to the version found in the name of the image.
+func (b *BMC) simpleUpdate(uri string, service mockup.Resource, info, params map[string]interface{}) (*response, error) {
+	image, err := stringParam(info, params, "ImageURI", true)
+	if err != nil {
+		return nil, err
+	}
+	transferProtocol, err := stringParam(info, params, "TransferProtocol", false)
+	if err != nil {
+		return nil, err
+	}
+	imageURL, err := url.Parse(image)
+	if err != nil || (imageURL.Scheme == "" && transferProtocol == "") {
+		return nil, newRequestError(http.StatusBadRequest, "PropertyValueFormatError", image, "ImageURI")
+	}
+	targets, err := b.updateTargets(service, params)
+	if err != nil {
+		return nil, err
+	}
+
+	t := b.newTask("Firmware update with " + path.Base(imageURL.Path))
+	resource, _ := b.store.Get(t.uri)
+	resp := &response{
+		status: http.StatusAccepted,
+		header: map[string]string{"Location": t.monitorURI},
+		body:   copyMap(resource),
+	}
+	b.logger.Printf("started the task %s updating %s with %s", t.uri, strings.Join(targets, ", "), image)
+	b.runUpdate(t, targets, image)
+	return resp, nil
+}
+
+// updateTargets returns the Targets of the update, or the updateable members of the firmware inventory
+func (b *BMC) updateTargets(service mockup.Resource, params map[string]interface{}) ([]string, error) {
+	var targets []string
+	if value, ok := params["Targets"]; ok && value != nil {
+		list, ok := value.([]interface{})
+		if !ok {
+			return nil, newRequestError(http.StatusBadRequest, "PropertyValueFormatError", fmt.Sprint(value), "Targets")
+		}
+		for _, target := range list {
+			uri, _ := target.(string)
+			if _, ok := b.store.Get(uri); !ok {
+				return nil, newRequestError(http.StatusBadRequest, "PropertyValueNotInList", fmt.Sprint(target), "Targets")
+			}
+			targets = append(targets, mockup.NormalizeURI(uri))
+		}
+		return targets, nil
+	}
+	for _, member := range b.store.Members(mockup.Link(service, "FirmwareInventory")) {
+		if firmware, ok := b.store.Get(member); ok && firmware["Updateable"] == true {
+			targets = append(targets, member)
+		}
+	}
+	return targets, nil
+}
+
+// runUpdate takes the task through its progress steps over the task duration of the BMC
+func (b *BMC) runUpdate(t *task, targets []string, image string) {
+	resource, _ := b.store.Get(t.uri)
+	resource["TaskState"] = "Running"
+	b.emit(taskEvent(t.uri, "TaskStarted", t.id))
+	step := b.taskDuration / 4
+	var progress func(percent int)
+	progress = func(percent int) {
+		if t.done {
+			// the task was cancelled
+			return
+		}
+		if percent < 100 {
+			resource["PercentComplete"] = percent
+			b.emit(taskEvent(t.uri, "TaskProgressChanged", t.id, fmt.Sprint(percent)))
+			b.after(step, func() { progress(percent + 25) })
+			return
+		}
+		b.completeUpdate(t, resource, targets, image)
+	}
+	b.after(step, func() { progress(25) })
+}
+
+func (b *BMC) completeUpdate(t *task, resource mockup.Resource, targets []string, image string) {
+	version := imageVersionPattern.FindString(path.Base(image))
+	restartBMC := false
+	for _, target := range targets {
+		firmware, ok := b.store.Get(target)
+		if !ok {
+			continue
+		}
+		if version != "" {
+			firmware["Version"] = version
+			restartBMC = b.setRelatedVersions(firmware, version) || restartBMC
+		}
+		b.emit(resourceChangedEvent(target))
+		b.emit(updateEvent(target, image))
+	}
+	resource["TaskState"] = "Completed"
+	resource["PercentComplete"] = 100
+	resource["EndTime"] = now()
+	resource["Messages"] = []interface{}{taskMessage("TaskCompletedOK", t.id)}
+	t.done = true
+	t.result = successResponse()
+	b.logger.Println("completed the task " + t.uri)
+	b.emit(taskEvent(t.uri, "TaskCompletedOK", t.id))
+	if restartBMC {
+		// the BMC restarts to run its new firmware
+		b.logger.Println("restarting after the update of the BMC firmware, closing all the sessions")
+		b.closeAllSessions()
+	}
+}
+
+// setRelatedVersions sets the firmware version of the resources related to the updated firmware,
+// it returns true when the firmware of a manager was updated
+func (b *BMC) setRelatedVersions(firmware mockup.Resource, version string) bool {
+	manager := false
+	related, _ := firmware["RelatedItem"].([]interface{})
+	for _, item := range related {
+		link, _ := item.(map[string]interface{})
+		uri, _ := link["@odata.id"].(string)
+		uri = mockup.NormalizeURI(uri)
+		if resource, ok := b.store.Get(uri); ok {
+			if _, ok := resource["FirmwareVersion"]; ok {
+				resource["FirmwareVersion"] = version
+			}
+		}
+		if strings.HasSuffix(uri, "/Bios") {
+			if system, ok := b.store.Get(strings.TrimSuffix(uri, "/Bios")); ok {
+				system["BiosVersion"] = version
+			}
+		}
+		if strings.HasPrefix(uri, mockup.ServiceRootURI+"/Managers/") {
+			manager = true
+		}
+	}
+	return manager
+}
+
+func (b *BMC) getTaskMonitor(w http.ResponseWriter, uri string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	t, ok := b.tasks[path.Base(uri)]
+	if !ok || t.monitorURI != uri {
+		writeError(w, http.StatusNotFound, "ResourceNotFound", "TaskMonitor", uri)
+		return
+	}
+	if !t.done {
+		resource, _ := b.store.Get(t.uri)
+		w.Header().Set("Location", t.monitorURI)
+		writeJSON(w, http.StatusAccepted, resource)
+		return
+	}
+	writeJSON(w, t.result.status, t.result.body)
+}
+
+// cancelTask cancels the running task of the task monitor, the monitor of a task done is removed
+func (b *BMC) cancelTask(w http.ResponseWriter, uri string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	t, ok := b.tasks[path.Base(uri)]
+	if !ok || t.monitorURI != uri {
+		writeError(w, http.StatusNotFound, "ResourceNotFound", "TaskMonitor", uri)
+		return
+	}
+	if t.done {
+		delete(b.tasks, t.id)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	resource, _ := b.store.Get(t.uri)
+	resource["TaskState"] = "Cancelled"
+	resource["TaskStatus"] = "Warning"
+	resource["EndTime"] = now()
+	resource["Messages"] = []interface{}{taskMessage("TaskCancelled", t.id)}
+	t.done = true
+	t.result = &response{status: http.StatusOK, body: copyMap(resource)}
+	b.logger.Println("cancelled the task " + t.uri)
+	b.emit(taskEvent(t.uri, "TaskCancelled", t.id))
+	w.WriteHeader(http.StatusNoContent)
+}
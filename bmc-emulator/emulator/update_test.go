@@ -0,0 +1,115 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package emulator
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+const (
+	testSimpleUpdate = "/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate"
+	testBMCFirmware  = "/redfish/v1/UpdateService/FirmwareInventory/BMC"
+	testBiosFirmware = "/redfish/v1/UpdateService/FirmwareInventory/BIOS"
+)
+
+func TestSimpleUpdate(t *testing.T) {
+	b := newTestBMC(t)
+	do(b, http.MethodPost, sessionsURI, map[string]string{"UserName": testUserName, "Password": testPassword}, false)
+
+	rec := do(b, http.MethodPost, testSimpleUpdate, map[string]interface{}{
+		"ImageURI": "http://images/bios-2.10.bin",
+		"Targets":  []string{testBiosFirmware},
+	}, true)
+	monitor := rec.Header().Get("Location")
+	if rec.Code != http.StatusAccepted || monitor != taskMonitorsURI+"/1" {
+		t.Fatalf("update should be accepted, got %d %v", rec.Code, rec.Header())
+	}
+	if version := getProperty(b, testBiosFirmware, "Version"); version != "2.10" {
+		t.Errorf("BIOS firmware version should be updated, got %v", version)
+	}
+	if version := getProperty(b, testSystem, "BiosVersion"); version != "2.10" {
+		t.Errorf("system BIOS version should be updated, got %v", version)
+	}
+	if version := getProperty(b, testBMCFirmware, "Version"); version != "1.00" {
+		t.Errorf("BMC firmware should not be updated, got %v", version)
+	}
+	if len(b.sessions) != 1 {
+		t.Error("BIOS update should not close the sessions")
+	}
+	if rec := do(b, http.MethodGet, monitor, nil, true); rec.Code != http.StatusOK {
+		t.Errorf("monitor of a completed task should return the result, got %d", rec.Code)
+	}
+	if state := getProperty(b, tasksURI+"/1", "TaskState"); state != "Completed" {
+		t.Errorf("task should be completed, got %v", state)
+	}
+
+	// the update of the BMC firmware restarts the BMC
+	rec = do(b, http.MethodPost, testSimpleUpdate, map[string]interface{}{"ImageURI": "https://images/bmc-2.50.bin"}, true)
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("update should be accepted, got %d", rec.Code)
+	}
+	if version := getProperty(b, "/redfish/v1/Managers/1", "FirmwareVersion"); version != "2.50" {
+		t.Errorf("manager firmware version should be updated, got %v", version)
+	}
+	if len(b.sessions) != 0 {
+		t.Error("BMC update should close the sessions")
+	}
+
+	for _, params := range []map[string]interface{}{
+		{},
+		{"ImageURI": "bmc.bin"},
+		{"ImageURI": "http://images/bmc.bin", "TransferProtocol": "TFTP"},
+		{"ImageURI": "http://images/bmc.bin", "Targets": []string{"/redfish/v1/UpdateService/FirmwareInventory/NIC"}},
+	} {
+		if rec := do(b, http.MethodPost, testSimpleUpdate, params, true); rec.Code != http.StatusBadRequest {
+			t.Errorf("update with %v should be rejected, got %d", params, rec.Code)
+		}
+	}
+}
+
+func TestCancelTask(t *testing.T) {
+	b := newTestBMC(t)
+	b.taskDuration = time.Hour
+	rec := do(b, http.MethodPost, testSimpleUpdate, map[string]interface{}{"ImageURI": "http://images/bmc-2.50.bin"}, true)
+	monitor := rec.Header().Get("Location")
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("update should be accepted, got %d", rec.Code)
+	}
+	rec = do(b, http.MethodGet, monitor, nil, true)
+	if rec.Code != http.StatusAccepted || decode(t, rec)["TaskState"] != "Running" {
+		t.Errorf("monitor of a running task should return the task, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	if rec := do(b, http.MethodDelete, monitor, nil, true); rec.Code != http.StatusNoContent {
+		t.Fatalf("task should be cancelled, got %d", rec.Code)
+	}
+	rec = do(b, http.MethodGet, monitor, nil, true)
+	if rec.Code != http.StatusOK || decode(t, rec)["TaskState"] != "Cancelled" {
+		t.Errorf("monitor of a cancelled task should return the task, got %d %s", rec.Code, rec.Body.String())
+	}
+	if version := getProperty(b, testBMCFirmware, "Version"); version != "1.00" {
+		t.Errorf("cancelled update should not change the version, got %v", version)
+	}
+
+	// the monitor of a task done is removed
+	if rec := do(b, http.MethodDelete, monitor, nil, true); rec.Code != http.StatusNoContent {
+		t.Errorf("monitor should be removed, got %d", rec.Code)
+	}
+	if rec := do(b, http.MethodGet, monitor, nil, true); rec.Code != http.StatusNotFound {
+		t.Errorf("removed monitor should not be found, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/ODIM-Project/ODIM/bmc-emulator
+
+go 1.19
@@ -0,0 +1,49 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/bmc-emulator/config"
+	"github.com/ODIM-Project/ODIM/bmc-emulator/emulator"
+	"github.com/ODIM-Project/ODIM/bmc-emulator/mockup"
+)
+
+func main() {
+	conf, err := config.Load()
+	if err != nil {
+		log.Fatal("failed to load the configuration: " + err.Error())
+	}
+	eventClient, err := emulator.NewEventClient(conf.RootCACertificatePath, time.Duration(conf.EventDeliveryTimeoutInSeconds)*time.Second)
+	if err != nil {
+		log.Fatal("failed to create the event client: " + err.Error())
+	}
+
+	errs := make(chan error, len(conf.BMCs))
+	for _, bmcConf := range conf.BMCs {
+		store, err := mockup.Load(bmcConf.MockupPath)
+		if err != nil {
+			log.Fatal("failed to load the mockup of the BMC " + bmcConf.Name + ": " + err.Error())
+		}
+		bmc := emulator.New(bmcConf, store, eventClient)
+		go func(name string) {
+			errs <- fmt.Errorf("the BMC %s stopped: %v", name, bmc.ListenAndServe())
+		}(bmcConf.Name)
+	}
+	log.Fatal(<-errs)
+}
@@ -0,0 +1,190 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package mockup holds the Redfish resources of an emulated BMC, loaded from a DMTF Redfish mockup
+package mockup
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ServiceRootURI is the URI of the Redfish service root
+const ServiceRootURI = "/redfish/v1"
+
+// Resource is a Redfish resource as decoded from its JSON
+type Resource map[string]interface{}
+
+// Store holds the resources of a BMC by URI. A Store is not safe for concurrent use.
+type Store struct {
+	resources map[string]Resource
+}
+
+// NewStore returns an empty store
+func NewStore() *Store {
+	return &Store{resources: make(map[string]Resource)}
+}
+
+// Load reads the mockup at path. A mockup is a directory tree with an index.json file per
+// resource, rooted either at the service root or at a directory holding redfish/v1.
+func Load(path string) (*Store, error) {
+	root := path
+	if _, err := os.Stat(filepath.Join(path, "redfish", "v1", "index.json")); err == nil {
+		root = filepath.Join(path, "redfish", "v1")
+	}
+	if _, err := os.Stat(filepath.Join(root, "index.json")); err != nil {
+		return nil, fmt.Errorf("no service root found in the mockup %s: %v", path, err)
+	}
+	store := NewStore()
+	err := filepath.Walk(root, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || info.Name() != "index.json" {
+			return nil
+		}
+		rel, err := filepath.Rel(root, filepath.Dir(file))
+		if err != nil {
+			return err
+		}
+		uri := ServiceRootURI
+		if rel != "." {
+			uri = ServiceRootURI + "/" + filepath.ToSlash(rel)
+		}
+		resource, err := readResource(file)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %v", file, err)
+		}
+		store.Put(uri, resource)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+func readResource(file string) (Resource, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// numbers are kept as they are written in the mockup
+	decoder.UseNumber()
+	var resource Resource
+	if err := decoder.Decode(&resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// NormalizeURI removes the query and the trailing slash of a resource URI
+func NormalizeURI(uri string) string {
+	if i := strings.IndexAny(uri, "?#"); i >= 0 {
+		uri = uri[:i]
+	}
+	if len(uri) > 1 {
+		uri = strings.TrimRight(uri, "/")
+	}
+	return uri
+}
+
+// Get returns the resource at uri. The resource is shared with the store.
+func (s *Store) Get(uri string) (Resource, bool) {
+	resource, ok := s.resources[NormalizeURI(uri)]
+	return resource, ok
+}
+
+// Put adds or replaces the resource at uri, its @odata.id is set to uri
+func (s *Store) Put(uri string, resource Resource) {
+	uri = NormalizeURI(uri)
+	resource["@odata.id"] = uri
+	s.resources[uri] = resource
+}
+
+// Delete removes the resource at uri
+func (s *Store) Delete(uri string) {
+	delete(s.resources, NormalizeURI(uri))
+}
+
+// URIs returns the URIs of all the resources, sorted
+func (s *Store) URIs() []string {
+	uris := make([]string, 0, len(s.resources))
+	for uri := range s.resources {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+	return uris
+}
+
+// Members returns the URIs of the members of the collection at uri
+func (s *Store) Members(uri string) []string {
+	collection, ok := s.Get(uri)
+	if !ok {
+		return nil
+	}
+	members, _ := collection["Members"].([]interface{})
+	uris := make([]string, 0, len(members))
+	for _, member := range members {
+		if link, ok := member.(map[string]interface{}); ok {
+			if oid, _ := link["@odata.id"].(string); oid != "" {
+				uris = append(uris, NormalizeURI(oid))
+			}
+		}
+	}
+	return uris
+}
+
+// AddMember adds a link to memberURI to the collection at uri
+func (s *Store) AddMember(uri, memberURI string) {
+	collection, ok := s.Get(uri)
+	if !ok {
+		return
+	}
+	members, _ := collection["Members"].([]interface{})
+	collection["Members"] = append(members, map[string]interface{}{"@odata.id": memberURI})
+	collection["Members@odata.count"] = len(members) + 1
+}
+
+// RemoveMember removes the link to memberURI from the collection at uri
+func (s *Store) RemoveMember(uri, memberURI string) {
+	collection, ok := s.Get(uri)
+	if !ok {
+		return
+	}
+	members, _ := collection["Members"].([]interface{})
+	kept := make([]interface{}, 0, len(members))
+	for _, member := range members {
+		if link, ok := member.(map[string]interface{}); ok && link["@odata.id"] == memberURI {
+			continue
+		}
+		kept = append(kept, member)
+	}
+	collection["Members"] = kept
+	collection["Members@odata.count"] = len(kept)
+}
+
+// Link returns the URI of the link held in property of the resource
+func Link(resource Resource, property string) string {
+	link, _ := resource[property].(map[string]interface{})
+	oid, _ := link["@odata.id"].(string)
+	return NormalizeURI(oid)
+}
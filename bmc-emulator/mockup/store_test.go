@@ -0,0 +1,107 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package mockup
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeResource(t *testing.T, dir string, resource string) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "index.json"), []byte(resource), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	store, err := Load("../mockups/simple")
+	if err != nil {
+		t.Fatalf("failed to load the sample mockup: %v", err)
+	}
+	system, ok := store.Get("/redfish/v1/Systems/1/")
+	if !ok || system["PowerState"] != "On" {
+		t.Errorf("unexpected system: %v", system)
+	}
+	if count, _ := system["ProcessorSummary"].(map[string]interface{})["Count"].(json.Number); count != "2" {
+		t.Errorf("numbers should be kept as json.Number, got %T", system["ProcessorSummary"].(map[string]interface{})["Count"])
+	}
+
+	// a mockup rooted at the service root
+	dir := t.TempDir()
+	writeResource(t, dir, `{"@odata.id":"/redfish/v1/","Systems":{"@odata.id":"/redfish/v1/Systems"}}`)
+	writeResource(t, filepath.Join(dir, "Systems"), `{"Members":[{"@odata.id":"/redfish/v1/Systems/1"}]}`)
+	store, err = Load(dir)
+	if err != nil {
+		t.Fatalf("failed to load the mockup: %v", err)
+	}
+	if !reflect.DeepEqual(store.URIs(), []string{"/redfish/v1", "/redfish/v1/Systems"}) {
+		t.Errorf("unexpected URIs: %v", store.URIs())
+	}
+	root, _ := store.Get(ServiceRootURI)
+	if root["@odata.id"] != ServiceRootURI || Link(root, "Systems") != "/redfish/v1/Systems" {
+		t.Errorf("unexpected service root: %v", root)
+	}
+
+	if _, err := Load(t.TempDir()); err == nil {
+		t.Error("a directory without service root should be rejected")
+	}
+	dir = t.TempDir()
+	writeResource(t, dir, `{`)
+	if _, err := Load(dir); err == nil {
+		t.Error("a malformed resource should be rejected")
+	}
+}
+
+func TestMembers(t *testing.T) {
+	store := NewStore()
+	store.Put("/redfish/v1/Systems/", Resource{"Members": []interface{}{}, "Members@odata.count": 0})
+	store.AddMember("/redfish/v1/Systems", "/redfish/v1/Systems/1")
+	store.AddMember("/redfish/v1/Systems", "/redfish/v1/Systems/2")
+	if members := store.Members("/redfish/v1/Systems"); !reflect.DeepEqual(members, []string{"/redfish/v1/Systems/1", "/redfish/v1/Systems/2"}) {
+		t.Errorf("unexpected members: %v", members)
+	}
+	store.RemoveMember("/redfish/v1/Systems", "/redfish/v1/Systems/1")
+	collection, _ := store.Get("/redfish/v1/Systems")
+	if members := store.Members("/redfish/v1/Systems"); !reflect.DeepEqual(members, []string{"/redfish/v1/Systems/2"}) || collection["Members@odata.count"] != 1 {
+		t.Errorf("unexpected collection: %v", collection)
+	}
+	if store.Members("/redfish/v1/Chassis") != nil {
+		t.Error("a missing collection should have no members")
+	}
+	store.Delete("/redfish/v1/Systems")
+	if _, ok := store.Get("/redfish/v1/Systems"); ok {
+		t.Error("the collection should be deleted")
+	}
+}
+
+func TestNormalizeURI(t *testing.T) {
+	for uri, want := range map[string]string{
+		"/redfish/v1/":               "/redfish/v1",
+		"/redfish/v1/Systems?$top=1": "/redfish/v1/Systems",
+		"/":                          "/",
+		"/redfish/v1/Systems/1#/Id":  "/redfish/v1/Systems/1",
+	} {
+		if got := NormalizeURI(uri); got != want {
+			t.Errorf("NormalizeURI(%q) = %q, want %q", uri, got, want)
+		}
+	}
+}
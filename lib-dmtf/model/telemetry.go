@@ -163,17 +163,18 @@ type Triggers struct {
 
 // NumericThresholds defines when a numeric metric triggers
 type NumericThresholds struct {
-	LowerCritical Threshold `json:"LowerCritical,omitempty"`
-	LowerWarning  Threshold `json:"LowerWarning,omitempty"`
-	UpperCritical Threshold `json:"UpperCritical,omitempty"`
-	UpperWarning  Threshold `json:"UpperWarning,omitempty"`
+	LowerCritical *Threshold `json:"LowerCritical,omitempty"`
+	LowerWarning  *Threshold `json:"LowerWarning,omitempty"`
+	UpperCritical *Threshold `json:"UpperCritical,omitempty"`
+	UpperWarning  *Threshold `json:"UpperWarning,omitempty"`
 }
 
 // Threshold schema for numeric threshold
 type Threshold struct {
-	Activation string `json:"Activation,omitempty"`
-	DwellTime  string `json:"DwellTime,omitempty"`
-	Reading    int    `json:"Reading,omitempty"`
+	Activation        string  `json:"Activation,omitempty"`
+	DwellTime         string  `json:"DwellTime,omitempty"`
+	HysteresisReading float64 `json:"HysteresisReading,omitempty"`
+	Reading           float64 `json:"Reading,omitempty"`
 }
 
 // TriggerLinks defines links to resources associated with Triggers
@@ -0,0 +1,54 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package datacommunicator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Ping verifies that the message bus of the broker type passed accepts
+// connections. For KAFKA it succeeds when any of the configured servers
+// is reachable. SetConfiguration must be called before using Ping.
+func Ping(ctx context.Context, bt string) error {
+	switch bt {
+	case KAFKA:
+		kp := new(KafkaPacket)
+		if err := kafkaConnect(kp); err != nil {
+			return err
+		}
+		var errs []string
+		for _, server := range kp.ServersInfo {
+			conn, err := kp.DialerConn.DialContext(ctx, "tcp", server)
+			if err == nil {
+				return conn.Close()
+			}
+			errs = append(errs, err.Error())
+		}
+		return fmt.Errorf("none of the kafka servers is reachable: %s", strings.Join(errs, "; "))
+	case REDISSTREAMS:
+		rp := new(RedisStreamsPacket)
+		if err := rp.getDBConnection(); err != nil {
+			return err
+		}
+		if err := rp.client.Ping(ctx).Err(); err != nil {
+			return fmt.Errorf("redis streams server is not reachable: %s", err.Error())
+		}
+		return nil
+	default:
+		return fmt.Errorf("Broker: \"Broker Type\" is not supported - %s", bt)
+	}
+}
@@ -0,0 +1,98 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package persistencemgr
+
+import (
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/metrics"
+	redis "github.com/go-redis/redis"
+)
+
+func init() {
+	metrics.NewGaugeFunc("odim_redis_pool_max_connections",
+		"Maximum number of connections the Redis connection pool can open", nil, func() float64 {
+			if config.Data.DBConf == nil {
+				return 0
+			}
+			return float64(config.Data.DBConf.MaxActiveConns)
+		})
+	for _, db := range []DbType{InMemory, OnDisk} {
+		for _, write := range []bool{false, true} {
+			registerPoolMetrics(db, write)
+		}
+	}
+}
+
+// registerPoolMetrics exposes the statistics of one of the connection pools
+func registerPoolMetrics(db DbType, write bool) {
+	dbName, poolName := "InMemory", "read"
+	if db == OnDisk {
+		dbName = "OnDisk"
+	}
+	if write {
+		poolName = "write"
+	}
+	stat := func(value func(*redis.PoolStats) uint32) func() float64 {
+		return func() float64 {
+			stats := getPoolStats(db, write)
+			if stats == nil {
+				return 0
+			}
+			return float64(value(stats))
+		}
+	}
+	labels := func(pairs ...string) map[string]string {
+		l := map[string]string{"db": dbName, "pool": poolName}
+		for i := 0; i+1 < len(pairs); i += 2 {
+			l[pairs[i]] = pairs[i+1]
+		}
+		return l
+	}
+	connections := "Number of connections in the Redis connection pool, by state"
+	metrics.NewGaugeFunc("odim_redis_pool_connections", connections, labels("state", "total"),
+		stat(func(s *redis.PoolStats) uint32 { return s.TotalConns }))
+	metrics.NewGaugeFunc("odim_redis_pool_connections", connections, labels("state", "idle"),
+		stat(func(s *redis.PoolStats) uint32 { return s.IdleConns }))
+	requests := "Number of connection requests served by the Redis connection pool, by result"
+	metrics.NewCounterFunc("odim_redis_pool_requests_total", requests, labels("result", "hit"),
+		stat(func(s *redis.PoolStats) uint32 { return s.Hits }))
+	metrics.NewCounterFunc("odim_redis_pool_requests_total", requests, labels("result", "miss"),
+		stat(func(s *redis.PoolStats) uint32 { return s.Misses }))
+	metrics.NewCounterFunc("odim_redis_pool_requests_total", requests, labels("result", "timeout"),
+		stat(func(s *redis.PoolStats) uint32 { return s.Timeouts }))
+	metrics.NewCounterFunc("odim_redis_pool_stale_connections_total",
+		"Number of stale connections removed from the Redis connection pool", labels(),
+		stat(func(s *redis.PoolStats) uint32 { return s.StaleConns }))
+}
+
+// getPoolStats returns the statistics of the connection pool,
+// nil is returned when the pool is not yet created
+func getPoolStats(db DbType, write bool) *redis.PoolStats {
+	connPool := inMemDBConnPool
+	if db == OnDisk {
+		connPool = onDiskDBConnPool
+	}
+	if connPool == nil {
+		return nil
+	}
+	client := connPool.ReadPool
+	if write {
+		client = connPool.WritePool
+	}
+	if client == nil {
+		return nil
+	}
+	return client.PoolStats()
+}
@@ -33,6 +33,7 @@ import (
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	"github.com/ODIM-Project/ODIM/lib-utilities/tracing"
 	redis "github.com/go-redis/redis"
 )
 
@@ -620,6 +621,8 @@ func (p *ConnPool) GetAllMatchingDetails(table, pattern string) ([]string, *erro
 
 // Transaction is to do a atomic operation using optimistic lock
 func (p *ConnPool) Transaction(ctx context.Context, key string, cb func(context.Context, string) error) *errors.Error {
+	ctx, span := tracing.StartDBSpan(ctx, "Transaction", key)
+	defer span.End()
 	err := p.WritePool.Watch(func(tx *redis.Tx) error {
 		_, err := tx.Get(key).Result()
 		if err != nil && err != redis.Nil {
@@ -1042,6 +1045,68 @@ func (p *ConnPool) RemoveMemberFromSet(key string, member string) *errors.Error
 	return nil
 }
 
+/*
+AddMembersToSortedSet adds the members to the redis sorted set with their scores
+Following are the input parameters for adding members to redis sorted set:
+1. key - redis sorted set name
+2. members - map of member to its score
+*/
+func (p *ConnPool) AddMembersToSortedSet(key string, members map[string]float64) *errors.Error {
+	if len(members) == 0 {
+		return nil
+	}
+	var entries = make([]redis.Z, 0, len(members))
+	for member, score := range members {
+		entries = append(entries, redis.Z{Score: score, Member: member})
+	}
+	createErr := p.WritePool.ZAdd(key, entries...).Err()
+	if createErr != nil {
+		if errs, aye := isDbConnectError(createErr); aye {
+			return errs
+		}
+		return errors.PackError(errors.DBUpdateFailed, createErr.Error())
+	}
+	return nil
+}
+
+/*
+GetSortedSetMembersByScore gets the members of a redis sorted set whose score
+lies between min and max, both included, ordered by the score
+Following are the input parameters to get members from redis sorted set:
+1. key - redis sorted set name
+2. min - minimum score, "-inf" for no lower limit
+3. max - maximum score, "+inf" for no upper limit
+*/
+func (p *ConnPool) GetSortedSetMembersByScore(key, min, max string) ([]string, *errors.Error) {
+	members, err := p.ReadPool.ZRangeByScore(key, redis.ZRangeBy{Min: min, Max: max}).Result()
+	if err != nil {
+		if errs, aye := isDbConnectError(err); aye {
+			return members, errs
+		}
+		return members, errors.PackError(errors.DBKeyFetchFailed, errorCollectingData, err)
+	}
+	return members, nil
+}
+
+/*
+RemoveSortedSetMembersByScore removes the members of a redis sorted set whose
+score lies between min and max, both included
+Following are the input parameters for removing members from redis sorted set:
+1. key - redis sorted set name
+2. min - minimum score, "-inf" for no lower limit
+3. max - maximum score, "+inf" for no upper limit
+*/
+func (p *ConnPool) RemoveSortedSetMembersByScore(key, min, max string) *errors.Error {
+	deleteErr := p.WritePool.ZRemRangeByScore(key, min, max).Err()
+	if deleteErr != nil {
+		if errs, aye := isDbConnectError(deleteErr); aye {
+			return errs
+		}
+		return errors.PackError(errors.DBUpdateFailed, deleteErr.Error())
+	}
+	return nil
+}
+
 // GetString is used to retrive index values of type string
 /* Inputs:
 1. index is the index name to search with
@@ -1568,6 +1633,46 @@ func (p *ConnPool) SetExpire(table, key string, data interface{}, expiretime int
 	return nil
 }
 
+// UpsertWithExpiry creates or replaces the data of the key and sets the key
+// to timeout after the given number of seconds
+func (p *ConnPool) UpsertWithExpiry(table, key string, data interface{}, expiretime int) *errors.Error {
+	saveID := table + ":" + key
+
+	jsondata, err := json.Marshal(data)
+	if err != nil {
+		return errors.PackError(errors.UndefinedErrorType, writeToDBJSONErrMsg+err.Error())
+	}
+	if err := p.WritePool.Set(saveID, jsondata, time.Duration(expiretime)*time.Second).Err(); err != nil {
+		if errs, aye := isDbConnectError(err); aye {
+			return errs
+		}
+		return errors.PackError(errors.UndefinedErrorType, writeToDBErrMsg+err.Error())
+	}
+	return nil
+}
+
+// CreateWithExpiry makes an entry into the database only if the key does not exist
+// yet and sets the key to timeout after the given number of seconds
+func (p *ConnPool) CreateWithExpiry(table, key string, data interface{}, expiretime int) *errors.Error {
+	saveID := table + ":" + key
+
+	jsondata, err := json.Marshal(data)
+	if err != nil {
+		return errors.PackError(errors.UndefinedErrorType, writeToDBJSONErrMsg+err.Error())
+	}
+	value, createErr := p.WritePool.SetNX(saveID, jsondata, time.Duration(expiretime)*time.Second).Result()
+	if createErr != nil {
+		if errs, aye := isDbConnectError(createErr); aye {
+			return errs
+		}
+		return errors.PackError(errors.UndefinedErrorType, writeToDBErrMsg+createErr.Error())
+	}
+	if !value {
+		return errors.PackError(errors.DBKeyAlreadyExist, errMsg, key, " already exists")
+	}
+	return nil
+}
+
 // TTL is for getting singular data
 // TTL takes "key" string as input which acts as a unique ID to fetch time left
 func (p *ConnPool) TTL(table, key string) (int, *errors.Error) {
@@ -1412,6 +1412,58 @@ func TestSetExpire(t *testing.T) {
 
 }
 
+func TestUpsertWithExpiry(t *testing.T) {
+
+	c, err := MockDBConnection(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		c.Delete("table", "key")
+	}()
+
+	if cerr := c.UpsertWithExpiry("table", "key", "sample", 10); cerr != nil {
+		t.Errorf(dataEntryFailed, cerr.Error())
+	}
+	if cerr := c.UpsertWithExpiry("table", "key", "updated", 10); cerr != nil {
+		t.Errorf(dataEntryFailed, cerr.Error())
+	}
+	data, rerr := c.Read("table", "key")
+	if rerr != nil || data != "\"updated\"" {
+		t.Errorf("UpsertWithExpiry() stored %v, %v", data, rerr)
+	}
+	if ttl, terr := c.TTL("table", "key"); terr != nil || ttl <= 0 {
+		t.Errorf("UpsertWithExpiry() did not set the expiry: %v, %v", ttl, terr)
+	}
+}
+
+func TestCreateWithExpiry(t *testing.T) {
+
+	c, err := MockDBConnection(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		c.Delete("table", "key")
+	}()
+
+	if cerr := c.CreateWithExpiry("table", "key", "sample", 10); cerr != nil {
+		t.Errorf(dataEntryFailed, cerr.Error())
+	}
+	if cerr := c.CreateWithExpiry("table", "key", "updated", 10); cerr == nil || cerr.ErrNo() != errors.DBKeyAlreadyExist {
+		t.Errorf("CreateWithExpiry() on an existing key = %v, want DBKeyAlreadyExist", cerr)
+	}
+	data, rerr := c.Read("table", "key")
+	if rerr != nil || data != "\"sample\"" {
+		t.Errorf("CreateWithExpiry() stored %v, %v", data, rerr)
+	}
+	if ttl, terr := c.TTL("table", "key"); terr != nil || ttl <= 0 {
+		t.Errorf("CreateWithExpiry() did not set the expiry: %v, %v", ttl, terr)
+	}
+}
+
 func TestSetExpireInvalidData(t *testing.T) {
 
 	c, err := MockDBConnection(t)
@@ -1851,3 +1903,40 @@ func TestConnPoolRemoveMemberFromSet(t *testing.T) {
 		})
 	}
 }
+
+func TestConnPoolSortedSetMembersByScore(t *testing.T) {
+	c, err := MockDBConnection(t)
+	if err != nil {
+		t.Fatal(mockDBConnection, err)
+	}
+
+	defer func() {
+		if derr := c.CleanUpDB(); derr != nil {
+			t.Errorf(dataCleanUpfailed, derr.Error())
+		}
+	}()
+
+	members := map[string]float64{"sample1": 10, "sample2": 20, "sample3": 30}
+	if err := c.AddMembersToSortedSet("MetricHistory:series", members); err != nil {
+		t.Fatal("error while adding members to sorted set: ", err)
+	}
+
+	got, gerr := c.GetSortedSetMembersByScore("MetricHistory:series", "15", "+inf")
+	if gerr != nil {
+		t.Fatal("error while getting members of sorted set: ", gerr)
+	}
+	if !reflect.DeepEqual(got, []string{"sample2", "sample3"}) {
+		t.Errorf("ConnPool.GetSortedSetMembersByScore() got = %v", got)
+	}
+
+	if err := c.RemoveSortedSetMembersByScore("MetricHistory:series", "-inf", "20"); err != nil {
+		t.Fatal("error while removing members of sorted set: ", err)
+	}
+	got, gerr = c.GetSortedSetMembersByScore("MetricHistory:series", "-inf", "+inf")
+	if gerr != nil {
+		t.Fatal("error while getting members of sorted set: ", gerr)
+	}
+	if !reflect.DeepEqual(got, []string{"sample3"}) {
+		t.Errorf("ConnPool.RemoveSortedSetMembersByScore() left = %v", got)
+	}
+}
@@ -12,7 +12,7 @@
 //License for the specific language governing permissions and limitations
 // under the License.
 
-// Package config ...
+// Package config holds the run time configuration shared by all the plugins built on lib-plugin-sdk
 package config
 
 import (
@@ -29,20 +29,28 @@ import (
 // Data will have the configuration data from config file
 var Data configModel
 
-// configModel is for holding all the run time configurations for the svc-redfish-plugin
+// DefaultPluginID is the plugin ID used when PluginConf.ID is not configured.
+// Vendor plugins set it before loading the configuration.
+var DefaultPluginID = "GRF"
+
+// configModel is for holding all the run time configurations for the plugin
 type configModel struct {
-	FirmwareVersion         string            `json:"FirmwareVersion"` //FirmwareVersion of plugin of the plugin
-	RootServiceUUID         string            `json:"RootServiceUUID"`
-	SessionTimeoutInMinutes float64           `json:"SessionTimeoutInMinutes"` //plugin token time out in minutes
-	PluginConf              *PluginConf       `json:"PluginConf"`
-	LoadBalancerConf        *LoadBalancerConf `json:"LoadBalancerConf"`
-	EventConf               *EventConf        `json:"EventConf"`
-	MessageBusConf          *MessageBusConf   `json:"MessageBusConf"`
-	KeyCertConf             *KeyCertConf      `json:"KeyCertConf"`
-	URLTranslation          *URLTranslation   `json:"URLTranslation"`
-	TLSConf                 *TLSConf          `json:"TLSConf"`
-	LogLevel                log.Level         `json:"LogLevel"`
-	LogFormat               lgr.LogFormat     `json:"LogFormat"`
+	FirmwareVersion         string             `json:"FirmwareVersion"` //FirmwareVersion of plugin of the plugin
+	RootServiceUUID         string             `json:"RootServiceUUID"`
+	SessionTimeoutInMinutes float64            `json:"SessionTimeoutInMinutes"` //plugin token time out in minutes
+	PluginConf              *PluginConf        `json:"PluginConf"`
+	LoadBalancerConf        *LoadBalancerConf  `json:"LoadBalancerConf"`
+	EventConf               *EventConf         `json:"EventConf"`
+	MessageBusConf          *MessageBusConf    `json:"MessageBusConf"`
+	KeyCertConf             *KeyCertConf       `json:"KeyCertConf"`
+	URLTranslation          *URLTranslation    `json:"URLTranslation"`
+	TLSConf                 *TLSConf           `json:"TLSConf"`
+	DeviceSessionConf       *DeviceSessionConf `json:"DeviceSessionConf"`
+	LogLevel                log.Level          `json:"LogLevel"`
+	LogFormat               lgr.LogFormat      `json:"LogFormat"`
+
+	// SecretsProviderConf is the provider of the secret:// references of the configuration
+	SecretsProviderConf *lutilconf.SecretsProviderConf `json:"SecretsProviderConf"`
 }
 
 // PluginConf is for holding all the plugin related configurations
@@ -65,6 +73,9 @@ type EventConf struct {
 	DestURI      string `json:"DestinationURI"`
 	ListenerHost string `json:"ListenerHost"`
 	ListenerPort string `json:"ListenerPort"`
+	// PollingIntervalInSeconds is the interval at which the devices in
+	// Polling event delivery mode are read for their events
+	PollingIntervalInSeconds int `json:"PollingIntervalInSeconds"`
 }
 
 // MessageBusConf will have configuration data of MessageBusConf
@@ -101,6 +112,23 @@ type TLSConf struct {
 	PreferredCipherSuites []string `json:"PreferredCipherSuites"`
 }
 
+// DeviceSessionConf holds the connection and session reuse configurations
+// used for the communication with the devices
+type DeviceSessionConf struct {
+	AuthType                 string  `json:"AuthType"`                 // Session to reuse a BMC session, BasicAuth to authenticate every request
+	SessionTimeoutInMinutes  float64 `json:"SessionTimeoutInMinutes"`  // time after which an unused BMC session is created again
+	MaxSessionsPerDevice     int     `json:"MaxSessionsPerDevice"`     // number of BMC sessions the plugin keeps open with a device
+	MaxConnsPerDevice        int     `json:"MaxConnsPerDevice"`        // number of concurrent connections with a device
+	IdleConnTimeoutInSeconds int     `json:"IdleConnTimeoutInSeconds"` // time after which an idle connection with a device is closed
+}
+
+const (
+	// SessionAuth is the DeviceSessionConf.AuthType for reusing a BMC session
+	SessionAuth = "Session"
+	// BasicAuth is the DeviceSessionConf.AuthType for authenticating every request
+	BasicAuth = "BasicAuth"
+)
+
 // SetConfiguration will extract the config data from file
 func SetConfiguration() error {
 	configFilePath := os.Getenv("PLUGIN_CONFIG_FILE_PATH")
@@ -111,6 +139,8 @@ func SetConfiguration() error {
 	if err != nil {
 		return fmt.Errorf("Failed to read the config file, got: %v", err)
 	}
+	// LogLevel is optional, a config file without it logs at info level
+	Data.LogLevel = log.InfoLevel
 	err = json.Unmarshal(configData, &Data)
 	if err != nil {
 		return fmt.Errorf("Failed to unmarshal config data, got: %v", err)
@@ -147,9 +177,15 @@ func ValidateConfiguration() error {
 	if err := checkCertsAndKeysConf(); err != nil {
 		return err
 	}
+	if err := resolveSecrets(); err != nil {
+		return err
+	}
 	if err := checkTLSConf(); err != nil {
 		return err
 	}
+	if err := checkDeviceSessionConf(); err != nil {
+		return err
+	}
 	checkLBConf()
 	checkURLTranslationConf()
 	return nil
@@ -161,7 +197,7 @@ func checkPluginConf() error {
 	}
 	if Data.PluginConf.ID == "" {
 		log.Warn("No value set for plugin ID, setting default value")
-		Data.PluginConf.ID = "GRF"
+		Data.PluginConf.ID = DefaultPluginID
 	}
 	if Data.PluginConf.Host == "" {
 		return fmt.Errorf("no value set for plugin Host")
@@ -208,6 +244,10 @@ func checkEventConf() error {
 	if Data.EventConf.ListenerPort == "" {
 		return fmt.Errorf("no value set for ListenerPort")
 	}
+	if Data.EventConf.PollingIntervalInSeconds <= 0 {
+		log.Warn("No value set for PollingIntervalInSeconds, setting default value")
+		Data.EventConf.PollingIntervalInSeconds = 60
+	}
 	return nil
 }
 
@@ -311,3 +351,31 @@ func checkTLSConf() error {
 
 	return nil
 }
+
+// Check or apply default values for the sessions and connections with the devices
+func checkDeviceSessionConf() error {
+	if Data.DeviceSessionConf == nil {
+		log.Warn("DeviceSessionConf not provided, setting default value")
+		Data.DeviceSessionConf = &DeviceSessionConf{}
+	}
+	switch Data.DeviceSessionConf.AuthType {
+	case "":
+		Data.DeviceSessionConf.AuthType = SessionAuth
+	case SessionAuth, BasicAuth:
+	default:
+		return fmt.Errorf("error: invalid value configured for AuthType: %s", Data.DeviceSessionConf.AuthType)
+	}
+	if Data.DeviceSessionConf.SessionTimeoutInMinutes <= 0 {
+		Data.DeviceSessionConf.SessionTimeoutInMinutes = 25
+	}
+	if Data.DeviceSessionConf.MaxSessionsPerDevice <= 0 {
+		Data.DeviceSessionConf.MaxSessionsPerDevice = 2
+	}
+	if Data.DeviceSessionConf.MaxConnsPerDevice <= 0 {
+		Data.DeviceSessionConf.MaxConnsPerDevice = 4
+	}
+	if Data.DeviceSessionConf.IdleConnTimeoutInSeconds <= 0 {
+		Data.DeviceSessionConf.IdleConnTimeoutInSeconds = 30
+	}
+	return nil
+}
@@ -20,6 +20,8 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	lutilconf "github.com/ODIM-Project/ODIM/lib-utilities/config"
 )
 
 const (
@@ -733,3 +735,128 @@ func Test_checkMessageBusConf(t *testing.T) {
 		})
 	}
 }
+
+func Test_checkDeviceSessionConf(t *testing.T) {
+	tests := []struct {
+		name      string
+		setConfig func()
+		wantErr   bool
+		wantAuth  string
+	}{
+		{
+			name: "Positive case ",
+			setConfig: func() {
+				SetUpMockConfig(t)
+			},
+			wantErr:  false,
+			wantAuth: BasicAuth,
+		},
+		{
+			name: "Positive case - Nil DeviceSessionConf ",
+			setConfig: func() {
+				SetUpMockConfig(t)
+				Data.DeviceSessionConf = nil
+			},
+			wantErr:  false,
+			wantAuth: SessionAuth,
+		},
+		{
+			name: "Negative case - invalid AuthType ",
+			setConfig: func() {
+				SetUpMockConfig(t)
+				Data.DeviceSessionConf.AuthType = "Digest"
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setConfig()
+			err := checkDeviceSessionConf()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkDeviceSessionConf() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				if Data.DeviceSessionConf.AuthType != tt.wantAuth {
+					t.Errorf("checkDeviceSessionConf() AuthType = %v, want %v", Data.DeviceSessionConf.AuthType, tt.wantAuth)
+				}
+				if Data.DeviceSessionConf.MaxSessionsPerDevice <= 0 || Data.DeviceSessionConf.MaxConnsPerDevice <= 0 {
+					t.Errorf("checkDeviceSessionConf() did not set the default limits")
+				}
+			}
+		})
+	}
+}
+
+func Test_resolveSecrets(t *testing.T) {
+	secretsDir := t.TempDir()
+	if err := ioutil.WriteFile(filepath.Join(secretsDir, "password"), []byte("hashedPassword\n"), 0600); err != nil {
+		t.Fatalf("error while creating the secret file: %v", err)
+	}
+	tests := []struct {
+		name         string
+		setConfig    func()
+		wantErr      bool
+		wantPassword string
+	}{
+		{
+			name: "Positive case - Password without reference ",
+			setConfig: func() {
+				SetUpMockConfig(t)
+				Data.PluginConf.Password = "hashedPassword"
+			},
+			wantErr:      false,
+			wantPassword: "hashedPassword",
+		},
+		{
+			name: "Positive case - Password from File provider ",
+			setConfig: func() {
+				SetUpMockConfig(t)
+				Data.PluginConf.Password = "secret://password"
+				Data.SecretsProviderConf = &lutilconf.SecretsProviderConf{Type: lutilconf.SecretsProviderFile, Directory: secretsDir}
+			},
+			wantErr:      false,
+			wantPassword: "hashedPassword",
+		},
+		{
+			name: "Negative case - unknown secret ",
+			setConfig: func() {
+				SetUpMockConfig(t)
+				Data.PluginConf.Password = "secret://unknown"
+				Data.SecretsProviderConf = &lutilconf.SecretsProviderConf{Type: lutilconf.SecretsProviderFile, Directory: secretsDir}
+			},
+			wantErr: true,
+		},
+		{
+			name: "Negative case - nil SecretsProviderConf ",
+			setConfig: func() {
+				SetUpMockConfig(t)
+				Data.PluginConf.Password = "secret://password"
+				Data.SecretsProviderConf = nil
+			},
+			wantErr: true,
+		},
+		{
+			name: "Negative case - Database provider ",
+			setConfig: func() {
+				SetUpMockConfig(t)
+				Data.PluginConf.Password = "secret://password"
+				Data.SecretsProviderConf = &lutilconf.SecretsProviderConf{Type: lutilconf.SecretsProviderDatabase}
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setConfig()
+			err := resolveSecrets()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("resolveSecrets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && Data.PluginConf.Password != tt.wantPassword {
+				t.Errorf("resolveSecrets() Password = %v, want %v", Data.PluginConf.Password, tt.wantPassword)
+			}
+		})
+	}
+	Data.SecretsProviderConf = nil
+}
@@ -172,7 +172,7 @@ func SetUpMockConfig(t *testing.T) error {
 	Data.FirmwareVersion = "1.0"
 	Data.SessionTimeoutInMinutes = 30
 	Data.PluginConf = &PluginConf{
-		ID:       "DELL",
+		ID:       "GRF",
 		Host:     localhost,
 		Port:     "45005",
 		UserName: "admin",
@@ -183,9 +183,10 @@ func SetUpMockConfig(t *testing.T) error {
 		Port: "45006",
 	}
 	Data.EventConf = &EventConf{
-		DestURI:      "/redfishEventListener",
-		ListenerHost: localhost,
-		ListenerPort: "45006",
+		DestURI:                  "/redfishEventListener",
+		ListenerHost:             localhost,
+		ListenerPort:             "45006",
+		PollingIntervalInSeconds: 60,
 	}
 	Data.MessageBusConf = &MessageBusConf{
 		EmbType:  "Kafka",
@@ -216,6 +217,13 @@ func SetUpMockConfig(t *testing.T) error {
 			"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256",
 		},
 	}
+	Data.DeviceSessionConf = &DeviceSessionConf{
+		AuthType:                 BasicAuth,
+		SessionTimeoutInMinutes:  25,
+		MaxSessionsPerDevice:     2,
+		MaxConnsPerDevice:        4,
+		IdleConnTimeoutInSeconds: 30,
+	}
 	warningList := &lutilconf.WarningList{}
 	lutilconf.SetVerifyPeer(Data.TLSConf.VerifyPeer)
 	lutilconf.SetTLSMinVersion(Data.TLSConf.MinVersion, warningList)
@@ -0,0 +1,103 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	lutilconf "github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/secrets"
+)
+
+const vaultRequestTimeout = 10 * time.Second
+
+// resolveSecrets replaces the secret:// references of the configuration with the secrets
+// read from the provider of SecretsProviderConf. The plugin reads them once when it starts.
+func resolveSecrets() error {
+	if !secrets.IsReference([]byte(Data.PluginConf.Password)) {
+		return nil
+	}
+	provider, err := newSecretsProvider(Data.SecretsProviderConf)
+	if err != nil {
+		return err
+	}
+	password, err := secrets.Resolve(provider, []byte(Data.PluginConf.Password))
+	if err != nil {
+		return fmt.Errorf("unable to resolve the plugin Password: %v", err)
+	}
+	Data.PluginConf.Password = string(password)
+	return nil
+}
+
+// newSecretsProvider returns the provider of the secrets of the plugin configuration.
+// The Database provider is not supported, the plugins do not have access to the ODIM database.
+func newSecretsProvider(conf *lutilconf.SecretsProviderConf) (secrets.Provider, error) {
+	if conf == nil {
+		return nil, fmt.Errorf("no value found for SecretsProviderConf to resolve the secret references")
+	}
+	switch conf.Type {
+	case lutilconf.SecretsProviderFile:
+		if conf.Directory == "" {
+			return nil, fmt.Errorf("no value set for Directory of the File secrets provider")
+		}
+		return secrets.FileProvider{Directory: conf.Directory}, nil
+	case lutilconf.SecretsProviderVault:
+		if conf.VaultAddress == "" {
+			return nil, fmt.Errorf("no value set for VaultAddress of the Vault secrets provider")
+		}
+		if conf.VaultTokenFilePath == "" {
+			return nil, fmt.Errorf("no value set for VaultTokenFilePath of the Vault secrets provider")
+		}
+		token, err := ioutil.ReadFile(conf.VaultTokenFilePath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the Vault token from %s: %v", conf.VaultTokenFilePath, err)
+		}
+		client, err := newVaultClient(conf.VaultAddress)
+		if err != nil {
+			return nil, err
+		}
+		return secrets.VaultProvider{
+			Address:   conf.VaultAddress,
+			Token:     strings.TrimSpace(string(token)),
+			MountPath: conf.VaultMountPath,
+			Client:    client,
+		}, nil
+	}
+	return nil, fmt.Errorf("invalid secrets provider type %s, expected %s or %s",
+		conf.Type, lutilconf.SecretsProviderFile, lutilconf.SecretsProviderVault)
+}
+
+// newVaultClient returns the client contacting Vault, the certificate of Vault is
+// verified with the root CA certificate of the plugin
+func newVaultClient(address string) (*http.Client, error) {
+	client := &http.Client{Timeout: vaultRequestTimeout}
+	if !strings.HasPrefix(address, "https://") {
+		return client, nil
+	}
+	rootCAs := x509.NewCertPool()
+	if !rootCAs.AppendCertsFromPEM(Data.KeyCertConf.RootCACertificate) {
+		return nil, fmt.Errorf("unable to load the root CA certificate for contacting vault")
+	}
+	client.Transport = &http.Transport{
+		TLSClientConfig: &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12},
+	}
+	return client, nil
+}
@@ -12,19 +12,22 @@
 //License for the specific language governing permissions and limitations
 // under the License.
 
-// Package dphandler ...
-package dphandler
+// Package sdkhandler ...
+package sdkhandler
 
 import (
+	"context"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"time"
 
+	pluginConfig "github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkmodel"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkresponse"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-dell/config"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dpmodel"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dpresponse"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dputilities"
 	iris "github.com/kataras/iris/v12"
 )
 
@@ -43,6 +46,7 @@ func TokenValidation(token string) bool {
 	return flag
 }
 
+// IoUtilReadAll is used to read the request and response bodies in the handlers
 var IoUtilReadAll = ioutil.ReadAll
 
 // Validate does Basic authentication with device and returns UUID of device in response
@@ -67,7 +71,7 @@ func Validate(ctx iris.Context) {
 		}
 	}
 	l.LogWithFields(ctxt).Debugf("incoming request received for the URI %s method %s", ctx.Request().RequestURI, ctx.Request().Method)
-	var deviceDetails dpmodel.Device
+	var deviceDetails sdkmodel.Device
 	//Get device details from request
 	err := ctx.ReadJSON(&deviceDetails)
 	if err != nil {
@@ -76,36 +80,49 @@ func Validate(ctx iris.Context) {
 		ctx.WriteString("Error: bad request.")
 		return
 	}
-	device := &dputilities.RedfishDevice{
+	// the certificate of the device is verified before the credentials are sent to it
+	trust, err := establishCertificateTrust(ctxt, deviceDetails)
+	if err != nil {
+		l.LogWithFields(ctxt).Error("error while setting the certificate trust of the device " + err.Error())
+		sdkresponse.SetErrorResponse(ctx, err.Error(), http.StatusBadRequest)
+		return
+	}
+	device := &sdkutilities.RedfishDevice{
 		Host:     deviceDetails.Host,
 		Username: deviceDetails.Username,
 		Password: string(deviceDetails.Password),
 	}
-	redfishClient, err := dputilities.GetRedfishClient()
+	redfishClient, err := sdkutilities.GetRedfishClient()
 	if err != nil {
 		l.LogWithFields(ctxt).Error("error while trying to create the redfish client to connect to device " + err.Error())
-		dpresponse.SetErrorResponse(ctx, err.Error(), http.StatusInternalServerError)
+		sdkresponse.SetErrorResponse(ctx, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	//Get ServiceRoot of the device
 	err = redfishClient.GetRootService(device)
 	if err != nil {
 		l.LogWithFields(ctxt).Error("error while trying to get RootService data of the device " + err.Error())
-		dpresponse.SetErrorResponse(ctx, err.Error(), http.StatusBadRequest)
+		sdkresponse.SetErrorResponse(ctx, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if device.RootNode.Systems == nil {
+		errMsg := "ServiceRoot of the device " + device.Host + " does not have Systems"
+		l.LogWithFields(ctxt).Error(errMsg)
+		sdkresponse.SetErrorResponse(ctx, errMsg, http.StatusBadRequest)
 		return
 	}
 	//Doing Get on device using basic Authentication
 	resp, err := redfishClient.BasicAuthWithDevice(ctxt, device, device.RootNode.Systems.Oid)
 	if err != nil {
 		l.LogWithFields(ctxt).Error("error while trying to authenticate device with basic auth " + err.Error())
-		dpresponse.SetErrorResponse(ctx, err.Error(), http.StatusBadRequest)
+		sdkresponse.SetErrorResponse(ctx, err.Error(), http.StatusBadRequest)
 		return
 	}
 
 	body, err := IoUtilReadAll(resp.Body)
 	if err != nil {
 		l.LogWithFields(ctxt).Error("error while trying to read the response body from the device" + err.Error())
-		dpresponse.SetErrorResponse(ctx, err.Error(), http.StatusBadRequest)
+		sdkresponse.SetErrorResponse(ctx, err.Error(), http.StatusBadRequest)
 		return
 	}
 	defer resp.Body.Close()
@@ -121,11 +138,35 @@ func Validate(ctx iris.Context) {
 		l.LogWithFields(ctxt).Warn("Could not retrieve ComputerSystems for " + device.Host + ": " + string(body))
 	}
 
-	response := dpresponse.Device{
+	response := sdkresponse.Device{
 		ServerIP:   device.Host,
 		Username:   device.Username,
 		DeviceUUID: device.RootNode.UUID,
+		// odimra stores the trust with the device and sends it back at the plugin start up
+		CertificateTrust: trust,
 	}
 	ctx.StatusCode(resp.StatusCode)
 	ctx.JSON(response)
 }
+
+// establishCertificateTrust sets the certificate trust requested for the device and returns it.
+// The certificate of a device trusted on first use is captured from the device when it is not
+// given. The trust of the device is kept when the request does not have one.
+func establishCertificateTrust(ctx context.Context, deviceDetails sdkmodel.Device) (*common.CertificateTrust, error) {
+	if deviceDetails.CertificateTrust == nil {
+		return nil, nil
+	}
+	trust := *deviceDetails.CertificateTrust
+	if trust.Mode == common.CertificateTrustModeTrustOnFirstUse && trust.Certificate == "" {
+		cert, err := sdkutilities.CaptureCertificate(ctx, deviceDetails.Host)
+		if err != nil {
+			return nil, fmt.Errorf("unable to capture the certificate of the device %s: %v", deviceDetails.Host, err)
+		}
+		trust = common.PinCertificate(cert)
+		l.LogWithFields(ctx).Info("pinned the certificate of the device " + deviceDetails.Host + " with fingerprint " + trust.Fingerprint)
+	}
+	if err := sdkutilities.SetCertificateTrust(deviceDetails.Host, trust); err != nil {
+		return nil, err
+	}
+	return &trust, nil
+}
@@ -11,7 +11,7 @@
 // WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
 // License for the specific language governing permissions and limitations
 // under the License.
-package dphandler
+package sdkhandler
 
 import (
 	"encoding/json"
@@ -22,8 +22,8 @@ import (
 	"testing"
 	"time"
 
-	"github.com/ODIM-Project/ODIM/plugin-dell/config"
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-dell/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	pluginConfig "github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
 	iris "github.com/kataras/iris/v12"
 	"github.com/kataras/iris/v12/httptest"
 )
@@ -0,0 +1,117 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package sdkhandler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkmodel"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	iris "github.com/kataras/iris/v12"
+)
+
+// aggregationSourcesURI is the origin of the events about the trust in the certificate of a
+// device, odimra sets the aggregation source of the device in place of it
+const aggregationSourcesURI = "/redfish/v1/AggregationService/AggregationSources"
+
+// replaceCertificateRequest holds the properties of the ReplaceCertificate action used by the plugin
+type replaceCertificateRequest struct {
+	CertificateString string `json:"CertificateString"`
+}
+
+// ReplaceCertificate sends the ReplaceCertificate action of the CertificateService to the device.
+// The new certificate of a device trusted on first use is pinned once the device accepted it,
+// the previous certificate is accepted until the device presents the new one.
+func ReplaceCertificate(ctx iris.Context) {
+	ctxt := ctx.Request().Context()
+	token := ctx.GetHeader("X-Auth-Token")
+	if token != "" {
+		flag := TokenValidation(token)
+		if !flag {
+			l.LogWithFields(ctxt).Error("Invalid/Expired X-Auth-Token")
+			ctx.StatusCode(http.StatusUnauthorized)
+			ctx.WriteString("Invalid/Expired X-Auth-Token")
+			return
+		}
+	}
+	var deviceDetails sdkmodel.Device
+	if err := ctx.ReadJSON(&deviceDetails); err != nil {
+		l.LogWithFields(ctxt).Error("While trying to collect data from request, got: " + err.Error())
+		ctx.StatusCode(http.StatusBadRequest)
+		ctx.WriteString("Error: bad request.")
+		return
+	}
+	var request replaceCertificateRequest
+	if err := json.Unmarshal(deviceDetails.PostBody, &request); err != nil {
+		l.LogWithFields(ctxt).Error("While trying to unmarshal the replace certificate request, got: " + err.Error())
+		ctx.StatusCode(http.StatusBadRequest)
+		ctx.WriteString("Error: bad request.")
+		return
+	}
+	uri := TranslateToSouthBoundURL(ctx.Request().RequestURI)
+	device := &sdkutilities.RedfishDevice{
+		Host:     deviceDetails.Host,
+		Username: deviceDetails.Username,
+		Password: string(deviceDetails.Password),
+		PostBody: []byte(TranslateToSouthBoundURL(string(deviceDetails.PostBody))),
+	}
+	statusCode, _, body, err := QueryDevice(ctxt, uri, device, http.MethodPost)
+	if err != nil {
+		ctx.StatusCode(statusCode)
+		ctx.WriteString(err.Error())
+		return
+	}
+	if statusCode < http.StatusMultipleChoices {
+		renewPinnedCertificate(ctxt, device.Host, request.CertificateString)
+	}
+	ctx.StatusCode(statusCode)
+	ctx.Write([]byte(TranslateToNorthBoundURL(string(body))))
+}
+
+// renewPinnedCertificate pins the certificate the device was given, when the device is trusted on first use
+func renewPinnedCertificate(ctx context.Context, host, certificate string) {
+	trust, exist := sdkutilities.GetCertificateTrust(host)
+	if !exist || trust.Mode != common.CertificateTrustModeTrustOnFirstUse {
+		return
+	}
+	certs, err := common.ParseCertificates(certificate)
+	if err != nil {
+		l.LogWithFields(ctx).Warn("unable to pin the new certificate of the device " + host + ": " + err.Error())
+		return
+	}
+	trust = common.PinCertificate(certs[0])
+	if err := sdkutilities.RenewCertificateTrust(host, trust); err != nil {
+		l.LogWithFields(ctx).Warn("unable to pin the new certificate of the device " + host + ": " + err.Error())
+		return
+	}
+	l.LogWithFields(ctx).Info("pinned the new certificate of the device " + host + " with fingerprint " + trust.Fingerprint)
+}
+
+// PublishCertificateChanged raises the event of a device presenting a certificate other than
+// the one pinned for it, the plugin does not send any request to the device meanwhile
+func PublishCertificateChanged(host, pinned, presented string) {
+	ctx := context.TODO()
+	message := fmt.Sprintf("The certificate of the BMC %s with fingerprint %s does not match the pinned certificate with fingerprint %s.",
+		host, presented, pinned)
+	l.LogWithFields(ctx).Warn(message)
+	event := newPolledEvent(aggregationSourcesURI, common.BMCCertificateChangedMessageID, "Critical", message,
+		[]string{host, pinned, presented})
+	publishDeviceEvents(ctx, host, []common.Event{event})
+}
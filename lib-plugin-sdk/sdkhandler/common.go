@@ -0,0 +1,71 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package sdkhandler ...
+package sdkhandler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	pluginConfig "github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+)
+
+// QueryDevice is for querying a redfish server
+func QueryDevice(ctx context.Context, uri string, device *sdkutilities.RedfishDevice, method string) (int, http.Header, []byte, error) {
+	redfishClient, err := sdkutilities.GetRedfishClient()
+	if err != nil {
+		errMsg := "While trying to create the redfish client, got:" + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return http.StatusInternalServerError, nil, nil, fmt.Errorf(errMsg)
+	}
+	resp, err := redfishClient.DeviceCall(device, uri, method)
+	if err != nil {
+		l.LogWithFields(ctx).Error(err.Error())
+		if resp == nil {
+			return http.StatusBadRequest, nil, nil, err
+		}
+	}
+
+	body, err := IoUtilReadAll(resp.Body)
+	defer resp.Body.Close()
+	if err != nil {
+		errMsg := "While trying to read the response body, got: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return http.StatusInternalServerError, nil, nil, fmt.Errorf(errMsg)
+	}
+	return resp.StatusCode, resp.Header, body, nil
+}
+
+// TranslateToSouthBoundURL replaces the ODIM specific parts of the data with
+// the south bound translation configured for the plugin
+func TranslateToSouthBoundURL(data string) string {
+	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
+		data = strings.Replace(data, key, value, -1)
+	}
+	return data
+}
+
+// TranslateToNorthBoundURL replaces the device specific parts of the data with
+// the north bound translation configured for the plugin
+func TranslateToNorthBoundURL(data string) string {
+	for key, value := range pluginConfig.Data.URLTranslation.NorthBoundURL {
+		data = strings.Replace(data, key, value, -1)
+	}
+	return data
+}
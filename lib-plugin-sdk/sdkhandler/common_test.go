@@ -12,21 +12,29 @@
 //License for the specific language governing permissions and limitations
 // under the License.
 
-// Package config ...
-package config
+// Package sdkhandler ...
+package sdkhandler
 
 import (
-	"fmt"
-	"math/rand"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"net"
+	"net/http"
+	testhttp "net/http/httptest"
+	"strings"
 	"testing"
-	"time"
 
-	lutilconf "github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
 )
 
 var (
-	localhost = "127.0.0.1"
-	hostCA    = []byte(`-----BEGIN CERTIFICATE-----
+	hostCA = []byte(`-----BEGIN CERTIFICATE-----
 MIIF0TCCA7mgAwIBAgIUcK0EfHC8broyD1HWUasSSsmRjyswDQYJKoZIhvcNAQEN
 BQAwcDELMAkGA1UEBhMCVVMxCzAJBgNVBAgMAkNBMRMwEQYDVQQHDApDYWxpZm9y
 bmlhMQwwCgYDVQQKDANIUEUxGDAWBgNVBAsMD1RlbGNvIFNvbHV0aW9uczEXMBUG
@@ -150,93 +158,96 @@ K6OL+MdQwFDPKEpgahApmQSze8weECGZ78kQ+bYjB/9qMFo/oNWoPDCdfy6zVWXK
 /gMvzv7MYj7mxp2YaGYvnnp65lVk2itjzqWkEA4X5U1E4mkRBs3l4GCwpaw7rKUF
 URZohcbG2+qtaL2nLZTvuy3CSEn3blGtWd9zp2IVibhF7HdFEgV8U+Fr0ao=
 -----END RSA PRIVATE KEY-----`)
-	hostPubKey = []byte(`-----BEGIN PUBLIC KEY-----
-MIICIjANBgkqhkiG9w0BAQEFAAOCAg8AMIICCgKCAgEAuZwJSkQK5blNhxu+Fo5c
-xeUcMX9rpUcB8us4BwZCsGq5DDpY8iunwmxLjtZb/fLFiz6iAfWx1vqLOcXPYbeY
-LjF8jIqJaWuYryrV9WRctw5p7OdiYmtJK8ILqe08VIZLfs8qr0KZZP5zzoMNEntu
-Elbs3Id2HUTrj7uJbSTZVMS32oJUEqtDzNK9pDl+cQIKFiV7Do+KPyMamKeiiari
-zDKiyYsNxtBS+53Cp1MPctqKwcr85u5aN1MXZnDSVoB6HewwuPlrLzf/f1d0H7Hf
-LJzAjxA9ikizJPL90oQiA94Ra1ZcTSMKZxbcErPJoOEWqMwTAzmYfd7KDinu64vL
-NF+CEQEJlLFdMIf3zIDQKY9UI8SD9JqM1NYfzH6a8GGK3rqEUBDrLkvUbOZs8DV6
-3YzY7ZB0lDxxtV/BVoSoVqONNYFyn7/vz+HXCaFGuO5x3ddPb5Gt0ckUWV9h3AsL
-CPe1s3VnWVys/lJyLuTGRs1QdR77gXQbv29g6QfB4fIrqaOit4DguTV1xmyWjIhj
-BMaLcqDJJ1bPJiyhMl5fQvnRgyk/HbKejW7wli59OnZW9stYxrhrPTqVfJOWvnJE
-Bq4VYWoMrcs2G3NGfgwBABsMEYbm2Nn558Nv8OkXuYd2ENFndoSxRa5Crk3HZ5mE
-Fy7PCcRO16uhaVrY97PbthcCAwEAAQ==
------END PUBLIC KEY-----`)
 )
 
-// SetUpMockConfig set ups a mock ration for unit testing
-func SetUpMockConfig(t *testing.T) error {
-	Data.RootServiceUUID = "3bd1f589-117a-4cf9-89f2-da44ee8e2325"
-	Data.FirmwareVersion = "1.0"
-	Data.SessionTimeoutInMinutes = 30
-	Data.PluginConf = &PluginConf{
-		ID:       "LENOVO",
-		Host:     localhost,
-		Port:     "45009",
-		UserName: "admin",
-		Password: "O01bKrP7Tzs7YoO3YvQt4pRa2J_R6HI34ZfP4MxbqNIYAVQVt2ewGXmhjvBfzMifM7bHFccXKGmdHvj3hY44Hw==",
-	}
-	Data.LoadBalancerConf = &LoadBalancerConf{
-		Host: localhost,
-		Port: "45010",
-	}
-	Data.EventConf = &EventConf{
-		DestURI:      "/redfishEventListener",
-		ListenerHost: localhost,
-		ListenerPort: "45010",
-	}
-	Data.MessageBusConf = &MessageBusConf{
-		EmbType:  "Kafka",
-		EmbQueue: []string{"REDFISH-EVENTS-TOPIC"},
-	}
-	Data.KeyCertConf = &KeyCertConf{
-		RootCACertificate: hostCA,
-		PrivateKey:        hostPrivKey,
-		Certificate:       hostCert,
-	}
-	Data.URLTranslation = &URLTranslation{
-		NorthBoundURL: map[string]string{
-			"ODIM": "redfish",
-		},
-		SouthBoundURL: map[string]string{
-			"redfish": "ODIM",
-		},
+type mockHandlerFunc func(string, string, string, http.ResponseWriter)
+
+func startTestServer(handler mockHandlerFunc) *testhttp.Server {
+	// create a listener with the desired port.
+	l, err := net.Listen("tcp", "localhost:1234")
+	if err != nil {
+		log.Fatal(err.Error())
 	}
-	Data.TLSConf = &TLSConf{
-		VerifyPeer: true,
-		MinVersion: "TLS_1.2",
-		MaxVersion: "TLS_1.2",
-		PreferredCipherSuites: []string{
-			"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
-			"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
-			"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
-			"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
-			"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256",
-		},
+
+	ts := testhttp.NewUnstartedServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/redfish/v1" {
+				handler("", "", r.URL.Path, w)
+			} else {
+				auth := strings.SplitN(r.Header.Get("Authorization"), " ", 2)
+				payload, _ := base64.StdEncoding.DecodeString(auth[1])
+				pair := strings.SplitN(string(payload), ":", 2)
+				handler(pair[0], pair[1], r.URL.Path, w)
+			}
+		}))
+
+	// NewUnstartedServer creates a listener. Close that listener and replace
+	// with the one we created.
+	ts.Listener = l
+	tlsConfig := &tls.Config{}
+
+	cert, err := tls.X509KeyPair(hostCert, hostPrivKey)
+	if err != nil {
+		log.Fatal("Failed to load key pair: " + err.Error())
 	}
+	tlsConfig.Certificates = []tls.Certificate{cert}
+	tlsConfig.BuildNameToCertificate()
 
-	warningList := &lutilconf.WarningList{}
+	capool := x509.NewCertPool()
+	if !capool.AppendCertsFromPEM(hostCA) {
+		log.Fatal("Failed to load CA certificate")
+	}
+	tlsConfig.RootCAs = capool
+	tlsConfig.ClientCAs = capool
 
-	lutilconf.SetVerifyPeer(Data.TLSConf.VerifyPeer)
-	lutilconf.SetTLSMinVersion(Data.TLSConf.MinVersion, warningList)
-	lutilconf.SetTLSMaxVersion(Data.TLSConf.MaxVersion, warningList)
-	lutilconf.SetPreferredCipherSuites(Data.TLSConf.PreferredCipherSuites)
+	ts.TLS = tlsConfig
+	ts.Config.TLSConfig = tlsConfig
+	return ts
+}
 
-	return nil
+func mockContext() context.Context {
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, common.TransactionID, "xyz")
+	ctx = context.WithValue(ctx, common.ActionID, "001")
+	ctx = context.WithValue(ctx, common.ActionName, "xyz")
+	ctx = context.WithValue(ctx, common.ThreadID, "0")
+	ctx = context.WithValue(ctx, common.ThreadName, "xyz")
+	ctx = context.WithValue(ctx, common.ProcessName, "xyz")
+	return ctx
 }
 
-// GetPublicKey provides the public key configured in MockConfig
-func GetPublicKey() []byte {
-	return hostPubKey
+func TestQueryDevice(t *testing.T) {
+	config.SetUpMockConfig(t)
+
+	device := sdkutilities.RedfishDevice{
+		Username: "Localhost",
+		Password: "password",
+	}
+	ctxt := mockContext()
+	config.Data.KeyCertConf.RootCACertificate = nil
+	statusCode, _, _, err := QueryDevice(ctxt, "/ODIM", &device, http.MethodPost)
+	assert.NotNil(t, err, "should contain error code StatusInternalServerError")
+	assert.Equal(t, http.StatusInternalServerError, statusCode)
+
+	device = sdkutilities.RedfishDevice{
+		Username: "Localhost",
+		Password: "password",
+	}
+	config.SetUpMockConfig(t)
+	statusCode, _, _, err = QueryDevice(ctxt, "/ODIM", &device, http.MethodPost)
+	assert.NotNil(t, err, "should contain error code StatusBadRequest")
+	assert.Equal(t, http.StatusBadRequest, statusCode)
 }
 
-// GetRandomPort provides a random port between a range
-func GetRandomPort() string {
-	minPort := 45100
-	maxPort := 65535
-	rand.Seed(time.Now().UnixNano())
-	port := (rand.Intn(maxPort-minPort+1) + minPort)
-	return fmt.Sprintf("%d", port)
+func TestURLTranslation(t *testing.T) {
+	config.SetUpMockConfig(t)
+	config.Data.URLTranslation.SouthBoundURL = map[string]string{
+		"ODIM":    "redfish",
+		"Systems": "systems",
+	}
+	config.Data.URLTranslation.NorthBoundURL = map[string]string{
+		"redfish": "ODIM",
+	}
+	assert.Equal(t, "/redfish/v1/systems/1", TranslateToSouthBoundURL("/ODIM/v1/Systems/1"))
+	assert.Equal(t, "/ODIM/v1/Systems/1", TranslateToNorthBoundURL("/redfish/v1/Systems/1"))
 }
@@ -12,8 +12,8 @@
 //License for the specific language governing permissions and limitations
 // under the License.
 
-// Package dphandler ...
-package dphandler
+// Package sdkhandler ...
+package sdkhandler
 
 import (
 	"context"
@@ -24,9 +24,9 @@ import (
 
 	uuid "github.com/satori/go.uuid"
 
+	pluginConfig "github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkmodel"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-dell/config"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dpmodel"
 	iris "github.com/kataras/iris/v12"
 	"golang.org/x/crypto/sha3"
 )
@@ -43,13 +43,14 @@ var tokenSpec TokenMap
 // CreateSession is used to create session for odimra to interact with plugin
 func CreateSession(ctx iris.Context) {
 	ctxt := ctx.Request().Context()
-	var userCreds dpmodel.Users
+	var userCreds sdkmodel.Users
 	rawBodyAsBytes, err := IoUtilReadAll(ctx.Request().Body)
 	if err != nil {
 		errorMessage := "While trying to validate the credentials, got: " + err.Error()
 		l.LogWithFields(ctxt).Error(errorMessage)
 		ctx.StatusCode(http.StatusBadRequest)
 		ctx.WriteString(errorMessage)
+		return
 	}
 	err = json.Unmarshal(rawBodyAsBytes, &userCreds)
 	if err != nil {
@@ -57,6 +58,7 @@ func CreateSession(ctx iris.Context) {
 		l.LogWithFields(ctxt).Error(errorMessage)
 		ctx.StatusCode(http.StatusInternalServerError)
 		ctx.WriteString(errorMessage)
+		return
 	}
 	//Validate the credentials
 	userName := userCreds.Username
@@ -71,14 +73,19 @@ func CreateSession(ctx iris.Context) {
 	}
 	//Create token
 	token := createToken()
-	currentTime := time.Now()
-	tokenSpec.Token = token
-	tokenSpec.LastUsed = currentTime
-	tokenDetails = append(tokenDetails, tokenSpec)
+	StoreToken(token)
 	ctx.StatusCode(http.StatusCreated)
 	ctx.Header("X-Auth-Token", token)
 }
 
+// StoreToken adds the token to the list of plugin generated tokens
+// which are accepted by TokenValidation
+func StoreToken(token string) {
+	tokenSpec.Token = token
+	tokenSpec.LastUsed = time.Now()
+	tokenDetails = append(tokenDetails, tokenSpec)
+}
+
 func validate(ctxt context.Context, userName, password string) bool {
 	//var err error
 	username := pluginConfig.Data.PluginConf.UserName
@@ -12,8 +12,8 @@
 //License for the specific language governing permissions and limitations
 // under the License.
 
-// Package dphandler ...
-package dphandler
+// Package sdkhandler ...
+package sdkhandler
 
 import (
 	"encoding/json"
@@ -23,7 +23,7 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/ODIM-Project/ODIM/plugin-dell/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
 	iris "github.com/kataras/iris/v12"
 	"github.com/kataras/iris/v12/httptest"
 )
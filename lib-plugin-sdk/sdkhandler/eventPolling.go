@@ -0,0 +1,415 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package sdkhandler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/google/uuid"
+)
+
+const (
+	// pollingSubscriptionURI is the location of the event subscription of a device in Polling
+	// event delivery mode, the plugin answers for it in place of the device
+	pollingSubscriptionURI = "/redfish/v1/EventService/Subscriptions/ODIM.EventPolling"
+	// polledEventRegistry is the registry of the power state changes seen by polling,
+	// odimra updates the power state of a system on its ServerPoweredOn and ServerPoweredOff messages
+	polledEventRegistry = "EventPolling.1.0."
+	// maxPolledLogEntries is the maximum number of new entries of a log service published in a poll
+	maxPolledLogEntries = 50
+
+	systemsCollectionURI  = "/redfish/v1/Systems"
+	chassisCollectionURI  = "/redfish/v1/Chassis"
+	managersCollectionURI = "/redfish/v1/Managers"
+)
+
+// publishPolledEvent publishes the events generated by polling a device
+var publishPolledEvent = WriteEventToJobQueue
+
+// resourceState holds the properties of a system or a chassis compared between the polls
+type resourceState struct {
+	PowerState string
+	Health     string
+}
+
+// polledResource holds the properties read from the resources of a device while polling it
+type polledResource struct {
+	PowerState string `json:"PowerState"`
+	Status     struct {
+		Health string `json:"Health"`
+	} `json:"Status"`
+	Members []common.Link `json:"Members"`
+	Entries *common.Link  `json:"Entries"`
+}
+
+// polledLogEntry holds the properties of a log entry published as an event
+type polledLogEntry struct {
+	Message     string   `json:"Message"`
+	MessageID   string   `json:"MessageId"`
+	MessageArgs []string `json:"MessageArgs"`
+	Severity    string   `json:"Severity"`
+	Created     string   `json:"Created"`
+	Links       struct {
+		OriginOfCondition *common.Link `json:"OriginOfCondition"`
+	} `json:"Links"`
+}
+
+// eventPoller reads the resources of a device periodically, and publishes the changes
+// seen in them as events of the device, as if the device posted them to the plugin
+type eventPoller struct {
+	mutex  sync.Mutex
+	device sdkutilities.RedfishDevice
+	stop   chan struct{}
+	// done is closed when the poller has stopped
+	done chan struct{}
+	// states holds the state of the systems and the chassis of the device, by URI
+	states map[string]resourceState
+	// logCollections holds the URIs of the entries collections of the log services of the device
+	logCollections []string
+	// logEntries holds the URIs of the entries seen in each log entries collection
+	logEntries  map[string]map[string]bool
+	initialized bool
+}
+
+var (
+	pollersMutex sync.Mutex
+	eventPollers = make(map[string]*eventPoller)
+)
+
+// startEventPolling starts polling the device for its events, the credentials of a device
+// already polled are updated. It returns the location of the event subscription of the device.
+func startEventPolling(device *sdkutilities.RedfishDevice) string {
+	pollersMutex.Lock()
+	defer pollersMutex.Unlock()
+	key := strings.ToLower(device.Host)
+	if poller, ok := eventPollers[key]; ok {
+		poller.setCredentials(device)
+		return pollingSubscriptionLocation(device.Host)
+	}
+	poller := &eventPoller{
+		device: sdkutilities.RedfishDevice{
+			Host:     device.Host,
+			Username: device.Username,
+			Password: device.Password,
+		},
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+		logEntries: make(map[string]map[string]bool),
+	}
+	eventPollers[key] = poller
+	go poller.run()
+	return pollingSubscriptionLocation(device.Host)
+}
+
+// updateEventPolling updates the credentials used by the poller of the device, if it is polled
+func updateEventPolling(device *sdkutilities.RedfishDevice) {
+	pollersMutex.Lock()
+	defer pollersMutex.Unlock()
+	if poller, ok := eventPollers[strings.ToLower(device.Host)]; ok {
+		poller.setCredentials(device)
+	}
+}
+
+// stopEventPolling stops the polling of the device, it returns false when the device is not polled
+func stopEventPolling(host string) bool {
+	pollersMutex.Lock()
+	defer pollersMutex.Unlock()
+	key := strings.ToLower(host)
+	poller, ok := eventPollers[key]
+	if ok {
+		close(poller.stop)
+		delete(eventPollers, key)
+	}
+	return ok
+}
+
+func pollingSubscriptionLocation(host string) string {
+	return "https://" + host + pollingSubscriptionURI
+}
+
+// isPollingSubscription returns true for the location of the subscription of a polled device
+func isPollingSubscription(location string) bool {
+	return strings.HasSuffix(location, pollingSubscriptionURI)
+}
+
+func (p *eventPoller) setCredentials(device *sdkutilities.RedfishDevice) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.device.Username = device.Username
+	p.device.Password = device.Password
+}
+
+func (p *eventPoller) run() {
+	defer close(p.done)
+	ctx := sdkutilities.CreateContext(uuid.New().String(), common.PluginEventHandlingActionID, common.PluginEventHandlingActionName, "1", "EventPolling")
+	l.LogWithFields(ctx).Info("started polling the device " + p.device.Host + " for its events")
+	for {
+		p.poll(ctx)
+		// the interval is read at every poll to apply the changes of the configuration
+		select {
+		case <-p.stop:
+			l.LogWithFields(ctx).Info("stopped polling the device " + p.device.Host + " for its events")
+			return
+		case <-time.After(time.Duration(config.Data.EventConf.PollingIntervalInSeconds) * time.Second):
+		}
+	}
+}
+
+// poll reads the resources of the device and publishes the changes seen since the previous poll.
+// The first poll of the device, and of each of its log services, only records their state.
+func (p *eventPoller) poll(ctx context.Context) {
+	p.mutex.Lock()
+	device := p.device
+	p.mutex.Unlock()
+	client, err := sdkutilities.GetRedfishClient()
+	if err != nil {
+		l.LogWithFields(ctx).Error("While trying to create the redfish client, got: " + err.Error())
+		return
+	}
+
+	states := make(map[string]resourceState)
+	for _, collectionURI := range []string{systemsCollectionURI, chassisCollectionURI} {
+		members, err := getPolledMembers(client, &device, collectionURI)
+		if err != nil {
+			// the state of an unreachable device is compared at the next poll
+			l.LogWithFields(ctx).Warn("While polling the device " + device.Host + ", got: " + err.Error())
+			return
+		}
+		for _, member := range members {
+			var resource polledResource
+			if err := getPolledResource(client, &device, member, &resource); err != nil {
+				l.LogWithFields(ctx).Warn("While polling the device " + device.Host + ", got: " + err.Error())
+				return
+			}
+			states[member] = resourceState{
+				PowerState: resource.PowerState,
+				Health:     resource.Status.Health,
+			}
+		}
+	}
+	var events []common.Event
+	if p.initialized {
+		events = getStateChangeEvents(p.states, states)
+	}
+	p.states = states
+
+	if p.logCollections == nil {
+		p.logCollections = getLogCollections(client, &device, states)
+	}
+	for _, collectionURI := range p.logCollections {
+		members, err := getPolledMembers(client, &device, collectionURI)
+		if err != nil {
+			// the log services are looked for again at the next poll
+			l.LogWithFields(ctx).Warn("While polling the device " + device.Host + ", got: " + err.Error())
+			p.logCollections = nil
+			continue
+		}
+		events = append(events, p.getLogEntryEvents(ctx, client, &device, collectionURI, members)...)
+	}
+	p.initialized = true
+	publishDeviceEvents(ctx, device.Host, events)
+}
+
+// getStateChangeEvents returns the events for the changes of the power state of the
+// systems, and of the health of the systems and the chassis
+func getStateChangeEvents(previous, current map[string]resourceState) []common.Event {
+	uris := make([]string, 0, len(current))
+	for uri := range current {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+	var events []common.Event
+	for _, uri := range uris {
+		state := current[uri]
+		old, ok := previous[uri]
+		if !ok {
+			continue
+		}
+		if state.PowerState != old.PowerState && strings.HasPrefix(uri, systemsCollectionURI+"/") {
+			switch state.PowerState {
+			case "On":
+				events = append(events, newPolledEvent(uri, polledEventRegistry+"ServerPoweredOn", "OK",
+					"The server is powered on.", nil))
+			case "Off":
+				events = append(events, newPolledEvent(uri, polledEventRegistry+"ServerPoweredOff", "OK",
+					"The server is powered off.", nil))
+			}
+		}
+		if state.Health != old.Health && state.Health != "" {
+			events = append(events, newPolledEvent(uri, "ResourceEvent.1.0.ResourceStatusChanged"+state.Health, state.Health,
+				fmt.Sprintf("The health of resource '%s' has changed to %s.", uri, state.Health), []string{uri, state.Health}))
+		}
+	}
+	return events
+}
+
+// getLogEntryEvents returns the events for the entries added to the log entries collection since the previous poll.
+// An entry is marked as seen only once its event is returned, the entries beyond maxPolledLogEntries and
+// the entries which could not be read are published at the next polls.
+func (p *eventPoller) getLogEntryEvents(ctx context.Context, client *sdkutilities.RedfishClient, device *sdkutilities.RedfishDevice, collectionURI string, members []string) []common.Event {
+	seen, known := p.logEntries[collectionURI]
+	current := make(map[string]bool, len(members))
+	var events []common.Event
+	for _, member := range members {
+		// the entries present when the collection is first polled are not published
+		if !known || seen[member] {
+			current[member] = true
+			continue
+		}
+		if len(events) >= maxPolledLogEntries {
+			continue
+		}
+		var entry polledLogEntry
+		if err := getPolledResource(client, device, member, &entry); err != nil {
+			l.LogWithFields(ctx).Warn("While polling the device " + device.Host + ", got: " + err.Error())
+			continue
+		}
+		messageID := entry.MessageID
+		if messageID == "" {
+			messageID = "ResourceEvent.1.0.ResourceCreated"
+		}
+		origin := member
+		if entry.Links.OriginOfCondition != nil && entry.Links.OriginOfCondition.Oid != "" {
+			origin = entry.Links.OriginOfCondition.Oid
+		}
+		event := newPolledEvent(origin, messageID, entry.Severity, entry.Message, entry.MessageArgs)
+		if entry.Created != "" {
+			event.EventTimestamp = entry.Created
+		}
+		events = append(events, event)
+		current[member] = true
+	}
+	p.logEntries[collectionURI] = current
+	return events
+}
+
+// getLogCollections returns the URIs of the entries collections of the log services of the
+// systems and the managers of the device
+func getLogCollections(client *sdkutilities.RedfishClient, device *sdkutilities.RedfishDevice, states map[string]resourceState) []string {
+	var resources []string
+	for uri := range states {
+		if strings.HasPrefix(uri, systemsCollectionURI+"/") {
+			resources = append(resources, uri)
+		}
+	}
+	managers, _ := getPolledMembers(client, device, managersCollectionURI)
+	resources = append(resources, managers...)
+	sort.Strings(resources)
+	collections := []string{}
+	for _, resource := range resources {
+		logServices, err := getPolledMembers(client, device, strings.TrimSuffix(resource, "/")+"/LogServices")
+		if err != nil {
+			continue
+		}
+		for _, logService := range logServices {
+			var service polledResource
+			if err := getPolledResource(client, device, logService, &service); err != nil || service.Entries == nil {
+				continue
+			}
+			collections = append(collections, service.Entries.Oid)
+		}
+	}
+	return collections
+}
+
+func newPolledEvent(origin, messageID, severity, message string, messageArgs []string) common.Event {
+	return common.Event{
+		EventType:         "Alert",
+		EventID:           uuid.New().String(),
+		Severity:          severity,
+		EventTimestamp:    time.Now().UTC().Format(time.RFC3339),
+		Message:           message,
+		MessageArgs:       messageArgs,
+		MessageID:         messageID,
+		OriginOfCondition: &common.Link{Oid: origin},
+	}
+}
+
+// publishDeviceEvents publishes the events like the events posted by the device
+func publishDeviceEvents(ctx context.Context, host string, events []common.Event) {
+	if len(events) == 0 {
+		return
+	}
+	for i := range events {
+		events[i].MemberID = strconv.Itoa(i)
+	}
+	ip, err := common.GetIPFromHostName(host)
+	if err != nil {
+		if ip, _, err = net.SplitHostPort(host); err != nil {
+			ip = host
+		}
+	}
+	request, err := json.Marshal(common.MessageData{
+		OdataType: "#Event.v1_7_0.Event",
+		Name:      "Events of the polled device",
+		Context:   "/redfish/v1/$metadata#Event.Event",
+		Events:    events,
+	})
+	if err != nil {
+		l.LogWithFields(ctx).Error("While trying to marshal the events of the device " + host + ", got: " + err.Error())
+		return
+	}
+	l.LogWithFields(ctx).Debugf("publishing %d events of the device %s", len(events), host)
+	publishPolledEvent(common.Events{
+		IP:      ip,
+		Request: []byte(TranslateToNorthBoundURL(string(request))),
+	})
+}
+
+// getPolledMembers returns the URIs of the members of a collection of the device
+func getPolledMembers(client *sdkutilities.RedfishClient, device *sdkutilities.RedfishDevice, uri string) ([]string, error) {
+	var collection polledResource
+	if err := getPolledResource(client, device, uri, &collection); err != nil {
+		return nil, err
+	}
+	members := make([]string, 0, len(collection.Members))
+	for _, member := range collection.Members {
+		if member.Oid != "" {
+			members = append(members, member.Oid)
+		}
+	}
+	return members, nil
+}
+
+func getPolledResource(client *sdkutilities.RedfishClient, device *sdkutilities.RedfishDevice, uri string, resource interface{}) error {
+	device.PostBody = nil
+	resp, err := client.DeviceCall(device, uri, http.MethodGet)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET on %s returned the status code %d", uri, resp.StatusCode)
+	}
+	return json.Unmarshal(body, resource)
+}
@@ -0,0 +1,281 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package sdkhandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	iris "github.com/kataras/iris/v12"
+	"github.com/kataras/iris/v12/httptest"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockPolledBMC serves the resources read by the event poller
+type mockPolledBMC struct {
+	mutex      sync.Mutex
+	powerState string
+	health     string
+	entries    []string
+}
+
+func (m *mockPolledBMC) handle(username, password, url string, w http.ResponseWriter) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	members := func(uris ...string) map[string]interface{} {
+		links := []common.Link{}
+		for _, uri := range uris {
+			links = append(links, common.Link{Oid: uri})
+		}
+		return map[string]interface{}{"Members": links}
+	}
+	var body interface{}
+	switch url {
+	case "/redfish/v1/Systems":
+		body = members("/redfish/v1/Systems/1")
+	case "/redfish/v1/Systems/1":
+		body = map[string]interface{}{
+			"PowerState": m.powerState,
+			"Status":     map[string]string{"Health": m.health},
+		}
+	case "/redfish/v1/Chassis":
+		body = members("/redfish/v1/Chassis/1")
+	case "/redfish/v1/Chassis/1":
+		body = map[string]interface{}{"Status": map[string]string{"Health": "OK"}}
+	case "/redfish/v1/Managers":
+		body = members()
+	case "/redfish/v1/Systems/1/LogServices":
+		body = members("/redfish/v1/Systems/1/LogServices/SEL")
+	case "/redfish/v1/Systems/1/LogServices/SEL":
+		body = map[string]interface{}{"Entries": common.Link{Oid: "/redfish/v1/Systems/1/LogServices/SEL/Entries"}}
+	case "/redfish/v1/Systems/1/LogServices/SEL/Entries":
+		body = members(m.entries...)
+	default:
+		for i, entry := range m.entries {
+			if url == entry {
+				body = map[string]interface{}{
+					"Message":   fmt.Sprintf("entry %d", i),
+					"MessageId": "Sample.1.0.Entry",
+					"Severity":  "Warning",
+					"Links": map[string]interface{}{
+						"OriginOfCondition": common.Link{Oid: "/redfish/v1/Systems/1"},
+					},
+				}
+			}
+		}
+	}
+	if body == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	data, _ := json.Marshal(body)
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+func (m *mockPolledBMC) set(powerState, health string, entries ...string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.powerState = powerState
+	m.health = health
+	m.entries = entries
+}
+
+func TestEventPolling(t *testing.T) {
+	config.SetUpMockConfig(t)
+	bmc := &mockPolledBMC{}
+	bmc.set("On", "OK", "/redfish/v1/Systems/1/LogServices/SEL/Entries/1")
+	ts := startTestServer(bmc.handle)
+	ts.StartTLS()
+	defer ts.Close()
+
+	var published []common.MessageData
+	publishPolledEvent = func(event common.Events) {
+		var message common.MessageData
+		json.Unmarshal(event.Request, &message)
+		published = append(published, message)
+	}
+	defer func() { publishPolledEvent = WriteEventToJobQueue }()
+
+	poller := &eventPoller{
+		device: sdkutilities.RedfishDevice{
+			Host:     "localhost:1234",
+			Username: "admin",
+			Password: "password",
+		},
+		logEntries: make(map[string]map[string]bool),
+	}
+	ctx := mockContext()
+
+	// the first poll only records the state of the device
+	poller.poll(ctx)
+	assert.Empty(t, published, "first poll should not publish events")
+	assert.Equal(t, []string{"/redfish/v1/Systems/1/LogServices/SEL/Entries"}, poller.logCollections)
+
+	// nothing changed
+	poller.poll(ctx)
+	assert.Empty(t, published, "unchanged device should not publish events")
+
+	bmc.set("Off", "Critical", "/redfish/v1/Systems/1/LogServices/SEL/Entries/1", "/redfish/v1/Systems/1/LogServices/SEL/Entries/2")
+	poller.poll(ctx)
+	if assert.Len(t, published, 1) {
+		events := published[0].Events
+		if assert.Len(t, events, 3) {
+			assert.Equal(t, polledEventRegistry+"ServerPoweredOff", events[0].MessageID)
+			assert.Equal(t, "Alert", events[0].EventType)
+			assert.Equal(t, "ResourceEvent.1.0.ResourceStatusChangedCritical", events[1].MessageID)
+			assert.Equal(t, "Critical", events[1].Severity)
+			assert.Equal(t, "Sample.1.0.Entry", events[2].MessageID)
+			assert.Equal(t, "entry 1", events[2].Message)
+			assert.Equal(t, "Warning", events[2].Severity)
+		}
+	}
+
+	bmc.set("On", "Critical", "/redfish/v1/Systems/1/LogServices/SEL/Entries/2")
+	poller.poll(ctx)
+	if assert.Len(t, published, 2) && assert.Len(t, published[1].Events, 1) {
+		assert.Equal(t, polledEventRegistry+"ServerPoweredOn", published[1].Events[0].MessageID)
+	}
+
+	// the state of an unreachable device is compared at the next poll
+	ts.Close()
+	poller.poll(ctx)
+	assert.Len(t, published, 2, "unreachable device should not publish events")
+}
+
+func TestEventPollingOfManyLogEntries(t *testing.T) {
+	config.SetUpMockConfig(t)
+	bmc := &mockPolledBMC{}
+	entries := []string{"/redfish/v1/Systems/1/LogServices/SEL/Entries/0"}
+	bmc.set("On", "OK", entries...)
+	ts := startTestServer(bmc.handle)
+	ts.StartTLS()
+	defer ts.Close()
+
+	var published []common.MessageData
+	publishPolledEvent = func(event common.Events) {
+		var message common.MessageData
+		json.Unmarshal(event.Request, &message)
+		published = append(published, message)
+	}
+	defer func() { publishPolledEvent = WriteEventToJobQueue }()
+
+	poller := &eventPoller{
+		device: sdkutilities.RedfishDevice{
+			Host:     "localhost:1234",
+			Username: "admin",
+			Password: "password",
+		},
+		logEntries: make(map[string]map[string]bool),
+	}
+	ctx := mockContext()
+	poller.poll(ctx)
+
+	for i := 1; i <= maxPolledLogEntries+5; i++ {
+		entries = append(entries, fmt.Sprintf("/redfish/v1/Systems/1/LogServices/SEL/Entries/%d", i))
+	}
+	bmc.set("On", "OK", entries...)
+	poller.poll(ctx)
+	if assert.Len(t, published, 1) {
+		assert.Len(t, published[0].Events, maxPolledLogEntries)
+	}
+
+	// the entries beyond the limit are published at the next poll
+	poller.poll(ctx)
+	if assert.Len(t, published, 2) && assert.Len(t, published[1].Events, 5) {
+		assert.Equal(t, fmt.Sprintf("entry %d", maxPolledLogEntries+1), published[1].Events[0].Message)
+	}
+
+	poller.poll(ctx)
+	assert.Len(t, published, 2, "published entries should not be published again")
+}
+
+func TestStartStopEventPolling(t *testing.T) {
+	config.SetUpMockConfig(t)
+	device := &sdkutilities.RedfishDevice{
+		Host:     "Polled.Device:443",
+		Username: "admin",
+		Password: "password",
+	}
+	location := startEventPolling(device)
+	assert.Equal(t, "https://Polled.Device:443"+pollingSubscriptionURI, location)
+	assert.True(t, isPollingSubscription(location))
+	assert.False(t, isPollingSubscription("https://polled.device:443/redfish/v1/EventService/Subscriptions/1"))
+
+	// the device is polled once, with the latest credentials
+	updated := &sdkutilities.RedfishDevice{
+		Host:     "polled.device:443",
+		Username: "admin",
+		Password: "newpassword",
+	}
+	assert.Equal(t, "https://polled.device:443"+pollingSubscriptionURI, startEventPolling(updated))
+	pollersMutex.Lock()
+	poller := eventPollers["polled.device:443"]
+	assert.Len(t, eventPollers, 1)
+	pollersMutex.Unlock()
+	poller.mutex.Lock()
+	assert.Equal(t, "newpassword", poller.device.Password)
+	poller.mutex.Unlock()
+
+	updated.Password = "password2"
+	updateEventPolling(updated)
+	poller.mutex.Lock()
+	assert.Equal(t, "password2", poller.device.Password)
+	poller.mutex.Unlock()
+
+	assert.True(t, stopEventPolling("polled.device:443"))
+	<-poller.done
+	assert.False(t, stopEventPolling("polled.device:443"))
+	// credentials of a device not polled are not recorded
+	updateEventPolling(updated)
+	pollersMutex.Lock()
+	assert.Empty(t, eventPollers)
+	pollersMutex.Unlock()
+}
+
+func TestEventPollingSubscription(t *testing.T) {
+	config.SetUpMockConfig(t)
+	mockApp := iris.New()
+	pluginRoutes := mockApp.Party("/ODIM/v1")
+	pluginRoutes.Post("/Subscriptions", CreateEventSubscription)
+	pluginRoutes.Delete("/Subscriptions", DeleteEventSubscription)
+	e := httptest.New(t, mockApp)
+
+	requestBody := map[string]interface{}{
+		"ManagerAddress":    "polled.device:1234",
+		"UserName":          "admin",
+		"Password":          "password",
+		"EventDeliveryMode": common.EventDeliveryModePolling,
+		"PostBody":          []byte(`{"EventTypes":["Alert"]}`),
+	}
+	e.POST("/ODIM/v1/Subscriptions").WithJSON(requestBody).Expect().
+		Status(http.StatusCreated).Header("Location").Equal("https://polled.device:1234" + pollingSubscriptionURI)
+
+	pollersMutex.Lock()
+	poller := eventPollers["polled.device:1234"]
+	pollersMutex.Unlock()
+	requestBody["Location"] = "https://polled.device:1234" + pollingSubscriptionURI
+	e.DELETE("/ODIM/v1/Subscriptions").WithJSON(requestBody).Expect().Status(http.StatusNoContent)
+	<-poller.done
+	pollersMutex.Lock()
+	assert.Empty(t, eventPollers)
+	pollersMutex.Unlock()
+}
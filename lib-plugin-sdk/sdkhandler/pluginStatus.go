@@ -12,10 +12,11 @@
 //License for the specific language governing permissions and limitations
 // under the License.
 
-// Package dphandler ...
-package dphandler
+// Package sdkhandler ...
+package sdkhandler
 
 import (
+	"context"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
@@ -23,14 +24,13 @@ import (
 	"sync"
 	"time"
 
-	iris "github.com/kataras/iris/v12"
-
-	//"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	pluginConfig "github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkmodel"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkresponse"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-dell/config"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dpmodel"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dpresponse"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dputilities"
+	iris "github.com/kataras/iris/v12"
 )
 
 // GetPluginStatus defines the GetPluginStatus iris handler.
@@ -49,22 +49,22 @@ func GetPluginStatus(ctx iris.Context) {
 			return
 		}
 	}
-	var messageQueueInfo []dpresponse.EmbQueue
-	var resp = dpresponse.PluginStatusResponse{
+	var messageQueueInfo []sdkresponse.EmbQueue
+	var resp = sdkresponse.PluginStatusResponse{
 		Comment: "Plugin Status Response",
 		Name:    "Common Redfish Plugin Status",
 		Version: pluginConfig.Data.FirmwareVersion,
 	}
-	resp.Status = dputilities.Status
-	elapsedTime := time.Since(dputilities.PluginStartTime)
+	resp.Status = sdkutilities.Status
+	elapsedTime := time.Since(sdkutilities.PluginStartTime)
 	resp.Status.Uptime = elapsedTime.String()
 	resp.Status.TimeStamp = time.Now().Format(time.RFC3339)
-	resp.EventMessageBus = dpresponse.EventMessageBus{
+	resp.EventMessageBus = sdkresponse.EventMessageBus{
 		EmbType: pluginConfig.Data.MessageBusConf.EmbType,
 	}
-	//messageQueueInfo := make([]dpresponse.EmbQueue, 0)
+	//messageQueueInfo := make([]sdkresponse.EmbQueue, 0)
 	for i := 0; i < len(pluginConfig.Data.MessageBusConf.EmbQueue); i++ {
-		messageQueueInfo = append(messageQueueInfo, dpresponse.EmbQueue{
+		messageQueueInfo = append(messageQueueInfo, sdkresponse.EmbQueue{
 			QueueName: pluginConfig.Data.MessageBusConf.EmbQueue[i],
 			QueueDesc: "Queue for redfish events",
 		})
@@ -92,7 +92,7 @@ func GetPluginStartup(ctx iris.Context) {
 		}
 	}
 
-	var startup dpmodel.StartUpData
+	var startup sdkmodel.StartUpData
 	err := ctx.ReadJSON(&startup)
 	if err != nil {
 		l.LogWithFields(ctxt).Error("While trying to collect data from request, got: " + err.Error())
@@ -108,103 +108,117 @@ func GetPluginStartup(ctx iris.Context) {
 	}
 	l.LogWithFields(ctxt).Debugf("inventory update request received for %d devices", len(startup.Devices))
 
-	errorCh := make(chan error)
-	startUpResponse := make(chan map[string]string)
-	respBody := make(map[string]string)
-	quit := make(chan bool)
-	var writeWG sync.WaitGroup
-	go func() {
-		for {
-			select {
-			case err = <-errorCh:
-				ctx.StatusCode(http.StatusInternalServerError)
-				ctx.WriteString(err.Error())
-				//close(startUpResponse)
-				//close(respHeader)
-				//close(errorCh)
-				writeWG.Done()
-				return
-			case startResp := <-startUpResponse:
-				for k, v := range startResp {
-					respBody[k] = v
-				}
-				writeWG.Done()
-			case <-quit:
-				//close(startUpResponse)
-				//close(respHeader)
-				//close(errorCh)
-				//close(quit)
-				break
-			}
-		}
-	}()
-
+	var (
+		respBody = make(map[string]string)
+		errs     []error
+		mutex    sync.Mutex
+		wg       sync.WaitGroup
+	)
 	for uuid, device := range startup.Devices {
 		if device.Operation == "add" {
-			dpmodel.AddDeviceToInventory(uuid, device)
+			sdkmodel.AddDeviceToInventory(uuid, device)
 			l.LogWithFields(ctxt).Debug("device " + uuid + " added to the inventory")
+			if device.CertificateTrust != nil {
+				if err := sdkutilities.SetCertificateTrust(device.Address, *device.CertificateTrust); err != nil {
+					l.LogWithFields(ctxt).Error("unable to set the certificate trust of the device " + uuid + ": " + err.Error())
+				}
+			}
+			// a delta update of a polled device only carries its new credentials
+			updateEventPolling(&sdkutilities.RedfishDevice{
+				Host:     device.Address,
+				Username: device.UserName,
+				Password: string(device.Password),
+			})
 		}
 		if device.Operation == "del" {
-			dpmodel.DeleteDeviceInInventory(uuid)
+			sdkmodel.DeleteDeviceInInventory(uuid)
+			stopEventPolling(device.Address)
+			sdkutilities.RemoveCertificateTrust(device.Address)
 			l.LogWithFields(ctxt).Debug("device " + uuid + " removed from the inventory")
 		}
 		if startup.ResyncEvtSubscription && startup.RequestType == "full" {
-			writeWG.Add(1)
-			l.LogWithFields(ctxt).Debug("performing event subscription check for all the devices in the inventory")
-			go checkCreateSub(device, startUpResponse, errorCh, &writeWG)
+			wg.Add(1)
+			l.LogWithFields(ctxt).Debug("performing event subscription check for the device " + uuid)
+			go func(device sdkmodel.DeviceData) {
+				defer wg.Done()
+				location, err := checkCreateSub(ctxt, device)
+				mutex.Lock()
+				defer mutex.Unlock()
+				if err != nil {
+					errs = append(errs, err)
+					return
+				}
+				respBody[device.Address] = location
+			}(device)
 		}
 	}
+	wg.Wait()
 
-	writeWG.Wait()
-	quit <- true
+	if len(errs) > 0 {
+		l.LogWithFields(ctxt).Error("event subscription check failed: " + errs[0].Error())
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.WriteString(errs[0].Error())
+		return
+	}
 	ctx.StatusCode(http.StatusOK)
 	ctx.JSON(respBody)
 }
 
-func checkCreateSub(server dpmodel.DeviceData, startUpResponse chan map[string]string, errorCh chan error, writeWG *sync.WaitGroup) {
-	var respBody = make(map[string]string)
-	device := &dputilities.RedfishDevice{
+// checkCreateSub verifies the event subscription of the device and recreates it
+// when it is missing or differs from the one known to odimra, it returns the
+// location of the subscription on the device
+func checkCreateSub(ctxt context.Context, server sdkmodel.DeviceData) (string, error) {
+	if server.EventSubscriptionInfo == nil {
+		return "", nil
+	}
+	device := &sdkutilities.RedfishDevice{
 		Host:     server.Address,
 		Username: server.UserName,
 		Password: string(server.Password),
 		Location: server.EventSubscriptionInfo.Location,
 	}
-	redfishClient, err := dputilities.GetRedfishClient()
+	if server.EventDeliveryMode == common.EventDeliveryModePolling {
+		if !isPollingSubscription(device.Location) {
+			deleteMatchingSubscriptions(ctxt, device)
+		}
+		return startEventPolling(device), nil
+	}
+	if isPollingSubscription(device.Location) {
+		// the device is back to the Push event delivery mode
+		stopEventPolling(device.Host)
+		return createDefaultSubscription(device)
+	}
+	redfishClient, err := sdkutilities.GetRedfishClient()
 	if err != nil {
-		errorCh <- err
-		return
+		return "", err
 	}
 	//Get Subscription details
 	resp, err := redfishClient.GetSubscriptionDetail(device)
 	if err != nil {
-		errorCh <- err
-		return
+		return "", err
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode == http.StatusOK {
 		body, err := ioutil.ReadAll(resp.Body)
 		if err != nil {
-			errorCh <- err
-			return
+			return "", err
 		}
-		var obj dpmodel.EvtSubPost
+		var obj sdkmodel.EvtSubPost
 		if err = json.Unmarshal([]byte(body), &obj); err != nil {
-			errorCh <- err
-			return
+			return "", err
 		}
 
 		res := reflect.DeepEqual(obj.EventTypes, server.EventSubscriptionInfo.EventTypes)
 		if !res {
 			//Delete Subscription details
-			resp, err := redfishClient.DeleteSubscriptionDetail(device)
+			resp, err = redfishClient.DeleteSubscriptionDetail(device)
 			if err != nil {
-				errorCh <- err
-				return
+				return "", err
 			}
 			defer resp.Body.Close()
 
 			//Create new Subscription with details in odimra
-			req := dpmodel.EvtSubPost{
+			req := sdkmodel.EvtSubPost{
 				Destination: "https://" + pluginConfig.Data.LoadBalancerConf.Host + ":" + pluginConfig.Data.LoadBalancerConf.Port + pluginConfig.Data.EventConf.DestURI,
 				EventTypes:  server.EventSubscriptionInfo.EventTypes,
 				Context:     "Event Subscription",
@@ -213,43 +227,49 @@ func checkCreateSub(server dpmodel.DeviceData, startUpResponse chan map[string]s
 			}
 			device.PostBody, err = json.Marshal(req)
 			if err != nil {
-				errorCh <- err
-				return
+				return "", err
 			}
 
 			//Subscribe to Events
 			resp, err = redfishClient.SubscribeForEvents(device)
 			if err != nil {
-				errorCh <- err
-				return
+				return "", err
 			}
 			defer resp.Body.Close()
 
 		}
 
 	} else if resp.StatusCode == http.StatusNotFound {
-		req := dpmodel.EvtSubPost{
-			Destination: "https://" + pluginConfig.Data.LoadBalancerConf.Host + ":" + pluginConfig.Data.LoadBalancerConf.Port + pluginConfig.Data.EventConf.DestURI,
-			EventTypes:  []string{"Alert"},
-			Context:     "Event Subscription",
-			//	HTTPHeaders: reqPostBody.HTTPHeaders,
-			Protocol: "Redfish",
-		}
-		device.PostBody, err = json.Marshal(req)
-		if err != nil {
-			errorCh <- err
-			return
-		}
+		return createDefaultSubscription(device)
+	}
 
-		//Subscribe to Events
-		resp, err = redfishClient.SubscribeForEvents(device)
-		if err != nil {
-			errorCh <- err
-			return
-		}
-		defer resp.Body.Close()
+	return resp.Header.Get("location"), nil
+}
+
+// createDefaultSubscription subscribes for the Alert events of the device,
+// it returns the location of the subscription on the device
+func createDefaultSubscription(device *sdkutilities.RedfishDevice) (string, error) {
+	redfishClient, err := sdkutilities.GetRedfishClient()
+	if err != nil {
+		return "", err
+	}
+	req := sdkmodel.EvtSubPost{
+		Destination: "https://" + pluginConfig.Data.LoadBalancerConf.Host + ":" + pluginConfig.Data.LoadBalancerConf.Port + pluginConfig.Data.EventConf.DestURI,
+		EventTypes:  []string{"Alert"},
+		Context:     "Event Subscription",
+		//	HTTPHeaders: reqPostBody.HTTPHeaders,
+		Protocol: "Redfish",
+	}
+	device.PostBody, err = json.Marshal(req)
+	if err != nil {
+		return "", err
 	}
 
-	respBody[device.Host] = resp.Header.Get("location")
-	startUpResponse <- respBody
+	//Subscribe to Events
+	resp, err := redfishClient.SubscribeForEvents(device)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	return resp.Header.Get("location"), nil
 }
@@ -12,8 +12,8 @@
 //License for the specific language governing permissions and limitations
 // under the License.
 
-// Package dphandler ..
-package dphandler
+// Package sdkhandler ...
+package sdkhandler
 
 import (
 	"encoding/json"
@@ -22,10 +22,9 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-dell/config"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dputilities"
 	"github.com/google/uuid"
 )
 
@@ -36,8 +35,6 @@ var (
 	Out <-chan interface{}
 )
 
-// RedfishEvents receives the subscribed events from the south bound system
-// Then it will send the received data and ip to publish method
 // RedfishEvents receives the subscribed events from the south bound system
 // Then it will send the received data and ip to publish method
 func RedfishEvents(w http.ResponseWriter, r *http.Request) {
@@ -51,7 +48,7 @@ func RedfishEvents(w http.ResponseWriter, r *http.Request) {
 	}
 	transactionID := uuid.New()
 	threadID := 1
-	ctx := dputilities.CreateContext(transactionID.String(), common.PluginEventHandlingActionID, common.PluginEventHandlingActionName, strconv.Itoa(threadID), common.PluginEventHandlingActionName)
+	ctx := sdkutilities.CreateContext(transactionID.String(), common.PluginEventHandlingActionID, common.PluginEventHandlingActionName, strconv.Itoa(threadID), common.PluginEventHandlingActionName)
 	remoteAddr := r.RemoteAddr
 	// if southbound entities are behind a proxy, then
 	// originator address is expected to be in X-Forwarded-For header
@@ -73,23 +70,19 @@ func RedfishEvents(w http.ResponseWriter, r *http.Request) {
 
 	request, _ := json.Marshal(req)
 
-	reqData := string(request)
-	//replacing the resposne with north bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.NorthBoundURL {
-		reqData = strings.Replace(reqData, key, value, -1)
-	}
+	//replacing the response with north bound translation URL
 	event := common.Events{
 		IP:      ip,
-		Request: []byte(reqData),
+		Request: []byte(TranslateToNorthBoundURL(string(request))),
 	}
 
-	// Call writeEventToJobQueue to write events to worker pool
-	writeEventToJobQueue(event)
+	// Call WriteEventToJobQueue to write events to worker pool
+	WriteEventToJobQueue(event)
 	w.WriteHeader(http.StatusOK)
 }
 
-// writeEventToJobQueue will write events to worker pool
-func writeEventToJobQueue(event common.Events) {
+// WriteEventToJobQueue will write events to worker pool
+func WriteEventToJobQueue(event common.Events) {
 	var events []interface{}
 	events = append(events, event)
 	done := make(chan bool)
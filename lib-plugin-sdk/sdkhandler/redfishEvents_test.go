@@ -12,8 +12,8 @@
 //License for the specific language governing permissions and limitations
 // under the License.
 
-// Package dphandler ..
-package dphandler
+// Package sdkhandler ...
+package sdkhandler
 
 import (
 	"bytes"
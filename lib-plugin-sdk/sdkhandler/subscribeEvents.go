@@ -12,8 +12,8 @@
 //License for the specific language governing permissions and limitations
 // under the License.
 
-// Package dphandler ...
-package dphandler
+// Package sdkhandler ...
+package sdkhandler
 
 import (
 	"context"
@@ -25,14 +25,18 @@ import (
 	"strconv"
 	"strings"
 
+	evtConfig "github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkmodel"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
-	evtConfig "github.com/ODIM-Project/ODIM/plugin-dell/config"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dpmodel"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dputilities"
 	iris "github.com/kataras/iris/v12"
 )
 
+// OmitSubscriptionEventTypes removes the EventTypes from the subscriptions posted to the devices,
+// for the plugins of devices which do not support them
+var OmitSubscriptionEventTypes bool
+
 // CreateEventSubscription : Subscribes for events
 func CreateEventSubscription(ctx iris.Context) {
 	ctxt := ctx.Request().Context()
@@ -44,13 +48,20 @@ func CreateEventSubscription(ctx iris.Context) {
 	//First delete existing matching subscription(our subscription) from device
 	deleteMatchingSubscriptions(ctxt, device)
 
-	var reqPostBody dpmodel.EvtSubPost
-	var reqData string
-
-	//replacing the reruest  with south bound translation URL
-	for key, value := range evtConfig.Data.URLTranslation.SouthBoundURL {
-		reqData = strings.Replace(string(deviceDetails.PostBody), key, value, -1)
+	// the plugin polls the device which cannot post its events, and answers for its subscription
+	if deviceDetails.EventDeliveryMode == common.EventDeliveryModePolling {
+		common.SetResponseHeader(ctx, map[string]string{
+			"Location": startEventPolling(device),
+		})
+		ctx.StatusCode(http.StatusCreated)
+		return
 	}
+	stopEventPolling(device.Host)
+
+	var reqPostBody sdkmodel.EvtSubPost
+
+	//replacing the request with south bound translation URL
+	reqData := TranslateToSouthBoundURL(string(deviceDetails.PostBody))
 
 	err = json.Unmarshal([]byte(reqData), &reqPostBody)
 	if err != nil {
@@ -59,23 +70,14 @@ func CreateEventSubscription(ctx iris.Context) {
 		return
 	}
 
-	// remove the mesaageids, resourcestypes and originresources from the request and post it to device
-	// since some of device doesnt support these
-	req := dpmodel.EvtSubPost{
-		Destination: "https://" + evtConfig.Data.LoadBalancerConf.Host + ":" + evtConfig.Data.LoadBalancerConf.Port + evtConfig.Data.EventConf.DestURI,
-		EventTypes:  reqPostBody.EventTypes,
-		Context:     reqPostBody.Context,
-		HTTPHeaders: reqPostBody.HTTPHeaders,
-		Protocol:    reqPostBody.Protocol,
-	}
-	device.PostBody, err = json.Marshal(req)
+	device.PostBody, err = json.Marshal(deviceSubscription(reqPostBody))
 	if err != nil {
 		ctx.StatusCode(http.StatusInternalServerError)
 		ctx.WriteString(err.Error())
 		return
 	}
 
-	redfishClient, err := dputilities.GetRedfishClient()
+	redfishClient, err := sdkutilities.GetRedfishClient()
 	if err != nil {
 		errMsg := "While trying to create the redfish client, got:" + err.Error()
 		l.LogWithFields(ctxt).Error(errMsg)
@@ -99,11 +101,28 @@ func CreateEventSubscription(ctx iris.Context) {
 	}
 }
 
+// deviceSubscription returns the subscription posted to the device for the subscription of the request.
+// The mesaageids, resourcestypes and originresources are removed from the request, and the
+// EventTypes when OmitSubscriptionEventTypes is set, since some of device doesnt support these
+func deviceSubscription(reqPostBody sdkmodel.EvtSubPost) sdkmodel.EvtSubPost {
+	req := sdkmodel.EvtSubPost{
+		Destination: "https://" + evtConfig.Data.LoadBalancerConf.Host + ":" + evtConfig.Data.LoadBalancerConf.Port + evtConfig.Data.EventConf.DestURI,
+		EventTypes:  reqPostBody.EventTypes,
+		Context:     reqPostBody.Context,
+		HTTPHeaders: reqPostBody.HTTPHeaders,
+		Protocol:    reqPostBody.Protocol,
+	}
+	if OmitSubscriptionEventTypes {
+		req.EventTypes = nil
+	}
+	return req
+}
+
 // Delete match subscription from device
-func deleteMatchingSubscriptions(ctxt context.Context, device *dputilities.RedfishDevice) {
+func deleteMatchingSubscriptions(ctxt context.Context, device *sdkutilities.RedfishDevice) {
 	// get all subscriptions
 	device.Location = "https://" + device.Host + "/redfish/v1/EventService/Subscriptions"
-	redfishClient, err := dputilities.GetRedfishClient()
+	redfishClient, err := sdkutilities.GetRedfishClient()
 	if err != nil {
 		l.LogWithFields(ctxt).Error("While trying to create the redfish client, got:" + err.Error())
 		return
@@ -133,10 +152,15 @@ func deleteMatchingSubscriptions(ctxt context.Context, device *dputilities.Redfi
 		return
 	}
 
-	members := subscriptionCollectionBody.(map[string]interface{})["Members"]
-	for _, member := range members.([]interface{}) {
-		device.Location = member.(map[string]interface{})["@odata.id"].(string)
-		device.Location = "https://" + device.Host + device.Location
+	collection, _ := subscriptionCollectionBody.(map[string]interface{})
+	members, _ := collection["Members"].([]interface{})
+	for _, member := range members {
+		memberLink, _ := member.(map[string]interface{})
+		memberURI, _ := memberLink["@odata.id"].(string)
+		if memberURI == "" {
+			continue
+		}
+		device.Location = "https://" + device.Host + memberURI
 		if isOurSubscription(ctxt, device) {
 			resp, err = redfishClient.DeleteSubscriptionDetail(device)
 			if err != nil {
@@ -147,9 +171,9 @@ func deleteMatchingSubscriptions(ctxt context.Context, device *dputilities.Redfi
 		}
 	}
 }
-func isOurSubscription(ctxt context.Context, device *dputilities.RedfishDevice) bool {
+func isOurSubscription(ctxt context.Context, device *sdkutilities.RedfishDevice) bool {
 
-	redfishClient, err := dputilities.GetRedfishClient()
+	redfishClient, err := sdkutilities.GetRedfishClient()
 	if err != nil {
 		l.LogWithFields(ctxt).Error("While trying to create the redfish client, got:" + err.Error())
 		return false
@@ -177,7 +201,8 @@ func isOurSubscription(ctxt context.Context, device *dputilities.RedfishDevice)
 		l.LogWithFields(ctxt).Error(err.Error())
 		return false
 	}
-	subscriptionDestinationFromDevice := subscriptionBody.(map[string]interface{})["Destination"].(string)
+	subscription, _ := subscriptionBody.(map[string]interface{})
+	subscriptionDestinationFromDevice, _ := subscription["Destination"].(string)
 	// if the subscription is ours then the destination should match with LBHOST:LBPORT.
 	//If it is not matching then retrun with MethodNotAllowed
 	if !strings.Contains(subscriptionDestinationFromDevice, evtConfig.Data.LoadBalancerConf.Host+":"+evtConfig.Data.LoadBalancerConf.Port) {
@@ -193,7 +218,12 @@ func DeleteEventSubscription(ctx iris.Context) {
 	if err != nil {
 		return
 	}
-	redfishClient, err := dputilities.GetRedfishClient()
+	if isPollingSubscription(device.Location) {
+		stopEventPolling(device.Host)
+		ctx.StatusCode(http.StatusNoContent)
+		return
+	}
+	redfishClient, err := sdkutilities.GetRedfishClient()
 	if err != nil {
 		errMsg := "While trying to create the redfish client, got:" + err.Error()
 		l.LogWithFields(ctxt).Error(errMsg)
@@ -219,7 +249,7 @@ func DeleteEventSubscription(ctx iris.Context) {
 
 // getDeviceDetails will accepts iris context and it will extract device details from context
 // then decrypt the password and return device details
-func getDeviceDetails(ctx iris.Context) (*dputilities.RedfishDevice, *dpmodel.Device, error) {
+func getDeviceDetails(ctx iris.Context) (*sdkutilities.RedfishDevice, *sdkmodel.Device, error) {
 	ctxt := ctx.Request().Context()
 
 	//Get token from Request
@@ -235,7 +265,7 @@ func getDeviceDetails(ctx iris.Context) (*dputilities.RedfishDevice, *dpmodel.De
 		}
 	}
 
-	var deviceDetails dpmodel.Device
+	var deviceDetails sdkmodel.Device
 
 	//Get device details from request
 	err := ctx.ReadJSON(&deviceDetails)
@@ -246,7 +276,7 @@ func getDeviceDetails(ctx iris.Context) (*dputilities.RedfishDevice, *dpmodel.De
 		return nil, nil, err
 	}
 
-	device := &dputilities.RedfishDevice{
+	device := &sdkutilities.RedfishDevice{
 		Host:     deviceDetails.Host,
 		Username: deviceDetails.Username,
 		Password: string(deviceDetails.Password),
@@ -270,7 +300,7 @@ func getDeviceDetails(ctx iris.Context) (*dputilities.RedfishDevice, *dpmodel.De
 // validateResponse will accepts iris context to write status code and response
 // method is to return status created incase of create subscription
 // otherwise return status ok
-func validateResponse(ctx iris.Context, device *dputilities.RedfishDevice, resp *http.Response, method string) error {
+func validateResponse(ctx iris.Context, device *sdkutilities.RedfishDevice, resp *http.Response, method string) error {
 	ctxt := ctx.Request().Context()
 	var body []byte
 	var err error
@@ -288,7 +318,7 @@ func validateResponse(ctx iris.Context, device *dputilities.RedfishDevice, resp
 		if resp.StatusCode != http.StatusCreated {
 			removeMessageID(ctx, device)
 			// Subscribe to Events
-			redfishClient, err := dputilities.GetRedfishClient()
+			redfishClient, err := sdkutilities.GetRedfishClient()
 			if err != nil {
 				errMsg := "While trying to create the redfish client, got:" + err.Error()
 				l.LogWithFields(ctxt).Error(errMsg)
@@ -315,18 +345,13 @@ func validateResponse(ctx iris.Context, device *dputilities.RedfishDevice, resp
 		}
 
 	}
-	header := make(map[string]string)
-	for k, v := range resp.Header {
-		var value string
-		for i := 0; i < len(v); i++ {
-			value = value + " " + v[i]
-		}
-		header[k] = value
+	header := map[string]string{
+		"Location": resp.Header.Get("Location"),
 	}
 	if resp.StatusCode == 401 {
 		ctx.StatusCode(http.StatusBadRequest)
-		ctx.WriteString("Authtication with the device failed")
-		return errors.New("Authtication with the device failed")
+		ctx.WriteString("Authentication with the device failed")
+		return errors.New("Authentication with the device failed")
 	}
 	if resp.StatusCode >= 300 {
 		l.LogWithFields(ctxt).Warn("Subscription operation failed: ", string(body))
@@ -337,8 +362,8 @@ func validateResponse(ctx iris.Context, device *dputilities.RedfishDevice, resp
 	return nil
 }
 
-func removeMessageID(ctx iris.Context, device *dputilities.RedfishDevice) {
-	var ReqPostBody dpmodel.EvtSubPost
+func removeMessageID(ctx iris.Context, device *sdkutilities.RedfishDevice) {
+	var ReqPostBody sdkmodel.EvtSubPost
 	err := json.Unmarshal(device.PostBody, &ReqPostBody)
 	if err != nil {
 		ctx.StatusCode(http.StatusInternalServerError)
@@ -12,8 +12,8 @@
 //License for the specific language governing permissions and limitations
 // under the License.
 
-//Package rfpmessagebus ...
-package rfpmessagebus
+// Package sdkmessagebus ...
+package sdkmessagebus
 
 import (
 	"context"
@@ -24,84 +24,66 @@ import (
 
 	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
 	dc "github.com/ODIM-Project/ODIM/lib-messagebus/datacommunicator"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkmodel"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
-	"github.com/ODIM-Project/ODIM/plugin-redfish/config"
-	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpmodel"
-	log "github.com/sirupsen/logrus"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
 )
 
+// FormatEvent converts a redfish event received from a device into the
+// event format published on the message bus. Vendor plugins can replace it
+// to translate their OEM payloads before the event is published.
+var FormatEvent = FormatRedfishEvent
+
 // Publish function will handle events request in two originofcondition format
 // originofcondition can be with or without @odata.id
 func Publish(ctx context.Context, data interface{}) bool {
 	if data == nil {
-		log.Error("Nil data passed to event publisher")
+		l.LogWithFields(ctx).Error("Invalid data on publishing events")
 		return false
 	}
 	event := data.(common.Events)
 	if event.EventType == "PluginStartUp" {
-		return writeToMessageBus(event)
+		return writeToMessageBus(ctx, event)
 	}
 
 	// this map is to check what type of event is recieved.
 	// if @odata.type contains MetricReport then its of type MetricReport message objects else event message objects
-	var metricReportEventDataMap map[string]interface{}
-	err := json.Unmarshal(event.Request, &metricReportEventDataMap)
+	var eventDataMap map[string]interface{}
+	err := json.Unmarshal(event.Request, &eventDataMap)
 	if err != nil {
-		log.Error("Failed to unmarshal the event, got: " + err.Error())
+		l.LogWithFields(ctx).Error("Failed to unmarshal the event, got: " + err.Error())
 		return false
 	}
-	odatatype := metricReportEventDataMap["@odata.type"]
-	if odatatype != nil && strings.Contains(odatatype.(string), "MetricReport") {
-		event, err = formatMetricReportEventRequest(event)
+	odataType, _ := eventDataMap["@odata.type"].(string)
+	if strings.Contains(odataType, "MetricReport") {
+		event, err = formatMetricReportEvent(event)
 	} else {
-		event, err = formatRedfishEventRequest(event)
+		event, err = FormatEvent(event)
 	}
 	if err != nil {
+		l.LogWithFields(ctx).Error("Failed to format the event, got: " + err.Error())
 		return false
 	}
-	if !writeToMessageBus(event) {
+	if !writeToMessageBus(ctx, event) {
 		return false
 	}
-	log.Info("Forwarded event: " + string(event.Request))
-	log.Info("Event Published")
+	l.LogWithFields(ctx).Debug("Forwarded event: " + string(event.Request))
+	l.LogWithFields(ctx).Info("Event Published")
 	return true
 }
 
-func formatMetricReportEventRequest(eventRequest common.Events) (common.Events, error) {
-	var event common.Events
-	// prepare the device data
-	var devices []rfpmodel.Device
-	rfpmodel.GetAllDevicesInInventory(&devices)
-
-	systemUUID := getMatchingDeviceUUID(eventRequest.IP, devices)
-	if systemUUID == "" {
-		return event, fmt.Errorf("System id is empty")
-	}
-
-	// to replace id in system
-	updatedData := strings.Replace(string(eventRequest.Request), "/redfish/v1/Systems/", "/redfish/v1/Systems/"+systemUUID+".", -1)
-	updatedData = strings.Replace(updatedData, "/redfish/v1/systems/", "/redfish/v1/Systems/"+systemUUID+".", -1)
-	// to replace id in chassis
-	updatedData = strings.Replace(updatedData, "/redfish/v1/Chassis/", "/redfish/v1/Chassis/"+systemUUID+".", -1)
-	updatedData = strings.Replace(updatedData, "/redfish/v1/chassis/", "/redfish/v1/Chassis/"+systemUUID+".", -1)
-
-	event.Request, _ = json.Marshal(updatedData)
-	event.IP = eventRequest.IP
-	event.EventType = "MetricReport"
-
-	return event, nil
-}
-
-func formatRedfishEventRequest(eventRequest common.Events) (common.Events, error) {
-	// Since we are deleting the first event from the eventlist,
-	// processing the first event
+// FormatRedfishEvent converts the event to common.MessageData, devices which
+// send OriginOfCondition without @odata.id are converted through dmtf.Event
+func FormatRedfishEvent(event common.Events) (common.Events, error) {
 	var message common.MessageData
-	err := json.Unmarshal(eventRequest.Request, &message)
-	if err != nil {
-		log.Error("Failed to unmarshal the event: " + err.Error())
-		return eventRequest, err
+	if err := json.Unmarshal(event.Request, &message); err == nil {
+		return event, nil
 	}
 	var messageData dmtf.Event
+	if err := json.Unmarshal(event.Request, &messageData); err != nil {
+		return event, err
+	}
 	message.Context = messageData.Context
 	message.Name = messageData.Name
 	message.OdataType = messageData.ODataType
@@ -117,35 +99,48 @@ func formatRedfishEventRequest(eventRequest common.Events) (common.Events, error
 		eventData.Severity = messageData.Events[i].Severity
 		eventData.Oem = messageData.Events[i].Oem
 		eventData.MessageID = messageData.Events[i].MessageID
-		eventData.OriginOfCondition = &common.Link{
-			Oid: messageData.Events[i].OriginOfCondition.Oid,
+		if messageData.Events[i].OriginOfCondition != nil {
+			eventData.OriginOfCondition = &common.Link{
+				Oid: messageData.Events[i].OriginOfCondition.Oid,
+			}
 		}
 		eventData.MessageArgs = messageData.Events[i].MessageArgs
 		message.Events = append(message.Events, eventData)
 	}
-	eventRequest.Request, _ = json.Marshal(message)
-	return eventRequest, nil
+	event.Request, _ = json.Marshal(message)
+	return event, nil
 }
 
-func getMatchingDeviceUUID(serverIP string, devices []rfpmodel.Device) string {
-	if len(devices) < 1 {
-		return ""
+func formatMetricReportEvent(eventRequest common.Events) (common.Events, error) {
+	var event common.Events
+	// prepare the device data
+	var devices []sdkmodel.Device
+	sdkmodel.GetAllDevicesInInventory(&devices)
+
+	systemUUID := getMatchingDeviceUUID(eventRequest.IP, devices)
+	if systemUUID == "" {
+		return event, fmt.Errorf("no device in the inventory matches %s", eventRequest.IP)
 	}
 
-	front := 0
-	rear := len(devices) - 1
-	for front <= rear {
-		host := getIPFromHostName(devices[front].Host)
-		if host == serverIP {
-			return devices[front].SystemID
-		}
+	// to replace id in system
+	updatedData := strings.Replace(string(eventRequest.Request), "/redfish/v1/Systems/", "/redfish/v1/Systems/"+systemUUID+".", -1)
+	updatedData = strings.Replace(updatedData, "/redfish/v1/systems/", "/redfish/v1/Systems/"+systemUUID+".", -1)
+	// to replace id in chassis
+	updatedData = strings.Replace(updatedData, "/redfish/v1/Chassis/", "/redfish/v1/Chassis/"+systemUUID+".", -1)
+	updatedData = strings.Replace(updatedData, "/redfish/v1/chassis/", "/redfish/v1/Chassis/"+systemUUID+".", -1)
 
-		host = getIPFromHostName(devices[rear].Host)
-		if host == serverIP {
-			return devices[rear].SystemID
+	event.Request, _ = json.Marshal(updatedData)
+	event.IP = eventRequest.IP
+	event.EventType = "MetricReport"
+
+	return event, nil
+}
+
+func getMatchingDeviceUUID(serverIP string, devices []sdkmodel.Device) string {
+	for _, device := range devices {
+		if getIPFromHostName(device.Host) == serverIP {
+			return device.SystemID
 		}
-		front++
-		rear--
 	}
 	return ""
 }
@@ -163,16 +158,15 @@ func getIPFromHostName(fqdn string) string {
 	return fmt.Sprintf("%v", addr[0])
 }
 
-func writeToMessageBus(events common.Events) bool {
+func writeToMessageBus(ctx context.Context, event common.Events) bool {
 	topic := config.Data.MessageBusConf.EmbQueue[0]
 	K, err := dc.Communicator(dc.KAFKA, config.Data.MessageBusConf.MessageBusConfigFilePath, topic)
 	if err != nil {
-		log.Error("Unable communicate with kafka, got: " + err.Error())
+		l.LogWithFields(ctx).Error("Unable communicate with kafka: " + err.Error())
 		return false
 	}
-
-	if err := K.Distribute(events); err != nil {
-		log.Error("Unable Publish events to kafka, got: " + err.Error())
+	if err := K.Distribute(event); err != nil {
+		l.LogWithFields(ctx).Error("Unable Publish events to kafka: " + err.Error())
 		return false
 	}
 	return true
@@ -12,16 +12,18 @@
 //License for the specific language governing permissions and limitations
 // under the License.
 
-// Package dpmessagebus ...
-package dpmessagebus
+// Package sdkmessagebus ...
+package sdkmessagebus
 
 import (
 	"context"
 	"encoding/json"
 	"testing"
 
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkmodel"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
-	"github.com/ODIM-Project/ODIM/plugin-dell/config"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestPublish(t *testing.T) {
@@ -58,6 +60,31 @@ func TestPublish(t *testing.T) {
 			mockConfig: func() {},
 			want:       true,
 		},
+		{
+			name: "Positive test - plugin startup event",
+			args: args{
+				ctx: context.TODO(),
+				data: common.Events{
+					IP:        "10.10.10.10",
+					EventType: "PluginStartUp",
+					Request:   []byte(`{"Name":"Plugin startup event"}`),
+				},
+			},
+			mockConfig: func() {},
+			want:       true,
+		},
+		{
+			name: "Negative test - metric report from unknown device",
+			args: args{
+				ctx: context.TODO(),
+				data: common.Events{
+					IP:      "10.10.10.11",
+					Request: []byte(`{"@odata.type":"#MetricReport.v1_4_2.MetricReport"}`),
+				},
+			},
+			mockConfig: func() {},
+			want:       false,
+		},
 		{
 			name: "Negative test - nil ",
 			args: args{
@@ -119,3 +146,29 @@ func TestPublish(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatRedfishEvent(t *testing.T) {
+	request := []byte(`{"Name":"Event Array","Events":[{"EventType":"Alert","MessageId":"Base.1.0.Success","OriginOfCondition":{"@odata.id":"/redfish/v1/Systems/1"}}]}`)
+	event, err := FormatRedfishEvent(common.Events{IP: "10.10.10.10", Request: request})
+	assert.Nil(t, err)
+	var message common.MessageData
+	assert.Nil(t, json.Unmarshal(event.Request, &message))
+	assert.Equal(t, 1, len(message.Events))
+	assert.Equal(t, "/redfish/v1/Systems/1", message.Events[0].OriginOfCondition.Oid)
+
+	_, err = FormatRedfishEvent(common.Events{Request: []byte(`invalid`)})
+	assert.NotNil(t, err)
+}
+
+func TestFormatMetricReportEvent(t *testing.T) {
+	sdkmodel.AddDeviceToInventory("6d4a0a66-7efa-578e-83cf-44dc68d2874e", sdkmodel.DeviceData{Address: "127.0.0.1"})
+	defer sdkmodel.DeleteDeviceInInventory("6d4a0a66-7efa-578e-83cf-44dc68d2874e")
+
+	event, err := formatMetricReportEvent(common.Events{
+		IP:      "127.0.0.1",
+		Request: []byte(`{"MetricValues":[{"MetricProperty":"/redfish/v1/Systems/1/Processors/1"}]}`),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "MetricReport", event.EventType)
+	assert.Contains(t, string(event.Request), "/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1/Processors/1")
+}
@@ -12,8 +12,8 @@
 //License for the specific language governing permissions and limitations
 // under the License.
 
-// Package dpmiddleware ...
-package dpmiddleware
+// Package sdkmiddleware ...
+package sdkmiddleware
 
 import (
 	"encoding/base64"
@@ -22,7 +22,7 @@ import (
 
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
 
-	"github.com/ODIM-Project/ODIM/plugin-dell/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
 	iris "github.com/kataras/iris/v12"
 	"golang.org/x/crypto/sha3"
 )
@@ -12,15 +12,15 @@
 //License for the specific language governing permissions and limitations
 // under the License.
 
-// Package dpmiddleware ...
-package dpmiddleware
+// Package sdkmiddleware ...
+package sdkmiddleware
 
 import (
 	"fmt"
 	"net/http"
 	"testing"
 
-	"github.com/ODIM-Project/ODIM/plugin-dell/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
 	iris "github.com/kataras/iris/v12"
 	"github.com/kataras/iris/v12/httptest"
 )
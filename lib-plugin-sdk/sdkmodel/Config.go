@@ -12,8 +12,8 @@
 //License for the specific language governing permissions and limitations
 // under the License.
 
-//Package dpmodel ...
-package dpmodel
+// Package sdkmodel ...
+package sdkmodel
 
 // PluginPrivateKey will contains base64encoded private key of plugin
 // this key will be used to decrypt the data.
@@ -12,8 +12,8 @@
 //License for the specific language governing permissions and limitations
 // under the License.
 
-//Package lpmodel ...
-package lpmodel
+// Package sdkmodel ...
+package sdkmodel
 
 //PrivateKey struct is used to define the private key used to decrypt the resouece passwords
 type PrivateKey struct {
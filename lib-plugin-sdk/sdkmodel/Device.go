@@ -12,10 +12,14 @@
 //License for the specific language governing permissions and limitations
 // under the License.
 
-// Package rfpmodel ...
-package rfpmodel
+// Package sdkmodel ...
+package sdkmodel
 
-import "sync"
+import (
+	"sync"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+)
 
 // Device struct definition
 type Device struct {
@@ -25,6 +29,11 @@ type Device struct {
 	PostBody []byte `json:"PostBody"`
 	Location string `json:"Location"`
 	SystemID string `json:"SystemID"`
+	// EventDeliveryMode is Polling when the events of the device are polled by the plugin
+	EventDeliveryMode string `json:"EventDeliveryMode"`
+	// CertificateTrust is how the certificate of the device is verified, it is given
+	// when the device is added and kept by the plugin for the next requests
+	CertificateTrust *common.CertificateTrust `json:"CertificateTrust,omitempty"`
 }
 
 // EvtSubPost ...
@@ -62,12 +71,14 @@ type DeviceInventoryData struct {
 
 // DeviceData holds device credentials, event subcription and trigger details
 type DeviceData struct {
-	UserName              string                 `json:"UserName"`
-	Password              []byte                 `json:"Password"`
-	Address               string                 `json:"Address"`
-	Operation             string                 `json:"Operation"`
-	EventSubscriptionInfo *EventSubscriptionInfo `json:"EventSubscriptionInfo"`
-	TriggerInfo           *TriggerInfo           `json:"TriggerInfo"`
+	UserName              string                   `json:"UserName"`
+	Password              []byte                   `json:"Password"`
+	Address               string                   `json:"Address"`
+	Operation             string                   `json:"Operation"`
+	EventSubscriptionInfo *EventSubscriptionInfo   `json:"EventSubscriptionInfo"`
+	TriggerInfo           *TriggerInfo             `json:"TriggerInfo"`
+	EventDeliveryMode     string                   `json:"EventDeliveryMode"`
+	CertificateTrust      *common.CertificateTrust `json:"CertificateTrust,omitempty"`
 }
 
 // EventSubscriptionInfo holds the event subscription details of a device
@@ -12,8 +12,8 @@
 //License for the specific language governing permissions and limitations
 // under the License.
 
-//Package lpmodel ...
-package lpmodel
+// Package sdkmodel ...
+package sdkmodel
 
 // ResetPostRequest stores the reset type
 type ResetPostRequest struct {
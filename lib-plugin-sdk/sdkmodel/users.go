@@ -12,8 +12,8 @@
 //License for the specific language governing permissions and limitations
 // under the License.
 
-//Package rfpmodel ...
-package rfpmodel
+// Package sdkmodel ...
+package sdkmodel
 
 //Users struct definition
 type Users struct {
@@ -0,0 +1,244 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package sdkplugin starts a plugin built on lib-plugin-sdk. It loads the
+// configuration, registers the REST and event interfaces mandated for ODIM
+// plugins and hands the vendor specific routes to the plugin.
+package sdkplugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	dc "github.com/ODIM-Project/ODIM/lib-messagebus/datacommunicator"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkhandler"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkmessagebus"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkmiddleware"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	lutilconf "github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	iris "github.com/kataras/iris/v12"
+	"github.com/sirupsen/logrus"
+)
+
+// Plugin holds the vendor specific parts of a plugin
+type Plugin struct {
+	// ID is the plugin ID used when PluginConf.ID is not configured
+	ID string
+	// Routes registers the vendor handlers on the /ODIM/v1 party,
+	// the session, validate, subscription, status and startup routes
+	// are registered by the SDK
+	Routes func(pluginRoutes iris.Party)
+	// FormatEvent replaces the default formatting of the redfish events
+	// received from the devices, it is used for OEM event translation
+	FormatEvent func(event common.Events) (common.Events, error)
+	// OmitSubscriptionEventTypes removes the EventTypes from the event
+	// subscriptions posted to the devices which do not support them
+	OmitSubscriptionEventTypes bool
+}
+
+// Run loads the plugin configuration and starts the plugin and event servers,
+// it returns only when the plugin server stops
+func (p *Plugin) Run() {
+	// intializing the plugin start time
+	hostName := os.Getenv("HOST_NAME")
+	podName := os.Getenv("POD_NAME")
+	pid := os.Getpid()
+	sdkutilities.PluginStartTime = time.Now()
+	log := logs.Log
+	logs.Adorn(logrus.Fields{
+		"host":   hostName,
+		"procid": podName + fmt.Sprintf("_%d", pid),
+	})
+	if p.ID != "" {
+		config.DefaultPluginID = p.ID
+	}
+	if err := config.SetConfiguration(); err != nil {
+		log.Logger.SetFormatter(&logs.SysLogFormatter{})
+		logs.Log.Fatal("Error while trying set up configuration: " + err.Error())
+	}
+	logs.SetFormatter(config.Data.LogFormat)
+	log.Logger.SetOutput(os.Stdout)
+	log.Logger.SetLevel(config.Data.LogLevel)
+	log.Info("Plugin Start time:", sdkutilities.PluginStartTime.Format(time.RFC3339))
+
+	// verifying the uid of the user
+	if uid := os.Geteuid(); uid == 0 {
+		log.Fatal("Plugin Service should not be run as the root user")
+	}
+
+	if err := dc.SetConfiguration(config.Data.MessageBusConf.MessageBusConfigFilePath); err != nil {
+		log.Fatal("While trying to set messagebus configuration, got: " + err.Error())
+	}
+
+	if p.FormatEvent != nil {
+		sdkmessagebus.FormatEvent = p.FormatEvent
+	}
+	sdkutilities.CertificateChanged = sdkhandler.PublishCertificateChanged
+	sdkhandler.OmitSubscriptionEventTypes = p.OmitSubscriptionEventTypes
+
+	// CreateJobQueue defines the queue which will act as an infinite buffer
+	// In channel is an entry or input channel and the Out channel is an exit or output channel
+	jobQueueSize := 10
+	sdkhandler.In, sdkhandler.Out = common.CreateJobQueue(jobQueueSize)
+
+	// RunReadWorkers will create a worker pool for doing a specific task
+	// which is passed to it as Publish method after reading the data from the channel.
+
+	// should be removed when context from svc-api is passed to this function
+	ctx := context.TODO()
+	ctx = context.WithValue(ctx, common.ThreadID, common.DefaultThreadID)
+	go common.RunReadWorkers(ctx, sdkhandler.Out, sdkmessagebus.Publish, 5)
+
+	configFilePath := os.Getenv("PLUGIN_CONFIG_FILE_PATH")
+	if configFilePath == "" {
+		log.Fatal("No value get the environment variable PLUGIN_CONFIG_FILE_PATH")
+	}
+	errChan := make(chan error)
+
+	// TrackConfigFileChanges monitors the plugin config changes using fsnotfiy
+	go sdkutilities.TrackIPConfigListener(configFilePath, errChan)
+
+	// logout of the device sessions held by the plugin when it is stopped
+	iris.RegisterOnInterrupt(func() {
+		sdkutilities.CloseDeviceSessions(ctx)
+	})
+
+	intializePluginStatus()
+	p.app()
+}
+
+func (p *Plugin) app() {
+	go sendStartupEvent()
+	go eventsrouters()
+
+	app := p.Routers()
+	conf := &lutilconf.HTTPConfig{
+		Certificate:   &config.Data.KeyCertConf.Certificate,
+		PrivateKey:    &config.Data.KeyCertConf.PrivateKey,
+		CACertificate: &config.Data.KeyCertConf.RootCACertificate,
+		ServerAddress: config.Data.PluginConf.Host,
+		ServerPort:    config.Data.PluginConf.Port,
+	}
+	pluginServer, err := conf.GetHTTPServerObj()
+	if err != nil {
+		logs.Log.Fatal("While initializing plugin server: " + err.Error())
+	}
+	app.Run(iris.Server(pluginServer))
+}
+
+// Routers returns the iris application with the SDK and the vendor routes
+func (p *Plugin) Routers() *iris.Application {
+	app := iris.New()
+	app.WrapRouter(func(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+		ctx := getContextHeader(r)
+		r = r.WithContext(ctx)
+		path := r.URL.Path
+		if len(path) > 1 && path[len(path)-1] == '/' && path[len(path)-2] != '/' {
+			path = path[:len(path)-1]
+			r.RequestURI = path
+			r.URL.Path = path
+		}
+		next(w, r)
+	})
+
+	pluginRoutes := app.Party("/ODIM/v1")
+	{
+		pluginRoutes.Post("/validate", sdkmiddleware.BasicAuth, sdkhandler.Validate)
+		pluginRoutes.Post("/Sessions", sdkhandler.CreateSession)
+		pluginRoutes.Post("/Subscriptions", sdkmiddleware.BasicAuth, sdkhandler.CreateEventSubscription)
+		pluginRoutes.Delete("/Subscriptions", sdkmiddleware.BasicAuth, sdkhandler.DeleteEventSubscription)
+		pluginRoutes.Post("/CertificateService/Actions/CertificateService.ReplaceCertificate", sdkmiddleware.BasicAuth, sdkhandler.ReplaceCertificate)
+
+		if p.Routes != nil {
+			p.Routes(pluginRoutes)
+		}
+	}
+	pluginRoutes.Get("/Status", sdkhandler.GetPluginStatus)
+	pluginRoutes.Post("/Startup", sdkmiddleware.BasicAuth, sdkhandler.GetPluginStartup)
+	return app
+}
+
+func eventsrouters() {
+	conf := &lutilconf.HTTPConfig{
+		Certificate:   &config.Data.KeyCertConf.Certificate,
+		PrivateKey:    &config.Data.KeyCertConf.PrivateKey,
+		CACertificate: &config.Data.KeyCertConf.RootCACertificate,
+		ServerAddress: config.Data.EventConf.ListenerHost,
+		ServerPort:    config.Data.EventConf.ListenerPort,
+	}
+	evtServer, err := conf.GetHTTPServerObj()
+	if err != nil {
+		logs.Log.Fatalf("fatal: error while initializing event server: %v", err)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(config.Data.EventConf.DestURI, sdkhandler.RedfishEvents)
+	evtServer.Handler = mux
+	logs.Log.Fatal(evtServer.ListenAndServeTLS("", ""))
+}
+
+// intializePluginStatus sets plugin status
+func intializePluginStatus() {
+	sdkutilities.Status.Available = "yes"
+}
+
+// sendStartupEvent is for sending startup event
+func sendStartupEvent() {
+	// grace wait time for plugin to be functional
+	time.Sleep(3 * time.Second)
+
+	var pluginIP string
+	if pluginIP = os.Getenv("ASSIGNED_POD_IP"); pluginIP == "" {
+		pluginIP = config.Data.PluginConf.Host
+	}
+
+	startupEvt := common.PluginStatusEvent{
+		Name:         "Plugin startup event",
+		Type:         "PluginStarted",
+		Timestamp:    time.Now().String(),
+		OriginatorID: pluginIP,
+	}
+
+	request, _ := json.Marshal(startupEvt)
+	event := common.Events{
+		IP:        net.JoinHostPort(config.Data.PluginConf.Host, config.Data.PluginConf.Port),
+		Request:   request,
+		EventType: "PluginStartUp",
+	}
+
+	done := make(chan bool)
+	events := []interface{}{event}
+	go common.RunWriteWorkers(sdkhandler.In, events, 1, done)
+	logs.Log.Info("successfully sent startup event")
+}
+
+// getContextHeader is used to get context details from header for logging
+func getContextHeader(r *http.Request) context.Context {
+	ctx := context.Background()
+	podName := os.Getenv("POD_NAME")
+	ctx = context.WithValue(ctx, common.TransactionID, r.Header.Get(common.TransactionID))
+	ctx = context.WithValue(ctx, common.ThreadID, r.Header.Get(common.ThreadID))
+	ctx = context.WithValue(ctx, common.ThreadName, r.Header.Get(common.ThreadName))
+	ctx = context.WithValue(ctx, common.ActionID, r.Header.Get(common.ActionID))
+	ctx = context.WithValue(ctx, common.ActionName, r.Header.Get(common.ActionName))
+	ctx = context.WithValue(ctx, common.ProcessName, podName)
+	return ctx
+}
@@ -12,12 +12,17 @@
 //License for the specific language governing permissions and limitations
 // under the License.
 
-//Package rfpresponse ...
-package rfpresponse
+// Package sdkresponse ...
+package sdkresponse
+
+import "github.com/ODIM-Project/ODIM/lib-utilities/common"
 
 //Device struct definition
 type Device struct {
 	ServerIP   string `json:"ServerIP"`
 	Username   string `json:"Username"`
 	DeviceUUID string `json:"device_UUID"`
+	// CertificateTrust is the trust set for the device, with the certificate
+	// captured from the device when it is trusted on first use
+	CertificateTrust *common.CertificateTrust `json:"CertificateTrust,omitempty"`
 }
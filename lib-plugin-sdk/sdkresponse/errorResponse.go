@@ -12,10 +12,11 @@
 //License for the specific language governing permissions and limitations
 // under the License.
 
-// Package dpresponse ...
-package dpresponse
+// Package sdkresponse ...
+package sdkresponse
 
 import (
+	errorResponse "github.com/ODIM-Project/ODIM/lib-utilities/response"
 	iris "github.com/kataras/iris/v12"
 )
 
@@ -42,11 +43,11 @@ type MsgExtendedInfo struct {
 func CreateErrorResponse(errs string) ErrorResponse {
 	var err = ErrorResponse{
 		Error{
-			Code:    "Base.1.13.0.GeneralError",
+			Code:    errorResponse.GeneralError,
 			Message: "See @Message.ExtendedInfo for more information.",
 			MessageExtendedInfo: []MsgExtendedInfo{
 				{
-					MessageID: "Base.1.13.0.GeneralError",
+					MessageID: errorResponse.GeneralError,
 					Message:   errs,
 				},
 			},
@@ -12,8 +12,8 @@
 //License for the specific language governing permissions and limitations
 // under the License.
 
-//Package dpresponse ...
-package dpresponse
+// Package sdkresponse ...
+package sdkresponse
 
 //PluginStatusResponse holds the information of response of PluginStatus
 type PluginStatusResponse struct {
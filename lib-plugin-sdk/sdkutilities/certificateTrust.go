@@ -0,0 +1,228 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package sdkutilities
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	lutilconf "github.com/ODIM-Project/ODIM/lib-utilities/config"
+)
+
+// deviceTrust is the trust of the plugin in the certificate of a device
+type deviceTrust struct {
+	host  string
+	trust common.CertificateTrust
+	// roots verify the certificate of a device in CAChain mode
+	roots *x509.CertPool
+	// fingerprint is the fingerprint of the certificate pinned in TrustOnFirstUse mode
+	fingerprint string
+	// previous is the fingerprint of the certificate pinned before the certificate of the
+	// device was replaced, it is accepted until the device presents the new certificate
+	previous string
+	// reported is the fingerprint of the changed certificate the last event was raised for
+	reported string
+}
+
+// certificateTrustStore holds the certificate trust of the devices by their host:port address.
+// The devices without a trust are verified with the root CA certificate of the plugin.
+type certificateTrustStore struct {
+	mutex   sync.Mutex
+	devices map[string]*deviceTrust
+}
+
+var certificateTrusts = &certificateTrustStore{
+	devices: make(map[string]*deviceTrust),
+}
+
+// CertificateChanged is called when a device trusted on first use presents a certificate other
+// than the one pinned for it, with the fingerprints of the pinned and the presented certificates.
+// It is called once for each changed certificate of the device.
+var CertificateChanged func(host, pinned, presented string)
+
+// deviceAddress returns the host:port address of the device, 443 is the port of a device given without one
+func deviceAddress(host string) string {
+	host = strings.ToLower(host)
+	if _, _, err := net.SplitHostPort(host); err == nil {
+		return host
+	}
+	return net.JoinHostPort(strings.Trim(host, "[]"), "443")
+}
+
+// SetCertificateTrust sets how the certificate of the device is verified. The pooled
+// connections with the devices are closed when the trust of the device is changed.
+func SetCertificateTrust(host string, trust common.CertificateTrust) error {
+	return certificateTrusts.set(host, trust, false)
+}
+
+// RenewCertificateTrust sets the trust of the device after its certificate is replaced.
+// The device may keep presenting its previous certificate for a while, which is accepted
+// until the device presents the certificate pinned now.
+func RenewCertificateTrust(host string, trust common.CertificateTrust) error {
+	return certificateTrusts.set(host, trust, true)
+}
+
+// RemoveCertificateTrust drops the trust of the device, which is verified
+// with the root CA certificate of the plugin afterwards
+func RemoveCertificateTrust(host string) {
+	certificateTrusts.mutex.Lock()
+	_, exist := certificateTrusts.devices[deviceAddress(host)]
+	delete(certificateTrusts.devices, deviceAddress(host))
+	certificateTrusts.mutex.Unlock()
+	if exist {
+		closeIdleDeviceConnections()
+	}
+}
+
+// GetCertificateTrust returns the trust of the device, the second value is false
+// when the device is verified with the root CA certificate of the plugin
+func GetCertificateTrust(host string) (common.CertificateTrust, bool) {
+	certificateTrusts.mutex.Lock()
+	defer certificateTrusts.mutex.Unlock()
+	device, exist := certificateTrusts.devices[deviceAddress(host)]
+	if !exist {
+		return common.CertificateTrust{Mode: common.CertificateTrustModeRootCA}, false
+	}
+	return device.trust, true
+}
+
+// CaptureCertificate returns the certificate presented by the device, without verifying it.
+// It is used to pin the certificate of a device trusted on first use.
+func CaptureCertificate(ctx context.Context, host string) (*x509.Certificate, error) {
+	tlsConfig, err := getDeviceTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig.InsecureSkipVerify = true
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: time.Duration(lutilconf.DefaultTLSHandShakeTimeout) * time.Second},
+		Config:    tlsConfig,
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", deviceAddress(host))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	certs := conn.(*tls.Conn).ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("device %s did not present a certificate", host)
+	}
+	return certs[0], nil
+}
+
+func (store *certificateTrustStore) set(host string, trust common.CertificateTrust, renew bool) error {
+	if err := trust.Validate(); err != nil {
+		return err
+	}
+	if trust.Mode == common.CertificateTrustModeRootCA {
+		RemoveCertificateTrust(host)
+		return nil
+	}
+	if trust.Certificate == "" {
+		return fmt.Errorf("the certificate of device %s is not captured", host)
+	}
+	certs, err := common.ParseCertificates(trust.Certificate)
+	if err != nil {
+		return err
+	}
+	device := &deviceTrust{
+		host:  host,
+		trust: trust,
+	}
+	switch trust.Mode {
+	case common.CertificateTrustModeTrustOnFirstUse:
+		device.fingerprint = common.CertificateFingerprint(certs[0])
+		device.trust.Fingerprint = device.fingerprint
+	case common.CertificateTrustModeCAChain:
+		device.roots = x509.NewCertPool()
+		for _, cert := range certs {
+			device.roots.AddCert(cert)
+		}
+	}
+
+	address := deviceAddress(host)
+	store.mutex.Lock()
+	current, exist := store.devices[address]
+	if exist && current.trust.Mode == trust.Mode && current.trust.Certificate == trust.Certificate {
+		store.mutex.Unlock()
+		return nil
+	}
+	if renew && exist {
+		device.previous = current.fingerprint
+	}
+	store.devices[address] = device
+	store.mutex.Unlock()
+	closeIdleDeviceConnections()
+	return nil
+}
+
+// tlsConfig returns the TLS configuration verifying the device at the address with its trust
+func (store *certificateTrustStore) tlsConfig(address string, base *tls.Config) *tls.Config {
+	address = strings.ToLower(address)
+	store.mutex.Lock()
+	device, exist := store.devices[address]
+	store.mutex.Unlock()
+	if !exist {
+		return base
+	}
+	tlsConfig := base.Clone()
+	switch device.trust.Mode {
+	case common.CertificateTrustModeTrustOnFirstUse:
+		// the pinned certificate replaces the verification of the chain and of the host name
+		tlsConfig.InsecureSkipVerify = true
+		tlsConfig.VerifyConnection = func(state tls.ConnectionState) error {
+			return store.verifyPinnedCertificate(address, state)
+		}
+	case common.CertificateTrustModeCAChain:
+		tlsConfig.RootCAs = device.roots
+	}
+	return tlsConfig
+}
+
+// verifyPinnedCertificate checks that the device presents its pinned certificate
+func (store *certificateTrustStore) verifyPinnedCertificate(address string, state tls.ConnectionState) error {
+	if len(state.PeerCertificates) == 0 {
+		return fmt.Errorf("device %s did not present a certificate", address)
+	}
+	presented := common.CertificateFingerprint(state.PeerCertificates[0])
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	device, exist := store.devices[address]
+	if !exist {
+		return fmt.Errorf("no certificate is pinned for device %s", address)
+	}
+	switch presented {
+	case device.fingerprint:
+		device.previous = ""
+		device.reported = ""
+		return nil
+	case device.previous:
+		return nil
+	}
+	if device.reported != presented {
+		device.reported = presented
+		if CertificateChanged != nil {
+			go CertificateChanged(device.host, device.fingerprint, presented)
+		}
+	}
+	return fmt.Errorf("certificate of device %s with fingerprint %s does not match the pinned certificate", address, presented)
+}
@@ -0,0 +1,153 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package sdkutilities
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// newSelfSignedCertificate returns a certificate other than the one of the mock BMC, in PEM format
+func newSelfSignedCertificate(t *testing.T) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "other"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func setUpCertificateTrust(t *testing.T) {
+	config.SetUpMockConfig(t)
+	ResetDeviceConnections()
+	certificateTrusts = &certificateTrustStore{devices: make(map[string]*deviceTrust)}
+}
+
+func deviceCallStatus(t *testing.T, device *RedfishDevice) (int, error) {
+	client, err := GetRedfishClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.DeviceCall(device, "/redfish/v1/Systems", http.MethodGet)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+func TestCertificateTrustOnFirstUse(t *testing.T) {
+	setUpCertificateTrust(t)
+	ts, _ := startMockBMC(t, &mockBMC{})
+	defer ts.Close()
+	device := &RedfishDevice{Host: ts.Listener.Addr().String(), Username: "admin", Password: "password"}
+	changed := make(chan []string, 2)
+	CertificateChanged = func(host, pinned, presented string) {
+		changed <- []string{host, pinned, presented}
+	}
+	defer func() { CertificateChanged = nil }()
+
+	cert, err := CaptureCertificate(context.TODO(), device.Host)
+	assert.Nil(t, err)
+	pinned := common.PinCertificate(cert)
+	assert.Nil(t, SetCertificateTrust(device.Host, pinned))
+	status, err := deviceCallStatus(t, device)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, status)
+
+	// the device presents a certificate other than the pinned one
+	other := common.CertificateTrust{Mode: common.CertificateTrustModeTrustOnFirstUse, Certificate: newSelfSignedCertificate(t)}
+	assert.Nil(t, SetCertificateTrust(device.Host, other))
+	for i := 0; i < 2; i++ {
+		_, err = deviceCallStatus(t, device)
+		assert.NotNil(t, err, "the changed certificate should be rejected")
+	}
+	select {
+	case args := <-changed:
+		trust, _ := GetCertificateTrust(device.Host)
+		assert.Equal(t, []string{device.Host, trust.Fingerprint, pinned.Fingerprint}, args)
+	case <-time.After(time.Second):
+		t.Fatal("no certificate change reported")
+	}
+	assert.Empty(t, changed, "the change should be reported once")
+
+	// the previous certificate is accepted while the device is not presenting the renewed one
+	assert.Nil(t, SetCertificateTrust(device.Host, pinned))
+	assert.Nil(t, RenewCertificateTrust(device.Host, other))
+	status, err = deviceCallStatus(t, device)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, status)
+
+	// the device is verified with the root CA certificate of the plugin without a trust
+	RemoveCertificateTrust(device.Host)
+	_, exist := GetCertificateTrust(device.Host)
+	assert.False(t, exist)
+	status, err = deviceCallStatus(t, device)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func TestCertificateTrustCAChain(t *testing.T) {
+	setUpCertificateTrust(t)
+	config.Data.KeyCertConf.RootCACertificate = []byte(newSelfSignedCertificate(t))
+	ts, _ := startMockBMC(t, &mockBMC{})
+	defer ts.Close()
+	device := &RedfishDevice{Host: ts.Listener.Addr().String(), Username: "admin", Password: "password"}
+
+	_, err := deviceCallStatus(t, device)
+	assert.NotNil(t, err, "the device should not be verified by the root CA certificate of the plugin")
+
+	assert.Nil(t, SetCertificateTrust(device.Host, common.CertificateTrust{
+		Mode:        common.CertificateTrustModeCAChain,
+		Certificate: string(hostCA),
+	}))
+	status, err := deviceCallStatus(t, device)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, status)
+
+	assert.NotNil(t, SetCertificateTrust(device.Host, common.CertificateTrust{Mode: common.CertificateTrustModeCAChain}))
+	assert.NotNil(t, SetCertificateTrust(device.Host, common.CertificateTrust{Mode: common.CertificateTrustModeTrustOnFirstUse}))
+}
+
+func TestDeviceAddress(t *testing.T) {
+	assert.Equal(t, "10.0.0.1:443", deviceAddress("10.0.0.1"))
+	assert.Equal(t, "bmc.example.com:8443", deviceAddress("BMC.example.com:8443"))
+	assert.Equal(t, "[fe80::1]:443", deviceAddress("fe80::1"))
+	assert.Equal(t, "[fe80::1]:443", deviceAddress("[fe80::1]"))
+}
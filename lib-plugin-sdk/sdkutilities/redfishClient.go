@@ -0,0 +1,262 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package sdkutilities ...
+package sdkutilities
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/gofrs/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// RedfishDeviceCollection struct definition
+type RedfishDeviceCollection struct {
+	RedfishDevices            []*RedfishDevice           `json:"targetHosts"`
+	UnreachableRedfishDevices []UnreachableRedfishDevice `json:"failedHosts,omitempty"`
+}
+
+// RedfishDevice struct definition
+type RedfishDevice struct {
+	Host            string            `json:"hostAddress"`
+	Username        string            `json:"username,omitempty"`
+	Password        string            `json:"password,omitempty"`
+	Token           string            `json:"token,omitempty"`
+	Tags            []string          `json:"Tags"`
+	RootNode        *dmtf.ServiceRoot `json:"rootNode,omitempty"`
+	ComputerSystems []*Identifier
+	PostBody        []byte `json:"PostBody,omitempty"`
+	Location        string `json:"Location"`
+}
+
+// Identifier struct definition
+type Identifier struct {
+	UUID uuid.UUID
+	URI  string
+}
+
+// UnreachableRedfishDevice struct definition
+type UnreachableRedfishDevice struct {
+	Host         string `json:"hostAddress"`
+	ErrorMessage string `json:"errorMessage"`
+}
+
+// RedfishClient struct definition
+type RedfishClient struct {
+	httpClient *http.Client
+}
+
+// MarshalJSON Custom marshalling code used to prevent the display of password or authtoken
+func (rfd RedfishDevice) MarshalJSON() ([]byte, error) {
+	type redfishdevice RedfishDevice
+
+	sanitizedRedfishDevice := redfishdevice(rfd)
+	sanitizedRedfishDevice.Password = ""
+	sanitizedRedfishDevice.Token = ""
+
+	return json.Marshal(sanitizedRedfishDevice)
+}
+
+var redfishServiceRootURI = "/redfish/v1"
+
+// GetRedfishClient : Returns a RedfishClient using the connection pool shared by all the requests to the devices.
+func GetRedfishClient() (*RedfishClient, error) {
+	httpClient, err := getDeviceHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	return &RedfishClient{httpClient: httpClient}, nil
+}
+
+// Get : Executes the REST call with the specified host and URI, then returns the response object.
+func (client *RedfishClient) Get(device *RedfishDevice, requestURI string) (*http.Response, error) {
+	endpoint := fmt.Sprintf("https://%s%s", device.Host, requestURI)
+	req, err := newDeviceRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	if device.Token != "" {
+		req.Header.Set("X-Auth-Token", device.Token)
+	}
+	return client.send(req)
+}
+
+// GetRootService : Retrieves the ServiceRoot endpoint for the device and saves the return in the device object
+func (client *RedfishClient) GetRootService(device *RedfishDevice) error {
+	resp, err := client.Get(device, redfishServiceRootURI)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		errMessage := "Could not retrieve ServiceRoot for " + device.Host + ": " + string(body)
+		log.Error(errMessage)
+		return fmt.Errorf(errMessage)
+	}
+	serviceRoot := &dmtf.ServiceRoot{}
+	json.Unmarshal(body, serviceRoot)
+	device.RootNode = serviceRoot
+	return nil
+}
+
+// AuthWithDevice : Performs authentication with the given device and saves the token
+func (client *RedfishClient) AuthWithDevice(ctx context.Context, device *RedfishDevice) error {
+	if device.RootNode == nil {
+		return fmt.Errorf("no ServiceRoot found for device")
+	}
+	token, _, err := client.createSession(device)
+	if err != nil {
+		return err
+	}
+	device.Token = token
+	l.LogWithFields(ctx).Debugf("session created with device %s", device.Host)
+	return nil
+}
+
+// BasicAuthWithDevice : Validates the credentials of the device with basic authentication,
+// a cached BMC session is not used so that the given credentials are always sent to the device
+func (client *RedfishClient) BasicAuthWithDevice(ctx context.Context, device *RedfishDevice, requestURI string) (*http.Response, error) {
+	endpoint := fmt.Sprintf("https://%s%s", device.Host, requestURI)
+	req, err := newDeviceRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(device.Username, device.Password)
+	return client.send(req)
+}
+
+// GetWithBasicAuth : Executes a GET on the device with the device credentials
+func (client *RedfishClient) GetWithBasicAuth(device *RedfishDevice, requestURI string) (*http.Response, error) {
+	return client.do(device, http.MethodGet, fmt.Sprintf("https://%s%s", device.Host, requestURI), nil, nil)
+}
+
+// SubscribeForEvents :Subscribes for events with the device credentials
+func (client *RedfishClient) SubscribeForEvents(device *RedfishDevice) (*http.Response, error) {
+	endpoint := fmt.Sprintf("https://%s%s", device.Host, "/redfish/v1/EventService/Subscriptions")
+	return client.do(device, http.MethodPost, endpoint, device.PostBody, nil)
+}
+
+// ResetComputerSystem :Reset the computer system with given ResetType
+func (client *RedfishClient) ResetComputerSystem(device *RedfishDevice, uri string) (*http.Response, error) {
+	return client.do(device, http.MethodPost, "https://"+device.Host+uri, device.PostBody, nil)
+}
+
+// SetDefaultBootOrder : sets default boot order
+func (client *RedfishClient) SetDefaultBootOrder(device *RedfishDevice, uri string) (*http.Response, error) {
+	return client.do(device, http.MethodPost, "https://"+device.Host+uri, nil, nil)
+}
+
+// DeleteSubscriptionDetail will accepts device struct
+// and it will delete the subscription detail
+func (client *RedfishClient) DeleteSubscriptionDetail(device *RedfishDevice) (*http.Response, error) {
+	return client.do(device, http.MethodDelete, device.Location, nil, nil)
+}
+
+// DeviceCall will call device with the given device details on the url given
+func (client *RedfishClient) DeviceCall(device *RedfishDevice, url, method string) (*http.Response, error) {
+	return client.do(device, method, fmt.Sprintf("https://%s%s", device.Host, url), device.PostBody, nil)
+}
+
+// DeviceCallWithHeader will call device with the given device details on the url given,
+// the given headers are added to the request and replace the default ones,
+// like the Content-Type of a multipart body or the If-Match of a PATCH
+func (client *RedfishClient) DeviceCallWithHeader(device *RedfishDevice, url, method string, header http.Header) (*http.Response, error) {
+	return client.do(device, method, fmt.Sprintf("https://%s%s", device.Host, url), device.PostBody, header)
+}
+
+// GetSubscriptionDetail will accepts device struct
+// and it will get the subscription detail
+func (client *RedfishClient) GetSubscriptionDetail(device *RedfishDevice) (*http.Response, error) {
+	return client.do(device, http.MethodGet, device.Location, nil, nil)
+}
+
+// do sends the request to the device. When AuthType is Session the cached BMC
+// session of the device is used, and created again once when the device
+// rejects it. Basic authentication is used when sessions are disabled or
+// when a session could not be created with the device.
+func (client *RedfishClient) do(device *RedfishDevice, method, endpoint string, body []byte, header http.Header) (*http.Response, error) {
+	if useDeviceSessions() {
+		token, err := client.sessionToken(device)
+		if err == nil {
+			var resp *http.Response
+			resp, err = client.doWithToken(method, endpoint, body, header, token)
+			if err != nil || resp.StatusCode != http.StatusUnauthorized {
+				return resp, err
+			}
+			// the session was closed or expired on the device, login again
+			resp.Body.Close()
+			deviceSessions.invalidate(device, token)
+			token, err = client.sessionToken(device)
+			if err == nil {
+				return client.doWithToken(method, endpoint, body, header, token)
+			}
+		}
+		log.Debug("using basic authentication with device " + device.Host + ": " + err.Error())
+	}
+	req, err := newDeviceRequest(method, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	setHeader(req, header)
+	req.SetBasicAuth(device.Username, device.Password)
+	return client.send(req)
+}
+
+func (client *RedfishClient) doWithToken(method, endpoint string, body []byte, header http.Header, token string) (*http.Response, error) {
+	req, err := newDeviceRequest(method, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	setHeader(req, header)
+	req.Header.Set("X-Auth-Token", token)
+	return client.send(req)
+}
+
+// newDeviceRequest creates a request with the headers sent on every call to the device
+func newDeviceRequest(method, endpoint string, body []byte) (*http.Request, error) {
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewBuffer(body)
+	}
+	req, err := http.NewRequest(method, endpoint, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	return req, nil
+}
+
+// setHeader replaces the headers of the request with the given ones
+func setHeader(req *http.Request, header http.Header) {
+	for key, values := range header {
+		req.Header.Del(key)
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+}
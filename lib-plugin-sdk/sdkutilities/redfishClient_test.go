@@ -12,8 +12,8 @@
 //License for the specific language governing permissions and limitations
 // under the License.
 
-// Package dputilities ...
-package dputilities
+// Package sdkutilities ...
+package sdkutilities
 
 import (
 	"crypto/tls"
@@ -24,8 +24,8 @@ import (
 	testhttp "net/http/httptest"
 	"testing"
 
-	"github.com/ODIM-Project/ODIM/plugin-dell/config"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dpmodel"
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 )
@@ -37,7 +37,7 @@ func TestRedfishDevice_MarshalJSON(t *testing.T) {
 		Password        string
 		Token           string
 		Tags            []string
-		RootNode        *dpmodel.ServiceRoot
+		RootNode        *dmtf.ServiceRoot
 		ComputerSystems []*Identifier
 		PostBody        []byte
 		Location        string
@@ -153,7 +153,7 @@ func TestAuthWithDevice_Success(t *testing.T) {
 	client := &RedfishClient{httpClient: t1.Client()}
 
 	// Set the RootNode property of the device object
-	device.RootNode = &dpmodel.ServiceRoot{}
+	device.RootNode = &dmtf.ServiceRoot{}
 
 	// Call the AuthWithDevice method
 	ctxt := mockContext()
@@ -208,8 +208,32 @@ func TestAuthWithDevice_Success(t *testing.T) {
 	}
 	redfishServiceRootURI = "/redfish/v1/GetRootService"
 	err = client.GetRootService(device)
-	if err != nil {
-		t.Errorf("Expected no error, but got: %v", err)
+	assert.NotNil(t, err, "GetRootService should fail when the device does not return the service root")
+}
+
+func TestDeviceCallWithHeader(t *testing.T) {
+	for _, authType := range []string{config.BasicAuth, config.SessionAuth} {
+		setUpSessionConfig(t, 1)
+		config.Data.DeviceSessionConf.AuthType = authType
+		bmc := &mockBMC{}
+		ts, client := startMockBMC(t, bmc)
+		device := &RedfishDevice{Host: ts.Listener.Addr().String(), Username: "admin", Password: "password", PostBody: []byte("{}")}
+
+		header := http.Header{}
+		header.Set("Content-Type", "multipart/form-data; boundary=abc")
+		header.Set("If-Match", `"etag"`)
+		resp, err := client.DeviceCallWithHeader(device, "/redfish/v1/UpdateService/upload", http.MethodPost, header)
+		assert.Nil(t, err, authType)
+		assert.Equal(t, http.StatusOK, resp.StatusCode, authType)
+		assert.Equal(t, "multipart/form-data; boundary=abc", bmc.header.Get("Content-Type"), authType)
+		assert.Equal(t, []string{`"etag"`}, bmc.header.Values("If-Match"), authType)
+		assert.Equal(t, "application/json", bmc.header.Get("Accept"), authType)
+
+		_, err = client.DeviceCall(device, "/redfish/v1/Systems", http.MethodGet)
+		assert.Nil(t, err, authType)
+		assert.Equal(t, "application/json", bmc.header.Get("Content-Type"), authType)
+		assert.Empty(t, bmc.header.Get("If-Match"), authType)
+		ts.Close()
 	}
 }
 
@@ -0,0 +1,386 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package sdkutilities
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	lutilconf "github.com/ODIM-Project/ODIM/lib-utilities/config"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+)
+
+const (
+	sessionServiceURI = "/redfish/v1/SessionService/Sessions"
+	// sessionRetryInterval is the time for which a failed session creation is not
+	// retried with the device, basic authentication is used meanwhile
+	sessionRetryInterval = time.Minute
+)
+
+var (
+	// clientMutex guards the creation of the client used for the device requests
+	clientMutex      sync.Mutex
+	deviceHTTPClient *http.Client
+	// deviceClientCA is the CA certificate deviceHTTPClient was created with
+	deviceClientCA []byte
+	// deviceTLSConfig is the TLS configuration of the devices verified with the CA certificate
+	deviceTLSConfig *tls.Config
+)
+
+// deviceSession is a BMC session created by the plugin with a device
+type deviceSession struct {
+	host     string
+	lastUsed time.Time
+	// mutex serialises the session creation so that concurrent requests
+	// to the device share one BMC session
+	mutex         sync.Mutex
+	token         string
+	location      string
+	loginErr      error
+	loginFailedAt time.Time
+}
+
+// deviceSessionStore holds the BMC sessions of all the devices
+type deviceSessionStore struct {
+	mutex    sync.Mutex
+	sessions map[string]*deviceSession
+}
+
+var deviceSessions = &deviceSessionStore{
+	sessions: make(map[string]*deviceSession),
+}
+
+// getDeviceHTTPClient returns the client shared by all the requests to the
+// devices. Its transport keeps a pool of keep-alive connections with each
+// device, the client is created again when the CA certificate is changed.
+// The certificate of each device is verified with the trust set for the device.
+func getDeviceHTTPClient() (*http.Client, error) {
+	clientMutex.Lock()
+	defer clientMutex.Unlock()
+	caCert := config.Data.KeyCertConf.RootCACertificate
+	if deviceHTTPClient != nil && bytes.Equal(caCert, deviceClientCA) {
+		return deviceHTTPClient, nil
+	}
+	tlsConfig := &tls.Config{}
+	httpConf := &lutilconf.HTTPConfig{
+		CACertificate: &caCert,
+	}
+	if err := httpConf.LoadCertificates(tlsConfig); err != nil {
+		return nil, err
+	}
+	lutilconf.TLSConfMutex.RLock()
+	lutilconf.Client.SetTLSConfig(tlsConfig)
+	lutilconf.TLSConfMutex.RUnlock()
+
+	sessionConf := deviceSessionConf()
+	if deviceHTTPClient != nil {
+		deviceHTTPClient.CloseIdleConnections()
+	}
+	dialer := &net.Dialer{
+		Timeout: time.Duration(lutilconf.DefaultTLSHandShakeTimeout) * time.Second,
+	}
+	deviceHTTPClient = &http.Client{
+		Timeout: time.Duration(lutilconf.DefaultHTTPConnTimeout) * time.Second,
+		Transport: &http.Transport{
+			DialTLSContext: func(ctx context.Context, network, address string) (net.Conn, error) {
+				tlsDialer := &tls.Dialer{
+					NetDialer: dialer,
+					Config:    certificateTrusts.tlsConfig(address, tlsConfig),
+				}
+				return tlsDialer.DialContext(ctx, network, address)
+			},
+			MaxConnsPerHost:       sessionConf.MaxConnsPerDevice,
+			MaxIdleConnsPerHost:   sessionConf.MaxConnsPerDevice,
+			IdleConnTimeout:       time.Duration(sessionConf.IdleConnTimeoutInSeconds) * time.Second,
+			ExpectContinueTimeout: time.Duration(lutilconf.DefaultHTTPExpectContinueTimeout) * time.Second,
+		},
+	}
+	deviceClientCA = append([]byte(nil), caCert...)
+	deviceTLSConfig = tlsConfig
+	return deviceHTTPClient, nil
+}
+
+// getDeviceTLSConfig returns a copy of the TLS configuration of the devices
+// verified with the CA certificate of the plugin
+func getDeviceTLSConfig() (*tls.Config, error) {
+	if _, err := getDeviceHTTPClient(); err != nil {
+		return nil, err
+	}
+	clientMutex.Lock()
+	defer clientMutex.Unlock()
+	return deviceTLSConfig.Clone(), nil
+}
+
+// closeIdleDeviceConnections closes the pooled connections with the devices,
+// so that the next requests verify the devices with their current trust
+func closeIdleDeviceConnections() {
+	clientMutex.Lock()
+	defer clientMutex.Unlock()
+	if deviceHTTPClient != nil {
+		deviceHTTPClient.CloseIdleConnections()
+	}
+}
+
+// ResetDeviceConnections closes the pooled connections with the devices, the
+// next request creates them again with the current TLS configuration
+func ResetDeviceConnections() {
+	clientMutex.Lock()
+	defer clientMutex.Unlock()
+	if deviceHTTPClient != nil {
+		deviceHTTPClient.CloseIdleConnections()
+		deviceHTTPClient = nil
+	}
+}
+
+// send sends the request to the device. A GET or HEAD request which failed on
+// a pooled connection the device closed meanwhile is sent again on a new
+// connection. The other requests are not sent again, the device could have
+// run the action already.
+func (client *RedfishClient) send(req *http.Request) (*http.Response, error) {
+	var reused bool
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			reused = info.Reused
+		},
+	}
+	resp, err := client.httpClient.Do(req.WithContext(httptrace.WithClientTrace(req.Context(), trace)))
+	if err == nil || !reused || !isRetriable(req) {
+		return resp, err
+	}
+	if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+		return resp, err
+	}
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return nil, err
+		}
+	}
+	return client.httpClient.Do(retry)
+}
+
+// isRetriable tells whether the request can be sent again, it is a GET or HEAD
+// request whose body, if any, can be read again
+func isRetriable(req *http.Request) bool {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return false
+	}
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// deviceSessionConf returns the configured session reuse settings, a plugin
+// without DeviceSessionConf uses basic authentication
+func deviceSessionConf() config.DeviceSessionConf {
+	if config.Data.DeviceSessionConf == nil {
+		return config.DeviceSessionConf{AuthType: config.BasicAuth}
+	}
+	return *config.Data.DeviceSessionConf
+}
+
+func useDeviceSessions() bool {
+	return deviceSessionConf().AuthType == config.SessionAuth
+}
+
+// sessionKey identifies the session of a device by the credentials it is
+// created with, so that changed credentials lead to a new session
+func sessionKey(device *RedfishDevice) string {
+	sum := sha256.Sum256([]byte(device.Host + "\x00" + device.Username + "\x00" + device.Password))
+	return hex.EncodeToString(sum[:])
+}
+
+// get returns the session of the device and the sessions which were not used
+// within the session timeout. It fails when the plugin already holds
+// MaxSessionsPerDevice sessions with the device.
+func (store *deviceSessionStore) get(device *RedfishDevice, conf config.DeviceSessionConf) (*deviceSession, []*deviceSession, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	now := time.Now()
+	timeout := time.Duration(conf.SessionTimeoutInMinutes * float64(time.Minute))
+	var expired []*deviceSession
+	for key, session := range store.sessions {
+		if now.Sub(session.lastUsed) > timeout {
+			delete(store.sessions, key)
+			expired = append(expired, session)
+		}
+	}
+	key := sessionKey(device)
+	if session, ok := store.sessions[key]; ok {
+		session.lastUsed = now
+		return session, expired, nil
+	}
+	count := 0
+	for _, session := range store.sessions {
+		if session.host == device.Host {
+			count++
+		}
+	}
+	if count >= conf.MaxSessionsPerDevice {
+		return nil, expired, fmt.Errorf("limit of %d sessions reached with device %s", conf.MaxSessionsPerDevice, device.Host)
+	}
+	session := &deviceSession{
+		host:     device.Host,
+		lastUsed: now,
+	}
+	store.sessions[key] = session
+	return session, expired, nil
+}
+
+// invalidate drops the session token of the device when it is still the given token
+func (store *deviceSessionStore) invalidate(device *RedfishDevice, token string) {
+	store.mutex.Lock()
+	session, ok := store.sessions[sessionKey(device)]
+	store.mutex.Unlock()
+	if !ok {
+		return
+	}
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	if session.token == token {
+		session.token = ""
+		session.location = ""
+	}
+}
+
+// sessionToken returns the token of the BMC session with the device, the
+// session is created when the plugin does not hold one yet
+func (client *RedfishClient) sessionToken(device *RedfishDevice) (string, error) {
+	session, expired, err := deviceSessions.get(device, deviceSessionConf())
+	if len(expired) > 0 {
+		go client.deleteSessions(context.TODO(), expired)
+	}
+	if err != nil {
+		return "", err
+	}
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	if session.token != "" {
+		return session.token, nil
+	}
+	if session.loginErr != nil && time.Since(session.loginFailedAt) < sessionRetryInterval {
+		return "", session.loginErr
+	}
+	token, location, err := client.createSession(device)
+	if err != nil {
+		session.loginErr = err
+		session.loginFailedAt = time.Now()
+		return "", err
+	}
+	session.token = token
+	session.location = location
+	session.loginErr = nil
+	return token, nil
+}
+
+// createSession creates a BMC session with the device and returns
+// the session token and the URL of the session
+func (client *RedfishClient) createSession(device *RedfishDevice) (string, string, error) {
+	body, err := json.Marshal(map[string]string{
+		"UserName": device.Username,
+		"Password": device.Password,
+	})
+	if err != nil {
+		return "", "", err
+	}
+	req, err := newDeviceRequest(http.MethodPost, fmt.Sprintf("https://%s%s", device.Host, sessionServiceURI), body)
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set("OData-Version", "4.0")
+	resp, err := client.send(req)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return "", "", fmt.Errorf("session creation with device %s failed with status code %d", device.Host, resp.StatusCode)
+	}
+	token := resp.Header.Get("X-Auth-Token")
+	if token == "" {
+		return "", "", fmt.Errorf("device %s did not return a session token", device.Host)
+	}
+	location := resp.Header.Get("Location")
+	if strings.HasPrefix(location, "/") {
+		location = "https://" + device.Host + location
+	}
+	return token, location, nil
+}
+
+// deleteSessions logs out of the given BMC sessions
+func (client *RedfishClient) deleteSessions(ctx context.Context, sessions []*deviceSession) {
+	var wg sync.WaitGroup
+	for _, session := range sessions {
+		session.mutex.Lock()
+		token, location := session.token, session.location
+		session.token, session.location = "", ""
+		session.mutex.Unlock()
+		if token == "" || location == "" {
+			continue
+		}
+		wg.Add(1)
+		go func(host, token, location string) {
+			defer wg.Done()
+			req, err := newDeviceRequest(http.MethodDelete, location, nil)
+			if err != nil {
+				l.LogWithFields(ctx).Warn("while deleting the session with device " + host + ", got: " + err.Error())
+				return
+			}
+			req.Header.Set("X-Auth-Token", token)
+			resp, err := client.send(req)
+			if err != nil {
+				l.LogWithFields(ctx).Warn("while deleting the session with device " + host + ", got: " + err.Error())
+				return
+			}
+			resp.Body.Close()
+		}(session.host, token, location)
+	}
+	wg.Wait()
+}
+
+// CloseDeviceSessions logs out of all the BMC sessions held by the plugin,
+// it is called when the plugin is stopped
+func CloseDeviceSessions(ctx context.Context) {
+	deviceSessions.mutex.Lock()
+	sessions := make([]*deviceSession, 0, len(deviceSessions.sessions))
+	for _, session := range deviceSessions.sessions {
+		sessions = append(sessions, session)
+	}
+	deviceSessions.sessions = make(map[string]*deviceSession)
+	deviceSessions.mutex.Unlock()
+	if len(sessions) == 0 {
+		return
+	}
+	client, err := GetRedfishClient()
+	if err != nil {
+		l.LogWithFields(ctx).Error("while closing the device sessions, got: " + err.Error())
+		return
+	}
+	client.deleteSessions(ctx, sessions)
+	l.LogWithFields(ctx).Infof("closed %d device sessions", len(sessions))
+}
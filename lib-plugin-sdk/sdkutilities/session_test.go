@@ -0,0 +1,228 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package sdkutilities
+
+import (
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	testhttp "net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockBMC counts the requests received by the test device
+type mockBMC struct {
+	mutex       sync.Mutex
+	logins      int
+	logouts     int
+	basicAuth   int
+	tokenAuth   int
+	failLogin   bool
+	rejectToken string
+	header      http.Header
+}
+
+func (bmc *mockBMC) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	bmc.mutex.Lock()
+	defer bmc.mutex.Unlock()
+	bmc.header = r.Header.Clone()
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == sessionServiceURI:
+		if bmc.failLogin {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		bmc.logins++
+		w.Header().Set("X-Auth-Token", "token"+string(rune('0'+bmc.logins)))
+		w.Header().Set("Location", sessionServiceURI+"/1")
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodDelete && r.URL.Path == sessionServiceURI+"/1":
+		bmc.logouts++
+		w.WriteHeader(http.StatusNoContent)
+	case r.Header.Get("X-Auth-Token") != "":
+		if r.Header.Get("X-Auth-Token") == bmc.rejectToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		bmc.tokenAuth++
+		w.WriteHeader(http.StatusOK)
+	default:
+		if _, _, ok := r.BasicAuth(); !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		bmc.basicAuth++
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func startMockBMC(t *testing.T, bmc *mockBMC) (*testhttp.Server, *RedfishClient) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := testhttp.NewUnstartedServer(bmc)
+	ts.Listener.Close()
+	ts.Listener = listener
+	cert, err := tls.X509KeyPair(hostCert, hostPrivKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts.TLS = &tls.Config{Certificates: []tls.Certificate{cert}}
+	ts.StartTLS()
+	return ts, &RedfishClient{httpClient: ts.Client()}
+}
+
+func setUpSessionConfig(t *testing.T, maxSessions int) {
+	config.SetUpMockConfig(t)
+	config.Data.DeviceSessionConf = &config.DeviceSessionConf{
+		AuthType:                 config.SessionAuth,
+		SessionTimeoutInMinutes:  25,
+		MaxSessionsPerDevice:     maxSessions,
+		MaxConnsPerDevice:        4,
+		IdleConnTimeoutInSeconds: 30,
+	}
+	deviceSessions = &deviceSessionStore{sessions: make(map[string]*deviceSession)}
+}
+
+func TestDeviceSessionReuse(t *testing.T) {
+	setUpSessionConfig(t, 2)
+	bmc := &mockBMC{}
+	ts, client := startMockBMC(t, bmc)
+	defer ts.Close()
+	device := &RedfishDevice{Host: ts.Listener.Addr().String(), Username: "admin", Password: "password"}
+
+	for i := 0; i < 3; i++ {
+		resp, err := client.DeviceCall(device, "/redfish/v1/Systems", http.MethodGet)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+	assert.Equal(t, 1, bmc.logins, "one session should be created for the device")
+	assert.Equal(t, 3, bmc.tokenAuth)
+	assert.Equal(t, 0, bmc.basicAuth)
+
+	// the session expired on the device, the plugin logs in again
+	bmc.rejectToken = "token1"
+	resp, err := client.DeviceCall(device, "/redfish/v1/Systems", http.MethodGet)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, bmc.logins)
+}
+
+func TestDeviceSessionFallback(t *testing.T) {
+	setUpSessionConfig(t, 1)
+	bmc := &mockBMC{failLogin: true}
+	ts, client := startMockBMC(t, bmc)
+	defer ts.Close()
+	device := &RedfishDevice{Host: ts.Listener.Addr().String(), Username: "admin", Password: "password"}
+
+	// the device does not support sessions, basic authentication is used
+	resp, err := client.DeviceCall(device, "/redfish/v1/Systems", http.MethodGet)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 1, bmc.basicAuth)
+
+	// the session limit of the device is reached, basic authentication is used
+	bmc.failLogin = false
+	other := &RedfishDevice{Host: device.Host, Username: "operator", Password: "password"}
+	resp, err = client.DeviceCall(other, "/redfish/v1/Systems", http.MethodGet)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 0, bmc.logins)
+	assert.Equal(t, 2, bmc.basicAuth)
+}
+
+func TestCloseDeviceSessions(t *testing.T) {
+	setUpSessionConfig(t, 2)
+	bmc := &mockBMC{}
+	ts, client := startMockBMC(t, bmc)
+	defer ts.Close()
+	device := &RedfishDevice{Host: ts.Listener.Addr().String(), Username: "admin", Password: "password"}
+
+	_, err := client.DeviceCall(device, "/redfish/v1/Systems", http.MethodGet)
+	assert.Nil(t, err)
+	client.deleteSessions(mockContext(), []*deviceSession{deviceSessions.sessions[sessionKey(device)]})
+	assert.Equal(t, 1, bmc.logouts)
+
+	// the sessions without a token are not deleted on the device
+	CloseDeviceSessions(mockContext())
+	assert.Equal(t, 1, bmc.logouts)
+	assert.Empty(t, deviceSessions.sessions)
+}
+
+func TestGetDeviceHTTPClient(t *testing.T) {
+	config.SetUpMockConfig(t)
+	first, err := getDeviceHTTPClient()
+	assert.Nil(t, err)
+	second, err := getDeviceHTTPClient()
+	assert.Nil(t, err)
+	assert.True(t, first == second, "the client should be reused")
+
+	ResetDeviceConnections()
+	third, err := getDeviceHTTPClient()
+	assert.Nil(t, err)
+	assert.False(t, first == third, "the client should be created again")
+}
+
+func TestSendOnClosedConnection(t *testing.T) {
+	config.SetUpMockConfig(t)
+	bmc := &mockBMC{}
+	ts, client := startMockBMC(t, bmc)
+	defer ts.Close()
+	device := &RedfishDevice{Host: ts.Listener.Addr().String(), Username: "admin", Password: "password"}
+
+	resp, err := client.DeviceCall(device, "/redfish/v1/Systems", http.MethodGet)
+	assert.Nil(t, err)
+	resp.Body.Close()
+	// the device closes the pooled connection, the request is sent on a new one
+	ts.CloseClientConnections()
+	resp, err = client.DeviceCall(device, "/redfish/v1/Systems", http.MethodGet)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, bmc.basicAuth)
+}
+
+func Test_isRetriable(t *testing.T) {
+	newRequest := func(method string, body io.Reader) *http.Request {
+		req, _ := http.NewRequest(method, "https://localhost/redfish/v1/Systems", body)
+		return req
+	}
+	unreadable := newRequest(http.MethodGet, strings.NewReader("{}"))
+	unreadable.GetBody = nil
+	tests := []struct {
+		name string
+		req  *http.Request
+		want bool
+	}{
+		{"GET", newRequest(http.MethodGet, nil), true},
+		{"HEAD", newRequest(http.MethodHead, nil), true},
+		{"GET with a body read again", newRequest(http.MethodGet, strings.NewReader("{}")), true},
+		{"GET with a body not read again", unreadable, false},
+		{"POST", newRequest(http.MethodPost, strings.NewReader("{}")), false},
+		{"PATCH", newRequest(http.MethodPatch, strings.NewReader("{}")), false},
+		{"DELETE", newRequest(http.MethodDelete, nil), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isRetriable(tt.req))
+		})
+	}
+}
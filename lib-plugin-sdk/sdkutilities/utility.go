@@ -12,22 +12,8 @@
 //License for the specific language governing permissions and limitations
 // under the License.
 
-//Package //(C) Copyright [2020] Hewlett Packard Enterprise Development LP
-//
-//Licensed under the Apache License, Version 2.0 (the "License"); you may
-//not use this file except in compliance with the License. You may obtain
-//a copy of the License at
-//
-//    http://www.apache.org/licenses/LICENSE-2.0
-//
-//Unless required by applicable law or agreed to in writing, software
-//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
-//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
-//License for the specific language governing permissions and limitations
-// under the License.
-
-// Package dputilities ...
-package dputilities
+// Package sdkutilities ...
+package sdkutilities
 
 import (
 	"context"
@@ -43,11 +29,11 @@ import (
 	"github.com/fsnotify/fsnotify"
 	"github.com/google/uuid"
 
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkmodel"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkresponse"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
-	"github.com/ODIM-Project/ODIM/plugin-dell/config"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dpmodel"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dpresponse"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -58,7 +44,7 @@ var (
 
 // GetPlainText ...
 func GetPlainText(ctx context.Context, password []byte) ([]byte, error) {
-	priv := []byte(dpmodel.PluginPrivateKey)
+	priv := []byte(sdkmodel.PluginPrivateKey)
 	block, _ := pem.Decode(priv)
 	enc := x509.IsEncryptedPEMBlock(block)
 	b := block.Bytes
@@ -95,7 +81,7 @@ func GetPlainText(ctx context.Context, password []byte) ([]byte, error) {
 }
 
 // Status holds the Status of plugin it will be intizaied during startup time
-var Status dpresponse.Status
+var Status sdkresponse.Status
 
 // PluginStartTime hold the time from which plugin started
 var PluginStartTime time.Time
@@ -152,6 +138,8 @@ func TrackConfigFileChanges(configFilePath string, eventChan chan<- interface{},
 					if err := config.SetConfiguration(); err != nil {
 						log.Error("While trying to set configuration, got: " + err.Error())
 					}
+					// the pooled device connections pick up the new TLS and connection settings
+					ResetDeviceConnections()
 					confMutex.Unlock()
 					eventChan <- "config file modified" + fileEvent.Name
 				}
@@ -12,22 +12,8 @@
 //License for the specific language governing permissions and limitations
 // under the License.
 
-//Package //(C) Copyright [2020] Hewlett Packard Enterprise Development LP
-//
-//Licensed under the Apache License, Version 2.0 (the "License"); you may
-//not use this file except in compliance with the License. You may obtain
-//a copy of the License at
-//
-//    http://www.apache.org/licenses/LICENSE-2.0
-//
-//Unless required by applicable law or agreed to in writing, software
-//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
-//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
-//License for the specific language governing permissions and limitations
-// under the License.
-
-// Package dputilities ...
-package dputilities
+// Package sdkutilities ...
+package sdkutilities
 
 import (
 	"bytes"
@@ -42,9 +28,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkmodel"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
-	"github.com/ODIM-Project/ODIM/plugin-dell/config"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dpmodel"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -104,7 +90,7 @@ func TestGetPlainText(t *testing.T) {
 		Type:  "RSA PRIVATE KEY",
 		Bytes: marshalledPrivateKey,
 	})
-	dpmodel.PluginPrivateKey = priv
+	sdkmodel.PluginPrivateKey = priv
 	// Decrypt the password using the GetPlainText function
 	ctxt := mockContext()
 	plaintext, err := GetPlainText(ctxt, ciphertext)
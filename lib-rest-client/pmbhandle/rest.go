@@ -26,6 +26,9 @@ import (
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/lib-utilities/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 )
@@ -59,9 +62,13 @@ func ContactPlugin(ctx context.Context, url, method, token string, odataID strin
 	if err != nil {
 		return nil, err
 	}
+	ctx, span := tracing.StartSpan(ctx, "HTTP "+method, trace.SpanKindClient,
+		attribute.String("http.method", method), attribute.String("http.url", url))
+	defer span.End()
 	req, err := http.NewRequest(method, url, bytes.NewBuffer(jsonStr))
 	if err != nil {
 		l.Log.Error(err.Error())
+		tracing.RecordError(span, err)
 		return nil, err
 	}
 	req = CreateHeader(ctx, req)
@@ -91,8 +98,10 @@ func ContactPlugin(ctx context.Context, url, method, token string, odataID strin
 	resp, err := httpClient.Do(req)
 	config.TLSConfMutex.RUnlock()
 	if err != nil {
+		tracing.RecordError(span, err)
 		return nil, err
 	}
+	tracing.SetHTTPStatus(span, resp.StatusCode)
 
 	if resp.StatusCode >= 300 {
 		l.Log.Warn("got " + resp.Status + " while fetching " + url + " with method " + method)
@@ -141,5 +150,6 @@ func CreateHeader(ctx context.Context, req *http.Request) *http.Request {
 		req.Header.Set(common.ThreadName, threadName)
 		req.Header.Set(common.ProcessName, processName)
 	}
+	tracing.Inject(ctx, req.Header)
 	return req
 }
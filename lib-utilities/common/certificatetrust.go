@@ -0,0 +1,121 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+)
+
+const (
+	// CertificateTrustModeRootCA is the certificate trust mode of a BMC whose certificate is
+	// verified with the root CA certificate configured for the plugin
+	CertificateTrustModeRootCA = "RootCA"
+	// CertificateTrustModeTrustOnFirstUse is the certificate trust mode of a BMC whose certificate
+	// is captured when the BMC is added, only that certificate is accepted afterwards
+	CertificateTrustModeTrustOnFirstUse = "TrustOnFirstUse"
+	// CertificateTrustModeCAChain is the certificate trust mode of a BMC whose certificate is
+	// verified with the CA chain uploaded when the BMC is added
+	CertificateTrustModeCAChain = "CAChain"
+
+	// BMCCertificateChangedMessageID is the message of the event raised by a plugin when a BMC
+	// presents a certificate other than the one pinned for it
+	BMCCertificateChangedMessageID = "CertificateTrust.1.0.BMCCertificateChanged"
+)
+
+// CertificateTrust holds how the plugins verify the certificate of a BMC
+type CertificateTrust struct {
+	Mode string `json:"Mode"`
+	// Certificate is, in PEM format, the certificate of the BMC pinned in TrustOnFirstUse
+	// mode or the CA chain verifying the certificate of the BMC in CAChain mode
+	Certificate string `json:"Certificate,omitempty"`
+	// Fingerprint is the SHA-256 fingerprint of the pinned certificate
+	Fingerprint string `json:"Fingerprint,omitempty"`
+}
+
+// Validate checks the mode and the certificates of the trust. The certificate of
+// TrustOnFirstUse is optional, it is captured from the BMC when it is not given.
+func (t CertificateTrust) Validate() error {
+	switch t.Mode {
+	case CertificateTrustModeRootCA:
+		if t.Certificate != "" {
+			return fmt.Errorf("no certificate is used in %s mode", t.Mode)
+		}
+		return nil
+	case CertificateTrustModeTrustOnFirstUse:
+		if t.Certificate == "" {
+			return nil
+		}
+	case CertificateTrustModeCAChain:
+		if t.Certificate == "" {
+			return fmt.Errorf("the CA chain is required in %s mode", t.Mode)
+		}
+	default:
+		return fmt.Errorf("unknown certificate trust mode %s", t.Mode)
+	}
+	_, err := ParseCertificates(t.Certificate)
+	return err
+}
+
+// PinCertificate returns the TrustOnFirstUse trust of a BMC presenting the certificate
+func PinCertificate(cert *x509.Certificate) CertificateTrust {
+	return CertificateTrust{
+		Mode: CertificateTrustModeTrustOnFirstUse,
+		Certificate: string(pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: cert.Raw,
+		})),
+		Fingerprint: CertificateFingerprint(cert),
+	}
+}
+
+// ParseCertificates returns the certificates of the PEM data in their order,
+// the first certificate of a chain is the certificate of the BMC
+func ParseCertificates(data string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := []byte(data)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid certificate: %v", err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no certificate found in PEM data")
+	}
+	return certs, nil
+}
+
+// CertificateFingerprint returns the SHA-256 fingerprint of the certificate as colon separated hex bytes
+func CertificateFingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	hexBytes := make([]string, len(sum))
+	for i, b := range sum {
+		hexBytes[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(hexBytes, ":")
+}
@@ -0,0 +1,96 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestCertificate(t *testing.T) (*x509.Certificate, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "bmc"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestCertificateTrustValidate(t *testing.T) {
+	_, certPEM := newTestCertificate(t)
+	tests := []struct {
+		name    string
+		trust   CertificateTrust
+		wantErr bool
+	}{
+		{"root CA", CertificateTrust{Mode: CertificateTrustModeRootCA}, false},
+		{"root CA with a certificate", CertificateTrust{Mode: CertificateTrustModeRootCA, Certificate: certPEM}, true},
+		{"trust on first use to capture", CertificateTrust{Mode: CertificateTrustModeTrustOnFirstUse}, false},
+		{"trust on first use pinned", CertificateTrust{Mode: CertificateTrustModeTrustOnFirstUse, Certificate: certPEM}, false},
+		{"CA chain", CertificateTrust{Mode: CertificateTrustModeCAChain, Certificate: certPEM}, false},
+		{"CA chain missing", CertificateTrust{Mode: CertificateTrustModeCAChain}, true},
+		{"CA chain not PEM", CertificateTrust{Mode: CertificateTrustModeCAChain, Certificate: "chain"}, true},
+		{"unknown mode", CertificateTrust{Mode: "None"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.trust.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPinCertificate(t *testing.T) {
+	cert, certPEM := newTestCertificate(t)
+	trust := PinCertificate(cert)
+	if trust.Mode != CertificateTrustModeTrustOnFirstUse || trust.Certificate != certPEM {
+		t.Errorf("PinCertificate() = %v", trust)
+	}
+	if len(trust.Fingerprint) != 95 || strings.ToUpper(trust.Fingerprint) != trust.Fingerprint {
+		t.Errorf("unexpected fingerprint %s", trust.Fingerprint)
+	}
+
+	// the certificate of the BMC comes first in a chain
+	other, otherPEM := newTestCertificate(t)
+	certs, err := ParseCertificates(otherPEM + certPEM)
+	if err != nil || len(certs) != 2 {
+		t.Fatalf("ParseCertificates() = %v, %v", certs, err)
+	}
+	if CertificateFingerprint(certs[0]) != CertificateFingerprint(other) {
+		t.Errorf("the certificates are not in their order")
+	}
+}
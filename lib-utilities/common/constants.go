@@ -195,6 +195,8 @@ const (
 	ZonesID = "Zones/{id}"
 	//EndpointsID is the URI for endpoints which performs operations on a specific fabric endpoint
 	EndpointsID = "Endpoints/{id}"
+	//APIKeysID is the URI for endpoints which performs operations on a specific api key
+	APIKeysID = "APIKeys/{id}"
 )
 
 // Below fields are Process Name for logging
@@ -206,6 +208,7 @@ const (
 	ActionID      = "actionid"
 	ProcessName   = "processname"
 	RequestBody   = "requestbody"
+	ClientIP      = "clientip"
 )
 
 // Below fields are service names for logging
@@ -242,6 +245,7 @@ const (
 	StartRequest                           = "StartRequest"
 	SimpleUpdate                           = "SimpleUpdate"
 	StartUpdate                            = "StartUpdate"
+	RemediateFirmwareDrift                 = "RemediateFirmwareDrift"
 	OverWriteCompletedTaskUtil             = "OverWriteCompletedTaskUtil"
 	AsyncTaskDelete                        = "AsyncTaskDelete"
 	ResetAggregates                        = "Reset-Aggregates"
@@ -249,6 +253,10 @@ const (
 	SetBootOrder                           = "SettingBootOrder"
 	CollectAndSetDefaultBootOrder          = "CollectAndSetDefaultBoorOrder"
 	AddAggregationSource                   = "AddingAggregationSource"
+	BulkAddAggregationSource               = "BulkAddingAggregationSource"
+	AdoptDiscoveredBMC                     = "AdoptingDiscoveredBMC"
+	ChangeConnectionMethod                 = "ChangingConnectionMethod"
+	RotateCredentials                      = "RotatingCredentials"
 	DeleteAggregationSource                = "DeleteAggregationSource"
 	SubTaskStatusUpdate                    = "SubTaskStatusUpdate"
 	ResetSystem                            = "ResetSystem"
@@ -348,6 +356,10 @@ var Actions = map[ActionKey]ActionType{
 	{"AccountService", "Accounts", "POST"}:      {"037", "CreateAccount"},
 	{"AccountService", AccountsID, "PATCH"}:     {"038", "UpdateAccount"},
 	{"AccountService", AccountsID, "DELETE"}:    {"039", "DeleteAccount"},
+	{"AccountService", "APIKeys", "GET"}:        {"225", "GetAllAPIKeys"},
+	{"AccountService", APIKeysID, "GET"}:        {"226", "GetAPIKey"},
+	{"AccountService", "APIKeys", "POST"}:       {"227", "CreateAPIKey"},
+	{"AccountService", APIKeysID, "DELETE"}:     {"228", "DeleteAPIKey"},
 	// Session Service URI
 	{"SessionService", "SessionService", "GET"}:   {"040", "GetSessionService"},
 	{"SessionService", "Sessions", "GET"}:         {"041", "GetAllActiveSessions"},
@@ -398,6 +410,12 @@ var Actions = map[ActionKey]ActionType{
 	{"AggregationService", "SetDefaultBootOrderActionInfo", "GET"}:           {"079", "GetSetDefaultBootOrderActionInfo"},
 	{"AggregationService", "AggregationService.Reset", "POST"}:               {"080", "AggregationServiceReset"},
 	{"AggregationService", "AggregationService.SetDefaultBootOrder", "POST"}: {"081", "SetDefaultBootOrder"},
+	{"AggregationService", "Odim.BulkAddAggregationSources", "POST"}:         {"231", "BulkAddAggregationSources"},
+	{"AggregationService", "DiscoveredBMCs", "GET"}:                          {"232", "GetAllDiscoveredBMCs"},
+	{"AggregationService", "DiscoveredBMCs/{id}", "GET"}:                     {"233", "GetDiscoveredBMC"},
+	{"AggregationService", "DiscoveredBMC.Adopt", "POST"}:                    {"234", "AdoptDiscoveredBMC"},
+	{"AggregationService", "Odim.ChangeConnectionMethod", "POST"}:            {"237", "ChangeConnectionMethod"},
+	{"AggregationService", "Odim.RotateCredentials", "POST"}:                 {"238", "RotateCredentials"},
 	//AggregationSources URI
 	{"AggregationService", "AggregationSources", "POST"}:   {"082", "AddAggregationSource"},
 	{"AggregationService", "AggregationSources", "GET"}:    {"083", "GetAllAggregationSource"},
@@ -509,6 +527,8 @@ var Actions = map[ActionKey]ActionType{
 	{"Managers", AccountsID, "DELETE"}:               {"192", "DeleteManagerAccounts"},
 	{"Managers", "Roles", "GET"}:                     {"193", "GetAllManagersRoles"},
 	{"Managers", RolesID, "GET"}:                     {"194", "GetManagersRoles"},
+	{"Managers", "LogLevel", "GET"}:                  {"229", "GetLogLevel"},
+	{"Managers", "LogLevel", "PATCH"}:                {"230", "SetLogLevel"},
 	// Update Service URI
 	{"UpdateService", "UpdateService", "GET"}:               {"195", "GetUpdateService"},
 	{"UpdateService", "UpdateService.SimpleUpdate", "POST"}: {"196", "UpdateServiceSimpleUpdate"},
@@ -517,24 +537,51 @@ var Actions = map[ActionKey]ActionType{
 	{"UpdateService", "FirmwareInventory/{id}", "GET"}:      {"199", "GetFirmwareInventory"},
 	{"UpdateService", "SoftwareInventory", "GET"}:           {"200", "GetSoftwareInventoryCollection"},
 	{"UpdateService", "SoftwareInventory/{id}", "GET"}:      {"201", "GetSoftwareInventory"},
+	{"UpdateService", "FirmwareBaselines", "GET"}:           {"240", "GetFirmwareBaselineCollection"},
+	{"UpdateService", "FirmwareBaselines", "POST"}:          {"241", "CreateFirmwareBaseline"},
+	{"UpdateService", "FirmwareBaselines/{id}", "GET"}:      {"242", "GetFirmwareBaseline"},
+	{"UpdateService", "FirmwareBaselines/{id}", "DELETE"}:   {"243", "DeleteFirmwareBaseline"},
+	{"UpdateService", "ComplianceReport", "GET"}:            {"244", "GetFirmwareComplianceReport"},
+	{"UpdateService", "FirmwareBaseline.Remediate", "POST"}: {"245", "RemediateFirmwareDrift"},
 	// Telemetry Service URI
-	{"TelemetryService", "TelemetryService", "GET"}:             {"202", "GetTelemetryService"},
-	{"TelemetryService", "MetricDefinitions", "GET"}:            {"203", "GetMetricDefinitionCollection"},
-	{"TelemetryService", "MetricReportDefinitions", "GET"}:      {"204", "GetMetricReportDefinitionCollection"},
-	{"TelemetryService", "MetricReports", "GET"}:                {"205", "GetMetricReportCollection"},
-	{"TelemetryService", "Triggers", "GET"}:                     {"206", "GetTriggerCollection"},
-	{"TelemetryService", "MetricDefinitions/{id}", "GET"}:       {"207", "GetMetricDefinition"},
-	{"TelemetryService", "MetricReportDefinitions/{id}", "GET"}: {"208", "GetMetricReportDefinition"},
-	{"TelemetryService", "MetricReports/{id}", "GET"}:           {"209", "GetMetricReport"},
-	{"TelemetryService", "Triggers/{id}", "GET"}:                {"210", "GetTrigger"},
-	{"TelemetryService", "Triggers/{id}", "PATCH"}:              {"211", "UpdateTrigger"},
+	{"TelemetryService", "TelemetryService", "GET"}:                {"202", "GetTelemetryService"},
+	{"TelemetryService", "MetricDefinitions", "GET"}:               {"203", "GetMetricDefinitionCollection"},
+	{"TelemetryService", "MetricReportDefinitions", "GET"}:         {"204", "GetMetricReportDefinitionCollection"},
+	{"TelemetryService", "MetricReports", "GET"}:                   {"205", "GetMetricReportCollection"},
+	{"TelemetryService", "Triggers", "GET"}:                        {"206", "GetTriggerCollection"},
+	{"TelemetryService", "MetricDefinitions/{id}", "GET"}:          {"207", "GetMetricDefinition"},
+	{"TelemetryService", "MetricReportDefinitions/{id}", "GET"}:    {"208", "GetMetricReportDefinition"},
+	{"TelemetryService", "MetricReports/{id}", "GET"}:              {"209", "GetMetricReport"},
+	{"TelemetryService", "Triggers/{id}", "GET"}:                   {"210", "GetTrigger"},
+	{"TelemetryService", "Triggers/{id}", "PATCH"}:                 {"211", "UpdateTrigger"},
+	{"TelemetryService", "MetricHistory", "GET"}:                   {"246", "GetMetricHistory"},
+	{"TelemetryService", "MetricReportDefinitions", "POST"}:        {"248", "CreateMetricReportDefinition"},
+	{"TelemetryService", "MetricReportDefinitions/{id}", "PATCH"}:  {"249", "UpdateMetricReportDefinition"},
+	{"TelemetryService", "MetricReportDefinitions/{id}", "DELETE"}: {"250", "DeleteMetricReportDefinition"},
+	{"TelemetryService", "Triggers", "POST"}:                       {"251", "CreateTrigger"},
+	{"TelemetryService", "Triggers/{id}", "DELETE"}:                {"252", "DeleteTrigger"},
+	{"TelemetryService", "Metrics", "GET"}:                         {"255", "GetOpenMetrics"},
 	//License Service URI
 	{"LicenseService", "LicenseService", "GET"}: {"212", "GetLicenseService"},
 	{"LicenseService", "Licenses", "GET"}:       {"213", "GetLicenseCollection"},
 	{"LicenseService", "Licenses/{id}", "GET"}:  {"214", "GetLicenseResource"},
 	{"LicenseService", "Licenses", "POST"}:      {"215", "InstallLicenseService"},
+	//Certificate Service URI
+	{"CertificateService", "CertificateService.ReplaceCertificate", "POST"}: {"240", "ReplaceCertificate"},
 	// 216 and 217 operations are svc-aggregation internal operations plugin health check and RediscoverSystem
 	// 218 is an internal operation in svc-task, assigned the values from 219 to 224 for SecureBoot and SecureBootDatabases APIs
+	// 225 to 228 are assigned for the APIKeys APIs of AccountService
+	// 229 and 230 are assigned for the LogLevel APIs of the ODIM manager
+	// 231 is assigned for the BulkAddAggregationSources action of AggregationService
+	// 232 to 234 are assigned for the DiscoveredBMCs APIs of AggregationService and 235 for the internal BMC discovery scan
+	// 236 is an svc-aggregation internal operation for the scheduled inventory refresh
+	// 237 is assigned for the ChangeConnectionMethod action of AggregationSources
+	// 238 is assigned for the RotateCredentials action of Aggregates and 239 for the scheduled credential rotation
+	// 240 is assigned for the ReplaceCertificate action of CertificateService
+	// 246 is assigned for the MetricHistory API of TelemetryService and 247 for its internal collection and maintenance
+	// 253 is assigned for computing the metric reports of the MetricReportDefinitions created through ODIM
+	// 254 is assigned for evaluating the Triggers created through ODIM
+	// 255 is assigned for the OpenMetrics API of TelemetryService and 256 for the Prometheus remote write
 }
 
 // Types contains schema versions to be returned
@@ -671,6 +718,8 @@ type Events struct {
 	IP        string `json:"ip"`
 	Request   []byte `json:"request"`
 	EventType string `json:"eventType"`
+	// TraceParent is the W3C trace context of the publisher, if any
+	TraceParent string `json:"traceParent,omitempty"`
 }
 
 // TaskEvent contains the task progress data sent form plugin to PMB
@@ -754,15 +803,36 @@ var PluginTaskIndex = "PluginTaskIndex"
 // ResetTaskIDPrefix is the prefix for the task id for a reset request
 const ResetTaskIDPrefix = "reset-"
 
+// LastFetchedTable is the name of the table in the in-memory DB having the time
+// each resource of the inventory was last fetched from the BMC
+const LastFetchedTable = "LastFetched"
+
+// LastFetchedHeader is the response header having the time the resource was last fetched from the BMC
+const LastFetchedHeader = "X-Odim-Last-Fetched"
+
+// RefreshQueryParam is the query parameter which forces a resource to be read from the BMC
+// instead of the inventory, e.g. /redfish/v1/Systems/{id}/Memory?refresh=true
+const RefreshQueryParam = "refresh"
+
+const (
+	// EventDeliveryModePush is the event delivery mode of a server posting its events
+	// to the plugin after the plugin subscribed to them on the server
+	EventDeliveryModePush = "Push"
+	// EventDeliveryModePolling is the event delivery mode of a server whose events are
+	// generated by the plugin from the changes seen in the resources it reads periodically
+	EventDeliveryModePolling = "Polling"
+)
+
 // Target is for sending the request to south bound/plugin
 type Target struct {
-	ManagerAddress string `json:"ManagerAddress"`
-	Password       []byte `json:"Password"`
-	UserName       string `json:"UserName"`
-	PostBody       []byte `json:"PostBody"`
-	DeviceUUID     string `json:"DeviceUUID"`
-	PluginID       string `json:"PluginID"`
-	Location       string `json:"Location"`
+	ManagerAddress    string `json:"ManagerAddress"`
+	Password          []byte `json:"Password"`
+	UserName          string `json:"UserName"`
+	PostBody          []byte `json:"PostBody"`
+	DeviceUUID        string `json:"DeviceUUID"`
+	PluginID          string `json:"PluginID"`
+	Location          string `json:"Location"`
+	EventDeliveryMode string `json:"EventDeliveryMode,omitempty"`
 }
 
 // Plugin is the model for plugin information
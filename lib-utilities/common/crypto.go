@@ -24,6 +24,7 @@ import (
 	"sync"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/secrets"
 )
 
 var (
@@ -32,8 +33,16 @@ var (
 )
 
 // DecryptWithPrivateKey is used to decrypt ciphered text to device password
-// with the private key whose path is available in the config file
+// with the private key whose path is available in the config file.
+// A reference to a secret is resolved from the configured secrets provider.
 func DecryptWithPrivateKey(ciphertext []byte) ([]byte, error) {
+	if secrets.IsReference(ciphertext) {
+		return resolveSecret(ciphertext)
+	}
+	return decryptWithPrivateKey(ciphertext)
+}
+
+func decryptWithPrivateKey(ciphertext []byte) ([]byte, error) {
 	MuxLock.Lock()
 	defer MuxLock.Unlock()
 	var err error
@@ -71,8 +80,12 @@ func DecryptWithPrivateKey(ciphertext []byte) ([]byte, error) {
 	return plainText, nil
 }
 
-// EncryptWithPublicKey is used to encrypt device password using odimra public key
+// EncryptWithPublicKey is used to encrypt device password using odimra public key.
+// A reference to a secret is returned as it is, so that only the reference is stored.
 func EncryptWithPublicKey(password []byte) ([]byte, error) {
+	if secrets.IsReference(password) {
+		return password, nil
+	}
 	var err error
 	block, _ := pem.Decode(config.Data.KeyCertConf.RSAPublicKey)
 	enc := x509.IsEncryptedPEMBlock(block)
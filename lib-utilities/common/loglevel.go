@@ -0,0 +1,95 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	"github.com/ODIM-Project/ODIM/lib-utilities/logs"
+)
+
+const (
+	// logLevelTable is the in-memory DB table holding the log level overrides
+	logLevelTable = "LogLevel"
+	// globalLogLevelKey is the key of the override applied to all the packages
+	globalLogLevelKey = "*"
+)
+
+// SaveLogLevelOverride stores the log level override, which all the service
+// instances apply until it expires. The DB entry expires along with it.
+func SaveLogLevelOverride(override logs.LevelOverride) error {
+	ttl := int(time.Until(override.ExpiresAt).Seconds())
+	if ttl <= 0 {
+		return fmt.Errorf("log level override for %q is already expired", override.Package)
+	}
+	conn, err := GetDBConnection(InMemory)
+	if err != nil {
+		return fmt.Errorf("unable to get DB connection: %s", err.Error())
+	}
+	if err := conn.UpsertWithExpiry(logLevelTable, logLevelKey(override.Package), override, ttl); err != nil {
+		return fmt.Errorf("unable to save the log level override: %s", err.Error())
+	}
+	return nil
+}
+
+// GetLogLevelOverrides returns the log level overrides which are not expired
+func GetLogLevelOverrides() ([]logs.LevelOverride, error) {
+	conn, err := GetDBConnection(InMemory)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get DB connection: %s", err.Error())
+	}
+	keys, err := conn.GetAllMatchingDetails(logLevelTable, "")
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the log level overrides: %s", err.Error())
+	}
+	overrides := make([]logs.LevelOverride, 0, len(keys))
+	for _, key := range keys {
+		data, err := conn.Read(logLevelTable, key)
+		if err != nil {
+			// the override expired after it was listed
+			continue
+		}
+		var override logs.LevelOverride
+		if err := json.Unmarshal([]byte(data), &override); err != nil {
+			logs.Log.Warn("invalid log level override stored for " + key + ": " + err.Error())
+			continue
+		}
+		overrides = append(overrides, override)
+	}
+	return overrides, nil
+}
+
+// DeleteLogLevelOverride removes the log level override of the package,
+// or the global one when the package is empty
+func DeleteLogLevelOverride(pkg string) error {
+	conn, err := GetDBConnection(InMemory)
+	if err != nil {
+		return fmt.Errorf("unable to get DB connection: %s", err.Error())
+	}
+	if err := conn.Delete(logLevelTable, logLevelKey(pkg)); err != nil && err.ErrNo() != errors.DBKeyNotFound {
+		return fmt.Errorf("unable to delete the log level override: %s", err.Error())
+	}
+	return nil
+}
+
+func logLevelKey(pkg string) string {
+	if pkg == "" {
+		return globalLogLevelKey
+	}
+	return pkg
+}
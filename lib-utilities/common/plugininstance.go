@@ -0,0 +1,126 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package common ...
+package common
+
+import (
+	"encoding/json"
+	"math/rand"
+	"net"
+	"strings"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+)
+
+// PluginInstanceStatusTable is the in-memory table having the health of the instances
+// of a plugin, as found by the plugin health check, keyed by the plugin ID
+const PluginInstanceStatusTable = "PluginInstanceStatus"
+
+// PluginInstance is an instance of a plugin. The requests of a plugin
+// having several instances are spread over its healthy instances
+type PluginInstance struct {
+	IP   string
+	Port string
+}
+
+// Address returns the address of the plugin instance in host:port form
+func (i PluginInstance) Address() string {
+	return net.JoinHostPort(i.IP, i.Port)
+}
+
+// GetPluginInstances returns all the instances of a plugin, which is the
+// address the plugin was added with followed by its additional instances
+func GetPluginInstances(ip, port string, instances []PluginInstance) []PluginInstance {
+	all := []PluginInstance{{IP: ip, Port: port}}
+	for _, instance := range instances {
+		if instance.IP == ip && instance.Port == port {
+			continue
+		}
+		all = append(all, instance)
+	}
+	return all
+}
+
+// SelectPluginInstance returns the instance of the plugin to send a request to.
+// The instances reported down by the plugin health check are skipped, unless none of them is up.
+// Sessions created with XAuthToken are local to an instance, so such plugins are only failed over
+// to the next healthy instance, and the requests of other plugins are spread over the healthy instances.
+func SelectPluginInstance(pluginID, preferredAuthType string, instances []PluginInstance) PluginInstance {
+	if len(instances) == 1 {
+		return instances[0]
+	}
+	health, err := GetPluginInstanceHealth(pluginID)
+	if err != nil && err.ErrNo() != errors.DBKeyNotFound {
+		return instances[0]
+	}
+	healthy := GetHealthyPluginInstances(instances, health)
+	if len(healthy) == 0 {
+		return instances[0]
+	}
+	if strings.EqualFold(preferredAuthType, "XAuthToken") {
+		return healthy[0]
+	}
+	return healthy[rand.Intn(len(healthy))]
+}
+
+// GetHealthyPluginInstances returns the instances which are not reported down in health.
+// Instances not checked yet are taken as healthy.
+func GetHealthyPluginInstances(instances []PluginInstance, health map[string]bool) []PluginInstance {
+	var healthy []PluginInstance
+	for _, instance := range instances {
+		if up, checked := health[instance.Address()]; !checked || up {
+			healthy = append(healthy, instance)
+		}
+	}
+	return healthy
+}
+
+// GetPluginInstanceHealth returns the health of the instances of a plugin, keyed by their address
+func GetPluginInstanceHealth(pluginID string) (map[string]bool, *errors.Error) {
+	conn, err := GetDBConnection(InMemory)
+	if err != nil {
+		return nil, err
+	}
+	data, err := conn.Read(PluginInstanceStatusTable, pluginID)
+	if err != nil {
+		return nil, err
+	}
+	var health map[string]bool
+	if err := json.Unmarshal([]byte(data), &health); err != nil {
+		return nil, errors.PackError(errors.JSONUnmarshalFailed, err)
+	}
+	return health, nil
+}
+
+// SavePluginInstanceHealth saves the health of the instances of a plugin, keyed by their address
+func SavePluginInstanceHealth(pluginID string, health map[string]bool) *errors.Error {
+	conn, err := GetDBConnection(InMemory)
+	if err != nil {
+		return err
+	}
+	return conn.Upsert(PluginInstanceStatusTable, pluginID, health)
+}
+
+// DeletePluginInstanceHealth removes the health of the instances of a plugin
+func DeletePluginInstanceHealth(pluginID string) *errors.Error {
+	conn, err := GetDBConnection(InMemory)
+	if err != nil {
+		return err
+	}
+	if err = conn.Delete(PluginInstanceStatusTable, pluginID); err != nil && err.ErrNo() != errors.DBKeyNotFound {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package common ...
+package common
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetPluginInstances(t *testing.T) {
+	tests := []struct {
+		name      string
+		instances []PluginInstance
+		want      []PluginInstance
+	}{
+		{
+			name: "single instance",
+			want: []PluginInstance{{IP: "10.0.0.1", Port: "45001"}},
+		},
+		{
+			name:      "additional instances",
+			instances: []PluginInstance{{IP: "10.0.0.2", Port: "45001"}, {IP: "10.0.0.3", Port: "45001"}},
+			want:      []PluginInstance{{IP: "10.0.0.1", Port: "45001"}, {IP: "10.0.0.2", Port: "45001"}, {IP: "10.0.0.3", Port: "45001"}},
+		},
+		{
+			name:      "instance repeating the plugin address",
+			instances: []PluginInstance{{IP: "10.0.0.1", Port: "45001"}, {IP: "10.0.0.2", Port: "45001"}},
+			want:      []PluginInstance{{IP: "10.0.0.1", Port: "45001"}, {IP: "10.0.0.2", Port: "45001"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetPluginInstances("10.0.0.1", "45001", tt.instances); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetPluginInstances() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetHealthyPluginInstances(t *testing.T) {
+	instances := []PluginInstance{{IP: "10.0.0.1", Port: "45001"}, {IP: "10.0.0.2", Port: "45001"}, {IP: "10.0.0.3", Port: "45001"}}
+	health := map[string]bool{
+		"10.0.0.1:45001": false,
+		"10.0.0.2:45001": true,
+	}
+	want := []PluginInstance{{IP: "10.0.0.2", Port: "45001"}, {IP: "10.0.0.3", Port: "45001"}}
+	if got := GetHealthyPluginInstances(instances, health); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetHealthyPluginInstances() = %v, want %v", got, want)
+	}
+	if got := GetHealthyPluginInstances(instances, nil); !reflect.DeepEqual(got, instances) {
+		t.Errorf("GetHealthyPluginInstances() = %v, want %v", got, instances)
+	}
+}
+
+func TestSelectPluginInstance(t *testing.T) {
+	instance := PluginInstance{IP: "10.0.0.1", Port: "45001"}
+	if got := SelectPluginInstance("GRF", "BasicAuth", []PluginInstance{instance}); got != instance {
+		t.Errorf("SelectPluginInstance() = %v, want %v", got, instance)
+	}
+}
+
+func TestPluginInstanceAddress(t *testing.T) {
+	if got := (PluginInstance{IP: "fd00::1", Port: "45001"}).Address(); got != "[fd00::1]:45001" {
+		t.Errorf("Address() = %v, want [fd00::1]:45001", got)
+	}
+}
@@ -62,6 +62,9 @@ func GetContextData(ctx context.Context) context.Context {
 		ctx = context.WithValue(ctx, ThreadID, md[ThreadID][0])
 		ctx = context.WithValue(ctx, ThreadName, md[ThreadName][0])
 	}
+	if len(md[ClientIP]) > 0 {
+		ctx = context.WithValue(ctx, ClientIP, md[ClientIP][0])
+	}
 
 	return ctx
 }
@@ -77,6 +80,9 @@ func CreateMetadata(ctx context.Context) context.Context {
 			ThreadID:      ctx.Value(ThreadID).(string),
 			ThreadName:    ctx.Value(ThreadName).(string),
 		})
+		if clientIP, ok := ctx.Value(ClientIP).(string); ok && clientIP != "" {
+			md.Set(ClientIP, clientIP)
+		}
 		ctx = metadata.NewOutgoingContext(ctx, md)
 	}
 
@@ -105,6 +111,9 @@ func CreateNewRequestContext(ctx context.Context) context.Context {
 	reqCtx = context.WithValue(reqCtx, ActionName, actionName)
 	reqCtx = context.WithValue(reqCtx, ThreadID, threadID)
 	reqCtx = context.WithValue(reqCtx, ThreadName, threadName)
+	if clientIP, ok := ctx.Value(ClientIP).(string); ok {
+		reqCtx = context.WithValue(reqCtx, ClientIP, clientIP)
+	}
 	return reqCtx
 }
 
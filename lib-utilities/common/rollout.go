@@ -0,0 +1,120 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+const (
+	// DefaultRolloutBatchSize is the number of servers an operation is rolled out to at a time
+	// when the batch size is not given
+	DefaultRolloutBatchSize = 5
+	// DefaultRolloutFailureThreshold is the number of failed servers which pauses a rollout
+	// when the failure threshold is not given
+	DefaultRolloutFailureThreshold = 1
+)
+
+// RolloutPolicy holds how an operation on many servers is rolled out in batches
+type RolloutPolicy struct {
+	// BatchSize is the number of servers the operation is performed on at a time.
+	// The next batch is started only after every server of the previous batch is done.
+	BatchSize int `json:"BatchSize,omitempty"`
+	// FailureThreshold is the number of failed servers which pauses the rollout.
+	// The servers of the batches not yet started are left as they are.
+	FailureThreshold int `json:"FailureThreshold,omitempty"`
+}
+
+// Validate checks the values of the policy
+func (p RolloutPolicy) Validate() error {
+	if p.BatchSize < 0 {
+		return fmt.Errorf("BatchSize must not be negative")
+	}
+	if p.FailureThreshold < 0 {
+		return fmt.Errorf("FailureThreshold must not be negative")
+	}
+	return nil
+}
+
+// RolloutResult holds the servers of a rollout by their outcome
+type RolloutResult struct {
+	Succeeded []string
+	Failed    []string
+	// Skipped holds the servers of the batches not started as the rollout was paused
+	Skipped []string
+}
+
+// Paused tells whether the rollout was paused by reaching the failure threshold
+func (r RolloutResult) Paused() bool {
+	return len(r.Skipped) > 0
+}
+
+// Done returns the number of servers on which the operation was performed
+func (r RolloutResult) Done() int {
+	return len(r.Succeeded) + len(r.Failed)
+}
+
+// RollOut performs an operation on the targets in batches as given in the policy.
+// The operation is performed concurrently on the targets of a batch, and it returns
+// whether it succeeded on the target, including any health check after it.
+// batchDone is called with the result so far after every batch, and may be nil.
+// The rollout is paused, leaving the remaining targets skipped, once the number of
+// failed targets reaches the failure threshold.
+func RollOut(ctx context.Context, targets []string, policy RolloutPolicy, operation func(context.Context, string) bool, batchDone func(RolloutResult)) RolloutResult {
+	batchSize := policy.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultRolloutBatchSize
+	}
+	failureThreshold := policy.FailureThreshold
+	if failureThreshold <= 0 {
+		failureThreshold = DefaultRolloutFailureThreshold
+	}
+
+	var result RolloutResult
+	for start := 0; start < len(targets); start += batchSize {
+		if len(result.Failed) >= failureThreshold {
+			result.Skipped = append(result.Skipped, targets[start:]...)
+			break
+		}
+		end := start + batchSize
+		if end > len(targets) {
+			end = len(targets)
+		}
+		batch := targets[start:end]
+		succeeded := make([]bool, len(batch))
+		var wg sync.WaitGroup
+		for i, target := range batch {
+			wg.Add(1)
+			go func(i int, target string) {
+				defer wg.Done()
+				succeeded[i] = operation(ctx, target)
+			}(i, target)
+		}
+		wg.Wait()
+		for i, target := range batch {
+			if succeeded[i] {
+				result.Succeeded = append(result.Succeeded, target)
+			} else {
+				result.Failed = append(result.Failed, target)
+			}
+		}
+		if batchDone != nil {
+			batchDone(result)
+		}
+	}
+	return result
+}
@@ -0,0 +1,98 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestRollOut(t *testing.T) {
+	var targets []string
+	for i := 1; i <= 12; i++ {
+		targets = append(targets, fmt.Sprintf("server%d", i))
+	}
+	failing := map[string]bool{"server2": true, "server4": true}
+	tests := []struct {
+		name        string
+		policy      RolloutPolicy
+		wantFailed  []string
+		wantSkipped int
+		wantBatches int
+	}{
+		{
+			name:        "default policy pauses after the first batch",
+			policy:      RolloutPolicy{},
+			wantFailed:  []string{"server2", "server4"},
+			wantSkipped: 7,
+			wantBatches: 1,
+		},
+		{
+			name:        "failure threshold not reached",
+			policy:      RolloutPolicy{BatchSize: 3, FailureThreshold: 3},
+			wantFailed:  []string{"server2", "server4"},
+			wantSkipped: 0,
+			wantBatches: 4,
+		},
+		{
+			name:        "failure threshold reached in the second batch",
+			policy:      RolloutPolicy{BatchSize: 3, FailureThreshold: 2},
+			wantFailed:  []string{"server2", "server4"},
+			wantSkipped: 6,
+			wantBatches: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lock sync.Mutex
+			var performed []string
+			operation := func(ctx context.Context, target string) bool {
+				lock.Lock()
+				performed = append(performed, target)
+				lock.Unlock()
+				return !failing[target]
+			}
+			batches := 0
+			result := RollOut(context.Background(), targets, tt.policy, operation, func(RolloutResult) { batches++ })
+			if !reflect.DeepEqual(result.Failed, tt.wantFailed) {
+				t.Errorf("RollOut() failed = %v, want %v", result.Failed, tt.wantFailed)
+			}
+			if len(result.Skipped) != tt.wantSkipped || result.Paused() != (tt.wantSkipped > 0) {
+				t.Errorf("RollOut() skipped = %v, want %v servers", result.Skipped, tt.wantSkipped)
+			}
+			if batches != tt.wantBatches {
+				t.Errorf("RollOut() batches = %v, want %v", batches, tt.wantBatches)
+			}
+			if len(performed) != result.Done() {
+				t.Errorf("RollOut() performed on %v servers, want %v", len(performed), result.Done())
+			}
+		})
+	}
+}
+
+func TestRolloutPolicy_Validate(t *testing.T) {
+	if err := (RolloutPolicy{BatchSize: 2, FailureThreshold: 1}).Validate(); err != nil {
+		t.Errorf("Validate() error = %v, want nil", err)
+	}
+	if err := (RolloutPolicy{BatchSize: -1}).Validate(); err == nil {
+		t.Error("Validate() error = nil, want error for negative BatchSize")
+	}
+	if err := (RolloutPolicy{FailureThreshold: -1}).Validate(); err == nil {
+		t.Error("Validate() error = nil, want error for negative FailureThreshold")
+	}
+}
@@ -0,0 +1,122 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/secrets"
+)
+
+// SecretTable is the table of the on-disk database having the secrets of the Database secrets provider
+const SecretTable = "Secret"
+
+const vaultRequestTimeout = 10 * time.Second
+
+var secretsProvider struct {
+	sync.Mutex
+	conf     config.SecretsProviderConf
+	provider secrets.Provider
+}
+
+// GetSecretsProvider returns the provider of the secrets set in the configuration.
+// The provider is created again when the configuration is changed.
+func GetSecretsProvider() (secrets.Provider, error) {
+	conf := config.SecretsProviderConf{Type: config.SecretsProviderDatabase}
+	if config.Data.SecretsProviderConf != nil {
+		conf = *config.Data.SecretsProviderConf
+	}
+	secretsProvider.Lock()
+	defer secretsProvider.Unlock()
+	if secretsProvider.provider != nil && secretsProvider.conf == conf {
+		return secretsProvider.provider, nil
+	}
+	provider, err := newSecretsProvider(conf)
+	if err != nil {
+		return nil, err
+	}
+	secretsProvider.conf = conf
+	secretsProvider.provider = provider
+	return provider, nil
+}
+
+func newSecretsProvider(conf config.SecretsProviderConf) (secrets.Provider, error) {
+	switch conf.Type {
+	case config.SecretsProviderFile:
+		return secrets.FileProvider{Directory: conf.Directory}, nil
+	case config.SecretsProviderVault:
+		client, err := newVaultClient(conf.VaultAddress)
+		if err != nil {
+			return nil, err
+		}
+		ttl := conf.VaultCacheTTLInSeconds
+		if ttl <= 0 {
+			ttl = config.DefaultVaultCacheTTLInSeconds
+		}
+		provider := secrets.VaultProvider{
+			Address:   conf.VaultAddress,
+			Token:     conf.VaultToken,
+			MountPath: conf.VaultMountPath,
+			Client:    client,
+		}
+		return secrets.NewCachingProvider(provider, time.Duration(ttl)*time.Second), nil
+	}
+	return secrets.DatabaseProvider{Read: readSecret, Decrypt: decryptWithPrivateKey}, nil
+}
+
+func newVaultClient(address string) (*http.Client, error) {
+	client := &http.Client{Timeout: vaultRequestTimeout}
+	if !strings.HasPrefix(address, "https://") {
+		return client, nil
+	}
+	tlsConfig := &tls.Config{}
+	httpConf := &config.HTTPConfig{
+		CACertificate: &config.Data.KeyCertConf.RootCACertificate,
+	}
+	if err := httpConf.LoadCertificates(tlsConfig); err != nil {
+		return nil, fmt.Errorf("unable to load the certificates for contacting vault: %v", err)
+	}
+	config.Client.SetTLSConfig(tlsConfig)
+	client.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+	return client, nil
+}
+
+// readSecret reads the encrypted secret stored at the path from the on-disk database
+func readSecret(path string) (string, error) {
+	conn, err := GetDBConnection(OnDisk)
+	if err != nil {
+		return "", err
+	}
+	data, err := conn.Read(SecretTable, path)
+	if err != nil {
+		return "", err
+	}
+	return data, nil
+}
+
+// resolveSecret returns the secret referenced from the configured secrets provider
+func resolveSecret(reference []byte) ([]byte, error) {
+	provider, err := GetSecretsProvider()
+	if err != nil {
+		return nil, err
+	}
+	return secrets.Resolve(provider, reference)
+}
@@ -0,0 +1,52 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+)
+
+func TestSecretReferences(t *testing.T) {
+	config.SetUpMockConfig(t)
+	defer func() {
+		config.Data.SecretsProviderConf = nil
+	}()
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "bmc-password"), []byte("Password1!\n"), 0600)
+	config.Data.SecretsProviderConf = &config.SecretsProviderConf{Type: config.SecretsProviderFile, Directory: dir}
+
+	reference := []byte("secret://bmc-password")
+	stored, err := EncryptWithPublicKey(reference)
+	if err != nil || string(stored) != string(reference) {
+		t.Fatalf("EncryptWithPublicKey() = %s, %v, want the reference stored as it is", stored, err)
+	}
+	password, err := DecryptWithPrivateKey(stored)
+	if err != nil || string(password) != "Password1!" {
+		t.Fatalf("DecryptWithPrivateKey() = %s, %v, want the secret of the file", password, err)
+	}
+	if _, err := DecryptWithPrivateKey([]byte("secret://unknown")); err == nil {
+		t.Errorf("DecryptWithPrivateKey() error = nil for a missing secret")
+	}
+
+	// the provider follows the changes of the configuration
+	config.Data.SecretsProviderConf = &config.SecretsProviderConf{Type: config.SecretsProviderFile, Directory: t.TempDir()}
+	if _, err := DecryptWithPrivateKey(stored); err == nil {
+		t.Errorf("DecryptWithPrivateKey() error = nil after the secrets directory is changed")
+	}
+}
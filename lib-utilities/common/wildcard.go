@@ -0,0 +1,205 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	// SystemIDWildCard is used to replace with system id in wildcard property
+	SystemIDWildCard = "SystemID"
+	// ChassisIDWildCard is used to replace with chassis id in wildcard property
+	ChassisIDWildCard = "ChassisID"
+)
+
+// WildCard is used to reduce the size the of list of metric properties
+type WildCard struct {
+	Name   string
+	Values []string
+}
+
+// FormWildCard is used to form the wild card
+// if the data not present in the db(means first time add server) then create empty wild and update it with metric properties
+// if the wild card data already present then update it with new properties
+func FormWildCard(dbData string, resourceDataMap map[string]interface{}) (string, error) {
+	var systemID, chassisID string
+	var wildCards []WildCard
+	var dbMetricProperties []interface{}
+
+	if len(dbData) < 1 {
+		wildCards = getEmptyWildCard()
+	} else {
+		var dbDataMap map[string]interface{}
+		err := json.Unmarshal([]byte(dbData), &dbDataMap)
+		if err != nil {
+			return "", err
+		}
+		if dbDataMap["Wildcards"] == nil {
+			return "", fmt.Errorf("wild card map is empty")
+		}
+		wildCards = GetWildCard(dbDataMap["Wildcards"].([]interface{}))
+		dbMetricProperties = dbDataMap["MetricProperties"].([]interface{})
+	}
+	metricProperties, isExists := resourceDataMap["MetricProperties"].([]interface{})
+	if isExists {
+		for _, mProperty := range metricProperties {
+			property := mProperty.(string)
+			for i, wCard := range wildCards {
+				// the property is already in the wild card form
+				if strings.Contains(property, "{"+wCard.Name+"}") {
+					break
+				}
+				if wCard.Name == SystemIDWildCard && strings.Contains(property, "/Systems/") {
+					property, systemID = getUpdatedProperty(property, SystemIDWildCard)
+					if !checkWildCardPresent(systemID, wildCards[i].Values) {
+						wildCards[i].Values = append(wildCards[i].Values, systemID)
+					}
+					break
+				}
+				if wCard.Name == ChassisIDWildCard && strings.Contains(property, "/Chassis/") {
+					property, chassisID = getUpdatedProperty(property, ChassisIDWildCard)
+					if !checkWildCardPresent(chassisID, wCard.Values) {
+						wildCards[i].Values = append(wildCards[i].Values, chassisID)
+					}
+					break
+				}
+			}
+			if !checkMetricPropertyPresent(property, dbMetricProperties) {
+				dbMetricProperties = append(dbMetricProperties, property)
+			}
+		}
+	}
+	var wCards []WildCard
+	for _, wCard := range wildCards {
+		if len(wCard.Values) > 0 {
+			wCards = append(wCards, wCard)
+		}
+	}
+	if len(wCards) > 0 {
+		resourceDataMap["Wildcards"] = wCards
+		resourceDataMap["MetricProperties"] = dbMetricProperties
+	}
+	resourceDataByte, err := json.Marshal(resourceDataMap)
+	if err != nil {
+		return "", err
+	}
+	return string(resourceDataByte), nil
+}
+
+// ExpandWildCard replaces the wild cards used in the metric properties with each of their values,
+// the properties without any wild card are returned as they are and the ones with a wild card
+// which is not given or has no values are left out
+func ExpandWildCard(properties []string, wildCards []WildCard) []string {
+	var expanded []string
+	for _, property := range properties {
+		current := []string{property}
+		for _, wCard := range wildCards {
+			name := "{" + wCard.Name + "}"
+			if !strings.Contains(property, name) {
+				continue
+			}
+			var next []string
+			for _, p := range current {
+				for _, value := range wCard.Values {
+					next = append(next, strings.Replace(p, name, value, -1))
+				}
+			}
+			current = next
+		}
+		for _, p := range current {
+			if strings.Contains(p, "{") || checkWildCardPresent(p, expanded) {
+				continue
+			}
+			expanded = append(expanded, p)
+		}
+	}
+	return expanded
+}
+
+// checkWildCardPresent will check the wild card present in the array
+// if its present returns true, else false.
+func checkWildCardPresent(val string, values []string) bool {
+	if len(values) < 1 {
+		return false
+	}
+	front := 0
+	rear := len(values) - 1
+	for front <= rear {
+		if values[front] == val || values[rear] == val {
+			return true
+		}
+		front++
+		rear--
+	}
+	return false
+}
+
+// getUpdatedProperty function get the uuid from the property and update the property with wild card name
+func getUpdatedProperty(property, wildCardName string) (string, string) {
+	prop := strings.Split(property, "/")[4]
+	uuid := strings.Split(prop, "#")[0]
+	property = strings.Replace(property, uuid, "{"+wildCardName+"}", -1)
+	return property, uuid
+}
+
+// GetWildCard function will convert array of interface to array of string
+func GetWildCard(wCard []interface{}) []WildCard {
+	var wildCard []WildCard
+	for _, val := range wCard {
+		card := val.(map[string]interface{})
+		b, err := json.Marshal(card)
+		if err != nil {
+			continue
+		}
+		var wc WildCard
+		json.Unmarshal(b, &wc)
+		wildCard = append(wildCard, wc)
+	}
+	return wildCard
+}
+
+// checkMetricPropertyPresent will check the metric property present in the array
+// if its present returns true, else false.
+func checkMetricPropertyPresent(val string, values []interface{}) bool {
+	if len(values) < 1 {
+		return false
+	}
+	front := 0
+	rear := len(values) - 1
+	for front <= rear {
+		if values[front].(string) == val || values[rear].(string) == val {
+			return true
+		}
+		front++
+		rear--
+	}
+	return false
+}
+
+// getEmptyWildCard function is for create empty wild card field with default SystemID and ChassisID name and empty values
+func getEmptyWildCard() []WildCard {
+	var wildCards []WildCard
+	var w WildCard
+	w.Name = SystemIDWildCard
+	w.Values = []string{}
+	wildCards = append(wildCards, w)
+	w.Name = ChassisIDWildCard
+	w.Values = []string{}
+	wildCards = append(wildCards, w)
+	return wildCards
+}
@@ -0,0 +1,96 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestFormWildCard(t *testing.T) {
+	resourceData := map[string]interface{}{
+		"Id": "PowerMetrics",
+		"MetricProperties": []interface{}{
+			"/redfish/v1/Chassis/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1/Power#/PowerControl/0/PowerConsumedWatts",
+			"/redfish/v1/Chassis/{ChassisID}/Thermal#/Temperatures/0/ReadingCelsius",
+			"/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1#/ProcessorSummary/Count",
+		},
+	}
+	data, err := FormWildCard("", resourceData)
+	if err != nil {
+		t.Fatalf("FormWildCard() error = %v", err)
+	}
+	var got struct {
+		MetricProperties []string
+		Wildcards        []WildCard
+	}
+	json.Unmarshal([]byte(data), &got)
+	wantProperties := []string{
+		"/redfish/v1/Chassis/{ChassisID}/Power#/PowerControl/0/PowerConsumedWatts",
+		"/redfish/v1/Chassis/{ChassisID}/Thermal#/Temperatures/0/ReadingCelsius",
+		"/redfish/v1/Systems/{SystemID}#/ProcessorSummary/Count",
+	}
+	if !reflect.DeepEqual(got.MetricProperties, wantProperties) {
+		t.Errorf("FormWildCard() properties = %v, want %v", got.MetricProperties, wantProperties)
+	}
+	wantWildCards := []WildCard{
+		{Name: SystemIDWildCard, Values: []string{"6d4a0a66-7efa-578e-83cf-44dc68d2874e.1"}},
+		{Name: ChassisIDWildCard, Values: []string{"6d4a0a66-7efa-578e-83cf-44dc68d2874e.1"}},
+	}
+	if !reflect.DeepEqual(got.Wildcards, wantWildCards) {
+		t.Errorf("FormWildCard() wild cards = %v, want %v", got.Wildcards, wantWildCards)
+	}
+
+	// the values of the wild cards already saved are kept
+	data, err = FormWildCard(data, map[string]interface{}{
+		"MetricProperties": []interface{}{"/redfish/v1/Systems/0f7ac3ab-3a9e-5e4a-a1a6-5b6f4aa2e0ff.1#/ProcessorSummary/Count"},
+	})
+	if err != nil {
+		t.Fatalf("FormWildCard() error = %v", err)
+	}
+	json.Unmarshal([]byte(data), &got)
+	if len(got.MetricProperties) != 3 || !reflect.DeepEqual(got.Wildcards[0].Values, []string{"6d4a0a66-7efa-578e-83cf-44dc68d2874e.1", "0f7ac3ab-3a9e-5e4a-a1a6-5b6f4aa2e0ff.1"}) {
+		t.Errorf("FormWildCard() = %v", data)
+	}
+
+	if _, err = FormWildCard(`{"Id":"PowerMetrics"}`, resourceData); err == nil {
+		t.Errorf("FormWildCard() expected an error for the data without wild cards")
+	}
+}
+
+func TestExpandWildCard(t *testing.T) {
+	properties := []string{
+		"/redfish/v1/Chassis/{ChassisID}/Power#/PowerControl/0/PowerConsumedWatts",
+		"/redfish/v1/Systems/{SystemID}#/ProcessorSummary/Count",
+		"/redfish/v1/Chassis/1.1/Thermal#/Temperatures/0/ReadingCelsius",
+	}
+	wildCards := []WildCard{
+		{Name: ChassisIDWildCard, Values: []string{"1.1", "2.1"}},
+		{Name: SystemIDWildCard, Values: []string{"1.1"}},
+	}
+	want := []string{
+		"/redfish/v1/Chassis/1.1/Power#/PowerControl/0/PowerConsumedWatts",
+		"/redfish/v1/Chassis/2.1/Power#/PowerControl/0/PowerConsumedWatts",
+		"/redfish/v1/Systems/1.1#/ProcessorSummary/Count",
+		"/redfish/v1/Chassis/1.1/Thermal#/Temperatures/0/ReadingCelsius",
+	}
+	if got := ExpandWildCard(properties, wildCards); !reflect.DeepEqual(got, want) {
+		t.Errorf("ExpandWildCard() = %v, want %v", got, want)
+	}
+	if got := ExpandWildCard(properties[:1], nil); len(got) != 0 {
+		t.Errorf("ExpandWildCard() = %v, want no property for a wild card without values", got)
+	}
+}
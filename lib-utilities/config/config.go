@@ -25,6 +25,8 @@ import (
 	"encoding/pem"
 	"fmt"
 	"io/ioutil"
+	"net"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
@@ -67,6 +69,13 @@ type configModel struct {
 	SupportedPluginTypes           []string                 `json:"SupportedPluginTypes"`
 	ConnectionMethodConf           []ConnectionMethodConf   `json:"ConnectionMethodConf"`
 	EventConf                      *EventConf               `json:"EventConf"`
+	MetricsConf                    *MetricsConf             `json:"MetricsConf"`
+	TracingConf                    *TracingConf             `json:"TracingConf"`
+	BMCDiscoveryConf               *BMCDiscoveryConf        `json:"BMCDiscoveryConf"`
+	InventoryRefreshConf           *InventoryRefreshConf    `json:"InventoryRefreshConf"`
+	SecretsProviderConf            *SecretsProviderConf     `json:"SecretsProviderConf"`
+	TelemetryHistoryConf           *TelemetryHistoryConf    `json:"TelemetryHistoryConf"`
+	TelemetryExportConf            *TelemetryExportConf     `json:"TelemetryExportConf"`
 	ResourceRateLimit              []string                 `json:"ResourceRateLimit"`
 	RequestLimitCountPerSession    int                      `json:"RequestLimitCountPerSession"`
 	SessionLimitCountPerUser       int                      `json:"SessionLimitCountPerUser"`
@@ -138,12 +147,15 @@ type PasswordRules struct {
 
 // APIGatewayConf holds API gateway related configurations
 type APIGatewayConf struct {
-	Host            string `json:"Host"`
-	Port            string `json:"Port"`
-	PrivateKeyPath  string `json:"PrivateKeyPath"`
-	CertificatePath string `json:"CertificatePath"`
+	Host            string   `json:"Host"`
+	Port            string   `json:"Port"`
+	PrivateKeyPath  string   `json:"PrivateKeyPath"`
+	CertificatePath string   `json:"CertificatePath"`
+	TrustedProxies  []string `json:"TrustedProxies"` // holds the addresses or CIDRs of the proxies whose X-Forwarded-For header is trusted
 	PrivateKey      []byte
 	Certificate     []byte
+	// TrustedProxyNetworks holds the parsed TrustedProxies
+	TrustedProxyNetworks []*net.IPNet `json:"-"`
 }
 
 // AddComputeSkipResources stores list of resources which need to ignored while inserting the contents to DB while adding Computer System
@@ -203,6 +215,80 @@ type EventConf struct {
 	DeliveryRetryIntervalSeconds int `json:"DeliveryRetryIntervalSeconds"` // holds value of retrying events posting in interval
 }
 
+// MetricsConf holds the configuration of the endpoint exposing the service metrics.
+// The health endpoints are served on the same address even if the metrics are disabled.
+type MetricsConf struct {
+	Enabled       bool   `json:"Enabled"`
+	ListenAddress string `json:"ListenAddress"` // address on which the /metrics and /health endpoints are served
+}
+
+// TracingConf holds the configuration for exporting the traces to an OpenTelemetry collector
+type TracingConf struct {
+	Enabled           bool    `json:"Enabled"`
+	CollectorEndpoint string  `json:"CollectorEndpoint"` // base URL of the OTLP/HTTP receiver of the collector
+	SamplingRatio     float64 `json:"SamplingRatio"`     // ratio of the traces started by ODIM to be sampled
+}
+
+// BMCDiscoveryConf holds the configuration for discovering the BMCs present in the configured subnets
+type BMCDiscoveryConf struct {
+	Enabled                 bool     `json:"Enabled"`
+	Subnets                 []string `json:"Subnets"` // CIDR ranges to be scanned for BMCs
+	Ports                   []int    `json:"Ports"`   // ports probed for the Redfish service root
+	ScanIntervalInMins      int      `json:"ScanIntervalInMins"`
+	ProbeTimeoutInSecs      int      `json:"ProbeTimeoutInSecs"`
+	MaxConcurrentProbes     int      `json:"MaxConcurrentProbes"`
+	MaxHostsPerScan         int      `json:"MaxHostsPerScan"`
+	DefaultUserName         string   `json:"DefaultUserName"`         // username used for adopting a discovered BMC
+	DefaultPasswordFilePath string   `json:"DefaultPasswordFilePath"` // file having the RSA-OAEP encrypted password used for adopting a discovered BMC
+	DefaultPassword         []byte
+}
+
+// InventoryRefreshConf holds the configuration for refreshing the inventory of the servers periodically
+type InventoryRefreshConf struct {
+	Enabled         bool                    `json:"Enabled"`
+	ResourceClasses []InventoryRefreshClass `json:"ResourceClasses"`
+}
+
+// InventoryRefreshClass is a class of resources refreshed from the servers at the configured interval
+type InventoryRefreshClass struct {
+	Name           string `json:"Name"` // name of the resource collection, e.g. Sensors or FirmwareInventory
+	IntervalInMins int    `json:"IntervalInMins"`
+}
+
+// SecretsProviderConf holds the configuration of the provider resolving the secret:// references
+// given as the credentials of the servers and of the plugins
+type SecretsProviderConf struct {
+	Type               string `json:"Type"`               // Database, File or Vault
+	Directory          string `json:"Directory"`          // directory of the secret files for the File provider
+	VaultAddress       string `json:"VaultAddress"`       // URL of the Vault server for the Vault provider
+	VaultMountPath     string `json:"VaultMountPath"`     // mount path of the KV version 2 secrets engine
+	VaultTokenFilePath string `json:"VaultTokenFilePath"` // file having the token for reading the secrets from Vault
+	VaultToken         string
+
+	// time the secrets read from Vault are kept before they are read again
+	VaultCacheTTLInSeconds int `json:"VaultCacheTTLInSeconds"`
+}
+
+// TelemetryHistoryConf holds the configuration of the history of the metric values
+// kept by the telemetry service
+type TelemetryHistoryConf struct {
+	Enabled                  bool `json:"Enabled"`
+	CollectionIntervalInMins int  `json:"CollectionIntervalInMins"` // interval of reading the metric reports from the servers
+	RetentionInDays          int  `json:"RetentionInDays"`
+	RawRetentionInHours      int  `json:"RawRetentionInHours"`      // older metric values are downsampled
+	DownsampleIntervalInMins int  `json:"DownsampleIntervalInMins"` // metric values of an interval are kept as one sample
+}
+
+// TelemetryExportConf holds the configuration of the export of the metric values of
+// the metric history to Prometheus
+type TelemetryExportConf struct {
+	OpenMetricsEnabled             bool   `json:"OpenMetricsEnabled"`             // metric values are served on the OpenMetrics endpoint
+	RemoteWriteURL                 string `json:"RemoteWriteURL"`                 // metric values are pushed to the Prometheus remote write URL when set
+	RemoteWriteIntervalInSecs      int    `json:"RemoteWriteIntervalInSecs"`      // interval of pushing the new metric values
+	RemoteWriteTimeoutInSecs       int    `json:"RemoteWriteTimeoutInSecs"`       // timeout of a push to the remote write URL
+	RemoteWriteBearerTokenFilePath string `json:"RemoteWriteBearerTokenFilePath"` // file having the bearer token of the remote write URL
+}
+
 // PluginTasksConf stores the information related to plugin tasks
 // and queueing and prioritization of requests to plugin
 type PluginTasksConf struct {
@@ -258,6 +344,25 @@ func ValidateConfiguration() (WarningList, error) {
 	if err = checkEventConf(warningList); err != nil {
 		return *warningList, err
 	}
+	checkMetricsConf(warningList)
+	if err = checkTracingConf(warningList); err != nil {
+		return *warningList, err
+	}
+	if err = checkBMCDiscoveryConf(warningList); err != nil {
+		return *warningList, err
+	}
+	if err = checkInventoryRefreshConf(warningList); err != nil {
+		return *warningList, err
+	}
+	if err = checkSecretsProviderConf(warningList); err != nil {
+		return *warningList, err
+	}
+	if err = checkTelemetryHistoryConf(warningList); err != nil {
+		return *warningList, err
+	}
+	if err = checkTelemetryExportConf(warningList); err != nil {
+		return *warningList, err
+	}
 	if err = checkResourceRateLimit(); err != nil {
 		return *warningList, err
 	}
@@ -543,9 +648,38 @@ func checkAPIGatewayConf() error {
 	if Data.APIGatewayConf.Certificate, err = ioutil.ReadFile(Data.APIGatewayConf.CertificatePath); err != nil {
 		return fmt.Errorf("error: value check failed for CertificatePath:%s with %v", Data.APIGatewayConf.CertificatePath, err)
 	}
+	if Data.APIGatewayConf.TrustedProxyNetworks, err = parseTrustedProxies(Data.APIGatewayConf.TrustedProxies); err != nil {
+		return err
+	}
 	return nil
 }
 
+// parseTrustedProxies parses the trusted proxies, given either as an IP address
+// or as a CIDR, into the networks they cover
+func parseTrustedProxies(proxies []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		if strings.Contains(proxy, "/") {
+			_, network, err := net.ParseCIDR(proxy)
+			if err != nil {
+				return nil, fmt.Errorf("error: value check failed for TrustedProxies:%s with %v", proxy, err)
+			}
+			networks = append(networks, network)
+			continue
+		}
+		ip := net.ParseIP(proxy)
+		if ip == nil {
+			return nil, fmt.Errorf("error: value check failed for TrustedProxies:%s is not a valid IP address", proxy)
+		}
+		bits := 8 * net.IPv6len
+		if ip4 := ip.To4(); ip4 != nil {
+			ip, bits = ip4, 8*net.IPv4len
+		}
+		networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+	}
+	return networks, nil
+}
+
 func checkAddComputeSkipResources(wl *WarningList) {
 	if Data.AddComputeSkipResources == nil {
 		wl.add("No value found for AddComputeRetrival, setting default value")
@@ -720,6 +854,234 @@ func checkEventConf(wl *WarningList) error {
 	return nil
 }
 
+func checkMetricsConf(wl *WarningList) {
+	if Data.MetricsConf == nil {
+		wl.add("MetricsConf not provided, setting default value")
+		Data.MetricsConf = &MetricsConf{
+			Enabled:       true,
+			ListenAddress: DefaultMetricsListenAddress,
+		}
+		return
+	}
+	if Data.MetricsConf.Enabled && Data.MetricsConf.ListenAddress == "" {
+		wl.add("No value found for metrics ListenAddress, setting default value")
+		Data.MetricsConf.ListenAddress = DefaultMetricsListenAddress
+	}
+}
+
+func checkTracingConf(wl *WarningList) error {
+	if Data.TracingConf == nil {
+		wl.add("TracingConf not provided, tracing is disabled")
+		Data.TracingConf = &TracingConf{
+			SamplingRatio: DefaultTracingSamplingRatio,
+		}
+		return nil
+	}
+	if Data.TracingConf.SamplingRatio <= 0 || Data.TracingConf.SamplingRatio > 1 {
+		wl.add("Invalid value configured for SamplingRatio, setting default value")
+		Data.TracingConf.SamplingRatio = DefaultTracingSamplingRatio
+	}
+	if Data.TracingConf.Enabled && Data.TracingConf.CollectorEndpoint == "" {
+		return fmt.Errorf("error: CollectorEndpoint is not provided for TracingConf")
+	}
+	return nil
+}
+
+func checkBMCDiscoveryConf(wl *WarningList) error {
+	if Data.BMCDiscoveryConf == nil {
+		wl.add("BMCDiscoveryConf not provided, BMC discovery is disabled")
+		Data.BMCDiscoveryConf = &BMCDiscoveryConf{}
+	}
+	conf := Data.BMCDiscoveryConf
+	var subnetHosts uint64
+	for _, subnet := range conf.Subnets {
+		_, ipNet, err := net.ParseCIDR(subnet)
+		if err != nil {
+			return fmt.Errorf("error: invalid subnet %s configured for BMCDiscoveryConf: %v", subnet, err)
+		}
+		subnetHosts += countSubnetHosts(ipNet)
+	}
+	for _, port := range conf.Ports {
+		if port <= 0 || port > 65535 {
+			return fmt.Errorf("error: invalid port %d configured for BMCDiscoveryConf", port)
+		}
+	}
+	if len(conf.Ports) == 0 {
+		conf.Ports = DefaultBMCDiscoveryPorts
+	}
+	if conf.ScanIntervalInMins <= 0 {
+		wl.add("No value set for ScanIntervalInMins, setting default value")
+		conf.ScanIntervalInMins = DefaultBMCDiscoveryScanIntervalInMins
+	}
+	if conf.ProbeTimeoutInSecs <= 0 {
+		wl.add("No value set for ProbeTimeoutInSecs, setting default value")
+		conf.ProbeTimeoutInSecs = DefaultBMCDiscoveryProbeTimeoutInSecs
+	}
+	if conf.MaxConcurrentProbes <= 0 {
+		wl.add("No value set for MaxConcurrentProbes, setting default value")
+		conf.MaxConcurrentProbes = DefaultBMCDiscoveryMaxConcurrentProbes
+	}
+	if conf.MaxHostsPerScan <= 0 {
+		wl.add("No value set for MaxHostsPerScan, setting default value")
+		conf.MaxHostsPerScan = DefaultBMCDiscoveryMaxHostsPerScan
+	}
+	// every scan probes all the addresses, so that the BMCs which no longer respond are removed
+	if subnetHosts > uint64(conf.MaxHostsPerScan) {
+		return fmt.Errorf("error: the subnets configured for BMCDiscoveryConf have more than %d addresses of MaxHostsPerScan", conf.MaxHostsPerScan)
+	}
+	if conf.Enabled && len(conf.Subnets) == 0 {
+		wl.add("No subnets configured for BMCDiscoveryConf, BMC discovery is disabled")
+		conf.Enabled = false
+	}
+	if conf.DefaultPasswordFilePath != "" && Data.KeyCertConf != nil && Data.KeyCertConf.RSAPrivateKeyPath != "" {
+		var err error
+		if conf.DefaultPassword, err = decryptRSAOAEPEncryptedPasswords(conf.DefaultPasswordFilePath); err != nil {
+			return fmt.Errorf("error: while decrypting password from the passwordFilePath:%s with %v", conf.DefaultPasswordFilePath, err)
+		}
+	}
+	return nil
+}
+
+// countSubnetHosts returns the number of addresses of the subnet probed by the BMC discovery,
+// which excludes the network and broadcast addresses of IPv4 subnets. The larger IPv6 subnets
+// count for 2^32 addresses, more than can be probed anyway.
+func countSubnetHosts(ipNet *net.IPNet) uint64 {
+	ones, bits := ipNet.Mask.Size()
+	hostBits := bits - ones
+	if hostBits > 32 {
+		hostBits = 32
+	}
+	count := uint64(1) << hostBits
+	if bits == 32 && hostBits > 1 {
+		count -= 2
+	}
+	return count
+}
+
+func checkInventoryRefreshConf(wl *WarningList) error {
+	if Data.InventoryRefreshConf == nil {
+		wl.add("InventoryRefreshConf not provided, periodic inventory refresh is disabled")
+		Data.InventoryRefreshConf = &InventoryRefreshConf{}
+	}
+	conf := Data.InventoryRefreshConf
+	for _, class := range conf.ResourceClasses {
+		if class.Name == "" {
+			return fmt.Errorf("error: no resource class name configured for InventoryRefreshConf")
+		}
+		if class.IntervalInMins <= 0 {
+			return fmt.Errorf("error: invalid interval %d configured for the resource class %s in InventoryRefreshConf",
+				class.IntervalInMins, class.Name)
+		}
+	}
+	if conf.Enabled && len(conf.ResourceClasses) == 0 {
+		wl.add("No resource classes configured for InventoryRefreshConf, periodic inventory refresh is disabled")
+		conf.Enabled = false
+	}
+	return nil
+}
+
+func checkSecretsProviderConf(wl *WarningList) error {
+	if Data.SecretsProviderConf == nil {
+		wl.add("SecretsProviderConf not provided, secrets are read from the database")
+		Data.SecretsProviderConf = &SecretsProviderConf{}
+	}
+	conf := Data.SecretsProviderConf
+	switch conf.Type {
+	case "":
+		conf.Type = SecretsProviderDatabase
+	case SecretsProviderDatabase:
+	case SecretsProviderFile:
+		if conf.Directory == "" {
+			return fmt.Errorf("error: no value set for Directory of the File secrets provider")
+		}
+	case SecretsProviderVault:
+		if conf.VaultAddress == "" {
+			return fmt.Errorf("error: no value set for VaultAddress of the Vault secrets provider")
+		}
+		if conf.VaultTokenFilePath == "" {
+			return fmt.Errorf("error: no value set for VaultTokenFilePath of the Vault secrets provider")
+		}
+		token, err := ioutil.ReadFile(conf.VaultTokenFilePath)
+		if err != nil {
+			return fmt.Errorf("error: unable to read the Vault token from %s: %v", conf.VaultTokenFilePath, err)
+		}
+		conf.VaultToken = strings.TrimSpace(string(token))
+		if conf.VaultCacheTTLInSeconds < 0 {
+			return fmt.Errorf("error: invalid value %d set for VaultCacheTTLInSeconds of the Vault secrets provider", conf.VaultCacheTTLInSeconds)
+		}
+		if conf.VaultCacheTTLInSeconds == 0 {
+			wl.add("No value set for VaultCacheTTLInSeconds, setting default value")
+			conf.VaultCacheTTLInSeconds = DefaultVaultCacheTTLInSeconds
+		}
+	default:
+		return fmt.Errorf("error: invalid secrets provider type %s, expected one of %s, %s or %s",
+			conf.Type, SecretsProviderDatabase, SecretsProviderFile, SecretsProviderVault)
+	}
+	return nil
+}
+
+func checkTelemetryHistoryConf(wl *WarningList) error {
+	if Data.TelemetryHistoryConf == nil {
+		wl.add("TelemetryHistoryConf not provided, metric history is disabled")
+		Data.TelemetryHistoryConf = &TelemetryHistoryConf{}
+	}
+	conf := Data.TelemetryHistoryConf
+	if conf.CollectionIntervalInMins < 0 || conf.RetentionInDays < 0 ||
+		conf.RawRetentionInHours < 0 || conf.DownsampleIntervalInMins < 0 {
+		return fmt.Errorf("error: negative value configured in TelemetryHistoryConf")
+	}
+	if conf.CollectionIntervalInMins == 0 {
+		wl.add("No value set for CollectionIntervalInMins of TelemetryHistoryConf, setting default value")
+		conf.CollectionIntervalInMins = DefaultTelemetryCollectionInterval
+	}
+	if conf.RetentionInDays == 0 {
+		wl.add("No value set for RetentionInDays of TelemetryHistoryConf, setting default value")
+		conf.RetentionInDays = DefaultTelemetryRetention
+	}
+	if conf.RawRetentionInHours == 0 {
+		wl.add("No value set for RawRetentionInHours of TelemetryHistoryConf, setting default value")
+		conf.RawRetentionInHours = DefaultTelemetryRawRetention
+	}
+	if conf.DownsampleIntervalInMins == 0 {
+		wl.add("No value set for DownsampleIntervalInMins of TelemetryHistoryConf, setting default value")
+		conf.DownsampleIntervalInMins = DefaultTelemetryDownsampleInterval
+	}
+	if conf.RawRetentionInHours > conf.RetentionInDays*24 {
+		return fmt.Errorf("error: RawRetentionInHours %d of TelemetryHistoryConf exceeds the retention of %d days",
+			conf.RawRetentionInHours, conf.RetentionInDays)
+	}
+	return nil
+}
+
+func checkTelemetryExportConf(wl *WarningList) error {
+	if Data.TelemetryExportConf == nil {
+		wl.add("TelemetryExportConf not provided, metric values are not exported")
+		Data.TelemetryExportConf = &TelemetryExportConf{}
+	}
+	conf := Data.TelemetryExportConf
+	if conf.RemoteWriteIntervalInSecs < 0 || conf.RemoteWriteTimeoutInSecs < 0 {
+		return fmt.Errorf("error: negative value configured in TelemetryExportConf")
+	}
+	if conf.RemoteWriteIntervalInSecs == 0 {
+		wl.add("No value set for RemoteWriteIntervalInSecs of TelemetryExportConf, setting default value")
+		conf.RemoteWriteIntervalInSecs = DefaultRemoteWriteInterval
+	}
+	if conf.RemoteWriteTimeoutInSecs == 0 {
+		wl.add("No value set for RemoteWriteTimeoutInSecs of TelemetryExportConf, setting default value")
+		conf.RemoteWriteTimeoutInSecs = DefaultRemoteWriteTimeout
+	}
+	if conf.RemoteWriteURL != "" {
+		remoteWriteURL, err := url.Parse(conf.RemoteWriteURL)
+		if err != nil || (remoteWriteURL.Scheme != "http" && remoteWriteURL.Scheme != "https") || remoteWriteURL.Host == "" {
+			return fmt.Errorf("error: invalid RemoteWriteURL %s configured in TelemetryExportConf", conf.RemoteWriteURL)
+		}
+	}
+	if (conf.OpenMetricsEnabled || conf.RemoteWriteURL != "") && !Data.TelemetryHistoryConf.Enabled {
+		wl.add("TelemetryHistoryConf is not enabled, no metric values are exported")
+	}
+	return nil
+}
+
 func checkResourceRateLimit() error {
 	for _, val := range Data.ResourceRateLimit {
 		resourceLimit := strings.Split(val, ":")
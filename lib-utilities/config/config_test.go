@@ -627,3 +627,365 @@ func TestValidateConfigurationForEventConf(t *testing.T) {
 	}
 	os.Remove(sampleFileForTest)
 }
+
+func TestValidateConfigurationForMetricsConf(t *testing.T) {
+	sampleFileForTest := filepath.Join(cwdDir, sampleFileName)
+	createFile(t, sampleFileForTest, sampleFileContent)
+	tests := []struct {
+		name        string
+		conf        *MetricsConf
+		wantEnabled bool
+	}{
+		{
+			name:        "Metrics conf not provided",
+			conf:        nil,
+			wantEnabled: true,
+		},
+		{
+			name:        "Empty listen address",
+			conf:        &MetricsConf{Enabled: true},
+			wantEnabled: true,
+		},
+		{
+			name:        "Metrics disabled",
+			conf:        &MetricsConf{Enabled: false},
+			wantEnabled: false,
+		},
+	}
+	for _, tt := range tests {
+		Data.MetricsConf = tt.conf
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ValidateConfiguration(); err != nil {
+				t.Errorf("TestValidateConfigurationForMetricsConf() = %v", err)
+			}
+			if Data.MetricsConf.Enabled != tt.wantEnabled {
+				t.Errorf("TestValidateConfigurationForMetricsConf() Enabled = %v, want %v", Data.MetricsConf.Enabled, tt.wantEnabled)
+			}
+			if tt.wantEnabled && Data.MetricsConf.ListenAddress != DefaultMetricsListenAddress {
+				t.Errorf("TestValidateConfigurationForMetricsConf() ListenAddress = %v, want %v", Data.MetricsConf.ListenAddress, DefaultMetricsListenAddress)
+			}
+		})
+	}
+	os.Remove(sampleFileForTest)
+}
+
+func TestValidateConfigurationForBMCDiscoveryConf(t *testing.T) {
+	sampleFileForTest := filepath.Join(cwdDir, sampleFileName)
+	createFile(t, sampleFileForTest, sampleFileContent)
+	tests := []struct {
+		name        string
+		conf        *BMCDiscoveryConf
+		wantErr     bool
+		wantEnabled bool
+	}{
+		{
+			name:        "BMC discovery conf not provided",
+			conf:        nil,
+			wantEnabled: false,
+		},
+		{
+			name:        "Enabled without subnets",
+			conf:        &BMCDiscoveryConf{Enabled: true},
+			wantEnabled: false,
+		},
+		{
+			name:        "Enabled with subnets",
+			conf:        &BMCDiscoveryConf{Enabled: true, Subnets: []string{"10.24.0.0/24", "fd00::/120"}},
+			wantEnabled: true,
+		},
+		{
+			name:    "Invalid subnet",
+			conf:    &BMCDiscoveryConf{Enabled: true, Subnets: []string{"10.24.0.0"}},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid port",
+			conf:    &BMCDiscoveryConf{Enabled: true, Subnets: []string{"10.24.0.0/24"}, Ports: []int{70000}},
+			wantErr: true,
+		},
+		{
+			name:    "Subnets larger than MaxHostsPerScan",
+			conf:    &BMCDiscoveryConf{Enabled: true, Subnets: []string{"10.24.0.0/24", "10.25.0.0/24"}, MaxHostsPerScan: 256},
+			wantErr: true,
+		},
+		{
+			name:    "IPv6 subnet larger than MaxHostsPerScan",
+			conf:    &BMCDiscoveryConf{Enabled: true, Subnets: []string{"fd00::/64"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		Data.BMCDiscoveryConf = tt.conf
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ValidateConfiguration()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TestValidateConfigurationForBMCDiscoveryConf() = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			conf := Data.BMCDiscoveryConf
+			if conf.Enabled != tt.wantEnabled {
+				t.Errorf("TestValidateConfigurationForBMCDiscoveryConf() Enabled = %v, want %v", conf.Enabled, tt.wantEnabled)
+			}
+			if len(conf.Ports) == 0 || conf.ScanIntervalInMins != DefaultBMCDiscoveryScanIntervalInMins ||
+				conf.ProbeTimeoutInSecs != DefaultBMCDiscoveryProbeTimeoutInSecs ||
+				conf.MaxConcurrentProbes != DefaultBMCDiscoveryMaxConcurrentProbes ||
+				conf.MaxHostsPerScan != DefaultBMCDiscoveryMaxHostsPerScan {
+				t.Errorf("TestValidateConfigurationForBMCDiscoveryConf() defaults not set: %+v", conf)
+			}
+		})
+	}
+	Data.BMCDiscoveryConf = nil
+	os.Remove(sampleFileForTest)
+}
+
+func TestValidateConfigurationForInventoryRefreshConf(t *testing.T) {
+	sampleFileForTest := filepath.Join(cwdDir, sampleFileName)
+	createFile(t, sampleFileForTest, sampleFileContent)
+	tests := []struct {
+		name        string
+		conf        *InventoryRefreshConf
+		wantErr     bool
+		wantEnabled bool
+	}{
+		{
+			name:        "Inventory refresh conf not provided",
+			conf:        nil,
+			wantEnabled: false,
+		},
+		{
+			name:        "Enabled without resource classes",
+			conf:        &InventoryRefreshConf{Enabled: true},
+			wantEnabled: false,
+		},
+		{
+			name:        "Enabled with resource classes",
+			conf:        &InventoryRefreshConf{Enabled: true, ResourceClasses: []InventoryRefreshClass{{Name: "Sensors", IntervalInMins: 5}}},
+			wantEnabled: true,
+		},
+		{
+			name:    "Resource class without name",
+			conf:    &InventoryRefreshConf{Enabled: true, ResourceClasses: []InventoryRefreshClass{{IntervalInMins: 5}}},
+			wantErr: true,
+		},
+		{
+			name:    "Resource class with invalid interval",
+			conf:    &InventoryRefreshConf{Enabled: true, ResourceClasses: []InventoryRefreshClass{{Name: "Sensors"}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		Data.InventoryRefreshConf = tt.conf
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ValidateConfiguration()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TestValidateConfigurationForInventoryRefreshConf() = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if Data.InventoryRefreshConf.Enabled != tt.wantEnabled {
+				t.Errorf("TestValidateConfigurationForInventoryRefreshConf() Enabled = %v, want %v", Data.InventoryRefreshConf.Enabled, tt.wantEnabled)
+			}
+		})
+	}
+	Data.InventoryRefreshConf = nil
+	os.Remove(sampleFileForTest)
+}
+
+func TestValidateConfigurationForSecretsProviderConf(t *testing.T) {
+	sampleFileForTest := filepath.Join(cwdDir, sampleFileName)
+	createFile(t, sampleFileForTest, sampleFileContent)
+	tokenFile := filepath.Join(t.TempDir(), "vault-token")
+	createFile(t, tokenFile, "root\n")
+	tests := []struct {
+		name      string
+		conf      *SecretsProviderConf
+		wantErr   bool
+		wantType  string
+		wantToken string
+	}{
+		{
+			name:     "Secrets provider conf not provided",
+			conf:     nil,
+			wantType: SecretsProviderDatabase,
+		},
+		{
+			name:     "File provider",
+			conf:     &SecretsProviderConf{Type: SecretsProviderFile, Directory: "/etc/odimra_secrets"},
+			wantType: SecretsProviderFile,
+		},
+		{
+			name:    "File provider without directory",
+			conf:    &SecretsProviderConf{Type: SecretsProviderFile},
+			wantErr: true,
+		},
+		{
+			name:      "Vault provider",
+			conf:      &SecretsProviderConf{Type: SecretsProviderVault, VaultAddress: "https://vault:8200", VaultTokenFilePath: tokenFile},
+			wantType:  SecretsProviderVault,
+			wantToken: "root",
+		},
+		{
+			name:    "Vault provider with invalid cache TTL",
+			conf:    &SecretsProviderConf{Type: SecretsProviderVault, VaultAddress: "https://vault:8200", VaultTokenFilePath: tokenFile, VaultCacheTTLInSeconds: -1},
+			wantErr: true,
+		},
+		{
+			name:    "Vault provider without token",
+			conf:    &SecretsProviderConf{Type: SecretsProviderVault, VaultAddress: "https://vault:8200"},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid provider type",
+			conf:    &SecretsProviderConf{Type: "Keyring"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		Data.SecretsProviderConf = tt.conf
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ValidateConfiguration()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TestValidateConfigurationForSecretsProviderConf() = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if Data.SecretsProviderConf.Type != tt.wantType || Data.SecretsProviderConf.VaultToken != tt.wantToken {
+				t.Errorf("TestValidateConfigurationForSecretsProviderConf() = %+v, want type %v and token %v",
+					Data.SecretsProviderConf, tt.wantType, tt.wantToken)
+			}
+		})
+	}
+	Data.SecretsProviderConf = nil
+	os.Remove(sampleFileForTest)
+}
+
+func TestValidateConfigurationForTelemetryHistoryConf(t *testing.T) {
+	sampleFileForTest := filepath.Join(cwdDir, sampleFileName)
+	createFile(t, sampleFileForTest, sampleFileContent)
+	tests := []struct {
+		name    string
+		conf    *TelemetryHistoryConf
+		wantErr bool
+		want    TelemetryHistoryConf
+	}{
+		{
+			name: "Telemetry history conf not provided",
+			conf: nil,
+			want: TelemetryHistoryConf{
+				CollectionIntervalInMins: DefaultTelemetryCollectionInterval,
+				RetentionInDays:          DefaultTelemetryRetention,
+				RawRetentionInHours:      DefaultTelemetryRawRetention,
+				DownsampleIntervalInMins: DefaultTelemetryDownsampleInterval,
+			},
+		},
+		{
+			name: "Enabled with configured values",
+			conf: &TelemetryHistoryConf{Enabled: true, CollectionIntervalInMins: 1, RetentionInDays: 7, RawRetentionInHours: 6, DownsampleIntervalInMins: 5},
+			want: TelemetryHistoryConf{Enabled: true, CollectionIntervalInMins: 1, RetentionInDays: 7, RawRetentionInHours: 6, DownsampleIntervalInMins: 5},
+		},
+		{
+			name:    "Negative retention",
+			conf:    &TelemetryHistoryConf{Enabled: true, RetentionInDays: -1},
+			wantErr: true,
+		},
+		{
+			name:    "Raw retention exceeding the retention",
+			conf:    &TelemetryHistoryConf{Enabled: true, RetentionInDays: 1, RawRetentionInHours: 48},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		Data.TelemetryHistoryConf = tt.conf
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ValidateConfiguration()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TestValidateConfigurationForTelemetryHistoryConf() = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if *Data.TelemetryHistoryConf != tt.want {
+				t.Errorf("TestValidateConfigurationForTelemetryHistoryConf() = %+v, want %+v", *Data.TelemetryHistoryConf, tt.want)
+			}
+		})
+	}
+	Data.TelemetryHistoryConf = nil
+	os.Remove(sampleFileForTest)
+}
+
+func TestValidateConfigurationForTelemetryExportConf(t *testing.T) {
+	sampleFileForTest := filepath.Join(cwdDir, sampleFileName)
+	createFile(t, sampleFileForTest, sampleFileContent)
+	tests := []struct {
+		name    string
+		conf    *TelemetryExportConf
+		wantErr bool
+		want    TelemetryExportConf
+	}{
+		{
+			name: "Telemetry export conf not provided",
+			conf: nil,
+			want: TelemetryExportConf{
+				RemoteWriteIntervalInSecs: DefaultRemoteWriteInterval,
+				RemoteWriteTimeoutInSecs:  DefaultRemoteWriteTimeout,
+			},
+		},
+		{
+			name: "Remote write with configured values",
+			conf: &TelemetryExportConf{OpenMetricsEnabled: true, RemoteWriteURL: "https://prometheus:9090/api/v1/write", RemoteWriteIntervalInSecs: 60, RemoteWriteTimeoutInSecs: 5},
+			want: TelemetryExportConf{OpenMetricsEnabled: true, RemoteWriteURL: "https://prometheus:9090/api/v1/write", RemoteWriteIntervalInSecs: 60, RemoteWriteTimeoutInSecs: 5},
+		},
+		{
+			name:    "Negative remote write interval",
+			conf:    &TelemetryExportConf{RemoteWriteIntervalInSecs: -1},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid remote write URL",
+			conf:    &TelemetryExportConf{RemoteWriteURL: "prometheus:9090/api/v1/write"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		Data.TelemetryExportConf = tt.conf
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ValidateConfiguration()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TestValidateConfigurationForTelemetryExportConf() = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if *Data.TelemetryExportConf != tt.want {
+				t.Errorf("TestValidateConfigurationForTelemetryExportConf() = %+v, want %+v", *Data.TelemetryExportConf, tt.want)
+			}
+		})
+	}
+	Data.TelemetryExportConf = nil
+	os.Remove(sampleFileForTest)
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	networks, err := parseTrustedProxies([]string{"10.0.0.1", "192.168.0.0/16", "fd00::1"})
+	if err != nil {
+		t.Fatalf("error: parseTrustedProxies failed: %v", err)
+	}
+	want := []string{"10.0.0.1/32", "192.168.0.0/16", "fd00::1/128"}
+	if len(networks) != len(want) {
+		t.Fatalf("error: got %d networks, want %d", len(networks), len(want))
+	}
+	for i, network := range networks {
+		if network.String() != want[i] {
+			t.Errorf("error: got network %s, want %s", network, want[i])
+		}
+	}
+	for _, proxy := range []string{"proxy.local", "10.0.0.0/33"} {
+		if _, err := parseTrustedProxies([]string{proxy}); err == nil {
+			t.Errorf("error: parseTrustedProxies accepted %s", proxy)
+		}
+	}
+}
@@ -98,9 +98,43 @@ const (
 	DefaultEventForwardingWorkerPoolCount = 1000
 	//DefaultEventSaveWorkerPoolCount - default EventSaveWorkerPoolCount value
 	DefaultEventSaveWorkerPoolCount = 10
+	// DefaultMetricsListenAddress - default address on which the service metrics are exposed
+	DefaultMetricsListenAddress = ":9110"
+	// DefaultTracingSamplingRatio - default SamplingRatio value
+	DefaultTracingSamplingRatio = 1.0
+	// DefaultBMCDiscoveryScanIntervalInMins - default ScanIntervalInMins value
+	DefaultBMCDiscoveryScanIntervalInMins = 60
+	// DefaultBMCDiscoveryProbeTimeoutInSecs - default ProbeTimeoutInSecs value
+	DefaultBMCDiscoveryProbeTimeoutInSecs = 3
+	// DefaultBMCDiscoveryMaxConcurrentProbes - default MaxConcurrentProbes value
+	DefaultBMCDiscoveryMaxConcurrentProbes = 64
+	// DefaultBMCDiscoveryMaxHostsPerScan - default MaxHostsPerScan value
+	DefaultBMCDiscoveryMaxHostsPerScan = 4096
+	// DefaultTelemetryCollectionInterval - default CollectionIntervalInMins value of TelemetryHistoryConf
+	DefaultTelemetryCollectionInterval = 5
+	// DefaultTelemetryRetention - default RetentionInDays value of TelemetryHistoryConf
+	DefaultTelemetryRetention = 30
+	// DefaultTelemetryRawRetention - default RawRetentionInHours value of TelemetryHistoryConf
+	DefaultTelemetryRawRetention = 24
+	// DefaultTelemetryDownsampleInterval - default DownsampleIntervalInMins value of TelemetryHistoryConf
+	DefaultTelemetryDownsampleInterval = 15
+	// DefaultRemoteWriteInterval - default RemoteWriteIntervalInSecs value of TelemetryExportConf
+	DefaultRemoteWriteInterval = 30
+	// DefaultRemoteWriteTimeout - default RemoteWriteTimeoutInSecs value of TelemetryExportConf
+	DefaultRemoteWriteTimeout = 10
+	// DefaultVaultCacheTTLInSeconds - default VaultCacheTTLInSeconds value of SecretsProviderConf
+	DefaultVaultCacheTTLInSeconds = 300
+	// SecretsProviderDatabase - secrets provider reading the encrypted secrets from the database
+	SecretsProviderDatabase = "Database"
+	// SecretsProviderFile - secrets provider reading the secrets from the files of a directory
+	SecretsProviderFile = "File"
+	// SecretsProviderVault - secrets provider reading the secrets from HashiCorp Vault
+	SecretsProviderVault = "Vault"
 )
 
 var (
+	// DefaultBMCDiscoveryPorts - holds the default list of ports probed for the Redfish service root of the BMCs
+	DefaultBMCDiscoveryPorts = []int{443}
 	// DefaultSkipListUnderSystem - holds the default list of resources which needs to be ignored for storing in DB under system resource
 	DefaultSkipListUnderSystem = []string{"Chassis", "LogServices", "Managers"}
 	// DefaultSkipListUnderManager - holds the default list of resources which needs to be ignored for storing in DB under manager resource
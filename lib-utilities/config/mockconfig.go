@@ -344,6 +344,46 @@ func SetUpMockConfig(t *testing.T) error {
 		DeliveryRetryAttempts:        1,
 		DeliveryRetryIntervalSeconds: 1,
 	}
+	Data.MetricsConf = &MetricsConf{
+		Enabled:       false,
+		ListenAddress: DefaultMetricsListenAddress,
+	}
+	Data.TracingConf = &TracingConf{
+		Enabled:       false,
+		SamplingRatio: DefaultTracingSamplingRatio,
+	}
+	Data.BMCDiscoveryConf = &BMCDiscoveryConf{
+		Enabled:             false,
+		Ports:               DefaultBMCDiscoveryPorts,
+		ScanIntervalInMins:  DefaultBMCDiscoveryScanIntervalInMins,
+		ProbeTimeoutInSecs:  DefaultBMCDiscoveryProbeTimeoutInSecs,
+		MaxConcurrentProbes: DefaultBMCDiscoveryMaxConcurrentProbes,
+		MaxHostsPerScan:     DefaultBMCDiscoveryMaxHostsPerScan,
+		DefaultUserName:     "admin",
+		DefaultPassword:     []byte("password"),
+	}
+	Data.InventoryRefreshConf = &InventoryRefreshConf{
+		Enabled: false,
+		ResourceClasses: []InventoryRefreshClass{
+			{Name: "Sensors", IntervalInMins: 5},
+			{Name: "FirmwareInventory", IntervalInMins: 1440},
+		},
+	}
+	Data.SecretsProviderConf = &SecretsProviderConf{
+		Type: SecretsProviderDatabase,
+	}
+	Data.TelemetryHistoryConf = &TelemetryHistoryConf{
+		Enabled:                  false,
+		CollectionIntervalInMins: 5,
+		RetentionInDays:          30,
+		RawRetentionInHours:      24,
+		DownsampleIntervalInMins: 15,
+	}
+	Data.TelemetryExportConf = &TelemetryExportConf{
+		OpenMetricsEnabled:        false,
+		RemoteWriteIntervalInSecs: 30,
+		RemoteWriteTimeoutInSecs:  10,
+	}
 	Data.TaskQueueConf = &TaskQueueConf{
 		QueueSize:        1000,
 		DBCommitInterval: 1000,
@@ -0,0 +1,45 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package health
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+)
+
+// RedisCheck verifies that both the in-memory and the on-disk databases
+// accept commands. It fails during a Sentinel failover until the new master
+// is reachable.
+func RedisCheck(ctx context.Context) error {
+	for _, db := range []common.DbType{common.InMemory, common.OnDisk} {
+		conn, err := common.GetDBConnection(db)
+		if err != nil {
+			return fmt.Errorf("unable to get %s DB connection: %s", dbName(db), err.Error())
+		}
+		if err := conn.Ping(); err != nil {
+			return fmt.Errorf("%s DB is not reachable: %s", dbName(db), err.Error())
+		}
+	}
+	return nil
+}
+
+func dbName(db common.DbType) string {
+	if db == common.InMemory {
+		return "in-memory"
+	}
+	return "on-disk"
+}
@@ -0,0 +1,198 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package health provides the liveness and readiness endpoints of the ODIM
+// services. The services register the checks of the dependencies they need
+// for serving requests, and the readiness endpoint reports the result of all
+// of them.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+)
+
+const (
+	// LivePath is the URI of the liveness endpoint
+	LivePath = "/health/live"
+	// ReadyPath is the URI of the readiness endpoint
+	ReadyPath = "/health/ready"
+
+	// StatusOK is reported when all the checks succeeded
+	StatusOK = "OK"
+	// StatusWarning is reported when only optional checks failed
+	StatusWarning = "Warning"
+	// StatusCritical is reported when a required check failed
+	StatusCritical = "Critical"
+
+	// checkTimeout is the time allowed for a single check to complete
+	checkTimeout = 5 * time.Second
+)
+
+// Check verifies a dependency of the service and returns an error when it
+// can not be used. Checks must return when the context is done.
+type Check func(ctx context.Context) error
+
+type registeredCheck struct {
+	name     string
+	check    Check
+	required bool
+}
+
+// Result is the outcome of a single check
+type Result struct {
+	Name     string `json:"Name"`
+	Status   string `json:"Status"`
+	Required bool   `json:"Required"`
+	Error    string `json:"Error,omitempty"`
+	Duration string `json:"Duration"`
+}
+
+// Report is the outcome of all the registered checks
+type Report struct {
+	Status string   `json:"Status"`
+	Checks []Result `json:"Checks"`
+}
+
+var (
+	checksLock sync.RWMutex
+	checks     = map[string]registeredCheck{}
+)
+
+// Register adds a check which must succeed for the service to be ready.
+// A check registered again with the same name replaces the previous one.
+func Register(name string, check Check) {
+	register(name, check, true)
+}
+
+// RegisterOptional adds a check whose failure degrades the service
+// without making it unready
+func RegisterOptional(name string, check Check) {
+	register(name, check, false)
+}
+
+func register(name string, check Check, required bool) {
+	checksLock.Lock()
+	defer checksLock.Unlock()
+	checks[name] = registeredCheck{name: name, check: check, required: required}
+}
+
+// Evaluate runs all the registered checks concurrently and returns the report
+func Evaluate(ctx context.Context) Report {
+	checksLock.RLock()
+	registered := make([]registeredCheck, 0, len(checks))
+	for _, c := range checks {
+		registered = append(registered, c)
+	}
+	checksLock.RUnlock()
+	sort.Slice(registered, func(i, j int) bool {
+		return registered[i].name < registered[j].name
+	})
+
+	report := Report{
+		Status: StatusOK,
+		Checks: make([]Result, len(registered)),
+	}
+	var wg sync.WaitGroup
+	for i, c := range registered {
+		wg.Add(1)
+		go func(i int, c registeredCheck) {
+			defer wg.Done()
+			report.Checks[i] = run(ctx, c)
+		}(i, c)
+	}
+	wg.Wait()
+
+	for _, result := range report.Checks {
+		if result.Status == StatusOK {
+			continue
+		}
+		if result.Required {
+			report.Status = StatusCritical
+			break
+		}
+		report.Status = StatusWarning
+	}
+	return report
+}
+
+// run executes a check within checkTimeout. The check is abandoned when it
+// does not honour the context, so that a hung dependency can not block the
+// readiness endpoint.
+func run(ctx context.Context, c registeredCheck) Result {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+	start := time.Now()
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- c.check(ctx)
+	}()
+	var err error
+	select {
+	case err = <-errChan:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	result := Result{
+		Name:     c.name,
+		Status:   StatusOK,
+		Required: c.required,
+		Duration: time.Since(start).String(),
+	}
+	if err != nil {
+		result.Error = err.Error()
+		result.Status = StatusWarning
+		if c.required {
+			result.Status = StatusCritical
+		}
+	}
+	return result
+}
+
+// LiveHandler returns the handler of the liveness endpoint, which succeeds
+// as long as the process is able to serve http requests
+func LiveHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"Status": StatusOK})
+	})
+}
+
+// ReadyHandler returns the handler of the readiness endpoint. It responds
+// with 503 Service Unavailable when any of the required checks failed.
+func ReadyHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := Evaluate(r.Context())
+		statusCode := http.StatusOK
+		if report.Status == StatusCritical {
+			statusCode = http.StatusServiceUnavailable
+			l.Log.Warnf("service is not ready: %+v", report.Checks)
+		}
+		writeJSON(w, statusCode, report)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		l.Log.Error("failed to write health report: " + err.Error())
+	}
+}
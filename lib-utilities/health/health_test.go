@@ -0,0 +1,148 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func resetChecks() {
+	checksLock.Lock()
+	checks = map[string]registeredCheck{}
+	checksLock.Unlock()
+}
+
+func passing(ctx context.Context) error {
+	return nil
+}
+
+func failing(ctx context.Context) error {
+	return fmt.Errorf("unreachable")
+}
+
+func hanging(ctx context.Context) error {
+	select {}
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name     string
+		required map[string]Check
+		optional map[string]Check
+		want     string
+	}{
+		{
+			name:     "all checks pass",
+			required: map[string]Check{"Redis": passing},
+			optional: map[string]Check{"svc.systems": passing},
+			want:     StatusOK,
+		},
+		{
+			name:     "optional check fails",
+			required: map[string]Check{"Redis": passing},
+			optional: map[string]Check{"svc.systems": failing},
+			want:     StatusWarning,
+		},
+		{
+			name:     "required check fails",
+			required: map[string]Check{"Redis": failing},
+			optional: map[string]Check{"svc.systems": failing},
+			want:     StatusCritical,
+		},
+		{
+			name:     "required check hangs",
+			required: map[string]Check{"Redis": hanging},
+			want:     StatusCritical,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetChecks()
+			defer resetChecks()
+			for name, check := range tt.required {
+				Register(name, check)
+			}
+			for name, check := range tt.optional {
+				RegisterOptional(name, check)
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			report := Evaluate(ctx)
+			if report.Status != tt.want {
+				t.Errorf("Evaluate() status = %v, want %v: %+v", report.Status, tt.want, report.Checks)
+			}
+			if len(report.Checks) != len(tt.required)+len(tt.optional) {
+				t.Errorf("Evaluate() reported %d checks", len(report.Checks))
+			}
+		})
+	}
+}
+
+func TestReadyHandler(t *testing.T) {
+	resetChecks()
+	defer resetChecks()
+	RegisterOptional("svc.systems", failing)
+
+	rec := httptest.NewRecorder()
+	ReadyHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ReadyPath, nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("ReadyHandler() code = %v, want %v", rec.Code, http.StatusOK)
+	}
+
+	Register("Redis", failing)
+	rec = httptest.NewRecorder()
+	ReadyHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ReadyPath, nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("ReadyHandler() code = %v, want %v", rec.Code, http.StatusServiceUnavailable)
+	}
+	var report Report
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatalf("ReadyHandler() returned invalid report: %v", err)
+	}
+	if report.Checks[0].Name != "Redis" || report.Checks[0].Error != "unreachable" {
+		t.Errorf("ReadyHandler() report = %+v", report)
+	}
+
+	rec = httptest.NewRecorder()
+	LiveHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, LivePath, nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("LiveHandler() code = %v, want %v", rec.Code, http.StatusOK)
+	}
+}
+
+func TestRollUp(t *testing.T) {
+	tests := []struct {
+		name    string
+		reports []ServiceReport
+		want    string
+	}{
+		{name: "no reports", want: StatusOK},
+		{name: "warning", reports: []ServiceReport{{Status: StatusOK}, {Status: StatusWarning}}, want: StatusWarning},
+		{name: "critical", reports: []ServiceReport{{Status: StatusCritical}, {Status: StatusWarning}}, want: StatusCritical},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RollUp(tt.reports); got != tt.want {
+				t.Errorf("RollUp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+)
+
+const (
+	// reportTable is the in-memory DB table holding the latest report of every service instance
+	reportTable = "ServiceHealth"
+	// reportInterval is the interval at which the service instances store their report
+	reportInterval = 30 * time.Second
+	// reportExpiry is the time after which the report of an instance which
+	// stopped reporting is removed, in seconds
+	reportExpiry = int(3 * reportInterval / time.Second)
+)
+
+// ServiceReport is the readiness report stored by a service instance
+type ServiceReport struct {
+	Service     string   `json:"Service"`
+	Instance    string   `json:"Instance"`
+	Status      string   `json:"Status"`
+	Checks      []Result `json:"Checks"`
+	LastUpdated string   `json:"LastUpdated"`
+}
+
+// StartReporting stores the readiness report of the service instance in the
+// in-memory DB every reportInterval, so that the health of all the services
+// can be rolled up. The report expires when the instance stops reporting.
+func StartReporting(serviceName, instance string) {
+	go func() {
+		for {
+			report := Evaluate(context.Background())
+			if err := storeReport(ServiceReport{
+				Service:     serviceName,
+				Instance:    instance,
+				Status:      report.Status,
+				Checks:      report.Checks,
+				LastUpdated: time.Now().UTC().Format(time.RFC3339),
+			}); err != nil {
+				l.Log.Warn("unable to store the health report of " + instance + ": " + err.Error())
+			}
+			time.Sleep(reportInterval)
+		}
+	}()
+}
+
+func storeReport(report ServiceReport) error {
+	conn, err := common.GetDBConnection(common.InMemory)
+	if err != nil {
+		return err
+	}
+	if err := conn.UpsertWithExpiry(reportTable, report.Instance, report, reportExpiry); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetServiceReports returns the latest reports of all the service instances,
+// sorted by service and instance name
+func GetServiceReports() ([]ServiceReport, error) {
+	conn, err := common.GetDBConnection(common.InMemory)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get DB connection: %s", err.Error())
+	}
+	instances, err := conn.GetAllMatchingDetails(reportTable, "")
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the service health reports: %s", err.Error())
+	}
+	reports := make([]ServiceReport, 0, len(instances))
+	for _, instance := range instances {
+		data, err := conn.Read(reportTable, instance)
+		if err != nil {
+			// the report expired after it was listed
+			continue
+		}
+		var report ServiceReport
+		if err := json.Unmarshal([]byte(data), &report); err != nil {
+			l.Log.Warn("invalid health report stored for " + instance + ": " + err.Error())
+			continue
+		}
+		reports = append(reports, report)
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		if reports[i].Service != reports[j].Service {
+			return reports[i].Service < reports[j].Service
+		}
+		return reports[i].Instance < reports[j].Instance
+	})
+	return reports, nil
+}
+
+// RollUp returns the worst status of the reports passed, which is StatusOK
+// when there are no reports
+func RollUp(reports []ServiceReport) string {
+	status := StatusOK
+	for _, report := range reports {
+		switch report.Status {
+		case StatusCritical:
+			return StatusCritical
+		case StatusWarning:
+			status = StatusWarning
+		}
+	}
+	return status
+}
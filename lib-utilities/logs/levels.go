@@ -0,0 +1,182 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package logs
+
+import (
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// modulePrefix is trimmed from the import path of the packages, so that the
+// overrides can be given as svc-events/events instead of the full path
+const modulePrefix = "github.com/ODIM-Project/ODIM/"
+
+// LevelOverride temporarily changes the log level of the packages whose
+// import path, relative to the ODIM repository, is Package or starts with
+// Package followed by a "/". An override without Package changes the level
+// of all the packages which don't have an override of their own.
+type LevelOverride struct {
+	Package   string       `json:"Package,omitempty"`
+	Level     logrus.Level `json:"LogLevel"`
+	ExpiresAt time.Time    `json:"ExpiresAt"`
+}
+
+// levelFilter is installed as formatter of the logger when package
+// overrides are active. The logger level is then lowered to the most
+// verbose of the levels, and the entries are dropped here according to
+// the level applicable to the package which logged them.
+type levelFilter struct {
+	next logrus.Formatter
+}
+
+var levels = struct {
+	sync.RWMutex
+	base      logrus.Level
+	overrides []LevelOverride
+}{}
+
+// logsPackage is the import path of this package, used to skip its frames
+// while looking for the caller
+var logsPackage = reflect.TypeOf(levelFilter{}).PkgPath()
+
+// pcPackages caches the package found by pcPackage for a program counter
+var pcPackages sync.Map
+
+// SetLevels sets the log level of the service, applying the overrides which
+// did not expire yet over the base level. It is safe to call SetLevels
+// again with the same values, the logger is updated only when needed.
+func SetLevels(base logrus.Level, overrides []LevelOverride) {
+	now := time.Now()
+	var active []LevelOverride
+	for _, override := range overrides {
+		if !override.ExpiresAt.IsZero() && !now.Before(override.ExpiresAt) {
+			continue
+		}
+		if override.Package == "" {
+			base = override.Level
+			continue
+		}
+		override.Package = strings.Trim(override.Package, "/")
+		active = append(active, override)
+	}
+	// the most specific package is matched first
+	sort.Slice(active, func(i, j int) bool {
+		return len(active[i].Package) > len(active[j].Package)
+	})
+
+	loggerLevel := base
+	for _, override := range active {
+		if override.Level > loggerLevel {
+			loggerLevel = override.Level
+		}
+	}
+
+	levels.Lock()
+	levels.base = base
+	levels.overrides = active
+	levels.Unlock()
+
+	logger := Log.Logger
+	if len(active) > 0 {
+		if _, ok := logger.Formatter.(*levelFilter); !ok {
+			logger.SetFormatter(&levelFilter{next: logger.Formatter})
+		}
+	} else if filter, ok := logger.Formatter.(*levelFilter); ok {
+		logger.SetFormatter(filter.next)
+	}
+	if logger.GetLevel() != loggerLevel {
+		logger.SetLevel(loggerLevel)
+	}
+}
+
+// GetLevelOverrides returns the package overrides currently applied
+func GetLevelOverrides() []LevelOverride {
+	levels.RLock()
+	defer levels.RUnlock()
+	return append([]LevelOverride{}, levels.overrides...)
+}
+
+// Format drops the entries above the level of the package which logged
+// them, and renders the others with the formatter of the service
+func (f *levelFilter) Format(entry *logrus.Entry) ([]byte, error) {
+	if entry.Level > packageLevel(callerPackage()) {
+		return nil, nil
+	}
+	return f.next.Format(entry)
+}
+
+func packageLevel(pkg string) logrus.Level {
+	levels.RLock()
+	defer levels.RUnlock()
+	for _, override := range levels.overrides {
+		if pkg == override.Package || strings.HasPrefix(pkg, override.Package+"/") {
+			return override.Level
+		}
+	}
+	return levels.base
+}
+
+// callerPackage returns the package of the first caller outside of
+// logrus and this package, relative to the ODIM repository
+func callerPackage() string {
+	var pcs [32]uintptr
+	for _, pc := range pcs[:runtime.Callers(3, pcs[:])] {
+		if pkg := pcPackage(pc); pkg != "" {
+			return pkg
+		}
+	}
+	return ""
+}
+
+// pcPackage returns the package of the innermost frame at the program
+// counter which is outside of logrus and this package, empty when there is
+// none. The frames of a program counter are resolved only once, since the
+// same few call sites log most of the entries.
+func pcPackage(pc uintptr) string {
+	if pkg, ok := pcPackages.Load(pc); ok {
+		return pkg.(string)
+	}
+	var pkg string
+	frames := runtime.CallersFrames([]uintptr{pc})
+	for {
+		frame, more := frames.Next()
+		framePkg := functionPackage(frame.Function)
+		if framePkg != logsPackage && !strings.HasPrefix(framePkg, "github.com/sirupsen/logrus") {
+			pkg = strings.TrimPrefix(framePkg, modulePrefix)
+			break
+		}
+		if !more {
+			break
+		}
+	}
+	pcPackages.Store(pc, pkg)
+	return pkg
+}
+
+// functionPackage extracts the import path from a function name like
+// github.com/ODIM-Project/ODIM/svc-events/events.(*ExternalInterfaces).Method
+func functionPackage(function string) string {
+	slash := strings.LastIndex(function, "/")
+	if dot := strings.Index(function[slash+1:], "."); dot >= 0 {
+		return function[:slash+1+dot]
+	}
+	return function
+}
@@ -0,0 +1,96 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package logs_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/sirupsen/logrus"
+)
+
+func TestSetLevels(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logs.Log.Logger
+	logger.SetOutput(&buf)
+	logger.SetFormatter(&logrus.TextFormatter{DisableTimestamp: true})
+	defer logs.SetLevels(logrus.WarnLevel, nil)
+
+	future := time.Now().Add(time.Minute)
+	past := time.Now().Add(-time.Minute)
+	tests := []struct {
+		name      string
+		overrides []logs.LevelOverride
+		debug     bool
+		info      bool
+	}{
+		{
+			name: "no overrides",
+		},
+		{
+			name:      "package override",
+			overrides: []logs.LevelOverride{{Package: "lib-utilities/logs_test", Level: logrus.DebugLevel, ExpiresAt: future}},
+			debug:     true,
+			info:      true,
+		},
+		{
+			name:      "parent package override",
+			overrides: []logs.LevelOverride{{Package: "lib-utilities", Level: logrus.InfoLevel, ExpiresAt: future}},
+			info:      true,
+		},
+		{
+			name: "most specific override wins",
+			overrides: []logs.LevelOverride{
+				{Package: "lib-utilities", Level: logrus.DebugLevel, ExpiresAt: future},
+				{Package: "lib-utilities/logs_test", Level: logrus.ErrorLevel, ExpiresAt: future},
+			},
+		},
+		{
+			name:      "other package override",
+			overrides: []logs.LevelOverride{{Package: "svc-events/events", Level: logrus.DebugLevel, ExpiresAt: future}},
+		},
+		{
+			name:      "package prefix is not a parent",
+			overrides: []logs.LevelOverride{{Package: "lib-util", Level: logrus.DebugLevel, ExpiresAt: future}},
+		},
+		{
+			name:      "global override",
+			overrides: []logs.LevelOverride{{Level: logrus.InfoLevel, ExpiresAt: future}},
+			info:      true,
+		},
+		{
+			name:      "expired override",
+			overrides: []logs.LevelOverride{{Package: "lib-utilities/logs_test", Level: logrus.DebugLevel, ExpiresAt: past}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			logs.SetLevels(logrus.WarnLevel, tt.overrides)
+			logs.Log.Debug("debug message")
+			logs.Log.Info("info message")
+			output := buf.String()
+			if got := strings.Contains(output, "debug message"); got != tt.debug {
+				t.Errorf("debug message logged = %v, want %v", got, tt.debug)
+			}
+			if got := strings.Contains(output, "info message"); got != tt.info {
+				t.Errorf("info message logged = %v, want %v", got, tt.info)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	grpcServerHandlingSeconds = NewHistogramVec("odim_grpc_server_handling_seconds",
+		"Time taken by the service to handle a gRPC call", nil, "method", "code")
+	grpcClientHandlingSeconds = NewHistogramVec("odim_grpc_client_handling_seconds",
+		"Time taken by a gRPC call made to another ODIM service to complete", nil, "method", "code")
+)
+
+// UnaryServerInterceptor records the latency and the status code
+// of every unary gRPC call handled by the service
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	grpcServerHandlingSeconds.WithLabelValues(info.FullMethod, status.Code(err).String()).Observe(time.Since(start).Seconds())
+	return resp, err
+}
+
+// UnaryClientInterceptor records the latency and the status code
+// of every unary gRPC call made by the service
+func UnaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	start := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	grpcClientHandlingSeconds.WithLabelValues(method, status.Code(err).String()).Observe(time.Since(start).Seconds())
+	return err
+}
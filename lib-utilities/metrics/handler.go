@@ -0,0 +1,44 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Path is the URI on which the metrics are exposed
+const Path = "/metrics"
+
+var serviceInfo = NewGaugeVec("odim_service_info", "Name of the ODIM service exposing the metrics, value is always 1", "service")
+
+// Handler returns the http handler exposing the metrics of the Registry
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// Expose registers the metrics handler on the mux passed, when the metrics
+// are enabled in MetricsConf
+func Expose(mux *http.ServeMux, serviceName string) {
+	serviceInfo.WithLabelValues(serviceName).Set(1)
+	if config.Data.MetricsConf == nil || !config.Data.MetricsConf.Enabled {
+		l.Log.Info("metrics are disabled for " + serviceName)
+		return
+	}
+	mux.Handle(Path, Handler())
+}
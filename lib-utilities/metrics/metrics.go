@@ -0,0 +1,99 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package metrics provides the instrumentation shared by all the ODIM
+// services. The metrics are built on the Prometheus client library and
+// registered in Registry, which is exposed by Handler.
+package metrics
+
+import (
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// DefaultBuckets are the histogram buckets, in seconds, used for latencies
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// Registry holds the metrics exposed by a service, along with the Go runtime
+// and process metrics
+var Registry = prometheus.NewRegistry()
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// register adds the collector to the Registry and returns the collector
+// registered for its metrics. When the same metric is already registered,
+// the existing collector is returned, so that packages can declare the same
+// metric more than once. A metric which cannot be registered is only logged,
+// the collector is then returned but its values are not exposed.
+func register(c prometheus.Collector) prometheus.Collector {
+	err := Registry.Register(c)
+	if err == nil {
+		return c
+	}
+	if registered, ok := err.(prometheus.AlreadyRegisteredError); ok {
+		return registered.ExistingCollector
+	}
+	l.Log.Error("failed to register metric: " + err.Error())
+	return c
+}
+
+// NewCounterVec creates and registers a counter family partitioned by labels
+func NewCounterVec(name, help string, labelNames ...string) *prometheus.CounterVec {
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{Name: name, Help: help}, labelNames)
+	if registered, ok := register(counter).(*prometheus.CounterVec); ok {
+		return registered
+	}
+	return counter
+}
+
+// NewGaugeVec creates and registers a gauge family partitioned by labels
+func NewGaugeVec(name, help string, labelNames ...string) *prometheus.GaugeVec {
+	gauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: name, Help: help}, labelNames)
+	if registered, ok := register(gauge).(*prometheus.GaugeVec); ok {
+		return registered
+	}
+	return gauge
+}
+
+// NewHistogramVec creates and registers a histogram family partitioned by labels.
+// DefaultBuckets are used when no buckets are passed.
+func NewHistogramVec(name, help string, buckets []float64, labelNames ...string) *prometheus.HistogramVec {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	histogram := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: name, Help: help, Buckets: buckets}, labelNames)
+	if registered, ok := register(histogram).(*prometheus.HistogramVec); ok {
+		return registered
+	}
+	return histogram
+}
+
+// NewGaugeFunc registers a gauge whose value is read from fn when collected.
+// The gauges of the same name are told apart by their constant labels.
+func NewGaugeFunc(name, help string, labels prometheus.Labels, fn func() float64) {
+	register(prometheus.NewGaugeFunc(prometheus.GaugeOpts{Name: name, Help: help, ConstLabels: labels}, fn))
+}
+
+// NewCounterFunc registers a counter whose value is read from fn when collected,
+// it is meant for the counters maintained by other libraries.
+// The counters of the same name are told apart by their constant labels.
+func NewCounterFunc(name, help string, labels prometheus.Labels, fn func() float64) {
+	register(prometheus.NewCounterFunc(prometheus.CounterOpts{Name: name, Help: help, ConstLabels: labels}, fn))
+}
@@ -0,0 +1,116 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// findMetric returns the metric of the family with the given label values
+func findMetric(t *testing.T, name string, labels map[string]string) *dto.Metric {
+	families, err := Registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			matched := 0
+			for _, pair := range metric.GetLabel() {
+				if labels[pair.GetName()] == pair.GetValue() {
+					matched++
+				}
+			}
+			if matched == len(labels) {
+				return metric
+			}
+		}
+	}
+	return nil
+}
+
+func TestRegisterExisting(t *testing.T) {
+	first := NewCounterVec("test_requests_total", "Total requests", "code")
+	second := NewCounterVec("test_requests_total", "Total requests", "code")
+	if first != second {
+		t.Errorf("NewCounterVec() did not return the registered family")
+	}
+	first.WithLabelValues("200").Inc()
+	second.WithLabelValues("200").Add(2)
+	if got := testutil.ToFloat64(first.WithLabelValues("200")); got != 3 {
+		t.Errorf("test_requests_total = %v, want 3", got)
+	}
+}
+
+func TestRegisterConflict(t *testing.T) {
+	NewCounterVec("test_conflict_total", "Total", "code")
+	gauge := NewGaugeVec("test_conflict_total", "Total", "code")
+	gauge.WithLabelValues("200").Set(5)
+	if got := testutil.ToFloat64(gauge.WithLabelValues("200")); got != 5 {
+		t.Errorf("gauge registered with a conflicting name = %v, want 5", got)
+	}
+}
+
+func TestNewGaugeFunc(t *testing.T) {
+	NewGaugeFunc("test_pool_connections", "Connections in the pool", prometheus.Labels{"pool": "read"}, func() float64 { return 7 })
+	NewGaugeFunc("test_pool_connections", "Connections in the pool", prometheus.Labels{"pool": "write"}, func() float64 { return 9 })
+	for pool, want := range map[string]float64{"read": 7, "write": 9} {
+		metric := findMetric(t, "test_pool_connections", map[string]string{"pool": pool})
+		if metric == nil || metric.GetGauge().GetValue() != want {
+			t.Errorf("test_pool_connections{pool=%q} = %v, want %v", pool, metric, want)
+		}
+	}
+}
+
+func TestHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, Path, nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("Handler() status = %v", rec.Code)
+	}
+	if got := rec.Header().Get("Content-Type"); !strings.HasPrefix(got, "text/plain") {
+		t.Errorf("Handler() Content-Type = %v", got)
+	}
+	if !strings.Contains(rec.Body.String(), "go_goroutines ") {
+		t.Errorf("Handler() runtime metrics are missing:\n%s", rec.Body.String())
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/Test/Method"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.Unavailable, "unavailable")
+	}
+	if _, err := UnaryServerInterceptor(context.Background(), nil, info, handler); status.Code(err) != codes.Unavailable {
+		t.Errorf("UnaryServerInterceptor() error = %v", err)
+	}
+	metric := findMetric(t, "odim_grpc_server_handling_seconds", map[string]string{"method": "/Test/Method", "code": "Unavailable"})
+	if metric == nil || metric.GetHistogram().GetSampleCount() != 1 {
+		t.Errorf("UnaryServerInterceptor() call was not recorded: %v", metric)
+	}
+}
@@ -27,6 +27,7 @@ const (
 	ErrorMessageOdataType               = "#Message.v1_1_2.Message"
 	propertyMissingArgCount             = 1
 	propertyValueNotInListArgCount      = 2
+	propertyValueOutOfRangeArgCount     = 2
 	propertyValueTypeErrorArgCount      = 2
 	resourceNotFoundArgCount            = 2
 	propertyValueFormatErrorArgCount    = 2
@@ -159,6 +160,17 @@ func (a *Args) CreateGenericErrorResponse() CommonError {
 					MessageArgs: errArg.MessageArgs,
 					Resolution:  "Choose a value from the enumeration list that the implementation can support and resubmit the request if the operation failed.",
 				})
+		case PropertyValueOutOfRange:
+			validateMessageArgs(errArg.MessageArgs, []string{"string", "string"}, propertyValueOutOfRangeArgCount)
+			e.Error.MessageExtendedInfo = append(e.Error.MessageExtendedInfo,
+				Msg{
+					OdataType:   ErrorMessageOdataType,
+					MessageID:   errArg.StatusMessage,
+					Message:     fmt.Sprintf("The value %v for the property %v is not in the supported range of acceptable values. %v", errArg.MessageArgs[0], errArg.MessageArgs[1], errArg.ErrorMessage),
+					Severity:    "Warning",
+					MessageArgs: errArg.MessageArgs,
+					Resolution:  "Correct the value for the property in the request body and resubmit the request if the operation failed.",
+				})
 		case PropertyValueTypeError:
 			validateMessageArgs(errArg.MessageArgs, []string{"string", "string"}, propertyValueTypeErrorArgCount)
 			e.Error.MessageExtendedInfo = append(e.Error.MessageExtendedInfo,
@@ -198,6 +198,36 @@ func TestCreateErrorResponse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: PropertyValueOutOfRange,
+			args: Args{
+				Code:    PropertyValueOutOfRange,
+				Message: PropertyValueOutOfRange,
+				ErrorArgs: []ErrArgs{
+					ErrArgs{
+						StatusMessage: PropertyValueOutOfRange,
+						ErrorMessage:  errMsg,
+						MessageArgs:   []interface{}{"test1", "test2"},
+					},
+				},
+			},
+			want: CommonError{
+				Error: ErrorClass{
+					Code:    PropertyValueOutOfRange,
+					Message: PropertyValueOutOfRange,
+					MessageExtendedInfo: []Msg{
+						Msg{
+							OdataType:   ErrorMessageOdataType,
+							MessageID:   PropertyValueOutOfRange,
+							Message:     fmt.Sprintf("The value %v for the property %v is not in the supported range of acceptable values. %v", "test1", "test2", errMsg),
+							Severity:    "Warning",
+							MessageArgs: []interface{}{"test1", "test2"},
+							Resolution:  "Correct the value for the property in the request body and resubmit the request if the operation failed.",
+						},
+					},
+				},
+			},
+		},
 		{
 			name: MalformedJSON,
 			args: Args{
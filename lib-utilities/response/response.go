@@ -55,6 +55,10 @@ const (
 	PropertyValueFormatError = BaseVersion + "PropertyValueFormatError"
 	// PropertyValueTypeError defines the message that the property is value given is having a different format
 	PropertyValueTypeError = BaseVersion + "PropertyValueTypeError"
+	// PropertyValueOutOfRange defines the message that the value of the property is not in the supported range
+	PropertyValueOutOfRange = BaseVersion + "PropertyValueOutOfRange"
+	// PropertyNotWritable defines the message that the property is read only and cannot be assigned a value
+	PropertyNotWritable = BaseVersion + "PropertyNotWritable"
 	// ResourceAtURIUnauthorized defines the authorization failure with plugin or other resources
 	ResourceAtURIUnauthorized = BaseVersion + "ResourceAtUriUnauthorized"
 	// CouldNotEstablishConnection defines the connection failure with plugin or other resources
@@ -0,0 +1,66 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package secrets
+
+import (
+	"sync"
+	"time"
+)
+
+// CachingProvider keeps the secrets read from another provider for a time, so that a
+// remote backend is not contacted each time a credential is used. A secret changed in
+// the backend is used once its cached value expires. Failures are not cached.
+type CachingProvider struct {
+	provider Provider
+	ttl      time.Duration
+	now      func() time.Time
+
+	lock    sync.Mutex
+	secrets map[string]cachedSecret
+}
+
+type cachedSecret struct {
+	value   []byte
+	expires time.Time
+}
+
+// NewCachingProvider returns a provider keeping the secrets read from the provider for the ttl
+func NewCachingProvider(provider Provider, ttl time.Duration) *CachingProvider {
+	return &CachingProvider{
+		provider: provider,
+		ttl:      ttl,
+		now:      time.Now,
+		secrets:  make(map[string]cachedSecret),
+	}
+}
+
+// GetSecret returns the cached secret, the secret is read from the provider when it
+// is not cached or its cached value expired
+func (p *CachingProvider) GetSecret(path string) ([]byte, error) {
+	p.lock.Lock()
+	secret, ok := p.secrets[path]
+	p.lock.Unlock()
+	if ok && p.now().Before(secret.expires) {
+		return append([]byte(nil), secret.value...), nil
+	}
+	value, err := p.provider.GetSecret(path)
+	if err != nil {
+		return nil, err
+	}
+	p.lock.Lock()
+	p.secrets[path] = cachedSecret{value: append([]byte(nil), value...), expires: p.now().Add(p.ttl)}
+	p.lock.Unlock()
+	return value, nil
+}
@@ -0,0 +1,61 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package secrets
+
+import (
+	"testing"
+	"time"
+)
+
+type countingProvider struct {
+	mockProvider
+	calls int
+}
+
+func (p *countingProvider) GetSecret(path string) ([]byte, error) {
+	p.calls++
+	return p.mockProvider.GetSecret(path)
+}
+
+func TestCachingProvider_GetSecret(t *testing.T) {
+	backend := &countingProvider{mockProvider: mockProvider{"bmc/password": "password"}}
+	provider := NewCachingProvider(backend, time.Minute)
+	now := time.Now()
+	provider.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		secret, err := provider.GetSecret("bmc/password")
+		if err != nil || string(secret) != "password" {
+			t.Fatalf("GetSecret() = %q, %v, want %q", secret, err, "password")
+		}
+	}
+	if backend.calls != 1 {
+		t.Errorf("provider called %d times, want 1", backend.calls)
+	}
+
+	backend.mockProvider["bmc/password"] = "changed"
+	now = now.Add(2 * time.Minute)
+	secret, err := provider.GetSecret("bmc/password")
+	if err != nil || string(secret) != "changed" {
+		t.Errorf("GetSecret() after expiry = %q, %v, want %q", secret, err, "changed")
+	}
+
+	if _, err := provider.GetSecret("bmc/unknown"); err == nil {
+		t.Errorf("GetSecret() of unknown secret returned no error")
+	}
+	if _, err := provider.GetSecret("bmc/unknown"); err == nil || backend.calls != 4 {
+		t.Errorf("failed lookup was cached, provider called %d times, want 4", backend.calls)
+	}
+}
@@ -0,0 +1,42 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package secrets
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// DatabaseProvider reads the secrets stored in the ODIM database. The secrets are encrypted
+// with the ODIM public key, as the credentials of the servers and of the plugins are.
+type DatabaseProvider struct {
+	// Read returns the encrypted secret stored with the path as its key
+	Read func(path string) (string, error)
+	// Decrypt returns the plain text of the secret
+	Decrypt func(ciphertext []byte) ([]byte, error)
+}
+
+// GetSecret reads the secret stored at the path and decrypts it
+func (p DatabaseProvider) GetSecret(path string) ([]byte, error) {
+	data, err := p.Read(path)
+	if err != nil {
+		return nil, err
+	}
+	var ciphertext []byte
+	if err := json.Unmarshal([]byte(data), &ciphertext); err != nil {
+		return nil, fmt.Errorf("unable to read the encrypted secret: %v", err)
+	}
+	return p.Decrypt(ciphertext)
+}
@@ -0,0 +1,38 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package secrets
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+)
+
+// FileProvider reads the secrets from the files of a directory, such as a Kubernetes
+// secret mounted as a volume. The path of a secret is the path of its file in the directory.
+type FileProvider struct {
+	Directory string
+}
+
+// GetSecret reads the file of the secret, without its trailing line break
+func (p FileProvider) GetSecret(path string) ([]byte, error) {
+	// the path is rooted before joining it so that it cannot point outside of the directory
+	name := filepath.Join(p.Directory, filepath.Clean("/"+filepath.FromSlash(path)))
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(data, "\r\n"), nil
+}
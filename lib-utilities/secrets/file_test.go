@@ -0,0 +1,53 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package secrets
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileProvider_GetSecret(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "bmc"), 0700)
+	os.WriteFile(filepath.Join(dir, "bmc", "password"), []byte("Password1!\n"), 0600)
+	outside := filepath.Join(filepath.Dir(dir), "outside")
+	os.WriteFile(outside, []byte("Outside1!"), 0600)
+	defer os.Remove(outside)
+
+	provider := FileProvider{Directory: dir}
+	tests := []struct {
+		name    string
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{"secret file", "bmc/password", "Password1!", false},
+		{"missing file", "bmc/unknown", "", true},
+		{"path outside of the directory", "../outside", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := provider.GetSecret(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetSecret() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("GetSecret() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
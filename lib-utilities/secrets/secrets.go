@@ -0,0 +1,64 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package secrets resolves the credentials which are kept outside of the ODIM database.
+// A credential stored as a reference of the form secret://<path> is resolved by the
+// configured provider each time it is used, so that the secret never reaches the database.
+package secrets
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Scheme is the prefix of the references to the secrets
+const Scheme = "secret://"
+
+// Provider returns the secret stored at a path of a secrets backend
+type Provider interface {
+	GetSecret(path string) ([]byte, error)
+}
+
+// IsReference reports whether the credential is a reference to a secret
+func IsReference(credential []byte) bool {
+	return bytes.HasPrefix(credential, []byte(Scheme))
+}
+
+// ReferencePath returns the path of the secret referenced
+func ReferencePath(reference []byte) (string, error) {
+	if !IsReference(reference) {
+		return "", fmt.Errorf("the value is not a reference to a secret")
+	}
+	path := string(reference[len(Scheme):])
+	if path == "" {
+		return "", fmt.Errorf("no path given in the secret reference")
+	}
+	return path, nil
+}
+
+// Resolve returns the secret referenced from the provider
+func Resolve(provider Provider, reference []byte) ([]byte, error) {
+	path, err := ReferencePath(reference)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := provider.GetSecret(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the secret %s: %v", path, err)
+	}
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("the secret %s is empty", path)
+	}
+	return secret, nil
+}
@@ -0,0 +1,93 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package secrets
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type mockProvider map[string]string
+
+func (p mockProvider) GetSecret(path string) ([]byte, error) {
+	secret, ok := p[path]
+	if !ok {
+		return nil, fmt.Errorf("not found")
+	}
+	return []byte(secret), nil
+}
+
+func TestIsReference(t *testing.T) {
+	if !IsReference([]byte("secret://bmc/password")) {
+		t.Errorf("IsReference() = false, want true")
+	}
+	if IsReference([]byte("password")) {
+		t.Errorf("IsReference() = true, want false")
+	}
+}
+
+func TestResolve(t *testing.T) {
+	provider := mockProvider{"bmc/password": "Password1!", "empty": ""}
+	tests := []struct {
+		name      string
+		reference string
+		want      []byte
+		wantErr   bool
+	}{
+		{"secret found", "secret://bmc/password", []byte("Password1!"), false},
+		{"secret not found", "secret://bmc/unknown", nil, true},
+		{"empty secret", "secret://empty", nil, true},
+		{"no path", "secret://", nil, true},
+		{"not a reference", "Password1!", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Resolve(provider, []byte(tt.reference))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Resolve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Resolve() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDatabaseProvider_GetSecret(t *testing.T) {
+	stored, _ := json.Marshal([]byte("encrypted"))
+	provider := DatabaseProvider{
+		Read: func(path string) (string, error) {
+			if path != "bmc/password" {
+				return "", fmt.Errorf("no data with the key %s found", path)
+			}
+			return string(stored), nil
+		},
+		Decrypt: func(ciphertext []byte) ([]byte, error) {
+			if string(ciphertext) != "encrypted" {
+				return nil, fmt.Errorf("decryption failed")
+			}
+			return []byte("Password1!"), nil
+		},
+	}
+	got, err := provider.GetSecret("bmc/password")
+	if err != nil || string(got) != "Password1!" {
+		t.Errorf("GetSecret() = %s, %v, want Password1!", got, err)
+	}
+	if _, err := provider.GetSecret("bmc/unknown"); err == nil {
+		t.Errorf("GetSecret() error = nil for an unknown secret")
+	}
+}
@@ -0,0 +1,86 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package secrets
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	// DefaultVaultMountPath is the mount path of the KV secrets engine of a Vault dev server
+	DefaultVaultMountPath = "secret"
+	// DefaultVaultKey is the key of the value read when the reference does not have one
+	DefaultVaultKey  = "password"
+	vaultTokenHeader = "X-Vault-Token"
+)
+
+// VaultProvider reads the secrets from the KV version 2 secrets engine of HashiCorp Vault.
+// The path of a secret is its path in the engine optionally followed by # and the key of
+// the value to be read, e.g. secret://bmc/rack1#password.
+type VaultProvider struct {
+	Address   string // URL of the Vault server, e.g. https://vault:8200
+	Token     string
+	MountPath string // mount path of the KV secrets engine, DefaultVaultMountPath when empty
+	Client    *http.Client
+}
+
+// vaultSecret is the response of Vault for reading a secret of the KV version 2 secrets engine
+type vaultSecret struct {
+	Data struct {
+		Data map[string]interface{} `json:"data"`
+	} `json:"data"`
+}
+
+// GetSecret reads the latest version of the secret from Vault
+func (p VaultProvider) GetSecret(path string) ([]byte, error) {
+	key := DefaultVaultKey
+	if i := strings.LastIndexByte(path, '#'); i >= 0 {
+		path, key = path[:i], path[i+1:]
+	}
+	mountPath := p.MountPath
+	if mountPath == "" {
+		mountPath = DefaultVaultMountPath
+	}
+	url := strings.TrimSuffix(p.Address, "/") + "/v1/" + strings.Trim(mountPath, "/") + "/data/" + strings.TrimPrefix(path, "/")
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(vaultTokenHeader, p.Token)
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vault responded with status %d", resp.StatusCode)
+	}
+	var secret vaultSecret
+	if err := json.NewDecoder(resp.Body).Decode(&secret); err != nil {
+		return nil, fmt.Errorf("unable to parse the response of vault: %v", err)
+	}
+	value, ok := secret.Data.Data[key].(string)
+	if !ok {
+		return nil, fmt.Errorf("the secret has no value for the key %s", key)
+	}
+	return []byte(value), nil
+}
@@ -0,0 +1,78 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package secrets
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newVaultDevServer returns a stand-in of a Vault dev server having the KV version 2
+// secrets engine mounted at the default path
+func newVaultDevServer(t *testing.T, token string, secrets map[string]map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(vaultTokenHeader) != token {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		path := strings.TrimPrefix(r.URL.Path, "/v1/secret/data/")
+		data, ok := secrets[path]
+		if r.Method != http.MethodGet || !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"data":     data,
+				"metadata": map[string]interface{}{"version": 1},
+			},
+		})
+	}))
+}
+
+func TestVaultProvider_GetSecret(t *testing.T) {
+	server := newVaultDevServer(t, "root", map[string]map[string]interface{}{
+		"bmc/rack1": {"password": "Password1!", "admin": "Admin1!"},
+	})
+	defer server.Close()
+	tests := []struct {
+		name    string
+		token   string
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{"default key", "root", "bmc/rack1", "Password1!", false},
+		{"given key", "root", "bmc/rack1#admin", "Admin1!", false},
+		{"unknown key", "root", "bmc/rack1#user", "", true},
+		{"unknown secret", "root", "bmc/rack2", "", true},
+		{"invalid token", "invalid", "bmc/rack1", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := VaultProvider{Address: server.URL, Token: tt.token, Client: server.Client()}
+			got, err := provider.GetSecret(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetSecret() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("GetSecret() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
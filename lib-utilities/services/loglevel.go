@@ -0,0 +1,46 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package services
+
+import (
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+)
+
+// logLevelSyncInterval is the interval at which the log level overrides
+// are read from the DB. It bounds the delay to apply or revert an override.
+const logLevelSyncInterval = 10 * time.Second
+
+// syncLogLevels applies the log level overrides stored in the DB on top of
+// the log level configured for the service. Overrides are kept as they are
+// when the DB is unreachable, and are reverted when they expire.
+func syncLogLevels() {
+	go func() {
+		var overrides []l.LevelOverride
+		for {
+			latest, err := common.GetLogLevelOverrides()
+			if err != nil {
+				l.Log.Debug("unable to read the log level overrides: " + err.Error())
+			} else {
+				overrides = latest
+			}
+			l.SetLevels(config.Data.LogLevel, overrides)
+			time.Sleep(logLevelSyncInterval)
+		}
+	}()
+}
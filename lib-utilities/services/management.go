@@ -0,0 +1,99 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/health"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/lib-utilities/metrics"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"google.golang.org/grpc"
+)
+
+// serveManagementEndpoints starts the http server exposing the metrics and
+// the health of the service on the address configured in MetricsConf.
+// It returns immediately, failures to serve the endpoints are only logged
+// since they must not stop the service.
+func serveManagementEndpoints(serviceName string) {
+	mux := http.NewServeMux()
+	metrics.Expose(mux, serviceName)
+	mux.Handle(health.LivePath, health.LiveHandler())
+	mux.Handle(health.ReadyPath, health.ReadyHandler())
+	if config.Data.MetricsConf == nil || config.Data.MetricsConf.ListenAddress == "" {
+		l.Log.Warn("management endpoints are not served, listen address is not configured for " + serviceName)
+		return
+	}
+	server := &http.Server{
+		Addr:              config.Data.MetricsConf.ListenAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		l.Log.Infof("exposing metrics and health of %s on %s", serviceName, server.Addr)
+		if err := server.ListenAndServe(); err != nil {
+			l.Log.Error("management server stopped: " + err.Error())
+		}
+	}()
+}
+
+// registerHealthChecks registers the readiness checks of the dependencies
+// common to all the services
+func registerHealthChecks() {
+	health.Register("Redis", health.RedisCheck)
+	health.Register("ServiceRegistry", ODIMService.CheckRegistry)
+}
+
+// CheckRegistry verifies that the service registry is reachable. For a
+// server it also verifies that the service is still registered, since
+// the other services can not reach it otherwise.
+func (s *odimService) CheckRegistry(ctx context.Context) error {
+	cli, err := s.getRegistryClient()
+	if err != nil {
+		return err
+	}
+	resp, err := clientv3.NewKV(cli).Get(ctx, s.serverName, clientv3.WithCountOnly())
+	if err != nil {
+		return fmt.Errorf("While trying to get the service from registry, got: %v", err)
+	}
+	if s.server != nil && s.serverAddress != "" && resp.Count == 0 {
+		return fmt.Errorf("%s is not registered in the service registry", s.serverName)
+	}
+	return nil
+}
+
+// CheckClient verifies that the service with the name passed is registered
+// and accepts connections
+func (s *odimService) CheckClient(ctx context.Context, clientName string) error {
+	clientAddress, err := s.getServiceAddress(clientName)
+	if err != nil {
+		return fmt.Errorf("While trying to get the service address from registry, got: %v", err)
+	}
+	conn, err := grpc.DialContext(
+		ctx,
+		clientAddress,
+		grpc.WithTransportCredentials(s.clientTransportCreds),
+		grpc.WithBlock(),
+	)
+	if err != nil {
+		return fmt.Errorf("%s is not reachable on %s: %v", clientName, clientAddress, err)
+	}
+	return conn.Close()
+}
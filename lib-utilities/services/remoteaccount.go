@@ -0,0 +1,41 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	managersproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/managers"
+)
+
+// ChangeRemoteAccountPassword calls the managers service to change the password of the
+// BMC account used by ODIM, the BMC is logged in with the password passed
+func ChangeRemoteAccountPassword(ctx context.Context, deviceUUID string, password, newPassword []byte) (*managersproto.ManagerResponse, error) {
+	conn, errConn := ODIMService.Client(Managers)
+	if errConn != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", errConn)
+	}
+	defer conn.Close()
+	managers := managersproto.NewManagersClient(conn)
+	ctxt := common.CreateNewRequestContext(ctx)
+	ctxt = common.CreateMetadata(ctxt)
+	return managers.ChangeRemoteAccountPassword(ctxt, &managersproto.RemoteAccountPasswordRequest{
+		DeviceUUID:  deviceUUID,
+		Password:    password,
+		NewPassword: newPassword,
+	})
+}
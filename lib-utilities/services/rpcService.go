@@ -24,12 +24,17 @@ import (
 	"os"
 	"os/signal"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/health"
+	"github.com/ODIM-Project/ODIM/lib-utilities/metrics"
+	"github.com/ODIM-Project/ODIM/lib-utilities/tracing"
 	uuid "github.com/satori/go.uuid"
 	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 )
@@ -53,6 +58,11 @@ type odimService struct {
 	serverAddress        string
 	serverName           string
 	serverTransportCreds credentials.TransportCredentials
+
+	// registryClient is shared by the lookups and the health checks made
+	// on the service registry, it is created on first use
+	registryLock   sync.Mutex
+	registryClient *clientv3.Client
 }
 
 // ODIMService holds the initialized instance of odimService
@@ -72,6 +82,11 @@ func InitializeService(serviceName string, errChan chan<- error) error {
 		}
 
 		ODIMService.intiateSignalHandler(errChan)
+		registerHealthChecks()
+		serveManagementEndpoints(serviceName)
+		health.StartReporting(serviceName, ODIMService.serverName)
+		tracing.Init(serviceName)
+		syncLogLevels()
 
 	default:
 		return fmt.Errorf("unknown framework type")
@@ -85,6 +100,11 @@ func InitializeClient(serviceName string) error {
 	if err != nil {
 		return fmt.Errorf("While trying to initiate ODIMService model, got: %v", err)
 	}
+	registerHealthChecks()
+	serveManagementEndpoints(serviceName)
+	health.StartReporting(serviceName, ODIMService.serverName)
+	tracing.Init(serviceName)
+	syncLogLevels()
 	return nil
 }
 
@@ -114,6 +134,7 @@ func (s *odimService) Client(clientName string) (*grpc.ClientConn, error) {
 	return grpc.Dial(
 		clientAddress,
 		grpc.WithTransportCredentials(s.clientTransportCreds),
+		grpc.WithChainUnaryInterceptor(otelgrpc.UnaryClientInterceptor(), metrics.UnaryClientInterceptor),
 	)
 }
 
@@ -153,20 +174,36 @@ func (s *odimService) Init(serviceName string) error {
 	}
 	ODIMService.server = grpc.NewServer(
 		grpc.Creds(s.serverTransportCreds),
+		grpc.ChainUnaryInterceptor(otelgrpc.UnaryServerInterceptor(), tracing.TransactionIDInterceptor, metrics.UnaryServerInterceptor),
 	)
 	return nil
 }
 
+// getRegistryClient returns the client of the service registry. The client
+// is created on the first call and reused afterwards, a failed creation is
+// retried on the next call.
+func (s *odimService) getRegistryClient() (*clientv3.Client, error) {
+	s.registryLock.Lock()
+	defer s.registryLock.Unlock()
+	if s.registryClient == nil {
+		cli, err := clientv3.New(clientv3.Config{
+			Endpoints:   []string{s.registryAddress},
+			DialTimeout: 5 * time.Second,
+			TLS:         s.etcdTLSConfig,
+		})
+		if err != nil {
+			return nil, fmt.Errorf(createClientErrMsg, err)
+		}
+		s.registryClient = cli
+	}
+	return s.registryClient, nil
+}
+
 func (s *odimService) getServiceAddress(serviceName string) (string, error) {
-	cli, err := clientv3.New(clientv3.Config{
-		Endpoints:   []string{s.registryAddress},
-		DialTimeout: 5 * time.Second,
-		TLS:         s.etcdTLSConfig,
-	})
+	cli, err := s.getRegistryClient()
 	if err != nil {
-		return "", fmt.Errorf(createClientErrMsg, err)
+		return "", err
 	}
-	defer cli.Close()
 	kv := clientv3.NewKV(cli)
 	resp, err := kv.Get(context.TODO(), serviceName, clientv3.WithPrefix())
 	if err != nil {
@@ -297,6 +334,7 @@ func (s *odimService) intiateSignalHandler(errChan chan<- error) {
 		syscall.SIGQUIT)
 	go func() {
 		sig := <-sigs
+		tracing.Shutdown()
 		errChan <- fmt.Errorf("Received interrupt signal from OS: %v", sig)
 		err := s.deregisterService()
 		errChan <- err
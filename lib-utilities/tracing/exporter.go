@@ -0,0 +1,158 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package tracing
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/lib-utilities/metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// tracesPath is the path of the OTLP/HTTP traces receiver
+	tracesPath      = "/v1/traces"
+	spanQueueSize   = 4096
+	maxBatchSize    = 512
+	exportInterval  = 5 * time.Second
+	exportTimeout   = 10 * time.Second
+	shutdownTimeout = 5 * time.Second
+)
+
+var exportedSpans = metrics.NewCounterVec("odim_tracing_spans_exported_total",
+	"Number of spans sent to the trace collector, by result", "result")
+
+// provider is the tracer provider of the service, nil as long as tracing is disabled
+var provider struct {
+	sync.Mutex
+	*sdktrace.TracerProvider
+}
+
+// Init enables the export of the spans of the service when tracing is enabled
+// in TracingConf. Failures are only logged since tracing must not stop the service.
+func Init(serviceName string) {
+	conf := config.Data.TracingConf
+	if conf == nil || !conf.Enabled {
+		l.Log.Info("tracing is disabled for " + serviceName)
+		return
+	}
+	options, err := collectorOptions(conf.CollectorEndpoint)
+	if err != nil {
+		l.Log.Error("failed to initialize tracing: " + err.Error())
+		return
+	}
+	exporter, err := otlptracehttp.New(context.Background(), options...)
+	if err != nil {
+		l.Log.Error("failed to initialize tracing: " + err.Error())
+		return
+	}
+	attributes := []attribute.KeyValue{semconv.ServiceNameKey.String(serviceName)}
+	if hostName, err := os.Hostname(); err == nil {
+		attributes = append(attributes, semconv.ServiceInstanceIDKey.String(hostName))
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(countingExporter{exporter},
+			sdktrace.WithMaxQueueSize(spanQueueSize),
+			sdktrace.WithMaxExportBatchSize(maxBatchSize),
+			sdktrace.WithBatchTimeout(exportInterval),
+			sdktrace.WithExportTimeout(exportTimeout)),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, attributes...)),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(conf.SamplingRatio))),
+	)
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		l.Log.Warn("tracing: " + err.Error())
+	}))
+	otel.SetTracerProvider(tp)
+
+	provider.Lock()
+	provider.TracerProvider = tp
+	provider.Unlock()
+	l.Log.Infof("exporting traces of %s to %s", serviceName, conf.CollectorEndpoint)
+}
+
+// Shutdown exports the spans still queued and disables the tracing
+func Shutdown() {
+	provider.Lock()
+	tp := provider.TracerProvider
+	provider.TracerProvider = nil
+	provider.Unlock()
+	if tp == nil {
+		return
+	}
+	otel.SetTracerProvider(trace.NewNoopTracerProvider())
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := tp.Shutdown(ctx); err != nil {
+		l.Log.Warn("failed to export the pending spans: " + err.Error())
+	}
+}
+
+// collectorOptions returns the options of the OTLP/HTTP exporter for the base URL of the collector
+func collectorOptions(endpoint string) ([]otlptracehttp.Option, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid collector endpoint %q, expected an http or https URL", endpoint)
+	}
+	options := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(u.Host),
+		otlptracehttp.WithURLPath(path.Join("/", u.Path, tracesPath)),
+		otlptracehttp.WithTimeout(exportTimeout),
+	}
+	switch u.Scheme {
+	case "http":
+		return append(options, otlptracehttp.WithInsecure()), nil
+	case "https":
+		tlsConfig := &tls.Config{}
+		httpConf := &config.HTTPConfig{
+			CACertificate: &config.Data.KeyCertConf.RootCACertificate,
+		}
+		if err := httpConf.LoadCertificates(tlsConfig); err != nil {
+			return nil, err
+		}
+		config.Client.SetTLSConfig(tlsConfig)
+		return append(options, otlptracehttp.WithTLSClientConfig(tlsConfig)), nil
+	}
+	return nil, fmt.Errorf("invalid collector endpoint %q, expected an http or https URL", endpoint)
+}
+
+// countingExporter counts the spans sent to the collector by result
+type countingExporter struct {
+	sdktrace.SpanExporter
+}
+
+func (e countingExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	err := e.SpanExporter.ExportSpans(ctx, spans)
+	result := "succeeded"
+	if err != nil {
+		result = "failed"
+	}
+	exportedSpans.WithLabelValues(result).Add(float64(len(spans)))
+	return err
+}
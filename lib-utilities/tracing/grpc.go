@@ -0,0 +1,39 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// transactionIDKey is the metadata key of the ODIM transaction ID,
+// same as common.TransactionID which cannot be imported here
+const transactionIDKey = "transactionid"
+
+// TransactionIDInterceptor sets the ODIM transaction ID received from the caller
+// on the server span. It is chained after the otelgrpc server interceptor,
+// which starts the span.
+func TransactionIDInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(transactionIDKey); len(values) > 0 && values[0] != "" {
+		trace.SpanFromContext(ctx).SetAttributes(attribute.String("odim.transaction_id", values[0]))
+	}
+	return handler(ctx, req)
+}
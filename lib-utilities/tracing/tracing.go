@@ -0,0 +1,105 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package tracing provides distributed tracing for the ODIM services using OpenTelemetry.
+// The trace context is propagated using the W3C Trace Context format and
+// the finished spans are exported to an OpenTelemetry collector using OTLP/HTTP.
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// TraceParentHeader is the W3C Trace Context header holding the parent span
+	TraceParentHeader = "traceparent"
+	// TraceStateHeader is the W3C Trace Context header holding vendor specific data
+	TraceStateHeader = "tracestate"
+
+	instrumentationName = "github.com/ODIM-Project/ODIM/lib-utilities/tracing"
+)
+
+// the trace context is propagated even when tracing is disabled, so that
+// the trace context received from a caller reaches the services further down
+func init() {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+}
+
+// StartSpan starts a span as a child of the span found in ctx, a new trace is
+// started when there is none. The returned context holds the new span.
+func StartSpan(ctx context.Context, name string, kind trace.SpanKind, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attributes...))
+}
+
+// StartDBSpan starts a span for an operation on a table of the database
+func StartDBSpan(ctx context.Context, operation, table string) (context.Context, trace.Span) {
+	return StartSpan(ctx, operation+" "+table, trace.SpanKindClient,
+		semconv.DBSystemRedis, semconv.DBOperationKey.String(operation), attribute.String("db.redis.table", table))
+}
+
+// RecordError records err on the span and marks the span as failed when err is not nil
+func RecordError(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+// SetHTTPStatus sets the status code attribute of an HTTP span,
+// the span is marked as failed for the server and client errors
+func SetHTTPStatus(span trace.Span, statusCode int) {
+	span.SetAttributes(semconv.HTTPStatusCodeKey.Int(statusCode))
+	if statusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, http.StatusText(statusCode))
+	}
+}
+
+// Inject sets the trace context headers of the current span in the header
+func Inject(ctx context.Context, header http.Header) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// Extract returns a context holding the trace context received in the header,
+// ctx is returned as is when the header does not hold a valid trace context
+func Extract(ctx context.Context, header http.Header) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(header))
+}
+
+// ExtractTraceParent returns a context holding the trace context of the traceparent
+// and tracestate values, ctx is returned as is when traceParent is not valid
+func ExtractTraceParent(ctx context.Context, traceParent, traceState string) context.Context {
+	if traceParent == "" {
+		return ctx
+	}
+	carrier := propagation.MapCarrier{TraceParentHeader: traceParent}
+	if traceState != "" {
+		carrier[TraceStateHeader] = traceState
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
+
+// TraceParent returns the traceparent value of the current span, empty when there is none
+func TraceParent(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	return carrier[TraceParentHeader]
+}
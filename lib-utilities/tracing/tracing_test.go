@@ -0,0 +1,173 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package tracing
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+const testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+// recordSpans sets a tracer provider recording the spans for the duration of the test
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(trace.NewNoopTracerProvider()) })
+	return recorder
+}
+
+func TestPropagation(t *testing.T) {
+	recordSpans(t)
+	header := http.Header{}
+	header.Set(TraceParentHeader, testTraceParent)
+	header.Set(TraceStateHeader, "vendor=value")
+	ctx, span := StartSpan(Extract(context.Background(), header), "test", trace.SpanKindServer)
+	defer span.End()
+
+	sc := span.SpanContext()
+	if sc.TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" || !sc.IsSampled() {
+		t.Errorf("StartSpan() did not continue the received trace, got %v", TraceParent(ctx))
+	}
+	if parent := span.(sdktrace.ReadOnlySpan).Parent(); parent.SpanID().String() != "00f067aa0ba902b7" {
+		t.Errorf("StartSpan() parent = %v", parent.SpanID())
+	}
+	out := http.Header{}
+	Inject(ctx, out)
+	if out.Get(TraceParentHeader) != "00-"+sc.TraceID().String()+"-"+sc.SpanID().String()+"-01" || out.Get(TraceStateHeader) != "vendor=value" {
+		t.Errorf("Inject() headers = %v", out)
+	}
+}
+
+func TestPropagationWhileDisabled(t *testing.T) {
+	ctx, span := StartSpan(ExtractTraceParent(context.Background(), testTraceParent, ""), "test", trace.SpanKindConsumer)
+	defer span.End()
+	if span.IsRecording() {
+		t.Errorf("StartSpan() recorded a span while tracing is disabled")
+	}
+	if got := TraceParent(ctx); got != testTraceParent {
+		t.Errorf("TraceParent() = %v, want %v", got, testTraceParent)
+	}
+	if got := TraceParent(ExtractTraceParent(context.Background(), "00-invalid", "")); got != "" {
+		t.Errorf("TraceParent() of an invalid traceparent = %v", got)
+	}
+}
+
+func TestExport(t *testing.T) {
+	received := make(chan *coltracepb.ExportTraceServiceRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/collector"+tracesPath || r.Header.Get("Content-Type") != "application/x-protobuf" {
+			t.Errorf("unexpected export request %v %v", r.URL.Path, r.Header)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		req := &coltracepb.ExportTraceServiceRequest{}
+		if err := proto.Unmarshal(body, req); err != nil {
+			t.Errorf("invalid export request: %v", err)
+		}
+		received <- req
+	}))
+	defer server.Close()
+
+	config.Data.TracingConf = &config.TracingConf{
+		Enabled:           true,
+		CollectorEndpoint: server.URL + "/collector",
+		SamplingRatio:     1,
+	}
+	Init("test-service")
+	ctx, parent := StartSpan(context.Background(), "parent", trace.SpanKindServer)
+	_, child := StartDBSpan(ctx, "Read", "Plugin")
+	child.End()
+	SetHTTPStatus(parent, http.StatusNotFound)
+	parent.End()
+	Shutdown()
+
+	select {
+	case req := <-received:
+		spans := req.ResourceSpans[0].ScopeSpans[0].Spans
+		if len(spans) != 2 {
+			t.Fatalf("exported %d spans, want 2", len(spans))
+		}
+		if string(spans[0].ParentSpanId) != string(spans[1].SpanId) || string(spans[0].TraceId) != string(spans[1].TraceId) {
+			t.Errorf("child span is not linked to its parent: %+v", spans)
+		}
+		if spans[1].Status.Code != tracepb.Status_STATUS_CODE_ERROR || spans[1].Kind != tracepb.Span_SPAN_KIND_SERVER {
+			t.Errorf("unexpected parent span %+v", spans[1])
+		}
+		found := false
+		for _, attribute := range req.ResourceSpans[0].Resource.Attributes {
+			found = found || (attribute.Key == "service.name" && attribute.Value.GetStringValue() == "test-service")
+		}
+		if !found {
+			t.Errorf("unexpected resource %+v", req.ResourceSpans[0].Resource)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("spans were not exported")
+	}
+}
+
+func TestCollectorOptions(t *testing.T) {
+	for _, endpoint := range []string{"", "collector:4318", "ftp://collector:4318"} {
+		if _, err := collectorOptions(endpoint); err == nil {
+			t.Errorf("collectorOptions(%q) accepted an invalid endpoint", endpoint)
+		}
+	}
+	if _, err := collectorOptions("http://collector:4318"); err != nil {
+		t.Errorf("collectorOptions() error = %v", err)
+	}
+}
+
+func TestTransactionIDInterceptor(t *testing.T) {
+	recorder := recordSpans(t)
+	incoming := metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs(TraceParentHeader, testTraceParent, transactionIDKey, "transaction-1"))
+	info := &grpc.UnaryServerInfo{FullMethod: "/Systems/GetSystemResource"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return TransactionIDInterceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		})
+	}
+	otelgrpc.UnaryServerInterceptor()(incoming, nil, info, handler)
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("recorded %d spans, want 1", len(spans))
+	}
+	if spans[0].Name() != "Systems/GetSystemResource" || spans[0].SpanContext().TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("unexpected server span %v %v", spans[0].Name(), spans[0].SpanContext().TraceID())
+	}
+	found := false
+	for _, attribute := range spans[0].Attributes() {
+		found = found || (attribute.Key == "odim.transaction_id" && attribute.Value.AsString() == "transaction-1")
+	}
+	if !found {
+		t.Errorf("transaction ID is not set on the server span: %v", spans[0].Attributes())
+	}
+}
@@ -21,18 +21,19 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkhandler"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkmodel"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkresponse"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-dell/config"
 	"github.com/ODIM-Project/ODIM/plugin-dell/dpmodel"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dpresponse"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dputilities"
 	iris "github.com/kataras/iris/v12"
 )
 
 // DeviceClient struct to call device for the operation
 type DeviceClient struct {
-	ChangeSettingsOnDevice func(device *dputilities.RedfishDevice, url, method string) (*http.Response, error)
+	ChangeSettingsOnDevice func(device *sdkutilities.RedfishDevice, url, method string) (*http.Response, error)
 	DecryptPassword        func(password []byte) ([]byte, error)
 }
 
@@ -46,13 +47,11 @@ func ChangeSettings(ctx iris.Context) {
 	uri := ctx.Request().RequestURI
 	//replacing the request url with south bound translation URL
 	l.LogWithFields(ctxt).Debugf("incoming request received to change setting for URI %s method %s", uri, ctx.Request().Method)
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	}
+	uri = sdkhandler.TranslateToSouthBoundURL(uri)
 	l.LogWithFields(ctxt).Debugf("the request URI has been replaced with southbound data %s", uri)
 	//Validating the token
 	if token != "" {
-		flag := TokenValidation(token)
+		flag := sdkhandler.TokenValidation(token)
 		if !flag {
 			l.LogWithFields(ctxt).Error("X-Auth-Token is either expired or invalid")
 			ctx.StatusCode(http.StatusUnauthorized)
@@ -61,7 +60,7 @@ func ChangeSettings(ctx iris.Context) {
 		}
 	}
 
-	var deviceDetails dpmodel.Device
+	var deviceDetails sdkmodel.Device
 
 	//Get device details from request
 	err := ctx.ReadJSON(&deviceDetails)
@@ -71,14 +70,14 @@ func ChangeSettings(ctx iris.Context) {
 		ctx.WriteString("Error: bad request.")
 		return
 	}
-	device := &dputilities.RedfishDevice{
+	device := &sdkutilities.RedfishDevice{
 		Host:     deviceDetails.Host,
 		Username: deviceDetails.Username,
 		Password: string(deviceDetails.Password),
 		PostBody: deviceDetails.PostBody,
 	}
 
-	redfishClient, err := dputilities.GetRedfishClient()
+	redfishClient, err := sdkutilities.GetRedfishClient()
 	if err != nil {
 		errMsg := "While trying to create the redfish client, got:" + err.Error()
 		l.LogWithFields(ctxt).Error(errMsg)
@@ -120,9 +119,9 @@ func ChangeSettings(ctx iris.Context) {
 }
 
 //changeBiosSettings contains the logic for changing the bios settings
-func changeBiosSettings(ctxt context.Context, uri string, device *dputilities.RedfishDevice) (int, []byte, string) {
+func changeBiosSettings(ctxt context.Context, uri string, device *sdkutilities.RedfishDevice) (int, []byte, string) {
 	var errorMessage string
-	statusCode, _, resp, err := queryDevice(ctxt, uri, device, http.MethodGet)
+	statusCode, _, resp, err := sdkhandler.QueryDevice(ctxt, uri, device, http.MethodGet)
 	if err != nil {
 		errorMessage = "While trying to retrieve bios settings details, got: " + err.Error()
 		l.LogWithFields(ctxt).Error(errorMessage)
@@ -141,7 +140,7 @@ func changeBiosSettings(ctxt context.Context, uri string, device *dputilities.Re
 		reqPostBody := map[string]interface{}{"TargetSettingsURI": uri}
 		req, _ := json.Marshal(reqPostBody)
 		device.PostBody = req
-		statusCode, _, resp, err = queryDevice(ctxt, jobsURI, device, http.MethodPost)
+		statusCode, _, resp, err = sdkhandler.QueryDevice(ctxt, jobsURI, device, http.MethodPost)
 		if err != nil {
 			errorMessage = "While trying to create a job for updating the Bios settings, got: " + err.Error()
 			l.LogWithFields(ctxt).Error(errorMessage)
@@ -164,11 +163,11 @@ func changeBiosSettings(ctxt context.Context, uri string, device *dputilities.Re
 
 // createBiosResponse is used for creating a final response for bios settings
 func createBiosResponse() []byte {
-	resp := dpresponse.ErrorResponse{
-		Error: dpresponse.Error{
+	resp := sdkresponse.ErrorResponse{
+		Error: sdkresponse.Error{
 			Code:    response.Success,
 			Message: "See @Message.ExtendedInfo for more information.",
-			MessageExtendedInfo: []dpresponse.MsgExtendedInfo{
+			MessageExtendedInfo: []sdkresponse.MsgExtendedInfo{
 				{
 					MessageID:   response.Success,
 					Message:     "A system reset is required for BIOS settings changes to get affected",
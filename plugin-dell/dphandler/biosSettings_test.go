@@ -31,10 +31,10 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkresponse"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
-	"github.com/ODIM-Project/ODIM/plugin-dell/config"
 	"github.com/ODIM-Project/ODIM/plugin-dell/dpmodel"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dpresponse"
 	iris "github.com/kataras/iris/v12"
 	"github.com/kataras/iris/v12/httptest"
 	log "github.com/sirupsen/logrus"
@@ -289,7 +289,7 @@ func TestChangeBootOrderSettings(t *testing.T) {
 
 	redfishRoutes.Patch("/Systems/{id}", ChangeSettings)
 
-	dpresponse.PluginToken = "token"
+	sdkresponse.PluginToken = "token"
 
 	e := httptest.New(t, mockApp)
 
@@ -351,7 +351,7 @@ func TestChangeBiosSettings(t *testing.T) {
 
 	redfishRoutes.Patch("/Systems/{id}/Bios/Settings", ChangeSettings)
 
-	dpresponse.PluginToken = "token"
+	sdkresponse.PluginToken = "token"
 
 	e := httptest.New(t, mockApp)
 
@@ -17,12 +17,11 @@ package dphandler
 
 import (
 	"net/http"
-	"strings"
 
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkhandler"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkmodel"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-dell/config"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dpmodel"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dputilities"
 	iris "github.com/kataras/iris/v12"
 )
 
@@ -34,7 +33,7 @@ func SetDefaultBootOrder(ctx iris.Context) {
 	token := ctx.GetHeader("X-Auth-Token")
 	//Validating the token
 	if token != "" {
-		flag := TokenValidation(token)
+		flag := sdkhandler.TokenValidation(token)
 		if !flag {
 			l.LogWithFields(ctxt).Error("X-Auth-Token is either expired or invalid")
 			ctx.StatusCode(http.StatusUnauthorized)
@@ -43,13 +42,11 @@ func SetDefaultBootOrder(ctx iris.Context) {
 		}
 	}
 
-	var deviceDetails dpmodel.Device
+	var deviceDetails sdkmodel.Device
 	uri := ctx.Request().RequestURI
 	l.LogWithFields(ctxt).Debugf("incoming request received for setting boot order for URI %s method %s", uri, ctx.Request().Method)
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	}
+	uri = sdkhandler.TranslateToSouthBoundURL(uri)
 	l.LogWithFields(ctxt).Debugf("the request URI for default boot setting has been replaced with southbound URI %s", uri)
 
 	//Get device details from request
@@ -61,13 +58,13 @@ func SetDefaultBootOrder(ctx iris.Context) {
 		return
 	}
 
-	device := &dputilities.RedfishDevice{
+	device := &sdkutilities.RedfishDevice{
 		Host:     deviceDetails.Host,
 		Username: deviceDetails.Username,
 		Password: string(deviceDetails.Password),
 	}
 
-	redfishClient, err := dputilities.GetRedfishClient()
+	redfishClient, err := sdkutilities.GetRedfishClient()
 	if err != nil {
 		errMsg := "While trying to create the redfish client, got:" + err.Error()
 		l.LogWithFields(ctxt).Error(errMsg)
@@ -77,7 +74,7 @@ func SetDefaultBootOrder(ctx iris.Context) {
 	}
 
 	//Subscribe to Events
-	resp, err := redfishClient.SetDefaultBootOrder(device, uri)
+	resp, err := redfishClient.DeviceCall(device, uri, http.MethodPatch)
 	if err != nil {
 		errorMessage := "while trying to set default boot order, got: " + err.Error()
 		l.LogWithFields(ctxt).Error(errorMessage)
@@ -23,7 +23,7 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/ODIM-Project/ODIM/plugin-dell/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
 	iris "github.com/kataras/iris/v12"
 	"github.com/kataras/iris/v12/httptest"
 )
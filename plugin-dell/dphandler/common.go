@@ -16,15 +16,8 @@
 package dphandler
 
 import (
-	"context"
-	"fmt"
 	"io/ioutil"
-	"net/http"
 	"strings"
-
-	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-dell/config"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dputilities"
 )
 
 // convertToNorthBoundURI searches the key in an array and return bool
@@ -43,36 +36,5 @@ func convertToSouthBoundURI(req string, storageInstance string) string {
 	return req
 }
 
-// queryDevice is for querying a Dell server
-func queryDevice(ctx context.Context, uri string, device *dputilities.RedfishDevice, method string) (int, http.Header, []byte, error) {
-	redfishClient, err := dputilities.GetRedfishClient()
-	if err != nil {
-		errMsg := "While trying to create the redfish client, got:" + err.Error()
-		l.LogWithFields(ctx).Error(errMsg)
-		return http.StatusInternalServerError, nil, nil, fmt.Errorf(errMsg)
-	}
-	resp, err := redfishClient.DeviceCall(device, uri, method)
-	if err != nil {
-		l.LogWithFields(ctx).Error(err.Error())
-		if resp == nil {
-			return http.StatusBadRequest, nil, nil, err
-		}
-	}
-
-	body, err := ioutil.ReadAll(resp.Body)
-	defer resp.Body.Close()
-	if err != nil {
-		errMsg := "While trying to read the response body, got: " + err.Error()
-		l.LogWithFields(ctx).Error(errMsg)
-		return http.StatusInternalServerError, nil, nil, fmt.Errorf(errMsg)
-	}
-	return resp.StatusCode, resp.Header, body, nil
-}
-
-//replacing the request url with south bound translation URL
-func replaceURI(uri string) string {
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	}
-	return uri
-}
+// IoUtilReadAll is used to read the response bodies in the Dell handlers
+var IoUtilReadAll = ioutil.ReadAll
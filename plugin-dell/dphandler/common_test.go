@@ -16,12 +16,7 @@
 package dphandler
 
 import (
-	"net/http"
 	"testing"
-
-	"github.com/ODIM-Project/ODIM/plugin-dell/config"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dputilities"
-	"github.com/stretchr/testify/assert"
 )
 
 func Test_convertToNorthBoundURI(t *testing.T) {
@@ -50,27 +45,3 @@ func Test_convertToNorthBoundURI(t *testing.T) {
 		})
 	}
 }
-
-func Test_queryDevice(t *testing.T) {
-	config.SetUpMockConfig(t)
-
-	device := dputilities.RedfishDevice{
-		Username: "Localhost",
-		Password: "password",
-	}
-	ctxt := mockContext()
-	config.Data.KeyCertConf.RootCACertificate = nil
-	statusCode, _, _, err := queryDevice(ctxt, "/ODIM", &device, http.MethodPost)
-	assert.NotNil(t, err, "should contain error code StatusInternalServerError")
-	assert.Equal(t, http.StatusInternalServerError, statusCode)
-
-	device = dputilities.RedfishDevice{
-		Username: "Localhost",
-		Password: "password",
-	}
-	config.SetUpMockConfig(t)
-	statusCode, _, _, err = queryDevice(ctxt, "/ODIM", &device, http.MethodPost)
-	assert.NotNil(t, err, "should contain error code StatusBadRequest")
-	assert.Equal(t, http.StatusBadRequest, statusCode)
-
-}
@@ -20,10 +20,10 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkhandler"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkmodel"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-dell/config"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dpmodel"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dputilities"
 	iris "github.com/kataras/iris/v12"
 )
 
@@ -34,9 +34,7 @@ func GetResource(ctx iris.Context) {
 	token := ctx.GetHeader("X-Auth-Token")
 	uri := ctx.Request().RequestURI
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	}
+	uri = sdkhandler.TranslateToSouthBoundURL(uri)
 	//Getting storageinstance from URI
 	storageInstance := ctx.Params().Get("id2")
 
@@ -48,7 +46,7 @@ func GetResource(ctx iris.Context) {
 	}
 	//Validating the token
 	if token != "" {
-		flag := TokenValidation(token)
+		flag := sdkhandler.TokenValidation(token)
 		if !flag {
 			l.LogWithFields(ctxt).Error("Invalid/Expired X-Auth-Token")
 			ctx.StatusCode(http.StatusUnauthorized)
@@ -57,7 +55,7 @@ func GetResource(ctx iris.Context) {
 		}
 	}
 
-	var deviceDetails dpmodel.Device
+	var deviceDetails sdkmodel.Device
 
 	//Get device details from request
 	err := ctx.ReadJSON(&deviceDetails)
@@ -68,13 +66,13 @@ func GetResource(ctx iris.Context) {
 		return
 	}
 
-	device := &dputilities.RedfishDevice{
+	device := &sdkutilities.RedfishDevice{
 		Host:     deviceDetails.Host,
 		Username: deviceDetails.Username,
 		Password: string(deviceDetails.Password),
 	}
 
-	redfishClient, err := dputilities.GetRedfishClient()
+	redfishClient, err := sdkutilities.GetRedfishClient()
 	if err != nil {
 		errMsg := "While trying to create the redfish client, got:" + err.Error()
 		l.LogWithFields(ctxt).Error(errMsg)
@@ -111,9 +109,7 @@ func GetResource(ctx iris.Context) {
 	}
 	respData := string(body)
 	//replacing the response with north bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.NorthBoundURL {
-		respData = strings.Replace(respData, key, value, -1)
-	}
+	respData = sdkhandler.TranslateToNorthBoundURL(respData)
 	respData = convertToNorthBoundURI(respData, storageInstance)
 
 	//Transforming NetworkAdapters URI's
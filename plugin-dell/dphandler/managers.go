@@ -20,13 +20,15 @@ import (
 	"net/http"
 	"strings"
 
+	pluginConfig "github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkhandler"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkmodel"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkresponse"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-dell/config"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dpmodel"
 	"github.com/ODIM-Project/ODIM/plugin-dell/dpresponse"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dputilities"
 	iris "github.com/kataras/iris/v12"
 )
 
@@ -38,7 +40,7 @@ func GetManagersCollection(ctx iris.Context) {
 	uri := ctx.Request().RequestURI
 	//Validating the token
 	if token != "" {
-		flag := TokenValidation(token)
+		flag := sdkhandler.TokenValidation(token)
 		if !flag {
 			l.LogWithFields(ctxt).Error("Invalid/Expired X-Auth-Token")
 			ctx.StatusCode(http.StatusUnauthorized)
@@ -46,7 +48,7 @@ func GetManagersCollection(ctx iris.Context) {
 			return
 		}
 	}
-	var deviceDetails dpmodel.Device
+	var deviceDetails sdkmodel.Device
 	// if any error come while getting the device then request will be for  plugins manager
 	ctx.ReadJSON(&deviceDetails)
 	if deviceDetails.Host == "" {
@@ -84,7 +86,7 @@ func GetManagersInfo(ctx iris.Context) {
 
 	//Validating the token
 	if token != "" {
-		flag := TokenValidation(token)
+		flag := sdkhandler.TokenValidation(token)
 		if !flag {
 			l.LogWithFields(ctxt).Error("Invalid/Expired X-Auth-Token")
 			ctx.StatusCode(http.StatusUnauthorized)
@@ -92,7 +94,7 @@ func GetManagersInfo(ctx iris.Context) {
 			return
 		}
 	}
-	var deviceDetails dpmodel.Device
+	var deviceDetails sdkmodel.Device
 	// if any error come while getting the device then request will be for  plugins manager
 	ctx.ReadJSON(&deviceDetails)
 	if deviceDetails.Host == "" {
@@ -119,18 +121,16 @@ func GetManagersInfo(ctx iris.Context) {
 
 }
 
-func getInfoFromDevice(uri string, deviceDetails dpmodel.Device, ctx iris.Context) {
+func getInfoFromDevice(uri string, deviceDetails sdkmodel.Device, ctx iris.Context) {
 	ctxt := ctx.Request().Context()
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	}
-	device := &dputilities.RedfishDevice{
+	uri = sdkhandler.TranslateToSouthBoundURL(uri)
+	device := &sdkutilities.RedfishDevice{
 		Host:     deviceDetails.Host,
 		Username: deviceDetails.Username,
 		Password: string(deviceDetails.Password),
 	}
-	redfishClient, err := dputilities.GetRedfishClient()
+	redfishClient, err := sdkutilities.GetRedfishClient()
 	if err != nil {
 		errMsg := "While trying to create the redfish client, got:" + err.Error()
 		l.LogWithFields(ctxt).Error(errMsg)
@@ -168,9 +168,7 @@ func getInfoFromDevice(uri string, deviceDetails dpmodel.Device, ctx iris.Contex
 	}
 	respData := string(body)
 	//replacing the response with north bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.NorthBoundURL {
-		respData = strings.Replace(respData, key, value, -1)
-	}
+	respData = sdkhandler.TranslateToNorthBoundURL(respData)
 	ctx.StatusCode(resp.StatusCode)
 	ctx.Write([]byte(respData))
 }
@@ -179,8 +177,8 @@ func getInfoFromDevice(uri string, deviceDetails dpmodel.Device, ctx iris.Contex
 func VirtualMediaActions(ctx iris.Context) {
 	ctxt := ctx.Request().Context()
 	uri := ctx.Request().RequestURI
-	uri = replaceURI(uri)
-	var deviceDetails dpmodel.Device
+	uri = sdkhandler.TranslateToSouthBoundURL(uri)
+	var deviceDetails sdkmodel.Device
 	//Get device details from request
 	err := ctx.ReadJSON(&deviceDetails)
 	if err != nil {
@@ -189,7 +187,7 @@ func VirtualMediaActions(ctx iris.Context) {
 		ctx.WriteString("Error: bad request.")
 		return
 	}
-	device := &dputilities.RedfishDevice{
+	device := &sdkutilities.RedfishDevice{
 		Host:     deviceDetails.Host,
 		Username: deviceDetails.Username,
 		Password: string(deviceDetails.Password),
@@ -208,7 +206,7 @@ func VirtualMediaActions(ctx iris.Context) {
 		}
 	}
 
-	statusCode, _, body, err := queryDevice(ctx, uri, device, http.MethodPost)
+	statusCode, _, body, err := sdkhandler.QueryDevice(ctx, uri, device, http.MethodPost)
 	if err != nil {
 		errMsg := "while performing actions on virtual media, got: " + err.Error()
 		l.LogWithFields(ctxt).Error(errMsg)
@@ -239,11 +237,11 @@ func VirtualMediaActions(ctx iris.Context) {
 
 // createVirtualMediaActionResponse is used for creating a final response for virtual media actions success scenario
 func createVirtualMediaActionResponse() ([]byte, error) {
-	resp := dpresponse.ErrorResponse{
-		Error: dpresponse.Error{
+	resp := sdkresponse.ErrorResponse{
+		Error: sdkresponse.Error{
 			Code:    response.Success,
 			Message: "See @Message.ExtendedInfo for more information.",
-			MessageExtendedInfo: []dpresponse.MsgExtendedInfo{
+			MessageExtendedInfo: []sdkresponse.MsgExtendedInfo{
 				{
 					MessageID:   response.Success,
 					Message:     "Successfully performed virtual media actions",
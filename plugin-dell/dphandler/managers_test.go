@@ -24,9 +24,10 @@ import (
 	"testing"
 	"time"
 
-	"github.com/ODIM-Project/ODIM/plugin-dell/config"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dpmodel"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dpresponse"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkhandler"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkmodel"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkresponse"
 	iris "github.com/kataras/iris/v12"
 	"github.com/kataras/iris/v12/httptest"
 )
@@ -35,8 +36,8 @@ func mockManagers(username, password, url string, w http.ResponseWriter) {
 	body := `{"data": "success"}`
 	insertURL := "/redfish/v1/Managers/1/VirtualMedia/1/Actions/VirtualMedia.InsertMedia"
 	ejectURL := "/redfish/v1/Managers/1/VirtualMedia/1/Actions/VirtualMedia.EjectMedia"
-	insertURL = replaceURI(insertURL)
-	ejectURL = replaceURI(ejectURL)
+	insertURL = sdkhandler.TranslateToSouthBoundURL(insertURL)
+	ejectURL = sdkhandler.TranslateToSouthBoundURL(ejectURL)
 
 	if url == insertURL && username == "admin" {
 		e, _ := json.Marshal(body)
@@ -74,7 +75,7 @@ func TestGetManagerCollection(t *testing.T) {
 	deviceHost := "localhost"
 	devicePort := "1234"
 	url := "/redfish/v1"
-	url = replaceURI(url)
+	url = sdkhandler.TranslateToSouthBoundURL(url)
 
 	config.SetUpMockConfig(t)
 	ts := startTestServer(mockManagers)
@@ -88,10 +89,10 @@ func TestGetManagerCollection(t *testing.T) {
 
 	redfishRoutes.Get("/Managers", GetManagersCollection)
 
-	dpresponse.PluginToken = "token"
+	sdkresponse.PluginToken = "token"
 	e := httptest.New(t, mockApp)
 
-	var deviceDetails = dpmodel.Device{
+	var deviceDetails = sdkmodel.Device{
 		Host: "",
 	}
 	managerURL := url + "/Managers"
@@ -102,11 +103,7 @@ func TestGetManagerCollection(t *testing.T) {
 	e.GET(managerURL).WithHeader("X-Auth-Token", "Invalidtoken").WithJSON(deviceDetails).Expect().Status(http.StatusUnauthorized)
 
 	// case for positive
-	tokenDetails = []TokenMap{
-		{
-			Token: "valid",
-		},
-	}
+	sdkhandler.StoreToken("valid")
 	requestBody := map[string]interface{}{
 		"ManagerAddress": fmt.Sprintf("%s:%s", deviceHost, devicePort),
 		"UserName":       "admin",
@@ -137,16 +134,16 @@ func TestGetManagerCollection(t *testing.T) {
 
 func TestGetManager(t *testing.T) {
 	url := "/redfish/v1"
-	url = replaceURI(url)
+	url = sdkhandler.TranslateToSouthBoundURL(url)
 	config.SetUpMockConfig(t)
 	mockApp := iris.New()
 	redfishRoutes := mockApp.Party(url)
 	redfishRoutes.Get("/Managers", GetManagersInfo)
 
-	dpresponse.PluginToken = "token"
+	sdkresponse.PluginToken = "token"
 	e := httptest.New(t, mockApp)
 
-	var deviceDetails = dpmodel.Device{
+	var deviceDetails = sdkmodel.Device{
 		Host: "",
 	}
 	managerURL := url + "/Managers"
@@ -171,7 +168,7 @@ func TestVirtualMediaActions(t *testing.T) {
 	redfishRoutes := mockApp.Party("/redfish/v1")
 	redfishRoutes.Post("/Managers/1/VirtualMedia/1/Actions/VirtualMedia.InsertMedia", VirtualMediaActions)
 	redfishRoutes.Post("/Managers/1/VirtualMedia/1/Actions/VirtualMedia.EjectMedia", VirtualMediaActions)
-	dpresponse.PluginToken = "token"
+	sdkresponse.PluginToken = "token"
 
 	test := httptest.New(t, mockApp)
 	attributes := map[string]interface{}{"Image": "abc"}
@@ -212,7 +209,7 @@ func TestVirtualMediaActions(t *testing.T) {
 func Test_getInfoFromDevice(t *testing.T) {
 	type args struct {
 		uri           string
-		deviceDetails dpmodel.Device
+		deviceDetails sdkmodel.Device
 		ctx           iris.Context
 	}
 	tests := []struct {
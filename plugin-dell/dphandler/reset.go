@@ -23,17 +23,17 @@ import (
 	"strings"
 
 	"github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkhandler"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkmodel"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-dell/config"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dpmodel"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dputilities"
 	iris "github.com/kataras/iris/v12"
 )
 
-// QueryDevice alias queryDevice
-var QueryDevice = queryDevice
+// QueryDevice alias sdkhandler.QueryDevice
+var QueryDevice = sdkhandler.QueryDevice
 
 // ResetComputerSystem : reset computer system
 func ResetComputerSystem(ctx iris.Context) {
@@ -43,7 +43,7 @@ func ResetComputerSystem(ctx iris.Context) {
 	token := ctx.GetHeader("X-Auth-Token")
 	//Validating the token
 	if token != "" {
-		flag := TokenValidation(token)
+		flag := sdkhandler.TokenValidation(token)
 		if !flag {
 			l.LogWithFields(ctxt).Error("Invalid/Expired X-Auth-Token")
 			ctx.StatusCode(http.StatusUnauthorized)
@@ -51,12 +51,10 @@ func ResetComputerSystem(ctx iris.Context) {
 			return
 		}
 	}
-	var deviceDetails dpmodel.Device
+	var deviceDetails sdkmodel.Device
 	uri := ctx.Request().RequestURI
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	}
+	uri = sdkhandler.TranslateToSouthBoundURL(uri)
 	//Get device details from request
 	err := ctx.ReadJSON(&deviceDetails)
 	if err != nil {
@@ -65,7 +63,7 @@ func ResetComputerSystem(ctx iris.Context) {
 		ctx.WriteString("Error: bad request.")
 		return
 	}
-	device := &dputilities.RedfishDevice{
+	device := &sdkutilities.RedfishDevice{
 		Host:     deviceDetails.Host,
 		Username: deviceDetails.Username,
 		Password: string(deviceDetails.Password),
@@ -103,10 +101,10 @@ func ResetComputerSystem(ctx iris.Context) {
 		ctx.Write(respBody)
 		return
 	}
-	device.PostBody, _ = json.Marshal(dpmodel.ResetPostRequest{
+	device.PostBody, _ = json.Marshal(sdkmodel.ResetPostRequest{
 		ResetType: resetType,
 	})
-	redfishClient, err := dputilities.GetRedfishClient()
+	redfishClient, err := sdkutilities.GetRedfishClient()
 	if err != nil {
 		errMsg := "While trying to create the redfish client, got:" + err.Error()
 		l.LogWithFields(ctxt).Error(errMsg)
@@ -23,13 +23,14 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkhandler"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkmodel"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkresponse"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-dell/config"
 	"github.com/ODIM-Project/ODIM/plugin-dell/dpmodel"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dpresponse"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dputilities"
 	iris "github.com/kataras/iris/v12"
 )
 
@@ -41,13 +42,11 @@ func CreateVolume(ctx iris.Context) {
 	token := ctx.GetHeader("X-Auth-Token")
 	uri := ctx.Request().RequestURI
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	}
+	uri = sdkhandler.TranslateToSouthBoundURL(uri)
 
 	//Validating the token
 	if token != "" {
-		flag := TokenValidation(token)
+		flag := sdkhandler.TokenValidation(token)
 		if !flag {
 			l.LogWithFields(ctxt).Error("Invalid/Expired X-Auth-Token")
 			ctx.StatusCode(http.StatusUnauthorized)
@@ -56,7 +55,7 @@ func CreateVolume(ctx iris.Context) {
 		}
 	}
 
-	var deviceDetails dpmodel.Device
+	var deviceDetails sdkmodel.Device
 	//Get device details from request
 	err := ctx.ReadJSON(&deviceDetails)
 	if err != nil {
@@ -86,7 +85,7 @@ func CreateVolume(ctx iris.Context) {
 	driveSystemID := s[4]
 	reqPostBody = strings.Replace(reqPostBody, driveSystemID, systemID, -1)
 	reqPostBody = convertToSouthBoundURI(reqPostBody, storageInstance)
-	device := &dputilities.RedfishDevice{
+	device := &sdkutilities.RedfishDevice{
 		Host:     deviceDetails.Host,
 		Username: deviceDetails.Username,
 		Password: string(deviceDetails.Password),
@@ -113,7 +112,7 @@ func CreateVolume(ctx iris.Context) {
 
 		// calling device for creating a volume
 		var header http.Header
-		statusCode, header, resp, err = queryDevice(ctx, uri, device, http.MethodPost)
+		statusCode, header, resp, err = sdkhandler.QueryDevice(ctx, uri, device, http.MethodPost)
 		if err != nil {
 			errMsg := "While trying to create volume, got: " + err.Error()
 			l.LogWithFields(ctxt).Error(errMsg)
@@ -128,7 +127,7 @@ func CreateVolume(ctx iris.Context) {
 			for {
 				time.Sleep(10 * time.Second)
 				// calling device for creating a volume
-				statusCode, header, resp, err = queryDevice(ctx, taskURI, device, http.MethodGet)
+				statusCode, header, resp, err = sdkhandler.QueryDevice(ctx, taskURI, device, http.MethodGet)
 				if err != nil {
 					errorMessage := "While trying to get task id in create volume, got: " + err.Error()
 					l.LogWithFields(ctxt).Error(errorMessage)
@@ -193,16 +192,14 @@ func DeleteVolume(ctx iris.Context) {
 	token := ctx.GetHeader("X-Auth-Token")
 	uri := ctx.Request().RequestURI
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	}
+	uri = sdkhandler.TranslateToSouthBoundURL(uri)
 
 	storageInstance := ctx.Params().Get("id2")
 	uri = convertToSouthBoundURI(uri, storageInstance)
 
 	//Validating the token
 	if token != "" {
-		flag := TokenValidation(token)
+		flag := sdkhandler.TokenValidation(token)
 		if !flag {
 			l.LogWithFields(ctxt).Error("Invalid/Expired X-Auth-Token")
 			ctx.StatusCode(http.StatusUnauthorized)
@@ -211,7 +208,7 @@ func DeleteVolume(ctx iris.Context) {
 		}
 	}
 
-	var deviceDetails dpmodel.Device
+	var deviceDetails sdkmodel.Device
 
 	//Get device details from request
 	err := ctx.ReadJSON(&deviceDetails)
@@ -221,14 +218,14 @@ func DeleteVolume(ctx iris.Context) {
 		ctx.WriteString("Error: bad request.")
 		return
 	}
-	device := &dputilities.RedfishDevice{
+	device := &sdkutilities.RedfishDevice{
 		Host:     deviceDetails.Host,
 		Username: deviceDetails.Username,
 		Password: string(deviceDetails.Password),
 		PostBody: deviceDetails.PostBody,
 	}
 
-	redfishClient, err := dputilities.GetRedfishClient()
+	redfishClient, err := sdkutilities.GetRedfishClient()
 	if err != nil {
 		errMsg := "While trying to create the redfish client, got:" + err.Error()
 		l.LogWithFields(ctxt).Error(errMsg)
@@ -310,23 +307,21 @@ func manualEvents(req common.MessageData, hostAddress string) {
 	request, _ := json.Marshal(req)
 	reqData := string(request)
 	//replacing the response with north bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.NorthBoundURL {
-		reqData = strings.Replace(reqData, key, value, -1)
-	}
+	reqData = sdkhandler.TranslateToNorthBoundURL(reqData)
 	event := common.Events{
 		IP:      hostAddress,
 		Request: []byte(reqData),
 	}
-	// Call writeEventToJobQueue to write events to worker pool
-	writeEventToJobQueue(event)
+	// Call sdkhandler.WriteEventToJobQueue to write events to worker pool
+	sdkhandler.WriteEventToJobQueue(event)
 }
 
 // getVolumeCollection lists all the available volumes in the device
-func getVolumeCollection(ctx iris.Context, uri string, device *dputilities.RedfishDevice) (int, string, []string) {
+func getVolumeCollection(ctx iris.Context, uri string, device *sdkutilities.RedfishDevice) (int, string, []string) {
 	// Getting the list of volumes already exist in the server
 	ctxt := ctx.Request().Context()
 
-	statusCode, _, resp, err := queryDevice(ctx, uri, device, http.MethodGet)
+	statusCode, _, resp, err := sdkhandler.QueryDevice(ctx, uri, device, http.MethodGet)
 	if err != nil {
 		errMsg := "While getting volume collection details during create volume, got: " + err.Error()
 		l.LogWithFields(ctxt).Error(errMsg)
@@ -371,12 +366,12 @@ func compareCollection(list1, list2 []string) string {
 
 // createResponse is used for creating a final response for create volume
 func createResponse(code, msg, msgID string) []byte {
-	resp := dpresponse.ErrorResponse{
-		Error: dpresponse.Error{
+	resp := sdkresponse.ErrorResponse{
+		Error: sdkresponse.Error{
 			Code:    code,
 			Message: "See @Message.ExtendedInfo for more information.",
-			MessageExtendedInfo: []dpresponse.MsgExtendedInfo{
-				dpresponse.MsgExtendedInfo{
+			MessageExtendedInfo: []sdkresponse.MsgExtendedInfo{
+				sdkresponse.MsgExtendedInfo{
 					MessageID:   msgID,
 					Message:     msg,
 					MessageArgs: []string{},
@@ -389,11 +384,11 @@ func createResponse(code, msg, msgID string) []byte {
 }
 
 // getFirmwareVersion of the device
-func getFirmwareVersion(ctx iris.Context, uri string, device *dputilities.RedfishDevice) (int, string) {
+func getFirmwareVersion(ctx iris.Context, uri string, device *sdkutilities.RedfishDevice) (int, string) {
 	// Getting the firmware version of a server
 	ctxt := ctx.Request().Context()
 
-	statusCode, _, resp, err := queryDevice(ctx, uri, device, http.MethodGet)
+	statusCode, _, resp, err := sdkhandler.QueryDevice(ctx, uri, device, http.MethodGet)
 	if err != nil {
 		errMsg := "While getting firmware version details during create volume, got: " + err.Error()
 		l.LogWithFields(ctxt).Error(errMsg)
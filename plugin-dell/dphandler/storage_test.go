@@ -22,9 +22,9 @@ import (
 	"testing"
 
 	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
-	"github.com/ODIM-Project/ODIM/plugin-dell/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkresponse"
 	"github.com/ODIM-Project/ODIM/plugin-dell/dpmodel"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dpresponse"
 	iris "github.com/kataras/iris/v12"
 	"github.com/kataras/iris/v12/httptest"
 )
@@ -101,7 +101,7 @@ func TestCreateVolume(t *testing.T) {
 
 	redfishRoutes.Post("/Systems/{id}/Storage/{rid}/Volumes", CreateVolume)
 
-	dpresponse.PluginToken = "token"
+	sdkresponse.PluginToken = "token"
 
 	e := httptest.New(t, mockApp)
 
@@ -169,7 +169,7 @@ func TestDeleteVolume(t *testing.T) {
 
 	redfishRoutes.Delete("/Systems/1/Storage/1/Volumes/1", DeleteVolume)
 
-	dpresponse.PluginToken = "token"
+	sdkresponse.PluginToken = "token"
 
 	e := httptest.New(t, mockApp)
 
@@ -18,13 +18,12 @@ package dphandler
 import (
 	"encoding/json"
 	"net/http"
-	"strings"
 
 	"github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkhandler"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkmodel"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-dell/config"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dpmodel"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dputilities"
 	iris "github.com/kataras/iris/v12"
 )
 
@@ -36,7 +35,7 @@ func SimpleUpdate(ctx iris.Context) {
 	token := ctx.GetHeader("X-Auth-Token")
 	//Validating the token
 	if token != "" {
-		flag := TokenValidation(token)
+		flag := sdkhandler.TokenValidation(token)
 		if !flag {
 			l.LogWithFields(ctxt).Error("Invalid/Expired X-Auth-Token")
 			ctx.StatusCode(http.StatusUnauthorized)
@@ -44,7 +43,7 @@ func SimpleUpdate(ctx iris.Context) {
 			return
 		}
 	}
-	var deviceDetails dpmodel.Device
+	var deviceDetails sdkmodel.Device
 	reqPostBody := &model.SimpleUpdate{}
 	uri := ctx.Request().RequestURI
 	//Get device details from request
@@ -68,21 +67,18 @@ func SimpleUpdate(ctx iris.Context) {
 		l.LogWithFields(ctxt).Error(errMsg)
 		return
 	}
-	var reqData string
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-		reqData = strings.Replace(string(deviceDetails.PostBody), key, value, -1)
-	}
+	uri = sdkhandler.TranslateToSouthBoundURL(uri)
+	reqData := sdkhandler.TranslateToSouthBoundURL(string(deviceDetails.PostBody))
 
-	device := &dputilities.RedfishDevice{
+	device := &sdkutilities.RedfishDevice{
 		Host:     deviceDetails.Host,
 		Username: deviceDetails.Username,
 		Password: string(deviceDetails.Password),
 		PostBody: []byte(reqData),
 	}
 
-	redfishClient, err := dputilities.GetRedfishClient()
+	redfishClient, err := sdkutilities.GetRedfishClient()
 	if err != nil {
 		errMsg := "While trying to create the redfish client, got:" + err.Error()
 		l.LogWithFields(ctxt).Error(errMsg)
@@ -121,7 +117,7 @@ func StartUpdate(ctx iris.Context) {
 	token := ctx.GetHeader("X-Auth-Token")
 	//Validating the token
 	if token != "" {
-		flag := TokenValidation(token)
+		flag := sdkhandler.TokenValidation(token)
 		if !flag {
 			l.LogWithFields(ctxt).Error("Invalid/Expired X-Auth-Token")
 			ctx.StatusCode(http.StatusUnauthorized)
@@ -129,7 +125,7 @@ func StartUpdate(ctx iris.Context) {
 			return
 		}
 	}
-	var deviceDetails dpmodel.Device
+	var deviceDetails sdkmodel.Device
 	uri := ctx.Request().RequestURI
 	//Get device details from request
 	err := ctx.ReadJSON(&deviceDetails)
@@ -140,13 +136,13 @@ func StartUpdate(ctx iris.Context) {
 		return
 	}
 
-	device := &dputilities.RedfishDevice{
+	device := &sdkutilities.RedfishDevice{
 		Host:     deviceDetails.Host,
 		Username: deviceDetails.Username,
 		Password: string(deviceDetails.Password),
 	}
 
-	redfishClient, err := dputilities.GetRedfishClient()
+	redfishClient, err := sdkutilities.GetRedfishClient()
 	if err != nil {
 		errMsg := "While trying to create the redfish client, got:" + err.Error()
 		l.LogWithFields(ctxt).Error(errMsg)
@@ -9,8 +9,8 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/ODIM-Project/ODIM/plugin-dell/config"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dpresponse"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkresponse"
 	iris "github.com/kataras/iris/v12"
 	"github.com/kataras/iris/v12/httptest"
 )
@@ -32,7 +32,7 @@ func TestSimpleUpdate(t *testing.T) {
 	redfishRoutes := mockApp.Party("/ODIM/v1")
 
 	redfishRoutes.Post("/UpdateService/Actions.SimpleUpdate", SimpleUpdate)
-	dpresponse.PluginToken = "token"
+	sdkresponse.PluginToken = "token"
 	test := httptest.New(t, mockApp)
 	attributes := map[string]interface{}{"ImageUri": "abc",
 		"Targets": []string{"/ODIM/v1/Systems/uuid.1"}}
@@ -91,7 +91,7 @@ func TestStartUpdate(t *testing.T) {
 	redfishRoutes := mockApp.Party("/ODIM/v1")
 
 	redfishRoutes.Post("/UpdateService/Actions.StartUpdate", StartUpdate)
-	dpresponse.PluginToken = "token"
+	sdkresponse.PluginToken = "token"
 	test := httptest.New(t, mockApp)
 	requestBody := map[string]interface{}{
 		"ManagerAddress": fmt.Sprintf("%s:%s", deviceHost, devicePort),
@@ -14,315 +14,136 @@
 package main
 
 import (
-	"context"
-	"encoding/json"
-	"fmt"
-	"net"
-	"net/http"
-	"os"
-	"time"
-
-	dc "github.com/ODIM-Project/ODIM/lib-messagebus/datacommunicator"
-	"github.com/ODIM-Project/ODIM/lib-utilities/common"
-	lutilconf "github.com/ODIM-Project/ODIM/lib-utilities/config"
-	"github.com/ODIM-Project/ODIM/lib-utilities/logs"
-	"github.com/ODIM-Project/ODIM/plugin-dell/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkmiddleware"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkplugin"
 	"github.com/ODIM-Project/ODIM/plugin-dell/dphandler"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dpmessagebus"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dpmiddleware"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dpmodel"
-	"github.com/ODIM-Project/ODIM/plugin-dell/dputilities"
 	iris "github.com/kataras/iris/v12"
-	"github.com/sirupsen/logrus"
 )
 
-var subscriptionInfo []dpmodel.Device
-
-//TokenObject will contains the generated token and public key of odimra
-type TokenObject struct {
-	AuthToken string `json:"authToken"`
-	PublicKey []byte `json:"publicKey"`
-}
-
 func main() {
-	// intializing the plugin start time
-	hostName := os.Getenv("HOST_NAME")
-	podName := os.Getenv("POD_NAME")
-	pid := os.Getpid()
-	dputilities.PluginStartTime = time.Now()
-	log := logs.Log
-	logs.Adorn(logrus.Fields{
-		"host":   hostName,
-		"procid": podName + fmt.Sprintf("_%d", pid),
-	})
-	if err := config.SetConfiguration(); err != nil {
-		log.Logger.SetFormatter(&logs.SysLogFormatter{})
-		logs.Log.Fatal("Error while trying set up configuration: " + err.Error())
-	}
-	logs.SetFormatter(config.Data.LogFormat)
-	log.Logger.SetOutput(os.Stdout)
-	log.Logger.SetLevel(config.Data.LogLevel)
-	log.Info("Plugin Start time:", dputilities.PluginStartTime.Format(time.RFC3339))
-
-	// verifying the uid of the user
-	if uid := os.Geteuid(); uid == 0 {
-		log.Fatal("Plugin Service should not be run as the root user")
-	}
-
-	if err := dc.SetConfiguration(config.Data.MessageBusConf.MessageBusConfigFilePath); err != nil {
-		log.Fatal("While trying to set messagebus configuration, got: " + err.Error())
-	}
-
-	// CreateJobQueue defines the queue which will act as an infinite buffer
-	// In channel is an entry or input channel and the Out channel is an exit or output channel
-	jobQueueSize := 10
-	dphandler.In, dphandler.Out = common.CreateJobQueue(jobQueueSize)
-
-	// RunReadWorkers will create a worker pool for doing a specific task
-	// which is passed to it as Publish method after reading the data from the channel.
-
-	// should be removed when context from svc-api is passed to this function
-	ctx := context.TODO()
-	ctx = context.WithValue(ctx, common.ThreadID, common.DefaultThreadID)
-	go common.RunReadWorkers(ctx, dphandler.Out, dpmessagebus.Publish, 5)
-
-	configFilePath := os.Getenv("PLUGIN_CONFIG_FILE_PATH")
-	if configFilePath == "" {
-		log.Fatal("No value get the environment variable PLUGIN_CONFIG_FILE_PATH")
+	plugin := sdkplugin.Plugin{
+		ID:     "GRF",
+		Routes: routes,
 	}
-	errChan := make(chan error)
-
-	// TrackConfigFileChanges monitors the dell config changes using fsnotfiy
-	go dputilities.TrackIPConfigListener(configFilePath, errChan)
-
-	intializePluginStatus()
-	app()
-}
-
-func app() {
-	go sendStartupEvent()
-	go eventsrouters()
-
-	app := routers()
-	conf := &lutilconf.HTTPConfig{
-		Certificate:   &config.Data.KeyCertConf.Certificate,
-		PrivateKey:    &config.Data.KeyCertConf.PrivateKey,
-		CACertificate: &config.Data.KeyCertConf.RootCACertificate,
-		ServerAddress: config.Data.PluginConf.Host,
-		ServerPort:    config.Data.PluginConf.Port,
-	}
-	pluginServer, err := conf.GetHTTPServerObj()
-	if err != nil {
-		logs.Log.Fatal("While initializing plugin server: " + err.Error())
-	}
-	app.Run(iris.Server(pluginServer))
-}
-
-func routers() *iris.Application {
-	app := iris.New()
-	app.WrapRouter(func(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
-		ctx := getContextHeader(r)
-		r = r.WithContext(ctx)
-		path := r.URL.Path
-		if len(path) > 1 && path[len(path)-1] == '/' && path[len(path)-2] != '/' {
-			path = path[:len(path)-1]
-			r.RequestURI = path
-			r.URL.Path = path
-		}
-		next(w, r)
-	})
-
-	pluginRoutes := app.Party("/ODIM/v1")
-	{
-		pluginRoutes.Post("/validate", dpmiddleware.BasicAuth, dphandler.Validate)
-		pluginRoutes.Post("/Sessions", dphandler.CreateSession)
-		pluginRoutes.Post("/Subscriptions", dpmiddleware.BasicAuth, dphandler.CreateEventSubscription)
-		pluginRoutes.Delete("/Subscriptions", dpmiddleware.BasicAuth, dphandler.DeleteEventSubscription)
-
-		//Adding routes related to all system gets
-		systems := pluginRoutes.Party("/Systems", dpmiddleware.BasicAuth)
-		systems.Get("", dphandler.GetResource)
-		systems.Get("/{id}", dphandler.GetResource)
-		systems.Get("/{id}/Storage", dphandler.GetResource)
-		systems.Get("/{id}/Storage/{id2}", dphandler.GetResource)
-		systems.Get("/{id}/Storage/{id2}/Volumes", dphandler.GetResource)
-		systems.Post("/{id}/Storage/{id2}/Volumes", dphandler.CreateVolume)
-		systems.Get("/{id}/Storage/{id2}/Volumes/{rid}", dphandler.GetResource)
-		systems.Delete("/{id}/Storage/{id2}/Volumes/{rid}", dphandler.DeleteVolume)
-		systems.Get("/{id}/Storage/{id2}/Drives/{rid}", dphandler.GetResource)
-		systems.Get("/{id}/BootOptions", dphandler.GetResource)
-		systems.Get("/{id}/BootOptions/{rid}", dphandler.GetResource)
-		systems.Get("/{id}/Processors", dphandler.GetResource)
-		systems.Get("/{id}/Processors/{rid}", dphandler.GetResource)
-		systems.Get("/{id}/LogServices", dphandler.GetResource)
-		systems.Get("/{id}/LogServices/{rid}", dphandler.GetResource)
-		systems.Get("/{id}/LogServices/{rid}/Entries", dphandler.GetResource)
-		systems.Get("/{id}/LogServices/{rid}/Entries/{rid2}", dphandler.GetResource)
-		systems.Post("/{id}/LogServices/{rid}/Actions/LogService.ClearLog", dphandler.GetResource)
-		systems.Get("/{id}/Memory", dphandler.GetResource)
-		systems.Get("/{id}/Memory/{rid}", dphandler.GetResource)
-		systems.Get("/{id}/NetworkInterfaces", dphandler.GetResource)
-		systems.Get("/{id}/MemoryDomains", dphandler.GetResource)
-		systems.Get("/{id}/EthernetInterfaces", dphandler.GetResource)
-		systems.Get("/{id}/EthernetInterfaces/{rid}", dphandler.GetResource)
-		systems.Get("/{id}/SecureBoot", dphandler.GetResource)
-		systems.Get("/{id}/EthernetInterfaces/{id2}/VLANS", dphandler.GetResource)
-		systems.Get("/{id}/EthernetInterfaces/{id2}/VLANS/{rid}", dphandler.GetResource)
-		systems.Get("/{id}/NetworkInterfaces/{rid}", dphandler.GetResource)
-		systems.Get("/{id}/PCIeDevices/{rid}", dphandler.GetResource)
-		systems.Patch("/{id}", dphandler.ChangeSettings)
-
-		systemsAction := systems.Party("/{id}/Actions")
-		systemsAction.Post("/ComputerSystem.Reset", dphandler.ResetComputerSystem)
-		systemsAction.Post("/ComputerSystem.SetDefaultBootOrder", dphandler.SetDefaultBootOrder)
-
-		biosParty := systems.Party("/{id}/Bios")
-		biosParty.Get("/", dphandler.GetResource)
-		biosParty.Get("/Settings", dphandler.GetResource)
-		biosParty.Patch("/Settings", dphandler.ChangeSettings)
-
-		chassis := pluginRoutes.Party("/Chassis")
-		chassis.Get("", dphandler.GetResource)
-		chassis.Get("/{id}", dphandler.GetResource)
-		chassis.Get("/{id}/NetworkAdapters", dphandler.GetResource)
-		chassis.Get("/{id}/NetworkAdapters/{rid}", dphandler.GetResource)
-		chassis.Get("/{id}/NetworkAdapters/{rid}/NetworkDeviceFunctions", dphandler.GetResource)
-		chassis.Get("/{id}/NetworkAdapters/{rid}/NetworkPorts", dphandler.GetResource)
-		chassis.Get("/{id}/NetworkAdapters/{rid}/NetworkDeviceFunctions/{id2}", dphandler.GetResource)
-		chassis.Get("/{id}/NetworkAdapters/{rid}/NetworkPorts/{id2}", dphandler.GetResource)
-		chassis.Get("/{id}/Assembly", dphandler.GetResource)
-		chassis.Get("/{id}/PCIeSlots", dphandler.GetResource)
-		chassis.Get("/{id}/PCIeSlots/{rid}", dphandler.GetResource)
-		chassis.Get("/{id}/PCIeDevices", dphandler.GetResource)
-		chassis.Get("/{id}/PCIeDevices/{rid}", dphandler.GetResource)
-		chassis.Get("/{id}/Sensors", dphandler.GetResource)
-		chassis.Get("/{id}/Sensors/{rid}", dphandler.GetResource)
-		chassis.Get("/{id}/LogServices", dphandler.GetResource)
-		chassis.Get("/{id}/LogServices/{rid}", dphandler.GetResource)
-		chassis.Get("/{id}/LogServices/{rid}/Entries", dphandler.GetResource)
-		chassis.Get("/{id}/LogServices/{rid}/Entries/{rid2}", dphandler.GetResource)
-
-		// Chassis Power URl routes
-		chassisPower := chassis.Party("/{id}/Power")
-		chassisPower.Get("/", dphandler.GetResource)
-		chassisPower.Get("#PowerControl/{id1}", dphandler.GetResource)
-		chassisPower.Get("#PowerSupplies/{id1}", dphandler.GetResource)
-		chassisPower.Get("#Redundancy/{id1}", dphandler.GetResource)
-
-		// Chassis Thermal Url Routes
-		chassisThermal := chassis.Party("/{id}/Thermal")
-		chassisThermal.Get("/", dphandler.GetResource)
-		chassisThermal.Get("#Fans/{id1}", dphandler.GetResource)
-		chassisThermal.Get("#Temperatures/{id1}", dphandler.GetResource)
-
-		// Manager routers
-		managers := pluginRoutes.Party("/Managers", dpmiddleware.BasicAuth)
-		managers.Get("", dphandler.GetManagersCollection)
-		managers.Get("/{id}", dphandler.GetManagersInfo)
-		managers.Get("/{id}/EthernetInterfaces", dphandler.GetResource)
-		managers.Get("/{id}/EthernetInterfaces/{rid}", dphandler.GetResource)
-		managers.Get("/{id}/NetworkProtocol", dphandler.GetResource)
-		managers.Get("/{id}/NetworkProtocol/{rid}", dphandler.GetResource)
-		managers.Get("/{id}/HostInterfaces", dphandler.GetResource)
-		managers.Get("/{id}/HostInterfaces/{rid}", dphandler.GetResource)
-		managers.Get("/{id}/VirtualMedia", dphandler.GetResource)
-		managers.Get("/{id}/VirtualMedia/{rid}", dphandler.GetResource)
-		managers.Post("/{id}/VirtualMedia/{rid}/Actions/VirtualMedia.EjectMedia", dphandler.VirtualMediaActions)
-		managers.Post("/{id}/VirtualMedia/{rid}/Actions/VirtualMedia.InsertMedia", dphandler.VirtualMediaActions)
-		managers.Get("/{id}/LogServices", dphandler.GetResource)
-		managers.Get("/{id}/LogServices/{rid}", dphandler.GetResource)
-		managers.Get("/{id}/LogServices/{rid}/Entries", dphandler.GetResource)
-		managers.Get("/{id}/LogServices/{rid}/Entries/{rid2}", dphandler.GetResource)
-		managers.Post("/{id}/LogServices/{rid}/Actions/LogService.ClearLog", dphandler.GetResource)
-
-		//Registries routers
-		registries := pluginRoutes.Party("/Registries", dpmiddleware.BasicAuth)
-		registries.Get("", dphandler.GetResource)
-		registries.Get("/{id}", dphandler.GetResource)
-
-		registryStore := pluginRoutes.Party("/registrystore", dpmiddleware.BasicAuth)
-		registryStore.Get("/registries/en/{id}", dphandler.GetResource)
-
-		registryStoreCap := pluginRoutes.Party("/RegistryStore", dpmiddleware.BasicAuth)
-		registryStoreCap.Get("/registries/en/{id}", dphandler.GetResource)
-
-		// Routes related to Update service
-		update := pluginRoutes.Party("/UpdateService", dpmiddleware.BasicAuth)
-		update.Post("/Actions/UpdateService.SimpleUpdate", dphandler.SimpleUpdate)
-		update.Post("/Actions/UpdateService.StartUpdate", dphandler.StartUpdate)
-		update.Get("/FirmwareInventory", dphandler.GetResource)
-		update.Get("/FirmwareInventory/{id}", dphandler.GetResource)
-		update.Get("/SoftwareInventory", dphandler.GetResource)
-		update.Get("/SoftwareInventory/{id}", dphandler.GetResource)
-	}
-	pluginRoutes.Get("/Status", dphandler.GetPluginStatus)
-	pluginRoutes.Post("/Startup", dpmiddleware.BasicAuth, dphandler.GetPluginStartup)
-	return app
-}
-
-func eventsrouters() {
-	conf := &lutilconf.HTTPConfig{
-		Certificate:   &config.Data.KeyCertConf.Certificate,
-		PrivateKey:    &config.Data.KeyCertConf.PrivateKey,
-		CACertificate: &config.Data.KeyCertConf.RootCACertificate,
-		ServerAddress: config.Data.EventConf.ListenerHost,
-		ServerPort:    config.Data.EventConf.ListenerPort,
-	}
-	evtServer, err := conf.GetHTTPServerObj()
-	if err != nil {
-		logs.Log.Fatalf("fatal: error while initializing event server: %v", err)
-	}
-	mux := http.NewServeMux()
-	mux.HandleFunc(config.Data.EventConf.DestURI, dphandler.RedfishEvents)
-	evtServer.Handler = mux
-	logs.Log.Fatal(evtServer.ListenAndServeTLS("", ""))
-}
-
-// intializePluginStatus sets plugin status
-func intializePluginStatus() {
-	dputilities.Status.Available = "yes"
-}
-
-// sendStartupEvent is for sending startup event
-func sendStartupEvent() {
-	// grace wait time for plugin to be functional
-	time.Sleep(3 * time.Second)
-
-	var pluginIP string
-	if pluginIP = os.Getenv("ASSIGNED_POD_IP"); pluginIP == "" {
-		pluginIP = config.Data.PluginConf.Host
-	}
-
-	startupEvt := common.PluginStatusEvent{
-		Name:         "Plugin startup event",
-		Type:         "PluginStarted",
-		Timestamp:    time.Now().String(),
-		OriginatorID: pluginIP,
-	}
-
-	request, _ := json.Marshal(startupEvt)
-	event := common.Events{
-		IP:        net.JoinHostPort(config.Data.PluginConf.Host, config.Data.PluginConf.Port),
-		Request:   request,
-		EventType: "PluginStartUp",
-	}
-
-	done := make(chan bool)
-	events := []interface{}{event}
-	go common.RunWriteWorkers(dphandler.In, events, 1, done)
-	logs.Log.Info("successfully sent startup event")
-
+	plugin.Run()
 }
 
-// getContextHeader is used to get context details from header for logging
-func getContextHeader(r *http.Request) context.Context {
-	ctx := context.Background()
-	podName := os.Getenv("POD_NAME")
-	ctx = context.WithValue(ctx, common.TransactionID, r.Header.Get(common.TransactionID))
-	ctx = context.WithValue(ctx, common.ThreadID, r.Header.Get(common.ThreadID))
-	ctx = context.WithValue(ctx, common.ThreadName, r.Header.Get(common.ThreadName))
-	ctx = context.WithValue(ctx, common.ActionID, r.Header.Get(common.ActionID))
-	ctx = context.WithValue(ctx, common.ActionName, r.Header.Get(common.ActionName))
-	ctx = context.WithValue(ctx, common.ProcessName, r.Header.Get(podName))
-	return ctx
+// routes registers the Dell specific routes, the common plugin routes
+// are registered by sdkplugin
+func routes(pluginRoutes iris.Party) {
+	//Adding routes related to all system gets
+	systems := pluginRoutes.Party("/Systems", sdkmiddleware.BasicAuth)
+	systems.Get("", dphandler.GetResource)
+	systems.Get("/{id}", dphandler.GetResource)
+	systems.Get("/{id}/Storage", dphandler.GetResource)
+	systems.Get("/{id}/Storage/{id2}", dphandler.GetResource)
+	systems.Get("/{id}/Storage/{id2}/Volumes", dphandler.GetResource)
+	systems.Post("/{id}/Storage/{id2}/Volumes", dphandler.CreateVolume)
+	systems.Get("/{id}/Storage/{id2}/Volumes/{rid}", dphandler.GetResource)
+	systems.Delete("/{id}/Storage/{id2}/Volumes/{rid}", dphandler.DeleteVolume)
+	systems.Get("/{id}/Storage/{id2}/Drives/{rid}", dphandler.GetResource)
+	systems.Get("/{id}/BootOptions", dphandler.GetResource)
+	systems.Get("/{id}/BootOptions/{rid}", dphandler.GetResource)
+	systems.Get("/{id}/Processors", dphandler.GetResource)
+	systems.Get("/{id}/Processors/{rid}", dphandler.GetResource)
+	systems.Get("/{id}/LogServices", dphandler.GetResource)
+	systems.Get("/{id}/LogServices/{rid}", dphandler.GetResource)
+	systems.Get("/{id}/LogServices/{rid}/Entries", dphandler.GetResource)
+	systems.Get("/{id}/LogServices/{rid}/Entries/{rid2}", dphandler.GetResource)
+	systems.Post("/{id}/LogServices/{rid}/Actions/LogService.ClearLog", dphandler.GetResource)
+	systems.Get("/{id}/Memory", dphandler.GetResource)
+	systems.Get("/{id}/Memory/{rid}", dphandler.GetResource)
+	systems.Get("/{id}/NetworkInterfaces", dphandler.GetResource)
+	systems.Get("/{id}/MemoryDomains", dphandler.GetResource)
+	systems.Get("/{id}/EthernetInterfaces", dphandler.GetResource)
+	systems.Get("/{id}/EthernetInterfaces/{rid}", dphandler.GetResource)
+	systems.Get("/{id}/SecureBoot", dphandler.GetResource)
+	systems.Get("/{id}/EthernetInterfaces/{id2}/VLANS", dphandler.GetResource)
+	systems.Get("/{id}/EthernetInterfaces/{id2}/VLANS/{rid}", dphandler.GetResource)
+	systems.Get("/{id}/NetworkInterfaces/{rid}", dphandler.GetResource)
+	systems.Get("/{id}/PCIeDevices/{rid}", dphandler.GetResource)
+	systems.Patch("/{id}", dphandler.ChangeSettings)
+
+	systemsAction := systems.Party("/{id}/Actions")
+	systemsAction.Post("/ComputerSystem.Reset", dphandler.ResetComputerSystem)
+	systemsAction.Post("/ComputerSystem.SetDefaultBootOrder", dphandler.SetDefaultBootOrder)
+
+	biosParty := systems.Party("/{id}/Bios")
+	biosParty.Get("/", dphandler.GetResource)
+	biosParty.Get("/Settings", dphandler.GetResource)
+	biosParty.Patch("/Settings", dphandler.ChangeSettings)
+
+	chassis := pluginRoutes.Party("/Chassis")
+	chassis.Get("", dphandler.GetResource)
+	chassis.Get("/{id}", dphandler.GetResource)
+	chassis.Get("/{id}/NetworkAdapters", dphandler.GetResource)
+	chassis.Get("/{id}/NetworkAdapters/{rid}", dphandler.GetResource)
+	chassis.Get("/{id}/NetworkAdapters/{rid}/NetworkDeviceFunctions", dphandler.GetResource)
+	chassis.Get("/{id}/NetworkAdapters/{rid}/NetworkPorts", dphandler.GetResource)
+	chassis.Get("/{id}/NetworkAdapters/{rid}/NetworkDeviceFunctions/{id2}", dphandler.GetResource)
+	chassis.Get("/{id}/NetworkAdapters/{rid}/NetworkPorts/{id2}", dphandler.GetResource)
+	chassis.Get("/{id}/Assembly", dphandler.GetResource)
+	chassis.Get("/{id}/PCIeSlots", dphandler.GetResource)
+	chassis.Get("/{id}/PCIeSlots/{rid}", dphandler.GetResource)
+	chassis.Get("/{id}/PCIeDevices", dphandler.GetResource)
+	chassis.Get("/{id}/PCIeDevices/{rid}", dphandler.GetResource)
+	chassis.Get("/{id}/Sensors", dphandler.GetResource)
+	chassis.Get("/{id}/Sensors/{rid}", dphandler.GetResource)
+	chassis.Get("/{id}/LogServices", dphandler.GetResource)
+	chassis.Get("/{id}/LogServices/{rid}", dphandler.GetResource)
+	chassis.Get("/{id}/LogServices/{rid}/Entries", dphandler.GetResource)
+	chassis.Get("/{id}/LogServices/{rid}/Entries/{rid2}", dphandler.GetResource)
+
+	// Chassis Power URl routes
+	chassisPower := chassis.Party("/{id}/Power")
+	chassisPower.Get("/", dphandler.GetResource)
+	chassisPower.Get("#PowerControl/{id1}", dphandler.GetResource)
+	chassisPower.Get("#PowerSupplies/{id1}", dphandler.GetResource)
+	chassisPower.Get("#Redundancy/{id1}", dphandler.GetResource)
+
+	// Chassis Thermal Url Routes
+	chassisThermal := chassis.Party("/{id}/Thermal")
+	chassisThermal.Get("/", dphandler.GetResource)
+	chassisThermal.Get("#Fans/{id1}", dphandler.GetResource)
+	chassisThermal.Get("#Temperatures/{id1}", dphandler.GetResource)
+
+	// Manager routers
+	managers := pluginRoutes.Party("/Managers", sdkmiddleware.BasicAuth)
+	managers.Get("", dphandler.GetManagersCollection)
+	managers.Get("/{id}", dphandler.GetManagersInfo)
+	managers.Get("/{id}/EthernetInterfaces", dphandler.GetResource)
+	managers.Get("/{id}/EthernetInterfaces/{rid}", dphandler.GetResource)
+	managers.Get("/{id}/NetworkProtocol", dphandler.GetResource)
+	managers.Get("/{id}/NetworkProtocol/{rid}", dphandler.GetResource)
+	managers.Get("/{id}/HostInterfaces", dphandler.GetResource)
+	managers.Get("/{id}/HostInterfaces/{rid}", dphandler.GetResource)
+	managers.Get("/{id}/VirtualMedia", dphandler.GetResource)
+	managers.Get("/{id}/VirtualMedia/{rid}", dphandler.GetResource)
+	managers.Post("/{id}/VirtualMedia/{rid}/Actions/VirtualMedia.EjectMedia", dphandler.VirtualMediaActions)
+	managers.Post("/{id}/VirtualMedia/{rid}/Actions/VirtualMedia.InsertMedia", dphandler.VirtualMediaActions)
+	managers.Get("/{id}/LogServices", dphandler.GetResource)
+	managers.Get("/{id}/LogServices/{rid}", dphandler.GetResource)
+	managers.Get("/{id}/LogServices/{rid}/Entries", dphandler.GetResource)
+	managers.Get("/{id}/LogServices/{rid}/Entries/{rid2}", dphandler.GetResource)
+	managers.Post("/{id}/LogServices/{rid}/Actions/LogService.ClearLog", dphandler.GetResource)
+
+	//Registries routers
+	registries := pluginRoutes.Party("/Registries", sdkmiddleware.BasicAuth)
+	registries.Get("", dphandler.GetResource)
+	registries.Get("/{id}", dphandler.GetResource)
+
+	registryStore := pluginRoutes.Party("/registrystore", sdkmiddleware.BasicAuth)
+	registryStore.Get("/registries/en/{id}", dphandler.GetResource)
+
+	registryStoreCap := pluginRoutes.Party("/RegistryStore", sdkmiddleware.BasicAuth)
+	registryStoreCap.Get("/registries/en/{id}", dphandler.GetResource)
+
+	// Routes related to Update service
+	update := pluginRoutes.Party("/UpdateService", sdkmiddleware.BasicAuth)
+	update.Post("/Actions/UpdateService.SimpleUpdate", dphandler.SimpleUpdate)
+	update.Post("/Actions/UpdateService.StartUpdate", dphandler.StartUpdate)
+	update.Get("/FirmwareInventory", dphandler.GetResource)
+	update.Get("/FirmwareInventory/{id}", dphandler.GetResource)
+	update.Get("/SoftwareInventory", dphandler.GetResource)
+	update.Get("/SoftwareInventory/{id}", dphandler.GetResource)
 }
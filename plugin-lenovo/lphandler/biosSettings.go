@@ -16,20 +16,20 @@
 package lphandler
 
 import (
-	log "github.com/sirupsen/logrus"
 	"io/ioutil"
 	"net/http"
+	"strings"
 
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-lenovo/config"
-	"github.com/ODIM-Project/ODIM/plugin-lenovo/lpmodel"
-	"github.com/ODIM-Project/ODIM/plugin-lenovo/lputilities"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkhandler"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkmodel"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
 	iris "github.com/kataras/iris/v12"
-	"strings"
+	log "github.com/sirupsen/logrus"
 )
 
 // DeviceClient struct to call device for the operation
 type DeviceClient struct {
-	ChangeSettingsOnDevice func(device *lputilities.RedfishDevice, url, method string) (*http.Response, error)
+	ChangeSettingsOnDevice func(device *sdkutilities.RedfishDevice, url, method string) (*http.Response, error)
 	DecryptPassword        func(password []byte) ([]byte, error)
 }
 
@@ -41,12 +41,10 @@ func ChangeSettings(ctx iris.Context) {
 	token := ctx.GetHeader("X-Auth-Token")
 	uri := ctx.Request().RequestURI
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	}
+	uri = sdkhandler.TranslateToSouthBoundURL(uri)
 	//Validating the token
 	if token != "" {
-		flag := TokenValidation(token)
+		flag := sdkhandler.TokenValidation(token)
 		if !flag {
 			log.Error("Invalid/Expired X-Auth-Token")
 			ctx.StatusCode(http.StatusUnauthorized)
@@ -55,7 +53,7 @@ func ChangeSettings(ctx iris.Context) {
 		}
 	}
 
-	var deviceDetails lpmodel.Device
+	var deviceDetails sdkmodel.Device
 
 	//Get device details from request
 	err := ctx.ReadJSON(&deviceDetails)
@@ -66,14 +64,14 @@ func ChangeSettings(ctx iris.Context) {
 		ctx.WriteString(errorMessage)
 		return
 	}
-	device := &lputilities.RedfishDevice{
+	device := &sdkutilities.RedfishDevice{
 		Host:     deviceDetails.Host,
 		Username: deviceDetails.Username,
 		Password: string(deviceDetails.Password),
 		PostBody: deviceDetails.PostBody,
 	}
 
-	redfishClient, err := lputilities.GetRedfishClient()
+	redfishClient, err := sdkutilities.GetRedfishClient()
 	if err != nil {
 		errMsg := "While trying to create the redfish client, got:" + err.Error()
 		log.Error(errMsg)
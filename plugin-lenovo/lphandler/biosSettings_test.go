@@ -21,7 +21,6 @@ import (
 	"crypto/x509"
 	"encoding/base64"
 	"fmt"
-	log "github.com/sirupsen/logrus"
 	"io/ioutil"
 	"net"
 	"net/http"
@@ -29,10 +28,11 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/ODIM-Project/ODIM/plugin-lenovo/config"
-	"github.com/ODIM-Project/ODIM/plugin-lenovo/lpresponse"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkresponse"
 	iris "github.com/kataras/iris/v12"
 	"github.com/kataras/iris/v12/httptest"
+	log "github.com/sirupsen/logrus"
 )
 
 var (
@@ -260,7 +260,7 @@ func TestChangeBootOrderSettings(t *testing.T) {
 
 	redfishRoutes.Patch("/Systems/{id}", ChangeSettings)
 
-	lpresponse.PluginToken = "token"
+	sdkresponse.PluginToken = "token"
 
 	e := httptest.New(t, mockApp)
 
@@ -295,7 +295,7 @@ func TestChangeBiosSettings(t *testing.T) {
 
 	redfishRoutes.Patch("/Systems/{id}/bios/settings", ChangeSettings)
 
-	lpresponse.PluginToken = "token"
+	sdkresponse.PluginToken = "token"
 
 	e := httptest.New(t, mockApp)
 
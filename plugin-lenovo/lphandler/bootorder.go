@@ -16,14 +16,14 @@
 package lphandler
 
 import (
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-lenovo/config"
-	"github.com/ODIM-Project/ODIM/plugin-lenovo/lpmodel"
-	"github.com/ODIM-Project/ODIM/plugin-lenovo/lputilities"
-	iris "github.com/kataras/iris/v12"
-	log "github.com/sirupsen/logrus"
 	"io/ioutil"
 	"net/http"
-	"strings"
+
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkhandler"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkmodel"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
+	iris "github.com/kataras/iris/v12"
+	log "github.com/sirupsen/logrus"
 )
 
 // SetDefaultBootOrder : sets the defult boot order
@@ -33,7 +33,7 @@ func SetDefaultBootOrder(ctx iris.Context) {
 	token := ctx.GetHeader("X-Auth-Token")
 	//Validating the token
 	if token != "" {
-		flag := TokenValidation(token)
+		flag := sdkhandler.TokenValidation(token)
 		if !flag {
 			log.Error("Invalid/Expired X-Auth-Token")
 			ctx.StatusCode(http.StatusUnauthorized)
@@ -42,12 +42,10 @@ func SetDefaultBootOrder(ctx iris.Context) {
 		}
 	}
 
-	var deviceDetails lpmodel.Device
+	var deviceDetails sdkmodel.Device
 	uri := ctx.Request().RequestURI
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	}
+	uri = sdkhandler.TranslateToSouthBoundURL(uri)
 	//Get device details from request
 	err := ctx.ReadJSON(&deviceDetails)
 	if err != nil {
@@ -58,13 +56,13 @@ func SetDefaultBootOrder(ctx iris.Context) {
 		return
 	}
 
-	device := &lputilities.RedfishDevice{
+	device := &sdkutilities.RedfishDevice{
 		Host:     deviceDetails.Host,
 		Username: deviceDetails.Username,
 		Password: string(deviceDetails.Password),
 	}
 
-	redfishClient, err := lputilities.GetRedfishClient()
+	redfishClient, err := sdkutilities.GetRedfishClient()
 	if err != nil {
 		errMsg := "While trying to create the redfish client, got:" + err.Error()
 		log.Error(errMsg)
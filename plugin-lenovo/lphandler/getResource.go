@@ -16,19 +16,17 @@
 package lphandler
 
 import (
+	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"strings"
 
-	"encoding/json"
-
-	log "github.com/sirupsen/logrus"
-
 	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-lenovo/config"
-	"github.com/ODIM-Project/ODIM/plugin-lenovo/lpmodel"
-	"github.com/ODIM-Project/ODIM/plugin-lenovo/lputilities"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkhandler"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkmodel"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
 	iris "github.com/kataras/iris/v12"
+	log "github.com/sirupsen/logrus"
 )
 
 //GetResource : Fetches details of the given resource from the device
@@ -37,11 +35,9 @@ func GetResource(ctx iris.Context) {
 	token := ctx.GetHeader("X-Auth-Token")
 	uri := ctx.Request().RequestURI
 	//replacing the reuest url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	} //Validating the token
+	uri = sdkhandler.TranslateToSouthBoundURL(uri) //Validating the token
 	if token != "" {
-		flag := TokenValidation(token)
+		flag := sdkhandler.TokenValidation(token)
 		if !flag {
 			log.Error("Invalid/Expired X-Auth-Token")
 			ctx.StatusCode(http.StatusUnauthorized)
@@ -50,7 +46,7 @@ func GetResource(ctx iris.Context) {
 		}
 	}
 
-	var deviceDetails lpmodel.Device
+	var deviceDetails sdkmodel.Device
 
 	//Get device details from request
 	err := ctx.ReadJSON(&deviceDetails)
@@ -62,14 +58,14 @@ func GetResource(ctx iris.Context) {
 		return
 	}
 
-	device := &lputilities.RedfishDevice{
+	device := &sdkutilities.RedfishDevice{
 		Host:     deviceDetails.Host,
 		Username: deviceDetails.Username,
 		Password: string(deviceDetails.Password),
 	}
-	//plainText, err := lputilities.GetPlainText(device.Password)
+	//plainText, err := sdkutilities.GetPlainText(device.Password)
 	//device.Password = plainText
-	redfishClient, err := lputilities.GetRedfishClient()
+	redfishClient, err := sdkutilities.GetRedfishClient()
 	if err != nil {
 		errMsg := "While trying to create the redfish client, got:" + err.Error()
 		log.Error(errMsg)
@@ -115,9 +111,7 @@ func GetResource(ctx iris.Context) {
 	}
 	respData := string(body)
 	//replacing the resposne with north bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.NorthBoundURL {
-		respData = strings.Replace(respData, key, value, -1)
-	}
+	respData = sdkhandler.TranslateToNorthBoundURL(respData)
 
 	respData = strings.Replace(respData, "/Bios/Pending", "/Bios/Settings", -1)
 
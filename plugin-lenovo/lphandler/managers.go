@@ -22,10 +22,12 @@ import (
 	"strings"
 
 	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	pluginConfig "github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkhandler"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkmodel"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-lenovo/config"
 	"github.com/ODIM-Project/ODIM/plugin-lenovo/lpmodel"
-	"github.com/ODIM-Project/ODIM/plugin-lenovo/lputilities"
 	iris "github.com/kataras/iris/v12"
 	log "github.com/sirupsen/logrus"
 )
@@ -37,7 +39,7 @@ func GetManagersCollection(ctx iris.Context) {
 	uri := ctx.Request().RequestURI
 	//Validating the token
 	if token != "" {
-		flag := TokenValidation(token)
+		flag := sdkhandler.TokenValidation(token)
 		if !flag {
 			log.Error("Invalid/Expired X-Auth-Token")
 			ctx.StatusCode(http.StatusUnauthorized)
@@ -45,7 +47,7 @@ func GetManagersCollection(ctx iris.Context) {
 			return
 		}
 	}
-	var deviceDetails lpmodel.Device
+	var deviceDetails sdkmodel.Device
 	// if any error come while getting the device then request will be for  plugins manager
 	ctx.ReadJSON(&deviceDetails)
 	if deviceDetails.Host == "" {
@@ -82,7 +84,7 @@ func GetManagersInfo(ctx iris.Context) {
 
 	//Validating the token
 	if token != "" {
-		flag := TokenValidation(token)
+		flag := sdkhandler.TokenValidation(token)
 		if !flag {
 			log.Error("Invalid/Expired X-Auth-Token")
 			ctx.StatusCode(http.StatusUnauthorized)
@@ -90,7 +92,7 @@ func GetManagersInfo(ctx iris.Context) {
 			return
 		}
 	}
-	var deviceDetails lpmodel.Device
+	var deviceDetails sdkmodel.Device
 	// if any error come while getting the device then request will be for  plugins manager
 	ctx.ReadJSON(&deviceDetails)
 	if deviceDetails.Host == "" {
@@ -117,17 +119,15 @@ func GetManagersInfo(ctx iris.Context) {
 
 }
 
-func getInfoFromDevice(uri string, deviceDetails lpmodel.Device, ctx iris.Context) {
+func getInfoFromDevice(uri string, deviceDetails sdkmodel.Device, ctx iris.Context) {
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	}
-	device := &lputilities.RedfishDevice{
+	uri = sdkhandler.TranslateToSouthBoundURL(uri)
+	device := &sdkutilities.RedfishDevice{
 		Host:     deviceDetails.Host,
 		Username: deviceDetails.Username,
 		Password: string(deviceDetails.Password),
 	}
-	redfishClient, err := lputilities.GetRedfishClient()
+	redfishClient, err := sdkutilities.GetRedfishClient()
 	if err != nil {
 		errMsg := "While trying to create the redfish client, got:" + err.Error()
 		log.Error(errMsg)
@@ -168,9 +168,7 @@ func getInfoFromDevice(uri string, deviceDetails lpmodel.Device, ctx iris.Contex
 	}
 	respData := string(body)
 	//replacing the resposne with north bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.NorthBoundURL {
-		respData = strings.Replace(respData, key, value, -1)
-	}
+	respData = sdkhandler.TranslateToNorthBoundURL(respData)
 	ctx.StatusCode(resp.StatusCode)
 	ctx.Write([]byte(respData))
 }
@@ -179,10 +177,8 @@ func getInfoFromDevice(uri string, deviceDetails lpmodel.Device, ctx iris.Contex
 func VirtualMediaActions(ctx iris.Context) {
 	uri := ctx.Request().RequestURI
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	}
-	var deviceDetails lpmodel.Device
+	uri = sdkhandler.TranslateToSouthBoundURL(uri)
+	var deviceDetails sdkmodel.Device
 	//Get device details from request
 	err := ctx.ReadJSON(&deviceDetails)
 	if err != nil {
@@ -191,7 +187,7 @@ func VirtualMediaActions(ctx iris.Context) {
 		ctx.WriteString("Error: bad request.")
 		return
 	}
-	device := &lputilities.RedfishDevice{
+	device := &sdkutilities.RedfishDevice{
 		Host:     deviceDetails.Host,
 		Username: deviceDetails.Username,
 		Password: string(deviceDetails.Password),
@@ -212,7 +208,7 @@ func VirtualMediaActions(ctx iris.Context) {
 		}
 	}
 	uri = convertToSouthBoundURI(uri)
-	statusCode, _, body, err := queryDevice(uri, device, http.MethodPatch)
+	statusCode, _, body, err := sdkhandler.QueryDevice(ctx.Request().Context(), uri, device, http.MethodPatch)
 	if err != nil {
 		errMsg := "while performing actions on virtual media, got: " + err.Error()
 		log.Error(errMsg)
@@ -22,9 +22,10 @@ import (
 	"testing"
 	"time"
 
-	"github.com/ODIM-Project/ODIM/plugin-lenovo/config"
-	"github.com/ODIM-Project/ODIM/plugin-lenovo/lpmodel"
-	"github.com/ODIM-Project/ODIM/plugin-lenovo/lpresponse"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkhandler"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkmodel"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkresponse"
 	iris "github.com/kataras/iris/v12"
 	"github.com/kataras/iris/v12/httptest"
 )
@@ -34,8 +35,8 @@ func mockManagers(username, password, url string, w http.ResponseWriter) {
 
 	insertURL := "/redfish/v1/Managers/1/VirtualMedia/1"
 	ejectURL := "/redfish/v1/Managers/1/VirtualMedia/1"
-	insertURL = translateToSouthBoundURL(insertURL)
-	ejectURL = translateToSouthBoundURL(ejectURL)
+	insertURL = sdkhandler.TranslateToSouthBoundURL(insertURL)
+	ejectURL = sdkhandler.TranslateToSouthBoundURL(ejectURL)
 
 	if url == insertURL && username == "admin" {
 		e, _ := json.Marshal(body)
@@ -66,7 +67,7 @@ func mockManagers(username, password, url string, w http.ResponseWriter) {
 
 func TestGetManagerCollection(t *testing.T) {
 	url := "/redfish/v1"
-	url = translateToSouthBoundURL(url)
+	url = sdkhandler.TranslateToSouthBoundURL(url)
 
 	config.SetUpMockConfig(t)
 	mockApp := iris.New()
@@ -74,10 +75,10 @@ func TestGetManagerCollection(t *testing.T) {
 
 	redfishRoutes.Get("/Managers", GetManagersCollection)
 
-	lpresponse.PluginToken = "token"
+	sdkresponse.PluginToken = "token"
 	e := httptest.New(t, mockApp)
 
-	var deviceDetails = lpmodel.Device{
+	var deviceDetails = sdkmodel.Device{
 		Host: "",
 	}
 	managerURL := url + "/Managers"
@@ -91,16 +92,16 @@ func TestGetManagerCollection(t *testing.T) {
 
 func TestGetManager(t *testing.T) {
 	url := "/redfish/v1"
-	url = translateToSouthBoundURL(url)
+	url = sdkhandler.TranslateToSouthBoundURL(url)
 	config.SetUpMockConfig(t)
 	mockApp := iris.New()
 	redfishRoutes := mockApp.Party(url)
 	redfishRoutes.Get("/Managers", GetManagersInfo)
 
-	lpresponse.PluginToken = "token"
+	sdkresponse.PluginToken = "token"
 	e := httptest.New(t, mockApp)
 
-	var deviceDetails = lpmodel.Device{
+	var deviceDetails = sdkmodel.Device{
 		Host: "",
 	}
 	managerURL := url + "/Managers"
@@ -125,7 +126,7 @@ func TestVirtualMediaActions(t *testing.T) {
 	redfishRoutes := mockApp.Party("/redfish/v1")
 	redfishRoutes.Post("/Managers/1/VirtualMedia/1/Actions/VirtualMedia.InsertMedia", VirtualMediaActions)
 	redfishRoutes.Post("/Managers/1/VirtualMedia/1/Actions/VirtualMedia.EjectMedia", VirtualMediaActions)
-	lpresponse.PluginToken = "token"
+	sdkresponse.PluginToken = "token"
 
 	test := httptest.New(t, mockApp)
 	attributes := map[string]interface{}{"Image": "abc"}
@@ -23,12 +23,12 @@ import (
 	"strings"
 
 	"github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkhandler"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkmodel"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkresponse"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-lenovo/config"
-	"github.com/ODIM-Project/ODIM/plugin-lenovo/lpmodel"
-	"github.com/ODIM-Project/ODIM/plugin-lenovo/lpresponse"
-	"github.com/ODIM-Project/ODIM/plugin-lenovo/lputilities"
 	iris "github.com/kataras/iris/v12"
 	log "github.com/sirupsen/logrus"
 )
@@ -40,7 +40,7 @@ func ResetComputerSystem(ctx iris.Context) {
 	token := ctx.GetHeader("X-Auth-Token")
 	//Validating the token
 	if token != "" {
-		flag := TokenValidation(token)
+		flag := sdkhandler.TokenValidation(token)
 		if !flag {
 			log.Error("Invalid/Expired X-Auth-Token")
 			ctx.StatusCode(http.StatusUnauthorized)
@@ -48,12 +48,10 @@ func ResetComputerSystem(ctx iris.Context) {
 			return
 		}
 	}
-	var deviceDetails lpmodel.Device
+	var deviceDetails sdkmodel.Device
 	uri := ctx.Request().RequestURI
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	}
+	uri = sdkhandler.TranslateToSouthBoundURL(uri)
 	//Get device details from request
 	err := ctx.ReadJSON(&deviceDetails)
 	if err != nil {
@@ -63,7 +61,7 @@ func ResetComputerSystem(ctx iris.Context) {
 		ctx.WriteString(errMsg)
 		return
 	}
-	device := &lputilities.RedfishDevice{
+	device := &sdkutilities.RedfishDevice{
 		Host:     deviceDetails.Host,
 		Username: deviceDetails.Username,
 		Password: string(deviceDetails.Password),
@@ -86,7 +84,7 @@ func ResetComputerSystem(ctx iris.Context) {
 	}
 	resetType := request["ResetType"].(string)
 	systemURI := strings.Split(uri, "Actions")[0]
-	statusCode, _, body, err := queryDevice(systemURI, device, http.MethodGet)
+	statusCode, _, body, err := sdkhandler.QueryDevice(ctx.Request().Context(), systemURI, device, http.MethodGet)
 	if err != nil {
 		errMsg := "error while getting system data, got: " + err.Error()
 		log.Error(errMsg)
@@ -108,10 +106,10 @@ func ResetComputerSystem(ctx iris.Context) {
 		ctx.Write(respBody)
 		return
 	}
-	device.PostBody, _ = json.Marshal(lpmodel.ResetPostRequest{
+	device.PostBody, _ = json.Marshal(sdkmodel.ResetPostRequest{
 		ResetType: resetType,
 	})
-	redfishClient, err := lputilities.GetRedfishClient()
+	redfishClient, err := sdkutilities.GetRedfishClient()
 	if err != nil {
 		errMsg := "While trying to create the redfish client, got:" + err.Error()
 		log.Error(errMsg)
@@ -156,12 +154,12 @@ func ResetComputerSystem(ctx iris.Context) {
 
 // createResetActionResponse is used for creating a final response for reset action success scenario
 func createResetActionResponse() ([]byte, error) {
-	resp := lpresponse.ErrorResopnse{
-		Error: lpresponse.Error{
+	resp := sdkresponse.ErrorResponse{
+		Error: sdkresponse.Error{
 			Code:    response.Success,
 			Message: "See @Message.ExtendedInfo for more information.",
-			MessageExtendedInfo: []lpresponse.MsgExtendedInfo{
-				lpresponse.MsgExtendedInfo{
+			MessageExtendedInfo: []sdkresponse.MsgExtendedInfo{
+				sdkresponse.MsgExtendedInfo{
 					MessageID:   response.Success,
 					Message:     "Reset initiated successfully",
 					MessageArgs: []string{},
@@ -21,10 +21,11 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkhandler"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkmodel"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkresponse"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
-	"github.com/ODIM-Project/ODIM/plugin-lenovo/lpmodel"
-	"github.com/ODIM-Project/ODIM/plugin-lenovo/lpresponse"
-	"github.com/ODIM-Project/ODIM/plugin-lenovo/lputilities"
 	iris "github.com/kataras/iris/v12"
 	log "github.com/sirupsen/logrus"
 )
@@ -35,7 +36,7 @@ func SimpleUpdate(ctx iris.Context) {
 	token := ctx.GetHeader("X-Auth-Token")
 	//Validating the token
 	if token != "" {
-		flag := TokenValidation(token)
+		flag := sdkhandler.TokenValidation(token)
 		if !flag {
 			log.Error("Invalid/Expired X-Auth-Token")
 			ctx.StatusCode(http.StatusUnauthorized)
@@ -43,9 +44,9 @@ func SimpleUpdate(ctx iris.Context) {
 			return
 		}
 	}
-	var deviceDetails lpmodel.Device
+	var deviceDetails sdkmodel.Device
 	uri := ctx.Request().RequestURI
-	uri = translateToSouthBoundURL(uri)
+	uri = sdkhandler.TranslateToSouthBoundURL(uri)
 	log.Debug("Incoming uri in SimpleUpdate : ", uri)
 	//Get device details from request
 	err := ctx.ReadJSON(&deviceDetails)
@@ -97,13 +98,13 @@ func SimpleUpdate(ctx iris.Context) {
 		ctx.StatusCode(http.StatusBadRequest)
 		ctx.WriteString("Error: bad request.")
 	}
-	device := &lputilities.RedfishDevice{
+	device := &sdkutilities.RedfishDevice{
 		Host:     deviceDetails.Host,
 		Username: deviceDetails.Username,
 		Password: string(deviceDetails.Password),
 		PostBody: marshalBody,
 	}
-	redfishClient, err := lputilities.GetRedfishClient()
+	redfishClient, err := sdkutilities.GetRedfishClient()
 	if err != nil {
 		errMsg := "While trying to get redfish client, got: " + err.Error()
 		log.Error(errMsg)
@@ -140,12 +141,12 @@ func SimpleUpdate(ctx iris.Context) {
 
 // createUpdateActionResponse is used for creating a final response for update action
 func createUpdateActionResponse(messageID, message string, msgArgs []string) ([]byte, error) {
-	resp := lpresponse.ErrorResopnse{
-		Error: lpresponse.Error{
+	resp := sdkresponse.ErrorResponse{
+		Error: sdkresponse.Error{
 			Code:    response.ExtendedInfo,
 			Message: "See @Message.ExtendedInfo for more information.",
-			MessageExtendedInfo: []lpresponse.MsgExtendedInfo{
-				lpresponse.MsgExtendedInfo{
+			MessageExtendedInfo: []sdkresponse.MsgExtendedInfo{
+				sdkresponse.MsgExtendedInfo{
 					MessageID:   messageID,
 					Message:     message,
 					MessageArgs: msgArgs,
@@ -4,12 +4,13 @@ package lphandler
 import (
 	"encoding/json"
 	"fmt"
-	"github.com/ODIM-Project/ODIM/plugin-lenovo/config"
-	"github.com/ODIM-Project/ODIM/plugin-lenovo/lpresponse"
-	iris "github.com/kataras/iris/v12"
-	"github.com/kataras/iris/v12/httptest"
 	"net/http"
 	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkresponse"
+	iris "github.com/kataras/iris/v12"
+	"github.com/kataras/iris/v12/httptest"
 )
 
 func mockSimpleUpdate(username, password, url string, w http.ResponseWriter) {
@@ -30,7 +31,7 @@ func TestSimpleUpdate(t *testing.T) {
 
 	redfishRoutes.Post("/UpdateService/Actions.SimpleUpdate", SimpleUpdate)
 	redfishRoutes.Post("/UpdateService/Actions.StartUpdate", SimpleUpdate)
-	lpresponse.PluginToken = "token"
+	sdkresponse.PluginToken = "token"
 	test := httptest.New(t, mockApp)
 	attributes := map[string]interface{}{"ImageUri": "abc",
 		"Targets": []string{"/ODIM/v1/Systems/uuid.1"}}
@@ -0,0 +1,245 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package rfphandler ...
+package rfphandler
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkhandler"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkresponse"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+)
+
+// biosAttribute is an attribute of the attribute registry of a BIOS
+type biosAttribute struct {
+	AttributeName string   `json:"AttributeName"`
+	Type          string   `json:"Type"`
+	ReadOnly      bool     `json:"ReadOnly"`
+	LowerBound    *float64 `json:"LowerBound"`
+	UpperBound    *float64 `json:"UpperBound"`
+	MinLength     *float64 `json:"MinLength"`
+	MaxLength     *float64 `json:"MaxLength"`
+	Value         []struct {
+		ValueName string `json:"ValueName"`
+	} `json:"Value"`
+}
+
+// attributeRegistry is the part of the attribute registry of a BIOS used for the validation of the settings
+type attributeRegistry struct {
+	RegistryEntries struct {
+		Attributes []biosAttribute `json:"Attributes"`
+	} `json:"RegistryEntries"`
+}
+
+// registryFile is the resource of the registries collection which locates a registry
+type registryFile struct {
+	ID       string `json:"Id"`
+	Registry string `json:"Registry"`
+	Location []struct {
+		Language string `json:"Language"`
+		URI      string `json:"Uri"`
+	} `json:"Location"`
+}
+
+var biosRegistries = newDeviceCache()
+
+// isBiosSettingsURI checks if the URI is the settings of a BIOS as exposed by ODIM
+func isBiosSettingsURI(uri string) bool {
+	return strings.HasSuffix(strings.ToLower(strings.TrimSuffix(uri, "/")), "/bios/settings")
+}
+
+// getBios returns the BIOS of the settings URI and the URI of its settings object
+// advertised in @Redfish.Settings, like Bios/SD on Supermicro BMCs. settingsURI
+// is returned when the BIOS does not advertise its settings object.
+func getBios(ctx context.Context, device *sdkutilities.RedfishDevice, settingsURI string) (map[string]interface{}, string, error) {
+	uri := strings.TrimSuffix(settingsURI, "/")
+	biosURI := uri[:len(uri)-len("/Settings")]
+	var bios map[string]interface{}
+	if _, err := getDeviceResource(ctx, device, biosURI, &bios); err != nil {
+		return nil, settingsURI, err
+	}
+	settings, _ := bios["@Redfish.Settings"].(map[string]interface{})
+	if settingsObject := getOdataID(settings, "SettingsObject"); settingsObject != "" {
+		return bios, settingsObject, nil
+	}
+	return bios, settingsURI, nil
+}
+
+// getBiosAttributes returns the attributes of the attribute registry of the
+// BIOS by their name. The registry is looked up in the registries collection
+// of the device, with its name or with the Id of the registry file.
+func getBiosAttributes(ctx context.Context, device *sdkutilities.RedfishDevice, registryName string) (map[string]biosAttribute, error) {
+	key := device.Host + "/" + registryName
+	if attributes, ok := biosRegistries.get(key); ok {
+		return attributes.(map[string]biosAttribute), nil
+	}
+	registriesURI := sdkhandler.TranslateToSouthBoundURL(serviceRootURI + "/Registries")
+	var file registryFile
+	if _, err := getDeviceResource(ctx, device, registriesURI+"/"+registryName, &file); err != nil {
+		file, err = findRegistryFile(ctx, device, registriesURI, registryName)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var location string
+	for _, l := range file.Location {
+		if l.URI != "" && (location == "" || strings.HasPrefix(l.Language, "en")) {
+			location = l.URI
+		}
+	}
+	if location == "" {
+		return nil, fmt.Errorf("the registry %s is not stored on the device", registryName)
+	}
+	var registry attributeRegistry
+	if _, err := getDeviceResource(ctx, device, location, &registry); err != nil {
+		return nil, err
+	}
+	if len(registry.RegistryEntries.Attributes) == 0 {
+		return nil, fmt.Errorf("the registry %s has no attributes", registryName)
+	}
+	attributes := make(map[string]biosAttribute, len(registry.RegistryEntries.Attributes))
+	for _, attribute := range registry.RegistryEntries.Attributes {
+		attributes[attribute.AttributeName] = attribute
+	}
+	biosRegistries.set(key, attributes)
+	return attributes, nil
+}
+
+// findRegistryFile looks for the registry in the members of the registries collection
+func findRegistryFile(ctx context.Context, device *sdkutilities.RedfishDevice, registriesURI, registryName string) (registryFile, error) {
+	var registries struct {
+		Members []struct {
+			OdataID string `json:"@odata.id"`
+		} `json:"Members"`
+	}
+	if _, err := getDeviceResource(ctx, device, registriesURI, &registries); err != nil {
+		return registryFile{}, err
+	}
+	for _, member := range registries.Members {
+		var file registryFile
+		if _, err := getDeviceResource(ctx, device, member.OdataID, &file); err != nil {
+			continue
+		}
+		if strings.EqualFold(file.ID, registryName) || strings.EqualFold(file.Registry, registryName) {
+			return file, nil
+		}
+	}
+	return registryFile{}, fmt.Errorf("the registry %s is not found on the device", registryName)
+}
+
+// validateBiosAttributes checks the attributes of the BIOS settings request against
+// the attribute registry, and returns a message for each attribute which is not valid
+func validateBiosAttributes(request map[string]interface{}, registry map[string]biosAttribute) []sdkresponse.MsgExtendedInfo {
+	attributes, _ := request["Attributes"].(map[string]interface{})
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var messages []sdkresponse.MsgExtendedInfo
+	for _, name := range names {
+		attribute, ok := registry[name]
+		switch {
+		case !ok:
+			messages = append(messages, sdkresponse.MsgExtendedInfo{
+				MessageID:   response.PropertyUnknown,
+				Message:     fmt.Sprintf("The property %s is not in the list of valid properties for the resource.", name),
+				MessageArgs: []string{name},
+			})
+		case attribute.ReadOnly:
+			messages = append(messages, sdkresponse.MsgExtendedInfo{
+				MessageID:   response.PropertyNotWritable,
+				Message:     fmt.Sprintf("The property %s is a read only property and cannot be assigned a value.", name),
+				MessageArgs: []string{name},
+			})
+		default:
+			if messageID := checkAttributeValue(attributes[name], attribute); messageID != "" {
+				messages = append(messages, attributeValueMessage(messageID, name, attributes[name]))
+			}
+		}
+	}
+	return messages
+}
+
+// checkAttributeValue returns the ID of the message for the value when it is
+// not valid for the attribute, the value null is left to the device
+func checkAttributeValue(value interface{}, attribute biosAttribute) string {
+	if value == nil {
+		return ""
+	}
+	switch attribute.Type {
+	case "Enumeration":
+		valueName, ok := value.(string)
+		if !ok {
+			return response.PropertyValueTypeError
+		}
+		for _, allowed := range attribute.Value {
+			if allowed.ValueName == valueName {
+				return ""
+			}
+		}
+		return response.PropertyValueNotInList
+	case "String", "Password":
+		text, ok := value.(string)
+		if !ok {
+			return response.PropertyValueTypeError
+		}
+		if !inRange(float64(len([]rune(text))), attribute.MinLength, attribute.MaxLength) {
+			return response.PropertyValueOutOfRange
+		}
+	case "Integer":
+		number, ok := value.(float64)
+		if !ok || number != math.Trunc(number) {
+			return response.PropertyValueTypeError
+		}
+		if !inRange(number, attribute.LowerBound, attribute.UpperBound) {
+			return response.PropertyValueOutOfRange
+		}
+	case "Boolean":
+		if _, ok := value.(bool); !ok {
+			return response.PropertyValueTypeError
+		}
+	}
+	return ""
+}
+
+func inRange(value float64, lower, upper *float64) bool {
+	return (lower == nil || value >= *lower) && (upper == nil || value <= *upper)
+}
+
+func attributeValueMessage(messageID, name string, value interface{}) sdkresponse.MsgExtendedInfo {
+	var format string
+	switch messageID {
+	case response.PropertyValueTypeError:
+		format = "The value '%s' for the property %s is of a different type than the property can accept."
+	case response.PropertyValueNotInList:
+		format = "The value '%s' for the property %s is not in the list of acceptable values."
+	default:
+		format = "The value '%s' for the property %s is not in the supported range of acceptable values."
+	}
+	valueString := fmt.Sprintf("%v", value)
+	return sdkresponse.MsgExtendedInfo{
+		MessageID:   messageID,
+		Message:     fmt.Sprintf(format, valueString, name),
+		MessageArgs: []string{valueString, name},
+	}
+}
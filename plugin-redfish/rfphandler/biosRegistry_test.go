@@ -0,0 +1,144 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package rfphandler ...
+package rfphandler
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+)
+
+// messageIDs returns the MessageId of the messages of an error response
+func messageIDs(body map[string]interface{}) []string {
+	var ids []string
+	errorBody, _ := body["Error"].(map[string]interface{})
+	messages, _ := errorBody["@Message.ExtendedInfo"].([]interface{})
+	for _, message := range messages {
+		id, _ := message.(map[string]interface{})["MessageId"].(string)
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestValidateBiosAttributes(t *testing.T) {
+	lower, upper, maxLength := 1.0, 24.0, 4.0
+	registry := map[string]biosAttribute{
+		"BootMode": {AttributeName: "BootMode", Type: "Enumeration", Value: []struct {
+			ValueName string `json:"ValueName"`
+		}{{ValueName: "Uefi"}, {ValueName: "LegacyBios"}}},
+		"Interval":  {AttributeName: "Interval", Type: "Integer", LowerBound: &lower, UpperBound: &upper},
+		"Name":      {AttributeName: "Name", Type: "String", MaxLength: &maxLength},
+		"QuietBoot": {AttributeName: "QuietBoot", Type: "Boolean"},
+		"Serial":    {AttributeName: "Serial", Type: "String", ReadOnly: true},
+	}
+	tests := []struct {
+		name       string
+		attributes map[string]interface{}
+		want       []string
+	}{
+		{"valid attributes", map[string]interface{}{"BootMode": "LegacyBios", "Interval": 12.0, "Name": "abcd", "QuietBoot": false}, nil},
+		{"unknown attribute", map[string]interface{}{"Unknown": "value"}, []string{response.PropertyUnknown}},
+		{"read only attribute", map[string]interface{}{"Serial": "1234"}, []string{response.PropertyNotWritable}},
+		{"value not in the list", map[string]interface{}{"BootMode": "Legacy"}, []string{response.PropertyValueNotInList}},
+		{"value of another type", map[string]interface{}{"Interval": "12", "QuietBoot": "true"}, []string{response.PropertyValueTypeError, response.PropertyValueTypeError}},
+		{"integer not whole", map[string]interface{}{"Interval": 1.5}, []string{response.PropertyValueTypeError}},
+		{"integer out of range", map[string]interface{}{"Interval": 48.0}, []string{response.PropertyValueOutOfRange}},
+		{"string too long", map[string]interface{}{"Name": "abcde"}, []string{response.PropertyValueOutOfRange}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messages := validateBiosAttributes(map[string]interface{}{"Attributes": tt.attributes}, registry)
+			var got []string
+			for _, message := range messages {
+				got = append(got, message.MessageID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("validateBiosAttributes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChangeBiosSettingsHPE(t *testing.T) {
+	server := startMockupServer(t, "hpe-ilo5")
+	e := newMockupTest(t)
+
+	// iLO applies the settings at the next boot and drops the attributes which are not valid
+	invalid := map[string]interface{}{"Attributes": map[string]interface{}{
+		"BootMode":                   "Legacy",
+		"MemPatrolScrubbingInterval": 48,
+		"SerialNumber":               "CZJ0000000",
+		"UnknownAttribute":           "Enabled",
+	}}
+	body := e.PATCH("/redfish/v1/Systems/1/Bios/Settings").WithJSON(mockupDevice(invalid)).Expect().Status(http.StatusBadRequest).JSON().Object().Raw()
+	want := []string{response.PropertyValueNotInList, response.PropertyValueOutOfRange, response.PropertyNotWritable, response.PropertyUnknown}
+	if got := messageIDs(body); !reflect.DeepEqual(got, want) {
+		t.Errorf("messages = %v, want %v", got, want)
+	}
+	if len(server.recorded()) != 0 {
+		t.Errorf("the settings which are not valid should not be sent to the device, got %v", server.recorded())
+	}
+
+	valid := map[string]interface{}{"Attributes": map[string]interface{}{"BootMode": "LegacyBios", "MemPatrolScrubbingInterval": 12}}
+	e.PATCH("/redfish/v1/Systems/1/Bios/Settings").WithJSON(mockupDevice(valid)).Expect().Status(http.StatusOK)
+	requests := server.recorded()
+	if len(requests) != 1 || requests[0].Method != http.MethodPatch || requests[0].Path != "/redfish/v1/systems/1/bios/settings/" {
+		t.Fatalf("the settings should be sent to the settings object of the BIOS, got %v", requests)
+	}
+	var sent map[string]interface{}
+	json.Unmarshal(requests[0].Body, &sent)
+	if !reflect.DeepEqual(sent, map[string]interface{}{"Attributes": map[string]interface{}{"BootMode": "LegacyBios", "MemPatrolScrubbingInterval": 12.0}}) {
+		t.Errorf("the device received %s", string(requests[0].Body))
+	}
+}
+
+func TestChangeBiosSettingsSupermicro(t *testing.T) {
+	server := startMockupServer(t, "supermicro-x12")
+	e := newMockupTest(t)
+
+	invalid := map[string]interface{}{"Attributes": map[string]interface{}{"QuietBoot#002E": "Enabled", "BIOSVersion#0000": "2.0"}}
+	body := e.PATCH("/redfish/v1/Systems/1/Bios/Settings").WithJSON(mockupDevice(invalid)).Expect().Status(http.StatusBadRequest).JSON().Object().Raw()
+	want := []string{response.PropertyNotWritable, response.PropertyValueTypeError}
+	if got := messageIDs(body); !reflect.DeepEqual(got, want) {
+		t.Errorf("messages = %v, want %v", got, want)
+	}
+
+	valid := map[string]interface{}{"Attributes": map[string]interface{}{"QuietBoot#002E": false, "SMTControl#0037": "Disabled"}}
+	e.PATCH("/redfish/v1/Systems/1/Bios/Settings").WithJSON(mockupDevice(valid)).Expect().Status(http.StatusOK)
+	requests := server.recorded()
+	if len(requests) != 1 || requests[0].Path != "/redfish/v1/Systems/1/Bios/SD" {
+		t.Fatalf("the settings should be sent to Bios/SD, got %v", requests)
+	}
+	if got := requests[0].Header.Get("If-Match"); got != `"7d1e6f20c3a4b5"` {
+		t.Errorf("If-Match = %s, want the ETag of Bios/SD", got)
+	}
+}
+
+func TestGetBiosSettingsObject(t *testing.T) {
+	startMockupServer(t, "supermicro-x12")
+	e := newMockupTest(t)
+
+	settings := e.GET("/redfish/v1/Systems/1/Bios/Settings").WithJSON(mockupDevice(nil)).Expect().Status(http.StatusOK).JSON().Object().Raw()
+	if settings["Id"] != "SD" {
+		t.Errorf("the settings object of the BIOS should be returned, got %v", settings)
+	}
+	if settings["@odata.id"] != "/redfish/v1/Systems/1/Bios/Settings" {
+		t.Errorf("@odata.id = %v, want the URI of the request", settings["@odata.id"])
+	}
+}
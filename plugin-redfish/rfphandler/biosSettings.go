@@ -16,20 +16,21 @@
 package rfphandler
 
 import (
-	log "github.com/sirupsen/logrus"
+	"context"
+	"encoding/json"
 	"io/ioutil"
 	"net/http"
 
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-redfish/config"
-	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpmodel"
-	"github.com/ODIM-Project/ODIM/plugin-redfish/rfputilities"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkhandler"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkmodel"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
 	iris "github.com/kataras/iris/v12"
-	"strings"
+	log "github.com/sirupsen/logrus"
 )
 
 // DeviceClient struct to call device for the operation
 type DeviceClient struct {
-	ChangeSettingsOnDevice func(device *rfputilities.RedfishDevice, url, method string) (*http.Response, error)
+	ChangeSettingsOnDevice func(device *sdkutilities.RedfishDevice, url, method string) (*http.Response, error)
 	DecryptPassword        func(password []byte) ([]byte, error)
 }
 
@@ -41,12 +42,10 @@ func ChangeSettings(ctx iris.Context) {
 	token := ctx.GetHeader("X-Auth-Token")
 	uri := ctx.Request().RequestURI
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	}
+	uri = sdkhandler.TranslateToSouthBoundURL(uri)
 	//Validating the token
 	if token != "" {
-		flag := TokenValidation(token)
+		flag := sdkhandler.TokenValidation(token)
 		if !flag {
 			log.Error("Invalid/Expired X-Auth-Token")
 			ctx.StatusCode(http.StatusUnauthorized)
@@ -55,7 +54,7 @@ func ChangeSettings(ctx iris.Context) {
 		}
 	}
 
-	var deviceDetails rfpmodel.Device
+	var deviceDetails sdkmodel.Device
 
 	//Get device details from request
 	err := ctx.ReadJSON(&deviceDetails)
@@ -66,14 +65,14 @@ func ChangeSettings(ctx iris.Context) {
 		ctx.WriteString(errorMessage)
 		return
 	}
-	device := &rfputilities.RedfishDevice{
+	device := &sdkutilities.RedfishDevice{
 		Host:     deviceDetails.Host,
 		Username: deviceDetails.Username,
 		Password: string(deviceDetails.Password),
 		PostBody: deviceDetails.PostBody,
 	}
 
-	redfishClient, err := rfputilities.GetRedfishClient()
+	redfishClient, err := sdkutilities.GetRedfishClient()
 	if err != nil {
 		errMsg := "While trying to create the redfish client, got:" + err.Error()
 		log.Error(errMsg)
@@ -81,7 +80,18 @@ func ChangeSettings(ctx iris.Context) {
 		ctx.WriteString(errMsg)
 		return
 	}
-	resp, err := redfishClient.DeviceCall(device, uri, http.MethodPatch)
+	var header http.Header
+	if isBiosSettingsURI(uri) {
+		var errorBody []byte
+		uri, header, errorBody = translateBiosSettings(ctx.Request().Context(), device, uri)
+		if errorBody != nil {
+			ctx.StatusCode(http.StatusBadRequest)
+			ctx.ContentType("application/json")
+			ctx.Write(errorBody)
+			return
+		}
+	}
+	resp, err := redfishClient.DeviceCallWithHeader(device, uri, http.MethodPatch, header)
 	if err != nil {
 		errorMessage := "While trying to change bios settings, got: " + err.Error()
 		log.Error(errorMessage)
@@ -100,3 +110,66 @@ func ChangeSettings(ctx iris.Context) {
 	ctx.StatusCode(resp.StatusCode)
 	ctx.Write(body)
 }
+
+// translateBiosSettings returns the URI of the settings object of the BIOS and
+// the headers of the PATCH required by the vendor of the device. The attributes
+// are checked against the attribute registry of the BIOS for the vendors which
+// do not reject the attributes which are not valid, like iLO which drops them
+// when the settings are applied at the next boot. The error response is returned
+// when the attributes are not valid.
+func translateBiosSettings(ctx context.Context, device *sdkutilities.RedfishDevice, uri string) (string, http.Header, []byte) {
+	profile := getVendorProfile(ctx, device)
+	bios, settingsURI, err := getBios(ctx, device, uri)
+	if err != nil {
+		log.Warn("Unable to read the BIOS of " + uri + ": " + err.Error())
+		return uri, nil, nil
+	}
+	if registryName, _ := bios["AttributeRegistry"].(string); profile.validateBiosAttributes && registryName != "" {
+		registry, err := getBiosAttributes(ctx, device, registryName)
+		if err != nil {
+			log.Warn("The BIOS attributes are not validated, unable to get the attribute registry " + registryName + ": " + err.Error())
+		} else {
+			var request map[string]interface{}
+			if err := json.Unmarshal(device.PostBody, &request); err == nil {
+				if messages := validateBiosAttributes(request, registry); len(messages) > 0 {
+					return settingsURI, nil, createErrorBody(messages...)
+				}
+			}
+		}
+	}
+	if !profile.ifMatchRequired {
+		return settingsURI, nil, nil
+	}
+	var settings map[string]interface{}
+	settingsHeader, err := getDeviceResource(ctx, device, settingsURI, &settings)
+	if err != nil {
+		log.Warn("Unable to get the ETag of " + settingsURI + ": " + err.Error())
+		return settingsURI, nil, nil
+	}
+	etag := settingsHeader.Get("ETag")
+	if etag == "" {
+		etag, _ = settings["@odata.etag"].(string)
+	}
+	if etag == "" {
+		return settingsURI, nil, nil
+	}
+	return settingsURI, http.Header{"If-Match": []string{etag}}, nil
+}
+
+// getBiosSettingsObject returns the settings object of the BIOS advertised in
+// @Redfish.Settings with the settings URI exposed by ODIM as its @odata.id,
+// for the devices which do not serve the settings of the BIOS at Bios/Settings
+func getBiosSettingsObject(ctx context.Context, device *sdkutilities.RedfishDevice, uri string) []byte {
+	_, settingsURI, err := getBios(ctx, device, uri)
+	if err != nil || isSameURI(settingsURI, uri) {
+		return nil
+	}
+	var settings map[string]interface{}
+	if _, err := getDeviceResource(ctx, device, settingsURI, &settings); err != nil {
+		log.Warn("Unable to get the BIOS settings object " + settingsURI + ": " + err.Error())
+		return nil
+	}
+	settings["@odata.id"] = uri
+	body, _ := json.Marshal(settings)
+	return body
+}
@@ -20,12 +20,11 @@ import (
 	"net/http"
 	"strings"
 
-	log "github.com/sirupsen/logrus"
-
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-redfish/config"
-	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpmodel"
-	"github.com/ODIM-Project/ODIM/plugin-redfish/rfputilities"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkhandler"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkmodel"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
 	iris "github.com/kataras/iris/v12"
+	log "github.com/sirupsen/logrus"
 )
 
 //GetResource : Fetches details of the given resource from the device
@@ -34,11 +33,9 @@ func GetResource(ctx iris.Context) {
 	token := ctx.GetHeader("X-Auth-Token")
 	uri := ctx.Request().RequestURI
 	//replacing the reuest url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	} //Validating the token
+	uri = sdkhandler.TranslateToSouthBoundURL(uri) //Validating the token
 	if token != "" {
-		flag := TokenValidation(token)
+		flag := sdkhandler.TokenValidation(token)
 		if !flag {
 			log.Error("Invalid/Expired X-Auth-Token")
 			ctx.StatusCode(http.StatusUnauthorized)
@@ -47,7 +44,7 @@ func GetResource(ctx iris.Context) {
 		}
 	}
 
-	var deviceDetails rfpmodel.Device
+	var deviceDetails sdkmodel.Device
 
 	//Get device details from request
 	err := ctx.ReadJSON(&deviceDetails)
@@ -59,14 +56,14 @@ func GetResource(ctx iris.Context) {
 		return
 	}
 
-	device := &rfputilities.RedfishDevice{
+	device := &sdkutilities.RedfishDevice{
 		Host:     deviceDetails.Host,
 		Username: deviceDetails.Username,
 		Password: string(deviceDetails.Password),
 	}
-	//plainText, err := rfputilities.GetPlainText(device.Password)
+	//plainText, err := sdkutilities.GetPlainText(device.Password)
 	//device.Password = plainText
-	redfishClient, err := rfputilities.GetRedfishClient()
+	redfishClient, err := sdkutilities.GetRedfishClient()
 	if err != nil {
 		errMsg := "While trying to create the redfish client, got:" + err.Error()
 		log.Error(errMsg)
@@ -102,7 +99,15 @@ func GetResource(ctx iris.Context) {
 		ctx.WriteString("Authtication with the device failed")
 		return
 	}
-	if resp.StatusCode >= 300 {
+	statusCode := resp.StatusCode
+	if statusCode == http.StatusNotFound && isBiosSettingsURI(uri) {
+		// the settings object of the BIOS is not at Bios/Settings on every BMC
+		if settings := getBiosSettingsObject(ctx.Request().Context(), device, uri); settings != nil {
+			statusCode, body = http.StatusOK, settings
+			ctx.ContentType("application/json")
+		}
+	}
+	if statusCode >= 300 {
 		log.Error("Could not retrieve generic resource for" + device.Host + ": \n" + string(body) + ":\n" + uri)
 
 	}
@@ -114,9 +119,7 @@ func GetResource(ctx iris.Context) {
 		respData = strings.Replace(string(respData), "settings","Settings",-1)
 	}
 	//replacing the resposne with north bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.NorthBoundURL {
-		respData = strings.Replace(respData, key, value, -1)
-	}
-	ctx.StatusCode(resp.StatusCode)
+	respData = sdkhandler.TranslateToNorthBoundURL(respData)
+	ctx.StatusCode(statusCode)
 	ctx.Write([]byte(respData))
 }
@@ -0,0 +1,79 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package rfphandler ...
+package rfphandler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkhandler"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkresponse"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	log "github.com/sirupsen/logrus"
+)
+
+// hpeAddFromURIAction is the OEM action of the update service of iLO which
+// adds a component to the iLO repository and installs it
+const hpeAddFromURIAction = "HpeiLOUpdateServiceExt.AddFromUri"
+
+// hpeSimpleUpdate updates the firmware of iLO BMCs. The component packages
+// (.fwpkg) are not flashed by UpdateService.SimpleUpdate, they are added to
+// the iLO repository and installed with HpeiLOUpdateServiceExt.AddFromUri.
+// The other images are flashed with SimpleUpdate given only the ImageURI,
+// iLO takes the transfer protocol from the URI and updates the component
+// the image is built for.
+func hpeSimpleUpdate(ctx context.Context, device *sdkutilities.RedfishDevice, request *model.SimpleUpdate) (int, []byte) {
+	updateServiceURI := sdkhandler.TranslateToSouthBoundURL(serviceRootURI + "/UpdateService")
+	actionURI := updateServiceURI + "/Actions/UpdateService.SimpleUpdate"
+	updateRequest := map[string]interface{}{"ImageURI": request.ImageURI}
+	if isComponentPackage(request.ImageURI) {
+		var updateService map[string]interface{}
+		if _, err := getDeviceResource(ctx, device, updateServiceURI, &updateService); err != nil {
+			errMsg := "While trying to get the update service, got: " + err.Error()
+			log.Error(errMsg)
+			return http.StatusInternalServerError, []byte(errMsg)
+		}
+		actionURI = getOemActions(updateService)[hpeAddFromURIAction]
+		if actionURI == "" {
+			log.Error("The update service of device " + device.Host + " does not support " + hpeAddFromURIAction)
+			return http.StatusBadRequest, createErrorBody(sdkresponse.MsgExtendedInfo{
+				MessageID:   response.ActionNotSupported,
+				Message:     fmt.Sprintf("The action %s is not supported by the resource.", hpeAddFromURIAction),
+				MessageArgs: []string{hpeAddFromURIAction},
+			})
+		}
+		updateRequest["UpdateRepository"] = true
+		updateRequest["UpdateTarget"] = true
+	}
+	body, _ := json.Marshal(updateRequest)
+	return postToDevice(device, actionURI, body, nil)
+}
+
+// isComponentPackage checks if the image is an iLO component package
+func isComponentPackage(imageURI string) bool {
+	imageURL, err := url.Parse(imageURI)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(path.Ext(imageURL.Path), ".fwpkg")
+}
@@ -0,0 +1,71 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package rfphandler ...
+package rfphandler
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestHPESimpleUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		imageURI string
+		wantPath string
+		wantBody map[string]interface{}
+	}{
+		{
+			name:     "component package",
+			imageURI: "http://10.0.0.5/images/U46_2.72_03_07_2023.fwpkg",
+			wantPath: "/redfish/v1/UpdateService/Actions/Oem/Hpe/HpeiLOUpdateServiceExt.AddFromUri/",
+			wantBody: map[string]interface{}{
+				"ImageURI":         "http://10.0.0.5/images/U46_2.72_03_07_2023.fwpkg",
+				"UpdateRepository": true,
+				"UpdateTarget":     true,
+			},
+		},
+		{
+			name:     "flat image",
+			imageURI: "https://10.0.0.5/images/ilo5_278.bin",
+			wantPath: "/ODIM/v1/UpdateService/Actions/UpdateService.SimpleUpdate",
+			wantBody: map[string]interface{}{"ImageURI": "https://10.0.0.5/images/ilo5_278.bin"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := startMockupServer(t, "hpe-ilo5")
+			e := newMockupTest(t)
+			request := map[string]interface{}{
+				"ImageURI":         tt.imageURI,
+				"TransferProtocol": "HTTP",
+				"Targets":          []string{"/ODIM/v1/Systems/1"},
+			}
+			e.POST("/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate").WithJSON(mockupDevice(request)).Expect().Status(http.StatusOK)
+
+			requests := server.recorded()
+			if len(requests) != 1 || requests[0].Path != tt.wantPath {
+				t.Fatalf("the update should be posted to %s, got %v", tt.wantPath, requests)
+			}
+			var body map[string]interface{}
+			json.Unmarshal(requests[0].Body, &body)
+			if !reflect.DeepEqual(body, tt.wantBody) {
+				t.Errorf("the device received %s", string(requests[0].Body))
+			}
+		})
+	}
+}
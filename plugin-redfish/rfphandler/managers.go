@@ -18,12 +18,12 @@ package rfphandler
 import (
 	"io/ioutil"
 	"net/http"
-	"strings"
 
 	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-redfish/config"
-	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpmodel"
-	"github.com/ODIM-Project/ODIM/plugin-redfish/rfputilities"
+	pluginConfig "github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkhandler"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkmodel"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
 	iris "github.com/kataras/iris/v12"
 	log "github.com/sirupsen/logrus"
 )
@@ -35,7 +35,7 @@ func GetManagersCollection(ctx iris.Context) {
 	uri := ctx.Request().RequestURI
 	//Validating the token
 	if token != "" {
-		flag := TokenValidation(token)
+		flag := sdkhandler.TokenValidation(token)
 		if !flag {
 			log.Error("Invalid/Expired X-Auth-Token")
 			ctx.StatusCode(http.StatusUnauthorized)
@@ -43,7 +43,7 @@ func GetManagersCollection(ctx iris.Context) {
 			return
 		}
 	}
-	var deviceDetails rfpmodel.Device
+	var deviceDetails sdkmodel.Device
 	// if any error come while getting the device then request will be for  plugins manager
 	ctx.ReadJSON(&deviceDetails)
 	if deviceDetails.Host == "" {
@@ -80,7 +80,7 @@ func GetManagersInfo(ctx iris.Context) {
 
 	//Validating the token
 	if token != "" {
-		flag := TokenValidation(token)
+		flag := sdkhandler.TokenValidation(token)
 		if !flag {
 			log.Error("Invalid/Expired X-Auth-Token")
 			ctx.StatusCode(http.StatusUnauthorized)
@@ -88,7 +88,7 @@ func GetManagersInfo(ctx iris.Context) {
 			return
 		}
 	}
-	var deviceDetails rfpmodel.Device
+	var deviceDetails sdkmodel.Device
 	// if any error come while getting the device then request will be for  plugins manager
 	ctx.ReadJSON(&deviceDetails)
 	if deviceDetails.Host == "" {
@@ -115,17 +115,15 @@ func GetManagersInfo(ctx iris.Context) {
 
 }
 
-func getInfoFromDevice(uri string, deviceDetails rfpmodel.Device, ctx iris.Context) {
+func getInfoFromDevice(uri string, deviceDetails sdkmodel.Device, ctx iris.Context) {
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	}
-	device := &rfputilities.RedfishDevice{
+	uri = sdkhandler.TranslateToSouthBoundURL(uri)
+	device := &sdkutilities.RedfishDevice{
 		Host:     deviceDetails.Host,
 		Username: deviceDetails.Username,
 		Password: string(deviceDetails.Password),
 	}
-	redfishClient, err := rfputilities.GetRedfishClient()
+	redfishClient, err := sdkutilities.GetRedfishClient()
 	if err != nil {
 		errMsg := "While trying to create the redfish client, got:" + err.Error()
 		log.Error(errMsg)
@@ -166,9 +164,7 @@ func getInfoFromDevice(uri string, deviceDetails rfpmodel.Device, ctx iris.Conte
 	}
 	respData := string(body)
 	//replacing the resposne with north bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.NorthBoundURL {
-		respData = strings.Replace(respData, key, value, -1)
-	}
+	respData = sdkhandler.TranslateToNorthBoundURL(respData)
 	ctx.StatusCode(resp.StatusCode)
 	ctx.Write([]byte(respData))
 }
@@ -177,10 +173,8 @@ func getInfoFromDevice(uri string, deviceDetails rfpmodel.Device, ctx iris.Conte
 func VirtualMediaActions(ctx iris.Context) {
 	uri := ctx.Request().RequestURI
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	}
-	var deviceDetails rfpmodel.Device
+	uri = sdkhandler.TranslateToSouthBoundURL(uri)
+	var deviceDetails sdkmodel.Device
 	//Get device details from request
 	err := ctx.ReadJSON(&deviceDetails)
 	if err != nil {
@@ -189,14 +183,14 @@ func VirtualMediaActions(ctx iris.Context) {
 		ctx.WriteString("Error: bad request.")
 		return
 	}
-	device := &rfputilities.RedfishDevice{
+	device := &sdkutilities.RedfishDevice{
 		Host:     deviceDetails.Host,
 		Username: deviceDetails.Username,
 		Password: string(deviceDetails.Password),
 		PostBody: deviceDetails.PostBody,
 	}
 
-	redfishClient, err := rfputilities.GetRedfishClient()
+	redfishClient, err := sdkutilities.GetRedfishClient()
 	if err != nil {
 		errMsg := "While trying to create the redfish client, got:" + err.Error()
 		log.Error(errMsg)
@@ -24,9 +24,10 @@ import (
 	"sync"
 
 	dmtfmodel "github.com/ODIM-Project/ODIM/lib-dmtf/model"
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-redfish/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkhandler"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkmodel"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
 	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpmodel"
-	"github.com/ODIM-Project/ODIM/plugin-redfish/rfputilities"
 	iris "github.com/kataras/iris/v12"
 	log "github.com/sirupsen/logrus"
 )
@@ -34,7 +35,7 @@ import (
 // ExternalInterface enables the communicunication with the external functions
 type ExternalInterface struct {
 	TokenValidation func(string) bool
-	GetDeviceData   func(string, *rfputilities.RedfishDevice) (int, []byte, map[string]interface{}, error)
+	GetDeviceData   func(string, *sdkutilities.RedfishDevice) (int, []byte, map[string]interface{}, error)
 }
 
 // GetMetricReport is for to get metric report from southbound resource
@@ -46,9 +47,7 @@ func (e *ExternalInterface) GetMetricReport(ctx iris.Context) {
 	reqURI := ctx.Request().RequestURI
 
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		reqURI = strings.Replace(reqURI, key, value, -1)
-	}
+	reqURI = sdkhandler.TranslateToSouthBoundURL(reqURI)
 	//Validating the token
 	if token != "" {
 		flag := e.TokenValidation(token)
@@ -61,8 +60,8 @@ func (e *ExternalInterface) GetMetricReport(ctx iris.Context) {
 	}
 
 	// prepare the device data
-	var devices []rfpmodel.Device
-	rfpmodel.GetAllDevicesInInventory(&devices)
+	var devices []sdkmodel.Device
+	sdkmodel.GetAllDevicesInInventory(&devices)
 
 	metricReportData := e.getMetricData(reqURI, devices)
 	var respMetricValue []dmtfmodel.MetricValue
@@ -78,7 +77,7 @@ func (e *ExternalInterface) GetMetricReport(ctx iris.Context) {
 }
 
 // getMetricData collects the metricreport from the BMC for the given list of BMC
-func (e *ExternalInterface) getMetricData(uri string, devices []rfpmodel.Device) map[string]dmtfmodel.MetricReports {
+func (e *ExternalInterface) getMetricData(uri string, devices []sdkmodel.Device) map[string]dmtfmodel.MetricReports {
 	var wg sync.WaitGroup
 	data := make(map[string]dmtfmodel.MetricReports)
 	var lock sync.Mutex
@@ -90,9 +89,9 @@ func (e *ExternalInterface) getMetricData(uri string, devices []rfpmodel.Device)
 	return data
 }
 
-func (e *ExternalInterface) getMetricReportInfo(uri string, device rfpmodel.Device, wg *sync.WaitGroup, data map[string]dmtfmodel.MetricReports, lock *sync.Mutex) {
+func (e *ExternalInterface) getMetricReportInfo(uri string, device sdkmodel.Device, wg *sync.WaitGroup, data map[string]dmtfmodel.MetricReports, lock *sync.Mutex) {
 	defer wg.Done()
-	statusCode, body, _, _ := e.GetDeviceData(uri, &rfputilities.RedfishDevice{
+	statusCode, body, _, _ := e.GetDeviceData(uri, &sdkutilities.RedfishDevice{
 		Host:     device.Host,
 		Username: device.Username,
 		Password: string(device.Password),
@@ -108,7 +107,7 @@ func (e *ExternalInterface) getMetricReportInfo(uri string, device rfpmodel.Devi
 			uri = metricVal.MetricDefinition.ODataID
 			metricID := metricVal.MetricID
 			if _, ok := rfpmodel.MetricPropertyData[metricID]; !ok {
-				statusCode, body, _, _ := e.GetDeviceData(uri, &rfputilities.RedfishDevice{
+				statusCode, body, _, _ := e.GetDeviceData(uri, &sdkutilities.RedfishDevice{
 					Host:     device.Host,
 					Username: device.Username,
 					Password: string(device.Password),
@@ -143,8 +142,8 @@ func (e *ExternalInterface) getMetricReportInfo(uri string, device rfpmodel.Devi
 }
 
 // GetDeviceData connects with the device and collect the information
-func GetDeviceData(uri string, device *rfputilities.RedfishDevice) (int, []byte, map[string]interface{}, error) {
-	redfishClient, err := rfputilities.GetRedfishClient()
+func GetDeviceData(uri string, device *sdkutilities.RedfishDevice) (int, []byte, map[string]interface{}, error) {
+	redfishClient, err := sdkutilities.GetRedfishClient()
 	if err != nil {
 		errMsg := "While trying to get the redfish client, got: " + err.Error()
 		log.Error(errMsg)
@@ -18,9 +18,9 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/ODIM-Project/ODIM/plugin-redfish/config"
-	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpmodel"
-	"github.com/ODIM-Project/ODIM/plugin-redfish/rfputilities"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkmodel"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
 	iris "github.com/kataras/iris/v12"
 	"github.com/kataras/iris/v12/httptest"
 )
@@ -29,7 +29,7 @@ func tokenValidationMock(token string) bool {
 	return true
 }
 
-func getMetricReportMock(uri string, device *rfputilities.RedfishDevice) (int, []byte, map[string]interface{}, error) {
+func getMetricReportMock(uri string, device *sdkutilities.RedfishDevice) (int, []byte, map[string]interface{}, error) {
 	var data string
 	if uri == "/redfish/v1/TelemetryService/MetricReports/CPUUtilCustom1" {
 		data = `{
@@ -102,7 +102,7 @@ func TestExternalInterface_GetMetricReport(t *testing.T) {
 		TokenValidation: tokenValidationMock,
 		GetDeviceData:   getMetricReportMock,
 	}
-	rfpmodel.DeviceInventory.Device["0e343dc6-f5f3-425a-9503-4a3c799579c8"] = rfpmodel.DeviceData{
+	sdkmodel.DeviceInventory.Device["0e343dc6-f5f3-425a-9503-4a3c799579c8"] = sdkmodel.DeviceData{
 		Address:  "172.16.1.205",
 		UserName: "admin",
 		Password: []byte("Admin123"),
@@ -0,0 +1,156 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package rfphandler ...
+package rfphandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkhandler"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkmodel"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkresponse"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	iris "github.com/kataras/iris/v12"
+	log "github.com/sirupsen/logrus"
+)
+
+// OemAction performs an OEM action of a resource, like the Oem/Hpe actions of
+// the iLO BMCs. The action is looked up in the OEM actions advertised by the
+// resource and posted to its target, the actions which are not advertised
+// are rejected with ActionNotSupported.
+func OemAction(ctx iris.Context) {
+	//Get token from Request
+	token := ctx.GetHeader("X-Auth-Token")
+	//Validating the token
+	if token != "" {
+		flag := sdkhandler.TokenValidation(token)
+		if !flag {
+			log.Error("Invalid/Expired X-Auth-Token")
+			ctx.StatusCode(http.StatusUnauthorized)
+			ctx.WriteString("Invalid/Expired X-Auth-Token")
+			return
+		}
+	}
+	var deviceDetails sdkmodel.Device
+	uri := ctx.Request().RequestURI
+	//replacing the request url with south bound translation URL
+	uri = sdkhandler.TranslateToSouthBoundURL(uri)
+	//Get device details from request
+	err := ctx.ReadJSON(&deviceDetails)
+	if err != nil {
+		errMsg := "Unable to collect data from request: " + err.Error()
+		log.Error(errMsg)
+		ctx.StatusCode(http.StatusBadRequest)
+		ctx.WriteString(errMsg)
+		return
+	}
+	device := &sdkutilities.RedfishDevice{
+		Host:     deviceDetails.Host,
+		Username: deviceDetails.Username,
+		Password: string(deviceDetails.Password),
+	}
+
+	resourceURI := uri[:strings.Index(uri, "/Actions/")]
+	statusCode, _, body, err := sdkhandler.QueryDevice(ctx.Request().Context(), resourceURI, device, http.MethodGet)
+	if err != nil || statusCode != http.StatusOK {
+		log.Error(fmt.Sprintf("While trying to get the actions of %s, got: %d %v", resourceURI, statusCode, err))
+		ctx.StatusCode(statusCode)
+		ctx.ContentType("application/json")
+		ctx.Write(body)
+		return
+	}
+	var resource map[string]interface{}
+	if err := json.Unmarshal(body, &resource); err != nil {
+		errMsg := "While trying to unmarshal the actions of " + resourceURI + ", got: " + err.Error()
+		log.Error(errMsg)
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.WriteString(errMsg)
+		return
+	}
+
+	actionName := path.Base(strings.TrimSuffix(uri, "/"))
+	target := findOemActionTarget(resource, uri, actionName)
+	if target == "" {
+		log.Error("The action " + uri + " is not advertised by " + resourceURI)
+		ctx.StatusCode(http.StatusBadRequest)
+		ctx.ContentType("application/json")
+		ctx.Write(createErrorBody(sdkresponse.MsgExtendedInfo{
+			MessageID:   response.ActionNotSupported,
+			Message:     fmt.Sprintf("The action %s is not supported by the resource.", actionName),
+			MessageArgs: []string{actionName},
+		}))
+		return
+	}
+	postBody := deviceDetails.PostBody
+	if len(postBody) == 0 {
+		postBody = []byte("{}")
+	}
+	statusCode, body = postToDevice(device, target, []byte(sdkhandler.TranslateToSouthBoundURL(string(postBody))), nil)
+	ctx.StatusCode(statusCode)
+	ctx.ContentType("application/json")
+	ctx.Write([]byte(sdkhandler.TranslateToNorthBoundURL(string(body))))
+}
+
+// getOemActions returns the targets of the OEM actions of the resource by their
+// name. The actions are advertised in Actions.Oem, directly or under the key
+// of the vendor, and by some BMCs in the Actions of the Oem property.
+func getOemActions(resource map[string]interface{}) map[string]string {
+	targets := make(map[string]string)
+	actions, _ := resource["Actions"].(map[string]interface{})
+	collectActions(actions["Oem"], targets)
+	oem, _ := resource["Oem"].(map[string]interface{})
+	for _, vendorOem := range oem {
+		vendorProperties, _ := vendorOem.(map[string]interface{})
+		collectActions(vendorProperties["Actions"], targets)
+	}
+	return targets
+}
+
+func collectActions(actions interface{}, targets map[string]string) {
+	properties, _ := actions.(map[string]interface{})
+	for key, value := range properties {
+		action, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if target, ok := action["target"].(string); ok && strings.HasPrefix(key, "#") {
+			targets[strings.TrimPrefix(key, "#")] = target
+			continue
+		}
+		collectActions(action, targets)
+	}
+}
+
+// findOemActionTarget returns the target of the OEM action of the resource
+// which is requested at uri, or which has the given name
+func findOemActionTarget(resource map[string]interface{}, uri, actionName string) string {
+	actions := getOemActions(resource)
+	for _, target := range actions {
+		if isSameURI(target, uri) {
+			return target
+		}
+	}
+	for name, target := range actions {
+		if strings.EqualFold(name, actionName) {
+			return target
+		}
+	}
+	return ""
+}
@@ -0,0 +1,75 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package rfphandler ...
+package rfphandler
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+)
+
+func TestOemAction(t *testing.T) {
+	server := startMockupServer(t, "hpe-ilo5")
+	e := newMockupTest(t)
+
+	e.POST("/redfish/v1/Systems/1/Actions/Oem/Hpe/HpeComputerSystemExt.PowerButton").WithJSON(mockupDevice(map[string]string{"PushType": "Press"})).Expect().Status(http.StatusOK)
+	requests := server.recorded()
+	if len(requests) != 1 || requests[0].Path != "/redfish/v1/Systems/1/Actions/Oem/Hpe/HpeComputerSystemExt.PowerButton/" {
+		t.Fatalf("the action should be posted to its target, got %v", requests)
+	}
+	if string(requests[0].Body) != `{"PushType":"Press"}` {
+		t.Errorf("the device received %s", string(requests[0].Body))
+	}
+
+	body := e.POST("/redfish/v1/Systems/1/Actions/Oem/Hpe/HpeComputerSystemExt.Unknown").WithJSON(mockupDevice(nil)).Expect().Status(http.StatusBadRequest).JSON().Object().Raw()
+	if got := messageIDs(body); !reflect.DeepEqual(got, []string{response.ActionNotSupported}) {
+		t.Errorf("messages = %v, want ActionNotSupported", got)
+	}
+	if len(server.recorded()) != 1 {
+		t.Errorf("the actions which are not advertised should not be sent to the device, got %v", server.recorded())
+	}
+}
+
+func TestGetOemActions(t *testing.T) {
+	resource := map[string]interface{}{
+		"Actions": map[string]interface{}{
+			"#ComputerSystem.Reset": map[string]interface{}{"target": "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset"},
+			"Oem": map[string]interface{}{
+				"#Contoso.Reset": map[string]interface{}{"target": "/redfish/v1/Systems/1/Actions/Oem/Contoso.Reset"},
+				"Dell": map[string]interface{}{
+					"#DellOem.Reset": map[string]interface{}{"target": "/redfish/v1/Systems/1/Actions/Oem/Dell/DellOem.Reset"},
+				},
+			},
+		},
+		"Oem": map[string]interface{}{
+			"Hpe": map[string]interface{}{
+				"Actions": map[string]interface{}{
+					"#HpeComputerSystemExt.PowerButton": map[string]interface{}{"target": "/redfish/v1/Systems/1/Actions/Oem/Hpe/HpeComputerSystemExt.PowerButton/"},
+				},
+			},
+		},
+	}
+	want := map[string]string{
+		"Contoso.Reset":                    "/redfish/v1/Systems/1/Actions/Oem/Contoso.Reset",
+		"DellOem.Reset":                    "/redfish/v1/Systems/1/Actions/Oem/Dell/DellOem.Reset",
+		"HpeComputerSystemExt.PowerButton": "/redfish/v1/Systems/1/Actions/Oem/Hpe/HpeComputerSystemExt.PowerButton/",
+	}
+	if got := getOemActions(resource); !reflect.DeepEqual(got, want) {
+		t.Errorf("getOemActions() = %v, want %v", got, want)
+	}
+}
@@ -24,8 +24,8 @@ import (
 	"testing"
 
 	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
-	"github.com/ODIM-Project/ODIM/plugin-redfish/config"
-	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpresponse"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkresponse"
 	iris "github.com/kataras/iris/v12"
 	"github.com/kataras/iris/v12/httptest"
 )
@@ -82,7 +82,7 @@ func TestCreateVolume(t *testing.T) {
 
 	redfishRoutes.Post("/Systems/{id}/Storage/{rid}/Volumes", CreateVolume)
 
-	rfpresponse.PluginToken = "token"
+	sdkresponse.PluginToken = "token"
 
 	e := httptest.New(t, mockApp)
 
@@ -125,7 +125,7 @@ func TesDeleteVolume(t *testing.T) {
 
 	redfishRoutes.Delete("/Systems/{id}/Storage/{id2}/Volumes/rid", CreateVolume)
 
-	rfpresponse.PluginToken = "token"
+	sdkresponse.PluginToken = "token"
 
 	e := httptest.New(t, mockApp)
 
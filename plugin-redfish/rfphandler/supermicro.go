@@ -0,0 +1,218 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package rfphandler ...
+package rfphandler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkhandler"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkresponse"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// imageDownloadTimeout is the time allowed for the download of an update image
+	imageDownloadTimeout = 10 * time.Minute
+	// maxImageSize is the size limit of an update image, the images are held in memory
+	maxImageSize = 512 << 20
+)
+
+// supermicroPreserveSettings are the Oem parameters of the multipart update
+// of Supermicro BMCs, which keep the configuration of the updated component
+var supermicroPreserveSettings = map[string]map[string]interface{}{
+	"BMC": {
+		"BMC": map[string]bool{"PreserveCfg": true, "PreserveSdr": true, "PreserveSsl": true},
+	},
+	"BIOS": {
+		"BIOS": map[string]bool{"PreserveME": true, "PreserveNVRAM": true, "PreserveSMBIOS": true},
+	},
+}
+
+// downloadImage fetches the image of an update from an HTTP or HTTPS server
+var downloadImage = func(imageURI, username, password string) ([]byte, error) {
+	imageURL, err := url.Parse(imageURI)
+	if err != nil {
+		return nil, err
+	}
+	if imageURL.Scheme != "http" && imageURL.Scheme != "https" {
+		return nil, fmt.Errorf("the transfer protocol %s is not supported", imageURL.Scheme)
+	}
+	req, err := http.NewRequest(http.MethodGet, imageURI, nil)
+	if err != nil {
+		return nil, err
+	}
+	if username != "" {
+		req.SetBasicAuth(username, password)
+	}
+	client := &http.Client{Timeout: imageDownloadTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("the server returned %s", resp.Status)
+	}
+	image, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxImageSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(image) > maxImageSize {
+		return nil, fmt.Errorf("the image is larger than %d bytes", maxImageSize)
+	}
+	return image, nil
+}
+
+// supermicroSimpleUpdate updates the firmware of Supermicro BMCs, which do not
+// fetch the image given in ImageURI. The image is downloaded by the plugin and
+// pushed to the MultipartHttpPushUri of the update service, with the settings
+// which preserve the configuration of the BMC or of the BIOS being updated.
+// One component is updated by an image, so one target is accepted.
+func supermicroSimpleUpdate(ctx context.Context, device *sdkutilities.RedfishDevice, request *model.SimpleUpdate) (int, []byte) {
+	if len(request.Targets) != 1 {
+		log.Error(fmt.Sprintf("Supermicro BMCs update one target per image, got %d targets", len(request.Targets)))
+		return http.StatusBadRequest, createErrorBody(sdkresponse.MsgExtendedInfo{
+			MessageID:   response.PropertyValueFormatError,
+			Message:     fmt.Sprintf("The value '%v' for the property Targets is of a different format than the property can accept.", request.Targets),
+			MessageArgs: []string{fmt.Sprintf("%v", request.Targets), "Targets"},
+		})
+	}
+	target, component := supermicroUpdateTarget(ctx, device, request.Targets[0])
+	if target == "" {
+		log.Error("Unable to find the component to update for target " + request.Targets[0])
+		return http.StatusBadRequest, createErrorBody(sdkresponse.MsgExtendedInfo{
+			MessageID:   response.PropertyValueNotInList,
+			Message:     fmt.Sprintf("The value '%s' for the property Targets is not in the list of acceptable values.", request.Targets[0]),
+			MessageArgs: []string{request.Targets[0], "Targets"},
+		})
+	}
+
+	var updateService map[string]interface{}
+	if _, err := getDeviceResource(ctx, device, sdkhandler.TranslateToSouthBoundURL(serviceRootURI+"/UpdateService"), &updateService); err != nil {
+		errMsg := "While trying to get the update service, got: " + err.Error()
+		log.Error(errMsg)
+		return http.StatusInternalServerError, []byte(errMsg)
+	}
+	pushURI, _ := updateService["MultipartHttpPushUri"].(string)
+	if pushURI == "" {
+		log.Error("The update service of device " + device.Host + " does not support multipart updates")
+		return http.StatusBadRequest, createErrorBody(sdkresponse.MsgExtendedInfo{
+			MessageID:   response.ActionNotSupported,
+			Message:     "The action UpdateService.SimpleUpdate is not supported by the resource.",
+			MessageArgs: []string{"UpdateService.SimpleUpdate"},
+		})
+	}
+
+	image, err := downloadImage(request.ImageURI, request.Username, request.Password)
+	if err != nil {
+		errMsg := "Unable to download the image " + request.ImageURI + ": " + err.Error()
+		log.Error(errMsg)
+		return http.StatusBadRequest, createErrorBody(sdkresponse.MsgExtendedInfo{
+			MessageID: response.GeneralError,
+			Message:   errMsg,
+		})
+	}
+	parameters := map[string]interface{}{
+		"Targets":                     []string{target},
+		"@Redfish.OperationApplyTime": "Immediate",
+		"Oem":                         map[string]interface{}{"Supermicro": supermicroPreserveSettings[component]},
+	}
+	imageURL, _ := url.Parse(request.ImageURI)
+	body, contentType, err := createMultipartUpdate(parameters, path.Base(imageURL.Path), image)
+	if err != nil {
+		errMsg := "While trying to create the multipart update request, got: " + err.Error()
+		log.Error(errMsg)
+		return http.StatusInternalServerError, []byte(errMsg)
+	}
+	return postToDevice(device, pushURI, body, http.Header{"Content-Type": []string{contentType}})
+}
+
+// supermicroUpdateTarget returns the target of the multipart update and the
+// updated component, BMC or BIOS, for a target of SimpleUpdate: a manager,
+// a system or its BIOS, or a firmware inventory item related to one of them
+func supermicroUpdateTarget(ctx context.Context, device *sdkutilities.RedfishDevice, target string) (string, string) {
+	target = strings.TrimSuffix(target, "/")
+	lowerTarget := strings.ToLower(target)
+	switch {
+	case strings.Contains(lowerTarget, "/firmwareinventory/"):
+		var item struct {
+			RelatedItem []struct {
+				OdataID string `json:"@odata.id"`
+			} `json:"RelatedItem"`
+		}
+		if _, err := getDeviceResource(ctx, device, target, &item); err != nil {
+			log.Warn("Unable to get the firmware inventory item " + target + ": " + err.Error())
+			return "", ""
+		}
+		for _, related := range item.RelatedItem {
+			if !strings.Contains(strings.ToLower(related.OdataID), "/firmwareinventory/") {
+				if target, component := supermicroUpdateTarget(ctx, device, related.OdataID); target != "" {
+					return target, component
+				}
+			}
+		}
+	case strings.Contains(lowerTarget, "/managers/"):
+		return target, "BMC"
+	case strings.HasSuffix(lowerTarget, "/bios"):
+		return target, "BIOS"
+	case strings.Contains(lowerTarget, "/systems/"):
+		return target + "/Bios", "BIOS"
+	}
+	return "", ""
+}
+
+// createMultipartUpdate creates the body of a multipart update with the
+// UpdateParameters and the UpdateFile parts, and returns it with its content type
+func createMultipartUpdate(parameters map[string]interface{}, fileName string, image []byte) ([]byte, string, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	parametersHeader := make(textproto.MIMEHeader)
+	parametersHeader.Set("Content-Disposition", `form-data; name="UpdateParameters"`)
+	parametersHeader.Set("Content-Type", "application/json")
+	part, err := writer.CreatePart(parametersHeader)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := json.NewEncoder(part).Encode(parameters); err != nil {
+		return nil, "", err
+	}
+	part, err = writer.CreateFormFile("UpdateFile", fileName)
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err := part.Write(image); err != nil {
+		return nil, "", err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+	return body.Bytes(), writer.FormDataContentType(), nil
+}
@@ -0,0 +1,154 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package rfphandler ...
+package rfphandler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+)
+
+func mockDownloadImage(t *testing.T) {
+	download := downloadImage
+	downloadImage = func(imageURI, username, password string) ([]byte, error) {
+		if imageURI == "http://10.0.0.5/images/missing.bin" {
+			return nil, fmt.Errorf("the server returned 404 Not Found")
+		}
+		return []byte("image of " + imageURI), nil
+	}
+	t.Cleanup(func() { downloadImage = download })
+}
+
+// readMultipartUpdate returns the UpdateParameters and the UpdateFile of a multipart update
+func readMultipartUpdate(t *testing.T, request recordedRequest) (map[string]interface{}, string) {
+	_, params, err := mime.ParseMediaType(request.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("the update should be a multipart request, got %s", request.Header.Get("Content-Type"))
+	}
+	var parameters map[string]interface{}
+	var file string
+	reader := multipart.NewReader(bytes.NewReader(request.Body), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			break
+		}
+		data, _ := ioutil.ReadAll(part)
+		switch part.FormName() {
+		case "UpdateParameters":
+			json.Unmarshal(data, &parameters)
+		case "UpdateFile":
+			file = part.FileName() + ":" + string(data)
+		}
+	}
+	return parameters, file
+}
+
+func TestSupermicroSimpleUpdate(t *testing.T) {
+	// the targets are translated to the URIs of the device, which are under
+	// /ODIM with the mock configuration, and the related items are read from the device
+	tests := []struct {
+		name       string
+		target     string
+		wantTarget string
+		wantOem    map[string]interface{}
+	}{
+		{
+			name:       "manager",
+			target:     "/ODIM/v1/Managers/1",
+			wantTarget: "/ODIM/v1/Managers/1",
+			wantOem:    map[string]interface{}{"BMC": map[string]interface{}{"PreserveCfg": true, "PreserveSdr": true, "PreserveSsl": true}},
+		},
+		{
+			name:       "system",
+			target:     "/ODIM/v1/Systems/1",
+			wantTarget: "/ODIM/v1/Systems/1/Bios",
+			wantOem:    map[string]interface{}{"BIOS": map[string]interface{}{"PreserveME": true, "PreserveNVRAM": true, "PreserveSMBIOS": true}},
+		},
+		{
+			name:       "firmware inventory",
+			target:     "/ODIM/v1/UpdateService/FirmwareInventory/BIOS",
+			wantTarget: "/redfish/v1/Systems/1/Bios",
+			wantOem:    map[string]interface{}{"BIOS": map[string]interface{}{"PreserveME": true, "PreserveNVRAM": true, "PreserveSMBIOS": true}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDownloadImage(t)
+			server := startMockupServer(t, "supermicro-x12")
+			e := newMockupTest(t)
+			request := map[string]interface{}{"ImageURI": "http://10.0.0.5/images/X12_firmware.bin", "Targets": []string{tt.target}}
+			e.POST("/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate").WithJSON(mockupDevice(request)).Expect().Status(http.StatusAccepted)
+
+			requests := server.recorded()
+			if len(requests) != 1 || requests[0].Path != "/redfish/v1/UpdateService/upload" {
+				t.Fatalf("the image should be pushed to the MultipartHttpPushUri, got %v", requests)
+			}
+			parameters, file := readMultipartUpdate(t, requests[0])
+			wantParameters := map[string]interface{}{
+				"Targets":                     []interface{}{tt.wantTarget},
+				"@Redfish.OperationApplyTime": "Immediate",
+				"Oem":                         map[string]interface{}{"Supermicro": tt.wantOem},
+			}
+			if !reflect.DeepEqual(parameters, wantParameters) {
+				t.Errorf("UpdateParameters = %v, want %v", parameters, wantParameters)
+			}
+			if want := "X12_firmware.bin:image of http://10.0.0.5/images/X12_firmware.bin"; file != want {
+				t.Errorf("UpdateFile = %s, want %s", file, want)
+			}
+		})
+	}
+}
+
+func TestSupermicroSimpleUpdateErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  map[string]interface{}
+		wantCode string
+	}{
+		{"no target", map[string]interface{}{"ImageURI": "http://10.0.0.5/images/X12_firmware.bin"}, response.PropertyValueFormatError},
+		{"unknown target", map[string]interface{}{"ImageURI": "http://10.0.0.5/images/X12_firmware.bin", "Targets": []string{"/ODIM/v1/Chassis/1"}}, response.PropertyValueNotInList},
+		{"image not downloaded", map[string]interface{}{"ImageURI": "http://10.0.0.5/images/missing.bin", "Targets": []string{"/ODIM/v1/Managers/1"}}, response.GeneralError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDownloadImage(t)
+			server := startMockupServer(t, "supermicro-x12")
+			e := newMockupTest(t)
+			body := e.POST("/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate").WithJSON(mockupDevice(tt.request)).Expect().Status(http.StatusBadRequest).JSON().Object().Raw()
+			if got := messageIDs(body); !reflect.DeepEqual(got, []string{tt.wantCode}) {
+				t.Errorf("messages = %v, want %s", got, tt.wantCode)
+			}
+			if len(server.recorded()) != 0 {
+				t.Errorf("no update should be sent to the device, got %v", server.recorded())
+			}
+		})
+	}
+}
+
+func TestDownloadImage(t *testing.T) {
+	if _, err := downloadImage("ftp://10.0.0.5/images/X12_firmware.bin", "", ""); err == nil {
+		t.Error("the images should be downloaded with HTTP or HTTPS only")
+	}
+}
@@ -19,12 +19,11 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
-	"strings"
 
 	"github.com/ODIM-Project/ODIM/lib-dmtf/model"
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-redfish/config"
-	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpmodel"
-	"github.com/ODIM-Project/ODIM/plugin-redfish/rfputilities"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkhandler"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkmodel"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
 	iris "github.com/kataras/iris/v12"
 	log "github.com/sirupsen/logrus"
 )
@@ -35,7 +34,7 @@ func SimpleUpdate(ctx iris.Context) {
 	token := ctx.GetHeader("X-Auth-Token")
 	//Validating the token
 	if token != "" {
-		flag := TokenValidation(token)
+		flag := sdkhandler.TokenValidation(token)
 		if !flag {
 			log.Error("Invalid/Expired X-Auth-Token")
 			ctx.StatusCode(http.StatusUnauthorized)
@@ -43,7 +42,7 @@ func SimpleUpdate(ctx iris.Context) {
 			return
 		}
 	}
-	var deviceDetails rfpmodel.Device
+	var deviceDetails sdkmodel.Device
 	uri := ctx.Request().RequestURI
 	//Get device details from request
 	reqPostBody := &model.SimpleUpdate{}
@@ -61,6 +60,22 @@ func SimpleUpdate(ctx iris.Context) {
 		log.Error(errMsg)
 		return
 	}
+	device := &sdkutilities.RedfishDevice{
+		Host:     deviceDetails.Host,
+		Username: deviceDetails.Username,
+		Password: string(deviceDetails.Password),
+	}
+	// the vendors which do not support the standard action translate the request
+	if profile := getVendorProfile(ctx.Request().Context(), device); profile.simpleUpdate != nil {
+		for i, target := range reqPostBody.Targets {
+			reqPostBody.Targets[i] = sdkhandler.TranslateToSouthBoundURL(target)
+		}
+		statusCode, body := profile.simpleUpdate(ctx.Request().Context(), device, reqPostBody)
+		ctx.StatusCode(statusCode)
+		ctx.ContentType("application/json")
+		ctx.Write([]byte(sdkhandler.TranslateToNorthBoundURL(string(body))))
+		return
+	}
 	reqPostBody.Targets = nil
 	deviceDetails.PostBody, err = json.Marshal(reqPostBody)
 	if err != nil {
@@ -68,20 +83,11 @@ func SimpleUpdate(ctx iris.Context) {
 		log.Error(errMsg)
 		return
 	}
-	var reqData string
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-		reqData = strings.Replace(string(deviceDetails.PostBody), key, value, -1)
-	}
-
-	device := &rfputilities.RedfishDevice{
-		Host:     deviceDetails.Host,
-		Username: deviceDetails.Username,
-		Password: string(deviceDetails.Password),
-		PostBody: []byte(reqData),
-	}
-	redfishClient, err := rfputilities.GetRedfishClient()
+	uri = sdkhandler.TranslateToSouthBoundURL(uri)
+	reqData := sdkhandler.TranslateToSouthBoundURL(string(deviceDetails.PostBody))
+	device.PostBody = []byte(reqData)
+	redfishClient, err := sdkutilities.GetRedfishClient()
 	if err != nil {
 		errMsg := "While trying to create the redfish client, got:" + err.Error()
 		log.Error(errMsg)
@@ -116,7 +122,7 @@ func StartUpdate(ctx iris.Context) {
 	token := ctx.GetHeader("X-Auth-Token")
 	//Validating the token
 	if token != "" {
-		flag := TokenValidation(token)
+		flag := sdkhandler.TokenValidation(token)
 		if !flag {
 			log.Error("Invalid/Expired X-Auth-Token")
 			ctx.StatusCode(http.StatusUnauthorized)
@@ -124,7 +130,7 @@ func StartUpdate(ctx iris.Context) {
 			return
 		}
 	}
-	var deviceDetails rfpmodel.Device
+	var deviceDetails sdkmodel.Device
 	uri := ctx.Request().RequestURI
 	//Get device details from request
 	err := ctx.ReadJSON(&deviceDetails)
@@ -136,13 +142,13 @@ func StartUpdate(ctx iris.Context) {
 		return
 	}
 
-	device := &rfputilities.RedfishDevice{
+	device := &sdkutilities.RedfishDevice{
 		Host:     deviceDetails.Host,
 		Username: deviceDetails.Username,
 		Password: string(deviceDetails.Password),
 	}
 
-	redfishClient, err := rfputilities.GetRedfishClient()
+	redfishClient, err := sdkutilities.GetRedfishClient()
 	if err != nil {
 		errMsg := "While trying to create the redfish client, got:" + err.Error()
 		log.Error(errMsg)
@@ -4,12 +4,13 @@ package rfphandler
 import (
 	"encoding/json"
 	"fmt"
-	"github.com/ODIM-Project/ODIM/plugin-redfish/config"
-	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpresponse"
-	iris "github.com/kataras/iris/v12"
-	"github.com/kataras/iris/v12/httptest"
 	"net/http"
 	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkresponse"
+	iris "github.com/kataras/iris/v12"
+	"github.com/kataras/iris/v12/httptest"
 )
 
 func mockSimpleUpdate(username, password, url string, w http.ResponseWriter) {
@@ -29,7 +30,7 @@ func TestSimpleUpdate(t *testing.T) {
 	redfishRoutes := mockApp.Party("/ODIM/v1")
 
 	redfishRoutes.Post("/UpdateService/Actions.SimpleUpdate", SimpleUpdate)
-	rfpresponse.PluginToken = "token"
+	sdkresponse.PluginToken = "token"
 	test := httptest.New(t, mockApp)
 	attributes := map[string]interface{}{"ImageUri": "abc",
 		"Targets": []string{"/ODIM/v1/Systems/uuid.1"}}
@@ -56,7 +57,7 @@ func TestStartUpdate(t *testing.T) {
 	redfishRoutes := mockApp.Party("/ODIM/v1")
 
 	redfishRoutes.Post("/UpdateService/Actions.StartUpdate", StartUpdate)
-	rfpresponse.PluginToken = "token"
+	sdkresponse.PluginToken = "token"
 	test := httptest.New(t, mockApp)
 	requestBody := map[string]interface{}{
 		"ManagerAddress": fmt.Sprintf("%s:%s", deviceHost, devicePort),
@@ -0,0 +1,237 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package rfphandler ...
+package rfphandler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkhandler"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkresponse"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	log "github.com/sirupsen/logrus"
+)
+
+// serviceRootURI is the service root of the devices, the URIs built by the
+// plugin are translated to the south bound URL like the request URIs
+const serviceRootURI = "/ODIM/v1"
+
+// vendorCacheTTL is the time a detected vendor or a fetched attribute
+// registry is kept for a device
+const vendorCacheTTL = time.Hour
+
+// vendorProfile holds the OEM specifics of the BMCs of a vendor which are
+// translated by the plugin, the generic profile is used for the other BMCs
+type vendorProfile struct {
+	name string
+	// vendors are the values of the Vendor property of the service root of the BMCs
+	vendors []string
+	// oemKeys are the keys of the vendor in the Oem property of the service root,
+	// for the BMCs which do not report the Vendor property
+	oemKeys []string
+	// validateBiosAttributes checks the BIOS attributes against the attribute
+	// registry of the BIOS before they are sent to the device
+	validateBiosAttributes bool
+	// ifMatchRequired sends the ETag of the BIOS settings object in If-Match
+	// with the PATCH of the BIOS settings
+	ifMatchRequired bool
+	// simpleUpdate replaces UpdateService.SimpleUpdate of the device,
+	// the standard action is used when it is nil
+	simpleUpdate func(ctx context.Context, device *sdkutilities.RedfishDevice, request *model.SimpleUpdate) (int, []byte)
+}
+
+var (
+	genericProfile = &vendorProfile{name: "Generic"}
+	hpeProfile     = &vendorProfile{
+		name:                   "HPE",
+		vendors:                []string{"HPE", "Hewlett Packard Enterprise"},
+		oemKeys:                []string{"Hpe", "Hp"},
+		validateBiosAttributes: true,
+		simpleUpdate:           hpeSimpleUpdate,
+	}
+	supermicroProfile = &vendorProfile{
+		name:                   "Supermicro",
+		vendors:                []string{"Supermicro"},
+		oemKeys:                []string{"Supermicro"},
+		validateBiosAttributes: true,
+		ifMatchRequired:        true,
+		simpleUpdate:           supermicroSimpleUpdate,
+	}
+	vendorProfiles = []*vendorProfile{hpeProfile, supermicroProfile}
+)
+
+// deviceCache holds the values fetched once from the devices, like their
+// vendor, for vendorCacheTTL
+type deviceCache struct {
+	mutex   sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	value  interface{}
+	expiry time.Time
+}
+
+func newDeviceCache() *deviceCache {
+	return &deviceCache{entries: make(map[string]cacheEntry)}
+}
+
+func (c *deviceCache) get(key string) (interface{}, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expiry) {
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func (c *deviceCache) set(key string, value interface{}) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	now := time.Now()
+	for k, entry := range c.entries {
+		if now.After(entry.expiry) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = cacheEntry{value: value, expiry: now.Add(vendorCacheTTL)}
+}
+
+var deviceVendors = newDeviceCache()
+
+// getVendorProfile returns the profile of the vendor of the device, detected
+// from its service root. The generic profile is returned when the service root
+// could not be read, and the detection is tried again with the next request.
+func getVendorProfile(ctx context.Context, device *sdkutilities.RedfishDevice) *vendorProfile {
+	if profile, ok := deviceVendors.get(device.Host); ok {
+		return profile.(*vendorProfile)
+	}
+	var serviceRoot map[string]interface{}
+	_, err := getDeviceResource(ctx, device, sdkhandler.TranslateToSouthBoundURL(serviceRootURI), &serviceRoot)
+	if err != nil {
+		log.Warn("Unable to detect the vendor of device " + device.Host + ": " + err.Error())
+		return genericProfile
+	}
+	profile := detectVendor(serviceRoot)
+	log.Info("Using the " + profile.name + " profile for device " + device.Host)
+	deviceVendors.set(device.Host, profile)
+	return profile
+}
+
+// detectVendor returns the profile matching the Vendor or the Oem property of the service root
+func detectVendor(serviceRoot map[string]interface{}) *vendorProfile {
+	vendor, _ := serviceRoot["Vendor"].(string)
+	oem, _ := serviceRoot["Oem"].(map[string]interface{})
+	for _, profile := range vendorProfiles {
+		for _, name := range profile.vendors {
+			if strings.EqualFold(vendor, name) {
+				return profile
+			}
+		}
+		for _, key := range profile.oemKeys {
+			if _, ok := oem[key]; ok {
+				return profile
+			}
+		}
+	}
+	return genericProfile
+}
+
+// getDeviceResource fetches a resource from the device and decodes it in resource
+func getDeviceResource(ctx context.Context, device *sdkutilities.RedfishDevice, uri string, resource interface{}) (http.Header, error) {
+	// the request body of the device is not sent with the GET
+	getDevice := *device
+	getDevice.PostBody = nil
+	statusCode, header, body, err := sdkhandler.QueryDevice(ctx, uri, &getDevice, http.MethodGet)
+	if err != nil {
+		return nil, err
+	}
+	if statusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET on %s returned %d: %s", uri, statusCode, string(body))
+	}
+	if err := json.Unmarshal(body, resource); err != nil {
+		return nil, fmt.Errorf("unable to decode %s: %s", uri, err.Error())
+	}
+	return header, nil
+}
+
+// postToDevice posts the body to the URI of the device with the given headers,
+// and returns the status code and the body of the response of the device
+func postToDevice(device *sdkutilities.RedfishDevice, uri string, body []byte, header http.Header) (int, []byte) {
+	redfishClient, err := sdkutilities.GetRedfishClient()
+	if err != nil {
+		errMsg := "While trying to create the redfish client, got:" + err.Error()
+		log.Error(errMsg)
+		return http.StatusInternalServerError, []byte(errMsg)
+	}
+	postDevice := *device
+	postDevice.PostBody = body
+	resp, err := redfishClient.DeviceCallWithHeader(&postDevice, uri, http.MethodPost, header)
+	if err != nil {
+		errMsg := "While trying to post to " + uri + ", got: " + err.Error()
+		log.Error(errMsg)
+		if resp == nil {
+			return http.StatusInternalServerError, []byte(errMsg)
+		}
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		respBody = []byte("While trying to read the response body, got: " + err.Error())
+		log.Error(string(respBody))
+	}
+	return resp.StatusCode, respBody
+}
+
+// getOdataID returns the @odata.id of the link in the property of the resource
+func getOdataID(resource map[string]interface{}, property string) string {
+	link, _ := resource[property].(map[string]interface{})
+	id, _ := link["@odata.id"].(string)
+	return id
+}
+
+// isSameURI compares two URIs of a device, the BMCs do not agree on
+// the case and on the trailing slash of the URIs
+func isSameURI(uri1, uri2 string) bool {
+	return strings.EqualFold(strings.TrimSuffix(uri1, "/"), strings.TrimSuffix(uri2, "/"))
+}
+
+// createErrorBody creates a Redfish error response with the given messages of the Base registry
+func createErrorBody(messages ...sdkresponse.MsgExtendedInfo) []byte {
+	for i := range messages {
+		if messages[i].MessageArgs == nil {
+			messages[i].MessageArgs = []string{}
+		}
+	}
+	resp := sdkresponse.ErrorResponse{
+		Error: sdkresponse.Error{
+			Code:                response.GeneralError,
+			Message:             "See @Message.ExtendedInfo for more information.",
+			MessageExtendedInfo: messages,
+		},
+	}
+	body, _ := json.Marshal(resp)
+	return body
+}
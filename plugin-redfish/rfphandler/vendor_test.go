@@ -0,0 +1,217 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package rfphandler ...
+package rfphandler
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	testhttp "net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/config"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkresponse"
+	"github.com/ODIM-Project/ODIM/lib-plugin-sdk/sdkutilities"
+	iris "github.com/kataras/iris/v12"
+	"github.com/kataras/iris/v12/httptest"
+)
+
+// recordedRequest is a request received by the mockup server which is not a GET
+type recordedRequest struct {
+	Method string
+	Path   string
+	Header http.Header
+	Body   []byte
+}
+
+// mockupServer serves a recorded mockup of a BMC at localhost:1234
+type mockupServer struct {
+	*testhttp.Server
+	mutex     sync.Mutex
+	resources map[string][]byte
+	requests  []recordedRequest
+}
+
+// mockupKey returns the key of a resource of the mockup, the BMCs and the
+// plugin do not agree on the case and on the trailing slash of the URIs,
+// and the mock plugin configuration translates /redfish to /ODIM
+func mockupKey(uri string) string {
+	uri = strings.Replace(uri, "/ODIM/v1", "/redfish/v1", 1)
+	return strings.ToLower(strings.TrimSuffix(uri, "/"))
+}
+
+func startMockupServer(t *testing.T, mockup string) *mockupServer {
+	config.SetUpMockConfig(t)
+	// the vendor of localhost:1234 is detected again by the other tests
+	resetDeviceCaches := func() {
+		deviceVendors = newDeviceCache()
+		biosRegistries = newDeviceCache()
+	}
+	resetDeviceCaches()
+	t.Cleanup(resetDeviceCaches)
+	server := &mockupServer{resources: make(map[string][]byte)}
+	root := filepath.Join("testdata", mockup)
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.Name() != "index.json" {
+			return err
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		uri := "/redfish/v1/" + filepath.ToSlash(filepath.Dir(strings.TrimPrefix(path, root+string(filepath.Separator))))
+		server.resources[mockupKey(strings.TrimSuffix(uri, "/."))] = data
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listener, err := net.Listen("tcp", "localhost:1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.Server = testhttp.NewUnstartedServer(http.HandlerFunc(server.serveHTTP))
+	server.Listener.Close()
+	server.Listener = listener
+	cert, err := tls.X509KeyPair(hostCert, hostPrivKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{cert}}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server
+}
+
+func (s *mockupServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	resource, ok := s.resources[mockupKey(r.URL.Path)]
+	if r.Method != http.MethodGet {
+		body, _ := ioutil.ReadAll(r.Body)
+		s.requests = append(s.requests, recordedRequest{Method: r.Method, Path: r.URL.Path, Header: r.Header.Clone(), Body: body})
+		switch {
+		case r.Method == http.MethodPatch && ok:
+			var current map[string]interface{}
+			json.Unmarshal(resource, &current)
+			if etag, _ := current["@odata.etag"].(string); etag != "" && strings.Contains(r.URL.Path, "/SD") && r.Header.Get("If-Match") != etag {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/upload"):
+			w.Header().Set("Location", "/redfish/v1/TaskService/Tasks/1")
+			w.WriteHeader(http.StatusAccepted)
+		case r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+		fmt.Fprint(w, `{"Method":"`+r.Method+`"}`)
+		return
+	}
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	var current map[string]interface{}
+	json.Unmarshal(resource, &current)
+	if etag, _ := current["@odata.etag"].(string); etag != "" {
+		w.Header().Set("ETag", etag)
+	}
+	w.Write(resource)
+}
+
+func (s *mockupServer) recorded() []recordedRequest {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]recordedRequest(nil), s.requests...)
+}
+
+// newMockupTest returns the test client of a plugin with the routes of the vendor specific handlers
+func newMockupTest(t *testing.T) *httptest.Expect {
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1")
+	redfishRoutes.Get("/Systems/{id}/Bios/Settings", GetResource)
+	redfishRoutes.Patch("/Systems/{id}/Bios/Settings", ChangeSettings)
+	redfishRoutes.Post("/Systems/{id}/Actions/Oem/{action:path}", OemAction)
+	redfishRoutes.Post("/UpdateService/Actions/UpdateService.SimpleUpdate", SimpleUpdate)
+	sdkresponse.PluginToken = "token"
+	return httptest.New(t, mockApp)
+}
+
+// mockupDevice returns the device details sent by ODIM for the mockup server
+func mockupDevice(postBody interface{}) map[string]interface{} {
+	body, _ := json.Marshal(postBody)
+	return map[string]interface{}{
+		"ManagerAddress": "localhost:1234",
+		"UserName":       "admin",
+		"Password":       []byte("P@$$w0rd"),
+		"PostBody":       body,
+	}
+}
+
+func TestDetectVendor(t *testing.T) {
+	tests := []struct {
+		name        string
+		serviceRoot map[string]interface{}
+		want        *vendorProfile
+	}{
+		{"iLO 5", map[string]interface{}{"Vendor": "HPE"}, hpeProfile},
+		{"iLO 4", map[string]interface{}{"Oem": map[string]interface{}{"Hp": map[string]interface{}{}}}, hpeProfile},
+		{"Supermicro", map[string]interface{}{"Vendor": "Supermicro"}, supermicroProfile},
+		{"Supermicro without Vendor", map[string]interface{}{"Oem": map[string]interface{}{"Supermicro": map[string]interface{}{}}}, supermicroProfile},
+		{"other vendor", map[string]interface{}{"Vendor": "Contoso"}, genericProfile},
+		{"no vendor", map[string]interface{}{}, genericProfile},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectVendor(tt.serviceRoot); got != tt.want {
+				t.Errorf("detectVendor() = %s, want %s", got.name, tt.want.name)
+			}
+		})
+	}
+}
+
+func TestGetVendorProfile(t *testing.T) {
+	startMockupServer(t, "supermicro-x12")
+	device := &sdkutilities.RedfishDevice{Host: "localhost:1234", Username: "admin", Password: "P@$$w0rd"}
+	ctx := context.Background()
+	if got := getVendorProfile(ctx, device); got != supermicroProfile {
+		t.Errorf("getVendorProfile() = %s, want Supermicro", got.name)
+	}
+	if _, cached := deviceVendors.get(device.Host); !cached {
+		t.Error("the vendor of the device should be cached")
+	}
+
+	// the detection is tried again when the device is not reachable
+	unreachable := &sdkutilities.RedfishDevice{Host: "localhost:1", Username: "admin", Password: "P@$$w0rd"}
+	if got := getVendorProfile(ctx, unreachable); got != genericProfile {
+		t.Errorf("getVendorProfile() = %s, want Generic", got.name)
+	}
+	if _, cached := deviceVendors.get(unreachable.Host); cached {
+		t.Error("the vendor of an unreachable device should not be cached")
+	}
+}
@@ -0,0 +1,308 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package account ...
+package account
+
+// ---------------------------------------------------------------------------------------
+// IMPORT Section
+// ---------------------------------------------------------------------------------------
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	accountproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/account"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asresponse"
+	"github.com/ODIM-Project/ODIM/svc-account-session/auth"
+)
+
+const (
+	apiKeyType           = "#APIKey.v1_0_0.APIKey"
+	apiKeyCollectionType = "#APIKeyCollection.APIKeyCollection"
+	apiKeyCollectionURI  = "/redfish/v1/AccountService/APIKeys"
+)
+
+// CreateAPIKey defines creation of a new API key for a service account.
+//
+// The key is owned by the session user, or by the user given in the request which
+// needs ConfigureUsers privilege. The privileges of the key must be a subset of the
+// privileges of the owner's role, and when not given all of them are assigned.
+// The key itself is present only in the response of this request, DB holds the hash of it.
+func CreateAPIKey(ctx context.Context, req *accountproto.APIKeyRequest, session *asmodel.Session) response.RPC {
+	var createReq asmodel.CreateAPIKeyRequest
+	if err := json.Unmarshal(req.RequestBody, &createReq); err != nil {
+		errMsg := "error while trying to unmarshal the request body of create api key API: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, nil)
+	}
+	errorLogPrefix := fmt.Sprintf("failed to create api key %s: ", createReq.Name)
+
+	invalidProperties, err := common.RequestParamsCaseValidator(req.RequestBody, createReq)
+	if err != nil {
+		errMsg := errorLogPrefix + "error while validating request parameters: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	} else if invalidProperties != "" {
+		errorMessage := errorLogPrefix + "One or more properties given in the request body are not valid, ensure properties are listed in upper camel case "
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errorMessage, []interface{}{invalidProperties}, nil)
+	}
+	if createReq.Name == "" {
+		errorMessage := errorLogPrefix + "Mandatory field Name is empty"
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errorMessage, []interface{}{"Name"}, nil)
+	}
+
+	// an API key is not allowed to issue further keys, which would let it outlive its expiry
+	if session.Origin == auth.APIKeyOrigin {
+		errorMessage := errorLogPrefix + "api key can not be used to create another api key"
+		auth.CustomAuthLog(ctx, session.Token, errorMessage, http.StatusForbidden)
+		return common.GeneralError(http.StatusForbidden, response.InsufficientPrivilege, errorMessage, nil, nil)
+	}
+	if createReq.UserName == "" {
+		createReq.UserName = session.UserName
+	}
+	if !session.Privileges[common.PrivilegeConfigureUsers] {
+		if createReq.UserName != session.UserName || !session.Privileges[common.PrivilegeConfigureSelf] {
+			errorMessage := errorLogPrefix + session.UserName + " does not have the privilege to create api key for " + createReq.UserName
+			auth.CustomAuthLog(ctx, session.Token, errorMessage, http.StatusForbidden)
+			return common.GeneralError(http.StatusForbidden, response.InsufficientPrivilege, errorMessage, nil, nil)
+		}
+	}
+
+	user, gerr := asmodel.GetUserDetails(createReq.UserName)
+	if gerr != nil {
+		errorMessage := errorLogPrefix + gerr.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		if errors.DBKeyNotFound == gerr.ErrNo() {
+			return common.GeneralError(http.StatusBadRequest, response.ResourceNotFound, errorMessage, []interface{}{"Account", createReq.UserName}, nil)
+		}
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	role, gerr := asmodel.GetRoleDetailsByID(user.RoleID)
+	if gerr != nil {
+		errorMessage := errorLogPrefix + gerr.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	privileges := createReq.Privileges
+	if len(privileges) == 0 {
+		privileges = role.AssignedPrivileges
+	}
+	for _, privilege := range privileges {
+		if !isPrivilegeAssigned(privilege, role.AssignedPrivileges) {
+			errorMessage := errorLogPrefix + "privilege " + privilege + " is not assigned to the role " + role.ID
+			l.LogWithFields(ctx).Error(errorMessage)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errorMessage, []interface{}{privilege, "Privileges"}, nil)
+		}
+	}
+
+	apiKey := asmodel.APIKey{
+		ID:          uuid.NewV4().String(),
+		Name:        createReq.Name,
+		UserName:    user.UserName,
+		RoleID:      user.RoleID,
+		Privileges:  privileges,
+		CreatedTime: time.Now().UTC(),
+	}
+	if createReq.ExpirationTime != "" {
+		expirationTime, err := time.Parse(time.RFC3339, createReq.ExpirationTime)
+		if err != nil || !expirationTime.After(apiKey.CreatedTime) {
+			errorMessage := errorLogPrefix + "ExpirationTime should be a future time in RFC3339 format"
+			l.LogWithFields(ctx).Error(errorMessage)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errorMessage, []interface{}{createReq.ExpirationTime, "ExpirationTime"}, nil)
+		}
+		expirationTime = expirationTime.UTC()
+		apiKey.ExpirationTime = &expirationTime
+	}
+	key, err := auth.GenerateAPIKey(apiKey.ID)
+	if err != nil {
+		errorMessage := errorLogPrefix + "error while generating the key: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	apiKey.KeyHash = auth.HashAPIKey(key)
+
+	l.LogWithFields(ctx).Infof("Creating api key %s for the user %s", apiKey.ID, apiKey.UserName)
+	if cerr := asmodel.CreateAPIKey(apiKey); cerr != nil {
+		errorMessage := errorLogPrefix + cerr.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+
+	resp := response.RPC{
+		StatusCode:    http.StatusCreated,
+		StatusMessage: response.Created,
+		Header: map[string]string{
+			"Location": apiKeyCollectionURI + "/" + apiKey.ID,
+		},
+	}
+	body := buildAPIKeyResponse(apiKey, resp.StatusMessage)
+	body.Key = key
+	resp.Body = body
+	return resp
+}
+
+// GetAllAPIKeys lists the API keys. Users with ConfigureUsers privilege
+// can see all the keys, other users can see only the keys owned by them.
+func GetAllAPIKeys(ctx context.Context, session *asmodel.Session) response.RPC {
+	errLogPrefix := "failed to fetch api keys : "
+	keys, err := asmodel.GetAllAPIKeys()
+	if err != nil {
+		errorMessage := errLogPrefix + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+
+	members := []asresponse.ListMember{}
+	for _, key := range keys {
+		if !session.Privileges[common.PrivilegeConfigureUsers] && key.UserName != session.UserName {
+			continue
+		}
+		members = append(members, asresponse.ListMember{
+			OdataID: apiKeyCollectionURI + "/" + key.ID,
+		})
+	}
+
+	resp := response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+	}
+	commonResponse := response.Response{
+		OdataType:    apiKeyCollectionType,
+		OdataID:      apiKeyCollectionURI,
+		OdataContext: "/redfish/v1/$metadata#APIKeyCollection.APIKeyCollection",
+		Name:         "API Keys",
+	}
+	commonResponse.CreateGenericResponse(resp.StatusMessage)
+	commonResponse = mapEmptyValuesResponseFields(commonResponse)
+	resp.Body = asresponse.List{
+		Response:     commonResponse,
+		MembersCount: len(members),
+		Members:      members,
+	}
+	return resp
+}
+
+// GetAPIKey returns the details of an API key along with the time and
+// the source addresses of its recent usage.
+func GetAPIKey(ctx context.Context, session *asmodel.Session, keyID string) response.RPC {
+	errLogPrefix := fmt.Sprintf("failed to fetch the api key %s: ", keyID)
+	apiKey, resp := getAuthorizedAPIKey(ctx, session, keyID, errLogPrefix)
+	if apiKey == nil {
+		return resp
+	}
+
+	resp = response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+	}
+	resp.Body = buildAPIKeyResponse(*apiKey, resp.StatusMessage)
+	return resp
+}
+
+// DeleteAPIKey revokes an API key
+func DeleteAPIKey(ctx context.Context, session *asmodel.Session, keyID string) response.RPC {
+	errLogPrefix := fmt.Sprintf("failed to delete the api key %s: ", keyID)
+	apiKey, resp := getAuthorizedAPIKey(ctx, session, keyID, errLogPrefix)
+	if apiKey == nil {
+		return resp
+	}
+
+	l.LogWithFields(ctx).Infof("Deleting the api key %s of the user %s", apiKey.ID, apiKey.UserName)
+	if err := asmodel.DeleteAPIKey(apiKey.ID); err != nil {
+		errorMessage := errLogPrefix + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	return response.RPC{
+		StatusCode:    http.StatusNoContent,
+		StatusMessage: response.ResourceRemoved,
+	}
+}
+
+// getAuthorizedAPIKey fetches the API key after checking the session is allowed to access it.
+// When the key is not returned, the response holds the error to be sent back.
+func getAuthorizedAPIKey(ctx context.Context, session *asmodel.Session, keyID, errLogPrefix string) (*asmodel.APIKey, response.RPC) {
+	apiKey, err := asmodel.GetAPIKey(keyID)
+	if err != nil {
+		errorMessage := errLogPrefix + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		if errors.DBKeyNotFound == err.ErrNo() {
+			return nil, common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errorMessage, []interface{}{"APIKey", keyID}, nil)
+		}
+		return nil, common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	if !session.Privileges[common.PrivilegeConfigureUsers] && apiKey.UserName != session.UserName {
+		errorMessage := errLogPrefix + session.UserName + " does not have the privilege to access api keys of other users"
+		auth.CustomAuthLog(ctx, session.Token, errorMessage, http.StatusForbidden)
+		return nil, common.GeneralError(http.StatusForbidden, response.InsufficientPrivilege, errorMessage, nil, nil)
+	}
+	return &apiKey, response.RPC{}
+}
+
+func buildAPIKeyResponse(apiKey asmodel.APIKey, statusMessage string) asresponse.APIKey {
+	commonResponse := response.Response{
+		OdataType:    apiKeyType,
+		OdataID:      apiKeyCollectionURI + "/" + apiKey.ID,
+		OdataContext: "/redfish/v1/$metadata#APIKey.APIKey",
+		ID:           apiKey.ID,
+		Name:         apiKey.Name,
+	}
+	commonResponse.CreateGenericResponse(statusMessage)
+	commonResponse = mapEmptyValuesResponseFields(commonResponse)
+	body := asresponse.APIKey{
+		Response:    commonResponse,
+		UserName:    apiKey.UserName,
+		RoleID:      apiKey.RoleID,
+		Privileges:  apiKey.Privileges,
+		CreatedTime: apiKey.CreatedTime.Format(time.RFC3339),
+		LastUsedIP:  apiKey.LastUsedIP,
+		SourceIPs:   apiKey.SourceIPs,
+		Links: asresponse.APIKeyLinks{
+			Account: asresponse.Role{
+				OdataID: "/redfish/v1/AccountService/Accounts/" + apiKey.UserName,
+			},
+			Role: asresponse.Role{
+				OdataID: "/redfish/v1/AccountService/Roles/" + apiKey.RoleID,
+			},
+		},
+	}
+	if apiKey.ExpirationTime != nil {
+		body.ExpirationTime = apiKey.ExpirationTime.Format(time.RFC3339)
+	}
+	if apiKey.LastUsedTime != nil {
+		body.LastUsedTime = apiKey.LastUsedTime.UTC().Format(time.RFC3339)
+	}
+	return body
+}
+
+func isPrivilegeAssigned(privilege string, assignedPrivileges []string) bool {
+	for _, assigned := range assignedPrivileges {
+		if assigned == privilege {
+			return true
+		}
+	}
+	return false
+}
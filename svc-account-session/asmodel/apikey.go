@@ -0,0 +1,125 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package asmodel ...
+package asmodel
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+)
+
+const apiKeyTable = "APIKey"
+
+// APIKey is the model for an API key issued to a service account.
+// Only the hash of the key is persisted, the key itself is returned to the
+// client once at the time of creation.
+type APIKey struct {
+	ID             string     `json:"Id"`
+	Name           string     `json:"Name"`
+	KeyHash        string     `json:"KeyHash"`
+	UserName       string     `json:"UserName"`
+	RoleID         string     `json:"RoleId"`
+	Privileges     []string   `json:"Privileges"`
+	CreatedTime    time.Time  `json:"CreatedTime"`
+	ExpirationTime *time.Time `json:"ExpirationTime,omitempty"`
+	LastUsedTime   *time.Time `json:"LastUsedTime,omitempty"`
+	LastUsedIP     string     `json:"LastUsedIP,omitempty"`
+	SourceIPs      []string   `json:"SourceIPs,omitempty"`
+}
+
+// CreateAPIKeyRequest is the model for creating an API key
+type CreateAPIKeyRequest struct {
+	Name           string   `json:"Name"`
+	UserName       string   `json:"UserName"`
+	Privileges     []string `json:"Privileges"`
+	ExpirationTime string   `json:"ExpirationTime"`
+}
+
+// CreateAPIKey will insert the API key details into the DB
+func CreateAPIKey(key APIKey) *errors.Error {
+	conn, err := GetDBConnectionFunc(common.OnDisk)
+	if err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to connecting to DB: ", err.Error())
+	}
+	if err = conn.Create(apiKeyTable, key.ID, key); err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to create api key: ", err.Error())
+	}
+	return nil
+}
+
+// GetAPIKey will fetch the details of a specific API key from the DB
+func GetAPIKey(keyID string) (APIKey, *errors.Error) {
+	var key APIKey
+	conn, err := GetDBConnectionFunc(common.OnDisk)
+	if err != nil {
+		return key, errors.PackError(err.ErrNo(), "error while trying to connecting to DB: ", err.Error())
+	}
+	data, err := conn.Read(apiKeyTable, keyID)
+	if err != nil {
+		return key, errors.PackError(err.ErrNo(), "error while trying to get api key: ", err.Error())
+	}
+	if jerr := json.Unmarshal([]byte(data), &key); jerr != nil {
+		return key, errors.PackError(errors.UndefinedErrorType, "error while trying to unmarshal api key: ", jerr)
+	}
+	return key, nil
+}
+
+// GetAllAPIKeys will fetch all the API keys from the DB
+func GetAllAPIKeys() ([]APIKey, *errors.Error) {
+	conn, err := GetDBConnectionFunc(common.OnDisk)
+	if err != nil {
+		return nil, errors.PackError(err.ErrNo(), "error while trying to connecting to DB: ", err.Error())
+	}
+	keyIDs, err := conn.GetAllDetails(apiKeyTable)
+	if err != nil {
+		return nil, err
+	}
+	var keys []APIKey
+	for _, keyID := range keyIDs {
+		key, err := GetAPIKey(keyID)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// UpdateAPIKey will update the API key details in the DB
+func UpdateAPIKey(key APIKey) *errors.Error {
+	conn, err := GetDBConnectionFunc(common.OnDisk)
+	if err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to connecting to DB: ", err.Error())
+	}
+	if _, err = conn.Update(apiKeyTable, key.ID, key); err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to update api key: ", err.Error())
+	}
+	return nil
+}
+
+// DeleteAPIKey will delete the API key from the DB
+func DeleteAPIKey(keyID string) *errors.Error {
+	conn, err := GetDBConnectionFunc(common.OnDisk)
+	if err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to connecting to DB: ", err.Error())
+	}
+	if err = conn.Delete(apiKeyTable, keyID); err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to delete api key: ", err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package asmodel ...
+package asmodel
+
+import (
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-persistence-manager/persistencemgr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+)
+
+var apiKey = APIKey{
+	ID:         "someKeyID",
+	Name:       "someKey",
+	KeyHash:    "someHash",
+	UserName:   "someUser",
+	RoleID:     "someRole",
+	Privileges: []string{"Login"},
+}
+
+func TestAPIKey(t *testing.T) {
+	common.SetUpMockConfig()
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+	}()
+	GetDBConnectionFunc = func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+		return common.GetDBConnection(dbFlag)
+	}
+	err := CreateAPIKey(apiKey)
+	assert.Nil(t, err, "There should be no error")
+	err = CreateAPIKey(apiKey)
+	assert.NotNil(t, err, "There should be an error for duplicate key")
+
+	key, err := GetAPIKey(apiKey.ID)
+	assert.Nil(t, err, "There should be no error")
+	assert.Equal(t, apiKey.KeyHash, key.KeyHash)
+
+	key.LastUsedIP = "10.0.0.1"
+	err = UpdateAPIKey(key)
+	assert.Nil(t, err, "There should be no error")
+
+	keys, err := GetAllAPIKeys()
+	assert.Nil(t, err, "There should be no error")
+	assert.Equal(t, []APIKey{key}, keys)
+
+	err = DeleteAPIKey(apiKey.ID)
+	assert.Nil(t, err, "There should be no error")
+	_, err = GetAPIKey(apiKey.ID)
+	assert.Equal(t, errors.DBKeyNotFound, err.ErrNo(), "Key should be deleted")
+}
+
+func TestAPIKeyDBError(t *testing.T) {
+	GetDBConnectionFunc = func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+		return nil, &errors.Error{}
+	}
+	dbErr := errors.PackError(0, "error while trying to connecting to DB: ", "")
+	assert.Equal(t, dbErr, CreateAPIKey(apiKey))
+	assert.Equal(t, dbErr, UpdateAPIKey(apiKey))
+	assert.Equal(t, dbErr, DeleteAPIKey(apiKey.ID))
+	_, err := GetAPIKey(apiKey.ID)
+	assert.Equal(t, dbErr, err)
+	_, err = GetAllAPIKeys()
+	assert.Equal(t, dbErr, err)
+}
@@ -0,0 +1,41 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package asresponse ...
+package asresponse
+
+import (
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+)
+
+// APIKey struct definition, the Key is filled only in the response of the create request
+type APIKey struct {
+	response.Response
+	Key            string      `json:"Key,omitempty"`
+	UserName       string      `json:"UserName"`
+	RoleID         string      `json:"RoleId"`
+	Privileges     []string    `json:"Privileges"`
+	CreatedTime    string      `json:"CreatedTime"`
+	ExpirationTime string      `json:"ExpirationTime,omitempty"`
+	LastUsedTime   string      `json:"LastUsedTime,omitempty"`
+	LastUsedIP     string      `json:"LastUsedIP,omitempty"`
+	SourceIPs      []string    `json:"SourceIPs,omitempty"`
+	Links          APIKeyLinks `json:"Links"`
+}
+
+// APIKeyLinks struct definition
+type APIKeyLinks struct {
+	Account Role `json:"Account"`
+	Role    Role `json:"Role"`
+}
@@ -0,0 +1,193 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package auth ...
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	// APIKeyPrefix is the prefix which distinguishes an API key from a session token
+	APIKeyPrefix = "odim_"
+	// APIKeyOrigin is set as the origin of the sessions derived from an API key
+	APIKeyOrigin = "APIKey"
+
+	apiKeySecretLength = 32
+	// maxAPIKeySourceIPs is the number of distinct source addresses remembered for a key
+	maxAPIKeySourceIPs = 10
+	// apiKeyUsageUpdateInterval limits how often the last used details are written to DB
+	apiKeyUsageUpdateInterval = time.Minute
+)
+
+// apiKeyUsageLocks holds a lock per API key ID, so that the usage of a key is
+// recorded by one request at a time without serializing the other keys
+var apiKeyUsageLocks sync.Map
+
+// IsAPIKey checks whether the token passed in X-Auth-Token is an API key
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, APIKeyPrefix)
+}
+
+// GenerateAPIKey will generate a new API key for the key ID passed.
+// The key ID is embedded in the key so that the key details can be looked up
+// without storing the key itself.
+func GenerateAPIKey(keyID string) (string, error) {
+	secret := make([]byte, apiKeySecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return APIKeyPrefix + keyID + "." + base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// HashAPIKey returns the hash of the API key which is persisted in DB
+func HashAPIKey(key string) string {
+	hash := sha3.New512()
+	hash.Write([]byte(key))
+	return base64.URLEncoding.EncodeToString(hash.Sum(nil))
+}
+
+// getAPIKeyID extracts the key ID from an API key
+func getAPIKeyID(key string) (string, bool) {
+	keyID, secret, found := strings.Cut(strings.TrimPrefix(key, APIKeyPrefix), ".")
+	if !found || keyID == "" || secret == "" {
+		return "", false
+	}
+	return keyID, true
+}
+
+// getAPIKeyDetails will validate the API key and return the stored details of it
+func getAPIKeyDetails(key string) (*asmodel.APIKey, *errors.Error) {
+	keyID, ok := getAPIKeyID(key)
+	if !ok {
+		return nil, errors.PackError(errors.InvalidAuthToken, "error: api key is malformed")
+	}
+	apiKey, err := asmodel.GetAPIKey(keyID)
+	if err != nil {
+		if errors.DBConnFailed == err.ErrNo() {
+			return nil, err
+		}
+		return nil, errors.PackError(errors.InvalidAuthToken, "error while trying to get api key details: ", err.Error())
+	}
+	if subtle.ConstantTimeCompare([]byte(apiKey.KeyHash), []byte(HashAPIKey(key))) != 1 {
+		return nil, errors.PackError(errors.InvalidAuthToken, "error: api key is not matching")
+	}
+	if apiKey.ExpirationTime != nil && time.Now().After(*apiKey.ExpirationTime) {
+		return nil, errors.PackError(errors.InvalidAuthToken, "error: api key ", apiKey.ID, " is expired")
+	}
+	return &apiKey, nil
+}
+
+// checkAPIKey validates the API key and builds a session out of it.
+// The privileges of the session are the privileges of the key which are still
+// assigned to the role of the owner, so that a key never outlives a
+// revocation of the owner's privileges.
+// Every successful check is a usage of the key, which is recorded here with
+// the key details already read, instead of reading and matching the key again.
+func checkAPIKey(ctx context.Context, key string) (*asmodel.Session, *errors.Error) {
+	apiKey, err := getAPIKeyDetails(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := updateAPIKeyUsage(ctx, apiKey); err != nil {
+		l.LogWithFields(ctx).Error("failed to record the usage of api key " + apiKey.ID + ": " + err.Error())
+	}
+	user, err := asmodel.GetUserDetails(apiKey.UserName)
+	if err != nil {
+		if errors.DBConnFailed == err.ErrNo() {
+			return nil, err
+		}
+		return nil, errors.PackError(errors.InvalidAuthToken, "error: owner of the api key ", apiKey.ID, " is not available: ", err.Error())
+	}
+	role, err := asmodel.GetRoleDetailsByID(user.RoleID)
+	if err != nil {
+		return nil, errors.PackError(err.ErrNo(), "error while trying to get role details of the api key owner: ", err.Error())
+	}
+	rolePrivileges := make(map[string]bool, len(role.AssignedPrivileges))
+	for _, privilege := range role.AssignedPrivileges {
+		rolePrivileges[privilege] = true
+	}
+	privileges := make(map[string]bool, len(apiKey.Privileges))
+	for _, privilege := range apiKey.Privileges {
+		if rolePrivileges[privilege] {
+			privileges[privilege] = true
+		}
+	}
+	return &asmodel.Session{
+		ID:           apiKey.ID,
+		Token:        key,
+		UserName:     user.UserName,
+		RoleID:       user.RoleID,
+		Privileges:   privileges,
+		Origin:       APIKeyOrigin,
+		CreatedTime:  apiKey.CreatedTime,
+		LastUsedTime: time.Now(),
+	}, nil
+}
+
+// updateAPIKeyUsage records the time and the source address of the latest
+// usage of the API key. The source address is read from the request context.
+// apiKey holds the details read while matching the key, and the key is read
+// again only when its usage is to be written.
+func updateAPIKeyUsage(ctx context.Context, apiKey *asmodel.APIKey) *errors.Error {
+	now := time.Now()
+	clientIP, _ := ctx.Value(common.ClientIP).(string)
+	if isAPIKeyUsageRecorded(apiKey, clientIP, now) {
+		return nil
+	}
+	value, _ := apiKeyUsageLocks.LoadOrStore(apiKey.ID, &sync.Mutex{})
+	lock := value.(*sync.Mutex)
+	lock.Lock()
+	defer lock.Unlock()
+	// another request may have recorded the usage while this one was waiting
+	current, err := asmodel.GetAPIKey(apiKey.ID)
+	if err != nil {
+		return err
+	}
+	if isAPIKeyUsageRecorded(&current, clientIP, now) {
+		return nil
+	}
+	current.LastUsedTime = &now
+	if clientIP != "" {
+		current.LastUsedIP = clientIP
+		sourceIPs := []string{clientIP}
+		for _, ip := range current.SourceIPs {
+			if ip != clientIP && len(sourceIPs) < maxAPIKeySourceIPs {
+				sourceIPs = append(sourceIPs, ip)
+			}
+		}
+		current.SourceIPs = sourceIPs
+	}
+	return asmodel.UpdateAPIKey(current)
+}
+
+// isAPIKeyUsageRecorded checks whether the usage of the key from the client
+// was recorded recently enough not to be written again
+func isAPIKeyUsageRecorded(apiKey *asmodel.APIKey, clientIP string, now time.Time) bool {
+	return apiKey.LastUsedTime != nil && now.Sub(*apiKey.LastUsedTime) < apiKeyUsageUpdateInterval &&
+		(clientIP == "" || clientIP == apiKey.LastUsedIP)
+}
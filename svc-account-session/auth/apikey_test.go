@@ -0,0 +1,116 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateAPIKey(t *testing.T) {
+	key, err := GenerateAPIKey("someID")
+	assert.Nil(t, err, "There should be no error")
+	assert.True(t, IsAPIKey(key), "Generated key should be identified as api key")
+	keyID, ok := getAPIKeyID(key)
+	assert.True(t, ok, "Key ID should be extracted from the key")
+	assert.Equal(t, "someID", keyID, "Key ID should be embedded in the key")
+
+	anotherKey, _ := GenerateAPIKey("someID")
+	assert.NotEqual(t, key, anotherKey, "Keys should be random")
+	assert.Equal(t, HashAPIKey(key), HashAPIKey(key), "Hash should be deterministic")
+	assert.NotEqual(t, HashAPIKey(key), HashAPIKey(anotherKey), "Hash of different keys should differ")
+
+	for _, key := range []string{"someToken", APIKeyPrefix, APIKeyPrefix + "someID", APIKeyPrefix + ".secret", APIKeyPrefix + "someID."} {
+		_, ok := getAPIKeyID(key)
+		assert.False(t, ok, "Key ID should not be extracted from malformed key "+key)
+	}
+}
+
+func TestCheckAPIKey(t *testing.T) {
+	config.SetUpMockConfig(t)
+	Lock.Lock()
+	common.SetUpMockConfig()
+	Lock.Unlock()
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+		common.TruncateDB(common.InMemory)
+	}()
+	ctx := mockContext()
+	role := asmodel.Role{
+		ID:                 "someRole",
+		AssignedPrivileges: []string{common.PrivilegeLogin, common.PrivilegeConfigureSelf},
+	}
+	if err := role.Create(); err != nil {
+		t.Fatalf("Error in creating role: %v", err)
+	}
+	if err := asmodel.CreateUser(asmodel.User{UserName: "someUser", RoleID: role.ID}); err != nil {
+		t.Fatalf("Error in creating user: %v", err)
+	}
+	key, _ := GenerateAPIKey("validKey")
+	expiredKey, _ := GenerateAPIKey("expiredKey")
+	expirationTime := time.Now().Add(-time.Minute)
+	apiKeys := []asmodel.APIKey{
+		{
+			ID:         "validKey",
+			KeyHash:    HashAPIKey(key),
+			UserName:   "someUser",
+			RoleID:     role.ID,
+			Privileges: []string{common.PrivilegeLogin, common.PrivilegeConfigureUsers},
+		},
+		{
+			ID:             "expiredKey",
+			KeyHash:        HashAPIKey(expiredKey),
+			UserName:       "someUser",
+			RoleID:         role.ID,
+			Privileges:     []string{common.PrivilegeLogin},
+			ExpirationTime: &expirationTime,
+		},
+	}
+	for _, apiKey := range apiKeys {
+		if err := asmodel.CreateAPIKey(apiKey); err != nil {
+			t.Fatalf("Error in creating api key: %v", err)
+		}
+	}
+
+	session, err := CheckSessionTimeOut(ctx, key)
+	assert.Nil(t, err, "There should be no error")
+	assert.Equal(t, map[string]bool{common.PrivilegeLogin: true}, session.Privileges, "Privileges not assigned to the role should be dropped")
+	assert.Equal(t, APIKeyOrigin, session.Origin)
+
+	_, err = CheckSessionTimeOut(ctx, expiredKey)
+	assert.NotNil(t, err, "There should be an error for expired key")
+	_, err = CheckSessionTimeOut(ctx, APIKeyPrefix+"validKey.invalidSecret")
+	assert.NotNil(t, err, "There should be an error for invalid secret")
+
+	ctx = context.WithValue(ctx, common.ClientIP, "10.0.0.1")
+	_, err = CheckSessionTimeOut(ctx, key)
+	assert.Nil(t, err, "There should be no error")
+	apiKey, _ := asmodel.GetAPIKey("validKey")
+	assert.NotNil(t, apiKey.LastUsedTime, "Last used time should be recorded")
+	assert.Equal(t, []string{"10.0.0.1"}, apiKey.SourceIPs)
+
+	ctx = context.WithValue(ctx, common.ClientIP, "10.0.0.2")
+	_, err = CheckSessionTimeOut(ctx, key)
+	assert.Nil(t, err, "There should be no error")
+	apiKey, _ = asmodel.GetAPIKey("validKey")
+	assert.Equal(t, "10.0.0.2", apiKey.LastUsedIP, "A new source address should be recorded at once")
+	assert.Equal(t, []string{"10.0.0.2", "10.0.0.1"}, apiKey.SourceIPs)
+}
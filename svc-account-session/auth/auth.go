@@ -56,11 +56,15 @@ func Auth(ctx context.Context, req *authproto.AuthRequest) (int32, string) {
 		}
 		return status, message
 	}
-	session.LastUsedTime = time.Now()
-	// Update Session
-	if err = session.Update(); err != nil {
-		l.LogWithFields(ctx).Error("SessionToken update failed with error: " + err.Error())
-		return err.GetAuthStatusCodeAndMessage()
+	// the usage of an API key is recorded when the key is checked,
+	// there is no session to keep alive
+	if !IsAPIKey(req.SessionToken) {
+		session.LastUsedTime = time.Now()
+		// Update Session
+		if err = session.Update(); err != nil {
+			l.LogWithFields(ctx).Error("SessionToken update failed with error: " + err.Error())
+			return err.GetAuthStatusCodeAndMessage()
+		}
 	}
 
 	// if the service has all the privileges then return success
@@ -69,6 +69,9 @@ func CheckSessionTimeOut(ctx context.Context, sessionToken string) (*asmodel.Ses
 	if sessionToken == "" {
 		return nil, errors.PackError(errors.InvalidAuthToken, "error: no session token found in header")
 	}
+	if IsAPIKey(sessionToken) {
+		return checkAPIKey(ctx, sessionToken)
+	}
 	session, err := asmodel.GetSession(sessionToken)
 	if err != nil {
 		return nil, errors.PackError(err.ErrNo(), "error while trying to get session details", ": ", err.Error())
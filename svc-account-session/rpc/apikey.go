@@ -0,0 +1,98 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package rpc ...
+package rpc
+
+import (
+	"context"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	accountproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/account"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-account-session/account"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+)
+
+// helper functions
+var (
+	CreateAPIKeyFunc  = account.CreateAPIKey
+	GetAllAPIKeysFunc = account.GetAllAPIKeys
+	GetAPIKeyFunc     = account.GetAPIKey
+	DeleteAPIKeyFunc  = account.DeleteAPIKey
+)
+
+// CreateAPIKey defines the operations which handles the RPC request response
+// for creating an API key of a service account.
+func (a *Account) CreateAPIKey(ctx context.Context, req *accountproto.APIKeyRequest) (*accountproto.AccountResponse, error) {
+	return handleAPIKeyRequest(ctx, req.SessionToken, "create api key", func(sess *asmodel.Session) response.RPC {
+		return CreateAPIKeyFunc(ctx, req, sess)
+	})
+}
+
+// GetAllAPIKeys defines the operations which handles the RPC request response
+// for listing the API keys.
+func (a *Account) GetAllAPIKeys(ctx context.Context, req *accountproto.APIKeyRequest) (*accountproto.AccountResponse, error) {
+	return handleAPIKeyRequest(ctx, req.SessionToken, "get all api keys", func(sess *asmodel.Session) response.RPC {
+		return GetAllAPIKeysFunc(ctx, sess)
+	})
+}
+
+// GetAPIKey defines the operations which handles the RPC request response
+// for viewing an API key.
+func (a *Account) GetAPIKey(ctx context.Context, req *accountproto.APIKeyRequest) (*accountproto.AccountResponse, error) {
+	return handleAPIKeyRequest(ctx, req.SessionToken, "get api key", func(sess *asmodel.Session) response.RPC {
+		return GetAPIKeyFunc(ctx, sess, req.KeyID)
+	})
+}
+
+// DeleteAPIKey defines the operations which handles the RPC request response
+// for revoking an API key.
+func (a *Account) DeleteAPIKey(ctx context.Context, req *accountproto.APIKeyRequest) (*accountproto.AccountResponse, error) {
+	return handleAPIKeyRequest(ctx, req.SessionToken, "delete api key", func(sess *asmodel.Session) response.RPC {
+		return DeleteAPIKeyFunc(ctx, sess, req.KeyID)
+	})
+}
+
+// handleAPIKeyRequest validates the session, updates its last used time and
+// returns the marshalled response of the operation
+func handleAPIKeyRequest(ctx context.Context, sessionToken, operation string, operate func(*asmodel.Session) response.RPC) (*accountproto.AccountResponse, error) {
+	ctx = getContext(ctx, common.SessionService)
+	var resp accountproto.AccountResponse
+	args := account.GetResponseArgs("", "", []interface{}{})
+
+	l.LogWithFields(ctx).Infof("Validating session and updating the last used time of the session before the request to %s", operation)
+	sess, errs := CheckSessionTimeOutFunc(ctx, sessionToken)
+	if errs != nil {
+		resp.Body, resp.StatusCode, resp.StatusMessage = validateSessionTimeoutError(ctx, sessionToken, errs)
+		return &resp, nil
+	}
+
+	err := UpdateLastUsedTimeFunc(ctx, sessionToken)
+	if err != nil {
+		resp = mapErrorResponse(ctx, resp, args, err)
+		return &resp, nil
+	}
+
+	data := operate(sess)
+	errorMessage := "error while trying to marshal the response body of the " + operation + " API: "
+	resp, err = mapAccountResponse(resp, data, errorMessage)
+	if err != nil {
+		l.LogWithFields(ctx).Error(resp.StatusMessage)
+		return &resp, nil
+	}
+	l.LogWithFields(ctx).Debugf("outgoing response of request to %s: %s", operation, string(resp.Body))
+	return &resp, nil
+}
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+	"github.com/ODIM-Project/ODIM/svc-account-session/auth"
 )
 
 // UpdateLastUsedTime is supposed to be used whenever there is a session usage.
@@ -28,6 +29,10 @@ import (
 // the active sessions won't time out and expire. As the input of the function
 // we are passing the session token. As return, function give backs the error, if any.
 func UpdateLastUsedTime(ctx context.Context, token string) error {
+	if auth.IsAPIKey(token) {
+		// the usage of an API key is recorded when the key is checked
+		return nil
+	}
 	session, err := asmodel.GetSession(token)
 	if err != nil {
 		return fmt.Errorf("error while trying to get the session details with the token %v: %v", token, err)
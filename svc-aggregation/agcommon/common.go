@@ -326,9 +326,13 @@ func LookupPlugin(ctx context.Context, addr string) (agmodel.Plugin, error) {
 	}
 
 	for _, plugin := range plugins {
-		if (plugin.IP == host || plugin.IP == resolvedAddr) && (plugin.Port == port) {
-			l.LogWithFields(ctx).Debug("lookup plugin IP" + plugin.ID)
-			return plugin, nil
+		// the address can be of any of the instances of the plugin
+		for _, instance := range common.GetPluginInstances(plugin.IP, plugin.Port, plugin.Instances) {
+			if (instance.IP == host || instance.IP == resolvedAddr) && (instance.Port == port) {
+				l.LogWithFields(ctx).Debug("lookup plugin IP" + plugin.ID)
+				plugin.IP, plugin.Port = instance.IP, instance.Port
+				return plugin, nil
+			}
 		}
 	}
 	return agmodel.Plugin{}, fmt.Errorf(addr + " address does not belong to any of the plugin")
@@ -24,7 +24,10 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/lib-utilities/tracing"
 	uuid "github.com/satori/go.uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // MQBusCommunicator holds the communicator interface function
@@ -41,11 +44,16 @@ func InitMQSCom() MQBusCommunicator {
 
 // Publish will takes the system id,Event type and publishes the data to message bus
 func Publish(ctx context.Context, systemID, eventType, collectionType string, MQ MQBusCommunicator) error {
+	ctx, span := tracing.StartSpan(ctx, "publish "+eventType, trace.SpanKindProducer,
+		attribute.String("messaging.system", config.Data.MessageBusConf.MessageBusType),
+		attribute.String("messaging.destination", config.Data.MessageBusConf.OdimControlMessageQueue))
+	defer span.End()
 	topicName := config.Data.MessageBusConf.OdimControlMessageQueue
 	//k, err := dc.Communicator(config.Data.MessageBusConf.MessageBusType, config.Data.MessageBusConf.MessageBusConfigFilePath, topicName)
 	k, err := MQ.Communicator(config.Data.MessageBusConf.MessageBusType, config.Data.MessageBusConf.MessageBusConfigFilePath, topicName)
 	if err != nil {
 		l.LogWithFields(ctx).Error("Unable to connect to " + config.Data.MessageBusConf.MessageBusType + " " + err.Error())
+		tracing.RecordError(span, err)
 		return err
 	}
 
@@ -55,6 +63,8 @@ func Publish(ctx context.Context, systemID, eventType, collectionType string, MQ
 		message = "The resource has been created successfully."
 	case "ResourceRemoved":
 		message = "The resource has been removed successfully."
+	case "ResourceUpdated":
+		message = "The resource has been updated successfully."
 	}
 
 	var event = common.Event{
@@ -77,12 +87,14 @@ func Publish(ctx context.Context, systemID, eventType, collectionType string, MQ
 	}
 	data, _ := json.Marshal(messageData)
 	var mbevent = common.Events{
-		IP:      collectionType,
-		Request: data,
+		IP:          collectionType,
+		Request:     data,
+		TraceParent: tracing.TraceParent(ctx),
 	}
 
 	if err := k.Distribute(mbevent); err != nil {
 		l.LogWithFields(ctx).Error("Unable Publish events to kafka" + err.Error())
+		tracing.RecordError(span, err)
 		return err
 	}
 	l.LogWithFields(ctx).Info("Event Published")
@@ -22,12 +22,14 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"time"
 
 	dmtfmodel "github.com/ODIM-Project/ODIM/lib-dmtf/model"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/lib-utilities/tracing"
 )
 
 const (
@@ -45,11 +47,14 @@ type Schema struct {
 
 // SaveSystem model is used to save encrypted data into db
 type SaveSystem struct {
-	ManagerAddress string
-	Password       []byte
-	UserName       string
-	DeviceUUID     string
-	PluginID       string
+	ManagerAddress    string
+	Password          []byte
+	UserName          string
+	DeviceUUID        string
+	PluginID          string
+	EventDeliveryMode string `json:",omitempty"`
+	// CertificateTrust is the way the plugin verifies the certificate of the BMC
+	CertificateTrust *common.CertificateTrust `json:",omitempty"`
 }
 
 // Plugin is the model for plugin information
@@ -62,16 +67,20 @@ type Plugin struct {
 	PluginType        string
 	PreferredAuthType string
 	ManagerUUID       string
+	Instances         []common.PluginInstance
 }
 
 // Target is for sending the requst to south bound/plugin
 type Target struct {
-	ManagerAddress string `json:"ManagerAddress"`
-	Password       []byte `json:"Password"`
-	UserName       string `json:"UserName"`
-	PostBody       []byte `json:"PostBody"`
-	DeviceUUID     string `json:"DeviceUUID"`
-	PluginID       string `json:"PluginID"`
+	ManagerAddress    string `json:"ManagerAddress"`
+	Password          []byte `json:"Password"`
+	UserName          string `json:"UserName"`
+	PostBody          []byte `json:"PostBody"`
+	DeviceUUID        string `json:"DeviceUUID"`
+	PluginID          string `json:"PluginID"`
+	EventDeliveryMode string `json:"EventDeliveryMode,omitempty"`
+	// CertificateTrust is the way the plugin verifies the certificate of the BMC
+	CertificateTrust *common.CertificateTrust `json:"CertificateTrust,omitempty"`
 }
 
 // SystemOperation hold the value system operation(InventoryRediscovery or Delete)
@@ -149,6 +158,8 @@ type DeviceData struct {
 	Operation             string
 	EventSubscriptionInfo *EventSubscriptionInfo
 	TriggerInfo           *TriggerInfo
+	EventDeliveryMode     string                   `json:",omitempty"`
+	CertificateTrust      *common.CertificateTrust `json:",omitempty"`
 }
 
 // EventSubscriptionInfo holds the event subscription details of a device
@@ -179,6 +190,8 @@ type PluginContactRequest struct {
 
 // GetResource fetches a resource from database using table and key
 func GetResource(ctx context.Context, Table, key string) (string, *errors.Error) {
+	_, span := tracing.StartDBSpan(ctx, "Read", Table)
+	defer span.End()
 	conn, err := common.GetDBConnection(common.InMemory)
 	if err != nil {
 		return "", errors.PackError(err.ErrNo(), err)
@@ -197,6 +210,8 @@ func GetResource(ctx context.Context, Table, key string) (string, *errors.Error)
 
 // Create connects to the persistencemgr and creates a system in db
 func (system *SaveSystem) Create(ctx context.Context, systemID string) *errors.Error {
+	_, span := tracing.StartDBSpan(ctx, "Create", "System")
+	defer span.End()
 
 	conn, err := common.GetDBConnection(common.OnDisk)
 	if err != nil {
@@ -275,6 +290,8 @@ func GetComputeSystem(ctx context.Context, deviceUUID string) (dmtfmodel.Compute
 
 // SaveComputeSystem will save the compute server complete details into the database
 func SaveComputeSystem(ctx context.Context, computeServer dmtfmodel.ComputerSystem, deviceUUID string) error {
+	_, span := tracing.StartDBSpan(ctx, "Create", "ComputerSystem")
+	defer span.End()
 	//use dmtf logic to save data into database
 	l.LogWithFields(ctx).Info("Saving server details into database")
 	err := computeServer.SaveInMemory(deviceUUID)
@@ -287,6 +304,8 @@ func SaveComputeSystem(ctx context.Context, computeServer dmtfmodel.ComputerSyst
 
 // SaveChassis will save the chassis details into the database
 func SaveChassis(ctx context.Context, chassis dmtfmodel.Chassis, deviceUUID string) error {
+	_, span := tracing.StartDBSpan(ctx, "Create", "Chassis")
+	defer span.End()
 	//use dmtf logic to save data into database
 	l.LogWithFields(ctx).Info("Saving chassis details into database")
 	err := chassis.SaveInMemory(deviceUUID)
@@ -628,6 +647,8 @@ func GetResourceDetails(ctx context.Context, key string) (string, *errors.Error)
 // GetResourceDetailsBytableName fetches a resource from database using key and table name
 
 func GetResourceDetailsBytableName(ctx context.Context, table, key string) (string, *errors.Error) {
+	_, span := tracing.StartDBSpan(ctx, "Read", table)
+	defer span.End()
 	conn, err := common.GetDBConnection(common.InMemory)
 	if err != nil {
 		return "", errors.PackError(err.ErrNo(), err)
@@ -796,6 +817,8 @@ func AddAggregationSource(req AggregationSource, aggregationSourceURI string) *e
 
 // GetAggregationSourceInfo fetches the AggregationSource info for the given aggregationSourceURI
 func GetAggregationSourceInfo(ctx context.Context, aggregationSourceURI string) (AggregationSource, *errors.Error) {
+	_, span := tracing.StartDBSpan(ctx, "Read", "AggregationSource")
+	defer span.End()
 	var aggregationSource AggregationSource
 
 	conn, err := common.GetDBConnection(common.OnDisk)
@@ -1068,6 +1091,16 @@ func DeleteMultipleKeys(keys []string, dbtype common.DbType) *errors.Error {
 	return nil
 }
 
+// ReadMultipleKeys is used to read the values of multiple keys from DB, the values are in the order of the keys
+// and empty for the keys not found
+func ReadMultipleKeys(keys []string, dbtype common.DbType) ([]string, *errors.Error) {
+	conn, err := common.GetDBConnection(dbtype)
+	if err != nil {
+		return nil, err
+	}
+	return conn.ReadMultipleKeys(keys)
+}
+
 // UpdateConnectionMethod updates the Connection Method details
 func UpdateConnectionMethod(connectionMethod ConnectionMethod, key string) *errors.Error {
 	conn, err := common.GetDBConnection(common.OnDisk)
@@ -1313,3 +1346,151 @@ func SaveBMCInventory(data map[string]interface{}) error {
 	}
 	return nil
 }
+
+// DiscoveredBMC is a BMC found by the discovery scan which is not aggregated yet
+type DiscoveredBMC struct {
+	HostName         string `json:"HostName"` // address on which the Redfish service root of the BMC was found
+	Vendor           string `json:"Vendor,omitempty"`
+	Product          string `json:"Product,omitempty"`
+	RedfishVersion   string `json:"RedfishVersion,omitempty"`
+	UUID             string `json:"UUID,omitempty"`
+	PluginID         string `json:"PluginID,omitempty"`
+	ConnectionMethod string `json:"ConnectionMethod,omitempty"`
+	FirstSeen        string `json:"FirstSeen"`
+	LastSeen         string `json:"LastSeen"`
+}
+
+// SaveDiscoveredBMC adds or updates the discovered BMC with the given uri
+func SaveDiscoveredBMC(bmc DiscoveredBMC, discoveredBMCURI string) *errors.Error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	const table string = "DiscoveredBMC"
+	if err := conn.Upsert(table, discoveredBMCURI, bmc); err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to save discovered BMC: ", err.Error())
+	}
+	return nil
+}
+
+// GetDiscoveredBMC fetches the discovered BMC with the given uri
+func GetDiscoveredBMC(discoveredBMCURI string) (DiscoveredBMC, *errors.Error) {
+	var bmc DiscoveredBMC
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return bmc, err
+	}
+	const table string = "DiscoveredBMC"
+	data, err := conn.Read(table, discoveredBMCURI)
+	if err != nil {
+		return bmc, errors.PackError(err.ErrNo(), "error: while trying to fetch discovered BMC data: ", err.Error())
+	}
+	if err := json.Unmarshal([]byte(data), &bmc); err != nil {
+		return bmc, errors.PackError(errors.JSONUnmarshalFailed, err)
+	}
+	return bmc, nil
+}
+
+// DeleteDiscoveredBMC deletes the discovered BMC with the given uri
+func DeleteDiscoveredBMC(discoveredBMCURI string) *errors.Error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	const table string = "DiscoveredBMC"
+	if err = conn.Delete(table, discoveredBMCURI); err != nil {
+		return err
+	}
+	return nil
+}
+
+// CheckBMCAddress checks whether a BMC with the given address is already aggregated
+func CheckBMCAddress(bmcAddress string) (bool, error) {
+	indexList, err := GetString("BMCAddress", bmcAddress)
+	if err != nil {
+		return false, err
+	}
+	return len(indexList) > 0, nil
+}
+
+// CredentialRotation is the credential rotation policy of an aggregate with the outcome of its last rotation
+type CredentialRotation struct {
+	IntervalInDays   int                        `json:"IntervalInDays,omitempty"`
+	PasswordLength   int                        `json:"PasswordLength"`
+	LastRotationTime string                     `json:"LastRotationTime,omitempty"`
+	NextRotationTime string                     `json:"NextRotationTime,omitempty"`
+	Results          []CredentialRotationResult `json:"Results,omitempty"`
+}
+
+// CredentialRotationResult is the outcome of the credential rotation of a server
+type CredentialRotationResult struct {
+	System  OdataID `json:"System"`
+	Status  string  `json:"Status"`
+	Message string  `json:"Message,omitempty"`
+}
+
+// SaveCredentialRotation adds or updates the credential rotation policy of the aggregate with the given uri
+func SaveCredentialRotation(rotation CredentialRotation, aggregateURI string) *errors.Error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	const table string = "CredentialRotation"
+	if err := conn.Upsert(table, aggregateURI, rotation); err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to save credential rotation policy: ", err.Error())
+	}
+	return nil
+}
+
+// GetCredentialRotation fetches the credential rotation policy of the aggregate with the given uri
+func GetCredentialRotation(aggregateURI string) (CredentialRotation, *errors.Error) {
+	var rotation CredentialRotation
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return rotation, err
+	}
+	const table string = "CredentialRotation"
+	data, err := conn.Read(table, aggregateURI)
+	if err != nil {
+		return rotation, errors.PackError(err.ErrNo(), "error: while trying to fetch credential rotation policy: ", err.Error())
+	}
+	if err := json.Unmarshal([]byte(data), &rotation); err != nil {
+		return rotation, errors.PackError(errors.JSONUnmarshalFailed, err)
+	}
+	return rotation, nil
+}
+
+// DeleteCredentialRotation deletes the credential rotation policy of the aggregate with the given uri
+func DeleteCredentialRotation(aggregateURI string) *errors.Error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	const table string = "CredentialRotation"
+	if err = conn.Delete(table, aggregateURI); err != nil {
+		return err
+	}
+	return nil
+}
+
+// LockCredentialRotation marks the credentials of the aggregate as being rotated, so that
+// the instances of the service do not rotate them together. The lock expires after the given
+// number of seconds in case it is not released.
+func LockCredentialRotation(aggregateURI string, expiry int) *errors.Error {
+	conn, err := common.GetDBConnection(common.InMemory)
+	if err != nil {
+		return err
+	}
+	const table string = "CredentialRotationLock"
+	return conn.CreateWithExpiry(table, aggregateURI, time.Now().UTC().Format(time.RFC3339), expiry)
+}
+
+// UnlockCredentialRotation releases the lock taken by LockCredentialRotation
+func UnlockCredentialRotation(aggregateURI string) *errors.Error {
+	conn, err := common.GetDBConnection(common.InMemory)
+	if err != nil {
+		return err
+	}
+	const table string = "CredentialRotationLock"
+	return conn.Delete(table, aggregateURI)
+}
@@ -54,12 +54,73 @@ type AggregateGetResponse struct {
 	ElementsCount int               `json:"ElementsCount,omitempty"`
 	Elements      []agmodel.OdataID `json:"Elements"`
 	Actions       AggregateActions  `json:"Actions,omitempty"`
+	Oem           *AggregateOem     `json:"Oem,omitempty"`
+}
+
+// AggregateOem defines the ODIM specific properties of an aggregate
+type AggregateOem struct {
+	Odim AggregateOdim `json:"Odim"`
+}
+
+// AggregateOdim holds the credential rotation policy of an aggregate and the outcome of its last rotation
+type AggregateOdim struct {
+	CredentialRotation agmodel.CredentialRotation `json:"CredentialRotation"`
 }
 
 // AggregateActions defines the links to the actions available under the service
 type AggregateActions struct {
-	AggregateReset               Action `json:"#Aggregate.Reset"`
-	AggregateSetDefaultBootOrder Action `json:"#Aggregate.SetDefaultBootOrder"`
-	AggregateAddElements         Action `json:"#Aggregate.AddElements"`
-	AggregateRemoveElements      Action `json:"#Aggregate.RemoveElements"`
+	AggregateReset               Action               `json:"#Aggregate.Reset"`
+	AggregateSetDefaultBootOrder Action               `json:"#Aggregate.SetDefaultBootOrder"`
+	AggregateAddElements         Action               `json:"#Aggregate.AddElements"`
+	AggregateRemoveElements      Action               `json:"#Aggregate.RemoveElements"`
+	Oem                          *AggregateOemActions `json:"Oem,omitempty"`
+}
+
+// AggregateOemActions defines the ODIM specific actions of an aggregate
+type AggregateOemActions struct {
+	RotateCredentials Action `json:"#Odim.RotateCredentials"`
+}
+
+// BulkAddSummary is the response of the BulkAddAggregationSources action stored
+// in its task once all the entries of the manifest are processed
+type BulkAddSummary struct {
+	Total    int             `json:"Total"`
+	Added    int             `json:"Added"`
+	Failed   int             `json:"Failed"`
+	Failures map[string]int  `json:"Failures"`
+	Results  []BulkAddResult `json:"Results"`
+}
+
+// BulkAddResult is the outcome of adding one entry of the bulk add manifest
+type BulkAddResult struct {
+	HostName          string `json:"HostName"`
+	Status            string `json:"Status"`
+	ErrorCategory     string `json:"ErrorCategory,omitempty"`
+	Message           string `json:"Message,omitempty"`
+	AggregationSource string `json:"AggregationSource,omitempty"`
+	Task              string `json:"Task,omitempty"`
+}
+
+// DiscoveredBMCResponse defines the response for a BMC found by the discovery scan
+type DiscoveredBMCResponse struct {
+	response.Response
+	HostName       string               `json:"HostName"`
+	Vendor         string               `json:"Vendor,omitempty"`
+	Product        string               `json:"Product,omitempty"`
+	RedfishVersion string               `json:"RedfishVersion,omitempty"`
+	UUID           string               `json:"UUID,omitempty"`
+	FirstSeen      string               `json:"FirstSeen"`
+	LastSeen       string               `json:"LastSeen"`
+	Links          DiscoveredBMCLinks   `json:"Links"`
+	Actions        DiscoveredBMCActions `json:"Actions"`
+}
+
+// DiscoveredBMCLinks defines the links of a discovered BMC
+type DiscoveredBMCLinks struct {
+	ConnectionMethod *OdataID `json:"ConnectionMethod,omitempty"`
+}
+
+// DiscoveredBMCActions defines the links to the actions available on a discovered BMC
+type DiscoveredBMCActions struct {
+	Adopt Action `json:"#DiscoveredBMC.Adopt"`
 }
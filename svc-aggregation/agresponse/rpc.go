@@ -47,8 +47,14 @@ type AggregationServiceResponse struct {
 
 //Actions struct definition
 type Actions struct {
-	Reset               Action `json:"#AggregationService.Reset"`
-	SetDefaultBootOrder Action `json:"#AggregationService.SetDefaultBootOrder"`
+	Reset               Action      `json:"#AggregationService.Reset"`
+	SetDefaultBootOrder Action      `json:"#AggregationService.SetDefaultBootOrder"`
+	Oem                 *OemActions `json:"Oem,omitempty"`
+}
+
+//OemActions struct definition for the ODIM specific actions of AggregationService
+type OemActions struct {
+	BulkAddAggregationSources Action `json:"#Odim.BulkAddAggregationSources"`
 }
 
 //Status struct definition
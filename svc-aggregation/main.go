@@ -14,18 +14,22 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 
 	"github.com/sirupsen/logrus"
 
 	dc "github.com/ODIM-Project/ODIM/lib-messagebus/datacommunicator"
+	"github.com/ODIM-Project/ODIM/lib-rest-client/pmbhandle"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/health"
 	"github.com/ODIM-Project/ODIM/lib-utilities/logs"
 	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
 	"github.com/ODIM-Project/ODIM/lib-utilities/services"
 	"github.com/ODIM-Project/ODIM/svc-aggregation/agcommon"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmessagebus"
 	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
 	"github.com/ODIM-Project/ODIM/svc-aggregation/rpc"
 	"github.com/ODIM-Project/ODIM/svc-aggregation/system"
@@ -73,6 +77,9 @@ func main() {
 	if err := dc.SetConfiguration(config.Data.MessageBusConf.MessageBusConfigFilePath); err != nil {
 		log.Fatal("error while trying to set message bus configuration: " + err.Error())
 	}
+	health.Register("MessageBus", func(ctx context.Context) error {
+		return dc.Ping(ctx, config.Data.MessageBusConf.MessageBusType)
+	})
 	if err := common.CheckDBConnection(); err != nil {
 		log.Fatal("error while trying to check DB connection health: " + err.Error())
 	}
@@ -116,6 +123,32 @@ func main() {
 
 	go system.PerformPluginHealthCheck()
 
+	discovery := system.ExternalInterface{
+		GetAllKeysFromTable:  agmodel.GetAllKeysFromTable,
+		GetConnectionMethod:  agmodel.GetConnectionMethod,
+		SaveDiscoveredBMC:    agmodel.SaveDiscoveredBMC,
+		GetDiscoveredBMCInfo: agmodel.GetDiscoveredBMC,
+		DeleteDiscoveredBMC:  agmodel.DeleteDiscoveredBMC,
+		CheckBMCAddress:      agmodel.CheckBMCAddress,
+	}
+	go discovery.PerformBMCDiscovery()
+
+	refresh := system.ExternalInterface{
+		ContactClient:     pmbhandle.ContactPlugin,
+		GetPluginStatus:   agcommon.GetPluginStatus,
+		DecryptPassword:   common.DecryptWithPrivateKey,
+		EventNotification: agmessagebus.Publish,
+	}
+	go refresh.PerformInventoryRefresh()
+
+	rotation := system.ExternalInterface{
+		ContactClient:   pmbhandle.ContactPlugin,
+		GetPluginStatus: agcommon.GetPluginStatus,
+		EncryptPassword: common.EncryptWithPublicKey,
+		DecryptPassword: common.DecryptWithPrivateKey,
+	}
+	go rotation.PerformCredentialRotation()
+
 	if err := services.ODIMService.Run(); err != nil {
 		log.Fatal("failed to run a service: " + err.Error())
 	}
@@ -89,6 +89,11 @@ func (a *Aggregator) GetAggregationService(ctx context.Context, req *aggregatorp
 				Target:     "/redfish/v1/AggregationService/Actions/AggregationService.SetDefaultBootOrder/",
 				ActionInfo: "/redfish/v1/AggregationService/SetDefaultBootOrderActionInfo",
 			},
+			Oem: &agresponse.OemActions{
+				BulkAddAggregationSources: agresponse.Action{
+					Target: "/redfish/v1/AggregationService/Actions/Oem/Odim.BulkAddAggregationSources",
+				},
+			},
 		},
 		Aggregates: agresponse.OdataID{
 			OdataID: "/redfish/v1/AggregationService/Aggregates",
@@ -401,6 +406,75 @@ func (a *Aggregator) AddAggregationSource(ctx context.Context, req *aggregatorpr
 	return resp, nil
 }
 
+// BulkAddAggregationSources function is for handling the RPC communication for BulkAddAggregationSources
+func (a *Aggregator) BulkAddAggregationSources(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+	*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.AggregationService, podName)
+	var taskID string
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeConfigureComponents}
+	authResp, err := a.connector.Auth(ctx, req.SessionToken, privileges, oemprivileges)
+	resp := &aggregatorproto.AggregatorResponse{}
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		generateResponse(authResp, resp)
+		return resp, nil
+	}
+	sessionUserName, err := a.connector.GetSessionUserName(ctx, req.SessionToken)
+	if err != nil {
+		errMsg := "Unable to get session username: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+	// manifest carries passwords, hence cannot log it
+	var bulkAddRequest system.BulkAddRequest
+	err = json.Unmarshal(req.RequestBody, &bulkAddRequest)
+	if err != nil {
+		errMsg := "Unable to parse the bulk add request: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+
+	// Task Service using RPC and get the taskID
+	taskURI, err := a.connector.CreateTask(ctx, sessionUserName)
+	if err != nil {
+		errMsg := "Unable to create the task: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+	strArray := strings.Split(taskURI, "/")
+	if strings.HasSuffix(taskURI, "/") {
+		taskID = strArray[len(strArray)-2]
+	} else {
+		taskID = strArray[len(strArray)-1]
+	}
+	// spawn the thread here to process the action asynchronously
+	threadID := 1
+	ctxt := context.WithValue(ctx, common.ThreadName, common.BulkAddAggregationSource)
+	ctxt = context.WithValue(ctxt, common.ThreadID, strconv.Itoa(threadID))
+	go a.connector.BulkAddAggregationSources(ctxt, taskID, sessionUserName, req)
+	threadID++
+
+	// return 202 Accepted
+	var rpcResp = response.RPC{
+		StatusCode:    http.StatusAccepted,
+		StatusMessage: response.TaskStarted,
+		Header: map[string]string{
+			"Location": "/taskmon/" + taskID,
+		},
+	}
+	generateTaskRespone(taskID, taskURI, &rpcResp)
+	generateResponse(rpcResp, resp)
+	l.LogWithFields(ctx).Debugf("final response for bulk add aggregation sources request: %s", string(resp.Body))
+	return resp, nil
+}
+
 func validateAggregationSourceRequest(req system.AggregationSource) string {
 	param := ""
 	if req.HostName == "" {
@@ -584,6 +658,67 @@ func (a *Aggregator) DeleteAggregationSource(ctx context.Context, req *aggregato
 	return resp, nil
 }
 
+// ChangeConnectionMethod function is for handling the RPC communication for the ChangeConnectionMethod
+// action of an aggregation source, the server is moved to the plugin of the new connection method under a task
+func (a *Aggregator) ChangeConnectionMethod(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+	*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.AggregationService, podName)
+	var taskID string
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeConfigureComponents}
+	authResp, err := a.connector.Auth(ctx, req.SessionToken, privileges, oemprivileges)
+	resp := &aggregatorproto.AggregatorResponse{}
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		generateResponse(authResp, resp)
+		return resp, nil
+	}
+	sessionUserName, err := a.connector.GetSessionUserName(ctx, req.SessionToken)
+	if err != nil {
+		errMsg := "Unable to get session username: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+
+	// Task Service using RPC and get the taskID
+	taskURI, err := a.connector.CreateTask(ctx, sessionUserName)
+	if err != nil {
+		errMsg := "Unable to create the task: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+	strArray := strings.Split(taskURI, "/")
+	if strings.HasSuffix(taskURI, "/") {
+		taskID = strArray[len(strArray)-2]
+	} else {
+		taskID = strArray[len(strArray)-1]
+	}
+	// spawn the thread here to process the action asynchronously
+	threadID := 1
+	ctxt := context.WithValue(ctx, common.ThreadName, common.ChangeConnectionMethod)
+	ctxt = context.WithValue(ctxt, common.ThreadID, strconv.Itoa(threadID))
+	go a.connector.ChangeConnectionMethod(ctxt, taskID, sessionUserName, req)
+	threadID++
+
+	// return 202 Accepted
+	var rpcResp = response.RPC{
+		StatusCode:    http.StatusAccepted,
+		StatusMessage: response.TaskStarted,
+		Header: map[string]string{
+			"Location": "/taskmon/" + taskID,
+		},
+	}
+	generateTaskRespone(taskID, taskURI, &rpcResp)
+	generateResponse(rpcResp, resp)
+	l.LogWithFields(ctx).Debugf("final response for change connection method request: %s", string(resp.Body))
+	return resp, nil
+}
+
 // CreateAggregate defines the operations which handles the RPC request response
 // for the CreateAggregate service of aggregation micro service.
 // The functionality retrives the request and return backs the response to
@@ -906,6 +1041,90 @@ func (a *Aggregator) SetDefaultBootOrderElementsOfAggregate(ctx context.Context,
 	return resp, nil
 }
 
+// ReplaceCertificate function is for handling the RPC communication for the ReplaceCertificate
+// action of the CertificateService, the certificate of a server is replaced through its plugin
+func (a *Aggregator) ReplaceCertificate(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+	*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.AggregationService, podName)
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeConfigureManager}
+	authResp, err := a.connector.Auth(ctx, req.SessionToken, privileges, oemprivileges)
+	resp := &aggregatorproto.AggregatorResponse{}
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		generateResponse(authResp, resp)
+		return resp, nil
+	}
+	data := a.connector.ReplaceCertificate(ctx, req)
+	generateResponse(data, resp)
+	l.LogWithFields(ctx).Debugf("final response for replace certificate request: %s", string(resp.Body))
+	return resp, nil
+}
+
+// RotateCredentials function is for handling the RPC communication for the RotateCredentials
+// action of an aggregate, the BMC credentials of the servers of the aggregate are rotated under a task
+func (a *Aggregator) RotateCredentials(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+	*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.AggregationService, podName)
+	var taskID string
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeConfigureComponents}
+	authResp, err := a.connector.Auth(ctx, req.SessionToken, privileges, oemprivileges)
+	resp := &aggregatorproto.AggregatorResponse{}
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		generateResponse(authResp, resp)
+		return resp, nil
+	}
+	sessionUserName, err := a.connector.GetSessionUserName(ctx, req.SessionToken)
+	if err != nil {
+		errMsg := "Unable to get session username: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+
+	// Task Service using RPC and get the taskID
+	taskURI, err := a.connector.CreateTask(ctx, sessionUserName)
+	if err != nil {
+		errMsg := "Unable to create the task: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+	strArray := strings.Split(taskURI, "/")
+	if strings.HasSuffix(taskURI, "/") {
+		taskID = strArray[len(strArray)-2]
+	} else {
+		taskID = strArray[len(strArray)-1]
+	}
+	// spawn the thread here to process the action asynchronously
+	threadID := 1
+	ctxt := context.WithValue(ctx, common.ThreadName, common.RotateCredentials)
+	ctxt = context.WithValue(ctxt, common.ThreadID, strconv.Itoa(threadID))
+	go a.connector.RotateCredentials(ctxt, taskID, sessionUserName, req)
+	threadID++
+
+	// return 202 Accepted
+	var rpcResp = response.RPC{
+		StatusCode:    http.StatusAccepted,
+		StatusMessage: response.TaskStarted,
+		Header: map[string]string{
+			"Location": "/taskmon/" + taskID,
+		},
+	}
+	generateTaskRespone(taskID, taskURI, &rpcResp)
+	generateResponse(rpcResp, resp)
+	l.LogWithFields(ctx).Debugf("final response for rotate credentials request: %s", string(resp.Body))
+	return resp, nil
+}
+
 // GetAllConnectionMethods defines the operations which handles the RPC request response
 // for the GetAllConnectionMethods service of systems micro service.
 // The functionality retrives the request and return backs the response to
@@ -1076,3 +1295,110 @@ func (a *Aggregator) GetSetDefaultBootOrderActionInfo(ctx context.Context, req *
 	l.LogWithFields(ctx).Debugf("final response for get set default boot order action info request: %s", string(resp.Body))
 	return resp, nil
 }
+
+// GetAllDiscoveredBMCs defines the operations which handles the RPC request response
+// for listing the BMCs found by the discovery scan and not aggregated yet
+func (a *Aggregator) GetAllDiscoveredBMCs(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+	*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.AggregationService, podName)
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeConfigureComponents}
+	authResp, err := a.connector.Auth(ctx, req.SessionToken, privileges, oemprivileges)
+	resp := &aggregatorproto.AggregatorResponse{}
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		generateResponse(authResp, resp)
+		return resp, nil
+	}
+	data := a.connector.GetAllDiscoveredBMCs(ctx)
+	generateResponse(data, resp)
+	l.LogWithFields(ctx).Debugf("final response for get all discovered BMCs request: %s", string(resp.Body))
+	return resp, nil
+}
+
+// GetDiscoveredBMC defines the operations which handles the RPC request response
+// for fetching a BMC found by the discovery scan
+func (a *Aggregator) GetDiscoveredBMC(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+	*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.AggregationService, podName)
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeConfigureComponents}
+	authResp, err := a.connector.Auth(ctx, req.SessionToken, privileges, oemprivileges)
+	resp := &aggregatorproto.AggregatorResponse{}
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		generateResponse(authResp, resp)
+		return resp, nil
+	}
+	data := a.connector.GetDiscoveredBMC(ctx, req.URL)
+	generateResponse(data, resp)
+	l.LogWithFields(ctx).Debugf("final response for get discovered BMC request: %s", string(resp.Body))
+	return resp, nil
+}
+
+// AdoptDiscoveredBMC function is for handling the RPC communication for the Adopt action
+// of a discovered BMC, the BMC is added as an aggregation source under a task
+func (a *Aggregator) AdoptDiscoveredBMC(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+	*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.AggregationService, podName)
+	var taskID string
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeConfigureComponents}
+	authResp, err := a.connector.Auth(ctx, req.SessionToken, privileges, oemprivileges)
+	resp := &aggregatorproto.AggregatorResponse{}
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		generateResponse(authResp, resp)
+		return resp, nil
+	}
+	sessionUserName, err := a.connector.GetSessionUserName(ctx, req.SessionToken)
+	if err != nil {
+		errMsg := "Unable to get session username: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+
+	// Task Service using RPC and get the taskID
+	taskURI, err := a.connector.CreateTask(ctx, sessionUserName)
+	if err != nil {
+		errMsg := "Unable to create the task: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+	strArray := strings.Split(taskURI, "/")
+	if strings.HasSuffix(taskURI, "/") {
+		taskID = strArray[len(strArray)-2]
+	} else {
+		taskID = strArray[len(strArray)-1]
+	}
+	// spawn the thread here to process the action asynchronously
+	threadID := 1
+	ctxt := context.WithValue(ctx, common.ThreadName, common.AdoptDiscoveredBMC)
+	ctxt = context.WithValue(ctxt, common.ThreadID, strconv.Itoa(threadID))
+	go a.connector.AdoptDiscoveredBMC(ctxt, taskID, sessionUserName, req)
+	threadID++
+
+	// return 202 Accepted
+	var rpcResp = response.RPC{
+		StatusCode:    http.StatusAccepted,
+		StatusMessage: response.TaskStarted,
+		Header: map[string]string{
+			"Location": "/taskmon/" + taskID,
+		},
+	}
+	generateTaskRespone(taskID, taskURI, &rpcResp)
+	generateResponse(rpcResp, resp)
+	l.LogWithFields(ctx).Debugf("final response for adopt discovered BMC request: %s", string(resp.Body))
+	return resp, nil
+}
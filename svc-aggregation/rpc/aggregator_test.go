@@ -471,6 +471,166 @@ func TestAggregator_AddAggreagationSource(t *testing.T) {
 	}
 }
 
+func TestAggregator_BulkAddAggregationSources(t *testing.T) {
+	config.SetUpMockConfig(t)
+	successReq, _ := json.Marshal(system.BulkAddRequest{
+		Manifest: "HostName,UserName,Password\n100.0.0.1:50000,admin,password\n",
+	})
+	tests := []struct {
+		name       string
+		req        *aggregatorproto.AggregatorRequest
+		statusCode int32
+	}{
+		{
+			name:       "positive case",
+			req:        &aggregatorproto.AggregatorRequest{SessionToken: "validToken", RequestBody: successReq},
+			statusCode: http.StatusAccepted,
+		},
+		{
+			name:       "auth fail",
+			req:        &aggregatorproto.AggregatorRequest{SessionToken: "invalidToken", RequestBody: successReq},
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			name:       "get session username fails",
+			req:        &aggregatorproto.AggregatorRequest{SessionToken: "noDetailsToken", RequestBody: successReq},
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			name:       "unable to create task",
+			req:        &aggregatorproto.AggregatorRequest{SessionToken: "noTaskToken", RequestBody: successReq},
+			statusCode: http.StatusInternalServerError,
+		},
+		{
+			name:       "with invalid request",
+			req:        &aggregatorproto.AggregatorRequest{SessionToken: "validToken", RequestBody: []byte("someData")},
+			statusCode: http.StatusBadRequest,
+		},
+	}
+	a := &Aggregator{connector: connector}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := a.BulkAddAggregationSources(mockContext(), tt.req)
+			if err != nil {
+				t.Fatalf("Aggregator.BulkAddAggregationSources() error = %v", err)
+			}
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("Aggregator.BulkAddAggregationSources() status = %v, want %v", resp.StatusCode, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestAggregator_DiscoveredBMCs(t *testing.T) {
+	config.SetUpMockConfig(t)
+	a := &Aggregator{connector: connector}
+	discoveredBMCURI := "/redfish/v1/AggregationService/Oem/Odim/DiscoveredBMCs/unknown"
+	tests := []struct {
+		name       string
+		call       func(context.Context, *aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
+		req        *aggregatorproto.AggregatorRequest
+		statusCode int32
+	}{
+		{"get collection auth fail", a.GetAllDiscoveredBMCs, &aggregatorproto.AggregatorRequest{SessionToken: "invalidToken"}, http.StatusUnauthorized},
+		{"get auth fail", a.GetDiscoveredBMC, &aggregatorproto.AggregatorRequest{SessionToken: "invalidToken", URL: discoveredBMCURI}, http.StatusUnauthorized},
+		{"get unknown BMC", a.GetDiscoveredBMC, &aggregatorproto.AggregatorRequest{SessionToken: "validToken", URL: discoveredBMCURI}, http.StatusNotFound},
+		{"adopt positive case", a.AdoptDiscoveredBMC, &aggregatorproto.AggregatorRequest{SessionToken: "validToken", URL: discoveredBMCURI + "/Actions/DiscoveredBMC.Adopt"}, http.StatusAccepted},
+		{"adopt auth fail", a.AdoptDiscoveredBMC, &aggregatorproto.AggregatorRequest{SessionToken: "invalidToken"}, http.StatusUnauthorized},
+		{"adopt get session username fails", a.AdoptDiscoveredBMC, &aggregatorproto.AggregatorRequest{SessionToken: "noDetailsToken"}, http.StatusUnauthorized},
+		{"adopt unable to create task", a.AdoptDiscoveredBMC, &aggregatorproto.AggregatorRequest{SessionToken: "noTaskToken"}, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := tt.call(mockContext(), tt.req)
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("status = %v, want %v", resp.StatusCode, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestAggregator_ChangeConnectionMethod(t *testing.T) {
+	config.SetUpMockConfig(t)
+	a := &Aggregator{connector: connector}
+	actionURI := "/redfish/v1/AggregationService/AggregationSources/unknown/Actions/Oem/Odim.ChangeConnectionMethod"
+	tests := []struct {
+		name       string
+		req        *aggregatorproto.AggregatorRequest
+		statusCode int32
+	}{
+		{"positive case", &aggregatorproto.AggregatorRequest{SessionToken: "validToken", URL: actionURI}, http.StatusAccepted},
+		{"auth fail", &aggregatorproto.AggregatorRequest{SessionToken: "invalidToken", URL: actionURI}, http.StatusUnauthorized},
+		{"get session username fails", &aggregatorproto.AggregatorRequest{SessionToken: "noDetailsToken", URL: actionURI}, http.StatusUnauthorized},
+		{"unable to create task", &aggregatorproto.AggregatorRequest{SessionToken: "noTaskToken", URL: actionURI}, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := a.ChangeConnectionMethod(mockContext(), tt.req)
+			if err != nil {
+				t.Fatalf("Aggregator.ChangeConnectionMethod() error = %v", err)
+			}
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("Aggregator.ChangeConnectionMethod() status = %v, want %v", resp.StatusCode, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestAggregator_ReplaceCertificate(t *testing.T) {
+	config.SetUpMockConfig(t)
+	a := &Aggregator{connector: connector}
+	actionURI := "/redfish/v1/CertificateService/Actions/CertificateService.ReplaceCertificate"
+	tests := []struct {
+		name       string
+		req        *aggregatorproto.AggregatorRequest
+		statusCode int32
+	}{
+		{"invalid request", &aggregatorproto.AggregatorRequest{SessionToken: "validToken", URL: actionURI, RequestBody: []byte(`{}`)}, http.StatusBadRequest},
+		{"auth fail", &aggregatorproto.AggregatorRequest{SessionToken: "invalidToken", URL: actionURI, RequestBody: []byte(`{}`)}, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := a.ReplaceCertificate(mockContext(), tt.req)
+			if err != nil {
+				t.Fatalf("Aggregator.ReplaceCertificate() error = %v", err)
+			}
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("Aggregator.ReplaceCertificate() status = %v, want %v", resp.StatusCode, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestAggregator_RotateCredentials(t *testing.T) {
+	config.SetUpMockConfig(t)
+	a := &Aggregator{connector: connector}
+	actionURI := "/redfish/v1/AggregationService/Aggregates/unknown/Actions/Oem/Odim.RotateCredentials"
+	tests := []struct {
+		name       string
+		req        *aggregatorproto.AggregatorRequest
+		statusCode int32
+	}{
+		{"positive case", &aggregatorproto.AggregatorRequest{SessionToken: "validToken", URL: actionURI}, http.StatusAccepted},
+		{"auth fail", &aggregatorproto.AggregatorRequest{SessionToken: "invalidToken", URL: actionURI}, http.StatusUnauthorized},
+		{"get session username fails", &aggregatorproto.AggregatorRequest{SessionToken: "noDetailsToken", URL: actionURI}, http.StatusUnauthorized},
+		{"unable to create task", &aggregatorproto.AggregatorRequest{SessionToken: "noTaskToken", URL: actionURI}, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := a.RotateCredentials(mockContext(), tt.req)
+			if err != nil {
+				t.Fatalf("Aggregator.RotateCredentials() error = %v", err)
+			}
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("Aggregator.RotateCredentials() status = %v, want %v", resp.StatusCode, tt.statusCode)
+			}
+		})
+	}
+}
+
 func TestAggregator_GetAllAggregationSource(t *testing.T) {
 	defer func() {
 		common.TruncateDB(common.OnDisk)
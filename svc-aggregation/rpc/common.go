@@ -66,6 +66,12 @@ func GetAggregator() *Aggregator {
 			DeleteMetricRequest:      agmodel.DeleteMetricRequest,
 			GetResource:              agmodel.GetResource,
 			Delete:                   agmodel.Delete,
+			SaveDiscoveredBMC:        agmodel.SaveDiscoveredBMC,
+			GetDiscoveredBMCInfo:     agmodel.GetDiscoveredBMC,
+			DeleteDiscoveredBMC:      agmodel.DeleteDiscoveredBMC,
+			CheckBMCAddress:          agmodel.CheckBMCAddress,
+
+			ChangeRemoteAccountPassword: services.ChangeRemoteAccountPassword,
 		},
 	}
 }
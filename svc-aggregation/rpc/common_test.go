@@ -58,6 +58,11 @@ var connector = &system.ExternalInterface{
 	GenericSave:              mockGenericSave,
 	CheckActiveRequest:       mockCheckActiveRequest,
 	DeleteActiveRequest:      mockDeleteActiveRequest,
+	GetDiscoveredBMCInfo:     mockGetDiscoveredBMCInfo,
+}
+
+func mockGetDiscoveredBMCInfo(reqURI string) (agmodel.DiscoveredBMC, *errors.Error) {
+	return agmodel.DiscoveredBMC{}, errors.PackError(errors.DBKeyNotFound, "no data with the with key "+reqURI+" found")
 }
 
 func mockGetAggregationSourceInfo(ctx context.Context, reqURI string) (agmodel.AggregationSource, *errors.Error) {
@@ -25,6 +25,7 @@ import (
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
 	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/lib-utilities/secrets"
 	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
 	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
 )
@@ -81,6 +82,38 @@ func (e *ExternalInterface) addAggregationSource(ctx context.Context, taskID, ta
 		Password:         aggregationSourceRequest.Password,
 		ConnectionMethod: aggregationSourceRequest.Links.ConnectionMethod,
 	}
+	// the server is contacted with the secret referenced, and the reference is stored in place of it
+	if secrets.IsReference([]byte(addResourceRequest.Password)) {
+		password, err := e.DecryptPassword([]byte(addResourceRequest.Password))
+		if err != nil {
+			errMsg := "unable to resolve the secret given as the password: " + err.Error()
+			l.LogWithFields(ctx).Error(errMsg)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errMsg, []interface{}{addResourceRequest.Password, "Password"}, taskInfo)
+		}
+		addResourceRequest.SecretReference = addResourceRequest.Password
+		addResourceRequest.Password = string(password)
+	}
+	instanceAddresses, instancesRequested := getRequestedPluginInstances(aggregationSourceRequest.Oem)
+	if instancesRequested {
+		instances, err := getPluginInstancesFromRequest(instanceAddresses, addResourceRequest.ManagerAddress)
+		if err != nil {
+			l.LogWithFields(ctx).Error(err.Error())
+			return common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, err.Error(), []interface{}{fmt.Sprintf("%v", instanceAddresses), "PluginInstances"}, taskInfo)
+		}
+		addResourceRequest.PluginInstances = instances
+	}
+	eventDeliveryMode, err := getRequestedEventDeliveryMode(aggregationSourceRequest.Oem)
+	if err != nil {
+		l.LogWithFields(ctx).Error(err.Error())
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, err.Error(), []interface{}{aggregationSourceRequest.Oem.Odim.EventDeliveryMode, "EventDeliveryMode"}, taskInfo)
+	}
+	addResourceRequest.EventDeliveryMode = eventDeliveryMode
+	certificateTrust, err := getRequestedCertificateTrust(aggregationSourceRequest.Oem)
+	if err != nil {
+		l.LogWithFields(ctx).Error(err.Error())
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, err.Error(), []interface{}{aggregationSourceRequest.Oem.Odim.CertificateTrustMode, "CertificateTrustMode"}, taskInfo)
+	}
+	addResourceRequest.CertificateTrust = certificateTrust
 
 	ipAddr := getKeyFromManagerAddress(addResourceRequest.ManagerAddress)
 	indexList, err := agmodel.GetString("BMCAddress", ipAddr)
@@ -152,6 +185,16 @@ func (e *ExternalInterface) addAggregationSource(ctx context.Context, taskID, ta
 	statusResp, statusCode, queueList := checkStatus(ctx, pluginContactRequest, addResourceRequest, cmVariants, taskInfo)
 	if statusCode == http.StatusOK {
 
+		if eventDeliveryMode != "" {
+			errMsg := "error: EventDeliveryMode can be given only for the aggregation source of a BMC"
+			l.LogWithFields(ctx).Error(errMsg)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errMsg, []interface{}{"Oem/Odim/EventDeliveryMode"}, taskInfo)
+		}
+		if certificateTrust != nil {
+			errMsg := "error: CertificateTrustMode can be given only for the aggregation source of a BMC"
+			l.LogWithFields(ctx).Error(errMsg)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errMsg, []interface{}{"Oem/Odim/CertificateTrustMode"}, taskInfo)
+		}
 		// check if AggregationSource has any values, if its there means its managing the bmcs
 		if len(connectionMethod.Links.AggregationSources) > 0 {
 			errMsg := "Cant proceed to add aggregation source, since connection method is already managing other aggregation sources"
@@ -160,6 +203,11 @@ func (e *ExternalInterface) addAggregationSource(ctx context.Context, taskID, ta
 		}
 		resp, aggregationSourceUUID, cipherText = e.addPluginData(ctx, addResourceRequest, taskID, targetURI, pluginContactRequest, queueList, cmVariants)
 	} else if statusCode == http.StatusNotFound {
+		if instancesRequested {
+			errMsg := "error: PluginInstances can be given only for the aggregation source of a plugin"
+			l.LogWithFields(ctx).Error(errMsg)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errMsg, []interface{}{"Oem/Odim/PluginInstances"}, taskInfo)
+		}
 		resp, aggregationSourceUUID, cipherText = e.addCompute(ctx, taskID, targetURI, cmVariants.PluginID, percentComplete, addResourceRequest, pluginContactRequest)
 	} else {
 		return statusResp
@@ -180,6 +228,10 @@ func (e *ExternalInterface) addAggregationSource(ctx context.Context, taskID, ta
 		UserName: aggregationSourceRequest.UserName,
 		Password: cipherText,
 		Links:    aggregationSourceRequest.Links,
+		Oem:      getAggregationSourceOem(addResourceRequest.PluginInstances),
+	}
+	if eventDeliveryMode != "" || certificateTrust != nil {
+		aggregationSourceData.Oem = getBMCAggregationSourceOem(eventDeliveryMode, certificateTrust)
 	}
 	var aggregationSourceURI = fmt.Sprintf("%s/%s", targetURI, aggregationSourceUUID)
 	dbErr := agmodel.AddAggregationSource(aggregationSourceData, aggregationSourceURI)
@@ -216,6 +268,7 @@ func (e *ExternalInterface) addAggregationSource(ctx context.Context, taskID, ta
 		HostName: aggregationSourceRequest.HostName,
 		UserName: aggregationSourceRequest.UserName,
 		Links:    aggregationSourceRequest.Links,
+		Oem:      aggregationSourceData.Oem,
 	}
 	resp.StatusCode = http.StatusCreated
 	percentComplete = 100
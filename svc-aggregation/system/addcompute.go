@@ -59,6 +59,9 @@ func (e *ExternalInterface) addCompute(ctx context.Context, taskID, targetURI, p
 	//saveSystem.Password = ciphertext
 	saveSystem.Password = []byte(addResourceRequest.Password)
 	saveSystem.PluginID = pluginID
+	saveSystem.EventDeliveryMode = addResourceRequest.EventDeliveryMode
+	// the plugin verifies the certificate of the server before sending it the credentials
+	saveSystem.CertificateTrust = addResourceRequest.CertificateTrust
 
 	pluginContactRequest.Plugin = plugin
 	pluginContactRequest.StatusPoll = true
@@ -105,6 +108,18 @@ func (e *ExternalInterface) addCompute(ctx context.Context, taskID, targetURI, p
 		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo), "", nil
 	}
 
+	trust, err := getEstablishedCertificateTrust(addResourceRequest.CertificateTrust, body)
+	if err != nil {
+		l.LogWithFields(ctx).Error(err.Error())
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, err.Error(), []interface{}{addResourceRequest.CertificateTrust.Mode, "CertificateTrustMode"}, taskInfo), "", nil
+	}
+	if trust != nil {
+		// the trust is shared with the caller, which shows the certificate pinned
+		// by the plugin in the aggregation source
+		saveSystem.CertificateTrust = trust
+		*addResourceRequest.CertificateTrust = *trust
+	}
+
 	commonError.Error.Code = errors.PropertyValueFormatError
 	resp.Body = commonError
 	resp.StatusCode = http.StatusCreated
@@ -287,14 +302,14 @@ func (e *ExternalInterface) addCompute(ctx context.Context, taskID, targetURI, p
 		l.LogWithFields(ctx).Error(h.ErrorMessage)
 		return common.GeneralError(h.StatusCode, h.StatusMessage, h.ErrorMessage, h.MsgArgs, taskInfo), "", nil
 	}
-	err = agmodel.SaveBMCInventory(h.InventoryData)
+	err = h.saveInventory(h.InventoryData)
 	if err != nil {
 		errorMessage := "GenericSave : error while trying to add resource data to DB: " + err.Error()
 		l.LogWithFields(ctx).Error(errorMessage)
 		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage,
 			nil, nil), "", nil
 	}
-	ciphertext, err := e.EncryptPassword([]byte(addResourceRequest.Password))
+	ciphertext, err := e.EncryptPassword(addResourceRequest.passwordToStore())
 	if err != nil {
 		go e.rollbackInMemory(resourceURI)
 		errMsg := "error while trying to encrypt: " + err.Error()
@@ -344,6 +359,9 @@ func (e *ExternalInterface) addCompute(ctx context.Context, taskID, targetURI, p
 				UserName:  addResourceRequest.UserName,
 				Password:  []byte(addResourceRequest.Password),
 				Operation: "add",
+				// the plugin starts polling the server when it is added in Polling mode
+				EventDeliveryMode: addResourceRequest.EventDeliveryMode,
+				CertificateTrust:  saveSystem.CertificateTrust,
 			},
 		},
 	}
@@ -110,13 +110,20 @@ func (e *ExternalInterface) addPluginData(ctx context.Context, req AddResourceRe
 				l.LogWithFields(ctx).Error(errMsg)
 				return common.GeneralError(http.StatusConflict, response.ResourceAlreadyExists, errMsg, []interface{}{"Plugin", "PluginID", ID}, taskInfo), "", nil
 			}
+			for _, instance := range req.PluginInstances {
+				if containsPluginInstance(common.GetPluginInstances(plugin.IP, plugin.Port, plugin.Instances), instance) {
+					errMsg := "error:plugin instance " + instance.Address() + " already belongs to the plugin " + plugin.ID
+					l.LogWithFields(ctx).Error(errMsg)
+					return common.GeneralError(http.StatusConflict, response.ResourceAlreadyExists, errMsg, []interface{}{"Plugin", "PluginID", ID}, taskInfo), "", nil
+				}
+			}
 		}
 	} else {
 		return common.GeneralError(http.StatusServiceUnavailable, response.CouldNotEstablishConnection, err.Error(),
 			[]interface{}{"Backend", config.Data.DBConf.OnDiskHost + ":" + config.Data.DBConf.OnDiskPort}, taskInfo), "", nil
 	}
 	// encrypt plugin password
-	ciphertext, err := e.EncryptPassword([]byte(req.Password))
+	ciphertext, err := e.EncryptPassword(req.passwordToStore())
 	if err != nil {
 		errMsg := "error: encryption failed: " + err.Error()
 		l.LogWithFields(ctx).Error(errMsg)
@@ -310,6 +317,7 @@ func (e *ExternalInterface) addPluginData(ctx context.Context, req AddResourceRe
 	// store encrypted password
 	plugin.Password = ciphertext
 	plugin.ManagerUUID = managerUUID
+	plugin.Instances = req.PluginInstances
 	// saving the pluginData
 	dbErr := agmodel.SavePluginData(plugin)
 	if dbErr != nil {
@@ -207,7 +207,7 @@ func (e *ExternalInterface) GetAggregate(ctx context.Context, req *aggregatorpro
 		StatusMessage: response.Success,
 	}
 
-	resp.Body = agresponse.AggregateGetResponse{
+	aggregateResponse := agresponse.AggregateGetResponse{
 		Response:      commonResponse,
 		ElementsCount: len(aggregate.Elements),
 		Elements:      aggregate.Elements,
@@ -224,8 +224,19 @@ func (e *ExternalInterface) GetAggregate(ctx context.Context, req *aggregatorpro
 			AggregateRemoveElements: agresponse.Action{
 				Target: "/redfish/v1/AggregationService/Aggregates/" + ID + "/Actions/Aggregate.RemoveElements",
 			},
+			Oem: &agresponse.AggregateOemActions{
+				RotateCredentials: agresponse.Action{
+					Target: "/redfish/v1/AggregationService/Aggregates/" + ID + rotateCredentialsURI,
+				},
+			},
 		},
 	}
+	if rotation, err := agmodel.GetCredentialRotation(req.URL); err == nil {
+		aggregateResponse.Oem = &agresponse.AggregateOem{
+			Odim: agresponse.AggregateOdim{CredentialRotation: rotation},
+		}
+	}
+	resp.Body = aggregateResponse
 	return resp
 }
 
@@ -251,6 +262,9 @@ func (e *ExternalInterface) DeleteAggregate(ctx context.Context, req *aggregator
 		}
 		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
 	}
+	if err = agmodel.DeleteCredentialRotation(req.URL); err != nil && errors.DBKeyNotFound != err.ErrNo() {
+		l.LogWithFields(ctx).Error("error while deleting the credential rotation policy of the aggregate : " + err.Error())
+	}
 	err1 := DeleteAggregateSubscription(ctx, req.URL, req.SessionToken, aggregate.Elements)
 	if err1 != nil {
 		l.LogWithFields(ctx).Error("Error while delete subscription details ", err.Error())
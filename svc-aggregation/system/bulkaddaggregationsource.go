@@ -0,0 +1,469 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/lib-utilities/secrets"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+)
+
+const (
+	bulkAddTargetURI           = "/redfish/v1/AggregationService/Actions/Oem/Odim.BulkAddAggregationSources"
+	aggregationSourcesURI      = "/redfish/v1/AggregationService/AggregationSources"
+	defaultBulkAddConcurrency  = 10
+	maxBulkAddConcurrency      = 50
+	bulkAddStatusAdded         = "Added"
+	bulkAddStatusFailed        = "Failed"
+	bulkAddErrorUnreachable    = "Unreachable"
+	bulkAddErrorAuthFailed     = "AuthFailed"
+	bulkAddErrorDuplicate      = "Duplicate"
+	bulkAddErrorPluginDown     = "PluginDown"
+	bulkAddErrorInvalidRequest = "InvalidRequest"
+	bulkAddErrorOther          = "Other"
+)
+
+// bulkAddManifestColumns are the columns accepted in a CSV manifest
+var bulkAddManifestColumns = []string{"HostName", "UserName", "Password", "CredentialReference", "ConnectionMethod"}
+
+// BulkAddRequest is the request body of the BulkAddAggregationSources action.
+// The sources to be added are given either as a JSON array in AggregationSources
+// or as a CSV document in Manifest, not both.
+type BulkAddRequest struct {
+	AggregationSources []BulkAddSource `json:"AggregationSources,omitempty"`
+	Manifest           string          `json:"Manifest,omitempty"`
+	MaxConcurrency     int             `json:"MaxConcurrency,omitempty"`
+}
+
+// BulkAddSource is a single entry of the bulk add manifest.
+// CredentialReference is the URI of an already added AggregationSource whose
+// credentials are to be reused when UserName and Password are not given.
+type BulkAddSource struct {
+	HostName            string `json:"HostName"`
+	UserName            string `json:"UserName,omitempty"`
+	Password            string `json:"Password,omitempty"`
+	CredentialReference string `json:"CredentialReference,omitempty"`
+	Links               *Links `json:"Links,omitempty"`
+}
+
+// BulkAddAggregationSources adds all the aggregation sources listed in the manifest.
+// Each entry is added under its own sub task and at most MaxConcurrency entries are
+// added in parallel. The parent task completes with a summary of every entry,
+// failures being categorized as unreachable, auth failed, duplicate or plugin down.
+func (e *ExternalInterface) BulkAddAggregationSources(ctx context.Context, taskID, sessionUserName string, req *aggregatorproto.AggregatorRequest) response.RPC {
+	var resp response.RPC
+	var percentComplete int32
+	targetURI := bulkAddTargetURI
+	taskInfo := &common.TaskUpdateInfo{Context: ctx, TaskID: taskID, TargetURI: targetURI, UpdateTask: e.UpdateTask, TaskRequest: string(req.RequestBody)}
+	err := e.UpdateTask(ctx, fillTaskData(taskID, targetURI, string(req.RequestBody), resp, common.Running, common.OK, percentComplete, http.MethodPost))
+	if err != nil {
+		errMsg := "error while starting the task: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+
+	var bulkAddRequest BulkAddRequest
+	if err := json.Unmarshal(req.RequestBody, &bulkAddRequest); err != nil {
+		errMsg := "unable to parse the bulk add request: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, taskInfo)
+	}
+	invalidProperties, err := common.RequestParamsCaseValidator(req.RequestBody, bulkAddRequest)
+	if err != nil {
+		errMsg := "error while validating request parameters: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+	} else if invalidProperties != "" {
+		errMsg := "error: one or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase "
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errMsg, []interface{}{invalidProperties}, taskInfo)
+	}
+	sources, statusMessage, msgArgs, err := bulkAddRequest.getSources()
+	if err != nil {
+		errMsg := "error while reading the bulk add manifest: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, statusMessage, errMsg, msgArgs, taskInfo)
+	}
+	concurrency := bulkAddRequest.MaxConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultBulkAddConcurrency
+	} else if concurrency > maxBulkAddConcurrency {
+		concurrency = maxBulkAddConcurrency
+	}
+
+	pluginErrors := e.getBulkAddPluginErrors(ctx, sources)
+	results := make([]agresponse.BulkAddResult, len(sources))
+	seenHosts := make(map[string]bool, len(sources))
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	var completed int
+	var cancelled bool
+
+	threadID := 1
+	for i, source := range sources {
+		hostKey := strings.ToLower(source.HostName)
+		duplicate := source.HostName != "" && seenHosts[hostKey]
+		seenHosts[hostKey] = true
+
+		mutex.Lock()
+		stop := cancelled
+		mutex.Unlock()
+		if stop {
+			break
+		}
+		semaphore <- struct{}{}
+		wg.Add(1)
+		addCtx := context.WithValue(ctx, common.ThreadName, common.BulkAddAggregationSource)
+		addCtx = context.WithValue(addCtx, common.ThreadID, strconv.Itoa(threadID))
+		threadID++
+		go func(index int, source BulkAddSource, duplicate bool) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			result := e.bulkAddSource(addCtx, taskID, sessionUserName, source, duplicate, pluginErrors)
+			mutex.Lock()
+			defer mutex.Unlock()
+			results[index] = result
+			completed++
+			if cancelled || completed == len(sources) {
+				return
+			}
+			// reserve the last percent for the final update of the parent task
+			percent := int32(completed * 99 / len(sources))
+			err := e.UpdateTask(ctx, fillTaskData(taskID, targetURI, string(req.RequestBody), resp, common.Running, common.OK, percent, http.MethodPost))
+			if err != nil && err.Error() == common.Cancelling {
+				cancelled = true
+			}
+		}(i, source, duplicate)
+	}
+	wg.Wait()
+
+	percentComplete = 100
+	if cancelled {
+		l.LogWithFields(ctx).Info("bulk add of aggregation sources is cancelled for the task " + taskID)
+		e.UpdateTask(ctx, fillTaskData(taskID, targetURI, string(req.RequestBody), resp, common.Cancelled, common.OK, percentComplete, http.MethodPost))
+		runtime.Goexit()
+	}
+
+	summary := summarizeBulkAddResults(results)
+	resp.StatusCode = http.StatusOK
+	resp.StatusMessage = response.Success
+	resp.Body = summary
+	taskStatus := common.OK
+	if summary.Failed > 0 {
+		taskStatus = common.Warning
+		l.LogWithFields(ctx).Warnf("%d of %d aggregation sources could not be added. for more information please check SubTasks in URI: /redfish/v1/TaskService/Tasks/%s",
+			summary.Failed, summary.Total, taskID)
+	} else {
+		l.LogWithFields(ctx).Infof("all %d aggregation sources are successfully added", summary.Total)
+	}
+	err = e.UpdateTask(ctx, fillTaskData(taskID, targetURI, string(req.RequestBody), resp, common.Completed, taskStatus, percentComplete, http.MethodPost))
+	if err != nil && err.Error() == common.Cancelling {
+		e.UpdateTask(ctx, fillTaskData(taskID, targetURI, string(req.RequestBody), resp, common.Cancelled, common.Critical, percentComplete, http.MethodPost))
+		runtime.Goexit()
+	}
+	return resp
+}
+
+// getSources returns the manifest entries from either the JSON array or the CSV manifest
+// along with the message ID and args to be used when the manifest is not valid
+func (req BulkAddRequest) getSources() ([]BulkAddSource, string, []interface{}, error) {
+	switch {
+	case len(req.AggregationSources) > 0 && req.Manifest != "":
+		return nil, response.PropertyValueConflict, []interface{}{"AggregationSources", "Manifest"},
+			fmt.Errorf("only one of AggregationSources and Manifest can be given")
+	case len(req.AggregationSources) > 0:
+		return req.AggregationSources, "", nil, nil
+	case req.Manifest != "":
+		return parseBulkAddManifest(req.Manifest)
+	}
+	return nil, response.PropertyMissing, []interface{}{"AggregationSources"},
+		fmt.Errorf("one of AggregationSources or Manifest is required")
+}
+
+// parseBulkAddManifest reads a CSV manifest. The first record is the header naming the
+// columns, which are any of bulkAddManifestColumns in any order.
+func parseBulkAddManifest(manifest string) ([]BulkAddSource, string, []interface{}, error) {
+	reader := csv.NewReader(strings.NewReader(manifest))
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+	header, err := reader.Read()
+	if err != nil {
+		return nil, response.PropertyValueFormatError, []interface{}{"<manifest>", "Manifest"},
+			fmt.Errorf("unable to read the manifest header: %v", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if !isBulkAddManifestColumn(name) {
+			return nil, response.PropertyUnknown, []interface{}{name}, fmt.Errorf("unknown manifest column %s", name)
+		}
+		columns[name] = i
+	}
+	if _, found := columns["HostName"]; !found {
+		return nil, response.PropertyMissing, []interface{}{"HostName"}, fmt.Errorf("manifest column HostName is missing")
+	}
+
+	var sources []BulkAddSource
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, response.PropertyValueFormatError, []interface{}{"<manifest>", "Manifest"},
+				fmt.Errorf("unable to read the manifest: %v", err)
+		}
+		value := func(column string) string {
+			if index, found := columns[column]; found && index < len(record) {
+				return strings.TrimSpace(record[index])
+			}
+			return ""
+		}
+		source := BulkAddSource{
+			HostName:            value("HostName"),
+			UserName:            value("UserName"),
+			Password:            value("Password"),
+			CredentialReference: value("CredentialReference"),
+		}
+		if connectionMethod := value("ConnectionMethod"); connectionMethod != "" {
+			source.Links = &Links{ConnectionMethod: &ConnectionMethod{OdataID: connectionMethod}}
+		}
+		sources = append(sources, source)
+	}
+	if len(sources) == 0 {
+		return nil, response.PropertyMissing, []interface{}{"AggregationSources"}, fmt.Errorf("manifest has no entries")
+	}
+	return sources, "", nil, nil
+}
+
+func isBulkAddManifestColumn(name string) bool {
+	for _, column := range bulkAddManifestColumns {
+		if column == name {
+			return true
+		}
+	}
+	return false
+}
+
+// getBulkAddPluginErrors checks the plugins serving the connection methods referred in
+// the manifest once, so that entries served by an unavailable plugin fail fast.
+// It returns the error message for each connection method whose plugin is down.
+func (e *ExternalInterface) getBulkAddPluginErrors(ctx context.Context, sources []BulkAddSource) map[string]string {
+	pluginErrors := make(map[string]string)
+	checked := make(map[string]bool)
+	for _, source := range sources {
+		if source.Links == nil || source.Links.ConnectionMethod == nil {
+			continue
+		}
+		connectionMethodURI := source.Links.ConnectionMethod.OdataID
+		if checked[connectionMethodURI] {
+			continue
+		}
+		checked[connectionMethodURI] = true
+		connectionMethod, err := e.GetConnectionMethod(ctx, connectionMethodURI)
+		if err != nil {
+			// the entry itself will fail with the appropriate error
+			continue
+		}
+		cmVariants := getConnectionMethodVariants(ctx, connectionMethod.ConnectionMethodVariant)
+		plugin, err := e.GetPluginMgrAddr(cmVariants.PluginID, agmodel.DBPluginDataRead{DBReadclient: agmodel.GetPluginDBConnection})
+		if err != nil {
+			// plugin is not added yet, entry may be the plugin itself
+			continue
+		}
+		if !e.GetPluginStatus(ctx, plugin) {
+			pluginErrors[connectionMethodURI] = fmt.Sprintf("plugin %s at %s:%s is not reachable", plugin.ID, plugin.IP, plugin.Port)
+			l.LogWithFields(ctx).Warn(pluginErrors[connectionMethodURI])
+		}
+	}
+	return pluginErrors
+}
+
+// bulkAddSource adds one entry of the manifest under a sub task of the bulk add task
+func (e *ExternalInterface) bulkAddSource(ctx context.Context, taskID, sessionUserName string, source BulkAddSource, duplicate bool, pluginErrors map[string]string) agresponse.BulkAddResult {
+	result := agresponse.BulkAddResult{
+		HostName: source.HostName,
+		Status:   bulkAddStatusFailed,
+	}
+	subTaskURI, err := e.CreateChildTask(ctx, sessionUserName, taskID)
+	if err != nil {
+		result.ErrorCategory = bulkAddErrorOther
+		result.Message = "error while trying to create sub task: " + err.Error()
+		l.LogWithFields(ctx).Error(result.Message)
+		return result
+	}
+	result.Task = subTaskURI
+	var subTaskID string
+	strArray := strings.Split(subTaskURI, "/")
+	if strings.HasSuffix(subTaskURI, "/") {
+		subTaskID = strArray[len(strArray)-2]
+	} else {
+		subTaskID = strArray[len(strArray)-1]
+	}
+	reqBody, _ := json.Marshal(source)
+	taskInfo := &common.TaskUpdateInfo{Context: ctx, TaskID: subTaskID, TargetURI: aggregationSourcesURI, UpdateTask: e.UpdateTask, TaskRequest: string(reqBody)}
+	var resp response.RPC
+	e.UpdateTask(ctx, fillTaskData(subTaskID, aggregationSourcesURI, string(reqBody), resp, common.Running, common.OK, 0, http.MethodPost))
+
+	aggregationSourceRequest, resp := e.getBulkAddAggregationSource(ctx, source, duplicate, pluginErrors, taskInfo)
+	if resp.StatusCode == 0 {
+		resp = e.addAggregationSource(ctx, subTaskID, aggregationSourcesURI, string(reqBody), 0, aggregationSourceRequest, taskInfo)
+	}
+	if resp.StatusCode == http.StatusCreated {
+		result.Status = bulkAddStatusAdded
+		result.AggregationSource = resp.Header["Location"]
+		return result
+	}
+	result.ErrorCategory = getBulkAddErrorCategory(resp, source.HostName)
+	result.Message = getErrorMessage(resp)
+	l.LogWithFields(ctx).Errorf("unable to add the aggregation source %s: %s", source.HostName, result.Message)
+	return result
+}
+
+// getBulkAddAggregationSource validates a manifest entry and builds the add request from it.
+// When the entry cannot be added the error response is returned after failing the sub task.
+func (e *ExternalInterface) getBulkAddAggregationSource(ctx context.Context, source BulkAddSource, duplicate bool, pluginErrors map[string]string, taskInfo *common.TaskUpdateInfo) (AggregationSource, response.RPC) {
+	aggregationSource := AggregationSource{
+		HostName: source.HostName,
+		UserName: source.UserName,
+		Password: source.Password,
+		Links:    source.Links,
+	}
+	if source.HostName == "" {
+		errMsg := "error: mandatory HostName missing in the manifest entry"
+		return aggregationSource, common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"HostName"}, taskInfo)
+	}
+	if duplicate {
+		errMsg := fmt.Sprintf("HostName %s is listed more than once in the manifest", source.HostName)
+		return aggregationSource, common.GeneralError(http.StatusConflict, response.ResourceAlreadyExists, errMsg, []interface{}{"AggregationSource", "HostName", source.HostName}, taskInfo)
+	}
+	if source.Links == nil || source.Links.ConnectionMethod == nil || source.Links.ConnectionMethod.OdataID == "" {
+		errMsg := "error: mandatory ConnectionMethod missing in the manifest entry"
+		return aggregationSource, common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"ConnectionMethod"}, taskInfo)
+	}
+	if source.UserName == "" && source.Password == "" && source.CredentialReference != "" {
+		reference, err := e.GetAggregationSourceInfo(ctx, source.CredentialReference)
+		if err != nil {
+			errMsg := "unable to get the referred aggregation source: " + err.Error()
+			return aggregationSource, common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"AggregationSource", source.CredentialReference}, taskInfo)
+		}
+		// a reference to a secret is kept so that the new aggregation source stores it as well
+		password, err1 := reference.Password, error(nil)
+		if !secrets.IsReference(password) {
+			password, err1 = e.DecryptPassword(reference.Password)
+		}
+		if err1 != nil {
+			errMsg := "error while trying to decrypt the referred credentials: " + err1.Error()
+			return aggregationSource, common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+		}
+		aggregationSource.UserName = reference.UserName
+		aggregationSource.Password = string(password)
+	}
+	if aggregationSource.UserName == "" || aggregationSource.Password == "" {
+		errMsg := "error: credentials or CredentialReference missing in the manifest entry"
+		return aggregationSource, common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"UserName"}, taskInfo)
+	}
+	if errMsg, found := pluginErrors[source.Links.ConnectionMethod.OdataID]; found {
+		resp := common.GeneralError(http.StatusServiceUnavailable, response.CouldNotEstablishConnection, errMsg, []interface{}{source.Links.ConnectionMethod.OdataID}, taskInfo)
+		return aggregationSource, resp
+	}
+	return aggregationSource, response.RPC{}
+}
+
+// getBulkAddErrorCategory maps the response of a failed add to the category reported for the entry
+func getBulkAddErrorCategory(resp response.RPC, hostName string) string {
+	switch resp.StatusMessage {
+	case response.ResourceAlreadyExists:
+		return bulkAddErrorDuplicate
+	case response.ResourceAtURIUnauthorized, response.NoValidSession:
+		return bulkAddErrorAuthFailed
+	case response.CouldNotEstablishConnection:
+		// connection errors name the unreachable address in the message args,
+		// which is the BMC when the plugin itself could be reached
+		ip, _ := getIPAndPortFromAddress(hostName)
+		for _, msg := range getErrorMessages(resp) {
+			for _, arg := range msg.MessageArgs {
+				if address, ok := arg.(string); ok && ip != "" && strings.Contains(address, ip) {
+					return bulkAddErrorUnreachable
+				}
+			}
+		}
+		return bulkAddErrorPluginDown
+	case response.PropertyMissing, response.PropertyUnknown, response.PropertyValueFormatError,
+		response.PropertyValueNotInList, response.ResourceNotFound, response.MalformedJSON:
+		return bulkAddErrorInvalidRequest
+	}
+	switch resp.StatusCode {
+	case http.StatusConflict:
+		return bulkAddErrorDuplicate
+	case http.StatusUnauthorized:
+		return bulkAddErrorAuthFailed
+	}
+	return bulkAddErrorOther
+}
+
+// getErrorMessages returns the extended messages of an error response
+func getErrorMessages(resp response.RPC) []response.Msg {
+	var commonError response.CommonError
+	body, _ := json.Marshal(resp.Body)
+	json.Unmarshal(body, &commonError)
+	return commonError.Error.MessageExtendedInfo
+}
+
+// getErrorMessage returns the first message of an error response
+func getErrorMessage(resp response.RPC) string {
+	for _, msg := range getErrorMessages(resp) {
+		if msg.Message != "" {
+			return msg.Message
+		}
+	}
+	var commonError response.CommonError
+	body, _ := json.Marshal(resp.Body)
+	json.Unmarshal(body, &commonError)
+	return commonError.Error.Message
+}
+
+func summarizeBulkAddResults(results []agresponse.BulkAddResult) agresponse.BulkAddSummary {
+	summary := agresponse.BulkAddSummary{
+		Total:    len(results),
+		Failures: map[string]int{},
+		Results:  results,
+	}
+	for _, result := range results {
+		if result.Status == bulkAddStatusAdded {
+			summary.Added++
+			continue
+		}
+		summary.Failed++
+		summary.Failures[result.ErrorCategory]++
+	}
+	return summary
+}
@@ -0,0 +1,249 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+)
+
+const (
+	bulkAddCMURI        = "/redfish/v1/AggregationService/ConnectionMethods/7ff3bd97-c41c-5de0-937d-85d390691b73"
+	bulkAddReferenceURI = "/redfish/v1/AggregationService/AggregationSources/36474ba4-a201-46aa-badf-d8104da418e8"
+)
+
+func mockPluginDownStatus(ctx context.Context, plugin agmodel.Plugin) bool {
+	return false
+}
+
+func TestParseBulkAddManifest(t *testing.T) {
+	tests := []struct {
+		name          string
+		manifest      string
+		want          []BulkAddSource
+		statusMessage string
+	}{
+		{
+			name: "valid manifest",
+			manifest: "HostName,UserName,Password,ConnectionMethod\n" +
+				"10.0.0.1,admin,secret," + bulkAddCMURI + "\n" +
+				"# commented entry\n" +
+				"10.0.0.2, admin, secret,\n",
+			want: []BulkAddSource{
+				{HostName: "10.0.0.1", UserName: "admin", Password: "secret", Links: &Links{ConnectionMethod: &ConnectionMethod{OdataID: bulkAddCMURI}}},
+				{HostName: "10.0.0.2", UserName: "admin", Password: "secret"},
+			},
+		},
+		{
+			name:     "columns in any order with credential reference",
+			manifest: "CredentialReference,HostName\n" + bulkAddReferenceURI + ",bmc1.example.com\n",
+			want: []BulkAddSource{
+				{HostName: "bmc1.example.com", CredentialReference: bulkAddReferenceURI},
+			},
+		},
+		{
+			name:          "unknown column",
+			manifest:      "HostName,Username\n10.0.0.1,admin\n",
+			statusMessage: response.PropertyUnknown,
+		},
+		{
+			name:          "missing HostName column",
+			manifest:      "UserName,Password\nadmin,secret\n",
+			statusMessage: response.PropertyMissing,
+		},
+		{
+			name:          "no entries",
+			manifest:      "HostName,UserName,Password\n",
+			statusMessage: response.PropertyMissing,
+		},
+		{
+			name:          "malformed record",
+			manifest:      "HostName,UserName\n\"10.0.0.1,admin\n",
+			statusMessage: response.PropertyValueFormatError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, statusMessage, _, err := parseBulkAddManifest(tt.manifest)
+			if tt.statusMessage != "" {
+				if err == nil || statusMessage != tt.statusMessage {
+					t.Errorf("parseBulkAddManifest() statusMessage = %v, err = %v, want %v", statusMessage, err, tt.statusMessage)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseBulkAddManifest() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseBulkAddManifest() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetBulkAddErrorCategory(t *testing.T) {
+	tests := []struct {
+		name     string
+		resp     response.RPC
+		hostName string
+		want     string
+	}{
+		{
+			name:     "already added",
+			resp:     common.GeneralError(http.StatusConflict, response.ResourceAlreadyExists, "exists", []interface{}{"ComputerSystem", "HostName", "10.0.0.1"}, nil),
+			hostName: "10.0.0.1",
+			want:     bulkAddErrorDuplicate,
+		},
+		{
+			name:     "active request",
+			resp:     response.RPC{StatusCode: http.StatusConflict},
+			hostName: "10.0.0.1",
+			want:     bulkAddErrorDuplicate,
+		},
+		{
+			name:     "bad credentials",
+			resp:     common.GeneralError(http.StatusUnauthorized, response.ResourceAtURIUnauthorized, "unauthorized", []interface{}{"10.0.0.1"}, nil),
+			hostName: "10.0.0.1",
+			want:     bulkAddErrorAuthFailed,
+		},
+		{
+			name:     "bmc not reachable",
+			resp:     common.GeneralError(http.StatusServiceUnavailable, response.CouldNotEstablishConnection, "unreachable", []interface{}{"10.0.0.1:443"}, nil),
+			hostName: "10.0.0.1:443",
+			want:     bulkAddErrorUnreachable,
+		},
+		{
+			name:     "plugin not reachable",
+			resp:     common.GeneralError(http.StatusServiceUnavailable, response.CouldNotEstablishConnection, "unreachable", []interface{}{"https://localhost:45001/ODIM/v1/Status"}, nil),
+			hostName: "10.0.0.1",
+			want:     bulkAddErrorPluginDown,
+		},
+		{
+			name:     "unknown connection method",
+			resp:     common.GeneralError(http.StatusNotFound, response.ResourceNotFound, "not found", []interface{}{"connectionmethod id", bulkAddCMURI}, nil),
+			hostName: "10.0.0.1",
+			want:     bulkAddErrorInvalidRequest,
+		},
+		{
+			name:     "internal error",
+			resp:     common.GeneralError(http.StatusInternalServerError, response.InternalError, "db down", nil, nil),
+			hostName: "10.0.0.1",
+			want:     bulkAddErrorOther,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getBulkAddErrorCategory(tt.resp, tt.hostName); got != tt.want {
+				t.Errorf("getBulkAddErrorCategory() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExternalInterface_BulkAddAggregationSources(t *testing.T) {
+	config.SetUpMockConfig(t)
+	e := getMockExternalInterface()
+	e.GetAggregationSourceInfo = mockGetAggregationSourceInfo
+	e.GetPluginStatus = mockPluginDownStatus
+
+	reqWithBoth, _ := json.Marshal(map[string]interface{}{
+		"AggregationSources": []BulkAddSource{{HostName: "10.0.0.1"}},
+		"Manifest":           "HostName\n10.0.0.2\n",
+	})
+	reqWithUnknownProperty, _ := json.Marshal(map[string]interface{}{
+		"aggregationSources": []BulkAddSource{{HostName: "10.0.0.1"}},
+	})
+	manifest := "HostName,UserName,Password,CredentialReference,ConnectionMethod\n" +
+		"10.0.0.1,admin,secret,," + "\n" +
+		"10.0.0.1,admin,secret,," + bulkAddCMURI + "\n" +
+		",admin,secret,," + bulkAddCMURI + "\n" +
+		"10.0.0.2,,,/redfish/v1/AggregationService/AggregationSources/unknown," + bulkAddCMURI + "\n" +
+		"10.0.0.3,,,," + bulkAddCMURI + "\n" +
+		"10.0.0.4,,," + bulkAddReferenceURI + "," + bulkAddCMURI + "\n"
+	reqWithManifest, _ := json.Marshal(BulkAddRequest{Manifest: manifest, MaxConcurrency: 2})
+
+	tests := []struct {
+		name       string
+		req        []byte
+		statusCode int32
+		want       []agresponse.BulkAddResult
+	}{
+		{
+			name:       "both sources and manifest",
+			req:        reqWithBoth,
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "no sources",
+			req:        []byte(`{}`),
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid property case",
+			req:        reqWithUnknownProperty,
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "every entry categorized",
+			req:        reqWithManifest,
+			statusCode: http.StatusOK,
+			want: []agresponse.BulkAddResult{
+				{HostName: "10.0.0.1", ErrorCategory: bulkAddErrorInvalidRequest},
+				{HostName: "10.0.0.1", ErrorCategory: bulkAddErrorDuplicate},
+				{HostName: "", ErrorCategory: bulkAddErrorInvalidRequest},
+				{HostName: "10.0.0.2", ErrorCategory: bulkAddErrorInvalidRequest},
+				{HostName: "10.0.0.3", ErrorCategory: bulkAddErrorInvalidRequest},
+				{HostName: "10.0.0.4", ErrorCategory: bulkAddErrorPluginDown},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := e.BulkAddAggregationSources(mockContext(), "someTaskID", "admin", &aggregatorproto.AggregatorRequest{RequestBody: tt.req})
+			if resp.StatusCode != tt.statusCode {
+				t.Fatalf("BulkAddAggregationSources() status = %v, want %v", resp.StatusCode, tt.statusCode)
+			}
+			if tt.want == nil {
+				return
+			}
+			summary, ok := resp.Body.(agresponse.BulkAddSummary)
+			if !ok {
+				t.Fatalf("BulkAddAggregationSources() body = %T, want agresponse.BulkAddSummary", resp.Body)
+			}
+			if summary.Total != len(tt.want) || summary.Failed != len(tt.want) || summary.Added != 0 {
+				t.Errorf("BulkAddAggregationSources() summary counts = %d/%d/%d", summary.Total, summary.Added, summary.Failed)
+			}
+			for i, want := range tt.want {
+				got := summary.Results[i]
+				if got.HostName != want.HostName || got.Status != bulkAddStatusFailed || got.ErrorCategory != want.ErrorCategory || got.Task == "" {
+					t.Errorf("BulkAddAggregationSources() result %d = %+v, want %+v", i, got, want)
+				}
+			}
+			if summary.Failures[bulkAddErrorInvalidRequest] != 4 || summary.Failures[bulkAddErrorDuplicate] != 1 || summary.Failures[bulkAddErrorPluginDown] != 1 {
+				t.Errorf("BulkAddAggregationSources() failures = %v", summary.Failures)
+			}
+		})
+	}
+}
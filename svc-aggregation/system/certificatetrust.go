@@ -0,0 +1,68 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+)
+
+// getRequestedCertificateTrust returns the certificate trust given in the Oem block
+// of an aggregation source request, nil when it is not given
+func getRequestedCertificateTrust(oem *AggregationSourceOem) (*common.CertificateTrust, error) {
+	if oem == nil || oem.Odim == nil || (oem.Odim.CertificateTrustMode == "" && oem.Odim.CACertificateChain == "") {
+		return nil, nil
+	}
+	trust := common.CertificateTrust{
+		Mode: oem.Odim.CertificateTrustMode,
+	}
+	switch trust.Mode {
+	case common.CertificateTrustModeRootCA, common.CertificateTrustModeTrustOnFirstUse:
+		if oem.Odim.CACertificateChain != "" {
+			return nil, fmt.Errorf("error: CACertificateChain can be given only in %s mode", common.CertificateTrustModeCAChain)
+		}
+	case common.CertificateTrustModeCAChain:
+		trust.Certificate = oem.Odim.CACertificateChain
+	default:
+		return nil, fmt.Errorf("error: CertificateTrustMode %s is not one of %s, %s, %s", trust.Mode,
+			common.CertificateTrustModeRootCA, common.CertificateTrustModeTrustOnFirstUse, common.CertificateTrustModeCAChain)
+	}
+	if err := trust.Validate(); err != nil {
+		return nil, fmt.Errorf("error: invalid CACertificateChain: %v", err)
+	}
+	return &trust, nil
+}
+
+// getEstablishedCertificateTrust returns the certificate trust the plugin established for a BMC
+// from the response of the plugin to its validation. The trust on first use carries the
+// certificate captured by the plugin. A plugin not returning the trust does not support it.
+func getEstablishedCertificateTrust(requested *common.CertificateTrust, body []byte) (*common.CertificateTrust, error) {
+	if requested == nil {
+		return nil, nil
+	}
+	var validateResponse struct {
+		CertificateTrust *common.CertificateTrust
+	}
+	json.Unmarshal(body, &validateResponse)
+	if validateResponse.CertificateTrust != nil {
+		return validateResponse.CertificateTrust, nil
+	}
+	if requested.Mode == common.CertificateTrustModeRootCA {
+		return requested, nil
+	}
+	return nil, fmt.Errorf("error: the plugin does not support the CertificateTrustMode %s", requested.Mode)
+}
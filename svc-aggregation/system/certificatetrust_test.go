@@ -0,0 +1,131 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+)
+
+// newTestCertificate returns a certificate signed by the parent, self-signed without a parent, and its PEM
+func newTestCertificate(t *testing.T, name string, isCA bool, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("error while generating the key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("error while creating the certificate: %v", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return cert, key, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestGetRequestedCertificateTrust(t *testing.T) {
+	_, _, chain := newTestCertificate(t, "ca", true, nil, nil)
+	tests := []struct {
+		name     string
+		oem      *AggregationSourceOem
+		wantMode string
+		wantErr  bool
+	}{
+		{name: "no Oem block", oem: nil},
+		{name: "mode not given", oem: &AggregationSourceOem{Odim: &AggregationSourceOdim{}}},
+		{name: "root CA", oem: &AggregationSourceOem{Odim: &AggregationSourceOdim{CertificateTrustMode: "RootCA"}}, wantMode: common.CertificateTrustModeRootCA},
+		{name: "trust on first use", oem: &AggregationSourceOem{Odim: &AggregationSourceOdim{CertificateTrustMode: "TrustOnFirstUse"}}, wantMode: common.CertificateTrustModeTrustOnFirstUse},
+		{name: "CA chain", oem: &AggregationSourceOem{Odim: &AggregationSourceOdim{CertificateTrustMode: "CAChain", CACertificateChain: chain}}, wantMode: common.CertificateTrustModeCAChain},
+		{name: "CA chain missing", oem: &AggregationSourceOem{Odim: &AggregationSourceOdim{CertificateTrustMode: "CAChain"}}, wantErr: true},
+		{name: "invalid CA chain", oem: &AggregationSourceOem{Odim: &AggregationSourceOdim{CertificateTrustMode: "CAChain", CACertificateChain: "chain"}}, wantErr: true},
+		{name: "CA chain in another mode", oem: &AggregationSourceOem{Odim: &AggregationSourceOdim{CertificateTrustMode: "TrustOnFirstUse", CACertificateChain: chain}}, wantErr: true},
+		{name: "CA chain without mode", oem: &AggregationSourceOem{Odim: &AggregationSourceOdim{CACertificateChain: chain}}, wantErr: true},
+		{name: "invalid mode", oem: &AggregationSourceOem{Odim: &AggregationSourceOdim{CertificateTrustMode: "Insecure"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getRequestedCertificateTrust(tt.oem)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getRequestedCertificateTrust() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var gotMode string
+			if got != nil {
+				gotMode = got.Mode
+			}
+			if gotMode != tt.wantMode {
+				t.Errorf("getRequestedCertificateTrust() mode = %v, want %v", gotMode, tt.wantMode)
+			}
+		})
+	}
+}
+
+func TestGetEstablishedCertificateTrust(t *testing.T) {
+	cert, _, _ := newTestCertificate(t, "bmc", false, nil, nil)
+	pinned := common.PinCertificate(cert)
+	validateResponse, _ := json.Marshal(map[string]interface{}{
+		"ServerIP":         "10.0.0.1",
+		"CertificateTrust": pinned,
+	})
+	tofu := &common.CertificateTrust{Mode: common.CertificateTrustModeTrustOnFirstUse}
+	rootCA := &common.CertificateTrust{Mode: common.CertificateTrustModeRootCA}
+
+	if got, err := getEstablishedCertificateTrust(nil, validateResponse); got != nil || err != nil {
+		t.Errorf("getEstablishedCertificateTrust() = %v, %v, want nil, nil", got, err)
+	}
+	got, err := getEstablishedCertificateTrust(tofu, validateResponse)
+	if err != nil || got == nil || *got != pinned {
+		t.Errorf("getEstablishedCertificateTrust() = %v, %v, want the pinned certificate", got, err)
+	}
+	// a plugin not returning the trust does not support it
+	if _, err := getEstablishedCertificateTrust(tofu, []byte(`{"ServerIP":"10.0.0.1"}`)); err == nil {
+		t.Error("getEstablishedCertificateTrust() error = nil, want an error for a plugin not supporting the trust")
+	}
+	if got, err := getEstablishedCertificateTrust(rootCA, []byte(`{"ServerIP":"10.0.0.1"}`)); err != nil || got != rootCA {
+		t.Errorf("getEstablishedCertificateTrust() = %v, %v, want the root CA trust", got, err)
+	}
+}
+
+func TestGetBMCAggregationSourceOemWithCertificateTrust(t *testing.T) {
+	trust := &common.CertificateTrust{Mode: common.CertificateTrustModeTrustOnFirstUse, Certificate: "pem", Fingerprint: "AB:CD"}
+	oem := getBMCAggregationSourceOem("", trust)
+	if oem == nil {
+		t.Fatal("getBMCAggregationSourceOem() = nil")
+	}
+	odim := (*oem).(AggregationSourceOem).Odim
+	if odim.CertificateTrustMode != trust.Mode || odim.CertificateFingerprint != trust.Fingerprint || odim.CACertificateChain != "" {
+		t.Errorf("getBMCAggregationSourceOem() = %+v, want the mode and the fingerprint of the trust", *odim)
+	}
+}
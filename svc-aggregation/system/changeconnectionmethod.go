@@ -0,0 +1,470 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agcommon"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+)
+
+const (
+	changeConnectionMethodURI = "/Actions/Oem/Odim.ChangeConnectionMethod"
+	// changeConnectionMethodOperation is the system operation blocking the other
+	// operations on the server while its connection method is changed
+	changeConnectionMethodOperation = "ChangeConnectionMethod"
+)
+
+// ChangeConnectionMethodRequest is the request body of the ChangeConnectionMethod action of an aggregation source
+type ChangeConnectionMethodRequest struct {
+	ConnectionMethod *ConnectionMethod `json:"ConnectionMethod"`
+}
+
+// ChangeConnectionMethod moves the server of an aggregation source to the plugin of another connection method.
+// The server keeps its device UUID and the IDs of its resources, so the aggregates and the event subscriptions
+// having it stay valid. The event subscription on the server is created again through the new plugin and the
+// inventory is resynced with what the new plugin reports.
+func (e *ExternalInterface) ChangeConnectionMethod(ctx context.Context, taskID, sessionUserName string, req *aggregatorproto.AggregatorRequest) response.RPC {
+	var resp response.RPC
+	var percentComplete int32
+	targetURI := req.URL
+	reqBody := string(req.RequestBody)
+	aggregationSourceURI := strings.TrimSuffix(req.URL, changeConnectionMethodURI)
+	taskInfo := &common.TaskUpdateInfo{Context: ctx, TaskID: taskID, TargetURI: targetURI, UpdateTask: e.UpdateTask, TaskRequest: reqBody}
+	err := e.UpdateTask(ctx, fillTaskData(taskID, targetURI, reqBody, resp, common.Running, common.OK, percentComplete, http.MethodPost))
+	if err != nil {
+		errMsg := "error while starting the task: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+
+	var changeRequest ChangeConnectionMethodRequest
+	if err := json.Unmarshal(req.RequestBody, &changeRequest); err != nil {
+		errMsg := "unable to parse the change connection method request: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, taskInfo)
+	}
+	invalidProperties, err := common.RequestParamsCaseValidator(req.RequestBody, changeRequest)
+	if err != nil {
+		errMsg := "error while validating request parameters: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+	} else if invalidProperties != "" {
+		errMsg := "error: one or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase "
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errMsg, []interface{}{invalidProperties}, taskInfo)
+	}
+	if changeRequest.ConnectionMethod == nil || changeRequest.ConnectionMethod.OdataID == "" {
+		errMsg := "error: ConnectionMethod is missing in the request"
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"ConnectionMethod"}, taskInfo)
+	}
+	connectionMethodURI := changeRequest.ConnectionMethod.OdataID
+
+	aggregationSource, dbErr := e.GetAggregationSourceInfo(ctx, aggregationSourceURI)
+	if dbErr != nil {
+		errMsg := "unable to get aggregation source: " + dbErr.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		if errors.DBKeyNotFound == dbErr.ErrNo() {
+			return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"AggregationSource", aggregationSourceURI}, taskInfo)
+		}
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+	}
+	links, ok := aggregationSource.Links.(map[string]interface{})
+	if !ok {
+		links = make(map[string]interface{})
+	}
+	currentConnectionMethodURI := getConnectionMethodLink(links)
+	if currentConnectionMethodURI == connectionMethodURI {
+		errMsg := "error: the aggregation source is already using the connection method " + connectionMethodURI
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueConflict, errMsg, []interface{}{"ConnectionMethod", "Links/ConnectionMethod"}, taskInfo)
+	}
+
+	aggregationSourceID := aggregationSourceURI[strings.LastIndexByte(aggregationSourceURI, '/')+1:]
+	deviceUUID := strings.SplitN(aggregationSourceID, ".", 2)[0]
+	target, terr := agmodel.GetTarget(deviceUUID)
+	if terr != nil || target == nil {
+		errMsg := "error: only the aggregation source of a server can be moved to another connection method"
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.ActionNotSupported, errMsg, []interface{}{"Odim.ChangeConnectionMethod"}, taskInfo)
+	}
+
+	connectionMethod, dbErr := e.GetConnectionMethod(ctx, connectionMethodURI)
+	if dbErr != nil {
+		errMsg := "unable to get connection method: " + dbErr.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		if errors.DBKeyNotFound == dbErr.ErrNo() {
+			return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"ConnectionMethod", connectionMethodURI}, taskInfo)
+		}
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+	}
+	cmVariants := getConnectionMethodVariants(ctx, connectionMethod.ConnectionMethodVariant)
+	if cmVariants.PluginType != "Compute" {
+		errMsg := "error: the connection method " + connectionMethodURI + " is not of a plugin managing servers"
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errMsg, []interface{}{connectionMethodURI, "ConnectionMethod"}, taskInfo)
+	}
+	dbPluginConn := agmodel.DBPluginDataRead{
+		DBReadclient: agmodel.GetPluginDBConnection,
+	}
+	plugin, dbErr := agmodel.GetPluginData(cmVariants.PluginID, dbPluginConn)
+	if dbErr != nil {
+		errMsg := "error while getting plugin data: " + dbErr.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"Plugin", cmVariants.PluginID}, taskInfo)
+	}
+	currentPlugin, dbErr := agmodel.GetPluginData(target.PluginID, dbPluginConn)
+	if dbErr != nil {
+		errMsg := "error while getting plugin data: " + dbErr.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+	}
+
+	// block the other operations on the server while it is moved to the new plugin
+	systemURI := "/redfish/v1/Systems/" + aggregationSourceID
+	if err := acquireSystemOperations(ctx, []string{systemURI}, changeConnectionMethodOperation); err != nil {
+		errMsg := "error: unable to move " + systemURI + " to the connection method " + connectionMethodURI + ": " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		if _, ok := err.(operationInProgressError); ok {
+			return common.GeneralError(http.StatusConflict, response.ResourceInUse, errMsg, nil, taskInfo)
+		}
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+	}
+	resp = e.moveServerToPlugin(ctx, target, currentPlugin, plugin, aggregationSourceURI, systemURI, taskInfo)
+	releaseSystemOperations(ctx, []string{systemURI})
+	if resp.StatusCode != 0 {
+		return resp
+	}
+	percentComplete = 50
+	e.UpdateTask(ctx, fillTaskData(taskID, targetURI, reqBody, resp, common.Running, common.OK, percentComplete, http.MethodPost))
+
+	// update the aggregation source and the connection methods linking it
+	links["ConnectionMethod"] = map[string]interface{}{"@odata.id": connectionMethodURI}
+	aggregationSource.Links = links
+	linkErr := e.updateConnectionMethodLinks(ctx, aggregationSource, aggregationSourceURI, currentConnectionMethodURI, connectionMethod, connectionMethodURI)
+
+	// the server is already managed by the new plugin, so the event subscription
+	// deleted through the old plugin is created again even when the links are not updated
+	if e.CreateSubcription != nil {
+		e.CreateSubcription(ctx, []string{systemURI})
+	}
+	if linkErr != nil {
+		errMsg := "server " + target.ManagerAddress + " is moved to plugin " + plugin.ID + ", but " + linkErr.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+	}
+	percentComplete = 70
+	e.UpdateTask(ctx, fillTaskData(taskID, targetURI, reqBody, resp, common.Running, common.OK, percentComplete, http.MethodPost))
+
+	// the resources keep their IDs, only the properties reported differently by the new plugin are updated
+	e.RediscoverSystemInventory(ctx, deviceUUID, systemURI, true)
+
+	commonResponse := response.Response{
+		OdataType:    common.AggregationSourceType,
+		OdataID:      aggregationSourceURI,
+		OdataContext: "/redfish/v1/$metadata#AggregationSource.AggregationSource",
+		ID:           aggregationSourceID,
+		Name:         "Aggregation Source",
+	}
+	commonResponse.CreateGenericResponse(response.Success)
+	commonResponse.Message = ""
+	commonResponse.MessageID = ""
+	commonResponse.Severity = ""
+	resp.Body = agresponse.AggregationSourceResponse{
+		Response: commonResponse,
+		HostName: aggregationSource.HostName,
+		UserName: aggregationSource.UserName,
+		Links:    aggregationSource.Links,
+	}
+	resp.StatusCode = http.StatusOK
+	resp.StatusMessage = response.Success
+	percentComplete = 100
+	e.UpdateTask(ctx, fillTaskData(taskID, targetURI, reqBody, resp, common.Completed, common.OK, percentComplete, http.MethodPost))
+	l.LogWithFields(ctx).Info("server " + target.ManagerAddress + " moved from plugin " + currentPlugin.ID + " to plugin " + plugin.ID)
+	return resp
+}
+
+// moveServerToPlugin validates the server with the new plugin and makes the new plugin manage it,
+// the status code of the response is set only when the server could not be moved
+func (e *ExternalInterface) moveServerToPlugin(ctx context.Context, target *agmodel.Target, currentPlugin, plugin agmodel.Plugin,
+	aggregationSourceURI, systemURI string, taskInfo *common.TaskUpdateInfo) response.RPC {
+	password, err := e.DecryptPassword(target.Password)
+	if err != nil {
+		errMsg := "error while trying to decrypt device password: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+	}
+	pluginContactRequest, getResponse, err := e.loginToPlugin(ctx, plugin)
+	if err != nil {
+		errMsg := err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(getResponse.StatusCode, getResponse.StatusMessage, errMsg, getResponse.MsgArgs, taskInfo)
+	}
+	// the new plugin has to be able to manage the server before anything is changed
+	pluginContactRequest.DeviceInfo = agmodel.SaveSystem{
+		ManagerAddress:   target.ManagerAddress,
+		UserName:         target.UserName,
+		Password:         password,
+		CertificateTrust: target.CertificateTrust,
+	}
+	pluginContactRequest.OID = "/ODIM/v1/validate"
+	pluginContactRequest.HTTPMethodType = http.MethodPost
+	body, _, getResponse, err := contactPlugin(ctx, pluginContactRequest, "error while trying to authenticate the compute server: ")
+	if err != nil {
+		errMsg := err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(getResponse.StatusCode, getResponse.StatusMessage, errMsg, getResponse.MsgArgs, taskInfo)
+	}
+	// the new plugin has to verify the certificate of the server the way the current one does
+	if _, err := getEstablishedCertificateTrust(target.CertificateTrust, body); err != nil {
+		l.LogWithFields(ctx).Error(err.Error())
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, err.Error(), []interface{}{target.CertificateTrust.Mode, "CertificateTrustMode"}, taskInfo)
+	}
+
+	// the server stops sending its events to the old plugin before the new plugin manages it,
+	// otherwise the events of the server arrive once through each plugin
+	if getResponse, err := e.deleteDeviceSubscription(ctx, target, password, currentPlugin); err != nil {
+		errMsg := "unable to delete the event subscription of the server through plugin " + currentPlugin.ID + ": " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(getResponse.StatusCode, getResponse.StatusMessage, errMsg, getResponse.MsgArgs, taskInfo)
+	}
+
+	saveSystem := agmodel.SaveSystem{
+		ManagerAddress:    target.ManagerAddress,
+		Password:          target.Password,
+		UserName:          target.UserName,
+		DeviceUUID:        target.DeviceUUID,
+		PluginID:          plugin.ID,
+		EventDeliveryMode: target.EventDeliveryMode,
+		CertificateTrust:  target.CertificateTrust,
+	}
+	if dbErr := agmodel.UpdateSystemData(saveSystem, target.DeviceUUID); dbErr != nil {
+		errMsg := "unable to update system info: " + dbErr.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+	}
+
+	// the server is listed under the manager of the new plugin
+	chassisList, dbErr := agmodel.GetAllMatchingDetails("Chassis", target.DeviceUUID+".", common.InMemory)
+	if dbErr != nil {
+		l.LogWithFields(ctx).Error("error while trying to collect the chassis list: " + dbErr.Error())
+	}
+	if err := moveManagerLinks(ctx, currentPlugin.ManagerUUID, plugin.ManagerUUID, systemURI, chassisList); err != nil {
+		l.LogWithFields(ctx).Error("failed to move " + systemURI + " to the manager of plugin " + plugin.ID + ": " + err.Error())
+	}
+
+	// the plugins keep the inventory of the servers they manage
+	device := agmodel.DeviceData{
+		Address:           target.ManagerAddress,
+		UserName:          target.UserName,
+		Password:          password,
+		EventDeliveryMode: target.EventDeliveryMode,
+		CertificateTrust:  target.CertificateTrust,
+	}
+	device.Operation = "del"
+	if err := PushPluginStartUpData(ctx, currentPlugin, &agmodel.PluginStartUpData{
+		RequestType: "delta",
+		Devices:     map[string]agmodel.DeviceData{target.DeviceUUID: device},
+	}); err != nil {
+		l.LogWithFields(ctx).Error(err.Error())
+	}
+	device.Operation = "add"
+	if err := PushPluginStartUpData(ctx, plugin, &agmodel.PluginStartUpData{
+		RequestType: "delta",
+		Devices:     map[string]agmodel.DeviceData{target.DeviceUUID: device},
+	}); err != nil {
+		l.LogWithFields(ctx).Error(err.Error())
+	}
+	return response.RPC{}
+}
+
+// loginToPlugin returns the request to contact the plugin with, carrying
+// either a session token or the credentials of the plugin
+func (e *ExternalInterface) loginToPlugin(ctx context.Context, plugin agmodel.Plugin) (getResourceRequest, responseStatus, error) {
+	var pluginContactRequest getResourceRequest
+	pluginContactRequest.ContactClient = e.ContactClient
+	pluginContactRequest.GetPluginStatus = e.GetPluginStatus
+	pluginContactRequest.Plugin = plugin
+	pluginContactRequest.StatusPoll = true
+	if !strings.EqualFold(plugin.PreferredAuthType, "XAuthToken") {
+		pluginContactRequest.LoginCredentials = map[string]string{
+			"UserName": plugin.Username,
+			"Password": string(plugin.Password),
+		}
+		return pluginContactRequest, responseStatus{}, nil
+	}
+	pluginContactRequest.HTTPMethodType = http.MethodPost
+	pluginContactRequest.DeviceInfo = map[string]interface{}{
+		"UserName": plugin.Username,
+		"Password": string(plugin.Password),
+	}
+	pluginContactRequest.OID = "/ODIM/v1/Sessions"
+	_, token, getResponse, err := contactPlugin(ctx, pluginContactRequest, "error while logging in to plugin: ")
+	if err != nil {
+		return pluginContactRequest, getResponse, err
+	}
+	pluginContactRequest.Token = token
+	return pluginContactRequest, getResponse, nil
+}
+
+// deleteDeviceSubscription deletes the event subscription of the server through the plugin managing it,
+// nothing is done when there is no event subscription on the server
+func (e *ExternalInterface) deleteDeviceSubscription(ctx context.Context, target *agmodel.Target, password []byte, plugin agmodel.Plugin) (responseStatus, error) {
+	deviceIPAddress, _, _, err := agcommon.LookupHost(target.ManagerAddress)
+	if err != nil {
+		return responseStatus{StatusCode: http.StatusBadRequest, StatusMessage: response.PropertyValueFormatError,
+			MsgArgs: []interface{}{target.ManagerAddress, "ManagerAddress"}}, err
+	}
+	deviceSubscription, err := agmodel.GetDeviceSubscriptions(ctx, agcommon.GetSearchKey(deviceIPAddress, common.DeviceSubscriptionIndex))
+	if err != nil {
+		l.LogWithFields(ctx).Debug("no event subscription to delete on " + target.ManagerAddress + ": " + err.Error())
+		return responseStatus{}, nil
+	}
+	pluginContactRequest, getResponse, err := e.loginToPlugin(ctx, plugin)
+	if err != nil {
+		return getResponse, err
+	}
+	pluginContactRequest.Plugin = getPluginInstance(plugin)
+	pluginContactRequest.OID = "/ODIM/v1/Subscriptions"
+	pluginContactRequest.HTTPMethodType = http.MethodDelete
+	pluginContactRequest.DeviceInfo = map[string]interface{}{
+		"ManagerAddress": target.ManagerAddress,
+		"UserName":       target.UserName,
+		"Password":       password,
+		"Location":       deviceSubscription.Location,
+	}
+	resp, err := callPlugin(ctx, pluginContactRequest)
+	if err != nil {
+		return responseStatus{StatusCode: http.StatusServiceUnavailable, StatusMessage: response.CouldNotEstablishConnection,
+			MsgArgs: []interface{}{"https://" + pluginContactRequest.Plugin.IP + ":" + pluginContactRequest.Plugin.Port + pluginContactRequest.OID}}, err
+	}
+	defer resp.Body.Close()
+	// the subscription may already be gone from the server
+	if resp.StatusCode >= http.StatusMultipleChoices && resp.StatusCode != http.StatusNotFound {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return responseStatus{StatusCode: http.StatusInternalServerError, StatusMessage: response.InternalError},
+			fmt.Errorf("plugin responded with status %d: %s", resp.StatusCode, string(body))
+	}
+	return responseStatus{}, nil
+}
+
+// updateConnectionMethodLinks saves the aggregation source linking the new connection method,
+// and moves the link to the aggregation source from the current connection method to the new one
+func (e *ExternalInterface) updateConnectionMethodLinks(ctx context.Context, aggregationSource agmodel.AggregationSource, aggregationSourceURI,
+	currentConnectionMethodURI string, connectionMethod agmodel.ConnectionMethod, connectionMethodURI string) error {
+	if dbErr := agmodel.UpdateAggregtionSource(aggregationSource, aggregationSourceURI); dbErr != nil {
+		return fmt.Errorf("unable to update aggregation source %s: %s", aggregationSourceURI, dbErr.Error())
+	}
+	aggregationSourceLink := agmodel.OdataID{OdataID: aggregationSourceURI}
+	currentConnectionMethod, dbErr := e.GetConnectionMethod(ctx, currentConnectionMethodURI)
+	if dbErr != nil {
+		return fmt.Errorf("unable to get connection method %s: %s", currentConnectionMethodURI, dbErr.Error())
+	}
+	currentConnectionMethod.Links.AggregationSources = removeAggregationSource(currentConnectionMethod.Links.AggregationSources, aggregationSourceLink)
+	if dbErr = e.UpdateConnectionMethod(currentConnectionMethod, currentConnectionMethodURI); dbErr != nil {
+		return fmt.Errorf("unable to update connection method %s: %s", currentConnectionMethodURI, dbErr.Error())
+	}
+	connectionMethod.Links.AggregationSources = append(connectionMethod.Links.AggregationSources, aggregationSourceLink)
+	if dbErr = e.UpdateConnectionMethod(connectionMethod, connectionMethodURI); dbErr != nil {
+		return fmt.Errorf("unable to update connection method %s: %s", connectionMethodURI, dbErr.Error())
+	}
+	return nil
+}
+
+// moveManagerLinks moves the links to the system and the chassis of a server
+// from the manager of a plugin to the manager of another plugin
+func moveManagerLinks(ctx context.Context, fromManagerUUID, toManagerUUID, systemURI string, chassisList []string) error {
+	fromManagerURI := "/redfish/v1/Managers/" + fromManagerUUID
+	data, dbErr := agmodel.GetResource(ctx, "Managers", fromManagerURI)
+	if dbErr != nil {
+		return dbErr
+	}
+	var managerData map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &managerData); err != nil {
+		return err
+	}
+	data, err := marshalManager(deleteLinkDetails(managerData, systemURI, chassisList))
+	if err != nil {
+		return err
+	}
+	if err := agmodel.GenericSave([]byte(data), "Managers", fromManagerURI); err != nil {
+		return err
+	}
+
+	toManagerURI := "/redfish/v1/Managers/" + toManagerUUID
+	data, dbErr = agmodel.GetResource(ctx, "Managers", toManagerURI)
+	if dbErr != nil {
+		return dbErr
+	}
+	managerData = nil
+	if err := json.Unmarshal([]byte(data), &managerData); err != nil {
+		return err
+	}
+	data, err = marshalManager(addLinkDetails(managerData, systemURI, chassisList))
+	if err != nil {
+		return err
+	}
+	return agmodel.GenericSave([]byte(data), "Managers", toManagerURI)
+}
+
+// addLinkDetails adds the links to the system and the chassis of a server to the manager of a plugin
+func addLinkDetails(managerData map[string]interface{}, systemURI string, chassisList []string) map[string]interface{} {
+	links, ok := managerData["Links"].(map[string]interface{})
+	if !ok {
+		links = make(map[string]interface{})
+		managerData["Links"] = links
+	}
+	addLink := func(property, uri string) {
+		members, _ := links[property].([]interface{})
+		link := map[string]interface{}{"@odata.id": uri}
+		for _, member := range members {
+			if reflect.DeepEqual(member, link) {
+				return
+			}
+		}
+		links[property] = append(members, link)
+	}
+	addLink("ManagerForServers", systemURI)
+	for _, chassisURI := range chassisList {
+		addLink("ManagerForChassis", chassisURI)
+	}
+	return managerData
+}
+
+func marshalManager(managerData map[string]interface{}) (string, error) {
+	data, err := json.Marshal(managerData)
+	return string(data), err
+}
+
+// getConnectionMethodLink returns the URI of the connection method in the links of an aggregation source
+func getConnectionMethodLink(links map[string]interface{}) string {
+	connectionMethod, _ := links["ConnectionMethod"].(map[string]interface{})
+	uri, _ := connectionMethod["@odata.id"].(string)
+	return uri
+}
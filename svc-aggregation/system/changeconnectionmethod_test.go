@@ -0,0 +1,145 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+)
+
+func TestExternalInterface_ChangeConnectionMethod(t *testing.T) {
+	config.SetUpMockConfig(t)
+	aggregationSourceURI := "/redfish/v1/AggregationService/AggregationSources/6d5a0a66-7efa-578e-83cf-44dc68d2874e.1"
+	e := &ExternalInterface{
+		UpdateTask: mockUpdateTask,
+		GetAggregationSourceInfo: func(ctx context.Context, uri string) (agmodel.AggregationSource, *errors.Error) {
+			if uri != aggregationSourceURI {
+				return agmodel.AggregationSource{}, errors.PackError(errors.DBKeyNotFound, "not found")
+			}
+			return agmodel.AggregationSource{
+				HostName: "10.0.0.1",
+				Links: map[string]interface{}{
+					"ConnectionMethod": map[string]interface{}{"@odata.id": mockGRFConnectionMethodURI},
+				},
+			}, nil
+		},
+	}
+	tests := []struct {
+		name        string
+		url         string
+		body        string
+		wantStatus  int32
+		wantMessage string
+	}{
+		{"invalid request", aggregationSourceURI + changeConnectionMethodURI, `{"ConnectionMethod":`, http.StatusBadRequest, response.MalformedJSON},
+		{"unknown property", aggregationSourceURI + changeConnectionMethodURI, `{"connectionMethod":{}}`, http.StatusBadRequest, response.PropertyUnknown},
+		{"no connection method", aggregationSourceURI + changeConnectionMethodURI, `{}`, http.StatusBadRequest, response.PropertyMissing},
+		{"unknown aggregation source", "/redfish/v1/AggregationService/AggregationSources/unknown" + changeConnectionMethodURI,
+			`{"ConnectionMethod":{"@odata.id":"` + mockDellConnectionMethodURI + `"}}`, http.StatusNotFound, response.ResourceNotFound},
+		{"same connection method", aggregationSourceURI + changeConnectionMethodURI,
+			`{"ConnectionMethod":{"@odata.id":"` + mockGRFConnectionMethodURI + `"}}`, http.StatusBadRequest, response.PropertyValueConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &aggregatorproto.AggregatorRequest{URL: tt.url, RequestBody: []byte(tt.body)}
+			resp := e.ChangeConnectionMethod(context.TODO(), "someTaskID", "admin", req)
+			if resp.StatusCode != tt.wantStatus || resp.StatusMessage != tt.wantMessage {
+				t.Errorf("ChangeConnectionMethod() = %v %v, want %v %v", resp.StatusCode, resp.StatusMessage, tt.wantStatus, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestAddLinkDetails(t *testing.T) {
+	systemURI := "/redfish/v1/Systems/uuid.1"
+	chassisList := []string{"/redfish/v1/Chassis/uuid.1"}
+	managerData := map[string]interface{}{
+		"Links": map[string]interface{}{
+			"ManagerForServers": []interface{}{map[string]interface{}{"@odata.id": systemURI}},
+		},
+	}
+	want := map[string]interface{}{
+		"Links": map[string]interface{}{
+			"ManagerForServers": []interface{}{map[string]interface{}{"@odata.id": systemURI}},
+			"ManagerForChassis": []interface{}{map[string]interface{}{"@odata.id": "/redfish/v1/Chassis/uuid.1"}},
+		},
+	}
+	if got := addLinkDetails(managerData, systemURI, chassisList); !reflect.DeepEqual(got, want) {
+		t.Errorf("addLinkDetails() = %v, want %v", got, want)
+	}
+	// links are added back once removed from the manager of the other plugin
+	got := addLinkDetails(deleteLinkDetails(map[string]interface{}{}, systemURI, chassisList), systemURI, chassisList)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("addLinkDetails() = %v, want %v", got, want)
+	}
+}
+
+func TestGetConnectionMethodLink(t *testing.T) {
+	links := map[string]interface{}{"ConnectionMethod": map[string]interface{}{"@odata.id": mockGRFConnectionMethodURI}}
+	if got := getConnectionMethodLink(links); got != mockGRFConnectionMethodURI {
+		t.Errorf("getConnectionMethodLink() = %v, want %v", got, mockGRFConnectionMethodURI)
+	}
+	if got := getConnectionMethodLink(nil); got != "" {
+		t.Errorf("getConnectionMethodLink() = %v, want empty", got)
+	}
+}
+
+func TestExternalInterface_deleteDeviceSubscription(t *testing.T) {
+	config.SetUpMockConfig(t)
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+	}()
+	target := &agmodel.Target{ManagerAddress: "10.24.0.15", UserName: "admin"}
+	plugin := agmodel.Plugin{ID: "GRF", IP: "localhost", Port: "9091", PreferredAuthType: "BasicAuth"}
+	var deleted map[string]interface{}
+	e := &ExternalInterface{
+		ContactClient: func(ctx context.Context, url, method, token, odataID string, body interface{}, credentials map[string]string) (*http.Response, error) {
+			if method != http.MethodDelete || odataID != "/ODIM/v1/Subscriptions" {
+				return nil, fmt.Errorf("unexpected request %s %s", method, odataID)
+			}
+			deleted, _ = body.(map[string]interface{})
+			return &http.Response{StatusCode: http.StatusNoContent, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+		},
+	}
+
+	// nothing is deleted when there is no subscription on the server
+	if _, err := e.deleteDeviceSubscription(context.TODO(), target, []byte("password"), plugin); err != nil || deleted != nil {
+		t.Errorf("deleteDeviceSubscription() error = %v, request = %v", err, deleted)
+	}
+
+	location := "https://10.24.0.15/redfish/v1/EventService/Subscriptions/1"
+	conn, _ := common.GetDBConnection(common.OnDisk)
+	if err := conn.CreateDeviceSubscriptionIndex(common.DeviceSubscriptionIndex, "10.24.0.15", location, []string{"/redfish/v1/Systems"}); err != nil {
+		t.Fatalf("unable to save the device subscription: %v", err)
+	}
+	if _, err := e.deleteDeviceSubscription(context.TODO(), target, []byte("password"), plugin); err != nil {
+		t.Errorf("deleteDeviceSubscription() error = %v", err)
+	}
+	if deleted["Location"] != location || deleted["ManagerAddress"] != target.ManagerAddress {
+		t.Errorf("deleteDeviceSubscription() request = %v, want the location %v", deleted, location)
+	}
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/logs"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
 	eventsproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/events"
+	managersproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/managers"
 	taskproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/task"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
 	"github.com/ODIM-Project/ODIM/lib-utilities/services"
@@ -44,10 +45,6 @@ import (
 )
 
 const (
-	// SystemUUID is used to replace with system id in wildcard property
-	SystemUUID = "SystemID"
-	// ChassisUUID is used to replace with chassis id in wildcard property
-	ChassisUUID = "ChassisID"
 	// ManagersTable is used to replace with table id Managers
 	ManagersTable = "Managers"
 	// PluginTable is used to replace with table id PluginTable
@@ -60,12 +57,6 @@ const (
 	EntriesCollection = "EntriesCollection"
 )
 
-// WildCard is used to reduce the size the of list of metric properties
-type WildCard struct {
-	Name   string
-	Values []string
-}
-
 // Device struct to define the response from plugin for UUID
 type Device struct {
 	ServerIP   string `json:"ServerIP"`
@@ -105,6 +96,13 @@ type ExternalInterface struct {
 	DeleteMetricRequest      func(string) *errors.Error
 	GetResource              func(context.Context, string, string) (string, *errors.Error)
 	Delete                   func(string, string, common.DbType) *errors.Error
+	SaveDiscoveredBMC        func(agmodel.DiscoveredBMC, string) *errors.Error
+	GetDiscoveredBMCInfo     func(string) (agmodel.DiscoveredBMC, *errors.Error)
+	DeleteDiscoveredBMC      func(string) *errors.Error
+	CheckBMCAddress          func(string) (bool, error)
+
+	// ChangeRemoteAccountPassword changes the password of the BMC account used by ODIM
+	ChangeRemoteAccountPassword func(context.Context, string, []byte, []byte) (*managersproto.ManagerResponse, error)
 }
 
 type responseStatus struct {
@@ -149,14 +147,73 @@ type respHolder struct {
 	PluginResponse string
 	TraversedLinks map[string]bool
 	InventoryData  map[string]interface{}
+	Resync         *inventoryResync
+}
+
+// saveInventory saves the resources fetched from the plugin along with the time they were fetched,
+// on an incremental rediscovery only the resources added or changed are saved
+func (h *respHolder) saveInventory(data map[string]interface{}) error {
+	inventory := getLastFetchedData(data, time.Now())
+	if h.Resync != nil {
+		data = h.Resync.getChangedResources(data)
+	}
+	for key, value := range data {
+		inventory[key] = value
+	}
+	if len(inventory) == 0 {
+		return nil
+	}
+	return agmodel.SaveBMCInventory(inventory)
+}
+
+// getLastFetchedData returns the entries having the time the resources were fetched from the BMC,
+// keyed by the URI of the resource in the LastFetched table
+func getLastFetchedData(data map[string]interface{}, fetchedAt time.Time) map[string]interface{} {
+	lastFetched := fetchedAt.UTC().Format(time.RFC3339)
+	lastFetchedData := make(map[string]interface{}, len(data))
+	for key := range data {
+		if getInventoryKeyTable(key) == "Registries" {
+			continue
+		}
+		lastFetchedData[common.LastFetchedTable+":"+getInventoryKeyURI(key)] = lastFetched
+	}
+	return lastFetchedData
 }
 
 // AddResourceRequest is payload of adding a  resource
 type AddResourceRequest struct {
-	ManagerAddress   string            `json:"ManagerAddress"`
-	UserName         string            `json:"UserName"`
-	Password         string            `json:"Password"`
-	ConnectionMethod *ConnectionMethod `json:"ConnectionMethod"`
+	ManagerAddress   string                  `json:"ManagerAddress"`
+	UserName         string                  `json:"UserName"`
+	Password         string                  `json:"Password"`
+	ConnectionMethod *ConnectionMethod       `json:"ConnectionMethod"`
+	PluginInstances  []common.PluginInstance `json:"-"`
+	// EventDeliveryMode is the event delivery mode requested for a BMC
+	EventDeliveryMode string `json:"-"`
+	// CertificateTrust is the way the certificate of a BMC is to be verified
+	CertificateTrust *common.CertificateTrust `json:"-"`
+	// SecretReference is the reference to the secret given as the password, which is
+	// stored in place of the password resolved from it
+	SecretReference string `json:"-"`
+}
+
+// passwordToStore returns the password to be encrypted and stored for the request
+func (req AddResourceRequest) passwordToStore() []byte {
+	if req.SecretReference != "" {
+		return []byte(req.SecretReference)
+	}
+	return []byte(req.Password)
+}
+
+// secretReferenceKey is the key of the update request of an aggregation source holding
+// the reference to the secret given as the password
+const secretReferenceKey = "SecretReference"
+
+// updatedPasswordToStore returns the password to be encrypted and stored for the update request
+func updatedPasswordToStore(updateRequest map[string]interface{}, password []byte) []byte {
+	if reference, ok := updateRequest[secretReferenceKey].([]byte); ok {
+		return reference
+	}
+	return password
 }
 
 // ConnectionMethod struct definition for @odata.id
@@ -188,10 +245,34 @@ var northBoundURL = "northboundurl"
 
 // AggregationSource  payload of adding a  AggregationSource
 type AggregationSource struct {
-	HostName string `json:"HostName"`
-	UserName string `json:"UserName"`
-	Password string `json:"Password"`
-	Links    *Links `json:"Links,omitempty"`
+	HostName string                `json:"HostName"`
+	UserName string                `json:"UserName"`
+	Password string                `json:"Password"`
+	Links    *Links                `json:"Links,omitempty"`
+	Oem      *AggregationSourceOem `json:"Oem,omitempty"`
+}
+
+// AggregationSourceOem holds the Oem properties of an aggregation source
+type AggregationSourceOem struct {
+	Odim *AggregationSourceOdim `json:"Odim,omitempty"`
+}
+
+// AggregationSourceOdim holds the properties of an aggregation source specific to ODIM
+type AggregationSourceOdim struct {
+	// PluginInstances holds the addresses, in host:port form, of the instances of
+	// a plugin other than the one at the HostName of the aggregation source
+	PluginInstances []string `json:"PluginInstances,omitempty"`
+	// EventDeliveryMode is the way the events of a BMC reach its plugin, Push or Polling
+	EventDeliveryMode string `json:"EventDeliveryMode,omitempty"`
+	// CertificateTrustMode is the way the certificate of a BMC is verified by its plugin,
+	// RootCA, TrustOnFirstUse or CAChain
+	CertificateTrustMode string `json:"CertificateTrustMode,omitempty"`
+	// CACertificateChain is the PEM encoded CA chain the certificate of a BMC is verified
+	// against in the CAChain mode, it is only given in the requests
+	CACertificateChain string `json:"CACertificateChain,omitempty"`
+	// CertificateFingerprint is the SHA-256 fingerprint of the certificate of a BMC
+	// pinned in the TrustOnFirstUse mode
+	CertificateFingerprint string `json:"CertificateFingerprint,omitempty"`
 }
 
 // Links holds information of Oem
@@ -299,6 +380,8 @@ func UpdateTaskData(ctx context.Context, taskData common.TaskData) error {
 
 func contactPlugin(ctx context.Context, req getResourceRequest, errorMessage string) ([]byte, string, responseStatus, error) {
 	var resp responseStatus
+	// the request is sent to one of the healthy instances of the plugin
+	req.Plugin = getPluginInstance(req.Plugin)
 	pluginResp, err := callPlugin(ctx, req)
 	if err != nil {
 		if req.StatusPoll {
@@ -592,6 +675,7 @@ func (h *respHolder) getAllRootInfo(ctx context.Context, taskID string, progress
 	body, _, getResponse, err := contactPlugin(ctx, req, "error while trying to get the"+resourceName+"collection details: ")
 	if err != nil {
 		h.lock.Lock()
+		h.Resync.fetchFailed(req.OID)
 		h.ErrorMessage = err.Error()
 		h.StatusMessage = getResponse.StatusMessage
 		h.StatusCode = getResponse.StatusCode
@@ -605,6 +689,7 @@ func (h *respHolder) getAllRootInfo(ctx context.Context, taskID string, progress
 	err = json.Unmarshal([]byte(body), &resourceMap)
 	if err != nil {
 		h.lock.Lock()
+		h.Resync.fetchFailed(req.OID)
 		h.ErrorMessage = "error while trying unmarshal " + resourceName + " " + err.Error()
 		h.StatusMessage = response.InternalError
 		h.StatusCode = http.StatusInternalServerError
@@ -633,6 +718,7 @@ func (h *respHolder) getSystemInfo(ctx context.Context, taskID string, progress
 	body, _, getResponse, err := contactPlugin(ctx, req, "error while trying to get system collection details: ")
 	if err != nil {
 		h.lock.Lock()
+		h.Resync.fetchFailed(req.OID)
 		h.ErrorMessage = err.Error()
 		if strings.Contains(h.ErrorMessage, errors.SystemNotSupportedErrString) {
 			h.StatusMessage = response.ActionNotSupported
@@ -649,6 +735,7 @@ func (h *respHolder) getSystemInfo(ctx context.Context, taskID string, progress
 
 	if err != nil {
 		h.lock.Lock()
+		h.Resync.fetchFailed(req.OID)
 		h.ErrorMessage = "error while trying unmarshal response body: " + err.Error()
 		h.StatusMessage = response.InternalError
 		h.StatusCode = http.StatusInternalServerError
@@ -702,7 +789,7 @@ func (h *respHolder) getSystemInfo(ctx context.Context, taskID string, progress
 		progress = h.getResourceDetails(ctx, taskID, progress, estimatedWork, req)
 	}
 	json.Unmarshal([]byte(updatedResourceData), &computeSystem)
-	err = agmodel.SaveBMCInventory(h.InventoryData)
+	err = h.saveInventory(h.InventoryData)
 
 	if err != nil {
 		h.lock.Lock()
@@ -736,6 +823,7 @@ func (h *respHolder) getStorageInfo(ctx context.Context, progress int32, alotted
 	body, _, getResponse, err := contactPlugin(ctx, req, "error while trying to get system storage collection details: ")
 	if err != nil {
 		h.lock.Lock()
+		h.Resync.fetchFailed(req.OID)
 		h.ErrorMessage = err.Error()
 		if strings.Contains(h.ErrorMessage, errors.SystemNotSupportedErrString) {
 			h.StatusMessage = response.ActionNotSupported
@@ -751,6 +839,7 @@ func (h *respHolder) getStorageInfo(ctx context.Context, progress int32, alotted
 	err = json.Unmarshal(body, &computeSystem)
 	if err != nil {
 		h.lock.Lock()
+		h.Resync.fetchFailed(req.OID)
 		h.ErrorMessage = "error while trying unmarshal response body of system storage: " + err.Error()
 		h.StatusMessage = response.InternalError
 		h.StatusCode = http.StatusInternalServerError
@@ -781,15 +870,7 @@ func (h *respHolder) getStorageInfo(ctx context.Context, progress int32, alotted
 	updatedResourceData := updateResourceDataWithUUID(string(body), req.DeviceUUID)
 	// persist the response with table Storage
 	resourceName := getResourceName(req.OID, true)
-	err = agmodel.GenericSave([]byte(updatedResourceData), resourceName, oidKey)
-	if err != nil {
-		h.lock.Lock()
-		h.ErrorMessage = "error while trying to save data: " + err.Error()
-		h.StatusMessage = response.InternalError
-		h.StatusCode = http.StatusInternalServerError
-		h.lock.Unlock()
-		return oidKey, progress, err
-	}
+	h.InventoryData[resourceName+":"+oidKey] = updatedResourceData
 	h.TraversedLinks[req.OID] = true
 	h.SystemURL = append(h.SystemURL, oidKey)
 	var retrievalLinks = make(map[string]bool)
@@ -805,6 +886,14 @@ func (h *respHolder) getStorageInfo(ctx context.Context, progress int32, alotted
 		// Passing taskid as empty string
 		progress = h.getResourceDetails(ctx, "", progress, estimatedWork, req)
 	}
+	if err = h.saveInventory(h.InventoryData); err != nil {
+		h.lock.Lock()
+		h.ErrorMessage = "error while trying to save data: " + err.Error()
+		h.StatusMessage = response.InternalError
+		h.StatusCode = http.StatusInternalServerError
+		h.lock.Unlock()
+		return oidKey, progress, err
+	}
 	json.Unmarshal([]byte(updatedResourceData), &computeSystem)
 	searchForm := createServerSearchIndex(ctx, computeSystem, systemURI, req.DeviceUUID)
 	//save the final search form here
@@ -897,6 +986,7 @@ func (h *respHolder) getIndividualInfo(ctx context.Context, taskID string, progr
 	body, _, getResponse, err := contactPlugin(ctx, req, "error while trying to get "+resourceName+" details: ")
 	if err != nil {
 		h.lock.Lock()
+		h.Resync.fetchFailed(req.OID)
 		h.ErrorMessage = err.Error()
 		h.StatusMessage = getResponse.StatusMessage
 		h.StatusCode = getResponse.StatusCode
@@ -907,6 +997,7 @@ func (h *respHolder) getIndividualInfo(ctx context.Context, taskID string, progr
 	err = json.Unmarshal(body, &resource)
 	if err != nil {
 		h.lock.Lock()
+		h.Resync.fetchFailed(req.OID)
 		h.ErrorMessage = "error while trying unmarshal response body: " + err.Error()
 		h.StatusMessage = response.InternalError
 		h.StatusCode = http.StatusInternalServerError
@@ -943,6 +1034,7 @@ func (h *respHolder) getResourceDetails(ctx context.Context, taskID string, prog
 	body, _, getResponse, err := contactPlugin(ctx, req, "error while trying to get the "+req.OID+" details: ")
 	if err != nil {
 		h.lock.Lock()
+		h.Resync.fetchFailed(req.OID)
 		h.ErrorMessage = err.Error()
 		h.StatusMessage = getResponse.StatusMessage
 		h.MsgArgs = getResponse.MsgArgs
@@ -954,6 +1046,7 @@ func (h *respHolder) getResourceDetails(ctx context.Context, taskID string, prog
 	err = json.Unmarshal(body, &resourceData)
 	if err != nil {
 		h.lock.Lock()
+		h.Resync.fetchFailed(req.OID)
 		h.ErrorMessage = "error while trying unmarshal : " + err.Error()
 		h.StatusCode = http.StatusInternalServerError
 		h.StatusMessage = response.InternalError
@@ -1536,144 +1629,7 @@ func (e *ExternalInterface) createWildCard(resourceData, resourceName, oid strin
 		return "", err
 	}
 	data, _ := e.GetResource(context.TODO(), resourceName, oid)
-	return formWildCard(data, resourceDataMap)
-}
-
-// formWildCard is used to form the wild card
-// if the data not present in the db(means first time add server) then create empty wild and update it with metric properties
-// if the wild card data already present then update it with new properties
-func formWildCard(dbData string, resourceDataMap map[string]interface{}) (string, error) {
-	var systemID, chassisID string
-	var wildCards []WildCard
-	var dbMetricProperties []interface{}
-
-	if len(dbData) < 1 {
-		wildCards = getEmptyWildCard()
-	} else {
-		var dbDataMap map[string]interface{}
-		err := json.Unmarshal([]byte(dbData), &dbDataMap)
-		if err != nil {
-			return "", err
-		}
-		if dbDataMap["Wildcards"] == nil {
-			return "", fmt.Errorf("wild card map is empty")
-		}
-		wildCards = getWildCard(dbDataMap["Wildcards"].([]interface{}))
-		dbMetricProperties = dbDataMap["MetricProperties"].([]interface{})
-	}
-	metricProperties, isExists := resourceDataMap["MetricProperties"].([]interface{})
-	if isExists {
-		for _, mProperty := range metricProperties {
-			property := mProperty.(string)
-			for i, wCard := range wildCards {
-				if wCard.Name == SystemUUID && strings.Contains(property, "/Systems/") {
-					property, systemID = getUpdatedProperty(property, SystemUUID)
-					if !checkWildCardPresent(systemID, wildCards[i].Values) {
-						wildCards[i].Values = append(wildCards[i].Values, systemID)
-					}
-					break
-				}
-				if wCard.Name == ChassisUUID && strings.Contains(property, "/Chassis/") {
-					property, chassisID = getUpdatedProperty(property, ChassisUUID)
-					if !checkWildCardPresent(chassisID, wCard.Values) {
-						wildCards[i].Values = append(wildCards[i].Values, chassisID)
-					}
-					break
-				}
-			}
-			if !checkMetricPropertyPresent(property, dbMetricProperties) {
-				dbMetricProperties = append(dbMetricProperties, property)
-			}
-		}
-	}
-	var wCards []WildCard
-	for _, wCard := range wildCards {
-		if len(wCard.Values) > 0 {
-			wCards = append(wCards, wCard)
-		}
-	}
-	if len(wCards) > 0 {
-		resourceDataMap["Wildcards"] = wCards
-		resourceDataMap["MetricProperties"] = dbMetricProperties
-	}
-	resourceDataByte, err := json.Marshal(resourceDataMap)
-	if err != nil {
-		return "", err
-	}
-	return string(resourceDataByte), nil
-}
-
-// checkWildCardPresent will check the wild card present in the array
-// if its present returns true, else false.
-func checkWildCardPresent(val string, values []string) bool {
-	if len(values) < 1 {
-		return false
-	}
-	front := 0
-	rear := len(values) - 1
-	for front <= rear {
-		if values[front] == val || values[rear] == val {
-			return true
-		}
-		front++
-		rear--
-	}
-	return false
-}
-
-// getUpdatedProperty function get the uuid from the property and update the property with wild card name
-func getUpdatedProperty(property, wildCardName string) (string, string) {
-	prop := strings.Split(property, "/")[4]
-	uuid := strings.Split(prop, "#")[0]
-	property = strings.Replace(property, uuid, "{"+wildCardName+"}", -1)
-	return property, uuid
-}
-
-// getWildCard function will convert array of interface to array of string
-func getWildCard(wCard []interface{}) []WildCard {
-	var wildCard []WildCard
-	for _, val := range wCard {
-		card := val.(map[string]interface{})
-		b, err := json.Marshal(card)
-		if err != nil {
-			continue
-		}
-		var wc WildCard
-		json.Unmarshal(b, &wc)
-		wildCard = append(wildCard, wc)
-	}
-	return wildCard
-}
-
-// checkMetricPropertyPresent will check the metric property present in the array
-// if its present returns true, else false.
-func checkMetricPropertyPresent(val string, values []interface{}) bool {
-	if len(values) < 1 {
-		return false
-	}
-	front := 0
-	rear := len(values) - 1
-	for front <= rear {
-		if values[front].(string) == val || values[rear].(string) == val {
-			return true
-		}
-		front++
-		rear--
-	}
-	return false
-}
-
-// getEmptyWildCard function is for create empty wild card field with default SystemID and ChassisID name and empty values
-func getEmptyWildCard() []WildCard {
-	var wildCards []WildCard
-	var w WildCard
-	w.Name = SystemUUID
-	w.Values = []string{}
-	wildCards = append(wildCards, w)
-	w.Name = ChassisUUID
-	w.Values = []string{}
-	wildCards = append(wildCards, w)
-	return wildCards
+	return common.FormWildCard(data, resourceDataMap)
 }
 
 func (e *ExternalInterface) monitorPluginTask(ctx context.Context, subTaskChannel chan<- int32, monitorTaskData *monitorTaskRequest) (responseStatus, error) {
@@ -0,0 +1,552 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/lib-utilities/secrets"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agcommon"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/google/uuid"
+)
+
+const (
+	// CredentialRotationActionID action id for logging
+	CredentialRotationActionID = "239"
+	// CredentialRotationActionName action name for logging
+	CredentialRotationActionName = "CredentialRotation"
+
+	rotateCredentialsURI = "/Actions/Oem/Odim.RotateCredentials"
+	// credentialRotationOperation is the system operation blocking the other
+	// operations on the server while its credentials are rotated
+	credentialRotationOperation = "CredentialRotation"
+	// credentialRotationLockExpiry is the time in seconds after which the lock on the
+	// rotation of an aggregate is released if the instance rotating it stopped
+	credentialRotationLockExpiry = 6 * 60 * 60
+	// credentialRotationCheckInterval is the interval at which the rotation policies are checked
+	credentialRotationCheckInterval = 10 * time.Minute
+
+	defaultPasswordLength = 16
+	minPasswordLength     = 8
+	maxPasswordLength     = 32
+
+	// the outcomes of the credential rotation of a server
+	credentialRotated          = "Rotated"
+	credentialRotationFailed   = "Failed"
+	credentialRotationReverted = "RolledBack"
+)
+
+// passwordCharacterClasses are the classes of characters of the generated passwords,
+// each class is used at least once so that the password complies with the BMC policies
+var passwordCharacterClasses = []string{
+	"ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+	"abcdefghijklmnopqrstuvwxyz",
+	"0123456789",
+	"!#%+-=?@_",
+}
+
+// RotateCredentialsRequest is the request body of the RotateCredentials action of an aggregate
+type RotateCredentialsRequest struct {
+	IntervalInDays *int `json:"IntervalInDays"`
+	PasswordLength *int `json:"PasswordLength"`
+}
+
+// RotateCredentials changes the password of the BMC account used by ODIM on every server of the aggregate.
+// The request can also set the interval in days at which the credentials of the aggregate are rotated again.
+func (e *ExternalInterface) RotateCredentials(ctx context.Context, taskID, sessionUserName string, req *aggregatorproto.AggregatorRequest) response.RPC {
+	var resp response.RPC
+	var percentComplete int32
+	targetURI := req.URL
+	reqBody := string(req.RequestBody)
+	aggregateURI := strings.TrimSuffix(req.URL, rotateCredentialsURI)
+	taskInfo := &common.TaskUpdateInfo{Context: ctx, TaskID: taskID, TargetURI: targetURI, UpdateTask: e.UpdateTask, TaskRequest: reqBody}
+	err := e.UpdateTask(ctx, fillTaskData(taskID, targetURI, reqBody, resp, common.Running, common.OK, percentComplete, http.MethodPost))
+	if err != nil {
+		errMsg := "error while starting the task: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+
+	var rotateRequest RotateCredentialsRequest
+	if len(req.RequestBody) > 0 {
+		if err := json.Unmarshal(req.RequestBody, &rotateRequest); err != nil {
+			errMsg := "unable to parse the rotate credentials request: " + err.Error()
+			l.LogWithFields(ctx).Error(errMsg)
+			return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, taskInfo)
+		}
+		invalidProperties, err := common.RequestParamsCaseValidator(req.RequestBody, rotateRequest)
+		if err != nil {
+			errMsg := "error while validating request parameters: " + err.Error()
+			l.LogWithFields(ctx).Error(errMsg)
+			return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+		} else if invalidProperties != "" {
+			errMsg := "error: one or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase "
+			l.LogWithFields(ctx).Error(errMsg)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errMsg, []interface{}{invalidProperties}, taskInfo)
+		}
+	}
+	if property, value := rotateRequest.validate(); property != "" {
+		errMsg := "error: invalid value " + value + " for " + property
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errMsg, []interface{}{value, property}, taskInfo)
+	}
+
+	aggregate, dbErr := agmodel.GetAggregate(aggregateURI)
+	if dbErr != nil {
+		errMsg := "unable to get aggregate: " + dbErr.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		if errors.DBKeyNotFound == dbErr.ErrNo() {
+			return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"Aggregate", aggregateURI}, taskInfo)
+		}
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+	}
+	rotation, dbErr := agmodel.GetCredentialRotation(aggregateURI)
+	if dbErr != nil && errors.DBKeyNotFound != dbErr.ErrNo() {
+		errMsg := "unable to get the credential rotation policy: " + dbErr.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+	}
+	rotateRequest.apply(&rotation)
+
+	if dbErr = agmodel.LockCredentialRotation(aggregateURI, credentialRotationLockExpiry); dbErr != nil {
+		errMsg := "unable to start the rotation of the credentials of " + aggregateURI + ": " + dbErr.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		if errors.DBKeyAlreadyExist == dbErr.ErrNo() {
+			return common.GeneralError(http.StatusConflict, response.ResourceInUse, errMsg, nil, taskInfo)
+		}
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+	}
+	defer agmodel.UnlockCredentialRotation(aggregateURI)
+
+	rotation = e.rotateAggregateCredentials(ctx, aggregateURI, aggregate, rotation, func(done, total int) {
+		percentComplete = int32(done * 100 / total)
+		e.UpdateTask(ctx, fillTaskData(taskID, targetURI, reqBody, resp, common.Running, common.OK, percentComplete, http.MethodPost))
+	})
+
+	taskStatus := common.OK
+	for _, result := range rotation.Results {
+		if result.Status != credentialRotated {
+			taskStatus = common.Warning
+			break
+		}
+	}
+	resp.StatusCode = http.StatusOK
+	resp.StatusMessage = response.Success
+	resp.Body = rotation
+	percentComplete = 100
+	e.UpdateTask(ctx, fillTaskData(taskID, targetURI, reqBody, resp, common.Completed, taskStatus, percentComplete, http.MethodPost))
+	return resp
+}
+
+// validate returns the property and the value of the request which is not valid
+func (req RotateCredentialsRequest) validate() (string, string) {
+	if req.IntervalInDays != nil && *req.IntervalInDays < 0 {
+		return "IntervalInDays", strconv.Itoa(*req.IntervalInDays)
+	}
+	if req.PasswordLength != nil && (*req.PasswordLength < minPasswordLength || *req.PasswordLength > maxPasswordLength) {
+		return "PasswordLength", strconv.Itoa(*req.PasswordLength)
+	}
+	return "", ""
+}
+
+// apply updates the rotation policy with the properties given in the request
+func (req RotateCredentialsRequest) apply(rotation *agmodel.CredentialRotation) {
+	if req.IntervalInDays != nil {
+		rotation.IntervalInDays = *req.IntervalInDays
+	}
+	if req.PasswordLength != nil {
+		rotation.PasswordLength = *req.PasswordLength
+	}
+	if rotation.PasswordLength == 0 {
+		rotation.PasswordLength = defaultPasswordLength
+	}
+}
+
+// PerformCredentialRotation rotates the credentials of the aggregates having a rotation
+// policy once their interval elapsed since their last rotation
+func (e *ExternalInterface) PerformCredentialRotation() {
+	transactionID := uuid.New()
+	ctx := agcommon.CreateContext(transactionID.String(), CredentialRotationActionID, CredentialRotationActionName, "1", common.AggregationService, podName)
+	l.LogWithFields(ctx).Info("credential rotation routine started")
+	for {
+		aggregateURIs, err := agmodel.GetAllKeysFromTable(ctx, "CredentialRotation")
+		if err != nil {
+			l.LogWithFields(ctx).Error("unable to get the credential rotation policies: " + err.Error())
+		}
+		for _, aggregateURI := range aggregateURIs {
+			rotation, dbErr := agmodel.GetCredentialRotation(aggregateURI)
+			if dbErr != nil || !isCredentialRotationDue(rotation, time.Now()) {
+				continue
+			}
+			aggregate, dbErr := agmodel.GetAggregate(aggregateURI)
+			if dbErr != nil {
+				l.LogWithFields(ctx).Error("unable to get the aggregate " + aggregateURI + ": " + dbErr.Error())
+				continue
+			}
+			// another instance of the service may already be rotating the credentials
+			if dbErr = agmodel.LockCredentialRotation(aggregateURI, credentialRotationLockExpiry); dbErr != nil {
+				continue
+			}
+			l.LogWithFields(ctx).Info("rotating the credentials of the servers of " + aggregateURI)
+			e.rotateAggregateCredentials(ctx, aggregateURI, aggregate, rotation, nil)
+			agmodel.UnlockCredentialRotation(aggregateURI)
+		}
+		time.Sleep(credentialRotationCheckInterval)
+	}
+}
+
+// isCredentialRotationDue checks whether the interval of the rotation policy elapsed since the last rotation
+func isCredentialRotationDue(rotation agmodel.CredentialRotation, now time.Time) bool {
+	if rotation.IntervalInDays <= 0 {
+		return false
+	}
+	next, err := time.Parse(time.RFC3339, rotation.NextRotationTime)
+	return err != nil || !now.Before(next)
+}
+
+// rotateAggregateCredentials rotates the credentials of the servers of the aggregate and saves the
+// outcome with the rotation policy. progress is called each time the credentials of a server are rotated.
+func (e *ExternalInterface) rotateAggregateCredentials(ctx context.Context, aggregateURI string, aggregate agmodel.Aggregate,
+	rotation agmodel.CredentialRotation, progress func(done, total int)) agmodel.CredentialRotation {
+	bmcs := groupSystemsByBMC(aggregate.Elements)
+	results := make([][]agmodel.CredentialRotationResult, len(bmcs))
+	batchSize := config.Data.ServerRediscoveryBatchSize
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+	var lock sync.Mutex
+	var done int
+	var wg sync.WaitGroup
+	semaphoreChan := make(chan int, batchSize)
+	for i, systems := range bmcs {
+		semaphoreChan <- 1
+		wg.Add(1)
+		go func(i int, systems []string) {
+			defer func() {
+				<-semaphoreChan
+				wg.Done()
+			}()
+			// the servers of a BMC share its credentials, which are rotated once
+			status, message := e.rotateBMCCredentials(ctx, systems, rotation.PasswordLength)
+			for _, system := range systems {
+				results[i] = append(results[i], agmodel.CredentialRotationResult{
+					System:  agmodel.OdataID{OdataID: system},
+					Status:  status,
+					Message: message,
+				})
+			}
+			if progress != nil {
+				lock.Lock()
+				done++
+				progress(done, len(bmcs))
+				lock.Unlock()
+			}
+		}(i, systems)
+	}
+	wg.Wait()
+
+	now := time.Now().UTC()
+	rotation.Results = nil
+	for _, bmcResults := range results {
+		rotation.Results = append(rotation.Results, bmcResults...)
+	}
+	rotation.LastRotationTime = now.Format(time.RFC3339)
+	rotation.NextRotationTime = ""
+	if rotation.IntervalInDays > 0 {
+		rotation.NextRotationTime = now.AddDate(0, 0, rotation.IntervalInDays).Format(time.RFC3339)
+	}
+	if err := agmodel.SaveCredentialRotation(rotation, aggregateURI); err != nil {
+		l.LogWithFields(ctx).Error("unable to save the outcome of the credential rotation of " + aggregateURI + ": " + err.Error())
+	}
+	return rotation
+}
+
+// groupSystemsByBMC groups the systems of an aggregate by the BMC managing them, keeping their order
+func groupSystemsByBMC(elements []agmodel.OdataID) [][]string {
+	var bmcs [][]string
+	index := make(map[string]int)
+	for _, element := range elements {
+		systemID := element.OdataID[strings.LastIndexByte(element.OdataID, '/')+1:]
+		deviceUUID := strings.SplitN(systemID, ".", 2)[0]
+		i, exist := index[deviceUUID]
+		if !exist {
+			i = len(bmcs)
+			index[deviceUUID] = i
+			bmcs = append(bmcs, nil)
+		}
+		bmcs[i] = append(bmcs[i], element.OdataID)
+	}
+	return bmcs
+}
+
+// rotateBMCCredentials changes the password of the BMC managing the systems and returns the outcome.
+// The password is changed on the BMC first, then the login with the new password is verified before
+// it is stored. The previous password is set back on the BMC when any of these steps fails.
+func (e *ExternalInterface) rotateBMCCredentials(ctx context.Context, systems []string, passwordLength int) (string, string) {
+	systemID := systems[0][strings.LastIndexByte(systems[0], '/')+1:]
+	deviceUUID := strings.SplitN(systemID, ".", 2)[0]
+	target, dbErr := agmodel.GetTarget(deviceUUID)
+	if dbErr != nil {
+		return credentialRotationFailed, "unable to get the BMC details: " + dbErr.Error()
+	}
+
+	// block the other operations on the server while its credentials are changed
+	if err := acquireSystemOperations(ctx, systems, credentialRotationOperation); err != nil {
+		return credentialRotationFailed, err.Error()
+	}
+	defer releaseSystemOperations(ctx, systems)
+
+	// the secrets provider keeps the password, which ODIM does not change
+	if secrets.IsReference(target.Password) {
+		return credentialRotationFailed, "the password is kept by the secrets provider"
+	}
+	password, err := e.DecryptPassword(target.Password)
+	if err != nil {
+		return credentialRotationFailed, "unable to decrypt the BMC password: " + err.Error()
+	}
+	plugin, dbErr := agmodel.GetPluginData(target.PluginID, agmodel.DBPluginDataRead{
+		DBReadclient: agmodel.GetPluginDBConnection,
+	})
+	if dbErr != nil {
+		return credentialRotationFailed, "unable to get the plugin data: " + dbErr.Error()
+	}
+	pluginContactRequest, err := e.newPluginContactRequest(ctx, plugin)
+	if err != nil {
+		return credentialRotationFailed, err.Error()
+	}
+	newPassword, err := generatePassword(passwordLength)
+	if err != nil {
+		return credentialRotationFailed, "unable to generate the password: " + err.Error()
+	}
+
+	if err := e.changeBMCPassword(ctx, *target, password, newPassword); err != nil {
+		return credentialRotationFailed, err.Error()
+	}
+	err = validateBMCLogin(ctx, pluginContactRequest, *target, newPassword)
+	if err == nil {
+		err = e.saveBMCPassword(ctx, *target, systems, newPassword)
+	}
+	if err != nil {
+		if rerr := e.changeBMCPassword(ctx, *target, newPassword, password); rerr != nil {
+			// the BMC accepted the new password, which is stored to keep managing the server
+			if serr := e.saveBMCPassword(ctx, *target, systems, newPassword); serr != nil {
+				l.LogWithFields(ctx).Error("unable to store the new password of the BMC " + target.ManagerAddress + ": " + serr.Error())
+			}
+			return credentialRotationFailed, err.Error() + "; unable to restore the previous password: " + rerr.Error()
+		}
+		return credentialRotationReverted, err.Error() + "; the previous password is restored"
+	}
+
+	// the plugins keep the credentials of the servers they manage
+	if err := PushPluginStartUpData(ctx, plugin, &agmodel.PluginStartUpData{
+		RequestType: "delta",
+		Devices: map[string]agmodel.DeviceData{
+			target.DeviceUUID: {
+				Address:           target.ManagerAddress,
+				UserName:          target.UserName,
+				Password:          newPassword,
+				Operation:         "add",
+				EventDeliveryMode: target.EventDeliveryMode,
+				CertificateTrust:  target.CertificateTrust,
+			},
+		},
+	}); err != nil {
+		l.LogWithFields(ctx).Error(err.Error())
+	}
+	l.LogWithFields(ctx).Info("credentials of the BMC " + target.ManagerAddress + " are rotated")
+	return credentialRotated, ""
+}
+
+// operationInProgressError is returned by acquireSystemOperations
+// when another operation is in progress on one of the systems
+type operationInProgressError struct {
+	operation string
+}
+
+func (e operationInProgressError) Error() string {
+	return e.operation + " operation is in progress"
+}
+
+// acquireSystemOperations stores the operation as the system operation of the systems, so that
+// the other operations are not started on them meanwhile. Nothing is stored when another
+// operation is in progress on any of the systems.
+func acquireSystemOperations(ctx context.Context, systems []string, operation string) error {
+	for i, systemURI := range systems {
+		systemOperation, dbErr := agmodel.GetSystemOperationInfo(ctx, systemURI)
+		if dbErr != nil && errors.DBKeyNotFound != dbErr.ErrNo() {
+			releaseSystemOperations(ctx, systems[:i])
+			return fmt.Errorf("unable to get the operations in progress: %s", dbErr.Error())
+		}
+		if systemOperation.Operation != "" {
+			releaseSystemOperations(ctx, systems[:i])
+			return operationInProgressError{operation: systemOperation.Operation}
+		}
+		systemOperation.Operation = operation
+		if dbErr = systemOperation.AddSystemOperationInfo(systemURI); dbErr != nil {
+			releaseSystemOperations(ctx, systems[:i])
+			return fmt.Errorf("unable to block the other operations: %s", dbErr.Error())
+		}
+	}
+	return nil
+}
+
+// releaseSystemOperations deletes the system operation of the systems
+func releaseSystemOperations(ctx context.Context, systems []string) {
+	for _, systemURI := range systems {
+		if err := agmodel.DeleteSystemOperationInfo(systemURI); err != nil {
+			l.LogWithFields(ctx).Error("failed to delete the system operation info of " + systemURI + ": " + err.Error())
+		}
+	}
+}
+
+// newPluginContactRequest returns the request for contacting the plugin, logged in with its credentials
+func (e *ExternalInterface) newPluginContactRequest(ctx context.Context, plugin agmodel.Plugin) (getResourceRequest, error) {
+	var pluginContactRequest getResourceRequest
+	pluginContactRequest.ContactClient = e.ContactClient
+	pluginContactRequest.GetPluginStatus = e.GetPluginStatus
+	pluginContactRequest.Plugin = plugin
+	pluginContactRequest.StatusPoll = true
+	if strings.EqualFold(plugin.PreferredAuthType, "XAuthToken") {
+		pluginContactRequest.HTTPMethodType = http.MethodPost
+		pluginContactRequest.DeviceInfo = map[string]interface{}{
+			"UserName": plugin.Username,
+			"Password": string(plugin.Password),
+		}
+		pluginContactRequest.OID = "/ODIM/v1/Sessions"
+		_, token, _, err := contactPlugin(ctx, pluginContactRequest, "error while logging in to plugin: ")
+		if err != nil {
+			return pluginContactRequest, err
+		}
+		pluginContactRequest.Token = token
+	} else {
+		pluginContactRequest.LoginCredentials = map[string]string{
+			"UserName": plugin.Username,
+			"Password": string(plugin.Password),
+		}
+	}
+	return pluginContactRequest, nil
+}
+
+// changeBMCPassword changes the password of the BMC account used by ODIM through the
+// managers service, which logs in to the BMC with the password passed
+func (e *ExternalInterface) changeBMCPassword(ctx context.Context, target agmodel.Target, password, newPassword []byte) error {
+	resp, err := e.ChangeRemoteAccountPassword(ctx, target.DeviceUUID, password, newPassword)
+	if err != nil {
+		return fmt.Errorf("error while changing the BMC password: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error while changing the BMC password: %s", string(resp.Body))
+	}
+	return nil
+}
+
+// validateBMCLogin checks the login to the BMC with the password
+func validateBMCLogin(ctx context.Context, pluginContactRequest getResourceRequest, target agmodel.Target, password []byte) error {
+	pluginContactRequest.DeviceInfo = agmodel.SaveSystem{
+		ManagerAddress: target.ManagerAddress,
+		UserName:       target.UserName,
+		Password:       password,
+	}
+	pluginContactRequest.HTTPMethodType = http.MethodPost
+	pluginContactRequest.OID = "/ODIM/v1/validate"
+	_, _, _, err := contactPlugin(ctx, pluginContactRequest, "error while logging in to the BMC with the new password: ")
+	return err
+}
+
+// saveBMCPassword stores the encrypted password in the BMC details and in the aggregation sources of the systems
+func (e *ExternalInterface) saveBMCPassword(ctx context.Context, target agmodel.Target, systems []string, password []byte) error {
+	ciphertext, err := e.EncryptPassword(password)
+	if err != nil {
+		return fmt.Errorf("unable to encrypt the BMC password: %v", err)
+	}
+	saveSystem := agmodel.SaveSystem{
+		ManagerAddress:    target.ManagerAddress,
+		Password:          ciphertext,
+		UserName:          target.UserName,
+		DeviceUUID:        target.DeviceUUID,
+		PluginID:          target.PluginID,
+		EventDeliveryMode: target.EventDeliveryMode,
+		CertificateTrust:  target.CertificateTrust,
+	}
+	if dbErr := agmodel.UpdateSystemData(saveSystem, target.DeviceUUID); dbErr != nil {
+		return fmt.Errorf("unable to store the BMC password: %v", dbErr.Error())
+	}
+	for _, systemURI := range systems {
+		aggregationSourceURI := "/redfish/v1/AggregationService/AggregationSources/" + systemURI[strings.LastIndexByte(systemURI, '/')+1:]
+		aggregationSource, dbErr := agmodel.GetAggregationSourceInfo(ctx, aggregationSourceURI)
+		if dbErr != nil {
+			continue
+		}
+		aggregationSource.Password = ciphertext
+		if dbErr = agmodel.UpdateAggregtionSource(aggregationSource, aggregationSourceURI); dbErr != nil {
+			l.LogWithFields(ctx).Error("unable to store the BMC password in " + aggregationSourceURI + ": " + dbErr.Error())
+		}
+	}
+	return nil
+}
+
+// generatePassword returns a random password of the given length having characters of every class
+func generatePassword(length int) ([]byte, error) {
+	var all string
+	password := make([]byte, 0, length)
+	for _, class := range passwordCharacterClasses {
+		c, err := randomCharacter(class)
+		if err != nil {
+			return nil, err
+		}
+		password = append(password, c)
+		all += class
+	}
+	for len(password) < length {
+		c, err := randomCharacter(all)
+		if err != nil {
+			return nil, err
+		}
+		password = append(password, c)
+	}
+	// shuffle the password so that the classes are not at fixed positions
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return nil, err
+		}
+		password[i], password[j.Int64()] = password[j.Int64()], password[i]
+	}
+	return password, nil
+}
+
+func randomCharacter(characters string) (byte, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(len(characters))))
+	if err != nil {
+		return 0, err
+	}
+	return characters[i.Int64()], nil
+}
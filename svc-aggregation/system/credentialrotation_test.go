@@ -0,0 +1,182 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	managersproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/managers"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+)
+
+func TestExternalInterface_RotateCredentials(t *testing.T) {
+	config.SetUpMockConfig(t)
+	e := &ExternalInterface{UpdateTask: mockUpdateTask}
+	actionURI := "/redfish/v1/AggregationService/Aggregates/someID" + rotateCredentialsURI
+	tests := []struct {
+		name        string
+		body        string
+		wantStatus  int32
+		wantMessage string
+	}{
+		{"invalid request", `{"IntervalInDays":`, http.StatusBadRequest, response.MalformedJSON},
+		{"unknown property", `{"intervalInDays":90}`, http.StatusBadRequest, response.PropertyUnknown},
+		{"negative interval", `{"IntervalInDays":-1}`, http.StatusBadRequest, response.PropertyValueNotInList},
+		{"short password", `{"PasswordLength":4}`, http.StatusBadRequest, response.PropertyValueNotInList},
+		{"long password", `{"PasswordLength":64}`, http.StatusBadRequest, response.PropertyValueNotInList},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &aggregatorproto.AggregatorRequest{URL: actionURI, RequestBody: []byte(tt.body)}
+			resp := e.RotateCredentials(context.TODO(), "someTaskID", "admin", req)
+			if resp.StatusCode != tt.wantStatus || resp.StatusMessage != tt.wantMessage {
+				t.Errorf("RotateCredentials() = %v %v, want %v %v", resp.StatusCode, resp.StatusMessage, tt.wantStatus, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestRotateCredentialsRequest_apply(t *testing.T) {
+	interval, length := 0, 20
+	rotation := agmodel.CredentialRotation{IntervalInDays: 90}
+	RotateCredentialsRequest{}.apply(&rotation)
+	if rotation.IntervalInDays != 90 || rotation.PasswordLength != defaultPasswordLength {
+		t.Errorf("apply() = %+v, want the interval kept and the default password length", rotation)
+	}
+	RotateCredentialsRequest{IntervalInDays: &interval, PasswordLength: &length}.apply(&rotation)
+	if rotation.IntervalInDays != 0 || rotation.PasswordLength != 20 {
+		t.Errorf("apply() = %+v, want the policy of the request", rotation)
+	}
+}
+
+func TestIsCredentialRotationDue(t *testing.T) {
+	now := time.Now().UTC()
+	tests := []struct {
+		name     string
+		rotation agmodel.CredentialRotation
+		want     bool
+	}{
+		{"no schedule", agmodel.CredentialRotation{NextRotationTime: now.Add(-time.Hour).Format(time.RFC3339)}, false},
+		{"never rotated", agmodel.CredentialRotation{IntervalInDays: 90}, true},
+		{"due", agmodel.CredentialRotation{IntervalInDays: 90, NextRotationTime: now.Add(-time.Hour).Format(time.RFC3339)}, true},
+		{"not due", agmodel.CredentialRotation{IntervalInDays: 90, NextRotationTime: now.Add(time.Hour).Format(time.RFC3339)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isCredentialRotationDue(tt.rotation, now); got != tt.want {
+				t.Errorf("isCredentialRotationDue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupSystemsByBMC(t *testing.T) {
+	elements := []agmodel.OdataID{
+		{OdataID: "/redfish/v1/Systems/uuid1.1"},
+		{OdataID: "/redfish/v1/Systems/uuid2.1"},
+		{OdataID: "/redfish/v1/Systems/uuid1.2"},
+	}
+	want := [][]string{
+		{"/redfish/v1/Systems/uuid1.1", "/redfish/v1/Systems/uuid1.2"},
+		{"/redfish/v1/Systems/uuid2.1"},
+	}
+	if got := groupSystemsByBMC(elements); !reflect.DeepEqual(got, want) {
+		t.Errorf("groupSystemsByBMC() = %v, want %v", got, want)
+	}
+}
+
+func TestGeneratePassword(t *testing.T) {
+	for _, length := range []int{minPasswordLength, defaultPasswordLength, maxPasswordLength} {
+		password, err := generatePassword(length)
+		if err != nil {
+			t.Fatalf("generatePassword() error = %v", err)
+		}
+		if len(password) != length {
+			t.Errorf("generatePassword() length = %v, want %v", len(password), length)
+		}
+		for _, class := range passwordCharacterClasses {
+			if !strings.ContainsAny(string(password), class) {
+				t.Errorf("generatePassword() = %v, want a character of %v", string(password), class)
+			}
+		}
+	}
+}
+
+func TestExternalInterface_changeBMCPassword(t *testing.T) {
+	target := agmodel.Target{DeviceUUID: "uuid1", UserName: "admin"}
+	var request []string
+	e := &ExternalInterface{
+		ChangeRemoteAccountPassword: func(ctx context.Context, deviceUUID string, password, newPassword []byte) (*managersproto.ManagerResponse, error) {
+			request = []string{deviceUUID, string(password), string(newPassword)}
+			if string(newPassword) == "rejected" {
+				return &managersproto.ManagerResponse{StatusCode: http.StatusInternalServerError, Body: []byte("rejected by the BMC")}, nil
+			}
+			return &managersproto.ManagerResponse{StatusCode: http.StatusOK}, nil
+		},
+	}
+	if err := e.changeBMCPassword(context.TODO(), target, []byte("current"), []byte("new")); err != nil {
+		t.Errorf("changeBMCPassword() error = %v", err)
+	}
+	if want := []string{"uuid1", "current", "new"}; !reflect.DeepEqual(request, want) {
+		t.Errorf("changeBMCPassword() request = %v, want %v", request, want)
+	}
+	if err := e.changeBMCPassword(context.TODO(), target, []byte("current"), []byte("rejected")); err == nil {
+		t.Errorf("changeBMCPassword() should fail when the managers service fails")
+	}
+}
+
+func TestAcquireSystemOperations(t *testing.T) {
+	config.SetUpMockConfig(t)
+	defer func() {
+		if err := common.TruncateDB(common.InMemory); err != nil {
+			t.Fatalf("error: %v", err)
+		}
+	}()
+	systems := []string{
+		"/redfish/v1/Systems/ef83e569-7336-492a-aaee-31c02d9db831.1",
+		"/redfish/v1/Systems/ef83e569-7336-492a-aaee-31c02d9db831.2",
+	}
+	deleteOperation := agmodel.SystemOperation{Operation: "Delete"}
+	if err := deleteOperation.AddSystemOperationInfo(systems[1]); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	err := acquireSystemOperations(context.TODO(), systems, inventoryRefreshOperation)
+	if _, ok := err.(operationInProgressError); !ok {
+		t.Fatalf("acquireSystemOperations() error = %v, want the operation in progress", err)
+	}
+	if _, err := agmodel.GetSystemOperationInfo(context.TODO(), systems[0]); err == nil {
+		t.Errorf("acquireSystemOperations() kept the operation of %v after a failure", systems[0])
+	}
+
+	releaseSystemOperations(context.TODO(), systems[1:])
+	if err := acquireSystemOperations(context.TODO(), systems, inventoryRefreshOperation); err != nil {
+		t.Fatalf("acquireSystemOperations() error = %v", err)
+	}
+	for _, systemURI := range systems {
+		if operation, _ := agmodel.GetSystemOperationInfo(context.TODO(), systemURI); operation.Operation != inventoryRefreshOperation {
+			t.Errorf("operation of %v = %q, want %q", systemURI, operation.Operation, inventoryRefreshOperation)
+		}
+	}
+	releaseSystemOperations(context.TODO(), systems)
+}
@@ -371,6 +371,9 @@ func (e *ExternalInterface) deletePlugin(ctx context.Context, oid string) respon
 		}
 		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
 	}
+	if err := common.DeletePluginInstanceHealth(pluginID); err != nil {
+		l.LogWithFields(ctx).Error("failed to remove the health of the instances of plugin " + pluginID + ": " + err.Error())
+	}
 	MQ := agmessagebus.InitMQSCom()
 	e.EventNotification(ctx, oid, "ResourceRemoved", "ManagerCollection", MQ)
 	resp.StatusCode = http.StatusOK
@@ -585,11 +588,11 @@ func (e *ExternalInterface) deleteWildCardValues(ctx context.Context, systemID s
 				l.LogWithFields(ctx).Error("Unable to unmarshall  the data: " + err.Error())
 				continue
 			}
-			var wildCards []WildCard
+			var wildCards []common.WildCard
 			var wildCardPresent bool
 			wCards := resourceData["Wildcards"]
 			if wCards != nil {
-				for _, wCard := range getWildCard(wCards.([]interface{})) {
+				for _, wCard := range common.GetWildCard(wCards.([]interface{})) {
 					wCard.Values = checkAndRemoveWildCardValue(systemID, wCard.Values)
 					wildCards = append(wildCards, wCard)
 					if len(wCard.Values) > 0 {
@@ -597,7 +600,8 @@ func (e *ExternalInterface) deleteWildCardValues(ctx context.Context, systemID s
 					}
 				}
 			}
-			if wildCardPresent {
+			// the definitions and triggers created through ODIM are deleted only by the user
+			if wildCardPresent || isComputedByOdim(resourceData) {
 				resourceData["Wildcards"] = wildCards
 				resourceDataByte, err := json.Marshal(resourceData)
 				if err != nil {
@@ -619,6 +623,14 @@ func (e *ExternalInterface) deleteWildCardValues(ctx context.Context, systemID s
 	}
 }
 
+// isComputedByOdim checks whether the telemetry resource was created through ODIM
+func isComputedByOdim(resourceData map[string]interface{}) bool {
+	oem, _ := resourceData["Oem"].(map[string]interface{})
+	odim, _ := oem["Odim"].(map[string]interface{})
+	computed, _ := odim["ComputedByOdim"].(bool)
+	return computed
+}
+
 // checkAndRemoveWildCardValue will check and remove the wild card value
 func checkAndRemoveWildCardValue(val string, values []string) []string {
 	var wildCardValues []string
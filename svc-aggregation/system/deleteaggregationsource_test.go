@@ -652,6 +652,35 @@ func Test_checkAndRemoveWildCardValue(t *testing.T) {
 	}
 }
 
+func Test_isComputedByOdim(t *testing.T) {
+	tests := []struct {
+		name         string
+		resourceData map[string]interface{}
+		want         bool
+	}{
+		{
+			name:         "resource of a server",
+			resourceData: map[string]interface{}{"Id": "PowerMetrics"},
+			want:         false,
+		},
+		{
+			name: "resource created through ODIM",
+			resourceData: map[string]interface{}{
+				"Id":  "InletTemperature",
+				"Oem": map[string]interface{}{"Odim": map[string]interface{}{"ComputedByOdim": true}},
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isComputedByOdim(tt.resourceData); got != tt.want {
+				t.Errorf("isComputedByOdim() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestExternalInterface_updateMemberCollection(t *testing.T) {
 	p := getMockExternalInterface()
 	config.SetUpMockConfig(t)
@@ -0,0 +1,546 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agcommon"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+	"github.com/google/uuid"
+)
+
+const (
+	// BMCDiscoveryActionID action id for logging
+	BMCDiscoveryActionID = "235"
+	// BMCDiscoveryActionName action name for logging
+	BMCDiscoveryActionName = "BMCDiscovery"
+
+	discoveredBMCsURI     = "/redfish/v1/AggregationService/Oem/Odim/DiscoveredBMCs"
+	discoveredBMCAdoptURI = "/Actions/DiscoveredBMC.Adopt"
+	genericPluginID       = "GRF"
+)
+
+// vendorPluginIDs maps the vendor found in the Redfish service root of a BMC
+// to the ID of the plugin managing the BMCs of that vendor
+var vendorPluginIDs = map[string]string{
+	"dell":   "DELL",
+	"lenovo": "LENOVO",
+}
+
+// AdoptDiscoveredBMCRequest is the request body of the Adopt action of a discovered BMC.
+// All the properties are optional, the defaults being the credentials configured for
+// the discovery and the connection method chosen while fingerprinting the BMC.
+type AdoptDiscoveredBMCRequest struct {
+	UserName string `json:"UserName,omitempty"`
+	Password string `json:"Password,omitempty"`
+	Links    *Links `json:"Links,omitempty"`
+}
+
+// redfishServiceRoot holds the properties of the service root used for fingerprinting a BMC
+type redfishServiceRoot struct {
+	RedfishVersion string                     `json:"RedfishVersion"`
+	Vendor         string                     `json:"Vendor"`
+	Product        string                     `json:"Product"`
+	UUID           string                     `json:"UUID"`
+	Oem            map[string]json.RawMessage `json:"Oem"`
+}
+
+// discoveryResult is a BMC found on the probed address
+type discoveryResult struct {
+	host string
+	port int
+	bmc  agmodel.DiscoveredBMC
+}
+
+// PerformBMCDiscovery scans the configured subnets for BMCs continuously over
+// the configured interval. The configuration is read before every scan, so that
+// the changes done to it take effect without restarting the service.
+func (e *ExternalInterface) PerformBMCDiscovery() {
+	transactionID := uuid.New()
+	ctx := agcommon.CreateContext(transactionID.String(), BMCDiscoveryActionID, BMCDiscoveryActionName, "1", common.AggregationService, podName)
+	l.LogWithFields(ctx).Info("BMC discovery routine started")
+	for {
+		interval := config.DefaultBMCDiscoveryScanIntervalInMins
+		if conf := config.Data.BMCDiscoveryConf; conf != nil {
+			if conf.Enabled {
+				if err := e.DiscoverBMCs(ctx, *conf); err != nil {
+					l.LogWithFields(ctx).Error("BMC discovery failed: " + err.Error())
+				}
+			}
+			if conf.ScanIntervalInMins > 0 {
+				interval = conf.ScanIntervalInMins
+			}
+		}
+		time.Sleep(time.Minute * time.Duration(interval))
+	}
+}
+
+// DiscoverBMCs probes every address of the configured subnets for a Redfish service root
+// and stores the BMCs found which are not aggregated yet as discovered BMCs. Discovered BMCs
+// which are no longer reachable or which got aggregated in the meantime are removed.
+func (e *ExternalInterface) DiscoverBMCs(ctx context.Context, conf config.BMCDiscoveryConf) error {
+	hosts, err := getDiscoveryHosts(conf.Subnets, conf.MaxHostsPerScan)
+	if err != nil {
+		return err
+	}
+	connectionMethods, err := e.getComputeConnectionMethods(ctx)
+	if err != nil {
+		return err
+	}
+	l.LogWithFields(ctx).Infof("probing %d addresses of subnets %v for BMCs", len(hosts), conf.Subnets)
+
+	client := &http.Client{
+		Timeout: time.Duration(conf.ProbeTimeoutInSecs) * time.Second,
+		Transport: &http.Transport{
+			// certificates of the BMCs are verified by the plugins only once they
+			// are added, the service root is read here just for fingerprinting
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+	concurrency := conf.MaxConcurrentProbes
+	if concurrency <= 0 {
+		concurrency = config.DefaultBMCDiscoveryMaxConcurrentProbes
+	}
+	found := make(map[string]discoveryResult)
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	for _, host := range hosts {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(host string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			for _, port := range conf.Ports {
+				bmc, ok := probeBMC(ctx, client, host, port)
+				if !ok {
+					continue
+				}
+				bmc.PluginID, bmc.ConnectionMethod = getDiscoveredBMCConnectionMethod(bmc.Vendor, connectionMethods)
+				mutex.Lock()
+				found[getDiscoveredBMCURI(host, port)] = discoveryResult{host: host, port: port, bmc: bmc}
+				mutex.Unlock()
+				return
+			}
+		}(host)
+	}
+	wg.Wait()
+
+	// every address is probed in each scan, so the discovered BMCs which did not respond
+	// in this scan, or which are no longer in the configured subnets, are removed
+	existingKeys, err := e.GetAllKeysFromTable(ctx, "DiscoveredBMC")
+	if err != nil {
+		return fmt.Errorf("failed to get discovered BMCs: %v", err)
+	}
+	for _, key := range existingKeys {
+		if _, exist := found[key]; !exist {
+			if err := e.DeleteDiscoveredBMC(key); err != nil {
+				l.LogWithFields(ctx).Error("failed to remove discovered BMC " + key + ": " + err.Error())
+			}
+		}
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	for uri, result := range found {
+		if e.isBMCAggregated(ctx, result.host, result.port) {
+			if err := e.DeleteDiscoveredBMC(uri); err != nil && err.ErrNo() != errors.DBKeyNotFound {
+				l.LogWithFields(ctx).Error("failed to remove discovered BMC " + uri + ": " + err.Error())
+			}
+			continue
+		}
+		bmc := result.bmc
+		bmc.FirstSeen, bmc.LastSeen = now, now
+		if existing, err := e.GetDiscoveredBMCInfo(uri); err == nil && existing.FirstSeen != "" {
+			bmc.FirstSeen = existing.FirstSeen
+		}
+		if err := e.SaveDiscoveredBMC(bmc, uri); err != nil {
+			l.LogWithFields(ctx).Error("failed to save discovered BMC " + uri + ": " + err.Error())
+		}
+	}
+	l.LogWithFields(ctx).Infof("BMC discovery found %d BMCs in subnets %v", len(found), conf.Subnets)
+	return nil
+}
+
+// getDiscoveryHosts returns the addresses of all the given subnets, excluding the
+// network and broadcast addresses of IPv4 subnets. It fails when the subnets have more
+// than maxHosts addresses, every scan has to probe all of them.
+func getDiscoveryHosts(subnets []string, maxHosts int) ([]string, error) {
+	var hosts []string
+	for _, subnet := range subnets {
+		_, ipNet, err := net.ParseCIDR(subnet)
+		if err != nil {
+			return nil, fmt.Errorf("invalid subnet %s: %v", subnet, err)
+		}
+		ones, bits := ipNet.Mask.Size()
+		ip := ipNet.IP.Mask(ipNet.Mask)
+		for ; ipNet.Contains(ip); ip = nextIP(ip) {
+			if bits == 32 && bits-ones > 1 && (ip.Equal(ipNet.IP) || isBroadcastIP(ip, ipNet)) {
+				continue
+			}
+			if maxHosts > 0 && len(hosts) >= maxHosts {
+				return nil, fmt.Errorf("subnets %v have more than %d addresses of MaxHostsPerScan", subnets, maxHosts)
+			}
+			hosts = append(hosts, ip.String())
+		}
+	}
+	return hosts, nil
+}
+
+func nextIP(ip net.IP) net.IP {
+	next := make(net.IP, len(ip))
+	copy(next, ip)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			break
+		}
+	}
+	return next
+}
+
+func isBroadcastIP(ip net.IP, ipNet *net.IPNet) bool {
+	for i := range ip {
+		if ip[i]|ipNet.Mask[i] != 0xff {
+			return false
+		}
+	}
+	return true
+}
+
+// probeBMC reads the unauthenticated Redfish service root of the address
+func probeBMC(ctx context.Context, client *http.Client, host string, port int) (agmodel.DiscoveredBMC, bool) {
+	var bmc agmodel.DiscoveredBMC
+	hostName := getDiscoveredBMCHostName(host, port)
+	resp, err := client.Get("https://" + net.JoinHostPort(host, strconv.Itoa(port)) + "/redfish/v1/")
+	if err != nil {
+		return bmc, false
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		l.LogWithFields(ctx).Debugf("service root of %s returned %d", hostName, resp.StatusCode)
+		return bmc, false
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return bmc, false
+	}
+	var serviceRoot redfishServiceRoot
+	if err := json.Unmarshal(body, &serviceRoot); err != nil || serviceRoot.RedfishVersion == "" {
+		l.LogWithFields(ctx).Debugf("%s did not respond with a Redfish service root", hostName)
+		return bmc, false
+	}
+	bmc = agmodel.DiscoveredBMC{
+		HostName:       hostName,
+		Vendor:         getServiceRootVendor(serviceRoot),
+		Product:        serviceRoot.Product,
+		RedfishVersion: serviceRoot.RedfishVersion,
+		UUID:           serviceRoot.UUID,
+	}
+	return bmc, true
+}
+
+// getServiceRootVendor returns the Vendor of the service root. Implementations
+// older than Redfish 1.5 do not have it, then the Oem section name is used.
+func getServiceRootVendor(serviceRoot redfishServiceRoot) string {
+	if serviceRoot.Vendor != "" {
+		return serviceRoot.Vendor
+	}
+	var oemNames []string
+	for name := range serviceRoot.Oem {
+		oemNames = append(oemNames, name)
+	}
+	sort.Strings(oemNames)
+	for _, name := range oemNames {
+		if _, exist := vendorPluginIDs[strings.ToLower(name)]; exist {
+			return name
+		}
+	}
+	if len(oemNames) > 0 {
+		return oemNames[0]
+	}
+	return ""
+}
+
+// getComputeConnectionMethods returns the URIs of the Compute connection methods by plugin ID
+func (e *ExternalInterface) getComputeConnectionMethods(ctx context.Context) (map[string]string, error) {
+	keys, err := e.GetAllKeysFromTable(ctx, "ConnectionMethod")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection methods: %v", err)
+	}
+	connectionMethods := make(map[string]string)
+	for _, key := range keys {
+		connectionMethod, err := e.GetConnectionMethod(ctx, key)
+		if err != nil {
+			l.LogWithFields(ctx).Error("failed to get connection method " + key + ": " + err.Error())
+			continue
+		}
+		variant := strings.Split(connectionMethod.ConnectionMethodVariant, ":")
+		if len(variant) != 3 || variant[0] != "Compute" {
+			continue
+		}
+		pluginID := variant[2]
+		if _, exist := connectionMethods[pluginID]; !exist {
+			connectionMethods[pluginID] = key
+		}
+	}
+	return connectionMethods, nil
+}
+
+// getDiscoveredBMCConnectionMethod picks the plugin for the vendor of the BMC, falling
+// back to the generic Redfish plugin when there is no plugin specific to the vendor
+func getDiscoveredBMCConnectionMethod(vendor string, connectionMethods map[string]string) (string, string) {
+	pluginName, exist := vendorPluginIDs[strings.ToLower(vendor)]
+	if !exist {
+		pluginName = genericPluginID
+	}
+	for _, name := range []string{pluginName, genericPluginID} {
+		var pluginIDs []string
+		for pluginID := range connectionMethods {
+			if strings.Split(pluginID, "_")[0] == name {
+				pluginIDs = append(pluginIDs, pluginID)
+			}
+		}
+		if len(pluginIDs) > 0 {
+			// prefer the latest version of the plugin
+			sort.Strings(pluginIDs)
+			pluginID := pluginIDs[len(pluginIDs)-1]
+			return pluginID, connectionMethods[pluginID]
+		}
+	}
+	return "", ""
+}
+
+// isBMCAggregated checks whether the BMC is already added as an aggregation source
+func (e *ExternalInterface) isBMCAggregated(ctx context.Context, host string, port int) bool {
+	for _, key := range []string{host, net.JoinHostPort(host, strconv.Itoa(port))} {
+		present, err := e.CheckBMCAddress(key)
+		if err != nil {
+			l.LogWithFields(ctx).Error("failed to check whether " + key + " is aggregated: " + err.Error())
+			continue
+		}
+		if present {
+			return true
+		}
+	}
+	return false
+}
+
+// getDiscoveredBMCHostName returns the HostName to be used while adding the BMC,
+// the port is left out when the BMC listens on the default https port
+func getDiscoveredBMCHostName(host string, port int) string {
+	if port == 443 {
+		return host
+	}
+	return net.JoinHostPort(host, strconv.Itoa(port))
+}
+
+// getDiscoveredBMCURI returns the URI of the discovered BMC, which stays the
+// same across the scans as long as the BMC is found on the same address
+func getDiscoveredBMCURI(host string, port int) string {
+	id := uuid.NewSHA1(uuid.NameSpaceURL, []byte(net.JoinHostPort(host, strconv.Itoa(port))))
+	return discoveredBMCsURI + "/" + id.String()
+}
+
+// GetAllDiscoveredBMCs returns the collection of the BMCs discovered and not aggregated yet
+func (e *ExternalInterface) GetAllDiscoveredBMCs(ctx context.Context) response.RPC {
+	keys, err := e.GetAllKeysFromTable(ctx, "DiscoveredBMC")
+	if err != nil {
+		errorMessage := err.Error()
+		l.LogWithFields(ctx).Error("Unable to get discovered BMCs : " + errorMessage)
+		return common.GeneralError(http.StatusServiceUnavailable, response.CouldNotEstablishConnection, errorMessage, []interface{}{config.Data.DBConf.OnDiskHost + ":" + config.Data.DBConf.OnDiskPort}, nil)
+	}
+	sort.Strings(keys)
+	var members = make([]agresponse.ListMember, 0)
+	for _, key := range keys {
+		members = append(members, agresponse.ListMember{
+			OdataID: key,
+		})
+	}
+	var resp = response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+	}
+	commonResponse := response.Response{
+		OdataType:    "#DiscoveredBMCCollection.DiscoveredBMCCollection",
+		OdataID:      discoveredBMCsURI,
+		OdataContext: "/redfish/v1/$metadata#DiscoveredBMCCollection.DiscoveredBMCCollection",
+		Name:         "Discovered BMCs",
+	}
+	commonResponse.CreateGenericResponse(response.Success)
+	commonResponse.Message = ""
+	commonResponse.ID = ""
+	commonResponse.MessageID = ""
+	commonResponse.Severity = ""
+	resp.Body = agresponse.List{
+		Response:     commonResponse,
+		MembersCount: len(members),
+		Members:      members,
+	}
+	return resp
+}
+
+// GetDiscoveredBMC returns the details of the discovered BMC with the given uri
+func (e *ExternalInterface) GetDiscoveredBMC(ctx context.Context, reqURI string) response.RPC {
+	bmc, err := e.GetDiscoveredBMCInfo(reqURI)
+	if err != nil {
+		errorMessage := err.Error()
+		l.LogWithFields(ctx).Error("Unable to get discovered BMC : " + errorMessage)
+		if errors.DBKeyNotFound == err.ErrNo() {
+			return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errorMessage, []interface{}{"DiscoveredBMC", reqURI}, nil)
+		}
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	commonResponse := response.Response{
+		OdataType:    "#DiscoveredBMC.v1_0_0.DiscoveredBMC",
+		OdataID:      reqURI,
+		OdataContext: "/redfish/v1/$metadata#DiscoveredBMC.DiscoveredBMC",
+		ID:           strings.TrimPrefix(reqURI, discoveredBMCsURI+"/"),
+		Name:         "Discovered BMC " + bmc.HostName,
+	}
+	commonResponse.CreateGenericResponse(response.Success)
+	commonResponse.Message = ""
+	commonResponse.MessageID = ""
+	commonResponse.Severity = ""
+	discoveredBMC := agresponse.DiscoveredBMCResponse{
+		Response:       commonResponse,
+		HostName:       bmc.HostName,
+		Vendor:         bmc.Vendor,
+		Product:        bmc.Product,
+		RedfishVersion: bmc.RedfishVersion,
+		UUID:           bmc.UUID,
+		FirstSeen:      bmc.FirstSeen,
+		LastSeen:       bmc.LastSeen,
+		Actions: agresponse.DiscoveredBMCActions{
+			Adopt: agresponse.Action{
+				Target: reqURI + discoveredBMCAdoptURI,
+			},
+		},
+	}
+	if bmc.ConnectionMethod != "" {
+		discoveredBMC.Links.ConnectionMethod = &agresponse.OdataID{OdataID: bmc.ConnectionMethod}
+	}
+	return response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+		Body:          discoveredBMC,
+	}
+}
+
+// AdoptDiscoveredBMC adds the discovered BMC as an aggregation source, using the
+// credentials configured for the discovery unless given in the request
+func (e *ExternalInterface) AdoptDiscoveredBMC(ctx context.Context, taskID, sessionUserName string, req *aggregatorproto.AggregatorRequest) response.RPC {
+	var resp response.RPC
+	var percentComplete int32
+	targetURI := req.URL
+	discoveredBMCURI := strings.TrimSuffix(req.URL, discoveredBMCAdoptURI)
+	// request carries credentials, hence cannot be saved in the task
+	taskInfo := &common.TaskUpdateInfo{Context: ctx, TaskID: taskID, TargetURI: targetURI, UpdateTask: e.UpdateTask, TaskRequest: ""}
+	err := e.UpdateTask(ctx, fillTaskData(taskID, targetURI, "", resp, common.Running, common.OK, percentComplete, http.MethodPost))
+	if err != nil {
+		errMsg := "error while starting the task: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+
+	var adoptRequest AdoptDiscoveredBMCRequest
+	if len(req.RequestBody) > 0 {
+		if err := json.Unmarshal(req.RequestBody, &adoptRequest); err != nil {
+			errMsg := "unable to parse the adopt request: " + err.Error()
+			l.LogWithFields(ctx).Error(errMsg)
+			return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, taskInfo)
+		}
+		invalidProperties, err := common.RequestParamsCaseValidator(req.RequestBody, adoptRequest)
+		if err != nil {
+			errMsg := "error while validating request parameters: " + err.Error()
+			l.LogWithFields(ctx).Error(errMsg)
+			return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+		} else if invalidProperties != "" {
+			errMsg := "error: one or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase "
+			l.LogWithFields(ctx).Error(errMsg)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errMsg, []interface{}{invalidProperties}, taskInfo)
+		}
+	}
+
+	bmc, dbErr := e.GetDiscoveredBMCInfo(discoveredBMCURI)
+	if dbErr != nil {
+		errMsg := "unable to get discovered BMC: " + dbErr.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		if errors.DBKeyNotFound == dbErr.ErrNo() {
+			return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"DiscoveredBMC", discoveredBMCURI}, taskInfo)
+		}
+		return common.GeneralError(http.StatusServiceUnavailable, response.CouldNotEstablishConnection, errMsg, []interface{}{config.Data.DBConf.OnDiskHost + ":" + config.Data.DBConf.OnDiskPort}, taskInfo)
+	}
+	aggregationSource, resp := getAdoptAggregationSource(bmc, adoptRequest, taskInfo)
+	if resp.StatusCode != 0 {
+		l.LogWithFields(ctx).Error(getErrorMessage(resp))
+		return resp
+	}
+	reqBody, _ := json.Marshal(AggregationSource{HostName: aggregationSource.HostName, UserName: aggregationSource.UserName, Links: aggregationSource.Links})
+	resp = e.addAggregationSource(ctx, taskID, aggregationSourcesURI, string(reqBody), percentComplete, aggregationSource, taskInfo)
+	if resp.StatusCode == http.StatusCreated {
+		if err := e.DeleteDiscoveredBMC(discoveredBMCURI); err != nil {
+			l.LogWithFields(ctx).Error("failed to remove adopted BMC " + discoveredBMCURI + ": " + err.Error())
+		}
+	}
+	return resp
+}
+
+// getAdoptAggregationSource builds the add request of the discovered BMC
+// filling the properties not given in the adopt request with the defaults
+func getAdoptAggregationSource(bmc agmodel.DiscoveredBMC, req AdoptDiscoveredBMCRequest, taskInfo *common.TaskUpdateInfo) (AggregationSource, response.RPC) {
+	aggregationSource := AggregationSource{
+		HostName: bmc.HostName,
+		UserName: req.UserName,
+		Password: req.Password,
+		Links:    req.Links,
+	}
+	if aggregationSource.UserName == "" && aggregationSource.Password == "" && config.Data.BMCDiscoveryConf != nil {
+		aggregationSource.UserName = config.Data.BMCDiscoveryConf.DefaultUserName
+		aggregationSource.Password = string(config.Data.BMCDiscoveryConf.DefaultPassword)
+	}
+	if aggregationSource.UserName == "" || aggregationSource.Password == "" {
+		errMsg := "error: credentials are neither given in the request nor configured for the discovery"
+		return aggregationSource, common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"UserName"}, taskInfo)
+	}
+	if aggregationSource.Links == nil || aggregationSource.Links.ConnectionMethod == nil || aggregationSource.Links.ConnectionMethod.OdataID == "" {
+		if bmc.ConnectionMethod == "" {
+			errMsg := "error: no connection method found for the discovered BMC, ConnectionMethod is to be given in the request"
+			return aggregationSource, common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"ConnectionMethod"}, taskInfo)
+		}
+		aggregationSource.Links = &Links{
+			ConnectionMethod: &ConnectionMethod{OdataID: bmc.ConnectionMethod},
+		}
+	}
+	return aggregationSource, response.RPC{}
+}
@@ -0,0 +1,372 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+)
+
+const (
+	mockGRFConnectionMethodURI  = "/redfish/v1/AggregationService/ConnectionMethods/7ff3bd97-c41c-5de0-937d-85d390691b73"
+	mockDellConnectionMethodURI = "/redfish/v1/AggregationService/ConnectionMethods/d3b8b8c2-6b19-4d3b-9b49-2f8b1d6f3a10"
+)
+
+// mockDiscoveredBMCStore is an in memory replacement of the DiscoveredBMC table
+type mockDiscoveredBMCStore struct {
+	sync.Mutex
+	bmcs       map[string]agmodel.DiscoveredBMC
+	aggregated map[string]bool
+}
+
+func getMockDiscoveryInterface(store *mockDiscoveredBMCStore) *ExternalInterface {
+	return &ExternalInterface{
+		UpdateTask: mockUpdateTask,
+		GetAllKeysFromTable: func(ctx context.Context, table string) ([]string, error) {
+			switch table {
+			case "ConnectionMethod":
+				return []string{mockGRFConnectionMethodURI, mockDellConnectionMethodURI}, nil
+			case "DiscoveredBMC":
+				store.Lock()
+				defer store.Unlock()
+				var keys []string
+				for key := range store.bmcs {
+					keys = append(keys, key)
+				}
+				return keys, nil
+			}
+			return nil, fmt.Errorf("Table not found")
+		},
+		GetConnectionMethod: func(ctx context.Context, uri string) (agmodel.ConnectionMethod, *errors.Error) {
+			switch uri {
+			case mockGRFConnectionMethodURI:
+				return agmodel.ConnectionMethod{ConnectionMethodType: "Redfish", ConnectionMethodVariant: "Compute:BasicAuth:GRF_v2.0.0"}, nil
+			case mockDellConnectionMethodURI:
+				return agmodel.ConnectionMethod{ConnectionMethodType: "Redfish", ConnectionMethodVariant: "Compute:BasicAuth:DELL_v2.0.0"}, nil
+			}
+			return agmodel.ConnectionMethod{}, errors.PackError(errors.DBKeyNotFound, "not found")
+		},
+		SaveDiscoveredBMC: func(bmc agmodel.DiscoveredBMC, uri string) *errors.Error {
+			store.Lock()
+			defer store.Unlock()
+			store.bmcs[uri] = bmc
+			return nil
+		},
+		GetDiscoveredBMCInfo: func(uri string) (agmodel.DiscoveredBMC, *errors.Error) {
+			store.Lock()
+			defer store.Unlock()
+			bmc, exist := store.bmcs[uri]
+			if !exist {
+				return bmc, errors.PackError(errors.DBKeyNotFound, "no data with the with key "+uri+" found")
+			}
+			return bmc, nil
+		},
+		DeleteDiscoveredBMC: func(uri string) *errors.Error {
+			store.Lock()
+			defer store.Unlock()
+			if _, exist := store.bmcs[uri]; !exist {
+				return errors.PackError(errors.DBKeyNotFound, "no data with the with key "+uri+" found")
+			}
+			delete(store.bmcs, uri)
+			return nil
+		},
+		CheckBMCAddress: func(address string) (bool, error) {
+			return store.aggregated[address], nil
+		},
+	}
+}
+
+func TestGetDiscoveryHosts(t *testing.T) {
+	tests := []struct {
+		name     string
+		subnets  []string
+		maxHosts int
+		want     []string
+		wantErr  bool
+	}{
+		{"network and broadcast addresses are skipped", []string{"10.0.0.0/30"}, 0, []string{"10.0.0.1", "10.0.0.2"}, false},
+		{"single host", []string{"10.0.0.5/32"}, 0, []string{"10.0.0.5"}, false},
+		{"point to point subnet", []string{"10.0.0.0/31"}, 0, []string{"10.0.0.0", "10.0.0.1"}, false},
+		{"multiple subnets", []string{"10.0.0.5/32", "192.168.1.8/30"}, 0, []string{"10.0.0.5", "192.168.1.9", "192.168.1.10"}, false},
+		{"number of hosts within the limit", []string{"10.0.0.0/30"}, 2, []string{"10.0.0.1", "10.0.0.2"}, false},
+		{"more hosts than the limit", []string{"10.0.0.0/24"}, 3, nil, true},
+		{"invalid subnet", []string{"10.0.0.0/33"}, 0, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getDiscoveryHosts(tt.subnets, tt.maxHosts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getDiscoveryHosts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getDiscoveryHosts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetDiscoveredBMCConnectionMethod(t *testing.T) {
+	connectionMethods := map[string]string{
+		"GRF_v1.0.0":  "/redfish/v1/AggregationService/ConnectionMethods/1",
+		"GRF_v2.0.0":  "/redfish/v1/AggregationService/ConnectionMethods/2",
+		"DELL_v2.0.0": "/redfish/v1/AggregationService/ConnectionMethods/3",
+	}
+	tests := []struct {
+		name             string
+		vendor           string
+		connectionMethod map[string]string
+		wantPluginID     string
+		wantURI          string
+	}{
+		{"vendor plugin", "Dell", connectionMethods, "DELL_v2.0.0", "/redfish/v1/AggregationService/ConnectionMethods/3"},
+		{"vendor without plugin", "Lenovo", connectionMethods, "GRF_v2.0.0", "/redfish/v1/AggregationService/ConnectionMethods/2"},
+		{"unknown vendor", "Contoso", connectionMethods, "GRF_v2.0.0", "/redfish/v1/AggregationService/ConnectionMethods/2"},
+		{"no connection method", "Dell", map[string]string{}, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pluginID, uri := getDiscoveredBMCConnectionMethod(tt.vendor, tt.connectionMethod)
+			if pluginID != tt.wantPluginID || uri != tt.wantURI {
+				t.Errorf("getDiscoveredBMCConnectionMethod() = %v, %v, want %v, %v", pluginID, uri, tt.wantPluginID, tt.wantURI)
+			}
+		})
+	}
+}
+
+func TestGetServiceRootVendor(t *testing.T) {
+	tests := []struct {
+		name        string
+		serviceRoot redfishServiceRoot
+		want        string
+	}{
+		{"vendor given", redfishServiceRoot{Vendor: "Lenovo"}, "Lenovo"},
+		{"known oem", redfishServiceRoot{Oem: map[string]json.RawMessage{"Contoso": nil, "Dell": nil}}, "Dell"},
+		{"unknown oem", redfishServiceRoot{Oem: map[string]json.RawMessage{"Contoso": nil}}, "Contoso"},
+		{"no vendor", redfishServiceRoot{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getServiceRootVendor(tt.serviceRoot); got != tt.want {
+				t.Errorf("getServiceRootVendor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExternalInterface_DiscoverBMCs(t *testing.T) {
+	config.SetUpMockConfig(t)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/redfish/v1/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"RedfishVersion":"1.11.0","Vendor":"Dell","Product":"Integrated Dell Remote Access Controller","UUID":"3256444f-c0c7-3080-5810-00374c4c4544"}`))
+	}))
+	serverURL, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(serverURL.Port())
+	conf := config.BMCDiscoveryConf{
+		Enabled:             true,
+		Subnets:             []string{"127.0.0.1/32"},
+		Ports:               []int{port},
+		ProbeTimeoutInSecs:  1,
+		MaxConcurrentProbes: 1,
+	}
+	store := &mockDiscoveredBMCStore{
+		bmcs:       make(map[string]agmodel.DiscoveredBMC),
+		aggregated: make(map[string]bool),
+	}
+	e := getMockDiscoveryInterface(store)
+	uri := getDiscoveredBMCURI("127.0.0.1", port)
+
+	if err := e.DiscoverBMCs(context.TODO(), conf); err != nil {
+		t.Fatalf("DiscoverBMCs() error = %v", err)
+	}
+	bmc, exist := store.bmcs[uri]
+	if !exist {
+		t.Fatalf("DiscoverBMCs() did not save the BMC at %s, saved %v", uri, store.bmcs)
+	}
+	if bmc.HostName != "127.0.0.1:"+serverURL.Port() || bmc.Vendor != "Dell" || bmc.PluginID != "DELL_v2.0.0" ||
+		bmc.ConnectionMethod != mockDellConnectionMethodURI || bmc.RedfishVersion != "1.11.0" {
+		t.Errorf("DiscoverBMCs() saved unexpected BMC %+v", bmc)
+	}
+
+	// first seen time is retained across the scans
+	bmc.FirstSeen = "2020-01-01T00:00:00Z"
+	store.bmcs[uri] = bmc
+	if err := e.DiscoverBMCs(context.TODO(), conf); err != nil {
+		t.Fatalf("DiscoverBMCs() error = %v", err)
+	}
+	if store.bmcs[uri].FirstSeen != "2020-01-01T00:00:00Z" {
+		t.Errorf("DiscoverBMCs() changed FirstSeen to %s", store.bmcs[uri].FirstSeen)
+	}
+
+	// aggregated BMCs are no longer listed
+	store.aggregated["127.0.0.1:"+serverURL.Port()] = true
+	if err := e.DiscoverBMCs(context.TODO(), conf); err != nil {
+		t.Fatalf("DiscoverBMCs() error = %v", err)
+	}
+	if _, exist := store.bmcs[uri]; exist {
+		t.Error("DiscoverBMCs() did not remove the aggregated BMC")
+	}
+
+	// BMCs not responding are removed
+	store.aggregated = make(map[string]bool)
+	if err := e.DiscoverBMCs(context.TODO(), conf); err != nil {
+		t.Fatalf("DiscoverBMCs() error = %v", err)
+	}
+	server.Close()
+	if err := e.DiscoverBMCs(context.TODO(), conf); err != nil {
+		t.Fatalf("DiscoverBMCs() error = %v", err)
+	}
+	if len(store.bmcs) != 0 {
+		t.Errorf("DiscoverBMCs() did not remove the BMC not responding, found %v", store.bmcs)
+	}
+
+	// BMCs of the subnets no longer configured are removed
+	staleURI := getDiscoveredBMCURI("10.0.0.1", port)
+	store.bmcs[staleURI] = agmodel.DiscoveredBMC{HostName: "10.0.0.1:" + serverURL.Port()}
+	if err := e.DiscoverBMCs(context.TODO(), conf); err != nil {
+		t.Fatalf("DiscoverBMCs() error = %v", err)
+	}
+	if _, exist := store.bmcs[staleURI]; exist {
+		t.Error("DiscoverBMCs() did not remove the BMC of a subnet no longer configured")
+	}
+
+	conf.Subnets = []string{"127.0.0.1"}
+	if err := e.DiscoverBMCs(context.TODO(), conf); err == nil {
+		t.Error("DiscoverBMCs() expected error for invalid subnet")
+	}
+}
+
+func TestExternalInterface_DiscoverBMCsNonRedfish(t *testing.T) {
+	config.SetUpMockConfig(t)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"Name":"not a redfish service"}`))
+	}))
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(serverURL.Port())
+	store := &mockDiscoveredBMCStore{
+		bmcs:       make(map[string]agmodel.DiscoveredBMC),
+		aggregated: make(map[string]bool),
+	}
+	e := getMockDiscoveryInterface(store)
+	conf := config.BMCDiscoveryConf{Subnets: []string{"127.0.0.1/32"}, Ports: []int{port}, ProbeTimeoutInSecs: 1}
+	if err := e.DiscoverBMCs(context.TODO(), conf); err != nil {
+		t.Fatalf("DiscoverBMCs() error = %v", err)
+	}
+	if len(store.bmcs) != 0 {
+		t.Errorf("DiscoverBMCs() saved %v for a service without Redfish service root", store.bmcs)
+	}
+}
+
+func TestExternalInterface_GetDiscoveredBMC(t *testing.T) {
+	config.SetUpMockConfig(t)
+	uri := getDiscoveredBMCURI("10.0.0.1", 443)
+	store := &mockDiscoveredBMCStore{
+		bmcs: map[string]agmodel.DiscoveredBMC{
+			uri: {HostName: "10.0.0.1", Vendor: "Dell", ConnectionMethod: mockDellConnectionMethodURI},
+		},
+	}
+	e := getMockDiscoveryInterface(store)
+
+	resp := e.GetAllDiscoveredBMCs(context.TODO())
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GetAllDiscoveredBMCs() status = %v", resp.StatusCode)
+	}
+	resp = e.GetDiscoveredBMC(context.TODO(), uri)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GetDiscoveredBMC() status = %v", resp.StatusCode)
+	}
+	resp = e.GetDiscoveredBMC(context.TODO(), discoveredBMCsURI+"/unknown")
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("GetDiscoveredBMC() status = %v, want %v", resp.StatusCode, http.StatusNotFound)
+	}
+}
+
+func TestExternalInterface_AdoptDiscoveredBMC(t *testing.T) {
+	config.SetUpMockConfig(t)
+	uri := getDiscoveredBMCURI("10.0.0.1", 443)
+	store := &mockDiscoveredBMCStore{
+		bmcs: map[string]agmodel.DiscoveredBMC{
+			uri:                                  {HostName: "10.0.0.1", Vendor: "Dell", ConnectionMethod: mockDellConnectionMethodURI},
+			getDiscoveredBMCURI("10.0.0.2", 443): {HostName: "10.0.0.2"},
+		},
+	}
+	e := getMockDiscoveryInterface(store)
+	tests := []struct {
+		name        string
+		url         string
+		body        string
+		wantStatus  int32
+		wantMessage string
+	}{
+		{"unknown discovered BMC", discoveredBMCsURI + "/unknown" + discoveredBMCAdoptURI, "", http.StatusNotFound, response.ResourceNotFound},
+		{"invalid request", uri + discoveredBMCAdoptURI, `{"UserName":`, http.StatusBadRequest, response.MalformedJSON},
+		{"unknown property", uri + discoveredBMCAdoptURI, `{"username":"admin"}`, http.StatusBadRequest, response.PropertyUnknown},
+		{"no connection method", getDiscoveredBMCURI("10.0.0.2", 443) + discoveredBMCAdoptURI, "", http.StatusBadRequest, response.PropertyMissing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &aggregatorproto.AggregatorRequest{URL: tt.url, RequestBody: []byte(tt.body)}
+			resp := e.AdoptDiscoveredBMC(context.TODO(), "someTaskID", "admin", req)
+			if resp.StatusCode != tt.wantStatus || resp.StatusMessage != tt.wantMessage {
+				t.Errorf("AdoptDiscoveredBMC() = %v %v, want %v %v", resp.StatusCode, resp.StatusMessage, tt.wantStatus, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestGetAdoptAggregationSource(t *testing.T) {
+	config.SetUpMockConfig(t)
+	bmc := agmodel.DiscoveredBMC{HostName: "10.0.0.1", ConnectionMethod: mockDellConnectionMethodURI}
+	taskInfo := &common.TaskUpdateInfo{Context: context.TODO(), TaskID: "someTaskID", UpdateTask: mockUpdateTask}
+
+	got, resp := getAdoptAggregationSource(bmc, AdoptDiscoveredBMCRequest{}, taskInfo)
+	if resp.StatusCode != 0 || got.UserName != "admin" || got.Password != "password" || got.Links.ConnectionMethod.OdataID != mockDellConnectionMethodURI {
+		t.Errorf("getAdoptAggregationSource() with defaults = %+v, %v", got, resp.StatusCode)
+	}
+
+	req := AdoptDiscoveredBMCRequest{
+		UserName: "root",
+		Password: "calvin",
+		Links:    &Links{ConnectionMethod: &ConnectionMethod{OdataID: mockGRFConnectionMethodURI}},
+	}
+	got, resp = getAdoptAggregationSource(bmc, req, taskInfo)
+	if resp.StatusCode != 0 || got.UserName != "root" || got.Password != "calvin" || got.Links.ConnectionMethod.OdataID != mockGRFConnectionMethodURI {
+		t.Errorf("getAdoptAggregationSource() with request = %+v, %v", got, resp.StatusCode)
+	}
+
+	config.Data.BMCDiscoveryConf.DefaultPassword = nil
+	_, resp = getAdoptAggregationSource(bmc, AdoptDiscoveredBMCRequest{}, taskInfo)
+	if resp.StatusCode != http.StatusBadRequest || resp.StatusMessage != response.PropertyMissing {
+		t.Errorf("getAdoptAggregationSource() without credentials = %v %v", resp.StatusCode, resp.StatusMessage)
+	}
+}
@@ -0,0 +1,95 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"context"
+	"fmt"
+
+	dmtfmodel "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+)
+
+// getRequestedEventDeliveryMode returns the event delivery mode given in the Oem block
+// of an aggregation source request, an empty string when it is not given
+func getRequestedEventDeliveryMode(oem *AggregationSourceOem) (string, error) {
+	if oem == nil || oem.Odim == nil || oem.Odim.EventDeliveryMode == "" {
+		return "", nil
+	}
+	switch oem.Odim.EventDeliveryMode {
+	case common.EventDeliveryModePush, common.EventDeliveryModePolling:
+		return oem.Odim.EventDeliveryMode, nil
+	}
+	return "", fmt.Errorf("error: EventDeliveryMode %s is not one of %s, %s", oem.Odim.EventDeliveryMode,
+		common.EventDeliveryModePush, common.EventDeliveryModePolling)
+}
+
+// getBMCAggregationSourceOem returns the Oem block of the aggregation source of a BMC
+// having the given event delivery mode and certificate trust
+func getBMCAggregationSourceOem(eventDeliveryMode string, trust *common.CertificateTrust) *dmtfmodel.Oem {
+	if eventDeliveryMode == "" && trust == nil {
+		return nil
+	}
+	odim := &AggregationSourceOdim{
+		EventDeliveryMode: eventDeliveryMode,
+	}
+	if trust != nil {
+		odim.CertificateTrustMode = trust.Mode
+		odim.CertificateFingerprint = trust.Fingerprint
+	}
+	var oem dmtfmodel.Oem = AggregationSourceOem{
+		Odim: odim,
+	}
+	return &oem
+}
+
+// resyncEventDelivery asks the plugin to apply the event delivery mode of the server, the plugin
+// replaces the event subscription on the server with the polling of the server, or the reverse
+func resyncEventDelivery(ctx context.Context, pluginID, targetUUID string) {
+	target, err := agmodel.GetTarget(targetUUID)
+	if err != nil || target == nil {
+		l.LogWithFields(ctx).Error("failed to get the details of the server " + targetUUID + " to apply its event delivery mode")
+		return
+	}
+	password, perr := DecryptWithPrivateKey(target.Password)
+	if perr != nil {
+		l.LogWithFields(ctx).Error("failed to decrypt the password of the server " + target.ManagerAddress + ": " + perr.Error())
+		return
+	}
+	target.Password = password
+	dbPluginConn := agmodel.DBPluginDataRead{
+		DBReadclient: agmodel.GetPluginDBConnection,
+	}
+	plugin, errs := agmodel.GetPluginData(pluginID, dbPluginConn)
+	if errs != nil {
+		l.LogWithFields(ctx).Error("failed to get the details of plugin " + pluginID + ": " + errs.Error())
+		return
+	}
+	plugin = getPluginInstance(plugin)
+	startUpData := agmodel.PluginStartUpData{
+		RequestType:           "full",
+		ResyncEvtSubscription: true,
+		Devices: map[string]agmodel.DeviceData{
+			target.DeviceUUID: getDeviceStartUpData(ctx, *target),
+		},
+	}
+	if err := syncPluginSubscriptions(ctx, plugin, startUpData, plugin.IP); err != nil {
+		l.LogWithFields(ctx).Error("failed to apply the event delivery mode of the server " + target.ManagerAddress + ": " + err.Error())
+		return
+	}
+	l.LogWithFields(ctx).Info("event delivery mode of the server " + target.ManagerAddress + " is applied by plugin " + pluginID)
+}
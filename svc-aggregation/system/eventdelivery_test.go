@@ -0,0 +1,66 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+)
+
+func TestGetRequestedEventDeliveryMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		oem     *AggregationSourceOem
+		want    string
+		wantErr bool
+	}{
+		{name: "no Oem block", oem: nil, want: ""},
+		{name: "mode not given", oem: &AggregationSourceOem{Odim: &AggregationSourceOdim{}}, want: ""},
+		{name: "polling", oem: &AggregationSourceOem{Odim: &AggregationSourceOdim{EventDeliveryMode: "Polling"}}, want: common.EventDeliveryModePolling},
+		{name: "push", oem: &AggregationSourceOem{Odim: &AggregationSourceOdim{EventDeliveryMode: "Push"}}, want: common.EventDeliveryModePush},
+		{name: "invalid mode", oem: &AggregationSourceOem{Odim: &AggregationSourceOdim{EventDeliveryMode: "polling"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getRequestedEventDeliveryMode(tt.oem)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getRequestedEventDeliveryMode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("getRequestedEventDeliveryMode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetBMCAggregationSourceOem(t *testing.T) {
+	if oem := getBMCAggregationSourceOem("", nil); oem != nil {
+		t.Errorf("getBMCAggregationSourceOem() = %v, want nil", *oem)
+	}
+	oem := getBMCAggregationSourceOem(common.EventDeliveryModePolling, nil)
+	if oem == nil {
+		t.Fatal("getBMCAggregationSourceOem() = nil")
+	}
+	want := AggregationSourceOem{Odim: &AggregationSourceOdim{EventDeliveryMode: common.EventDeliveryModePolling}}
+	if !reflect.DeepEqual(*oem, want) {
+		t.Errorf("getBMCAggregationSourceOem() = %v, want %v", *oem, want)
+	}
+	// the event delivery mode alone is not a request to change the plugin instances
+	if _, requested := getRequestedPluginInstances(&want); requested {
+		t.Error("getRequestedPluginInstances() = true, want false")
+	}
+}
@@ -112,6 +112,7 @@ func (e *ExternalInterface) GetAggregationSource(ctx context.Context, reqURI str
 		HostName: aggregationSource.HostName,
 		UserName: aggregationSource.UserName,
 		Links:    aggregationSource.Links,
+		Oem:      aggregationSource.Oem,
 	}
 	return resp
 }
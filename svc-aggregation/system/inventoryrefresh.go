@@ -0,0 +1,307 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agcommon"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/google/uuid"
+)
+
+const (
+	// InventoryRefreshActionID action id for logging
+	InventoryRefreshActionID = "236"
+	// InventoryRefreshActionName action name for logging
+	InventoryRefreshActionName = "InventoryRefresh"
+
+	inventoryRefreshOperation = "InventoryRefresh"
+)
+
+// inventoryRefreshScheduler keeps track of the refresh of the resource classes
+type inventoryRefreshScheduler struct {
+	lock        sync.Mutex
+	lastRefresh map[string]time.Time
+	running     map[string]bool
+}
+
+// inventoryRefreshResource is a resource read again from the plugin during an inventory refresh
+type inventoryRefreshResource struct {
+	table string // table of the stored resource, empty for a resource not stored yet
+	uri   string
+}
+
+// PerformInventoryRefresh refreshes the configured classes of resources of all the
+// servers, each class at its own interval. The configuration is read every minute,
+// so that the changes done to it take effect without restarting the service.
+func (e *ExternalInterface) PerformInventoryRefresh() {
+	transactionID := uuid.New()
+	ctx := agcommon.CreateContext(transactionID.String(), InventoryRefreshActionID, InventoryRefreshActionName, "1", common.AggregationService, podName)
+	l.LogWithFields(ctx).Info("inventory refresh routine started")
+	scheduler := &inventoryRefreshScheduler{
+		lastRefresh: make(map[string]time.Time),
+		running:     make(map[string]bool),
+	}
+	for {
+		if conf := config.Data.InventoryRefreshConf; conf != nil && conf.Enabled {
+			for _, class := range scheduler.getDueClasses(conf.ResourceClasses, time.Now()) {
+				go func(class string) {
+					defer scheduler.done(class)
+					e.RefreshInventory(ctx, class)
+				}(class)
+			}
+		}
+		time.Sleep(time.Minute)
+	}
+}
+
+// getDueClasses returns the resource classes whose interval elapsed since their last refresh.
+// The first refresh of a class is done one interval after it is seen, as the inventory is
+// read from the servers when they are added, and a class is not refreshed again while its
+// previous refresh is still running.
+func (s *inventoryRefreshScheduler) getDueClasses(classes []config.InventoryRefreshClass, now time.Time) []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var due []string
+	for _, class := range classes {
+		lastRefresh, exist := s.lastRefresh[class.Name]
+		if !exist {
+			s.lastRefresh[class.Name] = now
+			continue
+		}
+		if s.running[class.Name] || now.Sub(lastRefresh) < time.Duration(class.IntervalInMins)*time.Minute {
+			continue
+		}
+		s.lastRefresh[class.Name] = now
+		s.running[class.Name] = true
+		due = append(due, class.Name)
+	}
+	return due
+}
+
+// done marks the refresh of the resource class as completed
+func (s *inventoryRefreshScheduler) done(class string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.running, class)
+}
+
+// RefreshInventory reads the resources of the class of all the servers again from their plugins
+// and updates the inventory with the resources added, changed or removed. The servers are
+// refreshed in batches of ServerRediscoveryBatchSize.
+func (e *ExternalInterface) RefreshInventory(ctx context.Context, class string) {
+	targets, err := agmodel.GetAllSystems()
+	if err != nil {
+		l.LogWithFields(ctx).Error("unable to get the servers for refreshing the " + class + " resources: " + err.Error())
+		return
+	}
+	batchSize := config.Data.ServerRediscoveryBatchSize
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+	l.LogWithFields(ctx).Infof("refreshing the %s resources of %d servers", class, len(targets))
+	var wg sync.WaitGroup
+	semaphoreChan := make(chan int, batchSize)
+	for _, target := range targets {
+		semaphoreChan <- 1
+		wg.Add(1)
+		go func(target agmodel.Target) {
+			defer func() {
+				<-semaphoreChan
+				wg.Done()
+			}()
+			if err := e.refreshTargetInventory(ctx, target, class); err != nil {
+				l.LogWithFields(ctx).Error("unable to refresh the " + class + " resources of the BMC with ID " +
+					target.DeviceUUID + ": " + err.Error())
+			}
+		}(target)
+	}
+	wg.Wait()
+	l.LogWithFields(ctx).Info("refresh of the " + class + " resources is completed")
+}
+
+// refreshTargetInventory reads the stored resources of the class of the server again from the plugin
+func (e *ExternalInterface) refreshTargetInventory(ctx context.Context, target agmodel.Target, class string) error {
+	keys, dbErr := agmodel.GetAllMatchingDetails("*", target.DeviceUUID, common.InMemory)
+	if dbErr != nil {
+		return dbErr
+	}
+	var resources []inventoryRefreshResource
+	var systems []string
+	for _, key := range keys {
+		switch table := getInventoryKeyTable(key); table {
+		case "SystemOperation", common.LastFetchedTable, "SystemReset":
+			continue
+		case "ComputerSystem":
+			systems = append(systems, getInventoryKeyURI(key))
+			fallthrough
+		default:
+			if isResourceOfClass(getInventoryKeyURI(key), class) {
+				resources = append(resources, inventoryRefreshResource{table: table, uri: getInventoryKeyURI(key)})
+			}
+		}
+	}
+	if len(resources) == 0 {
+		return nil
+	}
+	// block the delete and the rediscovery of the servers while they are refreshed
+	if err := acquireSystemOperations(ctx, systems, inventoryRefreshOperation); err != nil {
+		if _, ok := err.(operationInProgressError); ok {
+			l.LogWithFields(ctx).Debug("skipping refresh of the BMC with ID " + target.DeviceUUID + ": " + err.Error())
+			return nil
+		}
+		return err
+	}
+	defer releaseSystemOperations(ctx, systems)
+	var storedKeys []string
+	for _, resource := range resources {
+		storedKeys = append(storedKeys, resource.table+":"+resource.uri)
+	}
+	stored, err := readInventoryVersions(storedKeys)
+	if err != nil {
+		return err
+	}
+	req, err := e.getTargetResourceRequest(ctx, target)
+	if err != nil {
+		return err
+	}
+
+	h := respHolder{
+		TraversedLinks: make(map[string]bool),
+		InventoryData:  make(map[string]interface{}),
+	}
+	failed := h.refreshResources(ctx, req, resources)
+	var scope []string
+	for key := range h.InventoryData {
+		scope = append(scope, getInventoryKeyURI(key))
+	}
+	for _, resource := range resources {
+		scope = append(scope, resource.uri)
+	}
+	h.Resync = newInventoryResync(target.DeviceUUID, scope, stored)
+	for _, oid := range failed {
+		h.Resync.fetchFailed(oid)
+	}
+	if err := h.saveInventory(h.InventoryData); err != nil {
+		return err
+	}
+	e.completeInventoryResync(ctx, h.Resync, "")
+	return nil
+}
+
+// refreshResources reads the resources from the plugin into the inventory data and returns the
+// southbound URIs which could not be read. Collections are read before their members, so that
+// the members not stored yet are read as well and the stored members which are not listed by
+// the collection anymore are not read, which leaves them to be removed.
+func (h *respHolder) refreshResources(ctx context.Context, req getResourceRequest, resources []inventoryRefreshResource) []string {
+	sort.Slice(resources, func(i, j int) bool {
+		depthI, depthJ := strings.Count(resources[i].uri, "/"), strings.Count(resources[j].uri, "/")
+		if depthI != depthJ {
+			return depthI < depthJ
+		}
+		return resources[i].uri < resources[j].uri
+	})
+	known := make(map[string]bool, len(resources))
+	for _, resource := range resources {
+		known[resource.uri] = true
+	}
+	var failed, unlisted []string
+	for i := 0; i < len(resources); i++ {
+		resource := resources[i]
+		if isUnderAnyURI(resource.uri, unlisted) {
+			continue
+		}
+		req.OID = strings.Replace(resource.uri, req.DeviceUUID+".", "", -1)
+		body, _, _, err := contactPlugin(ctx, req, "error while trying to get the "+req.OID+" details: ")
+		if err != nil {
+			l.LogWithFields(ctx).Error(err.Error())
+			failed = append(failed, req.OID)
+			continue
+		}
+		updatedResourceData := updateResourceDataWithUUID(string(body), req.DeviceUUID)
+		var resourceData map[string]interface{}
+		if err := json.Unmarshal([]byte(updatedResourceData), &resourceData); err != nil {
+			l.LogWithFields(ctx).Error("error while trying to unmarshal " + req.OID + ": " + err.Error())
+			failed = append(failed, req.OID)
+			continue
+		}
+		members, memberFlag := resourceData["Members"].([]interface{})
+		table := resource.table
+		if table == "" {
+			table = getResourceName(req.OID, memberFlag)
+		}
+		h.InventoryData[table+":"+resource.uri] = updatedResourceData
+		if !memberFlag {
+			continue
+		}
+		listed := make(map[string]bool, len(members))
+		for _, member := range members {
+			link, ok := member.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			memberURI, _ := link["@odata.id"].(string)
+			memberURI = strings.TrimSuffix(memberURI, "/")
+			if memberURI == "" {
+				continue
+			}
+			listed[memberURI] = true
+			if !known[memberURI] {
+				known[memberURI] = true
+				resources = append(resources, inventoryRefreshResource{uri: memberURI})
+			}
+		}
+		for uri := range known {
+			if isMemberURI(uri, resource.uri) && !listed[uri] {
+				unlisted = append(unlisted, uri)
+			}
+		}
+	}
+	return failed
+}
+
+// isResourceOfClass checks whether any segment of the URI is the name of the resource class
+func isResourceOfClass(uri, class string) bool {
+	for _, segment := range strings.Split(uri, "/") {
+		if segment == class {
+			return true
+		}
+	}
+	return false
+}
+
+// isMemberURI checks whether the uri is a direct subordinate of the collection
+func isMemberURI(uri, collectionURI string) bool {
+	return strings.HasPrefix(uri, collectionURI+"/") && !strings.Contains(strings.TrimPrefix(uri, collectionURI+"/"), "/")
+}
+
+// isUnderAnyURI checks whether the uri is under any of the prefixes
+func isUnderAnyURI(uri string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if isUnderURI(uri, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,165 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+)
+
+func TestInventoryRefreshScheduler_getDueClasses(t *testing.T) {
+	s := &inventoryRefreshScheduler{
+		lastRefresh: make(map[string]time.Time),
+		running:     make(map[string]bool),
+	}
+	classes := []config.InventoryRefreshClass{
+		{Name: "Sensors", IntervalInMins: 5},
+		{Name: "FirmwareInventory", IntervalInMins: 1440},
+	}
+	start := time.Now()
+	if due := s.getDueClasses(classes, start); len(due) != 0 {
+		t.Errorf("getDueClasses() = %v, want no class to be refreshed when first seen", due)
+	}
+	if due := s.getDueClasses(classes, start.Add(4*time.Minute)); len(due) != 0 {
+		t.Errorf("getDueClasses() = %v, want no class to be refreshed before its interval", due)
+	}
+	if due := s.getDueClasses(classes, start.Add(5*time.Minute)); !reflect.DeepEqual(due, []string{"Sensors"}) {
+		t.Errorf("getDueClasses() = %v, want [Sensors]", due)
+	}
+	if due := s.getDueClasses(classes, start.Add(10*time.Minute)); len(due) != 0 {
+		t.Errorf("getDueClasses() = %v, want no class to be refreshed while its refresh is running", due)
+	}
+	s.done("Sensors")
+	if due := s.getDueClasses(classes, start.Add(11*time.Minute)); !reflect.DeepEqual(due, []string{"Sensors"}) {
+		t.Errorf("getDueClasses() = %v, want [Sensors] once its previous refresh is done", due)
+	}
+}
+
+func TestIsResourceOfClass(t *testing.T) {
+	tests := []struct {
+		uri   string
+		class string
+		want  bool
+	}{
+		{"/redfish/v1/Chassis/uuid.1/Sensors", "Sensors", true},
+		{"/redfish/v1/Chassis/uuid.1/Sensors/Temp1", "Sensors", true},
+		{"/redfish/v1/UpdateService/FirmwareInventory/uuid.BMC", "FirmwareInventory", true},
+		{"/redfish/v1/Chassis/uuid.1/SensorsExtra", "Sensors", false},
+		{"/redfish/v1/Systems/uuid.1/Memory/1", "Sensors", false},
+	}
+	for _, tt := range tests {
+		if got := isResourceOfClass(tt.uri, tt.class); got != tt.want {
+			t.Errorf("isResourceOfClass(%v, %v) = %v, want %v", tt.uri, tt.class, got, tt.want)
+		}
+	}
+}
+
+func TestGetLastFetchedData(t *testing.T) {
+	fetchedAt := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+	data := map[string]interface{}{
+		"Memory:/redfish/v1/Systems/uuid.1/Memory/1": `{"Id":"1"}`,
+		"Registries:Base.1.0.0.json":                 `{"Id":"Base.1.0.0"}`,
+	}
+	want := map[string]interface{}{
+		common.LastFetchedTable + ":/redfish/v1/Systems/uuid.1/Memory/1": "2023-03-01T10:00:00Z",
+	}
+	if got := getLastFetchedData(data, fetchedAt); !reflect.DeepEqual(got, want) {
+		t.Errorf("getLastFetchedData() = %v, want %v", got, want)
+	}
+}
+
+func mockContactClientForRefresh(responses map[string]string) func(context.Context, string, string, string, string, interface{}, map[string]string) (*http.Response, error) {
+	return func(ctx context.Context, url, method, token string, odataID string, body interface{}, credentials map[string]string) (*http.Response, error) {
+		for oid, data := range responses {
+			if strings.HasSuffix(url, oid) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(bytes.NewBufferString(data)),
+				}, nil
+			}
+		}
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       ioutil.NopCloser(bytes.NewBufferString("not found")),
+		}, nil
+	}
+}
+
+func TestRespHolder_refreshResources(t *testing.T) {
+	config.SetUpMockConfig(t)
+	deviceUUID := "6d4a0a66-7efa-578e-83cf-44dc68d2874e"
+	chassisURI := "/redfish/v1/Chassis/" + deviceUUID + ".1"
+	req := getResourceRequest{
+		ContactClient: mockContactClientForRefresh(map[string]string{
+			"/Chassis/1/Sensors":       `{"@odata.id":"/redfish/v1/Chassis/1/Sensors","Members":[{"@odata.id":"/redfish/v1/Chassis/1/Sensors/Temp1"},{"@odata.id":"/redfish/v1/Chassis/1/Sensors/Temp3"}]}`,
+			"/Chassis/1/Sensors/Temp1": `{"@odata.id":"/redfish/v1/Chassis/1/Sensors/Temp1","Reading":40}`,
+			"/Chassis/1/Sensors/Temp3": `{"@odata.id":"/redfish/v1/Chassis/1/Sensors/Temp3","Reading":30}`,
+		}),
+		Plugin: agmodel.Plugin{
+			IP:                "localhost",
+			Port:              "9091",
+			PreferredAuthType: "BasicAuth",
+		},
+		DeviceUUID:     deviceUUID,
+		HTTPMethodType: http.MethodGet,
+	}
+	h := respHolder{
+		TraversedLinks: make(map[string]bool),
+		InventoryData:  make(map[string]interface{}),
+	}
+	resources := []inventoryRefreshResource{
+		{table: "Sensors", uri: chassisURI + "/Sensors/Temp1"},
+		{table: "Sensors", uri: chassisURI + "/Sensors/Temp2"},
+		{table: "Sensors", uri: chassisURI + "/Sensors/Temp2/Thresholds"},
+		{table: "SensorsCollection", uri: chassisURI + "/Sensors"},
+		{table: "Sensors", uri: chassisURI + "/Sensors/Temp4"},
+	}
+	failed := h.refreshResources(mockContext(), req, resources)
+	if len(failed) != 0 {
+		t.Errorf("refreshResources() failed = %v, want none", failed)
+	}
+	var keys []string
+	for key := range h.InventoryData {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	want := []string{
+		"Sensors:" + chassisURI + "/Sensors/Temp1",
+		"Sensors:" + chassisURI + "/Sensors/Temp3",
+		"SensorsCollection:" + chassisURI + "/Sensors",
+	}
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("refreshResources() read %v, want %v", keys, want)
+	}
+	if data := h.InventoryData["Sensors:"+chassisURI+"/Sensors/Temp1"].(string); !strings.Contains(data, chassisURI) {
+		t.Errorf("refreshResources() did not update the URIs of the resource: %v", data)
+	}
+
+	failed = h.refreshResources(mockContext(), req, []inventoryRefreshResource{{table: "Sensors", uri: chassisURI + "/Sensors/Temp5"}})
+	if !reflect.DeepEqual(failed, []string{"/redfish/v1/Chassis/1/Sensors/Temp5"}) {
+		t.Errorf("refreshResources() failed = %v, want the resource not found", failed)
+	}
+}
@@ -0,0 +1,316 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmessagebus"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+)
+
+// inventoryReadBatchSize is the number of resources read from DB in one request
+// while loading the stored inventory of a server
+const inventoryReadBatchSize = 500
+
+// inventoryResync holds the state of an incremental rediscovery of a server.
+// The resources fetched from the plugin are compared with the stored ones, so that
+// only the added and changed resources are written and the resources which are
+// not present on the server anymore are removed, without changing the URIs of
+// the resources which are still present.
+type inventoryResync struct {
+	lock       sync.Mutex
+	deviceUUID string
+	// scope is the list of northbound URIs under which the resources are rediscovered
+	scope []string
+	// stored is the version of the stored resources in scope, keyed by the DB key
+	stored  map[string]string
+	fetched map[string]bool
+	// failed is the list of southbound URIs which could not be fetched from the plugin,
+	// resources under them are never considered removed
+	failed  []string
+	added   []string
+	updated []string
+}
+
+// newInventoryResync returns the state of an incremental rediscovery for the resources in scope,
+// stored is the version of the stored resources keyed by the DB key
+func newInventoryResync(deviceUUID string, scope []string, stored map[string]string) *inventoryResync {
+	r := &inventoryResync{
+		deviceUUID: deviceUUID,
+		scope:      scope,
+		stored:     make(map[string]string),
+		fetched:    make(map[string]bool),
+	}
+	for key, version := range stored {
+		if r.inScope(key) {
+			r.stored[key] = version
+		}
+	}
+	return r
+}
+
+// getInventoryResyncScope returns the northbound URIs under which the resources of
+// the system are rediscovered, the system itself with the chassis and managers of the server
+func getInventoryResyncScope(deviceUUID, systemURI string) []string {
+	return []string{
+		systemURI,
+		"/redfish/v1/Chassis/" + deviceUUID + ".",
+		"/redfish/v1/Managers/" + deviceUUID + ".",
+	}
+}
+
+// loadInventoryResync reads the stored resources of the server in scope and
+// returns the state of an incremental rediscovery
+func loadInventoryResync(ctx context.Context, deviceUUID string, scope []string) (*inventoryResync, error) {
+	r := newInventoryResync(deviceUUID, scope, nil)
+	keys, dbErr := agmodel.GetAllMatchingDetails("*", deviceUUID, common.InMemory)
+	if dbErr != nil {
+		return nil, dbErr
+	}
+	var inScope []string
+	for _, key := range keys {
+		if r.inScope(key) {
+			inScope = append(inScope, key)
+		}
+	}
+	var err error
+	if r.stored, err = readInventoryVersions(inScope); err != nil {
+		return nil, err
+	}
+	l.LogWithFields(ctx).Debugf("loaded %d stored resources of the BMC with ID %s for rediscovery", len(r.stored), deviceUUID)
+	return r, nil
+}
+
+// readInventoryVersions reads the stored resources of the keys and returns their versions keyed by the DB key
+func readInventoryVersions(keys []string) (map[string]string, error) {
+	versions := make(map[string]string, len(keys))
+	for start := 0; start < len(keys); start += inventoryReadBatchSize {
+		end := start + inventoryReadBatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		values, err := agmodel.ReadMultipleKeys(keys[start:end], common.InMemory)
+		if err != nil {
+			return nil, err
+		}
+		for i, value := range values {
+			if value == "" {
+				continue
+			}
+			versions[keys[start+i]] = getResourceVersion(getStoredResource(value))
+		}
+	}
+	return versions, nil
+}
+
+// getStoredResource returns the resource data from the value stored in DB,
+// resources are saved as json encoded strings
+func getStoredResource(value string) string {
+	var data string
+	if err := json.Unmarshal([]byte(value), &data); err != nil {
+		return value
+	}
+	return data
+}
+
+// getResourceVersion returns the version of the resource data, which is the
+// @odata.etag of the resource when present, otherwise a hash of the resource
+func getResourceVersion(data string) string {
+	var resource map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &resource); err != nil {
+		sum := sha256.Sum256([]byte(data))
+		return hex.EncodeToString(sum[:])
+	}
+	if etag, ok := resource["@odata.etag"].(string); ok && etag != "" {
+		return etag
+	}
+	// marshaling the map again sorts the properties, so the hash does not
+	// depend on the order in which the BMC returns them
+	canonical, _ := json.Marshal(resource)
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:])
+}
+
+// inScope checks whether the DB key is of a resource under the URIs rediscovered
+func (r *inventoryResync) inScope(key string) bool {
+	if getInventoryKeyTable(key) == common.LastFetchedTable {
+		return false
+	}
+	uri := getInventoryKeyURI(key)
+	for _, prefix := range r.scope {
+		if isUnderURI(uri, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchFailed records the southbound URI which could not be fetched from the plugin
+func (r *inventoryResync) fetchFailed(oid string) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.failed = append(r.failed, strings.TrimSuffix(oid, "/"))
+}
+
+// getChangedResources records the resources fetched and returns the
+// resources which are new or changed since they were stored
+func (r *inventoryResync) getChangedResources(data map[string]interface{}) map[string]interface{} {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	changed := make(map[string]interface{})
+	for key, value := range data {
+		r.fetched[key] = true
+		if !r.inScope(key) {
+			changed[key] = value
+			continue
+		}
+		resource, ok := value.(string)
+		if !ok {
+			body, _ := json.Marshal(value)
+			resource = string(body)
+		}
+		version := getResourceVersion(resource)
+		storedVersion, exist := r.stored[key]
+		switch {
+		case !exist:
+			r.added = append(r.added, key)
+		case storedVersion != version:
+			r.updated = append(r.updated, key)
+		default:
+			continue
+		}
+		r.stored[key] = version
+		changed[key] = value
+	}
+	return changed
+}
+
+// getRemovedResources returns the DB keys of the stored resources which were not fetched
+// from the plugin, leaving out the resources not rediscovered and the ones under a URI
+// which could not be fetched
+func (r *inventoryResync) getRemovedResources() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var removed []string
+	for key := range r.stored {
+		if r.fetched[key] {
+			continue
+		}
+		switch getInventoryKeyTable(key) {
+		case "ComputerSystem", "SystemReset", "SystemOperation", "FirmwareInventory", "SoftwareInventory":
+			continue
+		}
+		southboundURI := strings.Replace(getInventoryKeyURI(key), r.deviceUUID+".", "", -1)
+		var underFailed bool
+		for _, oid := range r.failed {
+			if isUnderURI(southboundURI, oid) {
+				underFailed = true
+				break
+			}
+		}
+		if !underFailed {
+			removed = append(removed, key)
+		}
+	}
+	sort.Strings(removed)
+	return removed
+}
+
+// completeInventoryResync removes the resources which are not present on the server anymore
+// and publishes the events of the resources added, updated and removed, systemURI is the
+// system reported as updated when its inventory is populated again
+func (e *ExternalInterface) completeInventoryResync(ctx context.Context, r *inventoryResync, systemURI string) {
+	removed := r.getRemovedResources()
+	if len(removed) > 0 {
+		deleteKeys := append([]string{}, removed...)
+		for _, key := range removed {
+			deleteKeys = append(deleteKeys, common.LastFetchedTable+":"+getInventoryKeyURI(key))
+		}
+		if err := agmodel.DeleteMultipleKeys(deleteKeys, common.InMemory); err != nil {
+			l.LogWithFields(ctx).Error("error while deleting the removed resources of the BMC with ID " +
+				r.deviceUUID + ": " + err.Error())
+			removed = nil
+		}
+	}
+	l.LogWithFields(ctx).Infof("Resync of the BMC with ID %s found %d added, %d updated and %d removed resources",
+		r.deviceUUID, len(r.added), len(r.updated), len(removed))
+	if e.EventNotification == nil {
+		return
+	}
+	// when nothing of the server was stored, e.g. after the in-memory DB is lost,
+	// publishing every resource as added would flood the subscribers, so a single
+	// update event is published for the system
+	if len(r.stored) == len(r.added) && len(r.updated) == 0 && len(removed) == 0 {
+		if len(r.added) > 0 {
+			e.EventNotification(ctx, systemURI, "ResourceUpdated", "SystemsCollection", agmessagebus.InitMQSCom())
+		}
+		return
+	}
+	publish := func(keys []string, eventType string) {
+		sort.Strings(keys)
+		for _, key := range keys {
+			uri := getInventoryKeyURI(key)
+			e.EventNotification(ctx, uri, eventType, getInventoryCollectionType(uri), agmessagebus.InitMQSCom())
+		}
+	}
+	publish(r.added, "ResourceAdded")
+	publish(r.updated, "ResourceUpdated")
+	publish(removed, "ResourceRemoved")
+}
+
+// getInventoryCollectionType returns the collection type of the events published for the resource
+func getInventoryCollectionType(uri string) string {
+	switch {
+	case strings.HasPrefix(uri, "/redfish/v1/Chassis/"):
+		return "ChassisCollection"
+	case strings.HasPrefix(uri, "/redfish/v1/Managers/"):
+		return "ManagerCollection"
+	}
+	return "SystemsCollection"
+}
+
+// getInventoryKeyTable returns the table name of the inventory DB key
+func getInventoryKeyTable(key string) string {
+	if index := strings.Index(key, ":"); index >= 0 {
+		return key[:index]
+	}
+	return ""
+}
+
+// getInventoryKeyURI returns the URI of the resource from the inventory DB key
+func getInventoryKeyURI(key string) string {
+	return key[strings.Index(key, ":")+1:]
+}
+
+// isUnderURI checks whether the uri is the prefix itself or a subordinate of it,
+// a prefix ending with "." matches all the resources whose ID starts with it
+func isUnderURI(uri, prefix string) bool {
+	if strings.HasSuffix(prefix, ".") {
+		return strings.HasPrefix(uri, prefix)
+	}
+	return uri == prefix || strings.HasPrefix(uri, prefix+"/")
+}
@@ -0,0 +1,167 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmessagebus"
+)
+
+const mockResyncDeviceUUID = "6d4a0a66-7efa-578e-83cf-44dc68d2874e"
+
+func TestGetResourceVersion(t *testing.T) {
+	withEtag := `{"@odata.id":"/redfish/v1/Systems/1","@odata.etag":"W/\"1234\"","PowerState":"On"}`
+	if got := getResourceVersion(withEtag); got != `W/"1234"` {
+		t.Errorf("getResourceVersion() = %v, want the etag of the resource", got)
+	}
+	first := getResourceVersion(`{"Id":"1","PowerState":"On"}`)
+	reordered := getResourceVersion(`{"PowerState":"On", "Id":"1"}`)
+	changed := getResourceVersion(`{"Id":"1","PowerState":"Off"}`)
+	if first != reordered {
+		t.Errorf("getResourceVersion() depends on the order of the properties")
+	}
+	if first == changed {
+		t.Errorf("getResourceVersion() is the same for different resources")
+	}
+	if getResourceVersion("not json") == "" {
+		t.Errorf("getResourceVersion() is empty for invalid json")
+	}
+}
+
+func TestIsUnderURI(t *testing.T) {
+	tests := []struct {
+		uri    string
+		prefix string
+		want   bool
+	}{
+		{"/redfish/v1/Systems/uuid.1", "/redfish/v1/Systems/uuid.1", true},
+		{"/redfish/v1/Systems/uuid.1/Memory/1", "/redfish/v1/Systems/uuid.1", true},
+		{"/redfish/v1/Systems/uuid.10", "/redfish/v1/Systems/uuid.1", false},
+		{"/redfish/v1/Chassis/uuid.1/Thermal", "/redfish/v1/Chassis/uuid.", true},
+		{"/redfish/v1/Chassis/other.1", "/redfish/v1/Chassis/uuid.", false},
+	}
+	for _, tt := range tests {
+		if got := isUnderURI(tt.uri, tt.prefix); got != tt.want {
+			t.Errorf("isUnderURI(%v, %v) = %v, want %v", tt.uri, tt.prefix, got, tt.want)
+		}
+	}
+}
+
+func mockInventoryResync() *inventoryResync {
+	systemURI := "/redfish/v1/Systems/" + mockResyncDeviceUUID + ".1"
+	stored := map[string]string{
+		"ComputerSystem:" + systemURI:                                       getResourceVersion(`{"PowerState":"On"}`),
+		"Memory:" + systemURI + "/Memory/1":                                 getResourceVersion(`{"Id":"1"}`),
+		"Memory:" + systemURI + "/Memory/2":                                 getResourceVersion(`{"Id":"2"}`),
+		"Processors:" + systemURI + "/Processors/1":                         getResourceVersion(`{"Id":"1"}`),
+		"Processors:" + systemURI + "/Processors/2":                         getResourceVersion(`{"Id":"2"}`),
+		"SystemOperation:" + systemURI:                                      getResourceVersion(`{"Operation":"InventoryRediscovery"}`),
+		"Chassis:/redfish/v1/Chassis/" + mockResyncDeviceUUID + ".1":        getResourceVersion(`{"Id":"1"}`),
+		"Chassis:/redfish/v1/Chassis/" + mockResyncDeviceUUID + ".2":        getResourceVersion(`{"Id":"2"}`),
+		"ComputerSystem:/redfish/v1/Systems/" + mockResyncDeviceUUID + ".2": getResourceVersion(`{"Id":"2"}`),
+	}
+	return newInventoryResync(mockResyncDeviceUUID, getInventoryResyncScope(mockResyncDeviceUUID, systemURI), stored)
+}
+
+func TestInventoryResync(t *testing.T) {
+	systemURI := "/redfish/v1/Systems/" + mockResyncDeviceUUID + ".1"
+	r := mockInventoryResync()
+	if _, exist := r.stored["ComputerSystem:/redfish/v1/Systems/"+mockResyncDeviceUUID+".2"]; exist {
+		t.Errorf("newInventoryResync() kept a resource which is not rediscovered")
+	}
+	r.fetchFailed("/redfish/v1/Systems/1/Processors/")
+	fetched := map[string]interface{}{
+		"ComputerSystem:" + systemURI:                                `{"PowerState":"Off"}`,
+		"Memory:" + systemURI + "/Memory/1":                          `{"Id":"1"}`,
+		"Memory:" + systemURI + "/Memory/3":                          `{"Id":"3"}`,
+		"Chassis:/redfish/v1/Chassis/" + mockResyncDeviceUUID + ".1": `{"Id":"1"}`,
+		"Registries:Base.1.0.0.json":                                 `{"Id":"Base.1.0.0"}`,
+	}
+	changed := r.getChangedResources(fetched)
+	var changedKeys []string
+	for key := range changed {
+		changedKeys = append(changedKeys, key)
+	}
+	sort.Strings(changedKeys)
+	wantChanged := []string{
+		"ComputerSystem:" + systemURI,
+		"Memory:" + systemURI + "/Memory/3",
+		"Registries:Base.1.0.0.json",
+	}
+	if !reflect.DeepEqual(changedKeys, wantChanged) {
+		t.Errorf("getChangedResources() = %v, want %v", changedKeys, wantChanged)
+	}
+	if !reflect.DeepEqual(r.added, []string{"Memory:" + systemURI + "/Memory/3"}) {
+		t.Errorf("added resources = %v", r.added)
+	}
+	if !reflect.DeepEqual(r.updated, []string{"ComputerSystem:" + systemURI}) {
+		t.Errorf("updated resources = %v", r.updated)
+	}
+	// saving the same resources again must not report them twice
+	if changed := r.getChangedResources(fetched); len(changed) != 1 {
+		t.Errorf("getChangedResources() = %v, want only the resource not rediscovered", changed)
+	}
+
+	wantRemoved := []string{
+		"Chassis:/redfish/v1/Chassis/" + mockResyncDeviceUUID + ".2",
+		"Memory:" + systemURI + "/Memory/2",
+	}
+	if got := r.getRemovedResources(); !reflect.DeepEqual(got, wantRemoved) {
+		t.Errorf("getRemovedResources() = %v, want %v", got, wantRemoved)
+	}
+}
+
+func TestExternalInterface_completeInventoryResync(t *testing.T) {
+	systemURI := "/redfish/v1/Systems/" + mockResyncDeviceUUID + ".1"
+	var events []string
+	e := &ExternalInterface{
+		EventNotification: func(ctx context.Context, oid, eventType, collectionType string, MQ agmessagebus.MQBusCommunicator) error {
+			events = append(events, eventType+" "+collectionType+" "+oid)
+			return nil
+		},
+	}
+	ctx := mockContext()
+
+	r := newInventoryResync(mockResyncDeviceUUID, getInventoryResyncScope(mockResyncDeviceUUID, systemURI), nil)
+	r.getChangedResources(map[string]interface{}{
+		"ComputerSystem:" + systemURI:                                `{"Id":"1"}`,
+		"Chassis:/redfish/v1/Chassis/" + mockResyncDeviceUUID + ".1": `{"Id":"1"}`,
+	})
+	e.completeInventoryResync(ctx, r, systemURI)
+	if want := []string{"ResourceUpdated SystemsCollection " + systemURI}; !reflect.DeepEqual(events, want) {
+		t.Errorf("events on repopulating the inventory = %v, want %v", events, want)
+	}
+
+	events = nil
+	r = newInventoryResync(mockResyncDeviceUUID, getInventoryResyncScope(mockResyncDeviceUUID, systemURI), map[string]string{
+		"ComputerSystem:" + systemURI: getResourceVersion(`{"Id":"1"}`),
+	})
+	r.getChangedResources(map[string]interface{}{
+		"ComputerSystem:" + systemURI:                                `{"Id":"1","PowerState":"On"}`,
+		"Chassis:/redfish/v1/Chassis/" + mockResyncDeviceUUID + ".1": `{"Id":"1"}`,
+	})
+	e.completeInventoryResync(ctx, r, systemURI)
+	want := []string{
+		"ResourceAdded ChassisCollection /redfish/v1/Chassis/" + mockResyncDeviceUUID + ".1",
+		"ResourceUpdated SystemsCollection " + systemURI,
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("events on rediscovery = %v, want %v", events, want)
+	}
+}
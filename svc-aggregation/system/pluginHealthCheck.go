@@ -28,6 +28,7 @@ import (
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/lib-utilities/metrics"
 	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
 	"github.com/ODIM-Project/ODIM/svc-aggregation/agcommon"
@@ -47,6 +48,15 @@ var (
 	podName               = os.Getenv("POD_NAME")
 )
 
+var (
+	pluginUp = metrics.NewGaugeVec("odim_plugin_up",
+		"Result of the last health check of the plugin, 1 when the plugin is reachable", "plugin")
+	pluginHealthChecks = metrics.NewCounterVec("odim_plugin_health_checks_total",
+		"Number of plugin health checks performed, by result", "plugin", "result")
+	pluginConsecutiveFailures = metrics.NewGaugeVec("odim_plugin_health_check_consecutive_failures",
+		"Number of consecutive failed health checks of the plugin", "plugin")
+)
+
 const (
 	// PluginHealthCheckActionID action id for logging
 	PluginHealthCheckActionID = "216"
@@ -101,6 +111,7 @@ func PerformPluginHealthCheck() {
 	phc := agcommon.PluginHealthCheckInterface{
 		DecryptPassword: DecryptWithPrivateKey,
 	}
+	monitoredPlugins := make(map[string]bool)
 	for {
 		phc.DupPluginConf()
 		DBInterface := agcommon.DBDataInterface{
@@ -110,6 +121,7 @@ func PerformPluginHealthCheck() {
 		if pluginList, err := GetAllPluginfunc(ctx, DBInterface); err != nil {
 			l.LogWithFields(ctx).Error("failed to get list of all plugins:", err.Error())
 		} else {
+			monitoredPlugins = forgetRemovedPlugins(monitoredPlugins, pluginList)
 			for _, plugin := range pluginList {
 				threadID := 1
 				ctxt := context.WithValue(ctx, common.ThreadName, common.CheckPluginStatus)
@@ -123,7 +135,8 @@ func PerformPluginHealthCheck() {
 }
 
 func checkPluginStatus(ctx context.Context, phc *agcommon.PluginHealthCheckInterface, plugin agmodel.Plugin) {
-	active, topics := phc.GetPluginStatus(ctx, plugin)
+	active, topics, redistribute := checkPluginInstancesStatus(ctx, phc, plugin)
+	defer recordPluginStatus(plugin.ID, active)
 	if count, exist := GetPluginStatusRecord(plugin.ID); !exist {
 		agcommon.SetPluginStatusRecord(plugin.ID, 0)
 	} else {
@@ -131,19 +144,54 @@ func checkPluginStatus(ctx context.Context, phc *agcommon.PluginHealthCheckInter
 		case count != 0 && active:
 			agcommon.SetPluginStatusRecord(plugin.ID, 0)
 			if plugin.PluginType == "Compute" {
-				if err := sharePluginInventory(ctx, plugin, true, plugin.IP); err != nil {
+				if err := sharePluginInstancesInventory(ctx, plugin); err != nil {
 					l.LogWithFields(ctx).Error("failed to update server inventory of plugin " + plugin.ID + ": " + err.Error())
 					agcommon.SetPluginStatusRecord(plugin.ID, count+1)
 				}
 			}
 			PublishPluginStatusOKEvent(ctx, plugin.ID, topics)
 			l.LogWithFields(ctx).Infof("subscribing to %s message bus topics of plugin %s", topics, plugin.ID)
+		case active && redistribute && plugin.PluginType == "Compute":
+			// an instance of the plugin went up or down, so the servers are redistributed
+			// among the healthy instances along with their event subscriptions
+			if err := sharePluginInstancesInventory(ctx, plugin); err != nil {
+				l.LogWithFields(ctx).Error("failed to redistribute server inventory of plugin " + plugin.ID + ": " + err.Error())
+			}
 		case !active:
 			agcommon.SetPluginStatusRecord(plugin.ID, count+1)
 		}
 	}
 }
 
+// recordPluginStatus exports the result of the health check of a plugin
+func recordPluginStatus(pluginID string, active bool) {
+	result := "down"
+	pluginUp.WithLabelValues(pluginID).Set(0)
+	if active {
+		result = "up"
+		pluginUp.WithLabelValues(pluginID).Set(1)
+	}
+	pluginHealthChecks.WithLabelValues(pluginID, result).Inc()
+	count, _ := GetPluginStatusRecord(pluginID)
+	pluginConsecutiveFailures.WithLabelValues(pluginID).Set(float64(count))
+}
+
+// forgetRemovedPlugins drops the health check metrics of the plugins which are
+// no longer present and returns the set of plugins currently monitored
+func forgetRemovedPlugins(monitored map[string]bool, pluginList []agmodel.Plugin) map[string]bool {
+	current := make(map[string]bool, len(pluginList))
+	for _, plugin := range pluginList {
+		current[plugin.ID] = true
+	}
+	for pluginID := range monitored {
+		if !current[pluginID] {
+			pluginUp.DeleteLabelValues(pluginID)
+			pluginConsecutiveFailures.DeleteLabelValues(pluginID)
+		}
+	}
+	return current
+}
+
 // SendPluginStartUpData is for sending the plugin startup data
 // when the plugin requests through an event
 func SendPluginStartUpData(ctx context.Context, pluginIP string, plugin agmodel.Plugin) error {
@@ -165,6 +213,8 @@ func sharePluginInventory(ctx context.Context, plugin agmodel.Plugin, resyncSubs
 	}
 	phc.DupPluginConf()
 	managedServers := phc.GetPluginManagedServers(plugin)
+	// an instance of the plugin gets only the servers assigned to it
+	managedServers = getInstanceServers(plugin, common.PluginInstance{IP: plugin.IP, Port: plugin.Port}, managedServers)
 	managedServersCount := len(managedServers)
 	if managedServersCount == 0 {
 		l.LogWithFields(ctx).Info("plugin " + plugin.ID + " is not managing any server")
@@ -185,44 +235,76 @@ func sharePluginInventory(ctx context.Context, plugin agmodel.Plugin, resyncSubs
 		startIndex += phc.PluginConfig.StartUpResourceBatchSize
 		pluginStartUpData.Devices = make(map[string]agmodel.DeviceData, phc.PluginConfig.StartUpResourceBatchSize)
 		for _, server := range batchedServersData {
-			evtSubsInfo := &agmodel.EventSubscriptionInfo{}
-			subsID, evtTypes, err := agcommon.GetDeviceSubscriptionDetails(ctx, server.ManagerAddress)
-			if err != nil {
-				l.LogWithFields(ctx).Error("failed to get event subscription details for " + server.ManagerAddress + ": " + err.Error())
-			} else {
-				evtSubsInfo.Location = subsID
-				evtSubsInfo.EventTypes = append(evtSubsInfo.EventTypes, evtTypes...)
-			}
-			pluginStartUpData.Devices[server.DeviceUUID] = agmodel.DeviceData{
-				Address:               server.ManagerAddress,
-				UserName:              server.UserName,
-				Password:              server.Password,
-				Operation:             "add",
-				EventSubscriptionInfo: evtSubsInfo,
-			}
+			pluginStartUpData.Devices[server.DeviceUUID] = getDeviceStartUpData(ctx, server)
 		}
-		resp, err := sendPluginStartupRequest(ctx, plugin, pluginStartUpData, serverName)
-		if err != nil {
+		if err := syncPluginSubscriptions(ctx, plugin, pluginStartUpData, serverName); err != nil {
 			ret = fmt.Errorf("%v: %w", ret, err)
 			continue
 		}
-		body, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			ret = fmt.Errorf("%v: %w", ret, err)
-			continue
-		}
-		var subsData map[string]string
-		if err := json.Unmarshal(body, &subsData); err != nil {
-			ret = fmt.Errorf("%v: %w", ret, err)
-			continue
-		}
-		agcommon.UpdateDeviceSubscriptionDetails(ctx, subsData)
 		batchedServersData = nil
 	}
 	return
 }
 
+// getDeviceStartUpData returns the startup data of a server managed by a plugin,
+// along with the details of its event subscription
+func getDeviceStartUpData(ctx context.Context, server agmodel.Target) agmodel.DeviceData {
+	evtSubsInfo := &agmodel.EventSubscriptionInfo{}
+	subsID, evtTypes, err := agcommon.GetDeviceSubscriptionDetails(ctx, server.ManagerAddress)
+	if err != nil {
+		l.LogWithFields(ctx).Error("failed to get event subscription details for " + server.ManagerAddress + ": " + err.Error())
+	} else {
+		evtSubsInfo.Location = subsID
+		evtSubsInfo.EventTypes = append(evtSubsInfo.EventTypes, evtTypes...)
+	}
+	return agmodel.DeviceData{
+		Address:               server.ManagerAddress,
+		UserName:              server.UserName,
+		Password:              server.Password,
+		Operation:             "add",
+		EventSubscriptionInfo: evtSubsInfo,
+		EventDeliveryMode:     server.EventDeliveryMode,
+		CertificateTrust:      server.CertificateTrust,
+	}
+}
+
+// syncPluginSubscriptions sends the startup data to the plugin, and saves the
+// locations of the event subscriptions of the servers returned by the plugin
+func syncPluginSubscriptions(ctx context.Context, plugin agmodel.Plugin, startUpData agmodel.PluginStartUpData, serverName string) error {
+	resp, err := sendPluginStartupRequest(ctx, plugin, startUpData, serverName)
+	if err != nil {
+		return err
+	}
+	if resp == nil {
+		return fmt.Errorf("plugin %s did not accept the startup data", plugin.ID)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	var subsData map[string]string
+	if err := json.Unmarshal(body, &subsData); err != nil {
+		return err
+	}
+	agcommon.UpdateDeviceSubscriptionDetails(ctx, subsData)
+	return nil
+}
+
 func sendPluginInventoryUpdate(ctx context.Context, plugin agmodel.Plugin, startupData interface{}) error {
+	if len(plugin.Instances) != 0 {
+		// the update is sent to every instance of the plugin
+		var ret error
+		for _, instance := range plugin.Instances {
+			instancePlugin := plugin
+			instancePlugin.IP, instancePlugin.Port = instance.IP, instance.Port
+			instancePlugin.Instances = nil
+			if err := sendPluginInventoryUpdate(ctx, instancePlugin, startupData); err != nil {
+				ret = fmt.Errorf("%v: %w", ret, err)
+			}
+		}
+		return ret
+	}
 	if common.IsK8sDeployment() {
 		addrList, err := common.GetServiceEndpointAddresses(plugin.IP)
 		if err != nil {
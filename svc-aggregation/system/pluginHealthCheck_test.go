@@ -24,6 +24,7 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 	"github.com/ODIM-Project/ODIM/svc-aggregation/agcommon"
 	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -226,3 +227,14 @@ func TestSendPluginStartUpData(t *testing.T) {
 	assert.Nil(t, err, "There should be no error")
 
 }
+
+func Test_forgetRemovedPlugins(t *testing.T) {
+	recordPluginStatus("RemovedPlugin", false)
+	recordPluginStatus("ActivePlugin", true)
+	monitored := forgetRemovedPlugins(map[string]bool{"RemovedPlugin": true, "ActivePlugin": true},
+		[]agmodel.Plugin{{ID: "ActivePlugin"}})
+	assert.Equal(t, map[string]bool{"ActivePlugin": true}, monitored)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(pluginUp.WithLabelValues("ActivePlugin")))
+	assert.False(t, pluginUp.DeleteLabelValues("RemovedPlugin"), "Removed plugin should not be exposed")
+}
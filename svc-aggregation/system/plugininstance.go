@@ -0,0 +1,249 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"strings"
+	"sync"
+
+	dmtfmodel "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/lib-utilities/metrics"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agcommon"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+)
+
+var pluginInstanceUp = metrics.NewGaugeVec("odim_plugin_instance_up",
+	"Result of the last health check of the plugin instance, 1 when the instance is reachable", "plugin", "instance")
+
+// getPluginInstancesFromRequest validates the addresses of the additional instances of a plugin
+// given in the request, and returns all the instances of the plugin, the one at managerAddress first.
+// nil is returned when no additional instance is given.
+func getPluginInstancesFromRequest(addresses []string, managerAddress string) ([]common.PluginInstance, error) {
+	ip, port, err := net.SplitHostPort(managerAddress)
+	if err != nil {
+		return nil, fmt.Errorf("error: plugin address %s is not in host:port form", managerAddress)
+	}
+	instances := []common.PluginInstance{{IP: ip, Port: port}}
+	for _, address := range addresses {
+		ip, port, err := net.SplitHostPort(address)
+		if err != nil || ip == "" || port == "" {
+			return nil, fmt.Errorf("error: plugin instance %s is not in host:port form", address)
+		}
+		if err := validateManagerAddress(address); err != nil {
+			return nil, err
+		}
+		instance := common.PluginInstance{IP: ip, Port: port}
+		if !containsPluginInstance(instances, instance) {
+			instances = append(instances, instance)
+		}
+	}
+	if len(instances) == 1 {
+		return nil, nil
+	}
+	return instances, nil
+}
+
+// getAggregationSourceOem returns the Oem block of the aggregation source of a plugin
+// having the given instances, listing the instances other than the one at its HostName
+func getAggregationSourceOem(instances []common.PluginInstance) *dmtfmodel.Oem {
+	if len(instances) <= 1 {
+		return nil
+	}
+	var oem dmtfmodel.Oem = AggregationSourceOem{
+		Odim: &AggregationSourceOdim{
+			PluginInstances: getAdditionalInstanceAddresses(instances),
+		},
+	}
+	return &oem
+}
+
+// getAdditionalInstanceAddresses returns the addresses of the instances of a plugin
+// other than the first one, which is the instance at the address of the plugin
+func getAdditionalInstanceAddresses(instances []common.PluginInstance) []string {
+	addresses := []string{}
+	for i := 1; i < len(instances); i++ {
+		addresses = append(addresses, instances[i].Address())
+	}
+	return addresses
+}
+
+// isPluginInstanceRemoved returns true when an instance in previous is not present in current
+func isPluginInstanceRemoved(previous, current []common.PluginInstance) bool {
+	for _, instance := range previous {
+		if !containsPluginInstance(current, instance) {
+			return true
+		}
+	}
+	return false
+}
+
+// getRequestedPluginInstances returns the addresses of the additional plugin instances given in the
+// Oem block of an aggregation source request, and whether the block is present in the request
+func getRequestedPluginInstances(oem *AggregationSourceOem) ([]string, bool) {
+	if oem == nil || oem.Odim == nil || oem.Odim.PluginInstances == nil {
+		return nil, false
+	}
+	return oem.Odim.PluginInstances, true
+}
+
+// getPluginInstance returns the plugin with the address of the instance a request is to be sent to
+func getPluginInstance(plugin agmodel.Plugin) agmodel.Plugin {
+	if len(plugin.Instances) == 0 {
+		return plugin
+	}
+	instance := common.SelectPluginInstance(plugin.ID, plugin.PreferredAuthType, common.GetPluginInstances(plugin.IP, plugin.Port, plugin.Instances))
+	plugin.IP, plugin.Port = instance.IP, instance.Port
+	return plugin
+}
+
+// assignPluginInstance returns the instance owning the server at managerAddress among the given
+// instances. The instance scoring the highest for the server is picked, so that when an instance
+// is removed only its servers are spread over the others, and the other servers keep their instance.
+func assignPluginInstance(managerAddress string, instances []common.PluginInstance) common.PluginInstance {
+	var owner common.PluginInstance
+	var highest uint64
+	for i, instance := range instances {
+		hash := fnv.New64a()
+		hash.Write([]byte(managerAddress + "/" + instance.Address()))
+		if score := hash.Sum64(); i == 0 || score > highest {
+			owner, highest = instance, score
+		}
+	}
+	return owner
+}
+
+// getInstanceServers returns the servers of the plugin which are assigned to the instance.
+// The instances of a plugin having several of them are all listed in its Instances.
+// The servers are assigned among the healthy instances of the plugin, and all of them are
+// returned for a plugin having a single instance.
+func getInstanceServers(plugin agmodel.Plugin, instance common.PluginInstance, servers []agmodel.Target) []agmodel.Target {
+	if len(plugin.Instances) == 0 {
+		return servers
+	}
+	health, _ := common.GetPluginInstanceHealth(plugin.ID)
+	healthy := common.GetHealthyPluginInstances(plugin.Instances, health)
+	// the instance asking for its inventory is up, even if the health check did not see it yet
+	if !containsPluginInstance(healthy, instance) {
+		healthy = append(healthy, instance)
+	}
+	var assigned []agmodel.Target
+	for _, server := range servers {
+		if assignPluginInstance(server.ManagerAddress, healthy) == instance {
+			assigned = append(assigned, server)
+		}
+	}
+	return assigned
+}
+
+func containsPluginInstance(instances []common.PluginInstance, instance common.PluginInstance) bool {
+	for _, item := range instances {
+		if item == instance {
+			return true
+		}
+	}
+	return false
+}
+
+// checkPluginInstancesStatus checks the health of every instance of the plugin and returns whether any of
+// them is up, along with the message bus topics of the plugin. The health of the instances is saved for
+// the services to send their requests to a healthy instance. changed is true when an instance went up or
+// down, or was removed, since the previous check.
+func checkPluginInstancesStatus(ctx context.Context, phc *agcommon.PluginHealthCheckInterface, plugin agmodel.Plugin) (active bool, topics []string, changed bool) {
+	if len(plugin.Instances) == 0 {
+		active, topics = phc.GetPluginStatus(ctx, plugin)
+		return active, topics, false
+	}
+	instances := plugin.Instances
+	health := make(map[string]bool, len(instances))
+	instanceTopics := make(map[string][]string, len(instances))
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	for _, instance := range instances {
+		wg.Add(1)
+		go func(instance common.PluginInstance) {
+			defer wg.Done()
+			instancePlugin := plugin
+			instancePlugin.IP, instancePlugin.Port = instance.IP, instance.Port
+			up, topics := phc.GetPluginStatus(ctx, instancePlugin)
+			lock.Lock()
+			health[instance.Address()] = up
+			instanceTopics[instance.Address()] = topics
+			lock.Unlock()
+		}(instance)
+	}
+	wg.Wait()
+
+	for _, instance := range instances {
+		up := health[instance.Address()]
+		pluginInstanceUp.WithLabelValues(plugin.ID, instance.Address()).Set(0)
+		if up {
+			pluginInstanceUp.WithLabelValues(plugin.ID, instance.Address()).Set(1)
+			if !active {
+				active, topics = true, instanceTopics[instance.Address()]
+			}
+		}
+	}
+
+	previous, err := common.GetPluginInstanceHealth(plugin.ID)
+	if err == nil {
+		changed = isPluginInstanceHealthChanged(previous, health)
+		for address := range previous {
+			if _, exist := health[address]; !exist {
+				pluginInstanceUp.DeleteLabelValues(plugin.ID, address)
+			}
+		}
+	}
+	if err := common.SavePluginInstanceHealth(plugin.ID, health); err != nil {
+		l.LogWithFields(ctx).Error("failed to save the health of the instances of plugin " + plugin.ID + ": " + err.Error())
+	}
+	return active, topics, changed
+}
+
+// isPluginInstanceHealthChanged returns true when an instance went up or down, or was removed
+func isPluginInstanceHealthChanged(previous, current map[string]bool) bool {
+	for address, up := range previous {
+		if currentUp, exist := current[address]; !exist || currentUp != up {
+			return true
+		}
+	}
+	return false
+}
+
+// sharePluginInstancesInventory shares with every healthy instance of the plugin the servers
+// assigned to it, and makes the instances check the event subscriptions of those servers
+func sharePluginInstancesInventory(ctx context.Context, plugin agmodel.Plugin) error {
+	if len(plugin.Instances) == 0 {
+		return sharePluginInventory(ctx, plugin, true, plugin.IP)
+	}
+	health, _ := common.GetPluginInstanceHealth(plugin.ID)
+	var failures []string
+	for _, instance := range common.GetHealthyPluginInstances(plugin.Instances, health) {
+		instancePlugin := plugin
+		instancePlugin.IP, instancePlugin.Port = instance.IP, instance.Port
+		if err := sharePluginInventory(ctx, instancePlugin, true, instance.IP); err != nil {
+			failures = append(failures, instance.Address()+": "+err.Error())
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("unable to share the inventory with the plugin instances %s", strings.Join(failures, "; "))
+	}
+	return nil
+}
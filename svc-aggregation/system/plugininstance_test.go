@@ -0,0 +1,126 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+)
+
+func TestGetPluginInstancesFromRequest(t *testing.T) {
+	tests := []struct {
+		name      string
+		addresses []string
+		want      []common.PluginInstance
+		wantErr   bool
+	}{
+		{
+			name: "no additional instance",
+		},
+		{
+			name:      "additional instances",
+			addresses: []string{"10.0.0.2:45001", "10.0.0.1:45001", "10.0.0.2:45001"},
+			want:      []common.PluginInstance{{IP: "10.0.0.1", Port: "45001"}, {IP: "10.0.0.2", Port: "45001"}},
+		},
+		{
+			name:      "instance without port",
+			addresses: []string{"10.0.0.2"},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getPluginInstancesFromRequest(tt.addresses, "10.0.0.1:45001")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getPluginInstancesFromRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getPluginInstancesFromRequest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetAggregationSourceOem(t *testing.T) {
+	if oem := getAggregationSourceOem([]common.PluginInstance{{IP: "10.0.0.1", Port: "45001"}}); oem != nil {
+		t.Errorf("getAggregationSourceOem() = %v, want nil", *oem)
+	}
+	oem := getAggregationSourceOem([]common.PluginInstance{{IP: "10.0.0.1", Port: "45001"}, {IP: "10.0.0.2", Port: "45001"}})
+	if oem == nil {
+		t.Fatal("getAggregationSourceOem() = nil")
+	}
+	want := AggregationSourceOem{Odim: &AggregationSourceOdim{PluginInstances: []string{"10.0.0.2:45001"}}}
+	if !reflect.DeepEqual(*oem, want) {
+		t.Errorf("getAggregationSourceOem() = %v, want %v", *oem, want)
+	}
+}
+
+func TestAssignPluginInstance(t *testing.T) {
+	instances := []common.PluginInstance{{IP: "10.0.0.1", Port: "45001"}, {IP: "10.0.0.2", Port: "45001"}, {IP: "10.0.0.3", Port: "45001"}}
+	servers := []string{"10.1.0.1", "10.1.0.2", "10.1.0.3", "10.1.0.4", "10.1.0.5", "10.1.0.6", "10.1.0.7", "10.1.0.8"}
+	owners := make(map[string]common.PluginInstance, len(servers))
+	for _, server := range servers {
+		owners[server] = assignPluginInstance(server, instances)
+		if !containsPluginInstance(instances, owners[server]) {
+			t.Fatalf("assignPluginInstance() = %v, not one of the instances", owners[server])
+		}
+	}
+	// only the servers of the removed instance move to another instance
+	removed := instances[1]
+	for _, server := range servers {
+		owner := assignPluginInstance(server, []common.PluginInstance{instances[0], instances[2]})
+		if owners[server] != removed && owner != owners[server] {
+			t.Errorf("server %s moved from %v to %v", server, owners[server], owner)
+		}
+		if owner == removed {
+			t.Errorf("server %s assigned to the removed instance", server)
+		}
+	}
+}
+
+func TestGetInstanceServers(t *testing.T) {
+	servers := []agmodel.Target{{ManagerAddress: "10.1.0.1"}, {ManagerAddress: "10.1.0.2"}}
+	plugin := agmodel.Plugin{ID: "GRF", IP: "10.0.0.1", Port: "45001"}
+	got := getInstanceServers(plugin, common.PluginInstance{IP: "10.0.0.1", Port: "45001"}, servers)
+	if !reflect.DeepEqual(got, servers) {
+		t.Errorf("getInstanceServers() = %v, want %v", got, servers)
+	}
+}
+
+func TestIsPluginInstanceHealthChanged(t *testing.T) {
+	previous := map[string]bool{"10.0.0.1:45001": true, "10.0.0.2:45001": true}
+	if isPluginInstanceHealthChanged(previous, map[string]bool{"10.0.0.1:45001": true, "10.0.0.2:45001": true, "10.0.0.3:45001": false}) {
+		t.Error("isPluginInstanceHealthChanged() = true for an unchanged health")
+	}
+	if !isPluginInstanceHealthChanged(previous, map[string]bool{"10.0.0.1:45001": true, "10.0.0.2:45001": false}) {
+		t.Error("isPluginInstanceHealthChanged() = false for an instance gone down")
+	}
+	if !isPluginInstanceHealthChanged(previous, map[string]bool{"10.0.0.1:45001": true}) {
+		t.Error("isPluginInstanceHealthChanged() = false for a removed instance")
+	}
+}
+
+func TestIsPluginInstanceRemoved(t *testing.T) {
+	previous := []common.PluginInstance{{IP: "10.0.0.1", Port: "45001"}, {IP: "10.0.0.2", Port: "45001"}}
+	if isPluginInstanceRemoved(previous, append(previous, common.PluginInstance{IP: "10.0.0.3", Port: "45001"})) {
+		t.Error("isPluginInstanceRemoved() = true when an instance is added")
+	}
+	if !isPluginInstanceRemoved(previous, previous[:1]) {
+		t.Error("isPluginInstanceRemoved() = false when an instance is removed")
+	}
+}
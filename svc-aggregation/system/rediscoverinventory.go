@@ -41,7 +41,8 @@ const (
 )
 
 // RediscoverSystemInventory  is the handler for redicovering system whenever the restrat event detected in event service
-// It deletes old data and  Discovers Computersystem & Chassis and its top level odata.ID links and store them in inmemory db.
+// It Discovers Computersystem & Chassis and its top level odata.ID links and compares them with the stored inventory,
+// only the resources added or changed are stored in inmemory db and the resources not present anymore are removed.
 func (e *ExternalInterface) RediscoverSystemInventory(ctx context.Context, deviceUUID, systemURL string, updateFlag bool) {
 	l.LogWithFields(ctx).Info("Rediscovery of the BMC with ID " + deviceUUID + " is started.")
 	var resp response.RPC
@@ -143,8 +144,6 @@ func (e *ExternalInterface) RediscoverSystemInventory(ctx context.Context, devic
 		deleteResourceResetInfo(ctx, systemURL)
 	}()
 
-	deleteSubordinateResource(ctx, deviceUUID)
-
 	req.DeviceUUID = deviceUUID
 	req.DeviceInfo = target
 	req.OID = strings.Replace(systemURL, "/redfish/v1/Systems/"+deviceUUID+".", "/redfish/v1/Systems/", -1)
@@ -154,6 +153,15 @@ func (e *ExternalInterface) RediscoverSystemInventory(ctx context.Context, devic
 	var h respHolder
 	h.TraversedLinks = make(map[string]bool)
 	h.InventoryData = make(map[string]interface{})
+	resyncScope := getInventoryResyncScope(deviceUUID, systemURL)
+	if strings.Contains(systemURL, "/Storage") {
+		resyncScope = []string{systemURL}
+	}
+	h.Resync, err = loadInventoryResync(ctx, deviceUUID, resyncScope)
+	if err != nil {
+		l.LogWithFields(ctx).Error("Rediscovery for system: " + systemURL + " can't be processed, unable to read the stored inventory: " + err.Error())
+		return
+	}
 	progress := int32(100)
 	systemsEstimatedWork := int32(75)
 	if strings.Contains(systemURL, "/Storage") {
@@ -176,9 +184,13 @@ func (e *ExternalInterface) RediscoverSystemInventory(ctx context.Context, devic
 		req.OID = "/redfish/v1/Registries"
 		registriesEstimatedWork := int32(5)
 		progress = h.getAllRegistries(ctx, "", progress, registriesEstimatedWork, req)
-		agmodel.SaveBMCInventory(h.InventoryData)
+		if err := h.saveInventory(h.InventoryData); err != nil {
+			l.LogWithFields(ctx).Error("Rediscovery for system: " + systemURL + " failed to save the inventory: " + err.Error())
+			return
+		}
 
 	}
+	e.completeInventoryResync(ctx, h.Resync, systemURL)
 
 	var responseBody = map[string]string{
 		"UUID": deviceUUID,
@@ -241,15 +253,12 @@ func (e *ExternalInterface) RediscoverResources() error {
 				return
 			}
 			members := systemsCollection["Members"]
-			var systemURLArray []string
 			for _, member := range members.([]interface{}) {
 				systemURL := member.(map[string]interface{})["@odata.id"].(string)
 				if e.isServerRediscoveryRequired(ctxt, target.DeviceUUID, systemURL) == true {
 					e.RediscoverSystemInventory(ctxt, target.DeviceUUID, systemURL, true)
-					systemURLArray = append(systemURLArray, systemURL)
 				}
 			}
-			e.publishResourceUpdatedEvent(ctxt, systemURLArray, "SystemsCollection")
 		}(ctxt, targets[index])
 	}
 	// if everything is OK return success
@@ -257,10 +266,27 @@ func (e *ExternalInterface) RediscoverResources() error {
 
 }
 func (e *ExternalInterface) getTargetSystemCollection(ctx context.Context, target agmodel.Target) ([]byte, error) {
+	req, err := e.getTargetResourceRequest(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	req.OID = "/redfish/v1/Systems"
 
+	// Make the call to Plugin with above request
+	l.LogWithFields(ctx).Debugf("plugin contact request data for %s: %s", req.OID, string(req.Data))
+	body, _, _, err := contactPlugin(ctx, req, "error while trying to get the system collection details: ")
+	if err != nil {
+		return nil, fmt.Errorf("error while trying to get the system collection details")
+	}
+	return body, nil
+}
+
+// getTargetResourceRequest returns the request for reading the resources of the target from its plugin
+func (e *ExternalInterface) getTargetResourceRequest(ctx context.Context, target agmodel.Target) (getResourceRequest, error) {
+	var req getResourceRequest
 	decryptedPasswordByte, err := e.DecryptPassword(target.Password)
 	if err != nil {
-		return nil, err
+		return req, err
 	}
 	target.Password = decryptedPasswordByte
 	// get the plugin information
@@ -270,10 +296,9 @@ func (e *ExternalInterface) getTargetSystemCollection(ctx context.Context, targe
 	plugin, errs := agmodel.GetPluginData(target.PluginID, dbPluginConn)
 	if errs != nil {
 		l.LogWithFields(ctx).Error(errs.Error())
-		return nil, errs
+		return req, errs
 	}
 
-	var req getResourceRequest
 	req.ContactClient = e.ContactClient
 	req.GetPluginStatus = e.GetPluginStatus
 	req.Plugin = plugin
@@ -289,7 +314,7 @@ func (e *ExternalInterface) getTargetSystemCollection(ctx context.Context, targe
 		l.LogWithFields(ctx).Debugf("plugin contact request data for %s: %s", req.OID, string(req.Data))
 		_, token, _, err := contactPlugin(ctx, req, "error while getting the details "+req.OID+": ")
 		if err != nil {
-			return nil, err
+			return req, err
 		}
 		req.Token = token
 	} else {
@@ -300,17 +325,10 @@ func (e *ExternalInterface) getTargetSystemCollection(ctx context.Context, targe
 
 	}
 
+	req.HTTPMethodType = http.MethodGet
 	req.DeviceUUID = target.DeviceUUID
 	req.DeviceInfo = target
-	req.OID = "/redfish/v1/Systems"
-
-	// Make the call to Plugin with above request
-	l.LogWithFields(ctx).Debugf("plugin contact request data for %s: %s", req.OID, string(req.Data))
-	body, _, _, err := contactPlugin(ctx, req, "error while trying to get the system collection details: ")
-	if err != nil {
-		return nil, fmt.Errorf("error while trying to get the system collection details")
-	}
-	return body, nil
+	return req, nil
 }
 
 func (e *ExternalInterface) isServerRediscoveryRequired(ctx context.Context, deviceUUID string, systemKey string) bool {
@@ -354,13 +372,6 @@ func (e *ExternalInterface) isServerRediscoveryRequired(ctx context.Context, dev
 	return false
 }
 
-// publishResourceUpdatedEvent will publish ResourceUpdated events
-func (e *ExternalInterface) publishResourceUpdatedEvent(ctx context.Context, systemIDs []string, collectionName string) {
-	for i := 0; i < len(systemIDs); i++ {
-		e.PublishEventMB(ctx, systemIDs[i], "ResourceUpdated", collectionName)
-	}
-}
-
 func deleteResourceResetInfo(ctx context.Context, pattern string) {
 	var deleteKeys []string
 	keys, err := agmodel.GetAllMatchingDetails("SystemReset", pattern, common.InMemory)
@@ -374,32 +385,3 @@ func deleteResourceResetInfo(ctx context.Context, pattern string) {
 		agmodel.DeleteMultipleKeys(deleteKeys, common.InMemory)
 	}
 }
-
-// deleteSubordinateResource will delete all the subordinate resources assosiated with the pattern
-func deleteSubordinateResource(ctx context.Context, deviceUUID string) {
-	var deleteKeys []string
-	l.LogWithFields(ctx).Info("Initiated removal of subordinate resource for the BMC with ID " +
-		deviceUUID + " from the in-memory DB")
-	keys, err := agmodel.GetAllMatchingDetails("*", deviceUUID, common.InMemory)
-	if err != nil {
-		l.LogWithFields(ctx).Error("Unable to fetch all matching keys from system reset table: " + err.Error())
-		return
-	}
-	for _, key := range keys {
-		resourceDetails := strings.Split(key, ":")
-		switch resourceDetails[0] {
-		case "ComputerSystem", "SystemReset", "SystemOperation", "Chassis", "Managers", "FirmwareInventory", "SoftwareInventory":
-			continue
-		default:
-			key := resourceDetails[0] + ":" + resourceDetails[1]
-			deleteKeys = append(deleteKeys, key)
-		}
-	}
-	if len(deleteKeys) >= 1 {
-		if err = agmodel.DeleteMultipleKeys(deleteKeys, common.InMemory); err != nil {
-			l.LogWithFields(ctx).Error("error while deleting keys : " + err.Error())
-			return
-		}
-	}
-	l.LogWithFields(ctx).Info("Removal of subordinate resources for the BMC with ID " + deviceUUID + " from the in-memory DB is now complete.")
-}
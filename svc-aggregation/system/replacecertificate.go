@@ -0,0 +1,270 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+)
+
+const (
+	replaceCertificateOID = "/ODIM/v1/CertificateService/Actions/CertificateService.ReplaceCertificate"
+	// replaceCertificateOperation is the system operation of the servers whose certificate is replaced
+	replaceCertificateOperation = "ReplaceCertificate"
+)
+
+// ReplaceCertificateRequest is the request body of the ReplaceCertificate action of the CertificateService
+type ReplaceCertificateRequest struct {
+	CertificateURI    *agmodel.OdataID `json:"CertificateUri"`
+	CertificateString string           `json:"CertificateString"`
+	CertificateType   string           `json:"CertificateType"`
+}
+
+// ReplaceCertificate replaces a certificate of a BMC through the plugin managing it. The certificate
+// of a BMC trusted on first use is pinned again to the new certificate, and the certificate of a
+// BMC verified with a CA chain has to be signed by that chain.
+func (e *ExternalInterface) ReplaceCertificate(ctx context.Context, req *aggregatorproto.AggregatorRequest) response.RPC {
+	var replaceRequest ReplaceCertificateRequest
+	if err := json.Unmarshal(req.RequestBody, &replaceRequest); err != nil {
+		errMsg := "unable to parse the replace certificate request: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, nil)
+	}
+	invalidProperties, err := common.RequestParamsCaseValidator(req.RequestBody, replaceRequest)
+	if err != nil {
+		errMsg := "unable to validate the request parameters: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	} else if invalidProperties != "" {
+		errMsg := "one or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase "
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errMsg, []interface{}{invalidProperties}, nil)
+	}
+	if statusMessage, errMsg, args := replaceRequest.validate(); errMsg != "" {
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, statusMessage, errMsg, args, nil)
+	}
+	certificateURI := replaceRequest.CertificateURI.OdataID
+	targetUUID, deviceURI, ok := splitCertificateURI(certificateURI)
+	if !ok {
+		errMsg := "CertificateUri " + certificateURI + " is not the URI of a certificate of a server"
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errMsg, []interface{}{certificateURI, "CertificateUri"}, nil)
+	}
+	target, dbErr := agmodel.GetTarget(targetUUID)
+	if dbErr != nil || target == nil {
+		errMsg := "unable to get the details of the server managing " + certificateURI
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"Certificate", certificateURI}, nil)
+	}
+	certs, _ := common.ParseCertificates(replaceRequest.CertificateString)
+	if err := verifyReplacementCertificate(target.CertificateTrust, certs); err != nil {
+		errMsg := "the certificate of the server " + target.ManagerAddress + " would not be trusted: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueConflict, errMsg, []interface{}{"CertificateString", "CertificateTrustMode"}, nil)
+	}
+
+	// block the other operations on the server while its certificate is replaced
+	systems, errs := agmodel.GetAllMatchingDetails("ComputerSystem", "/redfish/v1/Systems/"+target.DeviceUUID+".", common.InMemory)
+	if errs != nil {
+		errMsg := "unable to get the systems of the server " + target.ManagerAddress + ": " + errs.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+	if err := acquireSystemOperations(ctx, systems, replaceCertificateOperation); err != nil {
+		errMsg := "unable to replace the certificate of the server " + target.ManagerAddress + ": " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		if _, ok := err.(operationInProgressError); ok {
+			return common.GeneralError(http.StatusConflict, response.ResourceInUse, errMsg, nil, nil)
+		}
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+	defer releaseSystemOperations(ctx, systems)
+
+	password, err := e.DecryptPassword(target.Password)
+	if err != nil {
+		errMsg := "unable to decrypt the device password: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+	plugin, errs := agmodel.GetPluginData(target.PluginID, agmodel.DBPluginDataRead{
+		DBReadclient: agmodel.GetPluginDBConnection,
+	})
+	if errs != nil {
+		errMsg := "unable to get the details of plugin " + target.PluginID + ": " + errs.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"plugin", target.PluginID}, nil)
+	}
+	pluginContactRequest, err := e.newPluginContactRequest(ctx, plugin)
+	if err != nil {
+		errMsg := err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusServiceUnavailable, response.CouldNotEstablishConnection, errMsg, []interface{}{plugin.IP + ":" + plugin.Port}, nil)
+	}
+	// the server knows the certificate by its own URI
+	replaceRequest.CertificateURI.OdataID = deviceURI
+	device := *target
+	device.Password = password
+	device.PostBody, _ = json.Marshal(replaceRequest)
+	pluginContactRequest.DeviceInfo = device
+	pluginContactRequest.OID = replaceCertificateOID
+	pluginContactRequest.HTTPMethodType = http.MethodPost
+	_, _, getResponse, err := contactPlugin(ctx, pluginContactRequest, "error while replacing the certificate of the server: ")
+	if err != nil && getResponse.StatusCode != http.StatusNoContent {
+		errMsg := err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(getResponse.StatusCode, getResponse.StatusMessage, errMsg, getResponse.MsgArgs, nil)
+	}
+	l.LogWithFields(ctx).Info("certificate " + certificateURI + " of the server " + target.ManagerAddress + " is replaced")
+
+	if target.CertificateTrust != nil && target.CertificateTrust.Mode == common.CertificateTrustModeTrustOnFirstUse {
+		if err := pinReplacedCertificate(ctx, plugin, *target, password, certs[0]); err != nil {
+			errMsg := "the certificate is replaced, but it could not be pinned: " + err.Error()
+			l.LogWithFields(ctx).Error(errMsg)
+			return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+		}
+	}
+	return response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+		Body: response.ErrorClass{
+			Code:    response.Success,
+			Message: "Request completed successfully.",
+		},
+	}
+}
+
+// validate returns the status message, the error message and the message arguments
+// of the first invalid property of the request, an empty error message when it is valid
+func (req ReplaceCertificateRequest) validate() (string, string, []interface{}) {
+	switch {
+	case req.CertificateURI == nil || req.CertificateURI.OdataID == "":
+		return response.PropertyMissing, "CertificateUri is missing", []interface{}{"CertificateUri"}
+	case req.CertificateString == "":
+		return response.PropertyMissing, "CertificateString is missing", []interface{}{"CertificateString"}
+	case req.CertificateType == "":
+		return response.PropertyMissing, "CertificateType is missing", []interface{}{"CertificateType"}
+	case req.CertificateType != "PEM" && req.CertificateType != "PEMchain":
+		return response.PropertyValueNotInList, "CertificateType " + req.CertificateType + " is not one of PEM, PEMchain",
+			[]interface{}{req.CertificateType, "CertificateType"}
+	}
+	if _, err := common.ParseCertificates(req.CertificateString); err != nil {
+		return response.PropertyValueFormatError, "invalid CertificateString: " + err.Error(), []interface{}{"CertificateString", "CertificateString"}
+	}
+	return "", "", nil
+}
+
+// splitCertificateURI returns the UUID of the server, and the URI of the certificate on the server,
+// of a certificate URI like /redfish/v1/Managers/<UUID>.<ID>/NetworkProtocol/HTTPS/Certificates/1
+func splitCertificateURI(certificateURI string) (string, string, bool) {
+	segments := strings.Split(certificateURI, "/")
+	if len(segments) < 6 || segments[1] != "redfish" || segments[2] != "v1" {
+		return "", "", false
+	}
+	resourceID := strings.SplitN(segments[4], ".", 2)
+	if len(resourceID) != 2 || resourceID[0] == "" || resourceID[1] == "" {
+		return "", "", false
+	}
+	segments[4] = resourceID[1]
+	return resourceID[0], strings.Join(segments, "/"), true
+}
+
+// verifyReplacementCertificate checks that the new certificate of a server verified with a CA chain
+// is signed by that chain, so that the plugin keeps trusting the server
+func verifyReplacementCertificate(trust *common.CertificateTrust, certs []*x509.Certificate) error {
+	if trust == nil || trust.Mode != common.CertificateTrustModeCAChain {
+		return nil
+	}
+	chain, err := common.ParseCertificates(trust.Certificate)
+	if err != nil {
+		return err
+	}
+	roots := x509.NewCertPool()
+	for _, cert := range chain {
+		roots.AddCert(cert)
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err = certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+	})
+	return err
+}
+
+// pinReplacedCertificate pins the new certificate of a server trusted on first use, in its details,
+// in its aggregation sources and in every instance of its plugin. It fails when the certificate is
+// not stored or not pushed to the instances of the plugin, which would reject the new certificate.
+func pinReplacedCertificate(ctx context.Context, plugin agmodel.Plugin, target agmodel.Target,
+	password []byte, cert *x509.Certificate) error {
+	trust := common.PinCertificate(cert)
+	saveSystem := agmodel.SaveSystem{
+		ManagerAddress:    target.ManagerAddress,
+		Password:          target.Password,
+		UserName:          target.UserName,
+		DeviceUUID:        target.DeviceUUID,
+		PluginID:          target.PluginID,
+		EventDeliveryMode: target.EventDeliveryMode,
+		CertificateTrust:  &trust,
+	}
+	if dbErr := agmodel.UpdateSystemData(saveSystem, target.DeviceUUID); dbErr != nil {
+		return fmt.Errorf("unable to store the certificate trust: %v", dbErr.Error())
+	}
+	aggregationSourceURIs, dbErr := agmodel.GetAllMatchingDetails("AggregationSource", aggregationSourcesURI+"/"+target.DeviceUUID+".", common.OnDisk)
+	if dbErr != nil {
+		l.LogWithFields(ctx).Error("unable to get the aggregation sources of the server " + target.ManagerAddress + ": " + dbErr.Error())
+	}
+	for _, aggregationSourceURI := range aggregationSourceURIs {
+		aggregationSource, dbErr := agmodel.GetAggregationSourceInfo(ctx, aggregationSourceURI)
+		if dbErr != nil {
+			continue
+		}
+		aggregationSource.Oem = getBMCAggregationSourceOem(target.EventDeliveryMode, &trust)
+		if dbErr = agmodel.UpdateAggregtionSource(aggregationSource, aggregationSourceURI); dbErr != nil {
+			l.LogWithFields(ctx).Error("unable to store the certificate fingerprint in " + aggregationSourceURI + ": " + dbErr.Error())
+		}
+	}
+	// the instance which replaced the certificate accepts both certificates until the server
+	// presents the new one, the other instances accept only the new one
+	err := PushPluginStartUpData(ctx, plugin, &agmodel.PluginStartUpData{
+		RequestType: "delta",
+		Devices: map[string]agmodel.DeviceData{
+			target.DeviceUUID: {
+				Address:           target.ManagerAddress,
+				UserName:          target.UserName,
+				Password:          password,
+				Operation:         "add",
+				EventDeliveryMode: target.EventDeliveryMode,
+				CertificateTrust:  &trust,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("unable to push the certificate trust to the instances of the plugin %s: %v", plugin.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+)
+
+func TestExternalInterface_ReplaceCertificate(t *testing.T) {
+	config.SetUpMockConfig(t)
+	e := &ExternalInterface{}
+	_, _, certificate := newTestCertificate(t, "bmc", false, nil, nil)
+	certificateString, _ := json.Marshal(certificate)
+	certificateURI := `{"@odata.id":"/redfish/v1/Managers/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1/NetworkProtocol/HTTPS/Certificates/1"}`
+	tests := []struct {
+		name        string
+		body        string
+		wantStatus  int32
+		wantMessage string
+	}{
+		{"malformed body", `{`, http.StatusBadRequest, response.MalformedJSON},
+		{"unknown property", `{"CertificateUri":` + certificateURI + `,"certificateString":"pem","CertificateType":"PEM"}`, http.StatusBadRequest, response.PropertyUnknown},
+		{"certificate URI missing", `{"CertificateString":"pem","CertificateType":"PEM"}`, http.StatusBadRequest, response.PropertyMissing},
+		{"certificate missing", `{"CertificateUri":` + certificateURI + `,"CertificateType":"PEM"}`, http.StatusBadRequest, response.PropertyMissing},
+		{"invalid certificate type", `{"CertificateUri":` + certificateURI + `,"CertificateString":"pem","CertificateType":"PKCS7"}`, http.StatusBadRequest, response.PropertyValueNotInList},
+		{"invalid certificate", `{"CertificateUri":` + certificateURI + `,"CertificateString":"pem","CertificateType":"PEM"}`, http.StatusBadRequest, response.PropertyValueFormatError},
+		{"URI not of a server", `{"CertificateUri":{"@odata.id":"/redfish/v1/Managers/1/NetworkProtocol/HTTPS/Certificates/1"},"CertificateString":` +
+			string(certificateString) + `,"CertificateType":"PEM"}`, http.StatusBadRequest, response.PropertyValueFormatError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := e.ReplaceCertificate(mockContext(), &aggregatorproto.AggregatorRequest{
+				SessionToken: "validToken",
+				RequestBody:  []byte(tt.body),
+			})
+			if resp.StatusCode != tt.wantStatus || resp.StatusMessage != tt.wantMessage {
+				t.Errorf("ReplaceCertificate() = %v %v, want %v %v", resp.StatusCode, resp.StatusMessage, tt.wantStatus, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestExternalInterface_ReplaceCertificateInProgress(t *testing.T) {
+	config.SetUpMockConfig(t)
+	defer func() {
+		if err := common.TruncateDB(common.OnDisk); err != nil {
+			t.Fatalf("error: %v", err)
+		}
+		if err := common.TruncateDB(common.InMemory); err != nil {
+			t.Fatalf("error: %v", err)
+		}
+	}()
+	deviceUUID := "6d4a0a66-7efa-578e-83cf-44dc68d2874e"
+	systemURI := "/redfish/v1/Systems/" + deviceUUID + ".1"
+	if err := mockDeviceData(deviceUUID, agmodel.Target{
+		ManagerAddress: "100.0.0.1",
+		UserName:       "admin",
+		DeviceUUID:     deviceUUID,
+		PluginID:       "GRF",
+	}); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if err := mockSystemResourceData([]byte(`{"Id":"1"}`), "ComputerSystem", systemURI); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	refreshOperation := agmodel.SystemOperation{Operation: inventoryRefreshOperation}
+	if err := refreshOperation.AddSystemOperationInfo(systemURI); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	_, _, certificate := newTestCertificate(t, "bmc", false, nil, nil)
+	certificateString, _ := json.Marshal(certificate)
+	e := &ExternalInterface{}
+	resp := e.ReplaceCertificate(mockContext(), &aggregatorproto.AggregatorRequest{
+		SessionToken: "validToken",
+		RequestBody: []byte(`{"CertificateUri":{"@odata.id":"/redfish/v1/Managers/` + deviceUUID + `.1/NetworkProtocol/HTTPS/Certificates/1"},` +
+			`"CertificateString":` + string(certificateString) + `,"CertificateType":"PEM"}`),
+	})
+	if resp.StatusCode != http.StatusConflict || resp.StatusMessage != response.ResourceInUse {
+		t.Errorf("ReplaceCertificate() = %v %v, want %v %v", resp.StatusCode, resp.StatusMessage, http.StatusConflict, response.ResourceInUse)
+	}
+	if operation, _ := agmodel.GetSystemOperationInfo(mockContext(), systemURI); operation.Operation != inventoryRefreshOperation {
+		t.Errorf("operation of %v = %q, want %q", systemURI, operation.Operation, inventoryRefreshOperation)
+	}
+}
+
+func TestSplitCertificateURI(t *testing.T) {
+	tests := []struct {
+		uri        string
+		wantUUID   string
+		wantDevice string
+		wantOK     bool
+	}{
+		{"/redfish/v1/Managers/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1/NetworkProtocol/HTTPS/Certificates/1", "6d4a0a66-7efa-578e-83cf-44dc68d2874e",
+			"/redfish/v1/Managers/1/NetworkProtocol/HTTPS/Certificates/1", true},
+		{"/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e.System.Embedded.1/Certificates/1", "6d4a0a66-7efa-578e-83cf-44dc68d2874e",
+			"/redfish/v1/Systems/System.Embedded.1/Certificates/1", true},
+		{"/redfish/v1/Managers/1/NetworkProtocol/HTTPS/Certificates/1", "", "", false},
+		{"/redfish/v1/Managers/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1", "", "", false},
+		{"/Managers/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1/Certificates/1", "", "", false},
+	}
+	for _, tt := range tests {
+		uuid, deviceURI, ok := splitCertificateURI(tt.uri)
+		if uuid != tt.wantUUID || deviceURI != tt.wantDevice || ok != tt.wantOK {
+			t.Errorf("splitCertificateURI(%s) = %v, %v, %v, want %v, %v, %v", tt.uri, uuid, deviceURI, ok, tt.wantUUID, tt.wantDevice, tt.wantOK)
+		}
+	}
+}
+
+func TestVerifyReplacementCertificate(t *testing.T) {
+	ca, caKey, caPEM := newTestCertificate(t, "ca", true, nil, nil)
+	signed, _, _ := newTestCertificate(t, "bmc", false, ca, caKey)
+	selfSigned, _, _ := newTestCertificate(t, "bmc", false, nil, nil)
+	chainTrust := &common.CertificateTrust{Mode: common.CertificateTrustModeCAChain, Certificate: caPEM}
+	tofuTrust := &common.CertificateTrust{Mode: common.CertificateTrustModeTrustOnFirstUse}
+	tests := []struct {
+		name    string
+		trust   *common.CertificateTrust
+		certs   []*x509.Certificate
+		wantErr bool
+	}{
+		{"root CA", nil, []*x509.Certificate{selfSigned}, false},
+		{"trust on first use", tofuTrust, []*x509.Certificate{selfSigned}, false},
+		{"signed by the CA chain", chainTrust, []*x509.Certificate{signed}, false},
+		{"not signed by the CA chain", chainTrust, []*x509.Certificate{selfSigned}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := verifyReplacementCertificate(tt.trust, tt.certs); (err != nil) != tt.wantErr {
+				t.Errorf("verifyReplacementCertificate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+
+//
+
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+
+//not use this file except in compliance with the License. You may obtain
+
+//a copy of the License at
+
+//
+
+//    http://www.apache.org/licenses/LICENSE-2.0
+
+package system
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+)
+
+func stubSecretPassword(password []byte) ([]byte, error) {
+	if string(password) == "secret://unknown" {
+		return nil, fmt.Errorf("secret not found")
+	}
+	return stubDevicePassword(password)
+}
+
+func TestAddResourceRequest_passwordToStore(t *testing.T) {
+	req := AddResourceRequest{Password: "password"}
+	if got := req.passwordToStore(); string(got) != "password" {
+		t.Errorf("passwordToStore() = %s, want password", got)
+	}
+	req.SecretReference = "secret://bmc/server1"
+	if got := req.passwordToStore(); string(got) != "secret://bmc/server1" {
+		t.Errorf("passwordToStore() = %s, want secret://bmc/server1", got)
+	}
+}
+
+func Test_updatedPasswordToStore(t *testing.T) {
+	tests := []struct {
+		name          string
+		updateRequest map[string]interface{}
+		want          []byte
+	}{
+		{
+			name:          "password given",
+			updateRequest: map[string]interface{}{"Password": "password"},
+			want:          []byte("password"),
+		},
+		{
+			name:          "secret reference given",
+			updateRequest: map[string]interface{}{secretReferenceKey: []byte("secret://bmc/server1")},
+			want:          []byte("secret://bmc/server1"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := updatedPasswordToStore(tt.updateRequest, []byte("password")); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("updatedPasswordToStore() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExternalInterface_addAggregationSourceWithUnknownSecret(t *testing.T) {
+	config.SetUpMockConfig(t)
+	p := getMockExternalInterface()
+	p.DecryptPassword = stubSecretPassword
+	req := AggregationSource{
+		HostName: "100.0.0.1",
+		UserName: "admin",
+		Password: "secret://unknown",
+		Links: &Links{
+			ConnectionMethod: &ConnectionMethod{
+				OdataID: "/redfish/v1/AggregationService/ConnectionMethods/7ff3bd97-c41c-5de0-937d-85d390691b73",
+			},
+		},
+	}
+	resp := p.addAggregationSource(mockContext(), "123", "/redfish/v1/AggregationService/AggregationSources", "", 0, req, nil)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("addAggregationSource() status = %v, want %v", resp.StatusCode, http.StatusBadRequest)
+	}
+	if resp.StatusMessage != response.PropertyValueFormatError {
+		t.Errorf("addAggregationSource() status message = %v, want %v", resp.StatusMessage, response.PropertyValueFormatError)
+	}
+}
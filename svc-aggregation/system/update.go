@@ -27,6 +27,7 @@ import (
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
 	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/lib-utilities/secrets"
 	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
 	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
 )
@@ -52,7 +53,21 @@ func (e *ExternalInterface) UpdateAggregationSource(ctx context.Context, req *ag
 		l.LogWithFields(ctx).Error(errMsg)
 		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
 	}
-	if len(updateRequest) <= 0 {
+	var oemRequest AggregationSource
+	json.Unmarshal(req.RequestBody, &oemRequest)
+	instanceAddresses, instancesRequested := getRequestedPluginInstances(oemRequest.Oem)
+	eventDeliveryMode, err := getRequestedEventDeliveryMode(oemRequest.Oem)
+	if err != nil {
+		l.LogWithFields(ctx).Error(err.Error())
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, err.Error(), []interface{}{oemRequest.Oem.Odim.EventDeliveryMode, "EventDeliveryMode"}, nil)
+	}
+	certificateTrust, err := getRequestedCertificateTrust(oemRequest.Oem)
+	if err != nil {
+		l.LogWithFields(ctx).Error(err.Error())
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, err.Error(), []interface{}{oemRequest.Oem.Odim.CertificateTrustMode, "CertificateTrustMode"}, nil)
+	}
+	delete(updateRequest, "Oem")
+	if len(updateRequest) <= 0 && !instancesRequested && eventDeliveryMode == "" && certificateTrust == nil {
 		param := "HostName UserName Password "
 		errMsg := "field " + param + " Missing"
 		l.LogWithFields(ctx).Error(errMsg)
@@ -88,6 +103,9 @@ func (e *ExternalInterface) UpdateAggregationSource(ctx context.Context, req *ag
 		}
 	}
 	if _, ok := updateRequest["Password"]; !ok {
+		if secrets.IsReference(aggregationSource.Password) {
+			updateRequest[secretReferenceKey] = aggregationSource.Password
+		}
 		decryptedPasswordByte, err := e.DecryptPassword(aggregationSource.Password)
 		if err != nil {
 			errMsg := "Unable to decrypt device password: " + err.Error()
@@ -97,6 +115,17 @@ func (e *ExternalInterface) UpdateAggregationSource(ctx context.Context, req *ag
 		updateRequest["Password"] = decryptedPasswordByte
 	} else {
 		bytePassword := []byte(updateRequest["Password"].(string))
+		// the server is contacted with the secret referenced, and the reference is stored in place of it
+		if secrets.IsReference(bytePassword) {
+			password, err := e.DecryptPassword(bytePassword)
+			if err != nil {
+				errMsg := "unable to resolve the secret given as the password: " + err.Error()
+				l.LogWithFields(ctx).Error(errMsg)
+				return common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errMsg, []interface{}{string(bytePassword), "Password"}, nil)
+			}
+			updateRequest[secretReferenceKey] = bytePassword
+			bytePassword = password
+		}
 		updateRequest["Password"] = bytePassword
 	}
 	if hostNameUpdated {
@@ -113,6 +142,15 @@ func (e *ExternalInterface) UpdateAggregationSource(ctx context.Context, req *ag
 			return common.GeneralError(http.StatusConflict, response.ResourceAlreadyExists, errMsg, []interface{}{"ComputerSystem", "HostName", ipAddr}, nil)
 		}
 	}
+	if instancesRequested {
+		updateRequest["PluginInstances"] = instanceAddresses
+	}
+	if eventDeliveryMode != "" {
+		updateRequest["EventDeliveryMode"] = eventDeliveryMode
+	}
+	if certificateTrust != nil {
+		updateRequest["CertificateTrust"] = certificateTrust
+	}
 	var data = strings.Split(req.URL, "/redfish/v1/AggregationService/AggregationSources/")
 	links := aggregationSource.Links.(map[string]interface{})
 	// Not adding update request log,since it has password in byte format
@@ -124,6 +162,15 @@ func (e *ExternalInterface) UpdateAggregationSource(ctx context.Context, req *ag
 	aggregationSource.HostName = updateRequest["HostName"].(string)
 	aggregationSource.UserName = updateRequest["UserName"].(string)
 	aggregationSource.Password = updateRequest["Password"].([]byte)
+	if instances, ok := updateRequest["PluginInstances"].([]common.PluginInstance); ok {
+		aggregationSource.Oem = getAggregationSourceOem(instances)
+	}
+	if eventDeliveryMode != "" || certificateTrust != nil {
+		// the Oem block of a BMC shows both its event delivery mode and its certificate trust
+		eventDeliveryMode, _ = updateRequest["EventDeliveryMode"].(string)
+		certificateTrust, _ = updateRequest["CertificateTrust"].(*common.CertificateTrust)
+		aggregationSource.Oem = getBMCAggregationSourceOem(eventDeliveryMode, certificateTrust)
+	}
 
 	dbErr = agmodel.UpdateAggregtionSource(aggregationSource, req.URL)
 	if dbErr != nil {
@@ -149,6 +196,7 @@ func (e *ExternalInterface) UpdateAggregationSource(ctx context.Context, req *ag
 		HostName: updateRequest["HostName"].(string),
 		UserName: updateRequest["UserName"].(string),
 		Links:    aggregationSource.Links,
+		Oem:      aggregationSource.Oem,
 	}
 	resp.StatusCode = http.StatusOK
 	resp.StatusMessage = response.Success
@@ -174,9 +222,37 @@ func (e *ExternalInterface) updateAggregationSourceWithConnectionMethod(ctx cont
 	target, terr := agmodel.GetTarget(uuidData[0])
 	// Not adding update request log,since it has password in byte format
 	if terr != nil || target == nil {
+		if _, ok := updateRequest["EventDeliveryMode"]; ok {
+			errMsg := "error: EventDeliveryMode can be given only for the aggregation source of a BMC"
+			l.LogWithFields(ctx).Error(errMsg)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errMsg, []interface{}{"Oem/Odim/EventDeliveryMode"}, nil)
+		}
+		if _, ok := updateRequest["CertificateTrust"]; ok {
+			errMsg := "error: CertificateTrustMode can be given only for the aggregation source of a BMC"
+			l.LogWithFields(ctx).Error(errMsg)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errMsg, []interface{}{"Oem/Odim/CertificateTrustMode"}, nil)
+		}
 		return e.updateManagerAggregationSource(ctx, data[1], cmVariants.PluginID, updateRequest, hostNameUpdated)
 	}
-	return e.updateBMCAggregationSource(ctx, uuidData[0], cmVariants.PluginID, updateRequest, hostNameUpdated)
+	if _, ok := updateRequest["PluginInstances"]; ok {
+		errMsg := "error: PluginInstances can be given only for the aggregation source of a plugin"
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errMsg, []interface{}{"Oem/Odim/PluginInstances"}, nil)
+	}
+	// the event delivery mode of the server is kept, unless it is given in the request
+	if _, ok := updateRequest["EventDeliveryMode"]; !ok {
+		updateRequest["EventDeliveryMode"] = target.EventDeliveryMode
+	}
+	// the certificate trust of the server is kept, unless it is given in the request,
+	// a new trust on first use pins the certificate the server presents now
+	if _, ok := updateRequest["CertificateTrust"]; !ok {
+		updateRequest["CertificateTrust"] = target.CertificateTrust
+	}
+	resp := e.updateBMCAggregationSource(ctx, uuidData[0], cmVariants.PluginID, updateRequest, hostNameUpdated)
+	if resp.StatusCode == http.StatusOK && updateRequest["EventDeliveryMode"] != target.EventDeliveryMode {
+		go resyncEventDelivery(ctx, cmVariants.PluginID, uuidData[0])
+	}
+	return resp
 }
 
 func (e *ExternalInterface) updateManagerAggregationSource(ctx context.Context, aggregationSourceID, pluginID string, updateRequest map[string]interface{}, hostNameUpdated bool) response.RPC {
@@ -189,11 +265,24 @@ func (e *ExternalInterface) updateManagerAggregationSource(ctx context.Context,
 		l.LogWithFields(ctx).Error(errMsg)
 		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"plugin", pluginID}, nil)
 	}
+	// the additional instances of the plugin are kept, unless they are given in the request
+	instanceAddresses := getAdditionalInstanceAddresses(common.GetPluginInstances(plugin.IP, plugin.Port, plugin.Instances))
+	if addresses, ok := updateRequest["PluginInstances"].([]string); ok {
+		instanceAddresses = addresses
+	}
+	instances, err := getPluginInstancesFromRequest(instanceAddresses, updateRequest["HostName"].(string))
+	if err != nil {
+		l.LogWithFields(ctx).Error(err.Error())
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, err.Error(), []interface{}{fmt.Sprintf("%v", instanceAddresses), "PluginInstances"}, nil)
+	}
+	previousInstances := plugin.Instances
 	ipData := strings.Split(updateRequest["HostName"].(string), ":")
 	plugin.IP = ipData[0]
 	plugin.Port = ipData[1]
 	plugin.Username = updateRequest["UserName"].(string)
 	plugin.Password = updateRequest["Password"].([]byte)
+	// the plugin is verified at the address given in the request
+	plugin.Instances = nil
 	var pluginContactRequest getResourceRequest
 	pluginContactRequest.ContactClient = e.ContactClient
 	pluginContactRequest.GetPluginStatus = e.GetPluginStatus
@@ -282,7 +371,7 @@ func (e *ExternalInterface) updateManagerAggregationSource(ctx context.Context,
 	}
 
 	// encrypt plugin password
-	ciphertext, err := e.EncryptPassword(plugin.Password)
+	ciphertext, err := e.EncryptPassword(updatedPasswordToStore(updateRequest, plugin.Password))
 	if err != nil {
 		errMsg := "Encryption failed: " + err.Error()
 		l.LogWithFields(ctx).Error(errMsg)
@@ -291,13 +380,23 @@ func (e *ExternalInterface) updateManagerAggregationSource(ctx context.Context,
 
 	plugin.Password = ciphertext
 	plugin.ManagerUUID = managerUUID
+	plugin.Instances = instances
 	updateRequest["Password"] = ciphertext
+	updateRequest["PluginInstances"] = instances
 	dbErr := agmodel.UpdatePluginData(plugin, pluginID)
 	if dbErr != nil {
 		errMsg := "Unable to update plugin info: " + dbErr.Error()
 		l.LogWithFields(ctx).Error(errMsg)
 		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
 	}
+	// the servers of the removed instances are taken over by the remaining ones
+	if plugin.PluginType == "Compute" && isPluginInstanceRemoved(previousInstances, common.GetPluginInstances(plugin.IP, plugin.Port, instances)) {
+		go func() {
+			if err := sharePluginInstancesInventory(ctx, plugin); err != nil {
+				l.LogWithFields(ctx).Error("failed to share the servers of the removed instances of plugin " + pluginID + ": " + err.Error())
+			}
+		}()
+	}
 
 	return response.RPC{
 		StatusCode: http.StatusOK,
@@ -350,6 +449,8 @@ func (e *ExternalInterface) updateBMCAggregationSource(ctx context.Context, aggr
 		UserName:       updateRequest["UserName"].(string),
 		Password:       updateRequest["Password"].([]byte),
 	}
+	requestedTrust, _ := updateRequest["CertificateTrust"].(*common.CertificateTrust)
+	saveSystem.CertificateTrust = requestedTrust
 	pluginContactRequest.DeviceInfo = saveSystem
 	pluginContactRequest.OID = "/ODIM/v1/validate"
 	pluginContactRequest.HTTPMethodType = http.MethodPost
@@ -368,6 +469,13 @@ func (e *ExternalInterface) updateBMCAggregationSource(ctx context.Context, aggr
 		l.LogWithFields(ctx).Error(errMsg)
 		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
 	}
+	trust, err := getEstablishedCertificateTrust(requestedTrust, body)
+	if err != nil {
+		l.LogWithFields(ctx).Error(err.Error())
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, err.Error(), []interface{}{requestedTrust.Mode, "CertificateTrustMode"}, nil)
+	}
+	saveSystem.CertificateTrust = trust
+	updateRequest["CertificateTrust"] = trust
 	if hostNameUpdated {
 		// Get All systems
 		pluginContactRequest.OID = "/redfish/v1/Systems"
@@ -442,8 +550,9 @@ func (e *ExternalInterface) updateBMCAggregationSource(ctx context.Context, aggr
 	// update the system
 	saveSystem.PluginID = pluginID
 	saveSystem.DeviceUUID = aggregationSourceID
+	saveSystem.EventDeliveryMode, _ = updateRequest["EventDeliveryMode"].(string)
 	// encrypt the device password
-	ciphertext, err := e.EncryptPassword([]byte(saveSystem.Password))
+	ciphertext, err := e.EncryptPassword(updatedPasswordToStore(updateRequest, []byte(saveSystem.Password)))
 	if err != nil {
 		errMsg := "Unable to encrypt device password: " + err.Error()
 		l.LogWithFields(ctx).Error(errMsg)
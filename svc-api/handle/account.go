@@ -33,6 +33,10 @@ type AccountRPCs struct {
 	GetAccountRPC     func(context.Context, accountproto.GetAccountRequest) (*accountproto.AccountResponse, error)
 	UpdateRPC         func(context.Context, accountproto.UpdateAccountRequest) (*accountproto.AccountResponse, error)
 	DeleteRPC         func(context.Context, accountproto.DeleteAccountRequest) (*accountproto.AccountResponse, error)
+	CreateAPIKeyRPC   func(context.Context, accountproto.APIKeyRequest) (*accountproto.AccountResponse, error)
+	GetAllAPIKeysRPC  func(context.Context, accountproto.APIKeyRequest) (*accountproto.AccountResponse, error)
+	GetAPIKeyRPC      func(context.Context, accountproto.APIKeyRequest) (*accountproto.AccountResponse, error)
+	DeleteAPIKeyRPC   func(context.Context, accountproto.APIKeyRequest) (*accountproto.AccountResponse, error)
 }
 
 // GetAccountService defines the GetAccountService iris handler.
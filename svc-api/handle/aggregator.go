@@ -19,6 +19,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strings"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
@@ -33,10 +34,15 @@ type AggregatorRPCs struct {
 	ResetRPC                                func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
 	SetDefaultBootOrderRPC                  func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
 	AddAggregationSourceRPC                 func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
+	BulkAddAggregationSourcesRPC            func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
+	GetAllDiscoveredBMCsRPC                 func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
+	GetDiscoveredBMCRPC                     func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
+	AdoptDiscoveredBMCRPC                   func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
 	GetAllAggregationSourceRPC              func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
 	GetAggregationSourceRPC                 func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
 	UpdateAggregationSourceRPC              func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
 	DeleteAggregationSourceRPC              func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
+	ChangeConnectionMethodRPC               func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
 	CreateAggregateRPC                      func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
 	GetAggregateCollectionRPC               func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
 	GetAggregateRPC                         func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
@@ -45,6 +51,8 @@ type AggregatorRPCs struct {
 	RemoveElementsFromAggregateRPC          func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
 	ResetAggregateElementsRPC               func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
 	SetDefaultBootOrderAggregateElementsRPC func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
+	RotateCredentialsRPC                    func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
+	ReplaceCertificateRPC                   func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
 	GetAllConnectionMethodsRPC              func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
 	GetConnectionMethodRPC                  func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
 	GetResetActionInfoServiceRPC            func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
@@ -215,6 +223,154 @@ func (a *AggregatorRPCs) AddAggregationSource(ctx iris.Context) {
 	sendAggregatorResponse(ctx, resp)
 }
 
+// BulkAddAggregationSources is the handler for adding AggregationSources listed in a manifest.
+// The manifest is either a JSON request body or a CSV document sent with Content-Type text/csv.
+func (a *AggregatorRPCs) BulkAddAggregationSources(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	l.LogWithFields(ctxt).Debugf("Incoming request received for bulk adding aggregationsources")
+	var req interface{}
+	if strings.HasPrefix(ctx.GetContentTypeRequested(), "text/csv") {
+		manifest, err := ctx.GetBody()
+		if err != nil {
+			errorMessage := "error while trying to read the manifest from the request body: " + err.Error()
+			l.LogWithFields(ctxt).Error(errorMessage)
+			common.SendMalformedJSONRequestErrResponse(ctx, errorMessage)
+			return
+		}
+		req = map[string]interface{}{"Manifest": string(manifest)}
+	} else if err := ctx.ReadJSON(&req); err != nil {
+		errorMessage := "error while trying to get JSON body from the aggregator request body: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendMalformedJSONRequestErrResponse(ctx, errorMessage)
+		return
+	}
+
+	sessionToken := ctx.Request().Header.Get(AuthTokenHeader)
+	if sessionToken == "" {
+		errorMessage := invalidAuthTokenErrMsg
+		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
+	}
+
+	// marshalling the req to make aggregator bulk add request
+	// Since aggregator bulk add request accepts []byte stream
+	request, err := json.Marshal(req)
+	if err != nil {
+		errorMessage := "error while trying to create JSON request body: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+	bulkAddRequest := aggregatorproto.AggregatorRequest{
+		SessionToken: sessionToken,
+		RequestBody:  request,
+	}
+	resp, err := a.BulkAddAggregationSourcesRPC(ctxt, bulkAddRequest)
+	if err != nil {
+		errorMessage := "RPC error: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+
+	sendAggregatorResponse(ctx, resp)
+}
+
+// GetAllDiscoveredBMCs is the handler for listing the BMCs found by the discovery scan
+func (a *AggregatorRPCs) GetAllDiscoveredBMCs(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	l.LogWithFields(ctxt).Debugf("Incoming request received for getting all discovered BMCs")
+	req := aggregatorproto.AggregatorRequest{
+		SessionToken: ctx.Request().Header.Get(AuthTokenHeader),
+	}
+	if req.SessionToken == "" {
+		errorMessage := invalidAuthTokenErrMsg
+		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
+	}
+	resp, err := a.GetAllDiscoveredBMCsRPC(ctxt, req)
+	if err != nil {
+		errorMessage := "RPC error: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+	ctx.ResponseWriter().Header().Set("Allow", "GET")
+	sendAggregatorResponse(ctx, resp)
+}
+
+// GetDiscoveredBMC is the handler for getting the details of a BMC found by the discovery scan
+func (a *AggregatorRPCs) GetDiscoveredBMC(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	req := aggregatorproto.AggregatorRequest{
+		SessionToken: ctx.Request().Header.Get(AuthTokenHeader),
+		URL:          ctx.Request().RequestURI,
+	}
+	l.LogWithFields(ctxt).Debugf("Incoming request received for getting discovered BMC with uri %s", req.URL)
+	if req.SessionToken == "" {
+		errorMessage := invalidAuthTokenErrMsg
+		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
+	}
+	resp, err := a.GetDiscoveredBMCRPC(ctxt, req)
+	if err != nil {
+		errorMessage := "RPC error: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+	ctx.ResponseWriter().Header().Set("Allow", "GET")
+	sendAggregatorResponse(ctx, resp)
+}
+
+// AdoptDiscoveredBMC is the handler for adding a discovered BMC as an AggregationSource.
+// The request body is optional, the credentials configured for the discovery are used without it.
+func (a *AggregatorRPCs) AdoptDiscoveredBMC(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	l.LogWithFields(ctxt).Debugf("Incoming request received for adopting discovered BMC with uri %s", ctx.Request().RequestURI)
+	request, err := ctx.GetBody()
+	if err != nil {
+		errorMessage := "error while trying to read the aggregator request body: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendMalformedJSONRequestErrResponse(ctx, errorMessage)
+		return
+	}
+	if len(request) > 0 {
+		var req interface{}
+		if err := json.Unmarshal(request, &req); err != nil {
+			errorMessage := "error while trying to get JSON body from the aggregator request body: " + err.Error()
+			l.LogWithFields(ctxt).Error(errorMessage)
+			common.SendMalformedJSONRequestErrResponse(ctx, errorMessage)
+			return
+		}
+	}
+
+	sessionToken := ctx.Request().Header.Get(AuthTokenHeader)
+	if sessionToken == "" {
+		errorMessage := invalidAuthTokenErrMsg
+		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
+	}
+	adoptRequest := aggregatorproto.AggregatorRequest{
+		SessionToken: sessionToken,
+		URL:          ctx.Request().RequestURI,
+		RequestBody:  request,
+	}
+	resp, err := a.AdoptDiscoveredBMCRPC(ctxt, adoptRequest)
+	if err != nil {
+		errorMessage := "RPC error: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+
+	sendAggregatorResponse(ctx, resp)
+}
+
 // GetAllAggregationSource is the handler for getting all  AggregationSource details
 func (a *AggregatorRPCs) GetAllAggregationSource(ctx iris.Context) {
 	defer ctx.Next()
@@ -330,6 +486,49 @@ func (a *AggregatorRPCs) DeleteAggregationSource(ctx iris.Context) {
 	sendAggregatorResponse(ctx, resp)
 }
 
+// ChangeConnectionMethod is the handler for moving the server of an AggregationSource
+// to the plugin of another ConnectionMethod
+func (a *AggregatorRPCs) ChangeConnectionMethod(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	l.LogWithFields(ctxt).Debugf("Incoming request received for changing the connection method with uri %s", ctx.Request().RequestURI)
+	request, err := ctx.GetBody()
+	if err != nil {
+		errorMessage := "error while trying to read the aggregator request body: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendMalformedJSONRequestErrResponse(ctx, errorMessage)
+		return
+	}
+	var req interface{}
+	if err := json.Unmarshal(request, &req); err != nil {
+		errorMessage := "error while trying to get JSON body from the aggregator request body: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendMalformedJSONRequestErrResponse(ctx, errorMessage)
+		return
+	}
+
+	sessionToken := ctx.Request().Header.Get(AuthTokenHeader)
+	if sessionToken == "" {
+		errorMessage := invalidAuthTokenErrMsg
+		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
+	}
+	changeRequest := aggregatorproto.AggregatorRequest{
+		SessionToken: sessionToken,
+		URL:          ctx.Request().RequestURI,
+		RequestBody:  request,
+	}
+	resp, err := a.ChangeConnectionMethodRPC(ctxt, changeRequest)
+	if err != nil {
+		errorMessage := "RPC error: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+
+	sendAggregatorResponse(ctx, resp)
+}
+
 // CreateAggregate is the handler for creating an aggregate
 func (a *AggregatorRPCs) CreateAggregate(ctx iris.Context) {
 	defer ctx.Next()
@@ -579,6 +778,94 @@ func (a *AggregatorRPCs) SetDefaultBootOrderAggregateElements(ctx iris.Context)
 	sendAggregatorResponse(ctx, resp)
 }
 
+// RotateCredentials is the handler for rotating the BMC credentials of the elements of an aggregate
+func (a *AggregatorRPCs) RotateCredentials(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	l.LogWithFields(ctxt).Debugf("Incoming request received for rotating the credentials of an aggregate with uri %s", ctx.Request().RequestURI)
+	request, err := ctx.GetBody()
+	if err != nil {
+		errorMessage := "error while trying to read the aggregator request body: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendMalformedJSONRequestErrResponse(ctx, errorMessage)
+		return
+	}
+	// the request body is optional, the current rotation policy is used without it
+	if len(request) > 0 {
+		var req interface{}
+		if err := json.Unmarshal(request, &req); err != nil {
+			errorMessage := "error while trying to get JSON body from the aggregator request body: " + err.Error()
+			l.LogWithFields(ctxt).Error(errorMessage)
+			common.SendMalformedJSONRequestErrResponse(ctx, errorMessage)
+			return
+		}
+	}
+
+	sessionToken := ctx.Request().Header.Get(AuthTokenHeader)
+	if sessionToken == "" {
+		errorMessage := invalidAuthTokenErrMsg
+		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
+	}
+	rotateRequest := aggregatorproto.AggregatorRequest{
+		SessionToken: sessionToken,
+		URL:          ctx.Request().RequestURI,
+		RequestBody:  request,
+	}
+	resp, err := a.RotateCredentialsRPC(ctxt, rotateRequest)
+	if err != nil {
+		errorMessage := rpcCallFailedErrMsg + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+
+	sendAggregatorResponse(ctx, resp)
+}
+
+// ReplaceCertificate is the handler for replacing a certificate of a server through its plugin
+func (a *AggregatorRPCs) ReplaceCertificate(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	l.LogWithFields(ctxt).Debugf("Incoming request received for replacing a certificate with uri %s", ctx.Request().RequestURI)
+	var req interface{}
+	err := ctx.ReadJSON(&req)
+	if err != nil {
+		errorMessage := "error while trying to get JSON body from the replace certificate request body: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendMalformedJSONRequestErrResponse(ctx, errorMessage)
+		return
+	}
+	request, err := json.Marshal(req)
+	if err != nil {
+		errorMessage := "error while trying to create JSON request body: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+
+	sessionToken := ctx.Request().Header.Get(AuthTokenHeader)
+	if sessionToken == "" {
+		errorMessage := invalidAuthTokenErrMsg
+		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
+	}
+	replaceRequest := aggregatorproto.AggregatorRequest{
+		SessionToken: sessionToken,
+		URL:          ctx.Request().RequestURI,
+		RequestBody:  request,
+	}
+	resp, err := a.ReplaceCertificateRPC(ctxt, replaceRequest)
+	if err != nil {
+		errorMessage := rpcCallFailedErrMsg + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+
+	sendAggregatorResponse(ctx, resp)
+}
+
 // GetAllConnectionMethods is the handler for get all connection methods
 func (a *AggregatorRPCs) GetAllConnectionMethods(ctx iris.Context) {
 	defer ctx.Next()
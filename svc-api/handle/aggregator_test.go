@@ -50,6 +50,36 @@ func testAddAggregationSourceRPCCall(ctx context.Context, req aggregatorproto.Ag
 	return response, err
 }
 
+func testBulkAddAggregationSourcesRPCCall(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	response, err := getMockAggregatorResponse(ctx, req, http.StatusAccepted, http.StatusUnauthorized)
+	return response, err
+}
+
+func testDiscoveredBMCsRPCCall(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	response, err := getMockAggregatorResponse(ctx, req, http.StatusOK, http.StatusUnauthorized)
+	return response, err
+}
+
+func testAdoptDiscoveredBMCRPCCall(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	response, err := getMockAggregatorResponse(ctx, req, http.StatusAccepted, http.StatusUnauthorized)
+	return response, err
+}
+
+func testChangeConnectionMethodRPCCall(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	response, err := getMockAggregatorResponse(ctx, req, http.StatusAccepted, http.StatusUnauthorized)
+	return response, err
+}
+
+func testRotateCredentialsRPCCall(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	response, err := getMockAggregatorResponse(ctx, req, http.StatusAccepted, http.StatusUnauthorized)
+	return response, err
+}
+
+func testReplaceCertificateRPCCall(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	response, err := getMockAggregatorResponse(ctx, req, http.StatusOK, http.StatusUnauthorized)
+	return response, err
+}
+
 func testGetAllAggregationSourceRPC(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
 	response, err := getMockAggregatorResponse(ctx, req, http.StatusOK, http.StatusUnauthorized)
 	return response, err
@@ -291,6 +321,115 @@ func TestAddAggregationSource(t *testing.T) {
 	}
 }
 
+func TestBulkAddAggregationSources(t *testing.T) {
+	var a AggregatorRPCs
+	a.BulkAddAggregationSourcesRPC = testBulkAddAggregationSourcesRPCCall
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/AggregationService/Actions/Oem")
+	redfishRoutes.Post("/Odim.BulkAddAggregationSources", a.BulkAddAggregationSources)
+	test := httptest.New(t, testApp)
+	uri := "/redfish/v1/AggregationService/Actions/Oem/Odim.BulkAddAggregationSources"
+	manifest := "HostName,UserName,Password,ConnectionMethod\n10.0.0.1,admin,Password1234,/redfish/v1/AggregationService/ConnectionMethods/1\n"
+	bulkAddRequest := map[string]interface{}{
+		"AggregationSources": []interface{}{addAggregationSourceRequest},
+	}
+	tests := []struct {
+		name           string
+		authToken      string
+		expectedStatus int
+	}{
+		{
+			name:           "Success",
+			authToken:      "ValidToken",
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "Unauthorized error",
+			authToken:      "InvalidToken",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Internal server error",
+			authToken:      "token",
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test.POST(uri).WithHeader("X-Auth-Token", tt.authToken).WithJSON(bulkAddRequest).Expect().Status(tt.expectedStatus)
+			test.POST(uri).WithHeader("X-Auth-Token", tt.authToken).WithHeader("Content-Type", "text/csv").WithText(manifest).Expect().Status(tt.expectedStatus)
+		})
+	}
+	test.POST(uri).WithHeader("X-Auth-Token", "ValidToken").WithBytes([]byte("{")).Expect().Status(http.StatusBadRequest)
+	test.POST(uri).WithJSON(bulkAddRequest).Expect().Status(http.StatusUnauthorized)
+}
+
+func TestDiscoveredBMCs(t *testing.T) {
+	var a AggregatorRPCs
+	a.GetAllDiscoveredBMCsRPC = testDiscoveredBMCsRPCCall
+	a.GetDiscoveredBMCRPC = testDiscoveredBMCsRPCCall
+	a.AdoptDiscoveredBMCRPC = testAdoptDiscoveredBMCRPCCall
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/AggregationService/Oem/Odim")
+	redfishRoutes.Get("/DiscoveredBMCs", a.GetAllDiscoveredBMCs)
+	redfishRoutes.Get("/DiscoveredBMCs/{id}", a.GetDiscoveredBMC)
+	redfishRoutes.Post("/DiscoveredBMCs/{id}/Actions/DiscoveredBMC.Adopt", a.AdoptDiscoveredBMC)
+	test := httptest.New(t, testApp)
+	uri := "/redfish/v1/AggregationService/Oem/Odim/DiscoveredBMCs"
+	tests := []struct {
+		name        string
+		authToken   string
+		getStatus   int
+		adoptStatus int
+	}{
+		{"Success", "ValidToken", http.StatusOK, http.StatusAccepted},
+		{"Unauthorized error", "InvalidToken", http.StatusUnauthorized, http.StatusUnauthorized},
+		{"Internal server error", "token", http.StatusInternalServerError, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test.GET(uri).WithHeader("X-Auth-Token", tt.authToken).Expect().Status(tt.getStatus)
+			test.GET(uri+"/1").WithHeader("X-Auth-Token", tt.authToken).Expect().Status(tt.getStatus)
+			test.POST(uri+"/1/Actions/DiscoveredBMC.Adopt").WithHeader("X-Auth-Token", tt.authToken).Expect().Status(tt.adoptStatus)
+			test.POST(uri+"/1/Actions/DiscoveredBMC.Adopt").WithHeader("X-Auth-Token", tt.authToken).WithJSON(map[string]string{"UserName": "admin", "Password": "password"}).Expect().Status(tt.adoptStatus)
+		})
+	}
+	test.POST(uri+"/1/Actions/DiscoveredBMC.Adopt").WithHeader("X-Auth-Token", "ValidToken").WithBytes([]byte("{")).Expect().Status(http.StatusBadRequest)
+	test.GET(uri).Expect().Status(http.StatusUnauthorized)
+	test.POST(uri + "/1/Actions/DiscoveredBMC.Adopt").Expect().Status(http.StatusUnauthorized)
+}
+
+func TestChangeConnectionMethod(t *testing.T) {
+	var a AggregatorRPCs
+	a.ChangeConnectionMethodRPC = testChangeConnectionMethodRPCCall
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/AggregationService/AggregationSources")
+	redfishRoutes.Post("/{id}/Actions/Oem/Odim.ChangeConnectionMethod", a.ChangeConnectionMethod)
+	test := httptest.New(t, testApp)
+	uri := "/redfish/v1/AggregationService/AggregationSources/1/Actions/Oem/Odim.ChangeConnectionMethod"
+	changeRequest := map[string]interface{}{
+		"ConnectionMethod": map[string]string{"@odata.id": "/redfish/v1/AggregationService/ConnectionMethods/1"},
+	}
+	tests := []struct {
+		name           string
+		authToken      string
+		expectedStatus int
+	}{
+		{"Success", "ValidToken", http.StatusAccepted},
+		{"Unauthorized error", "InvalidToken", http.StatusUnauthorized},
+		{"Internal server error", "token", http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test.POST(uri).WithHeader("X-Auth-Token", tt.authToken).WithJSON(changeRequest).Expect().Status(tt.expectedStatus)
+		})
+	}
+	test.POST(uri).WithHeader("X-Auth-Token", "ValidToken").WithBytes([]byte("{")).Expect().Status(http.StatusBadRequest)
+	test.POST(uri).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusBadRequest)
+	test.POST(uri).WithJSON(changeRequest).Expect().Status(http.StatusUnauthorized)
+}
+
 func TestGetAllAggregationSource(t *testing.T) {
 	var a AggregatorRPCs
 	a.GetAllAggregationSourceRPC = testGetAllAggregationSourceRPC
@@ -628,6 +767,71 @@ func TestSetDefaultBootOrderAggregateElements(t *testing.T) {
 	).WithHeader("X-Auth-Token", "token").WithJSON(aggregateRequest).Expect().Status(http.StatusInternalServerError)
 }
 
+func TestRotateCredentials(t *testing.T) {
+	var a AggregatorRPCs
+	a.RotateCredentialsRPC = testRotateCredentialsRPCCall
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/AggregationService/Aggregates")
+	redfishRoutes.Post("/{id}/Actions/Oem/Odim.RotateCredentials", a.RotateCredentials)
+	test := httptest.New(t, testApp)
+	uri := "/redfish/v1/AggregationService/Aggregates/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/Oem/Odim.RotateCredentials"
+	rotateRequest := map[string]interface{}{
+		"IntervalInDays": 90,
+		"PasswordLength": 16,
+	}
+	tests := []struct {
+		name           string
+		authToken      string
+		expectedStatus int
+	}{
+		{"Success", "ValidToken", http.StatusAccepted},
+		{"Unauthorized error", "InvalidToken", http.StatusUnauthorized},
+		{"Internal server error", "token", http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test.POST(uri).WithHeader("X-Auth-Token", tt.authToken).WithJSON(rotateRequest).Expect().Status(tt.expectedStatus)
+		})
+	}
+	// the request body is optional
+	test.POST(uri).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusAccepted)
+	test.POST(uri).WithHeader("X-Auth-Token", "ValidToken").WithBytes([]byte("{")).Expect().Status(http.StatusBadRequest)
+	test.POST(uri).WithJSON(rotateRequest).Expect().Status(http.StatusUnauthorized)
+}
+
+func TestReplaceCertificate(t *testing.T) {
+	var a AggregatorRPCs
+	a.ReplaceCertificateRPC = testReplaceCertificateRPCCall
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/CertificateService")
+	redfishRoutes.Post("/Actions/CertificateService.ReplaceCertificate", a.ReplaceCertificate)
+	test := httptest.New(t, testApp)
+	uri := "/redfish/v1/CertificateService/Actions/CertificateService.ReplaceCertificate"
+	replaceRequest := map[string]interface{}{
+		"CertificateUri": map[string]string{
+			"@odata.id": "/redfish/v1/Managers/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1/NetworkProtocol/HTTPS/Certificates/1",
+		},
+		"CertificateString": "-----BEGIN CERTIFICATE-----",
+		"CertificateType":   "PEM",
+	}
+	tests := []struct {
+		name           string
+		authToken      string
+		expectedStatus int
+	}{
+		{"Success", "ValidToken", http.StatusOK},
+		{"Unauthorized error", "InvalidToken", http.StatusUnauthorized},
+		{"Internal server error", "token", http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test.POST(uri).WithHeader("X-Auth-Token", tt.authToken).WithJSON(replaceRequest).Expect().Status(tt.expectedStatus)
+		})
+	}
+	test.POST(uri).WithHeader("X-Auth-Token", "ValidToken").WithBytes([]byte("{")).Expect().Status(http.StatusBadRequest)
+	test.POST(uri).WithJSON(replaceRequest).Expect().Status(http.StatusUnauthorized)
+}
+
 func TestGetAllConnectionMethods(t *testing.T) {
 	var a AggregatorRPCs
 	a.GetAllConnectionMethodsRPC = testGetAggregateRPCCall
@@ -0,0 +1,158 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package handle ...
+package handle
+
+import (
+	"encoding/json"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	accountproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/account"
+	iris "github.com/kataras/iris/v12"
+)
+
+// CreateAPIKey defines the CreateAPIKey iris handler.
+// The method extract the session token and the request body and creates the RPC request.
+// After the RPC call the method will feed the response to the iris
+// and gives out a proper response.
+func (a *AccountRPCs) CreateAPIKey(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	var req interface{}
+	if err := ctx.ReadJSON(&req); err != nil {
+		errorMessage := "error while trying to get JSON body from the api key create request body: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendMalformedJSONRequestErrResponse(ctx, errorMessage)
+		return
+	}
+	l.LogWithFields(ctxt).Debug("Incoming request for create api key received")
+	sessionToken := ctx.Request().Header.Get(AuthTokenHeader)
+	if sessionToken == "" {
+		errorMessage := invalidAuthTokenErrMsg
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
+	}
+
+	request, _ := json.Marshal(req)
+	createRequest := accountproto.APIKeyRequest{
+		SessionToken: sessionToken,
+		RequestBody:  request,
+	}
+	resp, err := a.CreateAPIKeyRPC(ctxt, createRequest)
+	if err != nil && resp == nil {
+		errorMessage := rpcCallFailedErrMsg + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+
+	// the body carries the key, so only the status is logged
+	sendAccountResponse(ctx, resp)
+	l.LogWithFields(ctxt).Debugf("Outgoing response for create api key with response status %d", int(resp.StatusCode))
+}
+
+// GetAllAPIKeys defines the GetAllAPIKeys iris handler.
+// The method extract the session token and creates the RPC request.
+// After the RPC call the method will feed the response to the iris
+// and gives out a proper response.
+func (a *AccountRPCs) GetAllAPIKeys(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	req := accountproto.APIKeyRequest{
+		SessionToken: ctx.Request().Header.Get(AuthTokenHeader),
+	}
+	l.LogWithFields(ctxt).Debug("Incoming request for get all api keys received")
+	if req.SessionToken == "" {
+		errorMessage := invalidAuthTokenErrMsg
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
+	}
+
+	resp, err := a.GetAllAPIKeysRPC(ctxt, req)
+	if err != nil && resp == nil {
+		errorMessage := rpcCallFailedErrMsg + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+
+	sendAccountResponse(ctx, resp)
+	l.LogWithFields(ctxt).Debugf("Outgoing response for get all api keys is %s and response status %d", string(resp.Body), int(resp.StatusCode))
+}
+
+// GetAPIKey defines the GetAPIKey iris handler.
+// The method extract the session token and the key id and creates the RPC request.
+// After the RPC call the method will feed the response to the iris
+// and gives out a proper response.
+func (a *AccountRPCs) GetAPIKey(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	req := accountproto.APIKeyRequest{
+		SessionToken: ctx.Request().Header.Get(AuthTokenHeader),
+		KeyID:        ctx.Params().Get("id"),
+	}
+	l.LogWithFields(ctxt).Debugf("Incoming request for get api key received with %s", req.KeyID)
+	if req.SessionToken == "" {
+		errorMessage := invalidAuthTokenErrMsg
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
+	}
+
+	resp, err := a.GetAPIKeyRPC(ctxt, req)
+	if err != nil && resp == nil {
+		errorMessage := rpcCallFailedErrMsg + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+
+	sendAccountResponse(ctx, resp)
+	l.LogWithFields(ctxt).Debugf("Outgoing response for get api key is %s and response status %d", string(resp.Body), int(resp.StatusCode))
+}
+
+// DeleteAPIKey defines the DeleteAPIKey iris handler.
+// The method extract the session token and the key id and creates the RPC request.
+// After the RPC call the method will feed the response to the iris
+// and gives out a proper response.
+func (a *AccountRPCs) DeleteAPIKey(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	req := accountproto.APIKeyRequest{
+		SessionToken: ctx.Request().Header.Get(AuthTokenHeader),
+		KeyID:        ctx.Params().Get("id"),
+	}
+	l.LogWithFields(ctxt).Debugf("Incoming request for deleting api key received with %s", req.KeyID)
+	if req.SessionToken == "" {
+		errorMessage := invalidAuthTokenErrMsg
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
+	}
+
+	resp, err := a.DeleteAPIKeyRPC(ctxt, req)
+	if err != nil && resp == nil {
+		errorMessage := rpcCallFailedErrMsg + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+
+	sendAccountResponse(ctx, resp)
+	l.LogWithFields(ctxt).Debugf("Outgoing response for deleting api key %s with response status %d", req.KeyID, int(resp.StatusCode))
+}
@@ -0,0 +1,123 @@
+// (C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package handle
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	accountproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/account"
+	iris "github.com/kataras/iris/v12"
+	"github.com/kataras/iris/v12/httptest"
+)
+
+func mockAPIKeyRPC(statusCode int32) func(context.Context, accountproto.APIKeyRequest) (*accountproto.AccountResponse, error) {
+	return func(ctx context.Context, req accountproto.APIKeyRequest) (*accountproto.AccountResponse, error) {
+		if req.SessionToken == "TokenRPC" {
+			return nil, errors.New("RPC Error")
+		}
+		return &accountproto.AccountResponse{
+			StatusCode: statusCode,
+		}, nil
+	}
+}
+
+func TestAccountRPCs_CreateAPIKey(t *testing.T) {
+	var a AccountRPCs
+	a.CreateAPIKeyRPC = mockAPIKeyRPC(http.StatusCreated)
+
+	body := map[string]interface{}{
+		"Name":       "someKey",
+		"Privileges": []string{"Login"},
+	}
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1")
+	redfishRoutes.Post("/AccountService/APIKeys", a.CreateAPIKey)
+
+	e := httptest.New(t, mockApp)
+	e.POST(
+		"/redfish/v1/AccountService/APIKeys",
+	).WithHeader("X-Auth-Token", "Token").WithJSON(body).Expect().Status(http.StatusCreated)
+	e.POST(
+		"/redfish/v1/AccountService/APIKeys",
+	).WithHeader("X-Auth-Token", "").WithJSON(body).Expect().Status(http.StatusUnauthorized)
+	e.POST(
+		"/redfish/v1/AccountService/APIKeys",
+	).WithHeader("X-Auth-Token", "Token").Expect().Status(http.StatusBadRequest)
+	e.POST(
+		"/redfish/v1/AccountService/APIKeys",
+	).WithHeader("X-Auth-Token", "TokenRPC").WithJSON(body).Expect().Status(http.StatusInternalServerError)
+}
+
+func TestAccountRPCs_GetAllAPIKeys(t *testing.T) {
+	var a AccountRPCs
+	a.GetAllAPIKeysRPC = mockAPIKeyRPC(http.StatusOK)
+
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1")
+	redfishRoutes.Get("/AccountService/APIKeys", a.GetAllAPIKeys)
+
+	e := httptest.New(t, mockApp)
+	e.GET(
+		"/redfish/v1/AccountService/APIKeys",
+	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusOK)
+	e.GET(
+		"/redfish/v1/AccountService/APIKeys",
+	).WithHeader("X-Auth-Token", "").Expect().Status(http.StatusUnauthorized)
+	e.GET(
+		"/redfish/v1/AccountService/APIKeys",
+	).WithHeader("X-Auth-Token", "TokenRPC").Expect().Status(http.StatusInternalServerError)
+}
+
+func TestAccountRPCs_GetAPIKey(t *testing.T) {
+	var a AccountRPCs
+	a.GetAPIKeyRPC = mockAPIKeyRPC(http.StatusOK)
+
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1")
+	redfishRoutes.Get("/AccountService/APIKeys/{id}", a.GetAPIKey)
+
+	e := httptest.New(t, mockApp)
+	e.GET(
+		"/redfish/v1/AccountService/APIKeys/someID",
+	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusOK)
+	e.GET(
+		"/redfish/v1/AccountService/APIKeys/someID",
+	).Expect().Status(http.StatusUnauthorized)
+	e.GET(
+		"/redfish/v1/AccountService/APIKeys/someID",
+	).WithHeader("X-Auth-Token", "TokenRPC").Expect().Status(http.StatusInternalServerError)
+}
+
+func TestAccountRPCs_DeleteAPIKey(t *testing.T) {
+	var a AccountRPCs
+	a.DeleteAPIKeyRPC = mockAPIKeyRPC(http.StatusNoContent)
+
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1")
+	redfishRoutes.Delete("/AccountService/APIKeys/{id}", a.DeleteAPIKey)
+
+	e := httptest.New(t, mockApp)
+	e.DELETE(
+		"/redfish/v1/AccountService/APIKeys/someID",
+	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusNoContent)
+	e.DELETE(
+		"/redfish/v1/AccountService/APIKeys/someID",
+	).Expect().Status(http.StatusUnauthorized)
+	e.DELETE(
+		"/redfish/v1/AccountService/APIKeys/someID",
+	).WithHeader("X-Auth-Token", "TokenRPC").Expect().Status(http.StatusInternalServerError)
+}
@@ -1079,6 +1079,10 @@ func AsMethodNotAllowed(ctx iris.Context) {
 		ctx.ResponseWriter().Header().Set("Allow", "GET, POST")
 	case "/redfish/v1/AccountService/Accounts/" + id:
 		ctx.ResponseWriter().Header().Set("Allow", "GET, PATCH, DELETE")
+	case "/redfish/v1/AccountService/APIKeys":
+		ctx.ResponseWriter().Header().Set("Allow", "GET, POST")
+	case "/redfish/v1/AccountService/APIKeys/" + id:
+		ctx.ResponseWriter().Header().Set("Allow", "GET, DELETE")
 	default:
 		ctx.ResponseWriter().Header().Set("Allow", "GET")
 	}
@@ -1218,6 +1222,8 @@ func ManagersMethodNotAllowed(ctx iris.Context) {
 		ctx.ResponseWriter().Header().Set("Allow", "POST")
 	case "/redfish/v1/Managers/" + systemID + "/VirtualMedia/" + subID + "/Actions/VirtualMedia.InsertMedia":
 		ctx.ResponseWriter().Header().Set("Allow", "POST")
+	case "/redfish/v1/Managers/" + systemID + "/Oem/Odim/LogLevel":
+		ctx.ResponseWriter().Header().Set("Allow", "GET, PATCH")
 	default:
 		ctx.ResponseWriter().Header().Set("Allow", "GET")
 	}
@@ -1239,6 +1245,36 @@ func UpdateServiceMethodNotAllowed(ctx iris.Context) {
 	fillMethodNotAllowedErrorResponse(ctx)
 }
 
+// FirmwareBaselinesMethodNotAllowed builds the response for the unallowed http operation on the firmware baseline collection and returns 405 error.
+func FirmwareBaselinesMethodNotAllowed(ctx iris.Context) {
+	defer ctx.Next()
+	ctx.ResponseWriter().Header().Set("Allow", "GET, POST")
+	fillMethodNotAllowedErrorResponse(ctx)
+}
+
+// FirmwareBaselineMethodNotAllowed builds the response for the unallowed http operation on a firmware baseline and returns 405 error.
+func FirmwareBaselineMethodNotAllowed(ctx iris.Context) {
+	defer ctx.Next()
+	ctx.ResponseWriter().Header().Set("Allow", "GET, DELETE")
+	fillMethodNotAllowedErrorResponse(ctx)
+}
+
+// TelemetryCollectionMethodNotAllowed builds the response for the unallowed http operation on the
+// MetricReportDefinitions and Triggers collections and returns 405 error.
+func TelemetryCollectionMethodNotAllowed(ctx iris.Context) {
+	defer ctx.Next()
+	ctx.ResponseWriter().Header().Set("Allow", "GET, POST")
+	fillMethodNotAllowedErrorResponse(ctx)
+}
+
+// TelemetryResourceMethodNotAllowed builds the response for the unallowed http operation on a
+// MetricReportDefinition or Triggers resource and returns 405 error.
+func TelemetryResourceMethodNotAllowed(ctx iris.Context) {
+	defer ctx.Next()
+	ctx.ResponseWriter().Header().Set("Allow", "GET, PATCH, DELETE")
+	fillMethodNotAllowedErrorResponse(ctx)
+}
+
 // MethodNotAllowed fills status code and status message for MethodNotAllowed responses
 func MethodNotAllowed(ctx iris.Context) {
 	defer ctx.Next()
@@ -1246,6 +1282,14 @@ func MethodNotAllowed(ctx iris.Context) {
 	fillMethodNotAllowedErrorResponse(ctx)
 }
 
+// CertificateServiceMethodNotAllowed builds the response for the unallowed http operations on the
+// actions of the CertificateService and returns 405 error.
+func CertificateServiceMethodNotAllowed(ctx iris.Context) {
+	defer ctx.Next()
+	ctx.ResponseWriter().Header().Set("Allow", "POST")
+	fillMethodNotAllowedErrorResponse(ctx)
+}
+
 // ChassisMethodNotAllowed holds builds reponse for the unallowed http operation on Chassis URLs and returns 405 error.
 func ChassisMethodNotAllowed(ctx iris.Context) {
 	defer ctx.Next()
@@ -1291,10 +1335,20 @@ func AggMethodNotAllowed(ctx iris.Context) {
 		ctx.ResponseWriter().Header().Set("Allow", "POST")
 	case "/redfish/v1/AggregationService/Actions/AggregationService.Reset":
 		ctx.ResponseWriter().Header().Set("Allow", "POST")
+	case "/redfish/v1/AggregationService/Actions/Oem/Odim.BulkAddAggregationSources":
+		ctx.ResponseWriter().Header().Set("Allow", "POST")
+	case "/redfish/v1/AggregationService/Oem/Odim/DiscoveredBMCs":
+		ctx.ResponseWriter().Header().Set("Allow", "GET")
+	case "/redfish/v1/AggregationService/Oem/Odim/DiscoveredBMCs/" + id:
+		ctx.ResponseWriter().Header().Set("Allow", "GET")
+	case "/redfish/v1/AggregationService/Oem/Odim/DiscoveredBMCs/" + id + "/Actions/DiscoveredBMC.Adopt":
+		ctx.ResponseWriter().Header().Set("Allow", "POST")
 	case "/redfish/v1/AggregationService/AggregationSources":
 		ctx.ResponseWriter().Header().Set("Allow", "GET, POST")
 	case "/redfish/v1/AggregationService/AggregationSources/" + id:
 		ctx.ResponseWriter().Header().Set("Allow", "GET, PATCH, DELETE")
+	case "/redfish/v1/AggregationService/AggregationSources/" + id + "/Actions/Oem/Odim.ChangeConnectionMethod":
+		ctx.ResponseWriter().Header().Set("Allow", "POST")
 	case "/redfish/v1/AggregationService/ConnectionMethods":
 		ctx.ResponseWriter().Header().Set("Allow", "GET")
 	case "/redfish/v1/AggregationService/ConnectionMethods/" + id:
@@ -1332,6 +1386,8 @@ func AggregateMethodNotAllowed(ctx iris.Context) {
 		ctx.ResponseWriter().Header().Set("Allow", "POST")
 	case "/redfish/v1/AggregationService/Aggregates/" + aggregateID + "Actions/Aggregate.SetDefaultBootOrder/":
 		ctx.ResponseWriter().Header().Set("Allow", "POST")
+	case "/redfish/v1/AggregationService/Aggregates/" + aggregateID + "/Actions/Oem/Odim.RotateCredentials":
+		ctx.ResponseWriter().Header().Set("Allow", "POST")
 	}
 	fillMethodNotAllowedErrorResponse(ctx)
 }
@@ -36,6 +36,8 @@ type ManagersRPCs struct {
 	CreateRemoteAccountServiceRPC func(ctx context.Context, req managersproto.ManagerRequest) (*managersproto.ManagerResponse, error)
 	UpdateRemoteAccountServiceRPC func(ctx context.Context, req managersproto.ManagerRequest) (*managersproto.ManagerResponse, error)
 	DeleteRemoteAccountServiceRPC func(ctx context.Context, req managersproto.ManagerRequest) (*managersproto.ManagerResponse, error)
+	GetLogLevelRPC                func(ctx context.Context, req managersproto.ManagerRequest) (*managersproto.ManagerResponse, error)
+	SetLogLevelRPC                func(ctx context.Context, req managersproto.ManagerRequest) (*managersproto.ManagerResponse, error)
 }
 
 // GetManagersCollection fetches all managers
@@ -317,6 +319,72 @@ func (mgr *ManagersRPCs) DeleteRemoteAccountService(ctx iris.Context) {
 	sendManagersResponse(ctx, resp)
 }
 
+// GetLogLevel defines the GetLogLevel iris handler.
+// The method returns the log level of the ODIM services along with
+// the overrides which are active.
+func (mgr *ManagersRPCs) GetLogLevel(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	req := getManagerRequest(ctx)
+	l.LogWithFields(ctxt).Debugf("Incoming request received for the getting log level of manager with id %s", req.ManagerID)
+	if req.SessionToken == "" {
+		errorMessage := invalidAuthTokenErrorMsg
+		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
+	}
+	resp, err := mgr.GetLogLevelRPC(ctxt, req)
+	if err != nil {
+		errorMessage := "error:  RPC error:" + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+	l.LogWithFields(ctxt).Debugf("Outgoing response for getting log level is %s and response status %d", string(resp.Body), int(resp.StatusCode))
+	ctx.ResponseWriter().Header().Set("Allow", "GET, PATCH")
+	sendManagersResponse(ctx, resp)
+}
+
+// SetLogLevel defines the SetLogLevel iris handler.
+// The method extracts the session token, uuid and request payload and
+// creates the RPC request to override the log level of the ODIM services
+// for a bounded time.
+func (mgr *ManagersRPCs) SetLogLevel(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	var reqIn interface{}
+	err := ctx.ReadJSON(&reqIn)
+	if err != nil {
+		errorMessage := "while trying to get JSON body from the log level request body: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendMalformedJSONRequestErrResponse(ctx, errorMessage)
+		return
+	}
+	request, err := json.Marshal(reqIn)
+	if err != nil {
+		errorMessage := "while trying to create JSON request body in log level request: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+	req := getManagerRequest(ctx)
+	req.RequestBody = request
+	l.LogWithFields(ctxt).Debugf("Incoming request received for the setting log level of manager with id %s and request body %s", req.ManagerID, string(request))
+	if req.SessionToken == "" {
+		errorMessage := invalidAuthTokenErrorMsg
+		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
+	}
+	resp, err := mgr.SetLogLevelRPC(ctxt, req)
+	if err != nil {
+		errorMessage := "error:  RPC error:" + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+	l.LogWithFields(ctxt).Debugf("Outgoing response for setting log level is %s and response status %d", string(resp.Body), int(resp.StatusCode))
+	sendManagersResponse(ctx, resp)
+}
+
 // sendManagersResponse writes the managers response to client
 func sendManagersResponse(ctx iris.Context, resp *managersproto.ManagerResponse) {
 	common.SetResponseHeader(ctx, resp.Header)
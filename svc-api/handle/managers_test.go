@@ -524,3 +524,68 @@ func TestUpdateRemoteAccountService(t *testing.T) {
 		"/redfish/v1/Managers/1A/RemoteAccountService/Accounts",
 	).WithHeader("X-Auth-Token", "").WithJSON(payload).Expect().Status(http.StatusUnauthorized)
 }
+
+func mockLogLevel(ctx context.Context, req managersproto.ManagerRequest) (*managersproto.ManagerResponse, error) {
+	if req.SessionToken != "ValidToken" {
+		return &managersproto.ManagerResponse{
+			StatusCode:    401,
+			StatusMessage: "Unauthorized",
+			Body:          []byte(`{"Response":"Unauthorized"}`),
+		}, nil
+	}
+	if req.ManagerID != "1A" {
+		return &managersproto.ManagerResponse{
+			StatusCode:    404,
+			StatusMessage: "ResourceNotFound",
+			Body:          []byte(`{"Response":"ResourceNotFound"}`),
+		}, nil
+	}
+	return &managersproto.ManagerResponse{
+		StatusCode:    200,
+		StatusMessage: "Success",
+		Body:          []byte(`{"Response":"Success"}`),
+	}, nil
+}
+
+func TestGetLogLevel(t *testing.T) {
+	var mgr ManagersRPCs
+	mgr.GetLogLevelRPC = mockLogLevel
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1/Managers")
+	redfishRoutes.Get("/{id}/Oem/Odim/LogLevel", mgr.GetLogLevel)
+	test := httptest.New(t, mockApp)
+
+	test.GET(
+		"/redfish/v1/Managers/1A/Oem/Odim/LogLevel",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+	test.GET(
+		"/redfish/v1/Managers/2A/Oem/Odim/LogLevel",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusNotFound)
+	test.GET(
+		"/redfish/v1/Managers/1A/Oem/Odim/LogLevel",
+	).WithHeader("X-Auth-Token", "").Expect().Status(http.StatusUnauthorized)
+}
+
+func TestSetLogLevel(t *testing.T) {
+	var mgr ManagersRPCs
+	mgr.SetLogLevelRPC = mockLogLevel
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1/Managers")
+	redfishRoutes.Patch("/{id}/Oem/Odim/LogLevel", mgr.SetLogLevel)
+	test := httptest.New(t, mockApp)
+
+	payload := map[string]interface{}{"LogLevel": "debug", "Package": "svc-events/events", "DurationSeconds": 600}
+
+	test.PATCH(
+		"/redfish/v1/Managers/1A/Oem/Odim/LogLevel",
+	).WithHeader("X-Auth-Token", "ValidToken").WithJSON(payload).Expect().Status(http.StatusOK)
+	test.PATCH(
+		"/redfish/v1/Managers/2A/Oem/Odim/LogLevel",
+	).WithHeader("X-Auth-Token", "ValidToken").WithJSON(payload).Expect().Status(http.StatusNotFound)
+	test.PATCH(
+		"/redfish/v1/Managers/1A/Oem/Odim/LogLevel",
+	).WithHeader("X-Auth-Token", "").WithJSON(payload).Expect().Status(http.StatusUnauthorized)
+	test.PATCH(
+		"/redfish/v1/Managers/1A/Oem/Odim/LogLevel",
+	).WithHeader("X-Auth-Token", "ValidToken").WithBytes([]byte(`{"LogLevel":`)).Expect().Status(http.StatusBadRequest)
+}
@@ -18,6 +18,7 @@ package handle
 import (
 	"context"
 	"encoding/json"
+	"strings"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
@@ -32,6 +33,7 @@ type SystemRPCs struct {
 	GetSystemResourceRPC       func(ctx context.Context, req systemsproto.GetSystemsRequest) (*systemsproto.SystemsResponse, error)
 	SystemResetRPC             func(ctx context.Context, req systemsproto.ComputerSystemResetRequest) (*systemsproto.SystemsResponse, error)
 	SetDefaultBootOrderRPC     func(ctx context.Context, req systemsproto.DefaultBootOrderRequest) (*systemsproto.SystemsResponse, error)
+	SystemOemActionRPC         func(ctx context.Context, req systemsproto.OemActionRequest) (*systemsproto.SystemsResponse, error)
 	ChangeBiosSettingsRPC      func(ctx context.Context, req systemsproto.BiosSettingsRequest) (*systemsproto.SystemsResponse, error)
 	ChangeBootOrderSettingsRPC func(ctx context.Context, req systemsproto.BootOrderSettingsRequest) (*systemsproto.SystemsResponse, error)
 	CreateVolumeRPC            func(ctx context.Context, req systemsproto.VolumeRequest) (*systemsproto.SystemsResponse, error)
@@ -116,7 +118,9 @@ func (sys *SystemRPCs) GetSystemResource(ctx iris.Context) {
 	}
 
 	storageID := ctx.Params().Get("id2")
-	switch req.URL {
+	// the query parameters, like refresh, don't change the methods allowed on the resource
+	resourceURI := strings.SplitN(req.URL, "?", 2)[0]
+	switch resourceURI {
 	case "/redfish/v1/Systems/" + req.RequestParam + "/Bios/Settings":
 		ctx.ResponseWriter().Header().Set("Allow", "GET, PATCH")
 	case "/redfish/v1/Systems/" + req.RequestParam + "/Storage/" + storageID + "/Volumes":
@@ -168,6 +172,51 @@ func (sys *SystemRPCs) ComputerSystemReset(ctx iris.Context) {
 	sendSystemsResponse(ctx, resp)
 }
 
+// SystemOemAction is the handler to perform an OEM action of a computer system
+// from iris context will get the request and check sessiontoken
+// and do rpc call and send response back
+func (sys *SystemRPCs) SystemOemAction(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	var req interface{}
+	err := ctx.ReadJSON(&req)
+	if err != nil {
+		errorMessage := "error while trying to get JSON body from the OEM action request body: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendMalformedJSONRequestErrResponse(ctx, errorMessage)
+		return
+	}
+	sessionToken := ctx.Request().Header.Get(AuthTokenHeader)
+	if sessionToken == "" {
+		errorMessage := invalidAuthTokenErrorMsg
+		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
+	}
+	request, err := json.Marshal(req)
+	if err != nil {
+		errorMessage := "error while trying to create JSON request body: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+	actionRequest := systemsproto.OemActionRequest{
+		SessionToken: sessionToken,
+		SystemID:     ctx.Params().Get("id"),
+		Action:       ctx.Params().Get("action"),
+		RequestBody:  request,
+	}
+	l.LogWithFields(ctxt).Debugf("Incoming request received for the OEM action %s of computer system %s with request body %s", actionRequest.Action, actionRequest.SystemID, string(request))
+	resp, err := sys.SystemOemActionRPC(ctxt, actionRequest)
+	if err != nil {
+		errorMessage := rpcFailedErrMsg + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+	l.LogWithFields(ctxt).Debugf("Outgoing response for the OEM action of computer system is %s with status code %d", string(resp.Body), int(resp.StatusCode))
+	sendSystemsResponse(ctx, resp)
+}
+
 // SetDefaultBootOrder is the handler to set default boot order
 // from iris context will get the request and check sessiontoken
 // and do rpc call and send response back
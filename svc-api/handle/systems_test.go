@@ -191,6 +191,19 @@ func TestSystemRPCs_GetSystemResource(t *testing.T) {
 	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusOK)
 }
 
+func TestSystemRPCs_GetSystemResourceWithRefresh(t *testing.T) {
+	var sys SystemRPCs
+	sys.GetSystemResourceRPC = mockGetSystemResource
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1/Systems")
+	redfishRoutes.Get("/{id}/SecureBoot", sys.GetSystemResource)
+
+	e := httptest.New(t, mockApp)
+	e.GET(
+		"/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1/SecureBoot",
+	).WithQuery("refresh", "true").WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusOK).Header("Allow").Equal("GET, PATCH")
+}
+
 func TestSystemRPCs_GetSystemResourceRPCError(t *testing.T) {
 	var sys SystemRPCs
 	sys.GetSystemResourceRPC = mockGetSystemResourceRPCError
@@ -430,6 +443,46 @@ func TestComputerSystemResetWithValidData(t *testing.T) {
 	).WithJSON(map[string]string{"Sample": "Body"}).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK).Headers().Equal(header)
 }
 
+func mockSystemOemAction(ctx context.Context, req systemsproto.OemActionRequest) (*systemsproto.SystemsResponse, error) {
+	if req.SessionToken == "TokenRPC" {
+		return &systemsproto.SystemsResponse{}, errors.New("Unable to RPC Call")
+	}
+	if req.SystemID != "123" || req.Action != "Hpe/HpeComputerSystemExt.PowerButton" {
+		return &systemsproto.SystemsResponse{
+			StatusCode:    400,
+			StatusMessage: "ActionNotSupported",
+			Body:          []byte(`{"Response":"ActionNotSupported"}`),
+		}, nil
+	}
+	return &systemsproto.SystemsResponse{
+		StatusCode:    200,
+		StatusMessage: "Success",
+		Body:          []byte(`{"Response":"Success"}`),
+	}, nil
+}
+
+func TestSystemOemAction(t *testing.T) {
+	var sys SystemRPCs
+	sys.SystemOemActionRPC = mockSystemOemAction
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1/Systems")
+	redfishRoutes.Post("/{id}/Actions/Oem/{action:path}", sys.SystemOemAction)
+
+	e := httptest.New(t, mockApp)
+	e.POST(
+		"/redfish/v1/Systems/123/Actions/Oem/Hpe/HpeComputerSystemExt.PowerButton",
+	).WithJSON(map[string]string{"PushType": "Press"}).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+	e.POST(
+		"/redfish/v1/Systems/123/Actions/Oem/Hpe/HpeComputerSystemExt.Unknown",
+	).WithJSON(map[string]string{"PushType": "Press"}).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusBadRequest)
+	e.POST(
+		"/redfish/v1/Systems/123/Actions/Oem/Hpe/HpeComputerSystemExt.PowerButton",
+	).WithJSON(map[string]string{"PushType": "Press"}).WithHeader("X-Auth-Token", "").Expect().Status(http.StatusUnauthorized)
+	e.POST(
+		"/redfish/v1/Systems/123/Actions/Oem/Hpe/HpeComputerSystemExt.PowerButton",
+	).WithJSON(map[string]string{"PushType": "Press"}).WithHeader("X-Auth-Token", "TokenRPC").Expect().Status(http.StatusInternalServerError)
+}
+
 func mockSetDefaultBootOrder(ctx context.Context, req systemsproto.DefaultBootOrderRequest) (*systemsproto.SystemsResponse, error) {
 	var response = &systemsproto.SystemsResponse{}
 	if req.SessionToken == "" {
@@ -17,6 +17,7 @@ package handle
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
@@ -36,6 +37,13 @@ type TelemetryRPCs struct {
 	GetMetricReportRPC                     func(context.Context, telemetryproto.TelemetryRequest) (*telemetryproto.TelemetryResponse, error)
 	GetTriggerRPC                          func(context.Context, telemetryproto.TelemetryRequest) (*telemetryproto.TelemetryResponse, error)
 	UpdateTriggerRPC                       func(context.Context, telemetryproto.TelemetryRequest) (*telemetryproto.TelemetryResponse, error)
+	GetMetricHistoryRPC                    func(context.Context, telemetryproto.TelemetryRequest) (*telemetryproto.TelemetryResponse, error)
+	GetOpenMetricsRPC                      func(context.Context, telemetryproto.TelemetryRequest) (*telemetryproto.TelemetryResponse, error)
+	CreateMetricReportDefinitionRPC        func(context.Context, telemetryproto.TelemetryRequest) (*telemetryproto.TelemetryResponse, error)
+	UpdateMetricReportDefinitionRPC        func(context.Context, telemetryproto.TelemetryRequest) (*telemetryproto.TelemetryResponse, error)
+	DeleteMetricReportDefinitionRPC        func(context.Context, telemetryproto.TelemetryRequest) (*telemetryproto.TelemetryResponse, error)
+	CreateTriggerRPC                       func(context.Context, telemetryproto.TelemetryRequest) (*telemetryproto.TelemetryResponse, error)
+	DeleteTriggerRPC                       func(context.Context, telemetryproto.TelemetryRequest) (*telemetryproto.TelemetryResponse, error)
 }
 
 const (
@@ -116,7 +124,7 @@ func (a *TelemetryRPCs) GetMetricReportDefinitionCollection(ctx iris.Context) {
 		common.SendFailedRPCCallResponse(ctx, errorMessage)
 	}
 	l.LogWithFields(ctxt).Debugf("Outgoing response for getting metric report definition collection is %s with status code %d", string(resp.Body), int(resp.StatusCode))
-	ctx.ResponseWriter().Header().Set("Allow", "GET")
+	ctx.ResponseWriter().Header().Set("Allow", "GET, POST")
 	common.SetResponseHeader(ctx, resp.Header)
 	ctx.StatusCode(int(resp.StatusCode))
 	ctx.Write(resp.Body)
@@ -170,7 +178,7 @@ func (a *TelemetryRPCs) GetTriggerCollection(ctx iris.Context) {
 		common.SendFailedRPCCallResponse(ctx, errorMessage)
 	}
 	l.LogWithFields(ctxt).Debugf("Outgoing response for getting trigger collection is %s with status code %d", string(resp.Body), int(resp.StatusCode))
-	ctx.ResponseWriter().Header().Set("Allow", "GET")
+	ctx.ResponseWriter().Header().Set("Allow", "GET, POST")
 	common.SetResponseHeader(ctx, resp.Header)
 	ctx.StatusCode(int(resp.StatusCode))
 	ctx.Write(resp.Body)
@@ -224,7 +232,7 @@ func (a *TelemetryRPCs) GetMetricReportDefinition(ctx iris.Context) {
 		common.SendFailedRPCCallResponse(ctx, errorMessage)
 	}
 	l.LogWithFields(ctxt).Debugf("Outgoing response for getting metric report definition is %s with status code %d", string(resp.Body), int(resp.StatusCode))
-	ctx.ResponseWriter().Header().Set("Allow", "GET")
+	ctx.ResponseWriter().Header().Set("Allow", "GET, PATCH, DELETE")
 	common.SetResponseHeader(ctx, resp.Header)
 	ctx.StatusCode(int(resp.StatusCode))
 	ctx.Write(resp.Body)
@@ -278,34 +286,137 @@ func (a *TelemetryRPCs) GetTrigger(ctx iris.Context) {
 		common.SendFailedRPCCallResponse(ctx, errorMessage)
 	}
 	l.LogWithFields(ctxt).Debugf("Outgoing response for getting trigger details is %s with status code %d", string(resp.Body), int(resp.StatusCode))
-	ctx.ResponseWriter().Header().Set("Allow", "GET, PATCH")
+	ctx.ResponseWriter().Header().Set("Allow", "GET, PATCH, DELETE")
 	common.SetResponseHeader(ctx, resp.Header)
 	ctx.StatusCode(int(resp.StatusCode))
 	ctx.Write(resp.Body)
 
 }
 
-// UpdateTrigger is the handler for getting TelemetryService details
+// UpdateTrigger is the handler for updating a Triggers resource created through ODIM
 func (a *TelemetryRPCs) UpdateTrigger(ctx iris.Context) {
+	a.configureTelemetryResource(ctx, a.UpdateTriggerRPC, "updating trigger", true)
+}
+
+// CreateMetricReportDefinition is the handler for creating a MetricReportDefinition,
+// the metric report of the definition is computed by ODIM from the servers
+func (a *TelemetryRPCs) CreateMetricReportDefinition(ctx iris.Context) {
+	a.configureTelemetryResource(ctx, a.CreateMetricReportDefinitionRPC, "creating metric report definition", true)
+}
+
+// UpdateMetricReportDefinition is the handler for updating a MetricReportDefinition created through ODIM
+func (a *TelemetryRPCs) UpdateMetricReportDefinition(ctx iris.Context) {
+	a.configureTelemetryResource(ctx, a.UpdateMetricReportDefinitionRPC, "updating metric report definition", true)
+}
+
+// DeleteMetricReportDefinition is the handler for deleting a MetricReportDefinition created through ODIM
+func (a *TelemetryRPCs) DeleteMetricReportDefinition(ctx iris.Context) {
+	a.configureTelemetryResource(ctx, a.DeleteMetricReportDefinitionRPC, "deleting metric report definition", false)
+}
+
+// CreateTrigger is the handler for creating a Triggers resource evaluated by ODIM
+func (a *TelemetryRPCs) CreateTrigger(ctx iris.Context) {
+	a.configureTelemetryResource(ctx, a.CreateTriggerRPC, "creating trigger", true)
+}
+
+// DeleteTrigger is the handler for deleting a Triggers resource created through ODIM
+func (a *TelemetryRPCs) DeleteTrigger(ctx iris.Context) {
+	a.configureTelemetryResource(ctx, a.DeleteTriggerRPC, "deleting trigger", false)
+}
+
+// configureTelemetryResource reads the request body if the operation has one
+// and sends the request to the telemetry service
+func (a *TelemetryRPCs) configureTelemetryResource(ctx iris.Context, configure func(context.Context, telemetryproto.TelemetryRequest) (*telemetryproto.TelemetryResponse, error),
+	operation string, withBody bool) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	req := telemetryproto.TelemetryRequest{
+		SessionToken: ctx.Request().Header.Get(AuthTokenHeader),
+		URL:          ctx.Request().RequestURI,
+	}
+	if withBody {
+		var body interface{}
+		if err := ctx.ReadJSON(&body); err != nil {
+			errorMessage := "error while trying to get JSON body from the request body for " + operation + ": " + err.Error()
+			l.LogWithFields(ctxt).Error(errorMessage)
+			common.SendMalformedJSONRequestErrResponse(ctx, errorMessage)
+			return
+		}
+		req.RequestBody, _ = json.Marshal(body)
+	}
+	if req.SessionToken == "" {
+		errorMessage := invalidAuthTokenErrorMsg
+		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
+	}
+	l.LogWithFields(ctxt).Debugf("Incoming request received for %s with request URI %s and request body %s", operation, req.URL, string(req.RequestBody))
+	resp, err := configure(ctxt, req)
+	if err != nil {
+		errorMessage := rpcCallFailedErrorMsg + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+	l.LogWithFields(ctxt).Debugf("Outgoing response for %s is %s with status code %d", operation, string(resp.Body), int(resp.StatusCode))
+	common.SetResponseHeader(ctx, resp.Header)
+	ctx.StatusCode(int(resp.StatusCode))
+	ctx.Write(resp.Body)
+}
+
+// GetMetricHistory is the handler for getting the metric values kept in the metric history,
+// the resource, the metric and the time window are given as query parameters
+func (a *TelemetryRPCs) GetMetricHistory(ctx iris.Context) {
 	defer ctx.Next()
 	ctxt := ctx.Request().Context()
 	req := telemetryproto.TelemetryRequest{
 		SessionToken: ctx.Request().Header.Get(AuthTokenHeader),
+		URL:          ctx.Request().RequestURI,
 	}
-	l.LogWithFields(ctxt).Debug("Incoming request received for updating trigger")
+	l.LogWithFields(ctxt).Debugf("Incoming request received for getting metric history with request URI %s", req.URL)
 	if req.SessionToken == "" {
 		errorMessage := invalidAuthTokenErrorMsg
 		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
 	}
-	resp, err := a.UpdateTriggerRPC(ctxt, req)
+	resp, err := a.GetMetricHistoryRPC(ctxt, req)
 	if err != nil {
 		errorMessage := rpcCallFailedErrorMsg + err.Error()
 		l.LogWithFields(ctxt).Error(errorMessage)
 		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
 	}
-	l.LogWithFields(ctxt).Debugf("Outgoing response for updating trigger is %s with status code %d", string(resp.Body), int(resp.StatusCode))
+	l.LogWithFields(ctxt).Debugf("Outgoing response for getting metric history is %s with status code %d", string(resp.Body), int(resp.StatusCode))
+	ctx.ResponseWriter().Header().Set("Allow", "GET")
 	common.SetResponseHeader(ctx, resp.Header)
 	ctx.StatusCode(int(resp.StatusCode))
 	ctx.Write(resp.Body)
+}
 
+// GetOpenMetrics is the handler for getting the latest metric values in the OpenMetrics
+// text format, for being scraped by Prometheus
+func (a *TelemetryRPCs) GetOpenMetrics(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	req := telemetryproto.TelemetryRequest{
+		SessionToken: ctx.Request().Header.Get(AuthTokenHeader),
+		URL:          ctx.Request().RequestURI,
+	}
+	l.LogWithFields(ctxt).Debugf("Incoming request received for getting OpenMetrics with request URI %s", req.URL)
+	if req.SessionToken == "" {
+		errorMessage := invalidAuthTokenErrorMsg
+		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
+	}
+	resp, err := a.GetOpenMetricsRPC(ctxt, req)
+	if err != nil {
+		errorMessage := rpcCallFailedErrorMsg + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+	l.LogWithFields(ctxt).Debugf("Outgoing response for getting OpenMetrics has status code %d", int(resp.StatusCode))
+	ctx.ResponseWriter().Header().Set("Allow", "GET")
+	common.SetResponseHeader(ctx, resp.Header)
+	ctx.StatusCode(int(resp.StatusCode))
+	ctx.Write(resp.Body)
 }
@@ -82,7 +82,7 @@ func TestGetMetricDefinitionCollection(t *testing.T) {
 }
 
 func TestGetMetricReportDefinitionCollection(t *testing.T) {
-	header["Allow"] = []string{"GET"}
+	header["Allow"] = []string{"GET, POST"}
 	defer delete(header, "Allow")
 	var a TelemetryRPCs
 	a.GetMetricReportDefinitionCollectionRPC = testTelemetryService
@@ -224,11 +224,86 @@ func TestUpdateTrigger(t *testing.T) {
 	test := httptest.New(t, testApp)
 	test.PATCH(
 		"/redfish/v1/TelemetryService/Triggers/1",
-	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK).Headers().Equal(header)
+	).WithJSON(map[string]interface{}{"MetricType": "Numeric"}).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK).Headers().Equal(header)
 	test.PATCH(
 		"/redfish/v1/TelemetryService/Triggers/1",
-	).WithHeader("X-Auth-Token", "").Expect().Status(http.StatusUnauthorized)
+	).WithJSON(map[string]interface{}{"MetricType": "Numeric"}).WithHeader("X-Auth-Token", "").Expect().Status(http.StatusUnauthorized)
 	test.PATCH(
 		"/redfish/v1/TelemetryService/Triggers/1",
+	).WithJSON(map[string]interface{}{"MetricType": "Numeric"}).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusInternalServerError)
+	test.PATCH(
+		"/redfish/v1/TelemetryService/Triggers/1",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusBadRequest)
+}
+
+func TestConfigureTelemetryResources(t *testing.T) {
+	var a TelemetryRPCs
+	a.CreateMetricReportDefinitionRPC = testTelemetryService
+	a.UpdateMetricReportDefinitionRPC = testTelemetryService
+	a.DeleteMetricReportDefinitionRPC = testTelemetryService
+	a.CreateTriggerRPC = testTelemetryService
+	a.DeleteTriggerRPC = testTelemetryService
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/TelemetryService")
+	redfishRoutes.Post("/MetricReportDefinitions", a.CreateMetricReportDefinition)
+	redfishRoutes.Patch("/MetricReportDefinitions/{id}", a.UpdateMetricReportDefinition)
+	redfishRoutes.Delete("/MetricReportDefinitions/{id}", a.DeleteMetricReportDefinition)
+	redfishRoutes.Post("/Triggers", a.CreateTrigger)
+	redfishRoutes.Delete("/Triggers/{id}", a.DeleteTrigger)
+	test := httptest.New(t, testApp)
+	body := map[string]interface{}{"MetricProperties": []string{"/redfish/v1/Chassis/{ChassisID}/Power#/PowerControl/0/PowerConsumedWatts"}}
+	test.POST(
+		"/redfish/v1/TelemetryService/MetricReportDefinitions",
+	).WithJSON(body).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+	test.POST(
+		"/redfish/v1/TelemetryService/MetricReportDefinitions",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusBadRequest)
+	test.PATCH(
+		"/redfish/v1/TelemetryService/MetricReportDefinitions/1",
+	).WithJSON(body).WithHeader("X-Auth-Token", "").Expect().Status(http.StatusUnauthorized)
+	test.DELETE(
+		"/redfish/v1/TelemetryService/MetricReportDefinitions/1",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+	test.POST(
+		"/redfish/v1/TelemetryService/Triggers",
+	).WithJSON(body).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusInternalServerError)
+	test.DELETE(
+		"/redfish/v1/TelemetryService/Triggers/1",
+	).WithHeader("X-Auth-Token", "InvalidToken").Expect().Status(http.StatusUnauthorized)
+}
+
+func TestGetMetricHistory(t *testing.T) {
+	var a TelemetryRPCs
+	a.GetMetricHistoryRPC = testTelemetryService
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/TelemetryService")
+	redfishRoutes.Get("/Oem/Odim/MetricHistory", a.GetMetricHistory)
+	test := httptest.New(t, testApp)
+	test.GET(
+		"/redfish/v1/TelemetryService/Oem/Odim/MetricHistory",
+	).WithQuery("MetricId", "PowerConsumedWatts").WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+	test.GET(
+		"/redfish/v1/TelemetryService/Oem/Odim/MetricHistory",
+	).WithHeader("X-Auth-Token", "").Expect().Status(http.StatusUnauthorized)
+	test.GET(
+		"/redfish/v1/TelemetryService/Oem/Odim/MetricHistory",
+	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusInternalServerError)
+}
+
+func TestGetOpenMetrics(t *testing.T) {
+	var a TelemetryRPCs
+	a.GetOpenMetricsRPC = testTelemetryService
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/TelemetryService")
+	redfishRoutes.Get("/Oem/Odim/Metrics", a.GetOpenMetrics)
+	test := httptest.New(t, testApp)
+	test.GET(
+		"/redfish/v1/TelemetryService/Oem/Odim/Metrics",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+	test.GET(
+		"/redfish/v1/TelemetryService/Oem/Odim/Metrics",
+	).WithHeader("X-Auth-Token", "").Expect().Status(http.StatusUnauthorized)
+	test.GET(
+		"/redfish/v1/TelemetryService/Oem/Odim/Metrics",
 	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusInternalServerError)
 }
@@ -39,6 +39,12 @@ type UpdateRPCs struct {
 	GetFirmwareInventoryCollectionRPC func(context.Context, updateproto.UpdateRequest) (*updateproto.UpdateResponse, error)
 	GetSoftwareInventoryRPC           func(context.Context, updateproto.UpdateRequest) (*updateproto.UpdateResponse, error)
 	GetSoftwareInventoryCollectionRPC func(context.Context, updateproto.UpdateRequest) (*updateproto.UpdateResponse, error)
+	CreateFirmwareBaselineRPC         func(context.Context, updateproto.UpdateRequest) (*updateproto.UpdateResponse, error)
+	GetFirmwareBaselineCollectionRPC  func(context.Context, updateproto.UpdateRequest) (*updateproto.UpdateResponse, error)
+	GetFirmwareBaselineRPC            func(context.Context, updateproto.UpdateRequest) (*updateproto.UpdateResponse, error)
+	DeleteFirmwareBaselineRPC         func(context.Context, updateproto.UpdateRequest) (*updateproto.UpdateResponse, error)
+	GetFirmwareComplianceReportRPC    func(context.Context, updateproto.UpdateRequest) (*updateproto.UpdateResponse, error)
+	RemediateFirmwareDriftRPC         func(context.Context, updateproto.UpdateRequest) (*updateproto.UpdateResponse, error)
 }
 
 // GetUpdateService is the handler for getting UpdateService details
@@ -272,6 +278,192 @@ func (a *UpdateRPCs) StartUpdate(ctx iris.Context) {
 	sendUpdateResponse(ctx, resp)
 }
 
+// CreateFirmwareBaseline is a handler for creating a firmware baseline
+func (a *UpdateRPCs) CreateFirmwareBaseline(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	var req interface{}
+	err := ctx.ReadJSON(&req)
+	if err != nil {
+		errorMessage := "error while trying to get JSON body from the create firmware baseline request body: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendMalformedJSONRequestErrResponse(ctx, errorMessage)
+		return
+	}
+	sessionToken := ctx.Request().Header.Get(AuthTokenHeader)
+	if sessionToken == "" {
+		errorMessage := invalidAuthTokenErrorMsg
+		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
+	}
+	request, _ := json.Marshal(req)
+	l.LogWithFields(ctxt).Debugf("Incoming request received for creating firmware baseline with request body %s", string(request))
+	updateRequest := updateproto.UpdateRequest{
+		SessionToken: sessionToken,
+		RequestBody:  request,
+	}
+	resp, err := a.CreateFirmwareBaselineRPC(ctxt, updateRequest)
+	if err != nil {
+		errorMessage := rpcCallFailedErrorMsg + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+	l.LogWithFields(ctxt).Debugf("Outgoing response for creating firmware baseline is %s with status code %d", string(resp.Body), int(resp.StatusCode))
+	sendUpdateResponse(ctx, resp)
+}
+
+// GetFirmwareBaselineCollection is a handler for listing the firmware baselines
+func (a *UpdateRPCs) GetFirmwareBaselineCollection(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	req := updateproto.UpdateRequest{
+		SessionToken: ctx.Request().Header.Get(AuthTokenHeader),
+		URL:          ctx.Request().RequestURI,
+	}
+	l.LogWithFields(ctxt).Debug("Incoming request received for getting firmware baseline collection")
+	if req.SessionToken == "" {
+		errorMessage := invalidAuthTokenErrorMsg
+		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
+	}
+	resp, err := a.GetFirmwareBaselineCollectionRPC(ctxt, req)
+	if err != nil {
+		errorMessage := rpcCallFailedErrorMsg + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+	l.LogWithFields(ctxt).Debugf("Outgoing response for getting firmware baseline collection is %s with status code %d", string(resp.Body), int(resp.StatusCode))
+	ctx.ResponseWriter().Header().Set("Allow", "GET, POST")
+	sendUpdateResponse(ctx, resp)
+}
+
+// GetFirmwareBaseline is a handler for getting a firmware baseline
+func (a *UpdateRPCs) GetFirmwareBaseline(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	req := updateproto.UpdateRequest{
+		SessionToken: ctx.Request().Header.Get(AuthTokenHeader),
+		ResourceID:   ctx.Params().Get("baseline_id"),
+		URL:          ctx.Request().RequestURI,
+	}
+	l.LogWithFields(ctxt).Debugf("Incoming request received for getting firmware baseline with url %s", req.URL)
+	if req.SessionToken == "" {
+		errorMessage := invalidAuthTokenErrorMsg
+		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
+	}
+	resp, err := a.GetFirmwareBaselineRPC(ctxt, req)
+	if err != nil {
+		errorMessage := rpcCallFailedErrorMsg + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+	l.LogWithFields(ctxt).Debugf("Outgoing response for getting firmware baseline is %s with status code %d", string(resp.Body), int(resp.StatusCode))
+	ctx.ResponseWriter().Header().Set("Allow", "GET, DELETE")
+	sendUpdateResponse(ctx, resp)
+}
+
+// DeleteFirmwareBaseline is a handler for deleting a firmware baseline
+func (a *UpdateRPCs) DeleteFirmwareBaseline(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	req := updateproto.UpdateRequest{
+		SessionToken: ctx.Request().Header.Get(AuthTokenHeader),
+		ResourceID:   ctx.Params().Get("baseline_id"),
+		URL:          ctx.Request().RequestURI,
+	}
+	l.LogWithFields(ctxt).Debugf("Incoming request received for deleting firmware baseline with url %s", req.URL)
+	if req.SessionToken == "" {
+		errorMessage := invalidAuthTokenErrorMsg
+		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
+	}
+	resp, err := a.DeleteFirmwareBaselineRPC(ctxt, req)
+	if err != nil {
+		errorMessage := rpcCallFailedErrorMsg + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+	l.LogWithFields(ctxt).Debugf("Outgoing response for deleting firmware baseline is %s with status code %d", string(resp.Body), int(resp.StatusCode))
+	sendUpdateResponse(ctx, resp)
+}
+
+// GetFirmwareComplianceReport is a handler for getting how the firmware of the servers complies with a baseline
+func (a *UpdateRPCs) GetFirmwareComplianceReport(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	req := updateproto.UpdateRequest{
+		SessionToken: ctx.Request().Header.Get(AuthTokenHeader),
+		ResourceID:   ctx.Params().Get("baseline_id"),
+		URL:          ctx.Request().RequestURI,
+	}
+	l.LogWithFields(ctxt).Debugf("Incoming request received for getting firmware compliance report with url %s", req.URL)
+	if req.SessionToken == "" {
+		errorMessage := invalidAuthTokenErrorMsg
+		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
+	}
+	resp, err := a.GetFirmwareComplianceReportRPC(ctxt, req)
+	if err != nil {
+		errorMessage := rpcCallFailedErrorMsg + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+	l.LogWithFields(ctxt).Debugf("Outgoing response for getting firmware compliance report is %s with status code %d", string(resp.Body), int(resp.StatusCode))
+	ctx.ResponseWriter().Header().Set("Allow", "GET")
+	sendUpdateResponse(ctx, resp)
+}
+
+// RemediateFirmwareDrift is a handler for the remediate action of a firmware baseline,
+// the request body is optional
+func (a *UpdateRPCs) RemediateFirmwareDrift(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	request, err := ctx.GetBody()
+	if err != nil {
+		errorMessage := "error while trying to read the remediate request body: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendMalformedJSONRequestErrResponse(ctx, errorMessage)
+		return
+	}
+	if len(request) > 0 {
+		var req interface{}
+		if err := json.Unmarshal(request, &req); err != nil {
+			errorMessage := "error while trying to get JSON body from the remediate request body: " + err.Error()
+			l.LogWithFields(ctxt).Error(errorMessage)
+			common.SendMalformedJSONRequestErrResponse(ctx, errorMessage)
+			return
+		}
+	}
+	sessionToken := ctx.Request().Header.Get(AuthTokenHeader)
+	if sessionToken == "" {
+		errorMessage := invalidAuthTokenErrorMsg
+		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
+	}
+	l.LogWithFields(ctxt).Debugf("Incoming request received for remediating firmware drift with request body %s", string(request))
+	updateRequest := updateproto.UpdateRequest{
+		SessionToken: sessionToken,
+		ResourceID:   ctx.Params().Get("baseline_id"),
+		URL:          ctx.Request().RequestURI,
+		RequestBody:  request,
+	}
+	resp, err := a.RemediateFirmwareDriftRPC(ctxt, updateRequest)
+	if err != nil {
+		errorMessage := rpcCallFailedErrorMsg + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+	l.LogWithFields(ctxt).Debugf("Outgoing response for remediating firmware drift is %s with status code %d", string(resp.Body), int(resp.StatusCode))
+	sendUpdateResponse(ctx, resp)
+}
+
 // sendUpdateResponse writes the update response to client
 func sendUpdateResponse(ctx iris.Context, resp *updateproto.UpdateResponse) {
 	common.SetResponseHeader(ctx, resp.Header)
@@ -286,3 +286,45 @@ func TestStartUpdateWithInvalidToken(t *testing.T) {
 		"/redfish/v1/UpdateService/Actions/UpdateService.StartUpdate",
 	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
 }
+
+func mockFirmwareBaselineRPC(ctx context.Context, req updateproto.UpdateRequest) (*updateproto.UpdateResponse, error) {
+	if req.SessionToken == "TokenRPC" {
+		return &updateproto.UpdateResponse{}, errors.New("Unable to RPC Call")
+	}
+	if req.ResourceID == "unknown" {
+		return &updateproto.UpdateResponse{StatusCode: http.StatusNotFound}, nil
+	}
+	return &updateproto.UpdateResponse{StatusCode: http.StatusOK, Body: []byte(`{"Response":"Success"}`)}, nil
+}
+
+func TestFirmwareBaselines(t *testing.T) {
+	var a UpdateRPCs
+	a.CreateFirmwareBaselineRPC = mockFirmwareBaselineRPC
+	a.GetFirmwareBaselineCollectionRPC = mockFirmwareBaselineRPC
+	a.GetFirmwareBaselineRPC = mockFirmwareBaselineRPC
+	a.DeleteFirmwareBaselineRPC = mockFirmwareBaselineRPC
+	a.GetFirmwareComplianceReportRPC = mockFirmwareBaselineRPC
+	a.RemediateFirmwareDriftRPC = mockFirmwareBaselineRPC
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/UpdateService/Oem/Odim/FirmwareBaselines")
+	redfishRoutes.Get("/", a.GetFirmwareBaselineCollection)
+	redfishRoutes.Post("/", a.CreateFirmwareBaseline)
+	redfishRoutes.Get("/{baseline_id}", a.GetFirmwareBaseline)
+	redfishRoutes.Delete("/{baseline_id}", a.DeleteFirmwareBaseline)
+	redfishRoutes.Get("/{baseline_id}/ComplianceReport", a.GetFirmwareComplianceReport)
+	redfishRoutes.Post("/{baseline_id}/Actions/FirmwareBaseline.Remediate", a.RemediateFirmwareDrift)
+	test := httptest.New(t, testApp)
+	baselinesURI := "/redfish/v1/UpdateService/Oem/Odim/FirmwareBaselines"
+	test.GET(baselinesURI).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+	test.GET(baselinesURI).WithHeader("X-Auth-Token", "").Expect().Status(http.StatusUnauthorized)
+	test.POST(baselinesURI).WithHeader("X-Auth-Token", "ValidToken").WithJSON(map[string]string{"Name": "gen10"}).Expect().Status(http.StatusOK)
+	test.POST(baselinesURI).WithHeader("X-Auth-Token", "ValidToken").WithBytes([]byte(`{"Name":`)).Expect().Status(http.StatusBadRequest)
+	test.POST(baselinesURI).WithHeader("X-Auth-Token", "TokenRPC").WithJSON(map[string]string{"Name": "gen10"}).Expect().Status(http.StatusInternalServerError)
+	test.GET(baselinesURI+"/b1").WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+	test.GET(baselinesURI+"/unknown").WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusNotFound)
+	test.DELETE(baselinesURI+"/b1").WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+	test.GET(baselinesURI+"/b1/ComplianceReport").WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+	test.POST(baselinesURI+"/b1/Actions/FirmwareBaseline.Remediate").WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+	test.POST(baselinesURI+"/b1/Actions/FirmwareBaseline.Remediate").WithHeader("X-Auth-Token", "ValidToken").WithBytes([]byte(`{"BatchSize":`)).Expect().Status(http.StatusBadRequest)
+	test.POST(baselinesURI+"/b1/Actions/FirmwareBaseline.Remediate").WithHeader("X-Auth-Token", "").Expect().Status(http.StatusUnauthorized)
+}
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"os"
 	"strings"
@@ -29,6 +30,7 @@ import (
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/health"
 	"github.com/ODIM-Project/ODIM/lib-utilities/logs"
 	sessionproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/session"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
@@ -189,6 +191,7 @@ func main() {
 	if err != nil {
 		logs.Log.Fatal("service initialization failed: " + err.Error())
 	}
+	registerBackendHealthChecks()
 
 	conf := &config.HTTPConfig{
 		Certificate:   &config.Data.APIGatewayConf.Certificate,
@@ -252,6 +255,7 @@ func createContext(r *http.Request, transactionID uuid.UUID, podName string) con
 	ctx = context.WithValue(ctx, common.ProcessName, podName)
 	ctx = context.WithValue(ctx, common.ThreadName, common.APIService)
 	ctx = context.WithValue(ctx, common.ThreadID, common.DefaultThreadID)
+	ctx = context.WithValue(ctx, common.ClientIP, clientIP(r))
 	if r.Body != nil {
 		body, _ := ioutil.ReadAll(r.Body)
 		json.Unmarshal(body, &reqBody)
@@ -261,3 +265,69 @@ func createContext(r *http.Request, transactionID uuid.UUID, podName string) con
 
 	return ctx
 }
+
+// clientIP returns the address of the client which sent the request.
+// X-Forwarded-For is honoured only when the request comes from a trusted proxy,
+// and the client is then the right-most hop which is not a trusted proxy, as the
+// entries on its left are set by the client itself and can be spoofed
+func clientIP(r *http.Request) string {
+	client, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		client = r.RemoteAddr
+	}
+	if !isTrustedProxy(client) {
+		return client
+	}
+	hops := strings.Split(strings.Join(r.Header.Values(common.XForwardedFor), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if hop == "" {
+			continue
+		}
+		client = hop
+		if !isTrustedProxy(hop) {
+			break
+		}
+	}
+	return client
+}
+
+// isTrustedProxy checks whether the address is one of the trusted proxies of the API gateway
+func isTrustedProxy(address string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil || config.Data.APIGatewayConf == nil {
+		return false
+	}
+	for _, network := range config.Data.APIGatewayConf.TrustedProxyNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// registerBackendHealthChecks adds the readiness checks of the back-end services.
+// Every request is authenticated by the account-session service, so API is not
+// ready without it, while the other services only degrade the API when down.
+func registerBackendHealthChecks() {
+	health.Register(services.AccountSession, backendCheck(services.AccountSession))
+	for _, service := range []string{
+		services.Aggregator,
+		services.Events,
+		services.Fabrics,
+		services.Licenses,
+		services.Managers,
+		services.Systems,
+		services.Tasks,
+		services.Telemetry,
+		services.Update,
+	} {
+		health.RegisterOptional(service, backendCheck(service))
+	}
+}
+
+func backendCheck(service string) health.Check {
+	return func(ctx context.Context) error {
+		return services.ODIMService.CheckClient(ctx, service)
+	}
+}
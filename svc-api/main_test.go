@@ -0,0 +1,89 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+)
+
+func TestClientIP(t *testing.T) {
+	config.SetUpMockConfig(t)
+	config.Data.APIGatewayConf.TrustedProxyNetworks = nil
+	for _, cidr := range []string{"10.0.0.1/32", "172.16.0.0/12"} {
+		_, network, _ := net.ParseCIDR(cidr)
+		config.Data.APIGatewayConf.TrustedProxyNetworks = append(config.Data.APIGatewayConf.TrustedProxyNetworks, network)
+	}
+	defer func() {
+		config.Data.APIGatewayConf.TrustedProxyNetworks = nil
+	}()
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor []string
+		want         string
+	}{
+		{
+			name:       "direct request",
+			remoteAddr: "192.168.1.10:50000",
+			want:       "192.168.1.10",
+		},
+		{
+			name:         "forwarded for an untrusted caller",
+			remoteAddr:   "192.168.1.10:50000",
+			forwardedFor: []string{"1.2.3.4"},
+			want:         "192.168.1.10",
+		},
+		{
+			name:         "forwarded by a trusted proxy",
+			remoteAddr:   "10.0.0.1:50000",
+			forwardedFor: []string{"192.168.1.10"},
+			want:         "192.168.1.10",
+		},
+		{
+			name:         "spoofed entry on the left of the client",
+			remoteAddr:   "10.0.0.1:50000",
+			forwardedFor: []string{"1.2.3.4, 192.168.1.10"},
+			want:         "192.168.1.10",
+		},
+		{
+			name:         "chain of trusted proxies",
+			remoteAddr:   "10.0.0.1:50000",
+			forwardedFor: []string{"1.2.3.4, 192.168.1.10", "172.16.5.5"},
+			want:         "192.168.1.10",
+		},
+		{
+			name:         "only trusted proxies",
+			remoteAddr:   "10.0.0.1:50000",
+			forwardedFor: []string{"172.16.5.5"},
+			want:         "172.16.5.5",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := http.NewRequest(http.MethodGet, "/redfish/v1", nil)
+			r.RemoteAddr = tt.remoteAddr
+			for _, forwarded := range tt.forwardedFor {
+				r.Header.Add("X-Forwarded-For", forwarded)
+			}
+			if got := clientIP(r); got != tt.want {
+				t.Errorf("clientIP() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/metrics"
+	iris "github.com/kataras/iris/v12"
+)
+
+// unmatchedRoute is the route label of the requests not matching any route
+const unmatchedRoute = "unmatched"
+
+var apiRequestDuration = metrics.NewHistogramVec("odim_api_request_duration_seconds",
+	"Time taken to serve a request received on the northbound API", nil, "method", "route", "code")
+
+// MetricsMiddleware records the latency of the API requests per route.
+// The registered route path is used as label instead of the request URI
+// to keep the number of series bounded.
+func MetricsMiddleware(ctx iris.Context) {
+	start := time.Now()
+	ctx.Next()
+	route := unmatchedRoute
+	if currentRoute := ctx.GetCurrentRoute(); currentRoute != nil {
+		route = currentRoute.Path()
+	}
+	apiRequestDuration.WithLabelValues(ctx.Method(), route, strconv.Itoa(ctx.GetStatusCode())).Observe(time.Since(start).Seconds())
+}
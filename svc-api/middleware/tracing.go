@@ -0,0 +1,46 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package middleware
+
+import (
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/tracing"
+	iris "github.com/kataras/iris/v12"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracingMiddleware starts a server span for every API request, named after
+// the matched route. The trace context received in the traceparent header is
+// continued, and the span is stored in the request context so that it is
+// propagated to the backend services and the plugins.
+func TracingMiddleware(ctx iris.Context) {
+	route := unmatchedRoute
+	if currentRoute := ctx.GetCurrentRoute(); currentRoute != nil {
+		route = currentRoute.Path()
+	}
+	reqCtx := tracing.Extract(ctx.Request().Context(), ctx.Request().Header)
+	reqCtx, span := tracing.StartSpan(reqCtx, ctx.Method()+" "+route, trace.SpanKindServer,
+		attribute.String("http.method", ctx.Method()),
+		attribute.String("http.route", route),
+		attribute.String("http.target", ctx.Request().URL.Path))
+	defer span.End()
+	if transactionID, ok := reqCtx.Value(common.TransactionID).(string); ok {
+		span.SetAttributes(attribute.String("odim.transaction_id", transactionID))
+	}
+	ctx.ResetRequest(ctx.Request().WithContext(reqCtx))
+	ctx.Next()
+	tracing.SetHTTPStatus(span, ctx.GetStatusCode())
+}
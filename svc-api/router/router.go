@@ -57,16 +57,25 @@ func Router() *iris.Application {
 		GetAccountRPC:     rpc.DoGetAccountRequest,
 		UpdateRPC:         rpc.DoUpdateAccountRequest,
 		DeleteRPC:         rpc.DoAccountDeleteRequest,
+		CreateAPIKeyRPC:   rpc.DoCreateAPIKeyRequest,
+		GetAllAPIKeysRPC:  rpc.DoGetAllAPIKeysRequest,
+		GetAPIKeyRPC:      rpc.DoGetAPIKeyRequest,
+		DeleteAPIKeyRPC:   rpc.DoDeleteAPIKeyRequest,
 	}
 	pc := handle.AggregatorRPCs{
 		GetAggregationServiceRPC:                rpc.DoGetAggregationService,
 		ResetRPC:                                rpc.DoResetRequest,
 		SetDefaultBootOrderRPC:                  rpc.DoSetDefaultBootOrderRequest,
 		AddAggregationSourceRPC:                 rpc.DoAddAggregationSource,
+		BulkAddAggregationSourcesRPC:            rpc.DoBulkAddAggregationSources,
+		GetAllDiscoveredBMCsRPC:                 rpc.DoGetAllDiscoveredBMCs,
+		GetDiscoveredBMCRPC:                     rpc.DoGetDiscoveredBMC,
+		AdoptDiscoveredBMCRPC:                   rpc.DoAdoptDiscoveredBMC,
 		GetAllAggregationSourceRPC:              rpc.DoGetAllAggregationSource,
 		GetAggregationSourceRPC:                 rpc.DoGetAggregationSource,
 		UpdateAggregationSourceRPC:              rpc.DoUpdateAggregationSource,
 		DeleteAggregationSourceRPC:              rpc.DoDeleteAggregationSource,
+		ChangeConnectionMethodRPC:               rpc.DoChangeConnectionMethod,
 		CreateAggregateRPC:                      rpc.DoCreateAggregate,
 		GetAggregateCollectionRPC:               rpc.DoGetAggregateCollection,
 		GetAggregateRPC:                         rpc.DoGeteAggregate,
@@ -75,6 +84,8 @@ func Router() *iris.Application {
 		RemoveElementsFromAggregateRPC:          rpc.DoRemoveElementsFromAggregate,
 		ResetAggregateElementsRPC:               rpc.DoResetAggregateElements,
 		SetDefaultBootOrderAggregateElementsRPC: rpc.DoSetDefaultBootOrderAggregateElements,
+		RotateCredentialsRPC:                    rpc.DoRotateCredentials,
+		ReplaceCertificateRPC:                   rpc.DoReplaceCertificate,
 		GetAllConnectionMethodsRPC:              rpc.DoGetAllConnectionMethods,
 		GetConnectionMethodRPC:                  rpc.DoGetConnectionMethod,
 		GetResetActionInfoServiceRPC:            rpc.DoGetResetActionInfoService,
@@ -105,6 +116,7 @@ func Router() *iris.Application {
 		GetSystemResourceRPC:       rpc.GetSystemResource,
 		SystemResetRPC:             rpc.ComputerSystemReset,
 		SetDefaultBootOrderRPC:     rpc.SetDefaultBootOrder,
+		SystemOemActionRPC:         rpc.SystemOemAction,
 		ChangeBiosSettingsRPC:      rpc.ChangeBiosSettings,
 		ChangeBootOrderSettingsRPC: rpc.ChangeBootOrderSettings,
 		CreateVolumeRPC:            rpc.CreateVolume,
@@ -147,6 +159,8 @@ func Router() *iris.Application {
 		CreateRemoteAccountServiceRPC: rpc.CreateRemoteAccountService,
 		UpdateRemoteAccountServiceRPC: rpc.UpdateRemoteAccountService,
 		DeleteRemoteAccountServiceRPC: rpc.DeleteRemoteAccountService,
+		GetLogLevelRPC:                rpc.GetLogLevel,
+		SetLogLevelRPC:                rpc.SetLogLevel,
 	}
 
 	update := handle.UpdateRPCs{
@@ -157,6 +171,12 @@ func Router() *iris.Application {
 		GetFirmwareInventoryCollectionRPC: rpc.DoGetFirmwareInventoryCollection,
 		GetSoftwareInventoryRPC:           rpc.DoGetSoftwareInventory,
 		GetSoftwareInventoryCollectionRPC: rpc.DoGetSoftwareInventoryCollection,
+		CreateFirmwareBaselineRPC:         rpc.DoCreateFirmwareBaseline,
+		GetFirmwareBaselineCollectionRPC:  rpc.DoGetFirmwareBaselineCollection,
+		GetFirmwareBaselineRPC:            rpc.DoGetFirmwareBaseline,
+		DeleteFirmwareBaselineRPC:         rpc.DoDeleteFirmwareBaseline,
+		GetFirmwareComplianceReportRPC:    rpc.DoGetFirmwareComplianceReport,
+		RemediateFirmwareDriftRPC:         rpc.DoRemediateFirmwareDrift,
 	}
 
 	telemetry := handle.TelemetryRPCs{
@@ -170,6 +190,13 @@ func Router() *iris.Application {
 		GetMetricReportRPC:                     rpc.DoGetMetricReport,
 		GetTriggerRPC:                          rpc.DoGetTrigger,
 		UpdateTriggerRPC:                       rpc.DoUpdateTrigger,
+		CreateMetricReportDefinitionRPC:        rpc.DoCreateMetricReportDefinition,
+		UpdateMetricReportDefinitionRPC:        rpc.DoUpdateMetricReportDefinition,
+		DeleteMetricReportDefinitionRPC:        rpc.DoDeleteMetricReportDefinition,
+		CreateTriggerRPC:                       rpc.DoCreateTrigger,
+		DeleteTriggerRPC:                       rpc.DoDeleteTrigger,
+		GetMetricHistoryRPC:                    rpc.DoGetMetricHistory,
+		GetOpenMetricsRPC:                      rpc.DoGetOpenMetrics,
 	}
 
 	for _, service := range config.Data.EnabledServices {
@@ -291,6 +318,7 @@ func Router() *iris.Application {
 			ratelimiter.DecrementCounter(sessionToken, ratelimiter.SessionRateLimit)
 		}
 	})
+	router.UseGlobal(middleware.TracingMiddleware, middleware.MetricsMiddleware)
 	taskmon := router.Party("/taskmon")
 	taskmon.SetRegisterRule(iris.RouteSkip)
 	taskmon.Get("/{TaskID}", ts.GetTaskMonitor)
@@ -338,6 +366,12 @@ func Router() *iris.Application {
 	account.Any("/", handle.AsMethodNotAllowed)
 	account.Any("/Accounts", handle.AsMethodNotAllowed)
 	account.Any("/Accounts/{id}", handle.AsMethodNotAllowed)
+	account.Get("/APIKeys", a.GetAllAPIKeys)
+	account.Get("/APIKeys/{id}", a.GetAPIKey)
+	account.Post("/APIKeys", a.CreateAPIKey)
+	account.Delete("/APIKeys/{id}", a.DeleteAPIKey)
+	account.Any("/APIKeys", handle.AsMethodNotAllowed)
+	account.Any("/APIKeys/{id}", handle.AsMethodNotAllowed)
 	account.Get("/Roles/", r.GetAllRoles)
 	account.Get("/Roles/{id}", r.GetRole)
 	account.Patch("/Roles/{id}", r.UpdateRole)
@@ -428,6 +462,8 @@ func Router() *iris.Application {
 	systems.Any("/{id}/Memory/{rid}", handle.SystemsMethodNotAllowed)
 	systems.Post("/{id}/Actions/ComputerSystem.Reset", system.ComputerSystemReset)
 	systems.Post("/{id}/Actions/ComputerSystem.SetDefaultBootOrder", system.SetDefaultBootOrder)
+	systems.Post("/{id}/Actions/Oem/{action:path}", system.SystemOemAction)
+	systems.Any("/{id}/Actions/Oem/{action:path}", handle.SystemsMethodNotAllowed)
 
 	storage := v1.Party("/Systems/{id}/Storage", middleware.SessionDelMiddleware)
 	storage.SetRegisterRule(iris.RouteSkip)
@@ -473,6 +509,14 @@ func Router() *iris.Application {
 	aggregation.Any("/Actions/AggregationService.Reset/", handle.AggMethodNotAllowed)
 	aggregation.Post("/Actions/AggregationService.SetDefaultBootOrder/", pc.SetDefaultBootOrder)
 	aggregation.Any("/Actions/AggregationService.SetDefaultBootOrder/", handle.AggMethodNotAllowed)
+	aggregation.Post("/Actions/Oem/Odim.BulkAddAggregationSources/", pc.BulkAddAggregationSources)
+	aggregation.Any("/Actions/Oem/Odim.BulkAddAggregationSources/", handle.AggMethodNotAllowed)
+	aggregation.Get("/Oem/Odim/DiscoveredBMCs", pc.GetAllDiscoveredBMCs)
+	aggregation.Any("/Oem/Odim/DiscoveredBMCs", handle.AggMethodNotAllowed)
+	aggregation.Get("/Oem/Odim/DiscoveredBMCs/{id}", pc.GetDiscoveredBMC)
+	aggregation.Any("/Oem/Odim/DiscoveredBMCs/{id}", handle.AggMethodNotAllowed)
+	aggregation.Post("/Oem/Odim/DiscoveredBMCs/{id}/Actions/DiscoveredBMC.Adopt", pc.AdoptDiscoveredBMC)
+	aggregation.Any("/Oem/Odim/DiscoveredBMCs/{id}/Actions/DiscoveredBMC.Adopt", handle.AggMethodNotAllowed)
 	aggregation.Post("/AggregationSources/", pc.AddAggregationSource)
 	aggregation.Get("/AggregationSources", pc.GetAllAggregationSource)
 	aggregation.Any("/AggregationSources", handle.AggMethodNotAllowed)
@@ -480,6 +524,8 @@ func Router() *iris.Application {
 	aggregation.Patch("/AggregationSources/{id}", pc.UpdateAggregationSource)
 	aggregation.Delete("/AggregationSources/{id}", pc.DeleteAggregationSource)
 	aggregation.Any("/AggregationSources/{id}", handle.AggMethodNotAllowed)
+	aggregation.Post("/AggregationSources/{id}/Actions/Oem/Odim.ChangeConnectionMethod", pc.ChangeConnectionMethod)
+	aggregation.Any("/AggregationSources/{id}/Actions/Oem/Odim.ChangeConnectionMethod", handle.AggMethodNotAllowed)
 	aggregation.Get("/ConnectionMethods/", pc.GetAllConnectionMethods)
 	aggregation.Get("/ConnectionMethods/{id}", pc.GetConnectionMethod)
 	aggregation.Any("/ConnectionMethods/", handle.AggMethodNotAllowed)
@@ -498,6 +544,8 @@ func Router() *iris.Application {
 	aggregation.Any("/Aggregates/{id}/Actions/Aggregate.Reset/", handle.AggregateMethodNotAllowed)
 	aggregation.Post("/Aggregates/{id}/Actions/Aggregate.SetDefaultBootOrder/", pc.SetDefaultBootOrderAggregateElements)
 	aggregation.Any("/Aggregates/{id}/Actions/Aggregate.SetDefaultBootOrder/", handle.AggregateMethodNotAllowed)
+	aggregation.Post("/Aggregates/{id}/Actions/Oem/Odim.RotateCredentials", pc.RotateCredentials)
+	aggregation.Any("/Aggregates/{id}/Actions/Oem/Odim.RotateCredentials", handle.AggregateMethodNotAllowed)
 	aggregation.Any("/", handle.AggMethodNotAllowed)
 
 	chassis := v1.Party("/Chassis", middleware.SessionDelMiddleware)
@@ -657,6 +705,9 @@ func Router() *iris.Application {
 	managers.Delete("/{id}/RemoteAccountService/Accounts/{rid}", manager.DeleteRemoteAccountService)
 	managers.Get("/{id}/RemoteAccountService/Roles", manager.GetRemoteAccountService)
 	managers.Get("/{id}/RemoteAccountService/Roles/{rid}", manager.GetRemoteAccountService)
+	managers.Get("/{id}/Oem/Odim/LogLevel", manager.GetLogLevel)
+	managers.Patch("/{id}/Oem/Odim/LogLevel", manager.SetLogLevel)
+	managers.Any("/{id}/Oem/Odim/LogLevel", handle.ManagersMethodNotAllowed)
 	managers.Any("/{id}/RemoteAccountService", handle.ManagersMethodNotAllowed)
 	managers.Any("/{id}/RemoteAccountService/Accounts", handle.ManagersMethodNotAllowed)
 	managers.Any("/{id}/RemoteAccountService/Accounts/{rid}", handle.ManagersMethodNotAllowed)
@@ -690,6 +741,18 @@ func Router() *iris.Application {
 	updateService.Any("/SoftwareInventory/{softwareInventory_id}", handle.UpdateServiceMethodNotAllowed)
 	updateService.Any("/Actions/UpdateService.SimpleUpdate", handle.UpdateServiceMethodNotAllowed)
 	updateService.Any("/Actions/UpdateService.StartUpdate", handle.UpdateServiceMethodNotAllowed)
+	firmwareBaselines := updateService.Party("/Oem/Odim/FirmwareBaselines")
+	firmwareBaselines.SetRegisterRule(iris.RouteSkip)
+	firmwareBaselines.Get("/", update.GetFirmwareBaselineCollection)
+	firmwareBaselines.Post("/", update.CreateFirmwareBaseline)
+	firmwareBaselines.Get("/{baseline_id}", update.GetFirmwareBaseline)
+	firmwareBaselines.Delete("/{baseline_id}", update.DeleteFirmwareBaseline)
+	firmwareBaselines.Get("/{baseline_id}/ComplianceReport", update.GetFirmwareComplianceReport)
+	firmwareBaselines.Post("/{baseline_id}/Actions/FirmwareBaseline.Remediate", update.RemediateFirmwareDrift)
+	firmwareBaselines.Any("/", handle.FirmwareBaselinesMethodNotAllowed)
+	firmwareBaselines.Any("/{baseline_id}", handle.FirmwareBaselineMethodNotAllowed)
+	firmwareBaselines.Any("/{baseline_id}/ComplianceReport", handle.UpdateServiceMethodNotAllowed)
+	firmwareBaselines.Any("/{baseline_id}/Actions/FirmwareBaseline.Remediate", handle.UpdateServiceMethodNotAllowed)
 
 	telemetryService := v1.Party("/TelemetryService", middleware.SessionDelMiddleware)
 	telemetryService.SetRegisterRule(iris.RouteSkip)
@@ -702,15 +765,24 @@ func Router() *iris.Application {
 	telemetryService.Get("/MetricReportDefinitions/{id}", telemetry.GetMetricReportDefinition)
 	telemetryService.Get("/MetricReports/{id}", telemetry.GetMetricReport)
 	telemetryService.Get("/Triggers/{id}", telemetry.GetTrigger)
+	telemetryService.Post("/MetricReportDefinitions", telemetry.CreateMetricReportDefinition)
+	telemetryService.Patch("/MetricReportDefinitions/{id}", telemetry.UpdateMetricReportDefinition)
+	telemetryService.Delete("/MetricReportDefinitions/{id}", telemetry.DeleteMetricReportDefinition)
+	telemetryService.Post("/Triggers", telemetry.CreateTrigger)
 	telemetryService.Patch("/Triggers/{id}", telemetry.UpdateTrigger)
+	telemetryService.Delete("/Triggers/{id}", telemetry.DeleteTrigger)
+	telemetryService.Get("/Oem/Odim/MetricHistory", telemetry.GetMetricHistory)
+	telemetryService.Get("/Oem/Odim/Metrics", telemetry.GetOpenMetrics)
 	telemetryService.Any("/MetricDefinitions", handle.MethodNotAllowed)
-	telemetryService.Any("/MetricReportDefinitions", handle.MethodNotAllowed)
+	telemetryService.Any("/MetricReportDefinitions", handle.TelemetryCollectionMethodNotAllowed)
 	telemetryService.Any("/MetricReports", handle.MethodNotAllowed)
-	telemetryService.Any("/Triggers", handle.MethodNotAllowed)
+	telemetryService.Any("/Triggers", handle.TelemetryCollectionMethodNotAllowed)
 	telemetryService.Any("/MetricDefinitions/{id}", handle.MethodNotAllowed)
-	telemetryService.Any("/MetricReportDefinitions/{id}", handle.MethodNotAllowed)
+	telemetryService.Any("/MetricReportDefinitions/{id}", handle.TelemetryResourceMethodNotAllowed)
 	telemetryService.Any("/MetricReports/{id}", handle.MethodNotAllowed)
-	telemetryService.Any("/Triggers/{id}", handle.MethodNotAllowed)
+	telemetryService.Any("/Triggers/{id}", handle.TelemetryResourceMethodNotAllowed)
+	telemetryService.Any("/Oem/Odim/MetricHistory", handle.MethodNotAllowed)
+	telemetryService.Any("/Oem/Odim/Metrics", handle.MethodNotAllowed)
 
 	licenseService := v1.Party("/LicenseService", middleware.SessionDelMiddleware)
 	licenseService.SetRegisterRule(iris.RouteSkip)
@@ -722,6 +794,11 @@ func Router() *iris.Application {
 	licenseService.Any("/Licenses", handle.LicenseMethodNotAllowed)
 	licenseService.Any("/Licenses/{id}", handle.LicenseMethodNotAllowed)
 
+	certificateService := v1.Party("/CertificateService", middleware.SessionDelMiddleware)
+	certificateService.SetRegisterRule(iris.RouteSkip)
+	certificateService.Post("/Actions/CertificateService.ReplaceCertificate", pc.ReplaceCertificate)
+	certificateService.Any("/Actions/CertificateService.ReplaceCertificate", handle.CertificateServiceMethodNotAllowed)
+
 	// composition service
 	if isCompositionEnabled {
 		compositionService := v1.Party("/CompositionService", middleware.SessionDelMiddleware)
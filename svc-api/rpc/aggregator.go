@@ -104,6 +104,82 @@ func DoAddAggregationSource(ctx context.Context, req aggregatorproto.AggregatorR
 	return resp, err
 }
 
+// DoBulkAddAggregationSources defines the RPC call function for
+// the BulkAddAggregationSources from aggregator micro service
+func DoBulkAddAggregationSources(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Aggregator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	aggregator := NewAggregatorClientFunc(conn)
+
+	resp, err := aggregator.BulkAddAggregationSources(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoGetAllDiscoveredBMCs defines the RPC call function for
+// the GetAllDiscoveredBMCs from aggregator micro service
+func DoGetAllDiscoveredBMCs(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Aggregator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	aggregator := NewAggregatorClientFunc(conn)
+
+	resp, err := aggregator.GetAllDiscoveredBMCs(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoGetDiscoveredBMC defines the RPC call function for
+// the GetDiscoveredBMC from aggregator micro service
+func DoGetDiscoveredBMC(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Aggregator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	aggregator := NewAggregatorClientFunc(conn)
+
+	resp, err := aggregator.GetDiscoveredBMC(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoAdoptDiscoveredBMC defines the RPC call function for
+// the AdoptDiscoveredBMC from aggregator micro service
+func DoAdoptDiscoveredBMC(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Aggregator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	aggregator := NewAggregatorClientFunc(conn)
+
+	resp, err := aggregator.AdoptDiscoveredBMC(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
 // DoGetAllAggregationSource defines the RPC call function for
 // the GetAllAggregationSource from aggregator micro service
 func DoGetAllAggregationSource(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
@@ -180,6 +256,25 @@ func DoDeleteAggregationSource(ctx context.Context, req aggregatorproto.Aggregat
 	return resp, err
 }
 
+// DoChangeConnectionMethod defines the RPC call function for
+// the ChangeConnectionMethod from aggregator micro service
+func DoChangeConnectionMethod(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Aggregator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	aggregator := NewAggregatorClientFunc(conn)
+
+	resp, err := aggregator.ChangeConnectionMethod(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
 // DoCreateAggregate defines the RPC call function for
 // the CreateAggregate from aggregator micro service
 func DoCreateAggregate(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
@@ -332,6 +427,44 @@ func DoSetDefaultBootOrderAggregateElements(ctx context.Context, req aggregatorp
 	return resp, err
 }
 
+// DoRotateCredentials defines the RPC call function for
+// the RotateCredentials action of an aggregate from aggregator micro service
+func DoRotateCredentials(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Aggregator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	aggregator := NewAggregatorClientFunc(conn)
+
+	resp, err := aggregator.RotateCredentials(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoReplaceCertificate defines the RPC call function for
+// the ReplaceCertificate action of the CertificateService from aggregator micro service
+func DoReplaceCertificate(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Aggregator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	aggregator := NewAggregatorClientFunc(conn)
+
+	resp, err := aggregator.ReplaceCertificate(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
 // DoGetAllConnectionMethods defines the RPC call function for
 // the get connection method collection from aggregator micro service
 func DoGetAllConnectionMethods(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
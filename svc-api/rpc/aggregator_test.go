@@ -218,6 +218,231 @@ func TestDoAddAggregationSource(t *testing.T) {
 	}
 }
 
+func TestDoBulkAddAggregationSources(t *testing.T) {
+	type args struct {
+		req aggregatorproto.AggregatorRequest
+	}
+	tests := []struct {
+		name                    string
+		args                    args
+		ClientFunc              func(clientName string) (*grpc.ClientConn, error)
+		NewAggregatorClientFunc func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient
+		want                    *aggregatorproto.AggregatorResponse
+		wantErr                 bool
+	}{
+		{
+			name:                    "Client func error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return nil },
+			want:                    nil,
+			wantErr:                 true,
+		},
+		{
+			name:                    "BulkAddAggregationSources error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return fakeStruct{} },
+			want:                    nil,
+			wantErr:                 true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewAggregatorClientFunc = tt.NewAggregatorClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoBulkAddAggregationSources(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoBulkAddAggregationSources() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoBulkAddAggregationSources() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoGetAllDiscoveredBMCs(t *testing.T) {
+	type args struct {
+		req aggregatorproto.AggregatorRequest
+	}
+	tests := []struct {
+		name                    string
+		args                    args
+		ClientFunc              func(clientName string) (*grpc.ClientConn, error)
+		NewAggregatorClientFunc func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient
+		want                    *aggregatorproto.AggregatorResponse
+		wantErr                 bool
+	}{
+		{
+			name:                    "Client func error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return nil },
+			want:                    nil,
+			wantErr:                 true,
+		},
+		{
+			name:                    "GetAllDiscoveredBMCs error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return fakeStruct{} },
+			want:                    nil,
+			wantErr:                 true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewAggregatorClientFunc = tt.NewAggregatorClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoGetAllDiscoveredBMCs(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoGetAllDiscoveredBMCs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoGetAllDiscoveredBMCs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoGetDiscoveredBMC(t *testing.T) {
+	type args struct {
+		req aggregatorproto.AggregatorRequest
+	}
+	tests := []struct {
+		name                    string
+		args                    args
+		ClientFunc              func(clientName string) (*grpc.ClientConn, error)
+		NewAggregatorClientFunc func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient
+		want                    *aggregatorproto.AggregatorResponse
+		wantErr                 bool
+	}{
+		{
+			name:                    "Client func error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return nil },
+			want:                    nil,
+			wantErr:                 true,
+		},
+		{
+			name:                    "GetDiscoveredBMC error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return fakeStruct{} },
+			want:                    nil,
+			wantErr:                 true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewAggregatorClientFunc = tt.NewAggregatorClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoGetDiscoveredBMC(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoGetDiscoveredBMC() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoGetDiscoveredBMC() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoAdoptDiscoveredBMC(t *testing.T) {
+	type args struct {
+		req aggregatorproto.AggregatorRequest
+	}
+	tests := []struct {
+		name                    string
+		args                    args
+		ClientFunc              func(clientName string) (*grpc.ClientConn, error)
+		NewAggregatorClientFunc func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient
+		want                    *aggregatorproto.AggregatorResponse
+		wantErr                 bool
+	}{
+		{
+			name:                    "Client func error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return nil },
+			want:                    nil,
+			wantErr:                 true,
+		},
+		{
+			name:                    "AdoptDiscoveredBMC error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return fakeStruct{} },
+			want:                    nil,
+			wantErr:                 true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewAggregatorClientFunc = tt.NewAggregatorClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoAdoptDiscoveredBMC(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoAdoptDiscoveredBMC() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoAdoptDiscoveredBMC() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoChangeConnectionMethod(t *testing.T) {
+	type args struct {
+		req aggregatorproto.AggregatorRequest
+	}
+	tests := []struct {
+		name                    string
+		args                    args
+		ClientFunc              func(clientName string) (*grpc.ClientConn, error)
+		NewAggregatorClientFunc func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient
+		want                    *aggregatorproto.AggregatorResponse
+		wantErr                 bool
+	}{
+		{
+			name:                    "Client func error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return nil },
+			want:                    nil,
+			wantErr:                 true,
+		},
+		{
+			name:                    "ChangeConnectionMethod error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return fakeStruct{} },
+			want:                    nil,
+			wantErr:                 true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewAggregatorClientFunc = tt.NewAggregatorClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoChangeConnectionMethod(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoChangeConnectionMethod() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoChangeConnectionMethod() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestDoGetAllAggregationSource(t *testing.T) {
 	type args struct {
 		req aggregatorproto.AggregatorRequest
@@ -758,6 +983,96 @@ func TestDoSetDefaultBootOrderAggregateElements(t *testing.T) {
 	}
 }
 
+func TestDoRotateCredentials(t *testing.T) {
+	type args struct {
+		req aggregatorproto.AggregatorRequest
+	}
+	tests := []struct {
+		name                    string
+		args                    args
+		ClientFunc              func(clientName string) (*grpc.ClientConn, error)
+		NewAggregatorClientFunc func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient
+		want                    *aggregatorproto.AggregatorResponse
+		wantErr                 bool
+	}{
+		{
+			name:                    "Client func error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return nil },
+			want:                    nil,
+			wantErr:                 true,
+		},
+		{
+			name:                    "RotateCredentials error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return fakeStruct{} },
+			want:                    nil,
+			wantErr:                 true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewAggregatorClientFunc = tt.NewAggregatorClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoRotateCredentials(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoRotateCredentials() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoRotateCredentials() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoReplaceCertificate(t *testing.T) {
+	type args struct {
+		req aggregatorproto.AggregatorRequest
+	}
+	tests := []struct {
+		name                    string
+		args                    args
+		ClientFunc              func(clientName string) (*grpc.ClientConn, error)
+		NewAggregatorClientFunc func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient
+		want                    *aggregatorproto.AggregatorResponse
+		wantErr                 bool
+	}{
+		{
+			name:                    "Client func error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return nil },
+			want:                    nil,
+			wantErr:                 true,
+		},
+		{
+			name:                    "ReplaceCertificate error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return fakeStruct{} },
+			want:                    nil,
+			wantErr:                 true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewAggregatorClientFunc = tt.NewAggregatorClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoReplaceCertificate(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoReplaceCertificate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoReplaceCertificate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestDoGetAllConnectionMethods(t *testing.T) {
 	type args struct {
 		req aggregatorproto.AggregatorRequest
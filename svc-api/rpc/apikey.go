@@ -0,0 +1,72 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package rpc ...
+package rpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	accountproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/account"
+	"github.com/ODIM-Project/ODIM/lib-utilities/services"
+)
+
+// DoCreateAPIKeyRequest defines the RPC call function for
+// the CreateAPIKey from account-session micro service
+func DoCreateAPIKeyRequest(ctx context.Context, req accountproto.APIKeyRequest) (*accountproto.AccountResponse, error) {
+	return doAPIKeyRequest(ctx, func(ctx context.Context, account accountproto.AccountClient) (*accountproto.AccountResponse, error) {
+		return account.CreateAPIKey(ctx, &req)
+	})
+}
+
+// DoGetAllAPIKeysRequest defines the RPC call function for
+// the GetAllAPIKeys from account-session micro service
+func DoGetAllAPIKeysRequest(ctx context.Context, req accountproto.APIKeyRequest) (*accountproto.AccountResponse, error) {
+	return doAPIKeyRequest(ctx, func(ctx context.Context, account accountproto.AccountClient) (*accountproto.AccountResponse, error) {
+		return account.GetAllAPIKeys(ctx, &req)
+	})
+}
+
+// DoGetAPIKeyRequest defines the RPC call function for
+// the GetAPIKey from account-session micro service
+func DoGetAPIKeyRequest(ctx context.Context, req accountproto.APIKeyRequest) (*accountproto.AccountResponse, error) {
+	return doAPIKeyRequest(ctx, func(ctx context.Context, account accountproto.AccountClient) (*accountproto.AccountResponse, error) {
+		return account.GetAPIKey(ctx, &req)
+	})
+}
+
+// DoDeleteAPIKeyRequest defines the RPC call function for
+// the DeleteAPIKey from account-session micro service
+func DoDeleteAPIKeyRequest(ctx context.Context, req accountproto.APIKeyRequest) (*accountproto.AccountResponse, error) {
+	return doAPIKeyRequest(ctx, func(ctx context.Context, account accountproto.AccountClient) (*accountproto.AccountResponse, error) {
+		return account.DeleteAPIKey(ctx, &req)
+	})
+}
+
+func doAPIKeyRequest(ctx context.Context, call func(context.Context, accountproto.AccountClient) (*accountproto.AccountResponse, error)) (*accountproto.AccountResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.AccountSession)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	resp, err := call(ctx, NewAccountClientFunc(conn))
+	if err != nil && resp == nil {
+		return nil, fmt.Errorf("error: something went wrong with rpc call: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
@@ -0,0 +1,47 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	accountproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/account"
+	"google.golang.org/grpc"
+)
+
+func TestDoAPIKeyRequests(t *testing.T) {
+	requests := map[string]func(context.Context, accountproto.APIKeyRequest) (*accountproto.AccountResponse, error){
+		"DoCreateAPIKeyRequest":  DoCreateAPIKeyRequest,
+		"DoGetAllAPIKeysRequest": DoGetAllAPIKeysRequest,
+		"DoGetAPIKeyRequest":     DoGetAPIKeyRequest,
+		"DoDeleteAPIKeyRequest":  DoDeleteAPIKeyRequest,
+	}
+	tests := []struct {
+		name                 string
+		ClientFunc           func(clientName string) (*grpc.ClientConn, error)
+		NewAccountClientFunc func(cc *grpc.ClientConn) accountproto.AccountClient
+	}{
+		{
+			name:                 "Client func error",
+			ClientFunc:           func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewAccountClientFunc: func(cc *grpc.ClientConn) accountproto.AccountClient { return nil },
+		},
+		{
+			name:                 "rpc error",
+			ClientFunc:           func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewAccountClientFunc: func(cc *grpc.ClientConn) accountproto.AccountClient { return fakeStruct{} },
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewAccountClientFunc = tt.NewAccountClientFunc
+		for name, request := range requests {
+			t.Run(tt.name+"/"+name, func(t *testing.T) {
+				got, err := request(context.Background(), accountproto.APIKeyRequest{})
+				if err == nil || got != nil {
+					t.Errorf("%s() got = %v, error = %v, want error", name, got, err)
+				}
+			})
+		}
+	}
+}
@@ -48,6 +48,22 @@ func (fakeStruct) Delete(ctx context.Context, in *accountproto.DeleteAccountRequ
 	return nil, errors.New("fakeError")
 }
 
+func (fakeStruct) CreateAPIKey(ctx context.Context, in *accountproto.APIKeyRequest, opts ...grpc.CallOption) (*accountproto.AccountResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) GetAllAPIKeys(ctx context.Context, in *accountproto.APIKeyRequest, opts ...grpc.CallOption) (*accountproto.AccountResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) GetAPIKey(ctx context.Context, in *accountproto.APIKeyRequest, opts ...grpc.CallOption) (*accountproto.AccountResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) DeleteAPIKey(ctx context.Context, in *accountproto.APIKeyRequest, opts ...grpc.CallOption) (*accountproto.AccountResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
 //------------------------------------AGGREGATOR-------------------------------------------------
 
 func (fakeStruct) Reset(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
@@ -75,6 +91,31 @@ func (fakeStruct) AddAggregationSource(ctx context.Context, in *aggregatorproto.
 	return nil, errors.New("fakeError")
 }
 
+func (fakeStruct) BulkAddAggregationSources(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
+
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) GetAllDiscoveredBMCs(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
+
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) GetDiscoveredBMC(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
+
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) AdoptDiscoveredBMC(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
+
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) ChangeConnectionMethod(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
+
+	return nil, errors.New("fakeError")
+}
+
 func (fakeStruct) GetAllAggregationSource(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
 
 	return nil, errors.New("fakeError")
@@ -135,6 +176,16 @@ func (fakeStruct) SetDefaultBootOrderElementsOfAggregate(ctx context.Context, in
 	return nil, errors.New("fakeError")
 }
 
+func (fakeStruct) RotateCredentials(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
+
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) ReplaceCertificate(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
+
+	return nil, errors.New("fakeError")
+}
+
 func (fakeStruct) GetAllConnectionMethods(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
 
 	return nil, errors.New("fakeError")
@@ -302,6 +353,15 @@ func (fakeStruct) DeleteRemoteAccountService(ctx context.Context, in *managerspr
 func (fakeStruct) UpdateRemoteAccountPassword(ctx context.Context, in *managersproto.ManagerRequest, opts ...grpc.CallOption) (*managersproto.ManagerResponse, error) {
 	return nil, errors.New("fakeError")
 }
+func (fakeStruct) ChangeRemoteAccountPassword(ctx context.Context, in *managersproto.RemoteAccountPasswordRequest, opts ...grpc.CallOption) (*managersproto.ManagerResponse, error) {
+	return nil, errors.New("fakeError")
+}
+func (fakeStruct) GetLogLevel(ctx context.Context, in *managersproto.ManagerRequest, opts ...grpc.CallOption) (*managersproto.ManagerResponse, error) {
+	return nil, errors.New("fakeError")
+}
+func (fakeStruct) SetLogLevel(ctx context.Context, in *managersproto.ManagerRequest, opts ...grpc.CallOption) (*managersproto.ManagerResponse, error) {
+	return nil, errors.New("fakeError")
+}
 
 //------------------------------------ROLE-------------------------------------------------
 
@@ -377,6 +437,10 @@ func (fakeStruct2) SetDefaultBootOrder(ctx context.Context, in *systemsproto.Def
 	return nil, errors.New("fakeError")
 }
 
+func (fakeStruct2) SystemOemAction(ctx context.Context, in *systemsproto.OemActionRequest, opts ...grpc.CallOption) (*systemsproto.SystemsResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
 func (fakeStruct2) ChangeBiosSettings(ctx context.Context, in *systemsproto.BiosSettingsRequest, opts ...grpc.CallOption) (*systemsproto.SystemsResponse, error) {
 	return nil, errors.New("fakeError")
 }
@@ -485,6 +549,38 @@ func (fakeStruct) UpdateTrigger(ctx context.Context, in *teleproto.TelemetryRequ
 	return nil, errors.New("fakeError")
 }
 
+func (fakeStruct) GetMetricHistory(ctx context.Context, in *teleproto.TelemetryRequest, opts ...grpc.CallOption) (*teleproto.TelemetryResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) GetOpenMetrics(ctx context.Context, in *teleproto.TelemetryRequest, opts ...grpc.CallOption) (*teleproto.TelemetryResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) IngestMetricReport(ctx context.Context, in *teleproto.TelemetryRequest, opts ...grpc.CallOption) (*teleproto.TelemetryResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) CreateMetricReportDefinition(ctx context.Context, in *teleproto.TelemetryRequest, opts ...grpc.CallOption) (*teleproto.TelemetryResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) UpdateMetricReportDefinition(ctx context.Context, in *teleproto.TelemetryRequest, opts ...grpc.CallOption) (*teleproto.TelemetryResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) DeleteMetricReportDefinition(ctx context.Context, in *teleproto.TelemetryRequest, opts ...grpc.CallOption) (*teleproto.TelemetryResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) CreateTrigger(ctx context.Context, in *teleproto.TelemetryRequest, opts ...grpc.CallOption) (*teleproto.TelemetryResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) DeleteTrigger(ctx context.Context, in *teleproto.TelemetryRequest, opts ...grpc.CallOption) (*teleproto.TelemetryResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
 //--------------------------------------------UPDATE----------------------------------------
 
 func (fakeStruct) GetUpdateService(ctx context.Context, in *updateproto.UpdateRequest, opts ...grpc.CallOption) (*updateproto.UpdateResponse, error) {
@@ -514,3 +610,27 @@ func (fakeStruct) SimepleUpdate(ctx context.Context, in *updateproto.UpdateReque
 func (fakeStruct) StartUpdate(ctx context.Context, in *updateproto.UpdateRequest, opts ...grpc.CallOption) (*updateproto.UpdateResponse, error) {
 	return nil, errors.New("fakeError")
 }
+
+func (fakeStruct) CreateFirmwareBaseline(ctx context.Context, in *updateproto.UpdateRequest, opts ...grpc.CallOption) (*updateproto.UpdateResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) GetFirmwareBaselineCollection(ctx context.Context, in *updateproto.UpdateRequest, opts ...grpc.CallOption) (*updateproto.UpdateResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) GetFirmwareBaseline(ctx context.Context, in *updateproto.UpdateRequest, opts ...grpc.CallOption) (*updateproto.UpdateResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) DeleteFirmwareBaseline(ctx context.Context, in *updateproto.UpdateRequest, opts ...grpc.CallOption) (*updateproto.UpdateResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) GetFirmwareComplianceReport(ctx context.Context, in *updateproto.UpdateRequest, opts ...grpc.CallOption) (*updateproto.UpdateResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) RemediateFirmwareDrift(ctx context.Context, in *updateproto.UpdateRequest, opts ...grpc.CallOption) (*updateproto.UpdateResponse, error) {
+	return nil, errors.New("fakeError")
+}
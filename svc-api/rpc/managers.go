@@ -181,3 +181,37 @@ func DeleteRemoteAccountService(ctx context.Context, req managersproto.ManagerRe
 	defer conn.Close()
 	return resp, nil
 }
+
+// GetLogLevel will do the rpc call to get the log level of the services
+func GetLogLevel(ctx context.Context, req managersproto.ManagerRequest) (*managersproto.ManagerResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Managers)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	mService := NewManagersClientFunc(conn)
+	resp, err := mService.GetLogLevel(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, nil
+}
+
+// SetLogLevel will do the rpc call to override the log level of the services
+func SetLogLevel(ctx context.Context, req managersproto.ManagerRequest) (*managersproto.ManagerResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Managers)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	mService := NewManagersClientFunc(conn)
+	resp, err := mService.SetLogLevel(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, nil
+}
@@ -442,3 +442,93 @@ func TestDeleteRemoteAccountService(t *testing.T) {
 		})
 	}
 }
+
+func TestGetLogLevel(t *testing.T) {
+	type args struct {
+		req managersproto.ManagerRequest
+	}
+	tests := []struct {
+		name                  string
+		args                  args
+		ClientFunc            func(clientName string) (*grpc.ClientConn, error)
+		NewManagersClientFunc func(cc *grpc.ClientConn) managersproto.ManagersClient
+		want                  *managersproto.ManagerResponse
+		wantErr               bool
+	}{
+		{
+			name:                  "Client func error",
+			args:                  args{},
+			ClientFunc:            func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewManagersClientFunc: func(cc *grpc.ClientConn) managersproto.ManagersClient { return nil },
+			want:                  nil,
+			wantErr:               true,
+		},
+		{
+			name:                  "GetLogLevel error",
+			args:                  args{},
+			ClientFunc:            func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewManagersClientFunc: func(cc *grpc.ClientConn) managersproto.ManagersClient { return fakeStruct{} },
+			want:                  nil,
+			wantErr:               true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewManagersClientFunc = tt.NewManagersClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetLogLevel(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetLogLevel() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetLogLevel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetLogLevel(t *testing.T) {
+	type args struct {
+		req managersproto.ManagerRequest
+	}
+	tests := []struct {
+		name                  string
+		args                  args
+		ClientFunc            func(clientName string) (*grpc.ClientConn, error)
+		NewManagersClientFunc func(cc *grpc.ClientConn) managersproto.ManagersClient
+		want                  *managersproto.ManagerResponse
+		wantErr               bool
+	}{
+		{
+			name:                  "Client func error",
+			args:                  args{},
+			ClientFunc:            func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewManagersClientFunc: func(cc *grpc.ClientConn) managersproto.ManagersClient { return nil },
+			want:                  nil,
+			wantErr:               true,
+		},
+		{
+			name:                  "SetLogLevel error",
+			args:                  args{},
+			ClientFunc:            func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewManagersClientFunc: func(cc *grpc.ClientConn) managersproto.ManagersClient { return fakeStruct{} },
+			want:                  nil,
+			wantErr:               true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewManagersClientFunc = tt.NewManagersClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SetLogLevel(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SetLogLevel() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SetLogLevel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -97,6 +97,23 @@ func ComputerSystemReset(ctx context.Context, req systemsproto.ComputerSystemRes
 	return resp, nil
 }
 
+// SystemOemAction will do the rpc call to perform an OEM action of the computer system
+func SystemOemAction(ctx context.Context, req systemsproto.OemActionRequest) (*systemsproto.SystemsResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Systems)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	asService := NewSystemsClientFunc(conn)
+	resp, err := asService.SystemOemAction(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, nil
+}
+
 // SetDefaultBootOrder will do the rpc call to set the default boot order of computer system
 func SetDefaultBootOrder(ctx context.Context, req systemsproto.DefaultBootOrderRequest) (*systemsproto.SystemsResponse, error) {
 	ctx = common.CreateMetadata(ctx)
@@ -227,6 +227,43 @@ func TestComputerSystemReset(t *testing.T) {
 	}
 }
 
+func TestSystemOemAction(t *testing.T) {
+	tests := []struct {
+		name                 string
+		req                  systemsproto.OemActionRequest
+		ClientFunc           func(clientName string) (*grpc.ClientConn, error)
+		NewSystemsClientFunc func(cc *grpc.ClientConn) systemsproto.SystemsClient
+		wantErr              bool
+	}{
+		{
+			name:                 "Client func error",
+			ClientFunc:           func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewSystemsClientFunc: func(cc *grpc.ClientConn) systemsproto.SystemsClient { return nil },
+			wantErr:              true,
+		},
+		{
+			name:                 "SystemOemAction error",
+			ClientFunc:           func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewSystemsClientFunc: func(cc *grpc.ClientConn) systemsproto.SystemsClient { return fakeStruct2{} },
+			wantErr:              true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewSystemsClientFunc = tt.NewSystemsClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SystemOemAction(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SystemOemAction() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != nil {
+				t.Errorf("SystemOemAction() = %v, want nil", got)
+			}
+		})
+	}
+}
+
 func TestSetDefaultBootOrder(t *testing.T) {
 	type args struct {
 		req systemsproto.DefaultBootOrderRequest
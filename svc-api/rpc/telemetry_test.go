@@ -487,3 +487,116 @@ func TestDoUpdateTrigger(t *testing.T) {
 		})
 	}
 }
+
+func TestDoGetMetricHistory(t *testing.T) {
+	type args struct {
+		req teleproto.TelemetryRequest
+	}
+	tests := []struct {
+		name                   string
+		args                   args
+		ClientFunc             func(clientName string) (*grpc.ClientConn, error)
+		NewTelemetryClientFunc func(cc *grpc.ClientConn) teleproto.TelemetryClient
+		want                   *teleproto.TelemetryResponse
+		wantErr                bool
+	}{
+		{
+			name:                   "Client func error",
+			args:                   args{},
+			ClientFunc:             func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewTelemetryClientFunc: func(cc *grpc.ClientConn) teleproto.TelemetryClient { return nil },
+			want:                   nil,
+			wantErr:                true,
+		},
+		{
+			name:                   "DoGetMetricHistory error",
+			args:                   args{},
+			ClientFunc:             func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewTelemetryClientFunc: func(cc *grpc.ClientConn) teleproto.TelemetryClient { return fakeStruct{} },
+			want:                   nil,
+			wantErr:                true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewTelemetryClientFunc = tt.NewTelemetryClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoGetMetricHistory(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoGetMetricHistory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoGetMetricHistory() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoGetOpenMetrics(t *testing.T) {
+	type args struct {
+		req teleproto.TelemetryRequest
+	}
+	tests := []struct {
+		name                   string
+		args                   args
+		ClientFunc             func(clientName string) (*grpc.ClientConn, error)
+		NewTelemetryClientFunc func(cc *grpc.ClientConn) teleproto.TelemetryClient
+		want                   *teleproto.TelemetryResponse
+		wantErr                bool
+	}{
+		{
+			name:                   "Client func error",
+			args:                   args{},
+			ClientFunc:             func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewTelemetryClientFunc: func(cc *grpc.ClientConn) teleproto.TelemetryClient { return nil },
+			want:                   nil,
+			wantErr:                true,
+		},
+		{
+			name:                   "DoGetOpenMetrics error",
+			args:                   args{},
+			ClientFunc:             func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewTelemetryClientFunc: func(cc *grpc.ClientConn) teleproto.TelemetryClient { return fakeStruct{} },
+			want:                   nil,
+			wantErr:                true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewTelemetryClientFunc = tt.NewTelemetryClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoGetOpenMetrics(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoGetOpenMetrics() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoGetOpenMetrics() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoConfigureTelemetryResources(t *testing.T) {
+	rpcs := map[string]func(context.Context, teleproto.TelemetryRequest) (*teleproto.TelemetryResponse, error){
+		"DoCreateMetricReportDefinition": DoCreateMetricReportDefinition,
+		"DoUpdateMetricReportDefinition": DoUpdateMetricReportDefinition,
+		"DoDeleteMetricReportDefinition": DoDeleteMetricReportDefinition,
+		"DoCreateTrigger":                DoCreateTrigger,
+		"DoDeleteTrigger":                DoDeleteTrigger,
+	}
+	for name, doRPC := range rpcs {
+		t.Run(name, func(t *testing.T) {
+			ClientFunc = func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") }
+			if _, err := doRPC(context.Background(), teleproto.TelemetryRequest{}); err == nil {
+				t.Errorf("%s() should fail when the client connection fails", name)
+			}
+			ClientFunc = func(clientName string) (*grpc.ClientConn, error) { return nil, nil }
+			NewTelemetryClientFunc = func(cc *grpc.ClientConn) teleproto.TelemetryClient { return fakeStruct{} }
+			if _, err := doRPC(context.Background(), teleproto.TelemetryRequest{}); err == nil {
+				t.Errorf("%s() should fail when the RPC fails", name)
+			}
+		})
+	}
+}
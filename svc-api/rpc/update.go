@@ -161,3 +161,117 @@ func DoStartUpdate(ctx context.Context, req updateproto.UpdateRequest) (*updatep
 	defer conn.Close()
 	return resp, err
 }
+
+// DoCreateFirmwareBaseline defines the RPC call for
+// CreateFirmwareBaseline from update micro service
+func DoCreateFirmwareBaseline(ctx context.Context, req updateproto.UpdateRequest) (*updateproto.UpdateResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Update)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	update := NewUpdateClientFunc(conn)
+
+	resp, err := update.CreateFirmwareBaseline(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoGetFirmwareBaselineCollection defines the RPC call for
+// GetFirmwareBaselineCollection from update micro service
+func DoGetFirmwareBaselineCollection(ctx context.Context, req updateproto.UpdateRequest) (*updateproto.UpdateResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Update)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	update := NewUpdateClientFunc(conn)
+
+	resp, err := update.GetFirmwareBaselineCollection(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoGetFirmwareBaseline defines the RPC call for
+// GetFirmwareBaseline from update micro service
+func DoGetFirmwareBaseline(ctx context.Context, req updateproto.UpdateRequest) (*updateproto.UpdateResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Update)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	update := NewUpdateClientFunc(conn)
+
+	resp, err := update.GetFirmwareBaseline(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoDeleteFirmwareBaseline defines the RPC call for
+// DeleteFirmwareBaseline from update micro service
+func DoDeleteFirmwareBaseline(ctx context.Context, req updateproto.UpdateRequest) (*updateproto.UpdateResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Update)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	update := NewUpdateClientFunc(conn)
+
+	resp, err := update.DeleteFirmwareBaseline(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoGetFirmwareComplianceReport defines the RPC call for
+// GetFirmwareComplianceReport from update micro service
+func DoGetFirmwareComplianceReport(ctx context.Context, req updateproto.UpdateRequest) (*updateproto.UpdateResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Update)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	update := NewUpdateClientFunc(conn)
+
+	resp, err := update.GetFirmwareComplianceReport(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoRemediateFirmwareDrift defines the RPC call for
+// RemediateFirmwareDrift from update micro service
+func DoRemediateFirmwareDrift(ctx context.Context, req updateproto.UpdateRequest) (*updateproto.UpdateResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Update)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	update := NewUpdateClientFunc(conn)
+
+	resp, err := update.RemediateFirmwareDrift(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
@@ -352,3 +352,30 @@ func TestDoStartUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestDoFirmwareBaselineRPCs(t *testing.T) {
+	rpcs := map[string]func(context.Context, updateproto.UpdateRequest) (*updateproto.UpdateResponse, error){
+		"DoCreateFirmwareBaseline":        DoCreateFirmwareBaseline,
+		"DoGetFirmwareBaselineCollection": DoGetFirmwareBaselineCollection,
+		"DoGetFirmwareBaseline":           DoGetFirmwareBaseline,
+		"DoDeleteFirmwareBaseline":        DoDeleteFirmwareBaseline,
+		"DoGetFirmwareComplianceReport":   DoGetFirmwareComplianceReport,
+		"DoRemediateFirmwareDrift":        DoRemediateFirmwareDrift,
+	}
+	clientFuncs := map[string]func(clientName string) (*grpc.ClientConn, error){
+		"Client func error": func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+		"RPC error":         func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+	}
+	NewUpdateClientFunc = func(cc *grpc.ClientConn) updateproto.UpdateClient { return fakeStruct{} }
+	for name, rpc := range rpcs {
+		for clientName, clientFunc := range clientFuncs {
+			ClientFunc = clientFunc
+			t.Run(name+" "+clientName, func(t *testing.T) {
+				got, err := rpc(context.Background(), updateproto.UpdateRequest{})
+				if err == nil || got != nil {
+					t.Errorf("%s() = %v, %v, want error", name, got, err)
+				}
+			})
+		}
+	}
+}
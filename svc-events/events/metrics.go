@@ -0,0 +1,48 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package events
+
+import (
+	"github.com/ODIM-Project/ODIM/lib-utilities/metrics"
+)
+
+const (
+	deliveryDelivered = "delivered"
+	deliveryFailed    = "failed"
+)
+
+var (
+	eventsForwarded = metrics.NewCounterVec("odim_events_forwarded_total",
+		"Number of events forwarded to the subscribers on the first attempt, by result", "result")
+	eventsUndeliveredSaved = metrics.NewCounterVec("odim_events_undelivered_saved_total",
+		"Number of events saved in UndeliveredEvents after a failed delivery")
+	eventsRedelivered = metrics.NewCounterVec("odim_events_redelivered_total",
+		"Number of undelivered events delivered on a later attempt")
+	eventsUndeliveredBacklog = metrics.NewGaugeVec("odim_events_undelivered_backlog",
+		"Number of events still in UndeliveredEvents for a destination, as of the last redelivery pass", "destination")
+)
+
+func init() {
+	metrics.NewGaugeFunc("odim_events_reattempt_in_queue",
+		"Number of events being retried by the delivery retry routines", nil, func() float64 {
+			reattemptLock.Lock()
+			defer reattemptLock.Unlock()
+			var total int
+			for _, count := range reAttemptInQueue {
+				total += count
+			}
+			return float64(total)
+		})
+}
@@ -38,10 +38,14 @@ import (
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
 	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
 	fabricproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/fabrics"
+	teleproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/telemetry"
 	"github.com/ODIM-Project/ODIM/lib-utilities/services"
+	"github.com/ODIM-Project/ODIM/lib-utilities/tracing"
 	"github.com/ODIM-Project/ODIM/svc-events/evmodel"
 	uuid "github.com/satori/go.uuid"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var (
@@ -86,6 +90,10 @@ func (e *ExternalInterfaces) PublishEventsToDestination(ctx context.Context, dat
 		return false
 	}
 	event := data.(common.Events)
+	ctx, span := tracing.StartSpan(tracing.ExtractTraceParent(ctx, event.TraceParent, ""), "consume event", trace.SpanKindConsumer,
+		attribute.String("messaging.source", event.IP),
+		attribute.String("odim.event_type", event.EventType))
+	defer span.End()
 	if event.EventType == "PluginStartUp" {
 		logging.Info("received plugin started event from ", event.IP)
 		go callPluginStartUp(ctx, event)
@@ -143,7 +151,8 @@ func (e *ExternalInterfaces) PublishEventsToDestination(ctx context.Context, dat
 				flag = true
 			}
 		} else if strings.EqualFold("ResourceAdded", message.Events[0].EventType) || strings.EqualFold("ResourceRemoved", message.Events[0].EventType) {
-			if strings.Contains(message.Events[0].OriginOfCondition.Oid, "Volumes") {
+			// resource events published by ODIM for the collections are the result of a rediscovery
+			if strings.Contains(message.Events[0].OriginOfCondition.Oid, "Volumes") && !strings.Contains(host, "Collection") {
 				s := strings.Split(message.Events[0].OriginOfCondition.Oid, "/")
 				storageURI := fmt.Sprintf("/%s/%s/%s/%s/%s/", s[1], s[2], s[3], s[4], s[5])
 				go rediscoverSystemInventory(ctx, deviceUUID, storageURI)
@@ -174,6 +183,9 @@ func (e *ExternalInterfaces) PublishEventsToDestination(ctx context.Context, dat
 
 func (e *ExternalInterfaces) publishMetricReport(ctx context.Context, requestData string) bool {
 	eventUniqueID := uuid.NewV4().String()
+	if conf := config.Data.TelemetryHistoryConf; conf != nil && conf.Enabled {
+		go ingestMetricReport(ctx, requestData)
+	}
 	subscriptions, err := e.GetEvtSubscriptions("MetricReport")
 	if err != nil {
 		return false
@@ -184,6 +196,29 @@ func (e *ExternalInterfaces) publishMetricReport(ctx context.Context, requestDat
 	return true
 }
 
+// ingestMetricReport sends the metric report to the telemetry service for saving it into the metric history
+func ingestMetricReport(ctx context.Context, requestData string) {
+	conn, err := ServiceDiscoveryFunc(services.Telemetry)
+	if err != nil {
+		logging.Error("Error while connecting to the telemetry service for ingesting the metric report: ", err.Error())
+		return
+	}
+	defer conn.Close()
+	tele := teleproto.NewTelemetryClient(conn)
+	ctxt := common.CreateNewRequestContext(ctx)
+	ctxt = common.CreateMetadata(ctxt)
+	resp, err := tele.IngestMetricReport(ctxt, &teleproto.TelemetryRequest{
+		RequestBody: []byte(requestData),
+	})
+	if err != nil {
+		logging.Error("Error while ingesting the metric report: ", err.Error())
+		return
+	}
+	if resp.StatusCode != http.StatusOK {
+		logging.Error("Error while ingesting the metric report: ", string(resp.Body))
+	}
+}
+
 func filterEventsToBeForwarded(ctx context.Context, subscription dmtf.EventDestination, event common.Event, originResources []model.Link) bool {
 	eventTypes := subscription.EventTypes
 	messageIds := subscription.MessageIds
@@ -213,6 +248,9 @@ func filterEventsToBeForwarded(ctx context.Context, subscription dmtf.EventDesti
 	return false
 }
 
+// aggregationSourcesURI is the origin of the events of a plugin about a server
+const aggregationSourcesURI = "/redfish/v1/AggregationService/AggregationSources"
+
 // formatEvent will format the event string according to the odimra
 // add uuid:systemid/chassisid in place of systemid/chassisid
 func formatEvent(event common.MessageData, originResource, hostIP string) (common.MessageData, string) {
@@ -230,6 +268,11 @@ func formatEvent(event common.MessageData, originResource, hostIP string) (commo
 			event.OriginOfCondition.Oid = strings.Replace(event.OriginOfCondition.Oid, "/redfish/v1/Chassis/", str, -1)
 			str = "/redfish/v1/Managers/" + deviceUUID + "."
 			event.OriginOfCondition.Oid = strings.Replace(event.OriginOfCondition.Oid, "/redfish/v1/Managers/", str, -1)
+			// the events of the plugin about the server itself, like the change of its certificate,
+			// originate from the aggregation source of the server
+			if event.OriginOfCondition.Oid == aggregationSourcesURI {
+				event.OriginOfCondition.Oid += "/" + originResource
+			}
 		}
 
 	}
@@ -292,9 +335,11 @@ func (e *ExternalInterfaces) postEvent(eventMessage evmodel.EventPost) {
 	resp, err := SendEventFunc(eventMessage.Destination, eventMessage.Message)
 	if err == nil {
 		resp.Body.Close()
+		eventsForwarded.WithLabelValues(deliveryDelivered).Inc()
 		logging.Info("Event is successfully forwarded 1 ")
 		return
 	}
+	eventsForwarded.WithLabelValues(deliveryFailed).Inc()
 	undeliveredEventID := eventMessage.Destination + ":" + eventMessage.EventID
 	eventMessage.UndeliveredEventID = undeliveredEventID
 	saveEventChanel <- eventMessage
@@ -350,6 +395,7 @@ func (e *ExternalInterfaces) reAttemptEvents(eventMessage evmodel.EventPost) {
 		if err == nil {
 			resp.Body.Close()
 			logging.Info("Event is successfully forwarded after reattempt ")
+			eventsRedelivered.WithLabelValues().Inc()
 			err = e.DeleteUndeliveredEvents(eventMessage.UndeliveredEventID)
 			if err != nil {
 				logging.Error("error while deleting undelivered events: ", err.Error())
@@ -364,8 +410,8 @@ func (e *ExternalInterfaces) reAttemptEvents(eventMessage evmodel.EventPost) {
 }
 
 // rediscoverSystemInventory will be triggered when ever the System Restart or Power On
-// event is detected it will create a rpc for aggregation which will delete all system inventory //
-// and rediscover all of them
+// event is detected it will create a rpc for aggregation which will rediscover the system inventory
+// and update only the resources added, changed or removed
 func rediscoverSystemInventory(ctx context.Context, systemID, systemURL string) {
 	systemURL = strings.TrimSuffix(systemURL, "/")
 
@@ -524,6 +570,7 @@ func (e *ExternalInterfaces) checkUndeliveredEvents(destination string) {
 			}
 		}()
 		cursorCount := 0
+		var pending, delivered int
 		for {
 			destData, tempCount, err := e.GetUndeliveredEventsKeyList(evmodel.UndeliveredEvents, destination, common.OnDisk, cursorCount)
 			if err != nil {
@@ -531,6 +578,7 @@ func (e *ExternalInterfaces) checkUndeliveredEvents(destination string) {
 				return
 			}
 			cursorCount = tempCount
+			pending += len(destData)
 			for _, dest := range destData {
 
 				event, err := e.GetUndeliveredEvents(dest)
@@ -551,6 +599,8 @@ func (e *ExternalInterfaces) checkUndeliveredEvents(destination string) {
 					break
 				}
 				logging.Debug("Event is successfully forwarded")
+				delivered++
+				eventsRedelivered.WithLabelValues().Inc()
 				err = e.DeleteUndeliveredEvents(dest)
 				if err != nil {
 					logging.Error("error while deleting undelivered events: ", err.Error())
@@ -560,6 +610,7 @@ func (e *ExternalInterfaces) checkUndeliveredEvents(destination string) {
 				break
 			}
 		}
+		eventsUndeliveredBacklog.WithLabelValues(destination).Set(float64(pending - delivered))
 
 	}
 }
@@ -252,6 +252,7 @@ func Test_callPluginStartUp(t *testing.T) {
 	pc.removeFabricRPCCall(evcommon.MockContext(), "Fabric", "test")
 	pc.addFabricRPCCall(evcommon.MockContext(), "Zones", "test")
 	pc.addFabricRPCCall(evcommon.MockContext(), "Fabric", "test")
+	ingestMetricReport(evcommon.MockContext(), "")
 	rediscoverSystemInventory(evcommon.MockContext(), "3bd1f589-117a-4cf9-89f2-da44ee8e012b.1", "/redfish/v1/UpdateService/FirmwareInentory/valid.1")
 
 	callPluginStartUp(evcommon.MockContext(), common.Events{})
@@ -309,6 +310,19 @@ func Test_isResourceTypeSubscribed(t *testing.T) {
 	assert.Equal(t, true, status, "There shoud be no error ")
 
 }
+func TestFormatEvent(t *testing.T) {
+	message := common.MessageData{
+		Events: []common.Event{
+			{MessageID: "ResourceEvent.1.0.ResourceChanged", OriginOfCondition: &common.Link{Oid: "/redfish/v1/Systems/1/Bios"}},
+			{MessageID: common.BMCCertificateChangedMessageID, OriginOfCondition: &common.Link{Oid: "/redfish/v1/AggregationService/AggregationSources"}},
+		},
+	}
+	event, deviceUUID := formatEvent(message, "6d4a0a66-7efa-578e-83cf-44dc68d2874e.1", "100.100.100.100")
+	assert.Equal(t, "6d4a0a66-7efa-578e-83cf-44dc68d2874e", deviceUUID, "device UUID should be the one of the system")
+	assert.Equal(t, "/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1/Bios", event.Events[0].OriginOfCondition.Oid)
+	assert.Equal(t, "/redfish/v1/AggregationService/AggregationSources/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1", event.Events[1].OriginOfCondition.Oid)
+}
+
 func mockCacheData() {
 	eventSourceToManagerIDMap = make(map[string]string, 2)
 	eventSourceToManagerIDMap["100.100.100.100"] = "6d4a0a66-7efa-578e-83cf-44dc68d2874e.1"
@@ -434,7 +434,9 @@ func (e *ExternalInterfaces) saveEventWorkers() {
 			err = conn.SaveUndeliveredEvents(evmodel.UndeliveredEvents, job.UndeliveredEventID, job.Message)
 			if err != nil {
 				logging.Error("error occurred while save saveEventWorker ", err.Error())
+				continue
 			}
 		}
+		eventsUndeliveredSaved.WithLabelValues().Inc()
 	}
 }
@@ -160,8 +160,13 @@ func (e *ExternalInterface) InstallLicenseService(ctx context.Context, req *lice
 	if err != nil {
 		l.LogWithFields(ctx).Error(err)
 		common.GeneralError(errStatusCode, response.InternalError, err.Error(), nil, taskInfo)
+		return
 	}
 	l.LogWithFields(ctx).Debug("Map with manager Links: ", linksMap)
+	if policy, ok := getRolloutPolicy(req.RequestBody, installreq.Links.Link); ok {
+		e.rollingInstallLicense(ctx, sessionUserName, taskID, installreq.LicenseString, linksMap, policy, taskInfo)
+		return
+	}
 	partialResultFlag := false
 	subTaskChannel := make(chan int32, len(linksMap))
 	for serverURI := range linksMap {
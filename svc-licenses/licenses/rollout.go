@@ -0,0 +1,150 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package licenses
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime"
+	"sort"
+	"strings"
+
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	lcommon "github.com/ODIM-Project/ODIM/svc-licenses/lcommon"
+)
+
+// licenseRolloutRequest holds the Oem properties of the install license request
+type licenseRolloutRequest struct {
+	Oem *struct {
+		Odim *common.RolloutPolicy `json:"Odim,omitempty"`
+	} `json:"Oem,omitempty"`
+}
+
+// getRolloutPolicy returns how the license is rolled out in batches, and whether it is to be,
+// which is the case when an aggregate is linked or the rollout properties are given
+func getRolloutPolicy(requestBody []byte, links []*dmtf.Link) (common.RolloutPolicy, bool) {
+	var request licenseRolloutRequest
+	json.Unmarshal(requestBody, &request)
+	if request.Oem != nil && request.Oem.Odim != nil {
+		return *request.Oem.Odim, true
+	}
+	for _, link := range links {
+		if strings.Contains(link.Oid, "Aggregates") {
+			return common.RolloutPolicy{}, true
+		}
+	}
+	return common.RolloutPolicy{}, false
+}
+
+// rollingInstallLicense installs the license on the managers in batches. The license is installed
+// on the managers of a batch only after it is installed on the managers of the previous batch.
+// The rollout is paused, leaving the task suspended, when the number of managers the license
+// could not be installed on reaches the failure threshold.
+func (e *ExternalInterface) rollingInstallLicense(ctx context.Context, sessionUserName, taskID, licenseString string, linksMap map[string]bool, policy common.RolloutPolicy, taskInfo *common.TaskUpdateInfo) {
+	if err := policy.Validate(); err != nil {
+		errMsg := "invalid rollout properties: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errMsg, []interface{}{fmt.Sprintf("%v", policy), "Oem/Odim"}, taskInfo)
+		return
+	}
+	var managers []string
+	for serverURI := range linksMap {
+		if _, _, err := lcommon.GetIDsFromURI(serverURI); err != nil {
+			errMsg := "error while trying to get system ID from " + serverURI + ": " + err.Error()
+			l.LogWithFields(ctx).Error(errMsg)
+			common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"SystemID", serverURI}, taskInfo)
+			return
+		}
+		managers = append(managers, serverURI)
+	}
+	if len(managers) == 0 {
+		errMsg := "the devices authorized have no managers"
+		l.LogWithFields(ctx).Error(errMsg)
+		common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errMsg, []interface{}{"", "AuthorizedDevices"}, taskInfo)
+		return
+	}
+	sort.Strings(managers)
+
+	encodedKey := base64.StdEncoding.EncodeToString([]byte(licenseString))
+	operation := func(ctx context.Context, serverURI string) bool {
+		uuid, managerID, _ := lcommon.GetIDsFromURI(serverURI)
+		reqPostBody := map[string]interface{}{"LicenseString": encodedKey, "AuthorizedDevices": "/redfish/v1/Managers/" + managerID}
+		reqBody, _ := json.Marshal(reqPostBody)
+		subTaskChannel := make(chan int32, 1)
+		e.sendRequest(ctx, uuid, sessionUserName, taskID, serverURI, reqBody, subTaskChannel)
+		statusCode := <-subTaskChannel
+		if statusCode != http.StatusOK && statusCode != http.StatusAccepted {
+			l.LogWithFields(ctx).Errorf("install license on %s failed with status %d", serverURI, statusCode)
+			return false
+		}
+		return true
+	}
+	batchDone := func(result common.RolloutResult) {
+		percentComplete := int32(result.Done() * 100 / len(managers))
+		if percentComplete == 100 {
+			return
+		}
+		var resp response.RPC
+		resp.StatusCode = http.StatusOK
+		task := fillTaskData(taskID, taskInfo.TargetURI, taskInfo.TaskRequest, resp, common.Running, common.OK, percentComplete, http.MethodPost)
+		err := e.External.UpdateTask(ctx, task)
+		if err != nil && err.Error() == common.Cancelling {
+			task = fillTaskData(taskID, taskInfo.TargetURI, taskInfo.TaskRequest, resp, common.Cancelled, common.OK, percentComplete, http.MethodPost)
+			e.External.UpdateTask(ctx, task)
+			runtime.Goexit()
+		}
+	}
+	result := common.RollOut(ctx, managers, policy, operation, batchDone)
+
+	if result.Paused() {
+		errMsg := fmt.Sprintf("the rollout is paused as the license could not be installed on %v, it is not installed on %v. for more information please check SubTasks in URI: /redfish/v1/TaskService/Tasks/%s",
+			result.Failed, result.Skipped, taskID)
+		l.LogWithFields(ctx).Error(errMsg)
+		resp := common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+		percentComplete := int32(result.Done() * 100 / len(managers))
+		task := fillTaskData(taskID, taskInfo.TargetURI, taskInfo.TaskRequest, resp, common.Suspended, common.Critical, percentComplete, http.MethodPost)
+		e.External.UpdateTask(ctx, task)
+		return
+	}
+	if len(result.Failed) > 0 {
+		errMsg := fmt.Sprintf("the license could not be installed on %v. for more information please check SubTasks in URI: /redfish/v1/TaskService/Tasks/%s", result.Failed, taskID)
+		l.LogWithFields(ctx).Error(errMsg)
+		common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+		return
+	}
+
+	l.LogWithFields(ctx).Info("All Install License requests successfully completed. for more information please check SubTasks in URI: /redfish/v1/TaskService/Tasks/" + taskID)
+	var resp response.RPC
+	resp.StatusMessage = response.Success
+	resp.StatusCode = http.StatusOK
+	args := response.Args{
+		Code:    resp.StatusMessage,
+		Message: "Request completed successfully",
+	}
+	resp.Body = args.CreateGenericErrorResponse()
+	task := fillTaskData(taskID, taskInfo.TargetURI, taskInfo.TaskRequest, resp, common.Completed, common.OK, 100, http.MethodPost)
+	err := e.External.UpdateTask(ctx, task)
+	if err != nil && err.Error() == common.Cancelling {
+		task = fillTaskData(taskID, taskInfo.TargetURI, taskInfo.TaskRequest, resp, common.Cancelled, common.Critical, 100, http.MethodPost)
+		e.External.UpdateTask(ctx, task)
+		runtime.Goexit()
+	}
+}
@@ -0,0 +1,129 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package licenses
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	licenseproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/licenses"
+	lcommon "github.com/ODIM-Project/ODIM/svc-licenses/lcommon"
+	"github.com/ODIM-Project/ODIM/svc-licenses/model"
+)
+
+// fakeLicensedManagers records the license installs of a rollout test
+type fakeLicensedManagers struct {
+	lock      sync.Mutex
+	failing   map[string]bool
+	installed []string
+	taskState string
+}
+
+func (f *fakeLicensedManagers) getTarget(uuid string) (*model.Target, *errors.Error) {
+	return &model.Target{ManagerAddress: uuid, UserName: "admin", Password: []byte("password"), PluginID: "ILO"}, nil
+}
+
+func (f *fakeLicensedManagers) updateTask(ctx context.Context, task common.TaskData) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if task.TaskID == "rollingTask" {
+		f.taskState = task.TaskState
+	}
+	return nil
+}
+
+func (f *fakeLicensedManagers) contactPlugin(ctx context.Context, req model.PluginContactRequest, errorMessage string) ([]byte, string, lcommon.PluginTaskInfo, model.ResponseStatus, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	address := req.DeviceInfo.(*model.Target).ManagerAddress
+	if f.failing[address] {
+		return nil, "", lcommon.PluginTaskInfo{}, model.ResponseStatus{StatusCode: http.StatusBadRequest}, fmt.Errorf("%sinvalid license", errorMessage)
+	}
+	f.installed = append(f.installed, address)
+	return []byte(`{}`), "", lcommon.PluginTaskInfo{}, model.ResponseStatus{StatusCode: http.StatusOK}, nil
+}
+
+func TestInstallLicenseServiceInBatches(t *testing.T) {
+	tests := []struct {
+		name          string
+		failing       map[string]bool
+		oem           string
+		wantInstalled []string
+		wantState     string
+	}{
+		{
+			name:          "all installed",
+			oem:           `{"Odim":{"BatchSize":2}}`,
+			wantInstalled: []string{"m1", "m2", "m3", "m4"},
+			wantState:     common.Completed,
+		},
+		{
+			name:          "rollout paused by a failed batch",
+			failing:       map[string]bool{"m2": true},
+			oem:           `{"Odim":{"BatchSize":2}}`,
+			wantInstalled: []string{"m1"},
+			wantState:     common.Suspended,
+		},
+		{
+			name:          "failure threshold not reached",
+			failing:       map[string]bool{"m1": true},
+			oem:           `{"Odim":{"BatchSize":1,"FailureThreshold":2}}`,
+			wantInstalled: []string{"m2", "m3", "m4"},
+			wantState:     common.Exception,
+		},
+		{
+			name:      "invalid batch size",
+			oem:       `{"Odim":{"BatchSize":-1}}`,
+			wantState: common.Exception,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeLicensedManagers{failing: tt.failing}
+			e := mockGetExternalInterface()
+			e.External.GetTarget = fake.getTarget
+			e.External.ContactPlugin = fake.contactPlugin
+			e.External.UpdateTask = fake.updateTask
+			req := &licenseproto.InstallLicenseRequest{
+				RequestBody: []byte(`{
+					"LicenseString": "XXX-XXX-XXX-XXX-XXX",
+					"Links": {
+						"AuthorizedDevices": [
+							{"@odata.id": "/redfish/v1/Managers/m1.1"},
+							{"@odata.id": "/redfish/v1/Managers/m2.1"},
+							{"@odata.id": "/redfish/v1/Managers/m3.1"},
+							{"@odata.id": "/redfish/v1/Managers/m4.1"}
+						]
+					},
+					"Oem": ` + tt.oem + `
+				}`)}
+			e.InstallLicenseService(mockContext(), req, "admin", "rollingTask")
+			sort.Strings(fake.installed)
+			if !reflect.DeepEqual(fake.installed, tt.wantInstalled) {
+				t.Errorf("InstallLicenseService() installed on %v, want %v", fake.installed, tt.wantInstalled)
+			}
+			if fake.taskState != tt.wantState {
+				t.Errorf("InstallLicenseService() task state = %v, want %v", fake.taskState, tt.wantState)
+			}
+		})
+	}
+}
@@ -21,6 +21,8 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-rest-client/pmbhandle"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	"github.com/ODIM-Project/ODIM/lib-utilities/health"
+	"github.com/ODIM-Project/ODIM/lib-utilities/logs"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
 	"github.com/ODIM-Project/ODIM/lib-utilities/services"
 	"github.com/ODIM-Project/ODIM/svc-managers/mgrcommon"
@@ -51,6 +53,11 @@ type DB struct {
 	UpdateData          func(string, map[string]interface{}, string) error
 	SavePluginTaskInfo  func(context.Context, string, string, string, string) error
 	GetResource         func(string, string) (string, *errors.Error)
+	GetServiceHealth    func() ([]health.ServiceReport, error)
+
+	GetLogLevelOverrides   func() ([]logs.LevelOverride, error)
+	SaveLogLevelOverride   func(logs.LevelOverride) error
+	DeleteLogLevelOverride func(string) error
 }
 
 // RPC struct to inject the rpc call to other services
@@ -75,6 +82,11 @@ func GetExternalInterface() *ExternalInterface {
 			UpdateData:          mgrmodel.UpdateData,
 			SavePluginTaskInfo:  services.SavePluginTaskInfo,
 			GetResource:         mgrmodel.GetResource,
+			GetServiceHealth:    health.GetServiceReports,
+
+			GetLogLevelOverrides:   common.GetLogLevelOverrides,
+			SaveLogLevelOverride:   common.SaveLogLevelOverride,
+			DeleteLogLevelOverride: common.DeleteLogLevelOverride,
 		},
 		RPC: RPC{
 			UpdateTask: mgrcommon.UpdateTask,
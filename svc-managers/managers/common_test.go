@@ -27,6 +27,8 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	"github.com/ODIM-Project/ODIM/lib-utilities/health"
+	"github.com/ODIM-Project/ODIM/lib-utilities/logs"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
 	"github.com/ODIM-Project/ODIM/svc-managers/mgrcommon"
 	"github.com/ODIM-Project/ODIM/svc-managers/mgrmodel"
@@ -63,6 +65,11 @@ func mockGetExternalInterface() *ExternalInterface {
 			UpdateData:          mockUpdateData,
 			SavePluginTaskInfo:  mockSavePluginTaskInfo,
 			GetResource:         mockGetResource,
+			GetServiceHealth:    mockGetServiceHealth,
+
+			GetLogLevelOverrides:   mockGetLogLevelOverrides,
+			SaveLogLevelOverride:   mockSaveLogLevelOverride,
+			DeleteLogLevelOverride: mockDeleteLogLevelOverride,
 		},
 		RPC: RPC{
 			UpdateTask: mockUpdateTask,
@@ -70,6 +77,33 @@ func mockGetExternalInterface() *ExternalInterface {
 	}
 }
 
+func mockGetServiceHealth() ([]health.ServiceReport, error) {
+	return []health.ServiceReport{
+		{Service: "svc.managers", Instance: "svc.managers-1", Status: health.StatusOK},
+		{Service: "svc.systems", Instance: "svc.systems-1", Status: health.StatusWarning},
+	}, nil
+}
+
+var mockLogLevelOverrides = map[string]logs.LevelOverride{}
+
+func mockGetLogLevelOverrides() ([]logs.LevelOverride, error) {
+	var overrides []logs.LevelOverride
+	for _, override := range mockLogLevelOverrides {
+		overrides = append(overrides, override)
+	}
+	return overrides, nil
+}
+
+func mockSaveLogLevelOverride(override logs.LevelOverride) error {
+	mockLogLevelOverrides[override.Package] = override
+	return nil
+}
+
+func mockDeleteLogLevelOverride(pkg string) error {
+	delete(mockLogLevelOverrides, pkg)
+	return nil
+}
+
 func mockGetAllKeysFromTable(table string) ([]string, error) {
 	return []string{"/redfish/v1/Managers/uuid.1"}, nil
 }
@@ -0,0 +1,175 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package managers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	managersproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/managers"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-managers/mgrmodel"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+// maxLogLevelDuration is the longest time for which the log level can be
+// overridden, in seconds, so that a forgotten override is always reverted
+const maxLogLevelDuration = 24 * 60 * 60
+
+// GetLogLevel returns the log level configured for the ODIM services and
+// the overrides which are active
+func (e *ExternalInterface) GetLogLevel(ctx context.Context, req *managersproto.ManagerRequest) response.RPC {
+	if req.ManagerID != config.Data.RootServiceUUID {
+		errorMessage := "log level is only available on the ODIM manager"
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errorMessage,
+			[]interface{}{"Managers", req.ManagerID}, nil)
+	}
+	return e.logLevelResponse(ctx, req.ManagerID)
+}
+
+// SetLogLevel overrides the log level of all the ODIM services, or only of
+// the given package, for DurationSeconds. All the service instances apply
+// the override within a few seconds and revert it when it expires.
+// A DurationSeconds of 0 reverts the override immediately.
+func (e *ExternalInterface) SetLogLevel(ctx context.Context, req *managersproto.ManagerRequest) response.RPC {
+	if req.ManagerID != config.Data.RootServiceUUID {
+		errorMessage := "log level is only available on the ODIM manager"
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errorMessage,
+			[]interface{}{"Managers", req.ManagerID}, nil)
+	}
+
+	var logLevelReq mgrmodel.LogLevelRequest
+	if err := json.Unmarshal(req.RequestBody, &logLevelReq); err != nil {
+		errorMessage := "while unmarshal the log level request: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, nil, nil)
+	}
+	invalidProperties, err := requestParamsCaseValidatorFunc(req.RequestBody, logLevelReq)
+	if err != nil {
+		errorMessage := "while validating request parameters for log level: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	} else if invalidProperties != "" {
+		errorMessage := "one or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase "
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errorMessage, []interface{}{invalidProperties}, nil)
+	}
+	statusCode, statusMessage, messageArgs, err := validateLogLevelFields(&logLevelReq)
+	if err != nil {
+		errorMessage := "request payload validation failed: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(statusCode, statusMessage, errorMessage, messageArgs, nil)
+	}
+
+	if *logLevelReq.DurationSeconds == 0 {
+		if err := e.DB.DeleteLogLevelOverride(logLevelReq.Package); err != nil {
+			errorMessage := "while reverting the log level: " + err.Error()
+			l.LogWithFields(ctx).Error(errorMessage)
+			return common.GeneralError(http.StatusServiceUnavailable, response.CouldNotEstablishConnection, errorMessage,
+				[]interface{}{config.Data.DBConf.InMemoryHost + ":" + config.Data.DBConf.InMemoryPort}, nil)
+		}
+		l.LogWithFields(ctx).Infof("log level override of package %q is reverted", logLevelReq.Package)
+		return e.logLevelResponse(ctx, req.ManagerID)
+	}
+
+	level, _ := logrus.ParseLevel(logLevelReq.LogLevel)
+	override := l.LevelOverride{
+		Package:   logLevelReq.Package,
+		Level:     level,
+		ExpiresAt: time.Now().Add(time.Duration(*logLevelReq.DurationSeconds) * time.Second).UTC(),
+	}
+	if err := e.DB.SaveLogLevelOverride(override); err != nil {
+		errorMessage := "while saving the log level: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusServiceUnavailable, response.CouldNotEstablishConnection, errorMessage,
+			[]interface{}{config.Data.DBConf.InMemoryHost + ":" + config.Data.DBConf.InMemoryPort}, nil)
+	}
+	l.LogWithFields(ctx).Infof("log level of package %q is set to %s until %s", override.Package, level.String(),
+		override.ExpiresAt.Format(time.RFC3339))
+	return e.logLevelResponse(ctx, req.ManagerID)
+}
+
+// validateLogLevelFields validates the log level request payload and returns
+// the status and the message to send back when it is not valid
+func validateLogLevelFields(request *mgrmodel.LogLevelRequest) (int32, string, []interface{}, error) {
+	validate := validator.New()
+	if err := validate.Struct(request); err != nil {
+		for _, err := range err.(validator.ValidationErrors) {
+			return http.StatusBadRequest, response.PropertyMissing, []interface{}{err.Field()}, fmt.Errorf(err.Field() + " field is missing")
+		}
+	}
+	duration := *request.DurationSeconds
+	if duration < 0 || duration > maxLogLevelDuration {
+		return http.StatusBadRequest, response.PropertyValueOutOfRange, []interface{}{strconv.Itoa(duration), "DurationSeconds"},
+			fmt.Errorf("DurationSeconds must be between 0 and %d", maxLogLevelDuration)
+	}
+	if duration == 0 {
+		return http.StatusOK, common.OK, []interface{}{}, nil
+	}
+	if request.LogLevel == "" {
+		return http.StatusBadRequest, response.PropertyMissing, []interface{}{"LogLevel"}, fmt.Errorf("LogLevel field is missing")
+	}
+	if _, err := logrus.ParseLevel(request.LogLevel); err != nil {
+		return http.StatusBadRequest, response.PropertyValueNotInList, []interface{}{request.LogLevel, "LogLevel"}, err
+	}
+	return http.StatusOK, common.OK, []interface{}{}, nil
+}
+
+func (e *ExternalInterface) logLevelResponse(ctx context.Context, managerID string) response.RPC {
+	overrides, err := e.DB.GetLogLevelOverrides()
+	if err != nil {
+		errorMessage := "while reading the log level overrides: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusServiceUnavailable, response.CouldNotEstablishConnection, errorMessage,
+			[]interface{}{config.Data.DBConf.InMemoryHost + ":" + config.Data.DBConf.InMemoryPort}, nil)
+	}
+	level := config.Data.LogLevel
+	active := []l.LevelOverride{}
+	now := time.Now()
+	for _, override := range overrides {
+		if !now.Before(override.ExpiresAt) {
+			continue
+		}
+		if override.Package == "" {
+			level = override.Level
+		}
+		active = append(active, override)
+	}
+	return response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+		Header: map[string]string{
+			"Allow": "GET, PATCH",
+		},
+		Body: mgrmodel.LogLevel{
+			OdataID:   "/redfish/v1/Managers/" + managerID + "/Oem/Odim/LogLevel",
+			OdataType: "#OdimLogLevel.v1_0_0.OdimLogLevel",
+			ID:        "LogLevel",
+			Name:      "Log Level",
+			LogLevel:  level.String(),
+			Overrides: active,
+		},
+	}
+}
@@ -0,0 +1,148 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package managers
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	managersproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/managers"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-managers/mgrmodel"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetLogLevel(t *testing.T) {
+	config.SetUpMockConfig(t)
+	ctx := mockContext()
+	e := mockGetExternalInterface()
+	tests := []struct {
+		name          string
+		managerID     string
+		body          string
+		statusCode    int
+		statusMessage string
+	}{
+		{
+			name:          "override package",
+			managerID:     config.Data.RootServiceUUID,
+			body:          `{"LogLevel":"debug","Package":"svc-events/events","DurationSeconds":600}`,
+			statusCode:    http.StatusOK,
+			statusMessage: response.Success,
+		},
+		{
+			name:          "override all packages",
+			managerID:     config.Data.RootServiceUUID,
+			body:          `{"LogLevel":"info","DurationSeconds":60}`,
+			statusCode:    http.StatusOK,
+			statusMessage: response.Success,
+		},
+		{
+			name:          "revert override",
+			managerID:     config.Data.RootServiceUUID,
+			body:          `{"DurationSeconds":0}`,
+			statusCode:    http.StatusOK,
+			statusMessage: response.Success,
+		},
+		{
+			name:          "not the ODIM manager",
+			managerID:     "uuid.1",
+			body:          `{"LogLevel":"debug","DurationSeconds":600}`,
+			statusCode:    http.StatusNotFound,
+			statusMessage: response.ResourceNotFound,
+		},
+		{
+			name:          "malformed body",
+			managerID:     config.Data.RootServiceUUID,
+			body:          `{"LogLevel":`,
+			statusCode:    http.StatusBadRequest,
+			statusMessage: response.MalformedJSON,
+		},
+		{
+			name:          "missing duration",
+			managerID:     config.Data.RootServiceUUID,
+			body:          `{"LogLevel":"debug"}`,
+			statusCode:    http.StatusBadRequest,
+			statusMessage: response.PropertyMissing,
+		},
+		{
+			name:          "missing log level",
+			managerID:     config.Data.RootServiceUUID,
+			body:          `{"DurationSeconds":600}`,
+			statusCode:    http.StatusBadRequest,
+			statusMessage: response.PropertyMissing,
+		},
+		{
+			name:          "invalid log level",
+			managerID:     config.Data.RootServiceUUID,
+			body:          `{"LogLevel":"verbose","DurationSeconds":600}`,
+			statusCode:    http.StatusBadRequest,
+			statusMessage: response.PropertyValueNotInList,
+		},
+		{
+			name:          "duration too long",
+			managerID:     config.Data.RootServiceUUID,
+			body:          `{"LogLevel":"debug","DurationSeconds":86401}`,
+			statusCode:    http.StatusBadRequest,
+			statusMessage: response.PropertyValueOutOfRange,
+		},
+		{
+			name:          "unknown property",
+			managerID:     config.Data.RootServiceUUID,
+			body:          `{"loglevel":"debug","DurationSeconds":600}`,
+			statusCode:    http.StatusBadRequest,
+			statusMessage: response.PropertyUnknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &managersproto.ManagerRequest{
+				ManagerID:   tt.managerID,
+				RequestBody: []byte(tt.body),
+			}
+			resp := e.SetLogLevel(ctx, req)
+			assert.Equal(t, tt.statusCode, int(resp.StatusCode), "unexpected status code")
+			assert.Equal(t, tt.statusMessage, resp.StatusMessage, "unexpected status message")
+		})
+	}
+}
+
+func TestGetLogLevel(t *testing.T) {
+	config.SetUpMockConfig(t)
+	ctx := mockContext()
+	e := mockGetExternalInterface()
+	mockLogLevelOverrides = map[string]logs.LevelOverride{}
+	req := &managersproto.ManagerRequest{
+		ManagerID:   config.Data.RootServiceUUID,
+		RequestBody: []byte(`{"LogLevel":"trace","Package":"svc-managers","DurationSeconds":600}`),
+	}
+	e.SetLogLevel(ctx, req)
+
+	resp := e.GetLogLevel(ctx, req)
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status code should be StatusOK")
+	logLevel := resp.Body.(mgrmodel.LogLevel)
+	assert.Equal(t, config.Data.LogLevel.String(), logLevel.LogLevel, "base log level should be the configured one")
+	if assert.Equal(t, 1, len(logLevel.Overrides), "override should be reported") {
+		assert.Equal(t, "svc-managers", logLevel.Overrides[0].Package)
+		assert.Equal(t, logrus.TraceLevel, logLevel.Overrides[0].Level)
+	}
+
+	req.ManagerID = "uuid.1"
+	resp = e.GetLogLevel(ctx, req)
+	assert.Equal(t, http.StatusNotFound, int(resp.StatusCode), "Status code should be StatusNotFound")
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	"github.com/ODIM-Project/ODIM/lib-utilities/health"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
 	managersproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/managers"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
@@ -235,6 +236,23 @@ func (e *ExternalInterface) getManagerDetails(ctx context.Context, id string) (m
 		}
 	}
 
+	status := &mgrmodel.Status{
+		State:  mgrData.State,
+		Health: mgrData.Health,
+	}
+	var oem *mgrmodel.ManagerOem
+	// the health of ODIM is the roll-up of the health reported by the services
+	if reports, err := e.DB.GetServiceHealth(); err != nil {
+		l.LogWithFields(ctx).Warn("unable to get the health of the services: " + err.Error())
+	} else {
+		status.Health = health.RollUp(reports)
+		oem = &mgrmodel.ManagerOem{
+			Odim: &mgrmodel.OdimManagerOem{
+				ServiceHealth: reports,
+			},
+		}
+	}
+
 	return mgrmodel.Manager{
 		OdataContext:    "/redfish/v1/$metadata#Manager.Manager",
 		OdataID:         "/redfish/v1/Managers/" + id,
@@ -244,10 +262,7 @@ func (e *ExternalInterface) getManagerDetails(ctx context.Context, id string) (m
 		ID:              mgrData.ID,
 		UUID:            mgrData.UUID,
 		FirmwareVersion: mgrData.FirmwareVersion,
-		Status: &mgrmodel.Status{
-			State:  mgrData.State,
-			Health: mgrData.Health,
-		},
+		Status:          status,
 		Links: &mgrmodel.Links{
 			ManagerForChassis:  chassisLink,
 			ManagerForServers:  serverLink,
@@ -259,6 +274,7 @@ func (e *ExternalInterface) getManagerDetails(ctx context.Context, id string) (m
 		DateTime:            time.Now().Format(time.RFC3339),
 		DateTimeLocalOffset: "+00:00",
 		PowerState:          mgrData.PowerState,
+		Oem:                 oem,
 	}, nil
 }
 
@@ -921,3 +937,71 @@ func (e *ExternalInterface) UpdateRemoteAccountPasswordService(ctx context.Conte
 	}
 	l.LogWithFields(ctx).Info("Password updated successfully for device " + target.DeviceUUID)
 }
+
+// ChangeRemoteAccountPassword changes the password of the BMC account used by ODIM, logged in
+// with the password of the request. The password stored for the BMC is not changed here, the
+// caller stores the new password once the login with it is verified.
+func (e *ExternalInterface) ChangeRemoteAccountPassword(ctx context.Context, req *managersproto.RemoteAccountPasswordRequest) response.RPC {
+	target, gerr := mgrmodel.GetTarget(req.DeviceUUID)
+	if gerr != nil {
+		errMsg := "error while getting device details: " + gerr.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"Systems", req.DeviceUUID}, nil)
+	}
+	bmcCreds := &mgrcommon.BmcUpdatedCreds{UserName: target.UserName, UpdatedPassword: string(req.Password)}
+	accountURI, err := e.findRemoteAccount(ctx, req.DeviceUUID, target.UserName, bmcCreds)
+	if err != nil {
+		errMsg := "error while finding the BMC account: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"Accounts", target.UserName}, nil)
+	}
+	requestBody, err := json.Marshal(mgrmodel.UpdateBMCAccount{Password: string(req.NewPassword)})
+	if err != nil {
+		errMsg := "error while marshalling the update BMC account request: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+	_, resp := e.Device.DeviceRequest(ctx, mgrcommon.ResourceInfoRequest{
+		URL:                   accountURI,
+		UUID:                  req.DeviceUUID,
+		ContactClient:         e.Device.ContactClient,
+		DecryptDevicePassword: e.Device.DecryptDevicePassword,
+		HTTPMethod:            http.MethodPatch,
+		RequestBody:           requestBody,
+		BmcUpdatedCreds:       bmcCreds,
+	})
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		errMsg := fmt.Sprintf("error while changing the password of the BMC account %s: the BMC responded with status %d", accountURI, resp.StatusCode)
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+	l.LogWithFields(ctx).Info("password of the BMC account " + accountURI + " of the device " + req.DeviceUUID + " is changed")
+	return response.RPC{StatusCode: http.StatusOK, StatusMessage: response.Success}
+}
+
+// findRemoteAccount returns the URI of the BMC account having the user name
+func (e *ExternalInterface) findRemoteAccount(ctx context.Context, uuid, userName string, bmcCreds *mgrcommon.BmcUpdatedCreds) (string, error) {
+	data, err := e.getResourceInfoFromDevice(ctx, "/redfish/v1/AccountService/Accounts", uuid, "", bmcCreds)
+	if err != nil {
+		return "", err
+	}
+	var accounts struct {
+		Members []dmtf.Link `json:"Members"`
+	}
+	if err := json.Unmarshal([]byte(data), &accounts); err != nil {
+		return "", fmt.Errorf("unable to parse the BMC accounts: %v", err)
+	}
+	for _, member := range accounts.Members {
+		data, err := e.getResourceInfoFromDevice(ctx, member.Oid, uuid, "", bmcCreds)
+		if err != nil {
+			return "", err
+		}
+		var account struct {
+			UserName string `json:"UserName"`
+		}
+		if err := json.Unmarshal([]byte(data), &account); err == nil && account.UserName == userName {
+			return member.Oid, nil
+		}
+	}
+	return "", fmt.Errorf("no account of the BMC has the user name %s", userName)
+}
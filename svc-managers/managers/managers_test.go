@@ -25,7 +25,9 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	"github.com/ODIM-Project/ODIM/lib-utilities/health"
 	managersproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/managers"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
 	"github.com/ODIM-Project/ODIM/svc-managers/mgrcommon"
 	"github.com/ODIM-Project/ODIM/svc-managers/mgrmodel"
 	"github.com/ODIM-Project/ODIM/svc-managers/mgrresponse"
@@ -87,6 +89,8 @@ func TestGetManager(t *testing.T) {
 	assert.Equal(t, req.ManagerID, manager.ID, "Unexpected manager ID, should be equal to the ID in request")
 	assert.Equal(t, "1.0", manager.FirmwareVersion, "Manager firmware version should be 1.0")
 	assert.Equal(t, time.Now().Format(time.RFC3339), manager.DateTime, "Invalid DateTime format")
+	assert.Equal(t, health.StatusWarning, manager.Status.Health, "Manager health should be the roll-up of the service health")
+	assert.Equal(t, 2, len(manager.Oem.Odim.ServiceHealth), "Manager should report the health of each service instance")
 }
 
 func TestGetManagerWithDeviceAbsent(t *testing.T) {
@@ -500,6 +504,51 @@ func TestUpdateRemoteAccountService(t *testing.T) {
 
 }
 
+func TestChangeRemoteAccountPassword(t *testing.T) {
+	ctx := mockContext()
+	config.SetUpMockConfig(t)
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+	}()
+	e := mockGetExternalInterface()
+	req := &managersproto.RemoteAccountPasswordRequest{
+		DeviceUUID:  "uuid",
+		Password:    []byte("Password"),
+		NewPassword: []byte("NewPassword"),
+	}
+	resp := e.ChangeRemoteAccountPassword(ctx, req)
+	assert.Equal(t, http.StatusNotFound, int(resp.StatusCode), "Status code should be StatusNotFound for an unknown device.")
+
+	conn, _ := common.GetDBConnection(common.OnDisk)
+	conn.Create("System", "uuid", mgrmodel.DeviceTarget{ManagerAddress: "10.24.0.14", UserName: "admin", DeviceUUID: "uuid", PluginID: "GRF"})
+	var patched mgrcommon.ResourceInfoRequest
+	e.Device.GetDeviceInfo = func(ctx context.Context, req mgrcommon.ResourceInfoRequest) (string, error) {
+		switch req.URL {
+		case "/redfish/v1/AccountService/Accounts":
+			return `{"Members":[{"@odata.id":"/redfish/v1/AccountService/Accounts/1"},{"@odata.id":"/redfish/v1/AccountService/Accounts/2"}]}`, nil
+		case "/redfish/v1/AccountService/Accounts/1":
+			return `{"UserName":"operator"}`, nil
+		}
+		return `{"UserName":"admin"}`, nil
+	}
+	e.Device.DeviceRequest = func(ctx context.Context, req mgrcommon.ResourceInfoRequest) (mgrcommon.PluginTaskInfo, response.RPC) {
+		patched = req
+		return mgrcommon.PluginTaskInfo{}, response.RPC{StatusCode: http.StatusNoContent}
+	}
+	resp = e.ChangeRemoteAccountPassword(ctx, req)
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status code should be StatusOK.")
+	assert.Equal(t, "/redfish/v1/AccountService/Accounts/2", patched.URL, "The account of the user of the device should be changed.")
+	assert.Equal(t, http.MethodPatch, patched.HTTPMethod)
+	assert.JSONEq(t, `{"Password":"NewPassword"}`, string(patched.RequestBody))
+	assert.Equal(t, "Password", patched.BmcUpdatedCreds.UpdatedPassword, "The device should be logged in with the password of the request.")
+
+	e.Device.DeviceRequest = func(ctx context.Context, req mgrcommon.ResourceInfoRequest) (mgrcommon.PluginTaskInfo, response.RPC) {
+		return mgrcommon.PluginTaskInfo{}, response.RPC{StatusCode: http.StatusBadRequest}
+	}
+	resp = e.ChangeRemoteAccountPassword(ctx, req)
+	assert.Equal(t, http.StatusInternalServerError, int(resp.StatusCode), "Status code should be StatusInternalServerError.")
+}
+
 func TestHandleRemoteAccountServiceError(t *testing.T) {
 	ctx := mockContext()
 	resp := handleRemoteAccountServiceError(ctx, "/redfish/v1/Managers/uuid.1/RemoteAccountService/Accounts/1", "uuid1.1", fmt.Errorf("Dummy Error "))
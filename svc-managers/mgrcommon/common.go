@@ -151,6 +151,10 @@ func DeviceCommunication(ctx context.Context, req ResourceInfoRequest) (PluginTa
 		return pluginTaskInfo, common.GeneralError(http.StatusInternalServerError, response.InternalError, fmt.Sprintf(errorMessage), nil, nil)
 	}
 
+	// the device may already use credentials which are not stored yet
+	if req.BmcUpdatedCreds != nil && req.BmcUpdatedCreds.UserName == target.UserName {
+		decryptedPasswordByte = []byte(req.BmcUpdatedCreds.UpdatedPassword)
+	}
 	contactRequest.DeviceInfo = map[string]interface{}{
 		"ManagerAddress": target.ManagerAddress,
 		"UserName":       target.UserName,
@@ -40,6 +40,7 @@ type Plugin struct {
 	ID                string
 	PluginType        string
 	PreferredAuthType string
+	Instances         []common.PluginInstance
 }
 
 // GetSystemByUUID fetches computer system details by UUID from database
@@ -83,6 +84,9 @@ func GetPluginData(pluginID string) (Plugin, *errors.Error) {
 		return Plugin{}, errors.PackError(errors.DecryptionFailed, "error: "+pluginID+" plugin password decryption failed: "+errs.Error())
 	}
 	plugin.Password = bytepw
+	// the requests of the plugin are sent to one of its healthy instances
+	instance := common.SelectPluginInstance(plugin.ID, plugin.PreferredAuthType, common.GetPluginInstances(plugin.IP, plugin.Port, plugin.Instances))
+	plugin.IP, plugin.Port = instance.IP, instance.Port
 
 	return plugin, nil
 }
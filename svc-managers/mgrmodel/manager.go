@@ -22,6 +22,8 @@ import (
 	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	"github.com/ODIM-Project/ODIM/lib-utilities/health"
+	"github.com/ODIM-Project/ODIM/lib-utilities/logs"
 )
 
 var (
@@ -72,6 +74,17 @@ type Manager struct {
 	SparePartNumber         string             `json:"SparePartNumber,omitempty"`
 	Description             string             `json:"Description,omitempty"`
 	DateTimeLocalOffset     string             `json:"DateTimeLocalOffset,omitempty"`
+	Oem                     *ManagerOem        `json:"Oem,omitempty"`
+}
+
+// ManagerOem holds the ODIM specific properties of the ODIM manager
+type ManagerOem struct {
+	Odim *OdimManagerOem `json:"Odim,omitempty"`
+}
+
+// OdimManagerOem holds the health reported by each instance of the ODIM services
+type OdimManagerOem struct {
+	ServiceHealth []health.ServiceReport `json:"ServiceHealth"`
 }
 
 // Status struct is to define the status of the manager
@@ -159,6 +172,24 @@ type UpdateBMCAccount struct {
 	RoleID   string `json:"RoleId,omitempty"`
 }
 
+// LogLevelRequest struct is to store the log level override request payload
+type LogLevelRequest struct {
+	LogLevel        string `json:"LogLevel,omitempty"`
+	Package         string `json:"Package,omitempty"`
+	DurationSeconds *int   `json:"DurationSeconds" validate:"required"`
+}
+
+// LogLevel holds the log level of the ODIM services and the overrides
+// which are active, with the time at which they are reverted
+type LogLevel struct {
+	OdataID   string               `json:"@odata.id"`
+	OdataType string               `json:"@odata.type"`
+	ID        string               `json:"Id"`
+	Name      string               `json:"Name"`
+	LogLevel  string               `json:"LogLevel"`
+	Overrides []logs.LevelOverride `json:"Overrides"`
+}
+
 // GetResource fetches a resource from database using table and key
 func GetResource(Table, key string) (string, *errors.Error) {
 	conn, err := getDBConnectionFunc(common.InMemory)
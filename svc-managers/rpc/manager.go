@@ -452,3 +452,59 @@ func (m *Managers) UpdateRemoteAccountPassword(ctx context.Context, req *manager
 	l.LogWithFields(ctx).Debugf("Outgoing update remote account service response to northbound: %s", string(resp.Body))
 	return &resp, nil
 }
+
+// ChangeRemoteAccountPassword defines the operations which handles the RPC request response
+// for changing the password of the BMC account used by ODIM. The request comes from
+// the other services of ODIM, which do not carry a session token, so it is not authorized here.
+func (m *Managers) ChangeRemoteAccountPassword(ctx context.Context, req *managersproto.RemoteAccountPasswordRequest) (*managersproto.ManagerResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = context.WithValue(ctx, common.ThreadName, common.ManagerService)
+	ctx = context.WithValue(ctx, common.ProcessName, podName)
+	var resp managersproto.ManagerResponse
+	fillManagersProtoResponse(ctx, &resp, m.EI.ChangeRemoteAccountPassword(ctx, req))
+	return &resp, nil
+}
+
+// GetLogLevel defines the operations which handles the RPC request response
+// for getting the log level of the ODIM services.
+// The function uses IsAuthorized of lib-util to validate the session token
+// which is present in the request.
+func (m *Managers) GetLogLevel(ctx context.Context, req *managersproto.ManagerRequest) (*managersproto.ManagerResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = context.WithValue(ctx, common.ThreadName, common.ManagerService)
+	ctx = context.WithValue(ctx, common.ProcessName, podName)
+	var resp managersproto.ManagerResponse
+	authResp, err := m.IsAuthorizedRPC(ctx, req.SessionToken, []string{common.PrivilegeLogin}, []string{})
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("error while authorizing the session token : %s", err.Error())
+		}
+		fillManagersProtoResponse(ctx, &resp, authResp)
+		return &resp, nil
+	}
+	fillManagersProtoResponse(ctx, &resp, m.EI.GetLogLevel(ctx, req))
+	l.LogWithFields(ctx).Debugf("Outgoing log level response to northbound: %s", string(resp.Body))
+	return &resp, nil
+}
+
+// SetLogLevel defines the operations which handles the RPC request response
+// for overriding the log level of the ODIM services for a bounded time.
+// The function uses IsAuthorized of lib-util to validate the session token
+// which is present in the request.
+func (m *Managers) SetLogLevel(ctx context.Context, req *managersproto.ManagerRequest) (*managersproto.ManagerResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = context.WithValue(ctx, common.ThreadName, common.ManagerService)
+	ctx = context.WithValue(ctx, common.ProcessName, podName)
+	var resp managersproto.ManagerResponse
+	authResp, err := m.IsAuthorizedRPC(ctx, req.SessionToken, []string{common.PrivilegeConfigureManager}, []string{})
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("error while authorizing the session token : %s", err.Error())
+		}
+		fillManagersProtoResponse(ctx, &resp, authResp)
+		return &resp, nil
+	}
+	fillManagersProtoResponse(ctx, &resp, m.EI.SetLogLevel(ctx, req))
+	l.LogWithFields(ctx).Debugf("Outgoing set log level response to northbound: %s", string(resp.Body))
+	return &resp, nil
+}
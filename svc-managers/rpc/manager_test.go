@@ -24,6 +24,8 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	"github.com/ODIM-Project/ODIM/lib-utilities/health"
+	"github.com/ODIM-Project/ODIM/lib-utilities/logs"
 	managersproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/managers"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
 	"github.com/ODIM-Project/ODIM/svc-managers/managers"
@@ -211,6 +213,11 @@ func mockGetExternalInterface() *managers.ExternalInterface {
 			UpdateData:          mockUpdateData,
 			SavePluginTaskInfo:  mockSavePluginTaskInfo,
 			GetResource:         mockGetResource,
+			GetServiceHealth:    mockGetServiceHealth,
+
+			GetLogLevelOverrides:   func() ([]logs.LevelOverride, error) { return nil, nil },
+			SaveLogLevelOverride:   func(logs.LevelOverride) error { return nil },
+			DeleteLogLevelOverride: func(string) error { return nil },
 		},
 		RPC: managers.RPC{
 			UpdateTask: mockUpdateTask,
@@ -544,3 +551,46 @@ func TestUpdateRemoteAccountService(t *testing.T) {
 	resp, _ = mgr.UpdateRemoteAccountService(ctx, req)
 	assert.Equal(t, int(resp.StatusCode), http.StatusUnauthorized, "Status code should be StatusUnauthorized.")
 }
+
+func mockGetServiceHealth() ([]health.ServiceReport, error) {
+	return nil, nil
+}
+
+func TestGetLogLevel(t *testing.T) {
+	common.SetUpMockConfig()
+	ctx := mockContext()
+	mgr := new(Managers)
+	mgr.IsAuthorizedRPC = mockIsAuthorized
+	mgr.EI = mockGetExternalInterface()
+	req := &managersproto.ManagerRequest{
+		ManagerID:    config.Data.RootServiceUUID,
+		SessionToken: "validToken",
+	}
+	resp, err := mgr.GetLogLevel(ctx, req)
+	assert.Nil(t, err, "There should be no error")
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status code should be StatusOK.")
+
+	req.SessionToken = "InvalidToken"
+	resp, _ = mgr.GetLogLevel(ctx, req)
+	assert.Equal(t, http.StatusUnauthorized, int(resp.StatusCode), "Status code should be StatusUnauthorized.")
+}
+
+func TestSetLogLevel(t *testing.T) {
+	common.SetUpMockConfig()
+	ctx := mockContext()
+	mgr := new(Managers)
+	mgr.IsAuthorizedRPC = mockIsAuthorized
+	mgr.EI = mockGetExternalInterface()
+	req := &managersproto.ManagerRequest{
+		ManagerID:    config.Data.RootServiceUUID,
+		SessionToken: "validToken",
+		RequestBody:  []byte(`{"LogLevel":"debug","Package":"svc-events/events","DurationSeconds":600}`),
+	}
+	resp, err := mgr.SetLogLevel(ctx, req)
+	assert.Nil(t, err, "There should be no error")
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status code should be StatusOK.")
+
+	req.SessionToken = "InvalidToken"
+	resp, _ = mgr.SetLogLevel(ctx, req)
+	assert.Equal(t, http.StatusUnauthorized, int(resp.StatusCode), "Status code should be StatusUnauthorized.")
+}
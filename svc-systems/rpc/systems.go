@@ -219,6 +219,36 @@ func (s *Systems) SetDefaultBootOrder(ctx context.Context, req *systemsproto.Def
 	return &resp, nil
 }
 
+// SystemOemAction defines the operations which handles the RPC request response
+// for the SystemOemAction service of systems micro service.
+// The functionality retrieves the request and return backs the response to
+// RPC according to the protoc file defined in the lib-utilities package.
+// The function also checks for the session time out of the token
+// which is present in the request.
+func (s *Systems) SystemOemAction(ctx context.Context, req *systemsproto.OemActionRequest) (*systemsproto.SystemsResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.SystemService, podName)
+	l.LogWithFields(ctx).Debugf("incoming SystemOemAction request")
+	var resp systemsproto.SystemsResponse
+	sessionToken := req.SessionToken
+	authResp, err := s.IsAuthorizedRPC(ctx, sessionToken, []string{common.PrivilegeConfigureComponents}, []string{})
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		fillSystemProtoResponse(ctx, &resp, authResp)
+		return &resp, nil
+	}
+	var pc = systems.PluginContact{
+		ContactClient:  pmbhandle.ContactPlugin,
+		DevicePassword: common.DecryptWithPrivateKey,
+	}
+	data := pc.SystemOemAction(ctx, req)
+	fillSystemProtoResponse(ctx, &resp, data)
+	l.LogWithFields(ctx).Debugf("outgoing response for SystemOemAction : %s", string(resp.Body))
+	return &resp, nil
+}
+
 // ChangeBiosSettings defines the operations which handles the RPC request response
 // for the ChangeBiosSettings service of systems micro service.
 // The functionality retrives the request and return backs the response to
@@ -502,6 +502,59 @@ func TestSystems_SetDefaultBootOrder(t *testing.T) {
 	}
 }
 
+func TestSystems_SystemOemAction(t *testing.T) {
+	common.SetUpMockConfig()
+	defer func() {
+		err := common.TruncateDB(common.InMemory)
+		if err != nil {
+			t.Fatalf("error: %v", err)
+		}
+		err = common.TruncateDB(common.OnDisk)
+		if err != nil {
+			t.Fatalf("error: %v", err)
+		}
+	}()
+	sys := new(Systems)
+	sys.IsAuthorizedRPC = mockIsAuthorized
+
+	tests := []struct {
+		name       string
+		req        *systemsproto.OemActionRequest
+		wantStatus int32
+	}{
+		{
+			name: "Request with invalid system id",
+			req: &systemsproto.OemActionRequest{
+				SystemID:     "6d4a0a66-7efa-578e-83cf-44dc68d2874e",
+				Action:       "HpeComputerSystemExt.PowerButton",
+				SessionToken: "validToken",
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "Request with invalid token",
+			req: &systemsproto.OemActionRequest{
+				SystemID:     "6d4a0a66-7efa-578e-83cf-44dc68d2874e.1",
+				Action:       "HpeComputerSystemExt.PowerButton",
+				SessionToken: "invalidToken",
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := sys.SystemOemAction(context.Background(), tt.req)
+			if err != nil {
+				t.Errorf("Systems.SystemOemAction() error = %v", err)
+				return
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("Systems.SystemOemAction() status = %v, want %v", resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
 func TestSystems_ChangeBiosSettings(t *testing.T) {
 	common.SetUpMockConfig()
 	defer func() {
@@ -21,11 +21,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/ODIM-Project/ODIM/lib-persistence-manager/persistencemgr"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/lib-utilities/tracing"
 )
 
 // Target is for sending the requst to south bound/plugin
@@ -48,6 +50,7 @@ type Plugin struct {
 	PluginType        string
 	PreferredAuthType string
 	ManagerUUID       string
+	Instances         []common.PluginInstance
 }
 
 var (
@@ -113,6 +116,8 @@ func GetSystemByUUID(ctx context.Context, systemUUID string) (string, *errors.Er
 
 // GetResource fetches a resource from database using table and key
 func GetResource(ctx context.Context, Table, key string) (string, *errors.Error) {
+	_, span := tracing.StartDBSpan(ctx, "Read", Table)
+	defer span.End()
 	l.LogWithFields(ctx).Debugf("incoming GetResource request with Table: %s, key: %s", Table, key)
 	conn, err := GetDBConnectionFunc(common.InMemory)
 	if err != nil {
@@ -255,6 +260,9 @@ func GetPluginData(pluginID string) (Plugin, *errors.Error) {
 		return Plugin{}, errors.PackError(errors.DecryptionFailed, "error: "+pluginID+" plugin password decryption failed: "+errs.Error())
 	}
 	plugin.Password = bytepw
+	// the requests of the plugin are sent to one of its healthy instances
+	instance := common.SelectPluginInstance(plugin.ID, plugin.PreferredAuthType, common.GetPluginInstances(plugin.IP, plugin.Port, plugin.Instances))
+	plugin.IP, plugin.Port = instance.IP, instance.Port
 
 	return plugin, nil
 }
@@ -278,6 +286,8 @@ func GetTarget(deviceUUID string) (*Target, *errors.Error) {
 
 // GenericSave will save any resource data into the database
 func GenericSave(ctx context.Context, body []byte, table string, key string) error {
+	_, span := tracing.StartDBSpan(ctx, "AddResourceData", table)
+	defer span.End()
 	connPool, err := GetDBConnectionFunc(common.InMemory)
 	if err != nil {
 		return fmt.Errorf("error while trying to connecting to DB: %v", err.Error())
@@ -291,6 +301,24 @@ func GenericSave(ctx context.Context, body []byte, table string, key string) err
 	return nil
 }
 
+// UpdateResource will replace the resource data already present in the database with the
+// data read from the BMC, along with the time at which the data was fetched
+func UpdateResource(ctx context.Context, table, key, data string, fetchedAt time.Time) *errors.Error {
+	_, span := tracing.StartDBSpan(ctx, "SaveBMCInventory", table)
+	defer span.End()
+	conn, err := GetDBConnectionFunc(common.InMemory)
+	if err != nil {
+		return err
+	}
+	if _, err = conn.Read(table, key); err != nil {
+		return err
+	}
+	return conn.SaveBMCInventory(map[string]interface{}{
+		table + ":" + key:                   data,
+		common.LastFetchedTable + ":" + key: fetchedAt.UTC().Format(time.RFC3339),
+	})
+}
+
 // GetStorageList is used to storage list of capacity
 /*
 1.index name to search with
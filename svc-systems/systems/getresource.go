@@ -21,10 +21,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
@@ -51,6 +53,8 @@ var (
 	GetDeviceLoadInfoFunc = getDeviceLoadInfo
 	// GetStringFunc function pointer for the smodel.GetString
 	GetStringFunc = smodel.GetString
+	// UpdateResourceFunc function pointer for the smodel.UpdateResource
+	UpdateResourceFunc = smodel.UpdateResource
 )
 
 func setRegexFlag(ctx context.Context, val string) bool {
@@ -566,14 +570,28 @@ func (p *PluginContact) GetSystemResource(ctx context.Context, req *systemsproto
 	}
 	uuid := requestData[0]
 
-	var respData string
+	// the refresh query parameter forces the resource to be read from the BMC
+	// instead of being served from the DB
+	var refresh bool
+	req.URL, refresh = getRefreshRequest(req.URL)
+	urlData := strings.Split(req.URL, "/")
+	//generating search URL which will be a part of key and also used in formatting response
+	var tableName string
+	if req.ResourceID == "" {
+		resourceName := urlData[len(urlData)-1]
+		tableName = common.SystemResource[resourceName]
+	} else {
+		tableName = urlData[len(urlData)-2]
+	}
+
+	var respData, lastFetched string
 	var saveRequired bool
 	// Getting the reset flag details for the requested URL
 	deviceLoadFlag := GetDeviceLoadInfoFunc(ctx, req.URL, req.RequestParam)
 	// deviceLoadFlag is true means flag is set for requested URL or the SystemID URL, load from device
 	// deviceLoadFlag is false indicates flag is not set, load from DB
-	if deviceLoadFlag {
-		l.LogWithFields(ctx).Debug("SystemReset flag is found for the URL ", req.URL)
+	if deviceLoadFlag || refresh {
+		l.LogWithFields(ctx).Debug("SystemReset flag or refresh is requested for the URL ", req.URL)
 		var getDeviceInfoRequest = scommon.ResourceInfoRequest{
 			URL:             req.URL,
 			UUID:            uuid,
@@ -587,18 +605,16 @@ func (p *PluginContact) GetSystemResource(ctx context.Context, req *systemsproto
 		if respData, err = scommon.GetResourceInfoFromDevice(ctx, getDeviceInfoRequest, saveRequired); err != nil {
 			return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, err.Error(), []interface{}{"ComputerSystem", req.URL}, nil)
 		}
+		fetchedAt := time.Now()
+		lastFetched = fetchedAt.UTC().Format(time.RFC3339)
+		if refresh && tableName != "" {
+			// only the resources already present in the DB are replaced with the live data
+			if err := UpdateResourceFunc(ctx, tableName, req.URL, respData, fetchedAt); err != nil && err.ErrNo() != errors.DBKeyNotFound {
+				l.LogWithFields(ctx).Warn("unable to save the refreshed data of " + req.URL + ": " + err.Error())
+			}
+		}
 	} else {
 		saveRequired = true
-		urlData := strings.Split(req.URL, "/")
-		//generating search URL which will be a part of key and also used in formatting response
-		var tableName string
-		if req.ResourceID == "" {
-			resourceName := urlData[len(urlData)-1]
-			tableName = common.SystemResource[resourceName]
-		} else {
-			tableName = urlData[len(urlData)-2]
-		}
-
 		l.LogWithFields(ctx).Debug("Getting the details from DB for URL ", req.URL)
 		data, err := smodel.GetResource(ctx, tableName, req.URL)
 		if err != nil {
@@ -618,12 +634,15 @@ func (p *PluginContact) GetSystemResource(ctx context.Context, req *systemsproto
 				if data, err = scommon.GetResourceInfoFromDevice(ctx, getDeviceInfoRequest, saveRequired); err != nil {
 					return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, err.Error(), []interface{}{"ComputerSystem", req.URL}, nil)
 				}
+				lastFetched = time.Now().UTC().Format(time.RFC3339)
 				if saveRequired && strings.Contains(req.URL, "/Storage") {
 					rediscoverStorageInventory(ctx, uuid, "/redfish/v1/Systems/"+requestData[1]+"/Storage")
 				}
 			} else {
 				return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
 			}
+		} else if fetched, err := smodel.GetResource(ctx, common.LastFetchedTable, req.URL); err == nil {
+			lastFetched = fetched
 		}
 		respData = data
 	}
@@ -638,6 +657,7 @@ func (p *PluginContact) GetSystemResource(ctx context.Context, req *systemsproto
 		delete(result, "MembersCount")
 		delete(result, "Members")
 		resp.Body = result
+		resp.Header = getLastFetchedHeader(lastFetched)
 		resp.StatusCode = http.StatusOK
 		resp.StatusMessage = response.Success
 		l.LogWithFields(ctx).Debug("Exiting the GetSystemResource with response ", resp)
@@ -658,11 +678,36 @@ func (p *PluginContact) GetSystemResource(ctx context.Context, req *systemsproto
 	}
 
 	resp.Body = resource
+	resp.Header = getLastFetchedHeader(lastFetched)
 	resp.StatusCode = http.StatusOK
 	resp.StatusMessage = response.Success
 	return resp
 
 }
+
+// getRefreshRequest returns the resource URI without the query parameters, and
+// whether a live read from the BMC is requested using the refresh query parameter
+func getRefreshRequest(reqURL string) (string, bool) {
+	paramStr := strings.SplitN(reqURL, "?", 2)
+	if len(paramStr) == 1 {
+		return reqURL, false
+	}
+	query, err := url.ParseQuery(paramStr[1])
+	if err != nil {
+		return paramStr[0], false
+	}
+	refresh, _ := strconv.ParseBool(query.Get(common.RefreshQueryParam))
+	return paramStr[0], refresh
+}
+
+// getLastFetchedHeader returns the response header having the time the resource was fetched from the BMC
+func getLastFetchedHeader(lastFetched string) map[string]string {
+	if lastFetched == "" {
+		return nil
+	}
+	return map[string]string{common.LastFetchedHeader: lastFetched}
+}
+
 func fillCapabilitiesResponse(respMap map[string]interface{}, oid string) (body []byte) {
 	if _, ok := respMap["RAIDType@Redfish.AllowableValues"]; !ok {
 		respMap["RAIDType@Redfish.AllowableValues"] = []string{"RAID0", "RAID1", "RAID3", "RAID4", "RAID5", "RAID6", "RAID10", "RAID01", "RAID6TP", "RAID1E", "RAID50", "RAID60", "RAID00", "RAID10E", "RAID1Triple", "RAID10Triple", "None"}
@@ -1101,6 +1101,34 @@ func Test_getDeviceLoadInfo(t *testing.T) {
 	assert.Equal(t, false, resp, "Status should be false")
 }
 
+func Test_getRefreshRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		reqURL      string
+		wantURL     string
+		wantRefresh bool
+	}{
+		{"no query", "/redfish/v1/Systems/uuid.1/Memory", "/redfish/v1/Systems/uuid.1/Memory", false},
+		{"refresh requested", "/redfish/v1/Systems/uuid.1/Memory?refresh=true", "/redfish/v1/Systems/uuid.1/Memory", true},
+		{"refresh disabled", "/redfish/v1/Systems/uuid.1/Memory?refresh=false", "/redfish/v1/Systems/uuid.1/Memory", false},
+		{"invalid refresh value", "/redfish/v1/Systems/uuid.1/Memory?refresh=now", "/redfish/v1/Systems/uuid.1/Memory", false},
+		{"invalid query", "/redfish/v1/Systems/uuid.1/Memory?%zz", "/redfish/v1/Systems/uuid.1/Memory", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotURL, gotRefresh := getRefreshRequest(tt.reqURL)
+			assert.Equal(t, tt.wantURL, gotURL, "URI without the query should be returned")
+			assert.Equal(t, tt.wantRefresh, gotRefresh, "refresh flag is not as expected")
+		})
+	}
+}
+
+func Test_getLastFetchedHeader(t *testing.T) {
+	assert.Nil(t, getLastFetchedHeader(""), "Header should not be set")
+	assert.Equal(t, map[string]string{common.LastFetchedHeader: "2022-01-01T00:00:00Z"},
+		getLastFetchedHeader("2022-01-01T00:00:00Z"), "Header should have the last fetched time")
+}
+
 func Test_getStringData(t *testing.T) {
 	GetStringFunc = func(index, match string, regexFlag bool) ([]string, error) {
 		return []string{}, &errors.Error{}
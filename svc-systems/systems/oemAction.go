@@ -0,0 +1,122 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package systems ...
+package systems
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	systemsproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/systems"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-systems/scommon"
+	"github.com/ODIM-Project/ODIM/svc-systems/smodel"
+)
+
+// SystemOemAction defines the logic for passing an OEM action of a computer system
+// to the plugin of the system, which translates it to the action of the vendor
+func (p *PluginContact) SystemOemAction(ctx context.Context, req *systemsproto.OemActionRequest) response.RPC {
+	var resp response.RPC
+	l.LogWithFields(ctx).Debugf("incoming SystemOemAction request for SystemID: %s, Action: %s", req.SystemID, req.Action)
+
+	// spliting the uuid and system id
+	requestData := strings.SplitN(req.SystemID, ".", 2)
+	if len(requestData) <= 1 {
+		errorMessage := "error: SystemUUID not found"
+		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errorMessage, []interface{}{"System", req.SystemID}, nil)
+	}
+	if req.Action == "" {
+		errorMessage := "error: OEM action name not found"
+		return common.GeneralError(http.StatusBadRequest, response.ActionNotSupported, errorMessage, []interface{}{"Oem"}, nil)
+	}
+	if len(req.RequestBody) > 0 {
+		var actionRequest map[string]interface{}
+		if err := JSONUnmarshalFunc(req.RequestBody, &actionRequest); err != nil {
+			errorMessage := "error while trying to parse the OEM action request: " + err.Error()
+			return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, nil, nil)
+		}
+	}
+	uuid := requestData[0]
+	target, gerr := smodel.GetTarget(uuid)
+	if gerr != nil {
+		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, gerr.Error(), []interface{}{"System", uuid}, nil)
+	}
+
+	decryptedPasswordByte, err := p.DevicePassword(target.Password)
+	if err != nil {
+		// Frame the RPC response body and response Header below
+		errorMessage := "error while trying to decrypt device password: " + err.Error()
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	target.Password = decryptedPasswordByte
+
+	// Get the Plugin info
+	plugin, gerr := smodel.GetPluginData(target.PluginID)
+	if gerr != nil {
+		errorMessage := "error while trying to get plugin details"
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+
+	var contactRequest scommon.PluginContactRequest
+	contactRequest.ContactClient = p.ContactClient
+	contactRequest.Plugin = plugin
+
+	if StringsEqualFold(plugin.PreferredAuthType, "XAuthToken") {
+		var err error
+		contactRequest.HTTPMethodType = http.MethodPost
+		contactRequest.DeviceInfo = map[string]interface{}{
+			"UserName": plugin.Username,
+			"Password": string(plugin.Password),
+		}
+		contactRequest.OID = "/ODIM/v1/Sessions"
+		_, token, _, getResponse, err := ContactPluginFunc(ctx, contactRequest, "error while creating session with the plugin: ")
+
+		if err != nil {
+			return common.GeneralError(getResponse.StatusCode, getResponse.StatusMessage, err.Error(), nil, nil)
+		}
+		contactRequest.Token = token
+	} else {
+		contactRequest.BasicAuth = map[string]string{
+			"UserName": plugin.Username,
+			"Password": string(plugin.Password),
+		}
+	}
+	target.PostBody = req.RequestBody
+	contactRequest.DeviceInfo = target
+	contactRequest.OID = "/ODIM/v1/Systems/" + requestData[1] + "/Actions/Oem/" + req.Action
+	contactRequest.HTTPMethodType = http.MethodPost
+
+	body, _, _, getResponse, err := ContactPluginFunc(ctx, contactRequest, "error while performing the OEM action of the computer system: ")
+	if err != nil {
+		resp.StatusCode = getResponse.StatusCode
+		json.Unmarshal(body, &resp.Body)
+		return resp
+	}
+	if len(body) == 0 {
+		return common.GeneralError(getResponse.StatusCode, response.Success, "", nil, nil)
+	}
+	resp.StatusCode = getResponse.StatusCode
+	resp.StatusMessage = response.Success
+	err = JSONUnmarshalFunc(body, &resp.Body)
+	if err != nil {
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, err.Error(), nil, nil)
+	}
+	l.LogWithFields(ctx).Debugf("outgoing response for SystemOemAction statuscode: %d", resp.StatusCode)
+	return resp
+}
@@ -0,0 +1,143 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package systems
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	systemsproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/systems"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-systems/smodel"
+)
+
+func TestPluginContact_SystemOemAction(t *testing.T) {
+	config.SetUpMockConfig(t)
+	ctx := mockContext()
+	defer func() {
+		err := common.TruncateDB(common.OnDisk)
+		if err != nil {
+			t.Fatalf("error: %v", err)
+		}
+		err = common.TruncateDB(common.InMemory)
+		if err != nil {
+			t.Fatalf("error: %v", err)
+		}
+	}()
+
+	device := smodel.Target{
+		ManagerAddress: "10.24.0.12",
+		Password:       []byte("imKp3Q6Cx989b6JSPHnRhritEcXWtaB3zqVBkSwhCenJYfgAYBf9FlAocE"),
+		UserName:       "admin",
+		DeviceUUID:     "7a2c6100-67da-5fd6-ab82-6870d29c7279",
+		PluginID:       "GRF",
+	}
+	err := mockPluginData(t)
+	if err != nil {
+		t.Fatalf("Error in creating mock DeviceData :%v", err)
+	}
+	err = mockDeviceData("7a2c6100-67da-5fd6-ab82-6870d29c7279", device)
+	if err != nil {
+		t.Fatalf("Error in creating mock DeviceData :%v", err)
+	}
+	pluginContact := PluginContact{
+		ContactClient:  mockContactClient,
+		DevicePassword: stubDevicePassword,
+	}
+	errArg1 := response.Args{
+		Code:    response.GeneralError,
+		Message: "",
+		ErrorArgs: []response.ErrArgs{
+			response.ErrArgs{
+				StatusMessage: response.ResourceNotFound,
+				ErrorMessage:  "error: SystemUUID not found",
+				MessageArgs:   []interface{}{"System", "7a2c6100-67da-5fd6-ab82-6870d29c7279"},
+			},
+		},
+	}
+	errArg2 := response.Args{
+		Code:    response.GeneralError,
+		Message: "",
+		ErrorArgs: []response.ErrArgs{
+			response.ErrArgs{
+				StatusMessage: response.ActionNotSupported,
+				ErrorMessage:  "error: OEM action name not found",
+				MessageArgs:   []interface{}{"Oem"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		req  *systemsproto.OemActionRequest
+		want response.RPC
+	}{
+		{
+			name: "invalid uuid without system id",
+			req: &systemsproto.OemActionRequest{
+				SystemID: "7a2c6100-67da-5fd6-ab82-6870d29c7279",
+				Action:   "HpeComputerSystemExt.PowerButton",
+			},
+			want: response.RPC{
+				StatusCode:    http.StatusNotFound,
+				StatusMessage: response.ResourceNotFound,
+				Body:          errArg1.CreateGenericErrorResponse(),
+			},
+		},
+		{
+			name: "missing action name",
+			req: &systemsproto.OemActionRequest{
+				SystemID: "7a2c6100-67da-5fd6-ab82-6870d29c7279.1",
+			},
+			want: response.RPC{
+				StatusCode:    http.StatusBadRequest,
+				StatusMessage: response.ActionNotSupported,
+				Body:          errArg2.CreateGenericErrorResponse(),
+			},
+		},
+		{
+			name: "Valid Request",
+			req: &systemsproto.OemActionRequest{
+				SystemID:    "7a2c6100-67da-5fd6-ab82-6870d29c7279.1",
+				Action:      "HpeComputerSystemExt.PowerButton",
+				RequestBody: []byte(`{"PushType": "Press"}`),
+			},
+			want: response.RPC{
+				StatusCode:    http.StatusOK,
+				StatusMessage: response.Success,
+				Body:          map[string]interface{}{"MessageId": response.Success},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pluginContact.SystemOemAction(ctx, tt.req); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PluginContact.SystemOemAction() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	resp := pluginContact.SystemOemAction(ctx, &systemsproto.OemActionRequest{
+		SystemID:    "7a2c6100-67da-5fd6-ab82-6870d29c7279.1",
+		Action:      "HpeComputerSystemExt.PowerButton",
+		RequestBody: []byte(`{"PushType":`),
+	})
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("PluginContact.SystemOemAction() status = %v, want %v", resp.StatusCode, http.StatusBadRequest)
+	}
+}
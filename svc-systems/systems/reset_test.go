@@ -86,6 +86,13 @@ func mockContactClient(ctx context.Context, url, method, token string, odataID s
 			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
 		}, nil
 	}
+	if url == "https://localhost:9091/ODIM/v1/Systems/1/Actions/Oem/HpeComputerSystemExt.PowerButton" {
+		body := `{"MessageId": "` + response.Success + `"}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		}, nil
+	}
 	if url == "https://localhost:9091/ODIM/v1/Systems/1/SecureBoot1" {
 		body := `{"@odata.id": "/ODIM/v1/Systems/1/SecureBoot1"}`
 		return &http.Response{
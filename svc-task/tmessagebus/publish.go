@@ -23,15 +23,23 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/lib-utilities/tracing"
 	uuid "github.com/satori/go.uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Publish will takes the taskURI, messageID, Event type and publishes the data to message bus
 func Publish(ctx context.Context, taskURI, messageID, eventType, taskMessage string) {
+	ctx, span := tracing.StartSpan(ctx, "publish "+eventType, trace.SpanKindProducer,
+		attribute.String("messaging.system", config.Data.MessageBusConf.MessageBusType),
+		attribute.String("messaging.destination", config.Data.MessageBusConf.OdimControlMessageQueue))
+	defer span.End()
 	topicName := config.Data.MessageBusConf.OdimControlMessageQueue
 	k, err := dc.Communicator(config.Data.MessageBusConf.MessageBusType, config.Data.MessageBusConf.MessageBusConfigFilePath, topicName)
 	if err != nil {
 		l.LogWithFields(ctx).Error("Unable to connect to " + config.Data.MessageBusConf.MessageBusType + " " + err.Error())
+		tracing.RecordError(span, err)
 		return
 	}
 
@@ -56,12 +64,14 @@ func Publish(ctx context.Context, taskURI, messageID, eventType, taskMessage str
 	}
 	data, _ := json.Marshal(messageData)
 	var mbevent = common.Events{
-		IP:      "TasksCollection",
-		Request: data,
+		IP:          "TasksCollection",
+		Request:     data,
+		TraceParent: tracing.TraceParent(ctx),
 	}
 
 	if err := k.Distribute(mbevent); err != nil {
 		l.LogWithFields(ctx).Error("TaskURI:" + taskURI + ", EventID:" + eventID + ", MessageID:" + messageID + " : unable to publish the event to message bus: " + err.Error())
+		tracing.RecordError(span, err)
 		return
 	}
 }
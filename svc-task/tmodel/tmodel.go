@@ -27,6 +27,7 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/lib-utilities/tracing"
 )
 
 const (
@@ -147,6 +148,8 @@ type Oem struct {
 //	t pointer to Task to be stored.
 //	db of type common.DbType(int32)
 func PersistTask(ctx context.Context, t *Task, db common.DbType) error {
+	_, span := tracing.StartDBSpan(ctx, "Create", "task")
+	defer span.End()
 	connPool, err := common.GetDBConnection(db)
 	if err != nil {
 		return fmt.Errorf("error while trying to connecting to DB: %v", err.Error())
@@ -203,6 +206,8 @@ func DeleteMultipleTaskFromDB(ctx context.Context, t []string) error {
 //		On Failure - return non nill value
 //	t of type *Task implicitly valid only when error is nil
 func GetTaskStatus(ctx context.Context, taskID string, db common.DbType) (*Task, error) {
+	_, span := tracing.StartDBSpan(ctx, "Read", "task")
+	defer span.End()
 	task := new(Task)
 	var taskData string
 	connPool, err := common.GetDBConnection(common.InMemory)
@@ -1,6 +1,7 @@
 package tqueue
 
 import (
+	"github.com/ODIM-Project/ODIM/lib-utilities/metrics"
 	"github.com/ODIM-Project/ODIM/svc-task/tmodel"
 )
 
@@ -20,6 +21,10 @@ func NewTaskQueue(size int) {
 	TaskQueue = &taskQueue{
 		queue: make(chan *tmodel.Task, size),
 	}
+	metrics.NewGaugeFunc("odim_task_queue_depth", "Number of task updates waiting in the task queue", nil,
+		func() float64 { return float64(len(TaskQueue.queue)) })
+	metrics.NewGaugeFunc("odim_task_queue_capacity", "Maximum number of task updates the task queue can hold", nil,
+		func() float64 { return float64(cap(TaskQueue.queue)) })
 }
 
 // EnqueueTask enqueue the update task requests in the channel which act as a queue.
@@ -24,6 +24,7 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/services"
 	"github.com/ODIM-Project/ODIM/svc-telemetry/rpc"
 	"github.com/ODIM-Project/ODIM/svc-telemetry/tcommon"
+	"github.com/ODIM-Project/ODIM/svc-telemetry/telemetry"
 
 	"github.com/sirupsen/logrus"
 )
@@ -73,6 +74,10 @@ func main() {
 	go tcommon.TrackConfigFileChanges(errChan)
 
 	registerHandlers(errChan)
+	go telemetry.GetExternalInterface().PerformMetricHistoryCollection()
+	go telemetry.GetExternalInterface().PerformMetricReportComputation()
+	go telemetry.GetExternalInterface().PerformTriggerEvaluation()
+	go telemetry.GetExternalInterface().PerformMetricRemoteWrite()
 	// Run server
 	if err := services.ODIMService.Run(); err != nil {
 		log.Error(err)
@@ -0,0 +1,70 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	teleproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/telemetry"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+)
+
+// CreateMetricReportDefinition is an rpc handler which is invoked during creation of a MetricReportDefinition
+func (a *Telemetry) CreateMetricReportDefinition(ctx context.Context, req *teleproto.TelemetryRequest) (*teleproto.TelemetryResponse, error) {
+	return a.configureTelemetryResource(ctx, req, "create metric report definition", a.connector.CreateMetricReportDefinition)
+}
+
+// UpdateMetricReportDefinition is an rpc handler which is invoked during update on MetricReportDefinition
+func (a *Telemetry) UpdateMetricReportDefinition(ctx context.Context, req *teleproto.TelemetryRequest) (*teleproto.TelemetryResponse, error) {
+	return a.configureTelemetryResource(ctx, req, "update metric report definition", a.connector.UpdateMetricReportDefinition)
+}
+
+// DeleteMetricReportDefinition is an rpc handler which is invoked during deletion of a MetricReportDefinition
+func (a *Telemetry) DeleteMetricReportDefinition(ctx context.Context, req *teleproto.TelemetryRequest) (*teleproto.TelemetryResponse, error) {
+	return a.configureTelemetryResource(ctx, req, "delete metric report definition", a.connector.DeleteMetricReportDefinition)
+}
+
+// CreateTrigger is an rpc handler which is invoked during creation of a Trigger
+func (a *Telemetry) CreateTrigger(ctx context.Context, req *teleproto.TelemetryRequest) (*teleproto.TelemetryResponse, error) {
+	return a.configureTelemetryResource(ctx, req, "create trigger", a.connector.CreateTrigger)
+}
+
+// DeleteTrigger is an rpc handler which is invoked during deletion of a Trigger
+func (a *Telemetry) DeleteTrigger(ctx context.Context, req *teleproto.TelemetryRequest) (*teleproto.TelemetryResponse, error) {
+	return a.configureTelemetryResource(ctx, req, "delete trigger", a.connector.DeleteTrigger)
+}
+
+// configureTelemetryResource authorizes the request for configuring the telemetry resources
+// and invokes the operation on the resource
+func (a *Telemetry) configureTelemetryResource(ctx context.Context, req *teleproto.TelemetryRequest, operation string,
+	configure func(context.Context, *teleproto.TelemetryRequest) response.RPC) (*teleproto.TelemetryResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.TelemetryService, podName)
+	resp := &teleproto.TelemetryResponse{}
+	authResp, err := a.connector.External.Auth(ctx, req.SessionToken, []string{common.PrivilegeConfigureComponents}, []string{})
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		fillProtoResponse(ctx, resp, authResp)
+		return resp, nil
+	}
+	fillProtoResponse(ctx, resp, configure(ctx, req))
+	l.LogWithFields(ctx).Debugf("final response for %s request: %s", operation, string(resp.Body))
+	return resp, nil
+}
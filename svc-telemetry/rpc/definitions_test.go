@@ -0,0 +1,48 @@
+// (C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	teleproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/telemetry"
+	tm "github.com/ODIM-Project/ODIM/svc-telemetry/telemetry"
+)
+
+func TestTelemetry_ConfigureTelemetryResources(t *testing.T) {
+	telemetry := new(Telemetry)
+	telemetry.connector = tm.MockGetExternalInterface()
+	handlers := map[string]func(context.Context, *teleproto.TelemetryRequest) (*teleproto.TelemetryResponse, error){
+		"CreateMetricReportDefinition": telemetry.CreateMetricReportDefinition,
+		"UpdateMetricReportDefinition": telemetry.UpdateMetricReportDefinition,
+		"DeleteMetricReportDefinition": telemetry.DeleteMetricReportDefinition,
+		"CreateTrigger":                telemetry.CreateTrigger,
+		"DeleteTrigger":                telemetry.DeleteTrigger,
+	}
+	for name, handler := range handlers {
+		t.Run(name, func(t *testing.T) {
+			got, err := handler(context.Background(), &teleproto.TelemetryRequest{SessionToken: "InvalidToken"})
+			if err != nil || got.StatusCode != http.StatusUnauthorized {
+				t.Errorf("Telemetry.%s() = %v, %v, want %v", name, got.StatusCode, err, http.StatusUnauthorized)
+			}
+			got, err = handler(context.Background(), &teleproto.TelemetryRequest{SessionToken: "validToken", URL: "error", RequestBody: []byte(`{`)})
+			if err != nil || got.StatusCode == http.StatusUnauthorized || got.StatusCode == 0 {
+				t.Errorf("Telemetry.%s() = %v, %v, the authorized request is not processed", name, got.StatusCode, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	teleproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/telemetry"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+)
+
+// GetMetricHistory is an rpc handler which is invoked during GET on the metric history
+func (a *Telemetry) GetMetricHistory(ctx context.Context, req *teleproto.TelemetryRequest) (*teleproto.TelemetryResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.TelemetryService, podName)
+	resp := &teleproto.TelemetryResponse{}
+	authResp, err := a.connector.External.Auth(ctx, req.SessionToken, []string{common.PrivilegeLogin}, []string{})
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		fillProtoResponse(ctx, resp, authResp)
+		return resp, nil
+	}
+	fillProtoResponse(ctx, resp, a.connector.GetMetricHistory(ctx, req))
+	l.LogWithFields(ctx).Debugf("final response for get metric history request: %s", string(resp.Body))
+	return resp, nil
+}
+
+// GetOpenMetrics is an rpc handler which is invoked during GET on the OpenMetrics export of the metric history
+func (a *Telemetry) GetOpenMetrics(ctx context.Context, req *teleproto.TelemetryRequest) (*teleproto.TelemetryResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.TelemetryService, podName)
+	resp := &teleproto.TelemetryResponse{}
+	authResp, err := a.connector.External.Auth(ctx, req.SessionToken, []string{common.PrivilegeLogin}, []string{})
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		fillProtoResponse(ctx, resp, authResp)
+		return resp, nil
+	}
+	data := a.connector.GetOpenMetrics(ctx, req)
+	fillProtoResponse(ctx, resp, data)
+	// the OpenMetrics text is returned as it is, not as a JSON string
+	if text, ok := data.Body.(string); ok {
+		resp.Body = []byte(text)
+	}
+	l.LogWithFields(ctx).Debugf("final response for get open metrics request with status code %d", resp.StatusCode)
+	return resp, nil
+}
+
+// IngestMetricReport is an rpc handler which is invoked by the event service for saving
+// the metric reports pushed by the servers into the metric history
+func (a *Telemetry) IngestMetricReport(ctx context.Context, req *teleproto.TelemetryRequest) (*teleproto.TelemetryResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.TelemetryService, podName)
+	resp := &teleproto.TelemetryResponse{}
+	if err := a.connector.IngestMetricReport(ctx, req.RequestBody); err != nil {
+		l.LogWithFields(ctx).Error("unable to save the metric report into the metric history: " + err.Error())
+		fillProtoResponse(ctx, resp, common.GeneralError(http.StatusInternalServerError, response.InternalError, err.Error(), nil, nil))
+		return resp, nil
+	}
+	resp.StatusCode = http.StatusOK
+	resp.StatusMessage = response.Success
+	return resp, nil
+}
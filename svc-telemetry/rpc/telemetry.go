@@ -185,7 +185,7 @@ func (a *Telemetry) UpdateTrigger(ctx context.Context, req *teleproto.TelemetryR
 	ctx = common.GetContextData(ctx)
 	ctx = common.ModifyContext(ctx, common.TelemetryService, podName)
 	resp := &teleproto.TelemetryResponse{}
-	authResp, err := a.connector.External.Auth(ctx, req.SessionToken, []string{common.PrivilegeLogin}, []string{})
+	authResp, err := a.connector.External.Auth(ctx, req.SessionToken, []string{common.PrivilegeConfigureComponents}, []string{})
 	if authResp.StatusCode != http.StatusOK {
 		if err != nil {
 			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
@@ -193,7 +193,7 @@ func (a *Telemetry) UpdateTrigger(ctx context.Context, req *teleproto.TelemetryR
 		fillProtoResponse(ctx, resp, authResp)
 		return resp, nil
 	}
-	fillProtoResponse(ctx, resp, a.connector.UpdateTrigger(req))
+	fillProtoResponse(ctx, resp, a.connector.UpdateTrigger(ctx, req))
 	l.LogWithFields(ctx).Debugf("final response for update trigger request: %s", string(resp.Body))
 	return resp, nil
 }
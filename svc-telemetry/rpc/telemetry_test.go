@@ -377,7 +377,7 @@ func TestTelemetry_UpdateTrigger(t *testing.T) {
 				ctx: context.Background(),
 				req: reqValid,
 			},
-			want:    0, // as update trigger is not implemented. Need to be changed with http.StatusOK once update trigger operation is implemented
+			want:    http.StatusBadRequest, // the request has no body
 			wantErr: false,
 		},
 		{
@@ -404,3 +404,53 @@ func TestTelemetry_UpdateTrigger(t *testing.T) {
 		})
 	}
 }
+
+func TestTelemetry_GetMetricHistory(t *testing.T) {
+	config.SetUpMockConfig(t)
+	telemetry := new(Telemetry)
+	telemetry.connector = tm.MockGetExternalInterface()
+	tests := []struct {
+		name       string
+		req        *teleproto.TelemetryRequest
+		wantStatus int32
+	}{
+		{
+			name:       "auth fail",
+			req:        &teleproto.TelemetryRequest{SessionToken: "InvalidToken", URL: "/redfish/v1/TelemetryService/Oem/Odim/MetricHistory?MetricId=PowerConsumedWatts"},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "query parameters missing",
+			req:        &teleproto.TelemetryRequest{SessionToken: "validToken", URL: "/redfish/v1/TelemetryService/Oem/Odim/MetricHistory"},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, _ := telemetry.GetMetricHistory(context.Background(), tt.req)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode, "status code should be equal")
+		})
+	}
+}
+
+func TestTelemetry_GetOpenMetrics(t *testing.T) {
+	config.SetUpMockConfig(t)
+	telemetry := new(Telemetry)
+	telemetry.connector = tm.MockGetExternalInterface()
+	req := &teleproto.TelemetryRequest{SessionToken: "InvalidToken", URL: "/redfish/v1/TelemetryService/Oem/Odim/Metrics"}
+	resp, _ := telemetry.GetOpenMetrics(context.Background(), req)
+	assert.Equal(t, int32(http.StatusUnauthorized), resp.StatusCode, "status code should be equal")
+	// the OpenMetrics export is disabled in the mock configuration
+	req.SessionToken = "validToken"
+	resp, _ = telemetry.GetOpenMetrics(context.Background(), req)
+	assert.Equal(t, int32(http.StatusNotFound), resp.StatusCode, "status code should be equal")
+}
+
+func TestTelemetry_IngestMetricReport(t *testing.T) {
+	config.SetUpMockConfig(t)
+	telemetry := new(Telemetry)
+	telemetry.connector = tm.MockGetExternalInterface()
+	// the metric history is disabled in the mock configuration, so nothing is saved
+	resp, _ := telemetry.IngestMetricReport(context.Background(), &teleproto.TelemetryRequest{RequestBody: []byte(`{"Id":"PowerMetrics"}`)})
+	assert.Equal(t, int32(http.StatusOK), resp.StatusCode, "status code should be equal")
+}
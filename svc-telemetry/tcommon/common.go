@@ -154,6 +154,72 @@ func getResourceInfo(ctx context.Context, pluginID string, metricReportData *dmt
 	metricReportData.MetricValues = append(metricReportData.MetricValues, metricsData.MetricValues...)
 }
 
+// DeviceResourceRequest holds the request of getting a resource from the server it belongs to
+type DeviceResourceRequest struct {
+	URL             string
+	ContactClient   func(context.Context, string, string, string, string, interface{}, map[string]string) (*http.Response, error)
+	DevicePassword  func([]byte) ([]byte, error)
+	GetPluginStatus func(context.Context, tmodel.Plugin) bool
+	GetTarget       func(context.Context, string) (*tmodel.Target, *errors.Error)
+	GetPluginData   func(string) (tmodel.Plugin, *errors.Error)
+}
+
+// GetResourceFromDevice gets the resource of the URL from the server it belongs to,
+// the server is identified by the uuid in the resource id of the URL (uuid.id)
+func GetResourceFromDevice(ctx context.Context, req DeviceResourceRequest) ([]byte, error) {
+	segments := strings.Split(req.URL, "/")
+	if len(segments) < 5 || !strings.Contains(segments[4], ".") {
+		return nil, fmt.Errorf("no server is found in the URI %s", req.URL)
+	}
+	deviceUUID := segments[4][:strings.Index(segments[4], ".")]
+	target, gerr := req.GetTarget(ctx, deviceUUID)
+	if gerr != nil {
+		return nil, fmt.Errorf("unable to get the server %s: %s", deviceUUID, gerr.Error())
+	}
+	plugin, gerr := req.GetPluginData(target.PluginID)
+	if gerr != nil {
+		return nil, fmt.Errorf("unable to get the plugin %s: %s", target.PluginID, gerr.Error())
+	}
+	var contactRequest PluginContactRequest
+	contactRequest.ContactClient = req.ContactClient
+	contactRequest.Plugin = plugin
+	contactRequest.GetPluginStatus = req.GetPluginStatus
+	if strings.EqualFold(plugin.PreferredAuthType, "XAuthToken") {
+		contactRequest.HTTPMethodType = http.MethodPost
+		contactRequest.DeviceInfo = map[string]interface{}{
+			"Username": plugin.Username,
+			"Password": string(plugin.Password),
+		}
+		contactRequest.OID = "/ODIM/v1/Sessions"
+		_, token, _, err := ContactPlugin(ctx, contactRequest, "error while getting the details "+contactRequest.OID+": ")
+		if err != nil {
+			return nil, err
+		}
+		contactRequest.Token = token
+	} else {
+		contactRequest.BasicAuth = map[string]string{
+			"UserName": plugin.Username,
+			"Password": string(plugin.Password),
+		}
+	}
+	password, err := req.DevicePassword(target.Password)
+	if err != nil {
+		return nil, fmt.Errorf("error while trying to decrypt device password: %s", err.Error())
+	}
+	contactRequest.DeviceInfo = map[string]interface{}{
+		"ManagerAddress": target.ManagerAddress,
+		"UserName":       target.UserName,
+		"Password":       password,
+	}
+	contactRequest.OID = strings.Replace(req.URL, deviceUUID+".", "", 1)
+	contactRequest.HTTPMethodType = http.MethodGet
+	body, _, _, err := ContactPlugin(ctx, contactRequest, "error while getting the details "+contactRequest.OID+": ")
+	if err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
 // ContactPlugin is commons which handles the request and response of Contact Plugin usage
 func ContactPlugin(ctx context.Context, req PluginContactRequest, errorMessage string) ([]byte, string, ResponseStatus, error) {
 	var resp ResponseStatus
@@ -289,3 +355,15 @@ func TrackConfigFileChanges(errChan chan error) {
 
 	}
 }
+
+// CreateContext creates a new context based on transactionId, actionId, actionName, threadId, threadName, ProcessName
+func CreateContext(transactionID, actionID, actionName, threadID, threadName, ProcessName string) context.Context {
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, common.TransactionID, transactionID)
+	ctx = context.WithValue(ctx, common.ActionID, actionID)
+	ctx = context.WithValue(ctx, common.ActionName, actionName)
+	ctx = context.WithValue(ctx, common.ThreadID, threadID)
+	ctx = context.WithValue(ctx, common.ThreadName, threadName)
+	ctx = context.WithValue(ctx, common.ProcessName, ProcessName)
+	return ctx
+}
@@ -80,6 +80,12 @@ func mockContactClient(ctx context.Context, url, method, token string, odataID s
 			StatusCode: http.StatusUnauthorized,
 			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
 		}, nil
+	} else if url == "https://localhost:9091/ODIM/v1/Chassis/1/Power" && token == "12345" {
+		body := `{"@odata.id":"/redfish/v1/Chassis/1/Power","PowerControl":[{"PowerConsumedWatts":212}]}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		}, nil
 	} else if url == "https://localhost:9091/ODIM/v1/TelemetryService/MetricReports/CPUUtilCustom1" {
 		body := `{"@odata.id": "/ODIM/v1/TelemetryService/MetricReports/CPUUtilCustom1"}`
 		return &http.Response{
@@ -216,3 +222,34 @@ func Test_callPlugin(t *testing.T) {
 	callPlugin(req)
 
 }
+
+func TestGetResourceFromDevice(t *testing.T) {
+	config.SetUpMockConfig(t)
+	ctx := mockContext()
+	var req = DeviceResourceRequest{
+		URL:             "/redfish/v1/Chassis/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1/Power",
+		ContactClient:   mockContactClient,
+		DevicePassword:  stubDevicePassword,
+		GetPluginStatus: mockPluginStatus,
+		GetTarget: func(ctx context.Context, uuid string) (*tmodel.Target, *errors.Error) {
+			if uuid != "6d4a0a66-7efa-578e-83cf-44dc68d2874e" {
+				return nil, errors.PackError(errors.DBKeyNotFound, "no server")
+			}
+			return &tmodel.Target{ManagerAddress: "10.0.0.1", UserName: "admin", PluginID: "GRF"}, nil
+		},
+		GetPluginData: func(pluginID string) (tmodel.Plugin, *errors.Error) {
+			return tmodel.Plugin{IP: "localhost", Port: "9091", ID: pluginID, PreferredAuthType: "XAuthToken"}, nil
+		},
+	}
+	body, err := GetResourceFromDevice(ctx, req)
+	assert.Nil(t, err, "There should be no error")
+	assert.Contains(t, string(body), "PowerConsumedWatts", "The resource of the server should be returned")
+
+	req.URL = "/redfish/v1/Chassis/0f7ac3ab-3a9e-5e4a-a1a6-5b6f4aa2e0ff.1/Power"
+	_, err = GetResourceFromDevice(ctx, req)
+	assert.NotNil(t, err, "There should be an error for an unknown server")
+
+	req.URL = "/redfish/v1/TelemetryService"
+	_, err = GetResourceFromDevice(ctx, req)
+	assert.NotNil(t, err, "There should be an error for a URI without a server")
+}
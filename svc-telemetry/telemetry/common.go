@@ -24,6 +24,7 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
 	"github.com/ODIM-Project/ODIM/lib-utilities/services"
 	"github.com/ODIM-Project/ODIM/svc-telemetry/tcommon"
+	"github.com/ODIM-Project/ODIM/svc-telemetry/tmessagebus"
 	"github.com/ODIM-Project/ODIM/svc-telemetry/tmodel"
 )
 
@@ -44,6 +45,7 @@ type External struct {
 	GetSessionUserName func(context.Context, string) (string, error)
 	GenericSave        func(context.Context, []byte, string, string) error
 	GetPluginStatus    func(context.Context, tmodel.Plugin) bool
+	PublishEvent       func(context.Context, string, common.MessageData) error
 }
 
 type responseStatus struct {
@@ -56,6 +58,13 @@ type responseStatus struct {
 type DB struct {
 	GetAllKeysFromTable func(context.Context, string, common.DbType) ([]string, error)
 	GetResource         func(context.Context, string, string, common.DbType) (string, *errors.Error)
+	AddMetricSamples    func(context.Context, tmodel.MetricSeries, []tmodel.MetricSample) *errors.Error
+	GetMetricSamples    func(context.Context, tmodel.MetricSeries, int64, int64) ([]tmodel.MetricSample, *errors.Error)
+	RemoveMetricSamples func(context.Context, tmodel.MetricSeries, int64, int64) *errors.Error
+	GetAllMetricSeries  func(context.Context) ([]tmodel.MetricSeries, *errors.Error)
+	RemoveMetricSeries  func(context.Context, tmodel.MetricSeries) *errors.Error
+	DeleteResource      func(context.Context, string, string) *errors.Error
+	GetAggregate        func(context.Context, string) (tmodel.Aggregate, *errors.Error)
 }
 
 // GetExternalInterface retrieves all the external connections update package functions uses
@@ -71,10 +80,18 @@ func GetExternalInterface() *ExternalInterface {
 			GetSessionUserName: services.GetSessionUserName,
 			GenericSave:        tmodel.GenericSave,
 			GetPluginStatus:    tcommon.GetPluginStatus,
+			PublishEvent:       tmessagebus.Publish,
 		},
 		DB: DB{
 			GetAllKeysFromTable: tmodel.GetAllKeysFromTable,
 			GetResource:         tmodel.GetResource,
+			AddMetricSamples:    tmodel.AddMetricSamples,
+			GetMetricSamples:    tmodel.GetMetricSamples,
+			RemoveMetricSamples: tmodel.RemoveMetricSamples,
+			GetAllMetricSeries:  tmodel.GetAllMetricSeries,
+			RemoveMetricSeries:  tmodel.RemoveMetricSeries,
+			DeleteResource:      tmodel.DeleteResource,
+			GetAggregate:        tmodel.GetAggregate,
 		},
 	}
 }
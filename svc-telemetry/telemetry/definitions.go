@@ -0,0 +1,723 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	teleproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/telemetry"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	tlresp "github.com/ODIM-Project/ODIM/svc-telemetry/tlresponse"
+	"github.com/google/uuid"
+)
+
+const (
+	metricReportDefinitionsURI = "/redfish/v1/TelemetryService/MetricReportDefinitions"
+	triggersURI                = "/redfish/v1/TelemetryService/Triggers"
+	metricReportDefinitionType = "#MetricReportDefinition.v1_4_2.MetricReportDefinition"
+	triggersType               = "#Triggers.v1_2_0.Triggers"
+	odimMetricReportDefinition = "#OdimMetricReportDefinition.v1_0_0.OdimMetricReportDefinition"
+	odimTriggers               = "#OdimTriggers.v1_0_0.OdimTriggers"
+
+	periodicReport      = "Periodic"
+	onRequestReport     = "OnRequest"
+	logToMetricReports  = "LogToMetricReportsCollection"
+	redfishEvent        = "RedfishEvent"
+	redfishMetricReport = "RedfishMetricReport"
+	numericTrigger      = "Numeric"
+	discreteTrigger     = "Discrete"
+
+	// minRecurrenceInterval is the shortest interval ODIM computes a periodic metric report with
+	minRecurrenceInterval = 10 * time.Second
+)
+
+var (
+	durationPattern   = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+	resourceIDPattern = regexp.MustCompile(`^[A-Za-z0-9_.\-]+$`)
+)
+
+// collectionLock serializes the updates of the collections of the resources created through ODIM,
+// the members are read and saved back with the member added or removed
+var collectionLock sync.Mutex
+
+// emptyCollections are the collections saved with the first resource created through ODIM
+// when none of the servers has such a resource
+var emptyCollections = map[string]tlresp.Collection{
+	"MetricReportDefinitionsCollection": {
+		OdataContext: "/redfish/v1/$metadata#MetricReportDefinitionCollection.MetricReportDefinitionCollection",
+		OdataID:      metricReportDefinitionsURI,
+		OdataType:    "#MetricReportDefinitionCollection.MetricReportDefinitionCollection",
+		Description:  "MetricReportDefinition Collection view",
+		Name:         "MetricReportDefinitionCollection",
+		Members:      []dmtf.Link{},
+	},
+	"MetricReportsCollection": {
+		OdataContext: "/redfish/v1/$metadata#MetricReportCollection.MetricReportCollection",
+		OdataID:      metricReportsURI,
+		OdataType:    "#MetricReportCollection.MetricReportCollection",
+		Description:  "MetricReport Collection view",
+		Name:         "MetricReportCollection",
+		Members:      []dmtf.Link{},
+	},
+	"TriggersCollection": {
+		OdataContext: "/redfish/v1/$metadata#TriggersCollection.TriggersCollection",
+		OdataID:      triggersURI,
+		OdataType:    "#TriggersCollection.TriggersCollection",
+		Description:  "Triggers Collection view",
+		Name:         "Triggers",
+		Members:      []dmtf.Link{},
+	},
+}
+
+// telemetryResource holds the details of the MetricReportDefinition or the Triggers
+// a create, patch or delete request is for
+type telemetryResource struct {
+	table         string
+	collectionURI string
+	resourceName  string
+	odataType     string
+	oemType       string
+	uri           string
+}
+
+func metricReportDefinitionResource(uri string) telemetryResource {
+	return telemetryResource{
+		table:         "MetricReportDefinitions",
+		collectionURI: metricReportDefinitionsURI,
+		resourceName:  "MetricReportDefinition",
+		odataType:     metricReportDefinitionType,
+		oemType:       odimMetricReportDefinition,
+		uri:           uri,
+	}
+}
+
+func triggersResource(uri string) telemetryResource {
+	return telemetryResource{
+		table:         "Triggers",
+		collectionURI: triggersURI,
+		resourceName:  "Triggers",
+		odataType:     triggersType,
+		oemType:       odimTriggers,
+		uri:           uri,
+	}
+}
+
+// odimOem is the Oem property of the MetricReportDefinitions and Triggers created through ODIM,
+// the servers of the aggregate are added to the values of the SystemID and ChassisID wild cards
+type odimOem struct {
+	Odim struct {
+		ComputedByOdim bool       `json:"ComputedByOdim,omitempty"`
+		Aggregate      *dmtf.Link `json:"Aggregate,omitempty"`
+	} `json:"Odim"`
+}
+
+// CreateMetricReportDefinition saves a new MetricReportDefinition, the metric report
+// of the definition is computed by ODIM from the metric properties of the servers
+func (e *ExternalInterface) CreateMetricReportDefinition(ctx context.Context, req *teleproto.TelemetryRequest) response.RPC {
+	var definition dmtf.MetricReportDefinitions
+	return e.createTelemetryResource(ctx, req, metricReportDefinitionResource(""), &definition, e.prepareMetricReportDefinition)
+}
+
+// UpdateMetricReportDefinition updates the properties of a MetricReportDefinition created through ODIM
+func (e *ExternalInterface) UpdateMetricReportDefinition(ctx context.Context, req *teleproto.TelemetryRequest) response.RPC {
+	var definition dmtf.MetricReportDefinitions
+	return e.updateTelemetryResource(ctx, req, metricReportDefinitionResource(req.URL), &definition, e.prepareMetricReportDefinition)
+}
+
+// DeleteMetricReportDefinition deletes a MetricReportDefinition created through ODIM along with its metric report
+func (e *ExternalInterface) DeleteMetricReportDefinition(ctx context.Context, req *teleproto.TelemetryRequest) response.RPC {
+	resource := metricReportDefinitionResource(req.URL)
+	if resp, ok := e.deleteTelemetryResource(ctx, resource); !ok {
+		return resp
+	}
+	reportURI := metricReportsURI + "/" + resourceID(resource.uri)
+	if _, err := e.DB.GetResource(ctx, "MetricReports", reportURI, common.InMemory); err == nil {
+		if err := e.DB.DeleteResource(ctx, "MetricReports", reportURI); err != nil {
+			l.LogWithFields(ctx).Warn("unable to delete the metric report " + reportURI + ": " + err.Error())
+		}
+		if err := e.removeCollectionMember(ctx, "MetricReportsCollection", metricReportsURI, reportURI); err != nil {
+			l.LogWithFields(ctx).Warn("unable to remove " + reportURI + " from the metric reports: " + err.Error())
+		}
+	}
+	return response.RPC{
+		StatusCode:    http.StatusNoContent,
+		StatusMessage: response.ResourceRemoved,
+	}
+}
+
+// CreateTrigger saves a new Triggers resource with the thresholds of the metric properties of the servers
+func (e *ExternalInterface) CreateTrigger(ctx context.Context, req *teleproto.TelemetryRequest) response.RPC {
+	var trigger dmtf.Triggers
+	return e.createTelemetryResource(ctx, req, triggersResource(""), &trigger, e.prepareTrigger)
+}
+
+// UpdateTrigger updates the properties of a Triggers resource created through ODIM
+func (e *ExternalInterface) UpdateTrigger(ctx context.Context, req *teleproto.TelemetryRequest) response.RPC {
+	var trigger dmtf.Triggers
+	return e.updateTelemetryResource(ctx, req, triggersResource(req.URL), &trigger, e.prepareTrigger)
+}
+
+// DeleteTrigger deletes a Triggers resource created through ODIM
+func (e *ExternalInterface) DeleteTrigger(ctx context.Context, req *teleproto.TelemetryRequest) response.RPC {
+	if resp, ok := e.deleteTelemetryResource(ctx, triggersResource(req.URL)); !ok {
+		return resp
+	}
+	return response.RPC{
+		StatusCode:    http.StatusNoContent,
+		StatusMessage: response.ResourceRemoved,
+	}
+}
+
+// createTelemetryResource validates the request body and saves it as a new resource of the collection
+func (e *ExternalInterface) createTelemetryResource(ctx context.Context, req *teleproto.TelemetryRequest, resource telemetryResource,
+	reqStruct interface{}, prepare func(context.Context, telemetryResource, map[string]interface{}) (response.RPC, bool)) response.RPC {
+	body, resp, ok := parseTelemetryRequest(ctx, req.RequestBody, reqStruct)
+	if !ok {
+		return resp
+	}
+	id, _ := body["Id"].(string)
+	if id == "" {
+		id = uuid.NewString()
+	} else if !resourceIDPattern.MatchString(id) {
+		errMsg := "Id can only have letters, digits, '_', '-' and '.'"
+		l.LogWithFields(ctx).Warn(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errMsg, []interface{}{id, "Id"}, nil)
+	}
+	resource.uri = resource.collectionURI + "/" + id
+	if _, err := e.DB.GetResource(ctx, resource.table, resource.uri, common.InMemory); err == nil {
+		errMsg := resource.uri + " already exists"
+		l.LogWithFields(ctx).Warn(errMsg)
+		return common.GeneralError(http.StatusConflict, response.ResourceAlreadyExists, errMsg, []interface{}{resource.resourceName, "Id", id}, nil)
+	}
+	if resp, ok := prepare(ctx, resource, body); !ok {
+		return resp
+	}
+	if resp, ok := e.saveTelemetryResource(ctx, resource, body); !ok {
+		return resp
+	}
+	if err := e.addCollectionMember(ctx, resource.table+"Collection", resource.collectionURI, resource.uri); err != nil {
+		errMsg := "unable to add " + resource.uri + " to the collection: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+	l.LogWithFields(ctx).Infof("%s is created", resource.uri)
+	return response.RPC{
+		StatusCode:    http.StatusCreated,
+		StatusMessage: response.Created,
+		Header: map[string]string{
+			"Location": resource.uri,
+		},
+		Body: body,
+	}
+}
+
+// updateTelemetryResource merges the properties of the request body into a resource created through ODIM
+func (e *ExternalInterface) updateTelemetryResource(ctx context.Context, req *teleproto.TelemetryRequest, resource telemetryResource,
+	reqStruct interface{}, prepare func(context.Context, telemetryResource, map[string]interface{}) (response.RPC, bool)) response.RPC {
+	patch, resp, ok := parseTelemetryRequest(ctx, req.RequestBody, reqStruct)
+	if !ok {
+		return resp
+	}
+	current, resp, ok := e.getOdimTelemetryResource(ctx, resource, "PATCH")
+	if !ok {
+		return resp
+	}
+	for key, value := range patch {
+		switch key {
+		case "@odata.id", "@odata.type", "Id", "MetricReport":
+			continue
+		}
+		if value == nil {
+			delete(current, key)
+			continue
+		}
+		current[key] = value
+	}
+	if resp, ok := prepare(ctx, resource, current); !ok {
+		return resp
+	}
+	if resp, ok := e.saveTelemetryResource(ctx, resource, current); !ok {
+		return resp
+	}
+	l.LogWithFields(ctx).Infof("%s is updated", resource.uri)
+	return response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+		Body:          current,
+	}
+}
+
+// deleteTelemetryResource deletes a resource created through ODIM and removes it from the collection
+func (e *ExternalInterface) deleteTelemetryResource(ctx context.Context, resource telemetryResource) (response.RPC, bool) {
+	if _, resp, ok := e.getOdimTelemetryResource(ctx, resource, "DELETE"); !ok {
+		return resp, false
+	}
+	if err := e.DB.DeleteResource(ctx, resource.table, resource.uri); err != nil {
+		errMsg := "unable to delete " + resource.uri + ": " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil), false
+	}
+	if err := e.removeCollectionMember(ctx, resource.table+"Collection", resource.collectionURI, resource.uri); err != nil {
+		l.LogWithFields(ctx).Warn("unable to remove " + resource.uri + " from the collection: " + err.Error())
+	}
+	l.LogWithFields(ctx).Infof("%s is deleted", resource.uri)
+	return response.RPC{}, true
+}
+
+// parseTelemetryRequest unmarshals the request body into the request structure for validating
+// the types and the case of the properties, the request body is returned as a map
+func parseTelemetryRequest(ctx context.Context, requestBody []byte, reqStruct interface{}) (map[string]interface{}, response.RPC, bool) {
+	var body map[string]interface{}
+	if err := json.Unmarshal(requestBody, reqStruct); err != nil {
+		errMsg := "unable to parse the request body: " + err.Error()
+		l.LogWithFields(ctx).Warn(errMsg)
+		return nil, common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, nil), false
+	}
+	json.Unmarshal(requestBody, &body)
+	invalidProperties, err := common.RequestParamsCaseValidator(requestBody, reqStruct)
+	if err != nil {
+		errMsg := "Unable to validate request parameters: " + err.Error()
+		l.LogWithFields(ctx).Warn(errMsg)
+		return nil, common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil), false
+	} else if invalidProperties != "" {
+		errMsg := "One or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase "
+		l.LogWithFields(ctx).Warn(errMsg)
+		return nil, common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errMsg, []interface{}{invalidProperties}, nil), false
+	}
+	return body, response.RPC{}, true
+}
+
+// getOdimTelemetryResource gets a resource which can be changed only if it is created through ODIM,
+// the resources collected from the servers are managed by the servers
+func (e *ExternalInterface) getOdimTelemetryResource(ctx context.Context, resource telemetryResource, method string) (map[string]interface{}, response.RPC, bool) {
+	data, gerr := e.DB.GetResource(ctx, resource.table, resource.uri, common.InMemory)
+	if gerr != nil {
+		errMsg := "unable to get " + resource.uri + ": " + gerr.Error()
+		l.LogWithFields(ctx).Warn(errMsg)
+		if errors.DBKeyNotFound == gerr.ErrNo() {
+			return nil, common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{resource.resourceName, resource.uri}, nil), false
+		}
+		return nil, common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil), false
+	}
+	var current map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &current); err != nil {
+		errMsg := "unable to parse " + resource.uri + ": " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return nil, common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil), false
+	}
+	if !getOdimOem(current).Odim.ComputedByOdim {
+		errMsg := resource.uri + " is collected from a server, only the resources created through ODIM can be changed"
+		l.LogWithFields(ctx).Warn(errMsg)
+		if method == http.MethodDelete {
+			return nil, common.GeneralError(http.StatusMethodNotAllowed, response.ResourceCannotBeDeleted, errMsg, nil, nil), false
+		}
+		return nil, common.GeneralError(http.StatusMethodNotAllowed, response.ActionNotSupported, errMsg, []interface{}{method}, nil), false
+	}
+	return current, response.RPC{}, true
+}
+
+// saveTelemetryResource saves the resource in the table the GET requests read from
+func (e *ExternalInterface) saveTelemetryResource(ctx context.Context, resource telemetryResource, body map[string]interface{}) (response.RPC, bool) {
+	data, err := json.Marshal(body)
+	if err == nil {
+		err = e.External.GenericSave(ctx, data, resource.table, resource.uri)
+	}
+	if err != nil {
+		errMsg := "unable to save " + resource.uri + ": " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil), false
+	}
+	return response.RPC{}, true
+}
+
+// prepareMetricReportDefinition validates a MetricReportDefinition, sets the defaults and
+// the properties managed by ODIM
+func (e *ExternalInterface) prepareMetricReportDefinition(ctx context.Context, resource telemetryResource, body map[string]interface{}) (response.RPC, bool) {
+	var definition dmtf.MetricReportDefinitions
+	data, _ := json.Marshal(body)
+	json.Unmarshal(data, &definition)
+
+	if body["MetricReportDefinitionType"] == nil {
+		definition.MetricReportDefinitionType = periodicReport
+	}
+	switch definition.MetricReportDefinitionType {
+	case periodicReport:
+		if definition.Schedule.RecurrenceInterval == "" {
+			return propertyMissing(ctx, "Schedule/RecurrenceInterval")
+		}
+		interval, err := parseDuration(definition.Schedule.RecurrenceInterval)
+		if err != nil || interval < minRecurrenceInterval {
+			errMsg := fmt.Sprintf("RecurrenceInterval must be an ISO 8601 duration of at least %v", minRecurrenceInterval)
+			l.LogWithFields(ctx).Warn(errMsg)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errMsg, []interface{}{definition.Schedule.RecurrenceInterval, "RecurrenceInterval"}, nil), false
+		}
+	case onRequestReport:
+	default:
+		errMsg := "MetricReportDefinitionType must be " + periodicReport + " or " + onRequestReport
+		l.LogWithFields(ctx).Warn(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errMsg, []interface{}{definition.MetricReportDefinitionType, "MetricReportDefinitionType"}, nil), false
+	}
+	if len(definition.ReportActions) == 0 {
+		definition.ReportActions = []string{logToMetricReports}
+	}
+	for _, action := range definition.ReportActions {
+		if action != logToMetricReports {
+			errMsg := "ReportActions of the definitions computed by ODIM can only be " + logToMetricReports
+			l.LogWithFields(ctx).Warn(errMsg)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errMsg, []interface{}{action, "ReportActions"}, nil), false
+		}
+	}
+	if resp, ok := e.prepareMetricProperties(ctx, resource, body); !ok {
+		return resp, false
+	}
+
+	id := resourceID(resource.uri)
+	body["MetricReportDefinitionType"] = definition.MetricReportDefinitionType
+	body["ReportActions"] = definition.ReportActions
+	body["MetricReport"] = dmtf.Link{Oid: metricReportsURI + "/" + id}
+	if body["MetricReportDefinitionEnabled"] == nil {
+		body["MetricReportDefinitionEnabled"] = true
+	}
+	state := "Enabled"
+	if enabled, _ := body["MetricReportDefinitionEnabled"].(bool); !enabled {
+		state = "Disabled"
+	}
+	body["Status"] = dmtf.Status{State: state, Health: "OK"}
+	return response.RPC{}, true
+}
+
+// prepareTrigger validates a Triggers resource, sets the defaults and the properties managed by ODIM
+func (e *ExternalInterface) prepareTrigger(ctx context.Context, resource telemetryResource, body map[string]interface{}) (response.RPC, bool) {
+	var trigger dmtf.Triggers
+	data, _ := json.Marshal(body)
+	json.Unmarshal(data, &trigger)
+
+	switch trigger.MetricType {
+	case numericTrigger:
+		thresholds := map[string]*dmtf.Threshold{
+			"LowerCritical": trigger.NumericThresholds.LowerCritical,
+			"LowerWarning":  trigger.NumericThresholds.LowerWarning,
+			"UpperCritical": trigger.NumericThresholds.UpperCritical,
+			"UpperWarning":  trigger.NumericThresholds.UpperWarning,
+		}
+		var present bool
+		for name, threshold := range thresholds {
+			if threshold == nil {
+				continue
+			}
+			present = true
+			switch threshold.Activation {
+			case "", "Increasing", "Decreasing", "Either":
+			default:
+				errMsg := "Activation must be Increasing, Decreasing or Either"
+				l.LogWithFields(ctx).Warn(errMsg)
+				return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errMsg, []interface{}{threshold.Activation, name + "/Activation"}, nil), false
+			}
+			if resp, ok := validateDwellTime(ctx, threshold.DwellTime, name+"/DwellTime"); !ok {
+				return resp, false
+			}
+			if threshold.HysteresisReading < 0 {
+				errMsg := "HysteresisReading must not be negative"
+				l.LogWithFields(ctx).Warn(errMsg)
+				return common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errMsg, []interface{}{threshold.HysteresisReading, name + "/HysteresisReading"}, nil), false
+			}
+		}
+		if !present {
+			return propertyMissing(ctx, "NumericThresholds")
+		}
+	case discreteTrigger:
+		switch trigger.DiscreteTriggerCondition {
+		case "Specified":
+			if len(trigger.DiscreteTriggers) == 0 {
+				return propertyMissing(ctx, "DiscreteTriggers")
+			}
+			for i, discrete := range trigger.DiscreteTriggers {
+				property := "DiscreteTriggers/" + strconv.Itoa(i)
+				if discrete.Value == "" {
+					return propertyMissing(ctx, property+"/Value")
+				}
+				switch discrete.Severity {
+				case "", "OK", "Warning", "Critical":
+				default:
+					errMsg := "Severity must be OK, Warning or Critical"
+					l.LogWithFields(ctx).Warn(errMsg)
+					return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errMsg, []interface{}{discrete.Severity, property + "/Severity"}, nil), false
+				}
+				if resp, ok := validateDwellTime(ctx, discrete.DwellTime, property+"/DwellTime"); !ok {
+					return resp, false
+				}
+			}
+		case "Changed":
+		case "":
+			return propertyMissing(ctx, "DiscreteTriggerCondition")
+		default:
+			errMsg := "DiscreteTriggerCondition must be Specified or Changed"
+			l.LogWithFields(ctx).Warn(errMsg)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errMsg, []interface{}{trigger.DiscreteTriggerCondition, "DiscreteTriggerCondition"}, nil), false
+		}
+	case "":
+		return propertyMissing(ctx, "MetricType")
+	default:
+		errMsg := "MetricType must be " + numericTrigger + " or " + discreteTrigger
+		l.LogWithFields(ctx).Warn(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errMsg, []interface{}{trigger.MetricType, "MetricType"}, nil), false
+	}
+
+	if len(trigger.TriggerActions) == 0 {
+		trigger.TriggerActions = []string{redfishEvent}
+	}
+	for _, action := range trigger.TriggerActions {
+		switch action {
+		case redfishEvent:
+		case redfishMetricReport:
+			if len(trigger.Links.MetricReportDefinitions) == 0 {
+				return propertyMissing(ctx, "Links/MetricReportDefinitions")
+			}
+		default:
+			errMsg := "TriggerActions of the triggers evaluated by ODIM can only be " + redfishEvent + " or " + redfishMetricReport
+			l.LogWithFields(ctx).Warn(errMsg)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errMsg, []interface{}{action, "TriggerActions"}, nil), false
+		}
+	}
+	for _, link := range trigger.Links.MetricReportDefinitions {
+		definition := metricReportDefinitionResource(link.ODataID)
+		if _, resp, ok := e.getOdimTelemetryResource(ctx, definition, http.MethodGet); !ok {
+			if resp.StatusCode == http.StatusMethodNotAllowed {
+				errMsg := link.ODataID + " is collected from a server, only the definitions created through ODIM can be linked"
+				resp = common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errMsg, []interface{}{link.ODataID, "Links/MetricReportDefinitions"}, nil)
+			}
+			return resp, false
+		}
+	}
+	if resp, ok := e.prepareMetricProperties(ctx, resource, body); !ok {
+		return resp, false
+	}
+
+	body["TriggerActions"] = trigger.TriggerActions
+	if len(trigger.Links.MetricReportDefinitions) > 0 {
+		links, _ := body["Links"].(map[string]interface{})
+		links["MetricReportDefinitions@odata.count"] = len(trigger.Links.MetricReportDefinitions)
+	}
+	if body["Status"] == nil {
+		body["Status"] = dmtf.Status{State: "Enabled", Health: "OK"}
+	}
+	return response.RPC{}, true
+}
+
+// prepareMetricProperties collapses the metric properties of the servers into the wild card form,
+// checks the aggregate of the Oem property and sets the properties common to all the resources
+// created through ODIM
+func (e *ExternalInterface) prepareMetricProperties(ctx context.Context, resource telemetryResource, body map[string]interface{}) (response.RPC, bool) {
+	properties, _ := body["MetricProperties"].([]interface{})
+	if len(properties) == 0 {
+		return propertyMissing(ctx, "MetricProperties")
+	}
+	for i, property := range properties {
+		if p, _ := property.(string); !strings.HasPrefix(p, "/redfish/v1/") || !strings.Contains(p, "#/") {
+			errMsg := "metric property must be the URI of a resource and a JSON pointer to its property"
+			l.LogWithFields(ctx).Warn(errMsg)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errMsg, []interface{}{fmt.Sprintf("%v", property), "MetricProperties/" + strconv.Itoa(i)}, nil), false
+		}
+	}
+
+	oem := getOdimOem(body)
+	if oem.Odim.Aggregate != nil {
+		if _, err := e.DB.GetAggregate(ctx, oem.Odim.Aggregate.Oid); err != nil {
+			errMsg := "unable to get the aggregate " + oem.Odim.Aggregate.Oid + ": " + err.Error()
+			l.LogWithFields(ctx).Warn(errMsg)
+			return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"Aggregate", oem.Odim.Aggregate.Oid}, nil), false
+		}
+	}
+
+	// the wild cards of the request are kept and the ids of the servers used
+	// in the metric properties are added to the SystemID and ChassisID wild cards
+	var wildCards []common.WildCard
+	if cards, ok := body["Wildcards"].([]interface{}); ok {
+		wildCards = common.GetWildCard(cards)
+	}
+	for _, name := range []string{common.SystemIDWildCard, common.ChassisIDWildCard} {
+		if !hasWildCard(wildCards, name) {
+			wildCards = append(wildCards, common.WildCard{Name: name, Values: []string{}})
+		}
+	}
+	dbData, _ := json.Marshal(map[string]interface{}{
+		"Wildcards":        wildCards,
+		"MetricProperties": []string{},
+	})
+	data, err := common.FormWildCard(string(dbData), body)
+	if err != nil {
+		errMsg := "unable to form the wild cards of the metric properties: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil), false
+	}
+	for key := range body {
+		delete(body, key)
+	}
+	json.Unmarshal([]byte(data), &body)
+
+	id := resourceID(resource.uri)
+	body["@odata.id"] = resource.uri
+	body["@odata.type"] = resource.odataType
+	body["Id"] = id
+	if name, _ := body["Name"].(string); name == "" {
+		body["Name"] = id
+	}
+	odim := map[string]interface{}{
+		"@odata.type":    resource.oemType,
+		"ComputedByOdim": true,
+	}
+	if oem.Odim.Aggregate != nil {
+		odim["Aggregate"] = oem.Odim.Aggregate
+	}
+	body["Oem"] = map[string]interface{}{"Odim": odim}
+	return response.RPC{}, true
+}
+
+// addCollectionMember adds the member to the collection, the collection is created if not present
+func (e *ExternalInterface) addCollectionMember(ctx context.Context, table, collectionURI, member string) error {
+	collectionLock.Lock()
+	defer collectionLock.Unlock()
+	collection, err := e.getCollection(ctx, table, collectionURI)
+	if err != nil {
+		return err
+	}
+	members, _ := collection["Members"].([]interface{})
+	for _, m := range members {
+		if link, _ := m.(map[string]interface{}); link["@odata.id"] == member {
+			return nil
+		}
+	}
+	members = append(members, map[string]interface{}{"@odata.id": member})
+	return e.saveCollection(ctx, table, collectionURI, collection, members)
+}
+
+// removeCollectionMember removes the member from the collection
+func (e *ExternalInterface) removeCollectionMember(ctx context.Context, table, collectionURI, member string) error {
+	collectionLock.Lock()
+	defer collectionLock.Unlock()
+	collection, err := e.getCollection(ctx, table, collectionURI)
+	if err != nil {
+		return err
+	}
+	members, _ := collection["Members"].([]interface{})
+	result := []interface{}{}
+	for _, m := range members {
+		if link, _ := m.(map[string]interface{}); link["@odata.id"] != member {
+			result = append(result, m)
+		}
+	}
+	return e.saveCollection(ctx, table, collectionURI, collection, result)
+}
+
+func (e *ExternalInterface) getCollection(ctx context.Context, table, collectionURI string) (map[string]interface{}, error) {
+	var collection map[string]interface{}
+	data, gerr := e.DB.GetResource(ctx, table, collectionURI, common.InMemory)
+	if gerr != nil {
+		if errors.DBKeyNotFound != gerr.ErrNo() {
+			return nil, fmt.Errorf(gerr.Error())
+		}
+		data, _ := json.Marshal(emptyCollections[table])
+		json.Unmarshal(data, &collection)
+		return collection, nil
+	}
+	if err := json.Unmarshal([]byte(data), &collection); err != nil {
+		return nil, err
+	}
+	return collection, nil
+}
+
+func (e *ExternalInterface) saveCollection(ctx context.Context, table, collectionURI string, collection map[string]interface{}, members []interface{}) error {
+	collection["Members"] = members
+	collection["Members@odata.count"] = len(members)
+	data, err := json.Marshal(collection)
+	if err != nil {
+		return err
+	}
+	return e.External.GenericSave(ctx, data, table, collectionURI)
+}
+
+// getOdimOem gets the ODIM specific properties of a MetricReportDefinition or Triggers resource
+func getOdimOem(body map[string]interface{}) odimOem {
+	var oem odimOem
+	if body["Oem"] != nil {
+		data, _ := json.Marshal(body["Oem"])
+		json.Unmarshal(data, &oem)
+	}
+	return oem
+}
+
+func hasWildCard(wildCards []common.WildCard, name string) bool {
+	for _, wCard := range wildCards {
+		if wCard.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func resourceID(uri string) string {
+	return uri[strings.LastIndex(uri, "/")+1:]
+}
+
+func propertyMissing(ctx context.Context, property string) (response.RPC, bool) {
+	errMsg := "'" + property + "' parameter cannot be empty"
+	l.LogWithFields(ctx).Warn(errMsg)
+	return common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{property}, nil), false
+}
+
+func validateDwellTime(ctx context.Context, dwellTime, property string) (response.RPC, bool) {
+	if dwellTime == "" {
+		return response.RPC{}, true
+	}
+	if _, err := parseDuration(dwellTime); err != nil {
+		errMsg := "DwellTime must be an ISO 8601 duration: " + err.Error()
+		l.LogWithFields(ctx).Warn(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errMsg, []interface{}{dwellTime, property}, nil), false
+	}
+	return response.RPC{}, true
+}
+
+// parseDuration parses the ISO 8601 durations used by Redfish, such as PT30S or P1DT2H
+func parseDuration(value string) (time.Duration, error) {
+	match := durationPattern.FindStringSubmatch(value)
+	if match == nil || value == "P" || strings.HasSuffix(value, "T") {
+		return 0, fmt.Errorf("invalid duration %s", value)
+	}
+	var duration time.Duration
+	units := []time.Duration{24 * time.Hour, time.Hour, time.Minute, time.Second}
+	for i, unit := range units {
+		if match[i+1] == "" {
+			continue
+		}
+		n, err := strconv.ParseFloat(match[i+1], 64)
+		if err != nil {
+			return 0, err
+		}
+		duration += time.Duration(n * float64(unit))
+	}
+	return duration, nil
+}
@@ -0,0 +1,456 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	teleproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/telemetry"
+	"github.com/ODIM-Project/ODIM/svc-telemetry/tmodel"
+)
+
+const aggregateURI = "/redfish/v1/AggregationService/Aggregates/a1"
+
+// fakeTelemetryDB keeps the resources of the telemetry tables in memory
+type fakeTelemetryDB map[string]map[string]string
+
+func (f fakeTelemetryDB) interfaceWith(e *ExternalInterface) *ExternalInterface {
+	e.DB.GetResource = func(ctx context.Context, table, key string, dbType common.DbType) (string, *errors.Error) {
+		data, ok := f[table][key]
+		if !ok {
+			return "", errors.PackError(errors.DBKeyNotFound, "no data with the key ", key, " found")
+		}
+		return data, nil
+	}
+	e.DB.GetAllKeysFromTable = func(ctx context.Context, table string, dbType common.DbType) ([]string, error) {
+		var keys []string
+		for key := range f[table] {
+			keys = append(keys, key)
+		}
+		return keys, nil
+	}
+	e.DB.DeleteResource = func(ctx context.Context, table, key string) *errors.Error {
+		delete(f[table], key)
+		return nil
+	}
+	e.DB.GetAggregate = func(ctx context.Context, uri string) (tmodel.Aggregate, *errors.Error) {
+		if uri != aggregateURI {
+			return tmodel.Aggregate{}, errors.PackError(errors.DBKeyNotFound, "no data with the key ", uri, " found")
+		}
+		return tmodel.Aggregate{Elements: []tmodel.OdataID{{OdataID: "/redfish/v1/Systems/uuid1.1"}}}, nil
+	}
+	e.External.GenericSave = func(ctx context.Context, data []byte, table, key string) error {
+		if f[table] == nil {
+			f[table] = make(map[string]string)
+		}
+		f[table][key] = string(data)
+		return nil
+	}
+	return e
+}
+
+func (f fakeTelemetryDB) resource(t *testing.T, table, key string) map[string]interface{} {
+	var resource map[string]interface{}
+	if err := json.Unmarshal([]byte(f[table][key]), &resource); err != nil {
+		t.Fatalf("%s is not saved in %s: %v", key, table, err)
+	}
+	return resource
+}
+
+func newFakeTelemetryDB() fakeTelemetryDB {
+	return fakeTelemetryDB{
+		"MetricReportDefinitions": {
+			metricReportDefinitionsURI + "/BMCPower": `{"@odata.id":"/redfish/v1/TelemetryService/MetricReportDefinitions/BMCPower","Id":"BMCPower"}`,
+		},
+		"Triggers": {
+			triggersURI + "/BMCTrigger": `{"@odata.id":"/redfish/v1/TelemetryService/Triggers/BMCTrigger","Id":"BMCTrigger"}`,
+		},
+		"Chassis": {
+			"/redfish/v1/Chassis/uuid1.1": "{}",
+			"/redfish/v1/Chassis/uuid2.1": "{}",
+		},
+	}
+}
+
+func telemetryRequest(url, body string) *teleproto.TelemetryRequest {
+	return &teleproto.TelemetryRequest{
+		SessionToken: "validToken",
+		URL:          url,
+		RequestBody:  []byte(body),
+	}
+}
+
+const powerDefinition = `{"Id":"Power","MetricReportDefinitionType":"Periodic","Schedule":{"RecurrenceInterval":"PT30S"},` +
+	`"MetricProperties":["/redfish/v1/Chassis/uuid1.1/Power#/PowerControl/0/PowerConsumedWatts"],` +
+	`"Oem":{"Odim":{"Aggregate":{"@odata.id":"/redfish/v1/AggregationService/Aggregates/a1"}}}}`
+
+func TestCreateMetricReportDefinition(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"success", powerDefinition, http.StatusCreated},
+		{"on request definition", `{"MetricReportDefinitionType":"OnRequest","MetricProperties":["/redfish/v1/Systems/{SystemID}/Processors/1#/Status/Health"]}`, http.StatusCreated},
+		{"malformed body", `{"Id":`, http.StatusBadRequest},
+		{"property in lower case", `{"id":"Power"}`, http.StatusBadRequest},
+		{"existing definition", `{"Id":"BMCPower"}`, http.StatusConflict},
+		{"invalid id", `{"Id":"a/b"}`, http.StatusBadRequest},
+		{"missing recurrence interval", `{"MetricProperties":["/redfish/v1/Chassis/uuid1.1/Power#/Voltages/0/ReadingVolts"]}`, http.StatusBadRequest},
+		{"short recurrence interval", `{"Schedule":{"RecurrenceInterval":"PT1S"},"MetricProperties":["/redfish/v1/Chassis/uuid1.1/Power#/Voltages/0/ReadingVolts"]}`, http.StatusBadRequest},
+		{"invalid type", `{"MetricReportDefinitionType":"OnChange","MetricProperties":["/redfish/v1/Chassis/uuid1.1/Power#/Voltages/0/ReadingVolts"]}`, http.StatusBadRequest},
+		{"invalid report action", `{"MetricReportDefinitionType":"OnRequest","ReportActions":["RedfishEvent"],"MetricProperties":["/redfish/v1/Chassis/uuid1.1/Power#/Voltages/0/ReadingVolts"]}`, http.StatusBadRequest},
+		{"missing metric properties", `{"MetricReportDefinitionType":"OnRequest"}`, http.StatusBadRequest},
+		{"invalid metric property", `{"MetricReportDefinitionType":"OnRequest","MetricProperties":["PowerConsumedWatts"]}`, http.StatusBadRequest},
+		{"unknown aggregate", `{"MetricReportDefinitionType":"OnRequest","MetricProperties":["/redfish/v1/Chassis/uuid1.1/Power#/Voltages/0/ReadingVolts"],"Oem":{"Odim":{"Aggregate":{"@odata.id":"/redfish/v1/AggregationService/Aggregates/a2"}}}}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newFakeTelemetryDB()
+			e := db.interfaceWith(MockGetExternalInterface())
+			got := e.CreateMetricReportDefinition(context.Background(), telemetryRequest(metricReportDefinitionsURI, tt.body))
+			if int(got.StatusCode) != tt.want {
+				t.Errorf("CreateMetricReportDefinition() = %v, want %v: %v", got.StatusCode, tt.want, got.Body)
+			}
+		})
+	}
+
+	db := newFakeTelemetryDB()
+	e := db.interfaceWith(MockGetExternalInterface())
+	got := e.CreateMetricReportDefinition(context.Background(), telemetryRequest(metricReportDefinitionsURI, powerDefinition))
+	if got.Header["Location"] != metricReportDefinitionsURI+"/Power" {
+		t.Errorf("CreateMetricReportDefinition() Location = %v", got.Header["Location"])
+	}
+	definition := db.resource(t, "MetricReportDefinitions", metricReportDefinitionsURI+"/Power")
+	wantProperties := []interface{}{"/redfish/v1/Chassis/{ChassisID}/Power#/PowerControl/0/PowerConsumedWatts"}
+	if !reflect.DeepEqual(definition["MetricProperties"], wantProperties) {
+		t.Errorf("MetricProperties = %v, want %v", definition["MetricProperties"], wantProperties)
+	}
+	if !getOdimOem(definition).Odim.ComputedByOdim {
+		t.Errorf("the definition is not marked as computed by ODIM: %v", definition["Oem"])
+	}
+	if definition["MetricReportDefinitionEnabled"] != true || definition["MetricReport"] == nil {
+		t.Errorf("the defaults of the definition are not set: %v", definition)
+	}
+	collection := db.resource(t, "MetricReportDefinitionsCollection", metricReportDefinitionsURI)
+	if collection["Members@odata.count"] != float64(1) {
+		t.Errorf("the definition is not added to the collection: %v", collection)
+	}
+}
+
+func TestUpdateMetricReportDefinition(t *testing.T) {
+	db := newFakeTelemetryDB()
+	e := db.interfaceWith(MockGetExternalInterface())
+	ctx := context.Background()
+	e.CreateMetricReportDefinition(ctx, telemetryRequest(metricReportDefinitionsURI, powerDefinition))
+
+	tests := []struct {
+		name string
+		url  string
+		body string
+		want int
+	}{
+		{"disable the definition", metricReportDefinitionsURI + "/Power", `{"MetricReportDefinitionEnabled":false}`, http.StatusOK},
+		{"invalid recurrence interval", metricReportDefinitionsURI + "/Power", `{"Schedule":{"RecurrenceInterval":"30S"}}`, http.StatusBadRequest},
+		{"definition of a server", metricReportDefinitionsURI + "/BMCPower", `{"MetricReportDefinitionEnabled":false}`, http.StatusMethodNotAllowed},
+		{"unknown definition", metricReportDefinitionsURI + "/Unknown", `{"MetricReportDefinitionEnabled":false}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := e.UpdateMetricReportDefinition(ctx, telemetryRequest(tt.url, tt.body))
+			if int(got.StatusCode) != tt.want {
+				t.Errorf("UpdateMetricReportDefinition() = %v, want %v: %v", got.StatusCode, tt.want, got.Body)
+			}
+		})
+	}
+	definition := db.resource(t, "MetricReportDefinitions", metricReportDefinitionsURI+"/Power")
+	if status, _ := definition["Status"].(map[string]interface{}); status["State"] != "Disabled" {
+		t.Errorf("Status = %v, want the Disabled state", definition["Status"])
+	}
+	if definition["Schedule"].(map[string]interface{})["RecurrenceInterval"] != "PT30S" {
+		t.Errorf("the definition is changed by an invalid request: %v", definition["Schedule"])
+	}
+}
+
+func TestDeleteMetricReportDefinition(t *testing.T) {
+	db := newFakeTelemetryDB()
+	e := db.interfaceWith(MockGetExternalInterface())
+	ctx := context.Background()
+	e.CreateMetricReportDefinition(ctx, telemetryRequest(metricReportDefinitionsURI, powerDefinition))
+	reportURI := metricReportsURI + "/Power"
+	db["MetricReports"] = map[string]string{reportURI: "{}"}
+	e.addCollectionMember(ctx, "MetricReportsCollection", metricReportsURI, reportURI)
+
+	tests := []struct {
+		name string
+		url  string
+		want int
+	}{
+		{"definition created through ODIM", metricReportDefinitionsURI + "/Power", http.StatusNoContent},
+		{"definition of a server", metricReportDefinitionsURI + "/BMCPower", http.StatusMethodNotAllowed},
+		{"deleted definition", metricReportDefinitionsURI + "/Power", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := e.DeleteMetricReportDefinition(ctx, telemetryRequest(tt.url, ""))
+			if int(got.StatusCode) != tt.want {
+				t.Errorf("DeleteMetricReportDefinition() = %v, want %v: %v", got.StatusCode, tt.want, got.Body)
+			}
+		})
+	}
+	if _, ok := db["MetricReports"][reportURI]; ok {
+		t.Errorf("the metric report of the deleted definition is not deleted")
+	}
+	for _, table := range []string{"MetricReportDefinitionsCollection", "MetricReportsCollection"} {
+		for uri := range db[table] {
+			if collection := db.resource(t, table, uri); collection["Members@odata.count"] != float64(0) {
+				t.Errorf("the deleted resources are not removed from %s: %v", table, collection)
+			}
+		}
+	}
+}
+
+func TestAddCollectionMemberConcurrently(t *testing.T) {
+	db := newFakeTelemetryDB()
+	e := db.interfaceWith(MockGetExternalInterface())
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			e.addCollectionMember(ctx, "MetricReportsCollection", metricReportsURI, fmt.Sprintf("%s/Report%d", metricReportsURI, i))
+		}(i)
+	}
+	wg.Wait()
+	if collection := db.resource(t, "MetricReportsCollection", metricReportsURI); collection["Members@odata.count"] != float64(20) {
+		t.Errorf("members added concurrently are lost: %v", collection["Members@odata.count"])
+	}
+}
+
+func TestCreateTrigger(t *testing.T) {
+	numeric := `"MetricType":"Numeric","NumericThresholds":{"UpperCritical":{"Reading":400,"Activation":"Increasing","DwellTime":"PT30S"}},` +
+		`"MetricProperties":["/redfish/v1/Chassis/uuid1.1/Power#/PowerControl/0/PowerConsumedWatts"]`
+	db := newFakeTelemetryDB()
+	e := db.interfaceWith(MockGetExternalInterface())
+	ctx := context.Background()
+	e.CreateMetricReportDefinition(ctx, telemetryRequest(metricReportDefinitionsURI, powerDefinition))
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"numeric trigger", `{"Id":"PowerLimit",` + numeric + `}`, http.StatusCreated},
+		{"discrete trigger", `{"MetricType":"Discrete","DiscreteTriggerCondition":"Specified","DiscreteTriggers":[{"Value":"Critical","Severity":"Critical","DwellTime":"PT1M"}],"MetricProperties":["/redfish/v1/Systems/uuid1.1#/Status/Health"]}`, http.StatusCreated},
+		{"trigger with a metric report", `{"TriggerActions":["RedfishMetricReport"],"Links":{"MetricReportDefinitions":[{"@odata.id":"/redfish/v1/TelemetryService/MetricReportDefinitions/Power"}]},` + numeric + `}`, http.StatusCreated},
+		{"existing trigger", `{"Id":"PowerLimit",` + numeric + `}`, http.StatusConflict},
+		{"missing metric type", `{"MetricProperties":["/redfish/v1/Chassis/uuid1.1/Power#/PowerControl/0/PowerConsumedWatts"]}`, http.StatusBadRequest},
+		{"missing thresholds", `{"MetricType":"Numeric","MetricProperties":["/redfish/v1/Chassis/uuid1.1/Power#/PowerControl/0/PowerConsumedWatts"]}`, http.StatusBadRequest},
+		{"invalid activation", `{"MetricType":"Numeric","NumericThresholds":{"UpperWarning":{"Reading":300,"Activation":"Rising"}},"MetricProperties":["/redfish/v1/Chassis/uuid1.1/Power#/PowerControl/0/PowerConsumedWatts"]}`, http.StatusBadRequest},
+		{"invalid dwell time", `{"MetricType":"Numeric","NumericThresholds":{"UpperWarning":{"Reading":300,"DwellTime":"30s"}},"MetricProperties":["/redfish/v1/Chassis/uuid1.1/Power#/PowerControl/0/PowerConsumedWatts"]}`, http.StatusBadRequest},
+		{"missing discrete triggers", `{"MetricType":"Discrete","DiscreteTriggerCondition":"Specified","MetricProperties":["/redfish/v1/Systems/uuid1.1#/Status/Health"]}`, http.StatusBadRequest},
+		{"missing metric report definitions", `{"TriggerActions":["RedfishMetricReport"],` + numeric + `}`, http.StatusBadRequest},
+		{"metric report definition of a server", `{"TriggerActions":["RedfishMetricReport"],"Links":{"MetricReportDefinitions":[{"@odata.id":"/redfish/v1/TelemetryService/MetricReportDefinitions/BMCPower"}]},` + numeric + `}`, http.StatusBadRequest},
+		{"invalid trigger action", `{"TriggerActions":["LogToLogService"],` + numeric + `}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := e.CreateTrigger(ctx, telemetryRequest(triggersURI, tt.body))
+			if int(got.StatusCode) != tt.want {
+				t.Errorf("CreateTrigger() = %v, want %v: %v", got.StatusCode, tt.want, got.Body)
+			}
+		})
+	}
+	trigger := db.resource(t, "Triggers", triggersURI+"/PowerLimit")
+	if !reflect.DeepEqual(trigger["TriggerActions"], []interface{}{redfishEvent}) {
+		t.Errorf("TriggerActions = %v, want the default RedfishEvent", trigger["TriggerActions"])
+	}
+}
+
+func TestExternalInterface_UpdateTrigger(t *testing.T) {
+	db := newFakeTelemetryDB()
+	e := db.interfaceWith(MockGetExternalInterface())
+	ctx := context.Background()
+	e.CreateTrigger(ctx, telemetryRequest(triggersURI, `{"Id":"Health","MetricType":"Discrete","DiscreteTriggerCondition":"Changed","MetricProperties":["/redfish/v1/Systems/uuid1.1#/Status/Health"]}`))
+
+	tests := []struct {
+		name string
+		url  string
+		body string
+		want int
+	}{
+		{"trigger created through ODIM", triggersURI + "/Health", `{"DiscreteTriggerCondition":"Specified","DiscreteTriggers":[{"Value":"Warning","Severity":"Warning"}]}`, http.StatusOK},
+		{"invalid condition", triggersURI + "/Health", `{"DiscreteTriggerCondition":"Updated"}`, http.StatusBadRequest},
+		{"trigger of a server", triggersURI + "/BMCTrigger", `{"DiscreteTriggerCondition":"Changed"}`, http.StatusMethodNotAllowed},
+		{"unknown trigger", triggersURI + "/Unknown", `{"DiscreteTriggerCondition":"Changed"}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := e.UpdateTrigger(ctx, telemetryRequest(tt.url, tt.body))
+			if int(got.StatusCode) != tt.want {
+				t.Errorf("UpdateTrigger() = %v, want %v: %v", got.StatusCode, tt.want, got.Body)
+			}
+		})
+	}
+}
+
+func TestDeleteTrigger(t *testing.T) {
+	db := newFakeTelemetryDB()
+	e := db.interfaceWith(MockGetExternalInterface())
+	ctx := context.Background()
+	e.CreateTrigger(ctx, telemetryRequest(triggersURI, `{"Id":"Health","MetricType":"Discrete","DiscreteTriggerCondition":"Changed","MetricProperties":["/redfish/v1/Systems/uuid1.1#/Status/Health"]}`))
+	if got := e.DeleteTrigger(ctx, telemetryRequest(triggersURI+"/Health", "")); got.StatusCode != http.StatusNoContent {
+		t.Errorf("DeleteTrigger() = %v, want %v", got.StatusCode, http.StatusNoContent)
+	}
+	if got := e.DeleteTrigger(ctx, telemetryRequest(triggersURI+"/BMCTrigger", "")); got.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("DeleteTrigger() = %v, want %v", got.StatusCode, http.StatusMethodNotAllowed)
+	}
+	if _, ok := db["Triggers"][triggersURI+"/Health"]; ok {
+		t.Errorf("the trigger is not deleted")
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"PT30S", 30 * time.Second, false},
+		{"PT1.5S", 1500 * time.Millisecond, false},
+		{"P1DT2H3M", 26*time.Hour + 3*time.Minute, false},
+		{"PT5M", 5 * time.Minute, false},
+		{"P", 0, true},
+		{"PT", 0, true},
+		{"30S", 0, true},
+		{"", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseDuration(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseDuration(%q) = %v, %v, want %v", tt.value, got, err, tt.want)
+		}
+	}
+}
+
+// powerContactClient answers the plugin requests for the power resources of the servers
+func powerContactClient(ctx context.Context, url, method, token string, odataID string, body interface{}, loginCredential map[string]string) (*http.Response, error) {
+	if strings.HasSuffix(url, "/Sessions") {
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`{}`)),
+			Header:     http.Header{"X-Auth-Token": []string{"12345"}},
+		}, nil
+	}
+	if strings.HasSuffix(url, "/Chassis/1/Power") {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`{"PowerControl":[{"PowerConsumedWatts":215.5}],"Voltages":[{"Name":"12V","ReadingVolts":null}]}`)),
+		}, nil
+	}
+	return nil, fmt.Errorf("InvalidRequest")
+}
+
+// reportInterface reads the power resources of the servers through a plugin
+func (f fakeTelemetryDB) reportInterface(t *testing.T) *ExternalInterface {
+	config.SetUpMockConfig(t)
+	e := f.interfaceWith(MockGetExternalInterface())
+	e.External.ContactClient = powerContactClient
+	e.External.DevicePassword = func(password []byte) ([]byte, error) { return password, nil }
+	e.External.GetPluginStatus = func(ctx context.Context, plugin tmodel.Plugin) bool { return false }
+	e.External.GetPluginData = func(pluginID string) (tmodel.Plugin, *errors.Error) {
+		return tmodel.Plugin{ID: pluginID, IP: "localhost", Port: "9091", PreferredAuthType: "XAuthToken"}, nil
+	}
+	e.External.GetTarget = func(ctx context.Context, deviceUUID string) (*tmodel.Target, *errors.Error) {
+		return &tmodel.Target{PluginID: "GRF", ManagerAddress: "10.0.0.1", DeviceUUID: deviceUUID}, nil
+	}
+	return e
+}
+
+func TestComputeMetricReport(t *testing.T) {
+	db := newFakeTelemetryDB()
+	e := db.reportInterface(t)
+	ctx := context.Background()
+	e.CreateMetricReportDefinition(ctx, telemetryRequest(metricReportDefinitionsURI, `{"Id":"Power","Schedule":{"RecurrenceInterval":"PT30S"},`+
+		`"MetricProperties":["/redfish/v1/Chassis/{ChassisID}/Power#/PowerControl/0/PowerConsumedWatts","/redfish/v1/Chassis/{ChassisID}/Power#/Voltages/0/ReadingVolts"],`+
+		`"Oem":{"Odim":{"Aggregate":{"@odata.id":"/redfish/v1/AggregationService/Aggregates/a1"}}}}`))
+
+	now := time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC)
+	lastReports := make(map[string]time.Time)
+	e.computeDueMetricReports(ctx, now, lastReports)
+	e.computeDueMetricReports(ctx, now.Add(10*time.Second), lastReports)
+
+	var report dmtf.MetricReports
+	json.Unmarshal([]byte(db["MetricReports"][metricReportsURI+"/Power"]), &report)
+	want := []dmtf.MetricValue{{
+		MetricID:       "PowerConsumedWatts",
+		MetricProperty: "/redfish/v1/Chassis/uuid1.1/Power#/PowerControl/0/PowerConsumedWatts",
+		MetricValue:    "215.5",
+		Timestamp:      "2026-10-01T10:00:00Z",
+	}}
+	if !reflect.DeepEqual(report.MetricValues, want) {
+		t.Errorf("MetricValues = %+v, want %+v", report.MetricValues, want)
+	}
+	if report.ReportSequence != "1" {
+		t.Errorf("ReportSequence = %v, the report is computed before its recurrence interval", report.ReportSequence)
+	}
+
+	e.computeDueMetricReports(ctx, now.Add(30*time.Second), lastReports)
+	json.Unmarshal([]byte(db["MetricReports"][metricReportsURI+"/Power"]), &report)
+	if report.ReportSequence != "2" {
+		t.Errorf("ReportSequence = %v, want 2", report.ReportSequence)
+	}
+
+	e.DeleteMetricReportDefinition(ctx, telemetryRequest(metricReportDefinitionsURI+"/Power", ""))
+	e.computeDueMetricReports(ctx, now.Add(time.Minute), lastReports)
+	if len(lastReports) != 0 {
+		t.Errorf("the deleted definition is not removed from the computed reports: %v", lastReports)
+	}
+}
+
+func TestGetOdimMetricReport(t *testing.T) {
+	db := newFakeTelemetryDB()
+	e := db.reportInterface(t)
+	ctx := context.Background()
+	e.CreateMetricReportDefinition(ctx, telemetryRequest(metricReportDefinitionsURI, `{"Id":"OnRequestPower","MetricReportDefinitionType":"OnRequest",`+
+		`"MetricProperties":["/redfish/v1/Chassis/uuid1.1/Power#/PowerControl/0/PowerConsumedWatts"]}`))
+	e.CreateMetricReportDefinition(ctx, telemetryRequest(metricReportDefinitionsURI, powerDefinition))
+
+	got := e.GetMetricReport(ctx, telemetryRequest(metricReportsURI+"/OnRequestPower", ""))
+	if got.StatusCode != http.StatusOK {
+		t.Fatalf("GetMetricReport() = %v, want %v", got.StatusCode, http.StatusOK)
+	}
+	if values, _ := got.Body.(map[string]interface{})["MetricValues"].([]interface{}); len(values) != 1 {
+		t.Errorf("the on request metric report is not computed: %v", got.Body)
+	}
+	if got := e.GetMetricReport(ctx, telemetryRequest(metricReportsURI+"/Power", "")); got.StatusCode != http.StatusNotFound {
+		t.Errorf("GetMetricReport() = %v, want %v for a report not computed yet", got.StatusCode, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,248 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	teleproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/telemetry"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-telemetry/tmodel"
+)
+
+const (
+	openMetricsURI         = "/redfish/v1/TelemetryService/Oem/Odim/Metrics"
+	openMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+	metricNamePrefix       = "odim_"
+	// minExportWindow is the shortest time window the metric values are exported from,
+	// the window is two collection intervals of the metric history when it is longer
+	minExportWindow = 10 * time.Minute
+)
+
+// exportedSeries is a series of the metric history with its Prometheus metric name and labels,
+// and its numeric samples of the export window
+type exportedSeries struct {
+	name    string
+	labels  []metricLabel // sorted by name
+	samples []exportedSample
+}
+
+type metricLabel struct {
+	name  string
+	value string
+}
+
+type exportedSample struct {
+	value     float64
+	timestamp int64 // unix time in seconds
+}
+
+// key identifies the series by its name and labels, as Prometheus does
+func (s exportedSeries) key() string {
+	var b strings.Builder
+	b.WriteString(s.name)
+	for _, label := range s.labels {
+		b.WriteString("," + label.name + "=" + label.value)
+	}
+	return b.String()
+}
+
+// GetOpenMetrics returns the latest numeric values of the metric history in the OpenMetrics
+// text format, for being scraped by Prometheus. The metrics are labeled with the metric report,
+// the metric, the system, chassis and sensor of the metric property and the aggregates of the server.
+func (e *ExternalInterface) GetOpenMetrics(ctx context.Context, req *teleproto.TelemetryRequest) response.RPC {
+	if conf := config.Data.TelemetryExportConf; conf == nil || !conf.OpenMetricsEnabled {
+		errMsg := "the OpenMetrics export of the metric values is not enabled"
+		l.LogWithFields(ctx).Warn(errMsg)
+		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"Metrics", openMetricsURI}, nil)
+	}
+	series, err := e.exportedMetricSeries(ctx, time.Now())
+	if err != nil {
+		errMsg := "unable to get the metric values: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+	return response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+		Header:        map[string]string{"Content-Type": openMetricsContentType},
+		Body:          openMetricsText(series),
+	}
+}
+
+// exportedMetricSeries reads the numeric samples of the export window of all the series of the metric history
+func (e *ExternalInterface) exportedMetricSeries(ctx context.Context, now time.Time) ([]exportedSeries, error) {
+	seriesList, err := e.DB.GetAllMetricSeries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	window := minExportWindow
+	if conf := config.Data.TelemetryHistoryConf; conf != nil && 2*time.Duration(conf.CollectionIntervalInMins)*time.Minute > window {
+		window = 2 * time.Duration(conf.CollectionIntervalInMins) * time.Minute
+	}
+	aggregates := e.serverAggregates(ctx)
+	var exported []exportedSeries
+	for _, series := range seriesList {
+		name := metricName(series)
+		if name == "" {
+			continue
+		}
+		samples, err := e.DB.GetMetricSamples(ctx, series, now.Add(-window).Unix(), now.Add(window).Unix())
+		if err != nil {
+			l.LogWithFields(ctx).Warn("unable to get the samples of the metric " + series.MetricID + " of " + series.MetricReport + ": " + err.Error())
+			continue
+		}
+		var numeric []exportedSample
+		for _, sample := range samples {
+			if value, err := strconv.ParseFloat(sample.Value, 64); err == nil {
+				numeric = append(numeric, exportedSample{value: value, timestamp: sample.Timestamp})
+			}
+		}
+		if len(numeric) == 0 {
+			continue
+		}
+		exported = append(exported, exportedSeries{name: name, labels: metricLabels(series, aggregates), samples: numeric})
+	}
+	sort.Slice(exported, func(i, j int) bool {
+		return exported[i].key() < exported[j].key()
+	})
+	return exported, nil
+}
+
+// serverAggregates maps the UUID of each server to the IDs of the aggregates it is a member of
+func (e *ExternalInterface) serverAggregates(ctx context.Context) map[string][]string {
+	keys, err := e.DB.GetAllKeysFromTable(ctx, "Aggregate", common.OnDisk)
+	if err != nil {
+		l.LogWithFields(ctx).Warn("unable to get the aggregates for the metric labels: " + err.Error())
+		return nil
+	}
+	sort.Strings(keys)
+	aggregates := make(map[string][]string)
+	for _, key := range keys {
+		aggregate, err := e.DB.GetAggregate(ctx, key)
+		if err != nil {
+			continue
+		}
+		for _, element := range aggregate.Elements {
+			if deviceUUID, _ := bmcResourceURI(element.OdataID); deviceUUID != "" && !containsString(aggregates[deviceUUID], resourceID(key)) {
+				aggregates[deviceUUID] = append(aggregates[deviceUUID], resourceID(key))
+			}
+		}
+	}
+	return aggregates
+}
+
+// metricName forms the Prometheus metric name of the series from its metric id, or from
+// the last property of its metric property when it has no metric id
+func metricName(series tmodel.MetricSeries) string {
+	id := series.MetricID
+	if id == "" {
+		_, pointer := splitMetricProperty(series.MetricProperty)
+		id = resourceID(pointer)
+	}
+	if id == "" {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(metricNamePrefix)
+	runes := []rune(id)
+	for i, r := range runes {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			// the camel case words are separated as in PowerConsumedWatts or CPUUtil
+			if unicode.IsUpper(r) && i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				(unicode.IsUpper(runes[i-1]) && i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
+
+// metricLabels forms the Prometheus labels of the series, sorted by name
+func metricLabels(series tmodel.MetricSeries, aggregates map[string][]string) []metricLabel {
+	labels := map[string]string{"metric_report": resourceID(series.MetricReport)}
+	if series.MetricID != "" {
+		labels["metric_id"] = series.MetricID
+	}
+	if series.MetricProperty != "" {
+		labels["metric_property"] = series.MetricProperty
+		uri, _ := splitMetricProperty(series.MetricProperty)
+		segments := strings.Split(uri, "/")
+		for i := 3; i+1 < len(segments); i++ {
+			switch {
+			case i == 3 && segments[i] == "Systems":
+				labels["system_id"] = segments[i+1]
+			case i == 3 && segments[i] == "Chassis":
+				labels["chassis_id"] = segments[i+1]
+			case segments[i] == "Sensors":
+				labels["sensor"] = segments[i+1]
+			}
+		}
+		if deviceUUID, _ := bmcResourceURI(uri); len(aggregates[deviceUUID]) > 0 {
+			labels["aggregates"] = strings.Join(aggregates[deviceUUID], ",")
+		}
+	}
+	var sorted []metricLabel
+	for name, value := range labels {
+		if value != "" {
+			sorted = append(sorted, metricLabel{name: name, value: value})
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].name < sorted[j].name
+	})
+	return sorted
+}
+
+// openMetricsText writes the latest sample of each series in the OpenMetrics text format,
+// the series must be sorted so that the series of a metric are written together
+func openMetricsText(series []exportedSeries) string {
+	var b strings.Builder
+	for i, s := range series {
+		if i == 0 || series[i-1].name != s.name {
+			b.WriteString("# TYPE " + s.name + " gauge\n")
+		}
+		b.WriteString(s.name)
+		if len(s.labels) > 0 {
+			b.WriteByte('{')
+			for j, label := range s.labels {
+				if j > 0 {
+					b.WriteByte(',')
+				}
+				b.WriteString(label.name + `="` + labelValueReplacer.Replace(label.value) + `"`)
+			}
+			b.WriteByte('}')
+		}
+		latest := s.samples[len(s.samples)-1]
+		b.WriteString(" " + strconv.FormatFloat(latest.value, 'g', -1, 64) + " " + strconv.FormatInt(latest.timestamp, 10) + "\n")
+	}
+	b.WriteString("# EOF\n")
+	return b.String()
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
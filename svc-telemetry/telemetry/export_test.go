@@ -0,0 +1,218 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	"github.com/ODIM-Project/ODIM/svc-telemetry/tmodel"
+	"github.com/golang/snappy"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// exportInterface keeps the metric history in memory with the server uuid in the aggregate a1
+func exportInterface(t *testing.T, history fakeHistoryDB) *ExternalInterface {
+	enableTelemetryHistory(t)
+	config.Data.TelemetryExportConf.OpenMetricsEnabled = true
+	e := history.interfaceWith(MockGetExternalInterface())
+	e.DB.GetAllKeysFromTable = func(ctx context.Context, table string, dbType common.DbType) ([]string, error) {
+		return []string{aggregateURI}, nil
+	}
+	e.DB.GetAggregate = func(ctx context.Context, uri string) (tmodel.Aggregate, *errors.Error) {
+		return tmodel.Aggregate{Elements: []tmodel.OdataID{{OdataID: "/redfish/v1/Systems/uuid.1"}}}, nil
+	}
+	return e
+}
+
+func TestGetOpenMetrics(t *testing.T) {
+	history := make(fakeHistoryDB)
+	e := exportInterface(t, history)
+	ctx := context.Background()
+	if err := e.IngestMetricReport(ctx, []byte(powerReport)); err != nil {
+		t.Fatalf("IngestMetricReport() = %v", err)
+	}
+
+	now := time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC)
+	series, err := e.exportedMetricSeries(ctx, now)
+	if err != nil {
+		t.Fatalf("exportedMetricSeries() = %v", err)
+	}
+	want := fmt.Sprintf(`# TYPE odim_power_consumed_watts gauge
+odim_power_consumed_watts{aggregates="a1",chassis_id="uuid.1",metric_id="PowerConsumedWatts",metric_property="/redfish/v1/Chassis/uuid.1/Power#/PowerControl/0/PowerConsumedWatts",metric_report="PowerMetrics"} 230 %d
+odim_power_consumed_watts{aggregates="a1",chassis_id="uuid.2",metric_id="PowerConsumedWatts",metric_property="/redfish/v1/Chassis/uuid.2/Power#/PowerControl/0/PowerConsumedWatts",metric_report="PowerMetrics"} 180 %d
+# EOF
+`, now.Unix(), now.Add(-5*time.Minute).Unix())
+	if got := openMetricsText(series); got != want {
+		t.Errorf("openMetricsText() = %v, want %v", got, want)
+	}
+
+	resp := e.GetOpenMetrics(ctx, telemetryRequest(openMetricsURI, ""))
+	if resp.StatusCode != http.StatusOK || resp.Header["Content-Type"] != openMetricsContentType {
+		t.Errorf("GetOpenMetrics() = %v %v, want the OpenMetrics text", resp.StatusCode, resp.Header)
+	}
+	config.Data.TelemetryExportConf.OpenMetricsEnabled = false
+	if resp := e.GetOpenMetrics(ctx, telemetryRequest(openMetricsURI, "")); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("GetOpenMetrics() = %v, want %v when the export is disabled", resp.StatusCode, http.StatusNotFound)
+	}
+}
+
+func TestMetricName(t *testing.T) {
+	tests := []struct {
+		series tmodel.MetricSeries
+		want   string
+	}{
+		{tmodel.MetricSeries{MetricID: "PowerConsumedWatts"}, "odim_power_consumed_watts"},
+		{tmodel.MetricSeries{MetricID: "CPUUtil"}, "odim_cpu_util"},
+		{tmodel.MetricSeries{MetricID: "Fan-1 Speed"}, "odim_fan_1_speed"},
+		{tmodel.MetricSeries{MetricProperty: "/redfish/v1/Chassis/uuid.1/Sensors/Temp1#/Reading"}, "odim_reading"},
+		{tmodel.MetricSeries{}, ""},
+	}
+	for _, tt := range tests {
+		if got := metricName(tt.series); got != tt.want {
+			t.Errorf("metricName(%+v) = %v, want %v", tt.series, got, tt.want)
+		}
+	}
+	labels := metricLabels(tmodel.MetricSeries{MetricReport: "/redfish/v1/TelemetryService/MetricReports/Thermal",
+		MetricProperty: "/redfish/v1/Chassis/uuid.1/Sensors/Temp1#/Reading"}, nil)
+	want := []metricLabel{{"chassis_id", "uuid.1"}, {"metric_property", "/redfish/v1/Chassis/uuid.1/Sensors/Temp1#/Reading"},
+		{"metric_report", "Thermal"}, {"sensor", "Temp1"}}
+	if !reflect.DeepEqual(labels, want) {
+		t.Errorf("metricLabels() = %v, want %v", labels, want)
+	}
+}
+
+// remoteWriteSample is a sample of a WriteRequest with the name of its series
+type remoteWriteSample struct {
+	name      string
+	value     float64
+	timestamp int64
+}
+
+// decodeWriteRequest decodes the samples of a WriteRequest of the Prometheus remote write protocol
+func decodeWriteRequest(t *testing.T, data []byte) []remoteWriteSample {
+	var samples []remoteWriteSample
+	fields := func(data []byte, field func(protowire.Number, protowire.Type, []byte) int) {
+		for len(data) > 0 {
+			number, typ, n := protowire.ConsumeTag(data)
+			if n < 0 {
+				t.Fatalf("invalid write request")
+			}
+			data = data[n:]
+			n = field(number, typ, data)
+			if n < 0 {
+				t.Fatalf("invalid write request")
+			}
+			data = data[n:]
+		}
+	}
+	fields(data, func(_ protowire.Number, _ protowire.Type, data []byte) int {
+		timeSeries, n := protowire.ConsumeBytes(data)
+		var name string
+		fields(timeSeries, func(number protowire.Number, _ protowire.Type, data []byte) int {
+			message, n := protowire.ConsumeBytes(data)
+			var values [2]string
+			var sample remoteWriteSample
+			fields(message, func(field protowire.Number, typ protowire.Type, data []byte) int {
+				switch typ {
+				case protowire.BytesType:
+					value, n := protowire.ConsumeString(data)
+					values[field-1] = value
+					return n
+				case protowire.Fixed64Type:
+					value, n := protowire.ConsumeFixed64(data)
+					sample.value = math.Float64frombits(value)
+					return n
+				}
+				value, n := protowire.ConsumeVarint(data)
+				sample.timestamp = int64(value)
+				return n
+			})
+			if number == 1 && values[0] == "__name__" {
+				name = values[1]
+			}
+			if number == 2 {
+				sample.name = name
+				samples = append(samples, sample)
+			}
+			return n
+		})
+		return n
+	})
+	return samples
+}
+
+func TestRemoteWriteMetrics(t *testing.T) {
+	history := make(fakeHistoryDB)
+	e := exportInterface(t, history)
+	ctx := context.Background()
+	e.IngestMetricReport(ctx, []byte(powerReport))
+
+	var pushed [][]remoteWriteSample
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Encoding") != "snappy" || r.Header.Get("X-Prometheus-Remote-Write-Version") != "0.1.0" {
+			t.Errorf("invalid remote write headers %v", r.Header)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		data, err := snappy.Decode(nil, body)
+		if err != nil {
+			t.Errorf("the remote write request is not snappy compressed: %v", err)
+		}
+		pushed = append(pushed, decodeWriteRequest(t, data))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	config.Data.TelemetryExportConf.RemoteWriteURL = server.URL
+
+	now := time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC)
+	lastPushed := make(map[string]int64)
+	if err := e.RemoteWriteMetrics(ctx, now, lastPushed); err != nil {
+		t.Fatalf("RemoteWriteMetrics() = %v", err)
+	}
+	want := []remoteWriteSample{
+		{"odim_power_consumed_watts", 210, now.Add(-5*time.Minute).Unix() * 1000},
+		{"odim_power_consumed_watts", 230, now.Unix() * 1000},
+		{"odim_power_consumed_watts", 180, now.Add(-5*time.Minute).Unix() * 1000},
+	}
+	if len(pushed) != 1 || !reflect.DeepEqual(pushed[0], want) {
+		t.Fatalf("pushed samples = %v, want %v", pushed, want)
+	}
+
+	e.RemoteWriteMetrics(ctx, now.Add(time.Minute), lastPushed)
+	if len(pushed) != 1 {
+		t.Errorf("the samples already pushed are pushed again: %v", pushed[1:])
+	}
+	e.IngestMetricReport(ctx, []byte(strings.Replace(powerReport, "2026-10-01T10:00:00Z", "2026-10-01T10:05:00Z", 1)))
+	e.RemoteWriteMetrics(ctx, now.Add(5*time.Minute), lastPushed)
+	if len(pushed) != 2 || !reflect.DeepEqual(pushed[1], []remoteWriteSample{{"odim_power_consumed_watts", 230, now.Add(5*time.Minute).Unix() * 1000}}) {
+		t.Errorf("pushed samples = %v, want the new sample", pushed[1:])
+	}
+
+	server.Close()
+	if err := e.RemoteWriteMetrics(ctx, now.Add(10*time.Minute), map[string]int64{}); err == nil {
+		t.Errorf("RemoteWriteMetrics() succeeded without a remote write server")
+	}
+}
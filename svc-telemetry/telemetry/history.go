@@ -0,0 +1,386 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	teleproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/telemetry"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-telemetry/tcommon"
+	tlresp "github.com/ODIM-Project/ODIM/svc-telemetry/tlresponse"
+	"github.com/ODIM-Project/ODIM/svc-telemetry/tmodel"
+	"github.com/google/uuid"
+)
+
+const (
+	// MetricHistoryActionID action id for logging the collection and maintenance of the metric history
+	MetricHistoryActionID = "247"
+	// MetricHistoryActionName action name for logging the collection and maintenance of the metric history
+	MetricHistoryActionName = "MetricHistory"
+
+	metricHistoryURI = "/redfish/v1/TelemetryService/Oem/Odim/MetricHistory"
+	metricReportsURI = "/redfish/v1/TelemetryService/MetricReports"
+	// defaultHistoryWindow is the time window queried when StartTime is not given
+	defaultHistoryWindow = 24 * time.Hour
+)
+
+var podName = os.Getenv("POD_NAME")
+
+// PerformMetricHistoryCollection reads the metric reports from the servers into the
+// metric history at the configured interval, and removes the samples older than the
+// retention period and downsamples the samples older than the raw retention period.
+// The configuration is read every minute, so that the changes done to it take effect
+// without restarting the service.
+func (e *ExternalInterface) PerformMetricHistoryCollection() {
+	ctx := tcommon.CreateContext(uuid.New().String(), MetricHistoryActionID, MetricHistoryActionName, "1", common.TelemetryService, podName)
+	l.LogWithFields(ctx).Info("metric history collection routine started")
+	var lastCollection, lastMaintenance time.Time
+	for {
+		if conf := config.Data.TelemetryHistoryConf; conf != nil && conf.Enabled {
+			now := time.Now()
+			if now.Sub(lastCollection) >= time.Duration(conf.CollectionIntervalInMins)*time.Minute {
+				lastCollection = now
+				e.CollectMetricReports(ctx)
+			}
+			if now.Sub(lastMaintenance) >= time.Duration(conf.DownsampleIntervalInMins)*time.Minute {
+				lastMaintenance = now
+				e.MaintainMetricHistory(ctx, now)
+			}
+		}
+		time.Sleep(time.Minute)
+	}
+}
+
+// CollectMetricReports reads all the metric reports of the MetricReports collection
+// from the servers and saves their metric values into the metric history
+func (e *ExternalInterface) CollectMetricReports(ctx context.Context) {
+	data, err := e.DB.GetResource(ctx, "MetricReportsCollection", metricReportsURI, common.InMemory)
+	if err != nil {
+		l.LogWithFields(ctx).Warn("unable to get the metric reports for the metric history: " + err.Error())
+		return
+	}
+	var collection tlresp.Collection
+	if jerr := json.Unmarshal([]byte(data), &collection); jerr != nil {
+		l.LogWithFields(ctx).Error("unable to read the metric report collection: " + jerr.Error())
+		return
+	}
+	for _, member := range collection.Members {
+		// the reports of the definitions created through ODIM are saved into the history when they are computed
+		if _, ok := e.getOdimMetricReportDefinition(ctx, metricReportDefinitionsURI+"/"+resourceID(member.Oid)); ok {
+			continue
+		}
+		report, ferr := tcommon.GetResourceInfoFromDevice(ctx, tcommon.ResourceInfoRequest{
+			URL:                 member.Oid,
+			ContactClient:       e.External.ContactClient,
+			DevicePassword:      e.External.DevicePassword,
+			GetPluginStatus:     e.External.GetPluginStatus,
+			GetAllKeysFromTable: e.DB.GetAllKeysFromTable,
+			GetPluginData:       e.External.GetPluginData,
+			GetResource:         e.DB.GetResource,
+			GenericSave:         e.External.GenericSave,
+		})
+		if ferr != nil {
+			l.LogWithFields(ctx).Warn("unable to get the metric report " + member.Oid + ": " + ferr.Error())
+			continue
+		}
+		if ierr := e.IngestMetricReport(ctx, report); ierr != nil {
+			l.LogWithFields(ctx).Error("unable to save the metric report " + member.Oid + " into the metric history: " + ierr.Error())
+		}
+	}
+}
+
+// IngestMetricReport saves the metric values of a metric report into the metric history.
+// The metric reports of the events are accepted as sent by the plugins, as a JSON string.
+// Nothing is saved when the metric history is disabled.
+func (e *ExternalInterface) IngestMetricReport(ctx context.Context, data []byte) error {
+	if conf := config.Data.TelemetryHistoryConf; conf == nil || !conf.Enabled {
+		return nil
+	}
+	var report dmtf.MetricReports
+	if err := json.Unmarshal(data, &report); err != nil {
+		var encoded string
+		if json.Unmarshal(data, &encoded) != nil {
+			return fmt.Errorf("invalid metric report: %v", err)
+		}
+		if err = json.Unmarshal([]byte(encoded), &report); err != nil {
+			return fmt.Errorf("invalid metric report: %v", err)
+		}
+	}
+	seriesSamples := metricReportSamples(report, time.Now())
+	for series, samples := range seriesSamples {
+		if err := e.DB.AddMetricSamples(ctx, series, samples); err != nil {
+			return fmt.Errorf("unable to save the samples of the metric %s of %s: %v", series.MetricID, series.MetricReport, err.Error())
+		}
+	}
+	l.LogWithFields(ctx).Debugf("saved the values of %d metrics of the metric report %s into the metric history", len(seriesSamples), report.ODataID)
+	return nil
+}
+
+// metricReportSamples groups the metric values of a metric report by their series. A metric
+// value without a timestamp gets the timestamp of the report, or now when the report has none.
+func metricReportSamples(report dmtf.MetricReports, now time.Time) map[tmodel.MetricSeries][]tmodel.MetricSample {
+	reportTime := parseMetricTimestamp(report.Timestamp, now)
+	seriesSamples := make(map[tmodel.MetricSeries][]tmodel.MetricSample)
+	for _, value := range report.MetricValues {
+		if value.MetricID == "" && value.MetricProperty == "" {
+			continue
+		}
+		series := tmodel.MetricSeries{
+			MetricReport:   report.ODataID,
+			MetricID:       value.MetricID,
+			MetricProperty: value.MetricProperty,
+		}
+		seriesSamples[series] = append(seriesSamples[series], tmodel.MetricSample{
+			Timestamp: parseMetricTimestamp(value.Timestamp, reportTime).Unix(),
+			Value:     value.MetricValue,
+		})
+	}
+	return seriesSamples
+}
+
+func parseMetricTimestamp(timestamp string, defaultTime time.Time) time.Time {
+	if parsed, err := time.Parse(time.RFC3339, timestamp); err == nil {
+		return parsed
+	}
+	return defaultTime
+}
+
+// MaintainMetricHistory removes the samples older than the retention period from the
+// metric history and downsamples the samples older than the raw retention period, the
+// samples of each downsampling interval being replaced by their aggregate
+func (e *ExternalInterface) MaintainMetricHistory(ctx context.Context, now time.Time) {
+	conf := config.Data.TelemetryHistoryConf
+	seriesList, err := e.DB.GetAllMetricSeries(ctx)
+	if err != nil {
+		l.LogWithFields(ctx).Error("unable to get the metric series for maintaining the metric history: " + err.Error())
+		return
+	}
+	retentionEnd := now.Add(-time.Duration(conf.RetentionInDays)*24*time.Hour).Unix() - 1
+	interval := int64(conf.DownsampleIntervalInMins) * 60
+	rawStart := now.Add(-time.Duration(conf.RawRetentionInHours) * time.Hour).Unix()
+	rawStart -= rawStart % interval
+	for _, series := range seriesList {
+		if err := e.DB.RemoveMetricSamples(ctx, series, math.MinInt64, retentionEnd); err != nil {
+			l.LogWithFields(ctx).Error("unable to remove the expired samples of the metric " + series.MetricID + ": " + err.Error())
+			continue
+		}
+		samples, err := e.DB.GetMetricSamples(ctx, series, math.MinInt64, math.MaxInt64)
+		if err != nil {
+			l.LogWithFields(ctx).Error("unable to get the samples of the metric " + series.MetricID + ": " + err.Error())
+			continue
+		}
+		if len(samples) == 0 {
+			if err := e.DB.RemoveMetricSeries(ctx, series); err != nil {
+				l.LogWithFields(ctx).Error("unable to remove the metric series " + series.MetricID + ": " + err.Error())
+			}
+			continue
+		}
+		for start, aggregate := range downsample(samples, rawStart, interval) {
+			if err := e.DB.RemoveMetricSamples(ctx, series, start, start+interval-1); err != nil {
+				l.LogWithFields(ctx).Error("unable to downsample the samples of the metric " + series.MetricID + ": " + err.Error())
+				break
+			}
+			if err := e.DB.AddMetricSamples(ctx, series, []tmodel.MetricSample{aggregate}); err != nil {
+				l.LogWithFields(ctx).Error("unable to downsample the samples of the metric " + series.MetricID + ": " + err.Error())
+				break
+			}
+		}
+	}
+}
+
+// downsample aggregates the samples taken before rawStart by intervals of the given
+// number of seconds, and returns the aggregates of the intervals to be replaced, by the
+// start of the interval. An interval having only its aggregate is left as is.
+// Numeric samples are aggregated to their average with their minimum and maximum, other
+// samples to the last value of the interval.
+func downsample(samples []tmodel.MetricSample, rawStart, interval int64) map[int64]tmodel.MetricSample {
+	buckets := make(map[int64][]tmodel.MetricSample)
+	for _, sample := range samples {
+		if sample.Timestamp >= rawStart {
+			continue
+		}
+		start := sample.Timestamp - sample.Timestamp%interval
+		buckets[start] = append(buckets[start], sample)
+	}
+	aggregates := make(map[int64]tmodel.MetricSample)
+	for start, bucket := range buckets {
+		if len(bucket) == 1 && bucket[0].Count > 0 && bucket[0].Timestamp == start {
+			continue
+		}
+		aggregates[start] = aggregateSamples(start, bucket)
+	}
+	return aggregates
+}
+
+func aggregateSamples(start int64, samples []tmodel.MetricSample) tmodel.MetricSample {
+	sort.SliceStable(samples, func(i, j int) bool { return samples[i].Timestamp < samples[j].Timestamp })
+	aggregate := tmodel.MetricSample{Timestamp: start}
+	var sum, min, max float64
+	numeric := true
+	for i, sample := range samples {
+		count := sample.Count
+		if count == 0 {
+			count = 1
+		}
+		aggregate.Count += count
+		aggregate.Value = sample.Value
+		value, err := strconv.ParseFloat(sample.Value, 64)
+		if err != nil {
+			numeric = false
+			continue
+		}
+		sampleMin, sampleMax := value, value
+		if sample.Min != nil && sample.Max != nil {
+			sampleMin, sampleMax = *sample.Min, *sample.Max
+		}
+		if i == 0 || sampleMin < min {
+			min = sampleMin
+		}
+		if i == 0 || sampleMax > max {
+			max = sampleMax
+		}
+		sum += value * float64(count)
+	}
+	if numeric {
+		aggregate.Value = strconv.FormatFloat(sum/float64(aggregate.Count), 'f', -1, 64)
+		aggregate.Min, aggregate.Max = &min, &max
+	}
+	return aggregate
+}
+
+// GetMetricHistory returns the metric values kept in the metric history for the series
+// of a resource or a metric in a time window. The query parameters are Resource, matched
+// against the MetricProperty and the MetricReport of the series, MetricId, StartTime and
+// EndTime. At least one of Resource and MetricId is needed, EndTime defaults to now and
+// StartTime to a day before EndTime.
+func (e *ExternalInterface) GetMetricHistory(ctx context.Context, req *teleproto.TelemetryRequest) response.RPC {
+	requestURL, err := url.Parse(req.URL)
+	if err != nil {
+		return common.GeneralError(http.StatusBadRequest, response.InvalidURI, err.Error(), []interface{}{req.URL}, nil)
+	}
+	query := requestURL.Query()
+	resource, metricID := query.Get("Resource"), query.Get("MetricId")
+	if resource == "" && metricID == "" {
+		errMsg := "either Resource or MetricId query parameter is required"
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"Resource"}, nil)
+	}
+	endTime := time.Now().UTC()
+	if value := query.Get("EndTime"); value != "" {
+		if endTime, err = time.Parse(time.RFC3339, value); err != nil {
+			l.LogWithFields(ctx).Error("invalid EndTime: " + err.Error())
+			return common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, err.Error(), []interface{}{value, "EndTime"}, nil)
+		}
+	}
+	startTime := endTime.Add(-defaultHistoryWindow)
+	if value := query.Get("StartTime"); value != "" {
+		if startTime, err = time.Parse(time.RFC3339, value); err != nil {
+			l.LogWithFields(ctx).Error("invalid StartTime: " + err.Error())
+			return common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, err.Error(), []interface{}{value, "StartTime"}, nil)
+		}
+	}
+	if startTime.After(endTime) {
+		errMsg := " StartTime is later than EndTime"
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.QueryCombinationInvalid, errMsg, nil, nil)
+	}
+
+	seriesList, gerr := e.DB.GetAllMetricSeries(ctx)
+	if gerr != nil {
+		l.LogWithFields(ctx).Error("unable to get the metric series: " + gerr.Error())
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, gerr.Error(), nil, nil)
+	}
+	sort.Slice(seriesList, func(i, j int) bool {
+		if seriesList[i].MetricReport != seriesList[j].MetricReport {
+			return seriesList[i].MetricReport < seriesList[j].MetricReport
+		}
+		if seriesList[i].MetricID != seriesList[j].MetricID {
+			return seriesList[i].MetricID < seriesList[j].MetricID
+		}
+		return seriesList[i].MetricProperty < seriesList[j].MetricProperty
+	})
+	history := tlresp.MetricHistory{
+		OdataID:   requestURL.RequestURI(),
+		OdataType: "#OdimMetricHistory.v1_0_0.OdimMetricHistory",
+		ID:        "MetricHistory",
+		Name:      "Metric History",
+		StartTime: startTime.UTC().Format(time.RFC3339),
+		EndTime:   endTime.UTC().Format(time.RFC3339),
+		Series:    []tlresp.MetricHistorySeries{},
+	}
+	for _, series := range seriesList {
+		if !matchesMetricSeries(series, resource, metricID) {
+			continue
+		}
+		samples, gerr := e.DB.GetMetricSamples(ctx, series, startTime.Unix(), endTime.Unix())
+		if gerr != nil {
+			l.LogWithFields(ctx).Error("unable to get the samples of the metric " + series.MetricID + ": " + gerr.Error())
+			return common.GeneralError(http.StatusInternalServerError, response.InternalError, gerr.Error(), nil, nil)
+		}
+		if len(samples) == 0 {
+			continue
+		}
+		historySeries := tlresp.MetricHistorySeries{
+			MetricReport:   series.MetricReport,
+			MetricID:       series.MetricID,
+			MetricProperty: series.MetricProperty,
+			Samples:        make([]tlresp.MetricHistorySample, 0, len(samples)),
+		}
+		for _, sample := range samples {
+			historySeries.Samples = append(historySeries.Samples, tlresp.MetricHistorySample{
+				Timestamp: time.Unix(sample.Timestamp, 0).UTC().Format(time.RFC3339),
+				Value:     sample.Value,
+				Min:       sample.Min,
+				Max:       sample.Max,
+				Count:     sample.Count,
+			})
+		}
+		history.Series = append(history.Series, historySeries)
+	}
+	return response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+		Body:          history,
+	}
+}
+
+// matchesMetricSeries checks whether the series is of the resource and of the metric queried,
+// an empty resource or metric id matching all the series
+func matchesMetricSeries(series tmodel.MetricSeries, resource, metricID string) bool {
+	if metricID != "" && series.MetricID != metricID {
+		return false
+	}
+	if resource == "" {
+		return true
+	}
+	resource = strings.TrimSuffix(resource, "/")
+	return series.MetricReport == resource || series.MetricProperty == resource ||
+		strings.HasPrefix(series.MetricProperty, resource+"/") || strings.HasPrefix(series.MetricProperty, resource+"#")
+}
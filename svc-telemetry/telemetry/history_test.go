@@ -0,0 +1,263 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	teleproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/telemetry"
+	tlresp "github.com/ODIM-Project/ODIM/svc-telemetry/tlresponse"
+	"github.com/ODIM-Project/ODIM/svc-telemetry/tmodel"
+)
+
+// fakeHistoryDB keeps the metric history in memory, the samples of a series
+// being unique like the members of the sorted sets of the database
+type fakeHistoryDB map[tmodel.MetricSeries]map[string]tmodel.MetricSample
+
+func (f fakeHistoryDB) interfaceWith(e *ExternalInterface) *ExternalInterface {
+	e.DB.AddMetricSamples = func(ctx context.Context, series tmodel.MetricSeries, samples []tmodel.MetricSample) *errors.Error {
+		if f[series] == nil {
+			f[series] = make(map[string]tmodel.MetricSample)
+		}
+		for _, sample := range samples {
+			data, _ := json.Marshal(sample)
+			f[series][string(data)] = sample
+		}
+		return nil
+	}
+	e.DB.GetMetricSamples = func(ctx context.Context, series tmodel.MetricSeries, start, end int64) ([]tmodel.MetricSample, *errors.Error) {
+		return f.samples(series, start, end), nil
+	}
+	e.DB.RemoveMetricSamples = func(ctx context.Context, series tmodel.MetricSeries, start, end int64) *errors.Error {
+		for member, sample := range f[series] {
+			if sample.Timestamp >= start && sample.Timestamp <= end {
+				delete(f[series], member)
+			}
+		}
+		return nil
+	}
+	e.DB.GetAllMetricSeries = func(ctx context.Context) ([]tmodel.MetricSeries, *errors.Error) {
+		var seriesList []tmodel.MetricSeries
+		for series := range f {
+			seriesList = append(seriesList, series)
+		}
+		return seriesList, nil
+	}
+	e.DB.RemoveMetricSeries = func(ctx context.Context, series tmodel.MetricSeries) *errors.Error {
+		delete(f, series)
+		return nil
+	}
+	return e
+}
+
+func (f fakeHistoryDB) samples(series tmodel.MetricSeries, start, end int64) []tmodel.MetricSample {
+	samples := []tmodel.MetricSample{}
+	for _, sample := range f[series] {
+		if sample.Timestamp >= start && sample.Timestamp <= end {
+			samples = append(samples, sample)
+		}
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i].Timestamp < samples[j].Timestamp })
+	return samples
+}
+
+func enableTelemetryHistory(t *testing.T) {
+	config.SetUpMockConfig(t)
+	config.Data.TelemetryHistoryConf.Enabled = true
+}
+
+func floatPtr(value float64) *float64 {
+	return &value
+}
+
+const powerReport = `{"@odata.id":"/redfish/v1/TelemetryService/MetricReports/PowerMetrics","@odata.type":"#MetricReport.v1_4_2.MetricReport","Id":"PowerMetrics","Name":"Power Metrics","Timestamp":"2026-10-01T10:00:00Z","MetricValues":[` +
+	`{"MetricId":"PowerConsumedWatts","MetricProperty":"/redfish/v1/Chassis/uuid.1/Power#/PowerControl/0/PowerConsumedWatts","MetricValue":"210","Timestamp":"2026-10-01T09:55:00Z"},` +
+	`{"MetricId":"PowerConsumedWatts","MetricProperty":"/redfish/v1/Chassis/uuid.1/Power#/PowerControl/0/PowerConsumedWatts","MetricValue":"230"},` +
+	`{"MetricId":"PowerConsumedWatts","MetricProperty":"/redfish/v1/Chassis/uuid.2/Power#/PowerControl/0/PowerConsumedWatts","MetricValue":"180","Timestamp":"2026-10-01T09:55:00Z"}]}`
+
+var (
+	chassis1Power = tmodel.MetricSeries{
+		MetricReport:   "/redfish/v1/TelemetryService/MetricReports/PowerMetrics",
+		MetricID:       "PowerConsumedWatts",
+		MetricProperty: "/redfish/v1/Chassis/uuid.1/Power#/PowerControl/0/PowerConsumedWatts",
+	}
+	chassis2Power = tmodel.MetricSeries{
+		MetricReport:   "/redfish/v1/TelemetryService/MetricReports/PowerMetrics",
+		MetricID:       "PowerConsumedWatts",
+		MetricProperty: "/redfish/v1/Chassis/uuid.2/Power#/PowerControl/0/PowerConsumedWatts",
+	}
+)
+
+func TestIngestMetricReport(t *testing.T) {
+	enableTelemetryHistory(t)
+	db := fakeHistoryDB{}
+	e := db.interfaceWith(MockGetExternalInterface())
+
+	// the metric reports of the events are JSON strings, ingesting both forms twice keeps the samples unique
+	eventReport, _ := json.Marshal(powerReport)
+	for _, data := range [][]byte{[]byte(powerReport), eventReport} {
+		if err := e.IngestMetricReport(context.TODO(), data); err != nil {
+			t.Fatalf("IngestMetricReport() error = %v", err)
+		}
+	}
+	want := []tmodel.MetricSample{
+		{Timestamp: time.Date(2026, 10, 1, 9, 55, 0, 0, time.UTC).Unix(), Value: "210"},
+		{Timestamp: time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC).Unix(), Value: "230"},
+	}
+	if got := db.samples(chassis1Power, 0, time.Now().Unix()); !reflect.DeepEqual(got, want) {
+		t.Errorf("IngestMetricReport() samples = %v, want %v", got, want)
+	}
+	if got := db.samples(chassis2Power, 0, time.Now().Unix()); len(got) != 1 {
+		t.Errorf("IngestMetricReport() samples of the second chassis = %v, want 1 sample", got)
+	}
+
+	if err := e.IngestMetricReport(context.TODO(), []byte("invalid")); err == nil {
+		t.Errorf("IngestMetricReport() of an invalid report should fail")
+	}
+
+	config.Data.TelemetryHistoryConf.Enabled = false
+	db = fakeHistoryDB{}
+	e = db.interfaceWith(MockGetExternalInterface())
+	if err := e.IngestMetricReport(context.TODO(), []byte(powerReport)); err != nil || len(db) != 0 {
+		t.Errorf("IngestMetricReport() with the history disabled = %v, saved %d series", err, len(db))
+	}
+}
+
+func TestMaintainMetricHistory(t *testing.T) {
+	enableTelemetryHistory(t)
+	conf := config.Data.TelemetryHistoryConf
+	conf.RetentionInDays, conf.RawRetentionInHours, conf.DownsampleIntervalInMins = 30, 24, 15
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-48 * time.Hour)
+	db := fakeHistoryDB{}
+	e := db.interfaceWith(MockGetExternalInterface())
+	state := tmodel.MetricSeries{MetricReport: "/redfish/v1/TelemetryService/MetricReports/State", MetricID: "PowerState"}
+	expired := tmodel.MetricSeries{MetricReport: "/redfish/v1/TelemetryService/MetricReports/Old", MetricID: "Temperature"}
+	e.DB.AddMetricSamples(context.TODO(), chassis1Power, []tmodel.MetricSample{
+		{Timestamp: now.Add(-40 * 24 * time.Hour).Unix(), Value: "100"},
+		{Timestamp: old.Unix(), Value: "200"},
+		{Timestamp: old.Add(5 * time.Minute).Unix(), Value: "300"},
+		{Timestamp: old.Add(10 * time.Minute).Unix(), Value: "250"},
+		{Timestamp: old.Add(15 * time.Minute).Unix(), Value: "220"},
+		{Timestamp: now.Add(-time.Hour).Unix(), Value: "240"},
+	})
+	e.DB.AddMetricSamples(context.TODO(), state, []tmodel.MetricSample{
+		{Timestamp: old.Unix(), Value: "On"},
+		{Timestamp: old.Add(5 * time.Minute).Unix(), Value: "Off"},
+	})
+	e.DB.AddMetricSamples(context.TODO(), expired, []tmodel.MetricSample{
+		{Timestamp: now.Add(-31 * 24 * time.Hour).Unix(), Value: "40"},
+	})
+
+	e.MaintainMetricHistory(context.TODO(), now)
+	// downsampling the samples again leaves them as they are
+	e.MaintainMetricHistory(context.TODO(), now)
+
+	wantPower := []tmodel.MetricSample{
+		{Timestamp: old.Unix(), Value: "250", Min: floatPtr(200), Max: floatPtr(300), Count: 3},
+		{Timestamp: old.Add(15 * time.Minute).Unix(), Value: "220", Min: floatPtr(220), Max: floatPtr(220), Count: 1},
+		{Timestamp: now.Add(-time.Hour).Unix(), Value: "240"},
+	}
+	if got := db.samples(chassis1Power, 0, now.Unix()); !reflect.DeepEqual(got, wantPower) {
+		t.Errorf("MaintainMetricHistory() power samples = %+v, want %+v", got, wantPower)
+	}
+	wantState := []tmodel.MetricSample{{Timestamp: old.Unix(), Value: "Off", Count: 2}}
+	if got := db.samples(state, 0, now.Unix()); !reflect.DeepEqual(got, wantState) {
+		t.Errorf("MaintainMetricHistory() state samples = %+v, want %+v", got, wantState)
+	}
+	if _, exist := db[expired]; exist {
+		t.Errorf("MaintainMetricHistory() should remove the series without samples")
+	}
+}
+
+func TestGetMetricHistory(t *testing.T) {
+	enableTelemetryHistory(t)
+	db := fakeHistoryDB{}
+	e := db.interfaceWith(MockGetExternalInterface())
+	if err := e.IngestMetricReport(context.TODO(), []byte(powerReport)); err != nil {
+		t.Fatalf("IngestMetricReport() error = %v", err)
+	}
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int32
+		wantSeries []string
+		wantCount  int
+	}{
+		{
+			name:       "samples of a chassis",
+			url:        metricHistoryURI + "?Resource=/redfish/v1/Chassis/uuid.1&StartTime=2026-10-01T09:00:00Z&EndTime=2026-10-01T11:00:00Z",
+			wantStatus: http.StatusOK,
+			wantSeries: []string{chassis1Power.MetricProperty},
+			wantCount:  2,
+		},
+		{
+			name:       "samples of a metric in a time window",
+			url:        metricHistoryURI + "?MetricId=PowerConsumedWatts&StartTime=2026-10-01T09:00:00Z&EndTime=2026-10-01T09:59:00Z",
+			wantStatus: http.StatusOK,
+			wantSeries: []string{chassis1Power.MetricProperty, chassis2Power.MetricProperty},
+			wantCount:  2,
+		},
+		{
+			name:       "no samples in the default time window",
+			url:        metricHistoryURI + "?Resource=/redfish/v1/TelemetryService/MetricReports/PowerMetrics",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "resource and metric id missing",
+			url:        metricHistoryURI,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid start time",
+			url:        metricHistoryURI + "?MetricId=PowerConsumedWatts&StartTime=yesterday",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "start time later than end time",
+			url:        metricHistoryURI + "?MetricId=PowerConsumedWatts&StartTime=2026-10-02T00:00:00Z&EndTime=2026-10-01T00:00:00Z",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := e.GetMetricHistory(context.TODO(), &teleproto.TelemetryRequest{URL: tt.url})
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("GetMetricHistory() status = %v, want %v", resp.StatusCode, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			history := resp.Body.(tlresp.MetricHistory)
+			var gotSeries []string
+			var gotCount int
+			for _, series := range history.Series {
+				gotSeries = append(gotSeries, series.MetricProperty)
+				gotCount += len(series.Samples)
+			}
+			if !reflect.DeepEqual(gotSeries, tt.wantSeries) || gotCount != tt.wantCount {
+				t.Errorf("GetMetricHistory() series = %v with %d samples, want %v with %d samples", gotSeries, gotCount, tt.wantSeries, tt.wantCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/svc-telemetry/tcommon"
+	"github.com/golang/snappy"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	// RemoteWriteActionID action id for logging the push of the metric values to the Prometheus remote write URL
+	RemoteWriteActionID = "256"
+	// RemoteWriteActionName action name for logging the push of the metric values to the Prometheus remote write URL
+	RemoteWriteActionName = "RemoteWriteMetrics"
+)
+
+// PerformMetricRemoteWrite pushes the new metric values of the metric history to the
+// Prometheus remote write URL at the configured interval. The configuration is read
+// on each push, so that the changes done to it take effect without restarting the service.
+func (e *ExternalInterface) PerformMetricRemoteWrite() {
+	ctx := tcommon.CreateContext(uuid.New().String(), RemoteWriteActionID, RemoteWriteActionName, "1", common.TelemetryService, podName)
+	l.LogWithFields(ctx).Info("metric remote write routine started")
+	lastPushed := make(map[string]int64)
+	for {
+		interval := time.Minute
+		if conf := config.Data.TelemetryExportConf; conf != nil && conf.RemoteWriteURL != "" {
+			if err := e.RemoteWriteMetrics(ctx, time.Now(), lastPushed); err != nil {
+				l.LogWithFields(ctx).Error("unable to push the metric values to " + conf.RemoteWriteURL + ": " + err.Error())
+			}
+			interval = time.Duration(conf.RemoteWriteIntervalInSecs) * time.Second
+		}
+		time.Sleep(interval)
+	}
+}
+
+// RemoteWriteMetrics pushes the numeric samples of the metric history which are newer than
+// the last samples pushed of their series, lastPushed is updated once the push is accepted
+func (e *ExternalInterface) RemoteWriteMetrics(ctx context.Context, now time.Time, lastPushed map[string]int64) error {
+	series, err := e.exportedMetricSeries(ctx, now)
+	if err != nil {
+		return err
+	}
+	exported := make(map[string]bool)
+	var newSeries []exportedSeries
+	for _, s := range series {
+		key := s.key()
+		exported[key] = true
+		var samples []exportedSample
+		for _, sample := range s.samples {
+			if sample.timestamp > lastPushed[key] {
+				samples = append(samples, sample)
+			}
+		}
+		if len(samples) > 0 {
+			s.samples = samples
+			newSeries = append(newSeries, s)
+		}
+	}
+	for key := range lastPushed {
+		if !exported[key] {
+			delete(lastPushed, key)
+		}
+	}
+	if len(newSeries) == 0 {
+		return nil
+	}
+	if err := pushRemoteWrite(ctx, config.Data.TelemetryExportConf, snappy.Encode(nil, encodeWriteRequest(newSeries))); err != nil {
+		return err
+	}
+	for _, s := range newSeries {
+		lastPushed[s.key()] = s.samples[len(s.samples)-1].timestamp
+	}
+	l.LogWithFields(ctx).Debugf("pushed the new values of %d metric series to the remote write URL", len(newSeries))
+	return nil
+}
+
+// encodeWriteRequest encodes the series as the WriteRequest protobuf message of the
+// Prometheus remote write protocol, the metric name is the __name__ label of a series
+func encodeWriteRequest(series []exportedSeries) []byte {
+	var request []byte
+	for _, s := range series {
+		var timeSeries []byte
+		for _, label := range append([]metricLabel{{name: "__name__", value: s.name}}, s.labels...) {
+			var labelField []byte
+			labelField = protowire.AppendTag(labelField, 1, protowire.BytesType)
+			labelField = protowire.AppendString(labelField, label.name)
+			labelField = protowire.AppendTag(labelField, 2, protowire.BytesType)
+			labelField = protowire.AppendString(labelField, label.value)
+			timeSeries = protowire.AppendTag(timeSeries, 1, protowire.BytesType)
+			timeSeries = protowire.AppendBytes(timeSeries, labelField)
+		}
+		for _, sample := range s.samples {
+			var sampleField []byte
+			sampleField = protowire.AppendTag(sampleField, 1, protowire.Fixed64Type)
+			sampleField = protowire.AppendFixed64(sampleField, math.Float64bits(sample.value))
+			sampleField = protowire.AppendTag(sampleField, 2, protowire.VarintType)
+			sampleField = protowire.AppendVarint(sampleField, uint64(sample.timestamp*1000))
+			timeSeries = protowire.AppendTag(timeSeries, 2, protowire.BytesType)
+			timeSeries = protowire.AppendBytes(timeSeries, sampleField)
+		}
+		request = protowire.AppendTag(request, 1, protowire.BytesType)
+		request = protowire.AppendBytes(request, timeSeries)
+	}
+	return request
+}
+
+// pushRemoteWrite sends the snappy compressed WriteRequest to the remote write URL, the servers
+// signed by the root CA of ODIM are trusted in addition to the ones of the system
+func pushRemoteWrite(ctx context.Context, conf *config.TelemetryExportConf, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, conf.RemoteWriteURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("User-Agent", "odimra-telemetry")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	if conf.RemoteWriteBearerTokenFilePath != "" {
+		token, err := ioutil.ReadFile(conf.RemoteWriteBearerTokenFilePath)
+		if err != nil {
+			return fmt.Errorf("unable to read the bearer token: %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil {
+		rootCAs = x509.NewCertPool()
+	}
+	if config.Data.KeyCertConf != nil {
+		rootCAs.AppendCertsFromPEM(config.Data.KeyCertConf.RootCACertificate)
+	}
+	client := &http.Client{
+		Timeout: time.Duration(conf.RemoteWriteTimeoutInSecs) * time.Second,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12},
+		},
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		message, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("the remote write URL returned %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
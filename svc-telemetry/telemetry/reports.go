@@ -0,0 +1,393 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/svc-telemetry/tcommon"
+	"github.com/google/uuid"
+)
+
+const (
+	// MetricReportActionID action id for logging the computation of the metric reports of the definitions created through ODIM
+	MetricReportActionID = "253"
+	// MetricReportActionName action name for logging the computation of the metric reports of the definitions created through ODIM
+	MetricReportActionName = "ComputeMetricReports"
+
+	metricReportType = "#MetricReport.v1_4_2.MetricReport"
+	// metricReportCheckInterval is how often the definitions are checked for the periodic metric reports due
+	metricReportCheckInterval = 5 * time.Second
+	// maxConcurrentMetricResources is the number of resources read at a time from the servers
+	// for computing a metric report
+	maxConcurrentMetricResources = 20
+)
+
+// PerformMetricReportComputation computes the periodic metric reports of the
+// MetricReportDefinitions created through ODIM at their recurrence interval
+func (e *ExternalInterface) PerformMetricReportComputation() {
+	ctx := tcommon.CreateContext(uuid.New().String(), MetricReportActionID, MetricReportActionName, "1", common.TelemetryService, podName)
+	l.LogWithFields(ctx).Info("metric report computation routine started")
+	lastReports := make(map[string]time.Time)
+	for {
+		e.computeDueMetricReports(ctx, time.Now(), lastReports)
+		time.Sleep(metricReportCheckInterval)
+	}
+}
+
+// computeDueMetricReports computes the metric reports of the enabled periodic definitions
+// whose recurrence interval has passed since their last report
+func (e *ExternalInterface) computeDueMetricReports(ctx context.Context, now time.Time, lastReports map[string]time.Time) {
+	active := make(map[string]bool)
+	for _, definition := range e.getOdimMetricReportDefinitions(ctx) {
+		if definition.MetricReportDefinitionType != periodicReport || !definition.MetricReportDefinitionEnabled {
+			continue
+		}
+		interval, err := parseDuration(definition.Schedule.RecurrenceInterval)
+		if err != nil {
+			l.LogWithFields(ctx).Warn("invalid recurrence interval of " + definition.ODataID + ": " + err.Error())
+			continue
+		}
+		active[definition.ODataID] = true
+		if now.Sub(lastReports[definition.ODataID]) < interval {
+			continue
+		}
+		lastReports[definition.ODataID] = now
+		if _, err := e.ComputeMetricReport(ctx, definition, now); err != nil {
+			l.LogWithFields(ctx).Error("unable to compute the metric report of " + definition.ODataID + ": " + err.Error())
+		}
+	}
+	for uri := range lastReports {
+		if !active[uri] {
+			delete(lastReports, uri)
+		}
+	}
+}
+
+// getOdimMetricReportDefinitions reads the MetricReportDefinitions created through ODIM
+func (e *ExternalInterface) getOdimMetricReportDefinitions(ctx context.Context) []dmtf.MetricReportDefinitions {
+	keys, err := e.DB.GetAllKeysFromTable(ctx, "MetricReportDefinitions", common.InMemory)
+	if err != nil {
+		l.LogWithFields(ctx).Warn("unable to get the metric report definitions: " + err.Error())
+		return nil
+	}
+	sort.Strings(keys)
+	var definitions []dmtf.MetricReportDefinitions
+	for _, key := range keys {
+		definition, ok := e.getOdimMetricReportDefinition(ctx, key)
+		if ok {
+			definitions = append(definitions, definition)
+		}
+	}
+	return definitions
+}
+
+// getOdimMetricReportDefinition reads the MetricReportDefinition of the URI,
+// false is returned when it is not found or not created through ODIM
+func (e *ExternalInterface) getOdimMetricReportDefinition(ctx context.Context, uri string) (dmtf.MetricReportDefinitions, bool) {
+	var definition dmtf.MetricReportDefinitions
+	data, gerr := e.DB.GetResource(ctx, "MetricReportDefinitions", uri, common.InMemory)
+	if gerr != nil {
+		return definition, false
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &body); err != nil || !getOdimOem(body).Odim.ComputedByOdim {
+		return definition, false
+	}
+	if err := json.Unmarshal([]byte(data), &definition); err != nil {
+		l.LogWithFields(ctx).Warn("unable to read the metric report definition " + uri + ": " + err.Error())
+		return definition, false
+	}
+	return definition, true
+}
+
+// ComputeMetricReport reads the metric properties of the definition from the servers,
+// the wild cards of the metric properties are expanded with their values and with
+// the servers of the aggregate of the definition. The report is saved in the
+// MetricReports collection and in the metric history.
+func (e *ExternalInterface) ComputeMetricReport(ctx context.Context, definition dmtf.MetricReportDefinitions, now time.Time) (dmtf.MetricReports, error) {
+	properties := e.expandMetricProperties(ctx, definition.MetricProperties, definition.Wildcards, definition.Oem)
+	resources := e.getMetricResources(ctx, properties)
+
+	timestamp := now.UTC().Format(time.RFC3339)
+	var values []dmtf.MetricValue
+	for _, property := range properties {
+		uri, pointer := splitMetricProperty(property)
+		resource, ok := resources[uri]
+		if !ok {
+			continue
+		}
+		value, ok := metricPropertyValue(resource, pointer)
+		if !ok {
+			l.LogWithFields(ctx).Debugf("no value is found for the metric property %s", property)
+			continue
+		}
+		values = append(values, dmtf.MetricValue{
+			MetricID:       resourceID(pointer),
+			MetricProperty: property,
+			MetricValue:    value,
+			Timestamp:      timestamp,
+		})
+	}
+
+	id := resourceID(definition.ODataID)
+	report := dmtf.MetricReports{
+		ODataID:                metricReportsURI + "/" + id,
+		ODataType:              metricReportType,
+		ODataContext:           "/redfish/v1/$metadata#MetricReport.MetricReport",
+		ID:                     id,
+		Name:                   definition.Name,
+		MetricReportDefinition: dmtf.Oid{ODataID: definition.ODataID},
+		MetricValues:           values,
+		ReportSequence:         "1",
+		Timestamp:              timestamp,
+	}
+	if previous, err := e.DB.GetResource(ctx, "MetricReports", report.ODataID, common.InMemory); err == nil {
+		var previousReport dmtf.MetricReports
+		json.Unmarshal([]byte(previous), &previousReport)
+		if sequence, err := strconv.Atoi(previousReport.ReportSequence); err == nil {
+			report.ReportSequence = strconv.Itoa(sequence + 1)
+		}
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		return report, err
+	}
+	for _, action := range definition.ReportActions {
+		if action != logToMetricReports {
+			continue
+		}
+		if err := e.External.GenericSave(ctx, data, "MetricReports", report.ODataID); err != nil {
+			return report, err
+		}
+		if err := e.addCollectionMember(ctx, "MetricReportsCollection", metricReportsURI, report.ODataID); err != nil {
+			return report, err
+		}
+	}
+	if err := e.IngestMetricReport(ctx, data); err != nil {
+		l.LogWithFields(ctx).Warn("unable to save the metric report " + report.ODataID + " into the metric history: " + err.Error())
+	}
+	l.LogWithFields(ctx).Debugf("metric report %s is computed with %d metric values", report.ODataID, len(values))
+	return report, nil
+}
+
+// expandMetricProperties expands the wild cards of the metric properties of a MetricReportDefinition
+// or Triggers resource with their values and with the servers of the aggregate of its Oem property
+func (e *ExternalInterface) expandMetricProperties(ctx context.Context, properties []string, wCards []dmtf.WildCard, oem *dmtf.Oem) []string {
+	var wildCards []common.WildCard
+	for _, wCard := range wCards {
+		wildCards = append(wildCards, common.WildCard{Name: wCard.Name, Values: wCard.Values})
+	}
+	if aggregate := oemAggregate(oem); aggregate != "" {
+		wildCards = e.addAggregateWildCards(ctx, aggregate, wildCards)
+	}
+	return common.ExpandWildCard(properties, wildCards)
+}
+
+// getMetricResources gets the resources of the metric properties from the servers,
+// each resource is read once for all its metric properties. At most
+// maxConcurrentMetricResources resources are read at a time.
+func (e *ExternalInterface) getMetricResources(ctx context.Context, properties []string) map[string]map[string]interface{} {
+	uris := make(map[string]bool)
+	for _, property := range properties {
+		uri, _ := splitMetricProperty(property)
+		uris[uri] = true
+	}
+	resources := make(map[string]map[string]interface{})
+	semaphore := make(chan struct{}, maxConcurrentMetricResources)
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	for uri := range uris {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(uri string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			body, err := tcommon.GetResourceFromDevice(ctx, tcommon.DeviceResourceRequest{
+				URL:             uri,
+				ContactClient:   e.External.ContactClient,
+				DevicePassword:  e.External.DevicePassword,
+				GetPluginStatus: e.External.GetPluginStatus,
+				GetTarget:       e.External.GetTarget,
+				GetPluginData:   e.External.GetPluginData,
+			})
+			if err != nil {
+				l.LogWithFields(ctx).Warn("unable to get " + uri + " for the metric report: " + err.Error())
+				return
+			}
+			var resource map[string]interface{}
+			if err := json.Unmarshal(body, &resource); err != nil {
+				l.LogWithFields(ctx).Warn("unable to read " + uri + " for the metric report: " + err.Error())
+				return
+			}
+			lock.Lock()
+			defer lock.Unlock()
+			resources[uri] = resource
+		}(uri)
+	}
+	wg.Wait()
+	return resources
+}
+
+// addAggregateWildCards adds the servers of the aggregate to the values of the SystemID
+// wild card, and their chassis to the values of the ChassisID wild card
+func (e *ExternalInterface) addAggregateWildCards(ctx context.Context, aggregateURI string, wildCards []common.WildCard) []common.WildCard {
+	aggregate, err := e.DB.GetAggregate(ctx, aggregateURI)
+	if err != nil {
+		l.LogWithFields(ctx).Warn("unable to get the aggregate " + aggregateURI + ": " + err.Error())
+		return wildCards
+	}
+	var systemIDs, chassisIDs []string
+	for _, element := range aggregate.Elements {
+		systemIDs = append(systemIDs, resourceID(element.OdataID))
+	}
+	if len(systemIDs) > 0 {
+		chassis, err := e.DB.GetAllKeysFromTable(ctx, "Chassis", common.InMemory)
+		if err != nil {
+			l.LogWithFields(ctx).Warn("unable to get the chassis of the aggregate " + aggregateURI + ": " + err.Error())
+		}
+		sort.Strings(chassis)
+		for _, key := range chassis {
+			chassisID := resourceID(key)
+			for _, systemID := range systemIDs {
+				if strings.HasPrefix(chassisID, systemID[:strings.Index(systemID+".", ".")+1]) {
+					chassisIDs = append(chassisIDs, chassisID)
+					break
+				}
+			}
+		}
+	}
+	for name, ids := range map[string][]string{common.SystemIDWildCard: systemIDs, common.ChassisIDWildCard: chassisIDs} {
+		index := -1
+		for i := range wildCards {
+			if wildCards[i].Name == name {
+				index = i
+			}
+		}
+		if index < 0 {
+			wildCards = append(wildCards, common.WildCard{Name: name})
+			index = len(wildCards) - 1
+		}
+		for _, id := range ids {
+			if !containsString(wildCards[index].Values, id) {
+				wildCards[index].Values = append(wildCards[index].Values, id)
+			}
+		}
+	}
+	return wildCards
+}
+
+// oemAggregate gets the URI of the aggregate of the Oem property of a MetricReportDefinition or Triggers resource
+func oemAggregate(oem *dmtf.Oem) string {
+	data, _ := json.Marshal(map[string]interface{}{"Oem": oem})
+	var body map[string]interface{}
+	json.Unmarshal(data, &body)
+	if aggregate := getOdimOem(body).Odim.Aggregate; aggregate != nil {
+		return aggregate.Oid
+	}
+	return ""
+}
+
+// splitMetricProperty splits the metric property into the URI of the resource and the JSON pointer of the property
+func splitMetricProperty(property string) (string, string) {
+	parts := strings.SplitN(property, "#", 2)
+	if len(parts) < 2 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
+
+// metricPropertyValue gets the value of the property the JSON pointer refers to,
+// only the numbers, strings and booleans are metric values
+func metricPropertyValue(resource map[string]interface{}, pointer string) (string, bool) {
+	var current interface{} = resource
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return "", false
+			}
+			current = value
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(node) {
+				return "", false
+			}
+			current = node[index]
+		default:
+			return "", false
+		}
+	}
+	switch value := current.(type) {
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), true
+	case string:
+		return value, true
+	case bool:
+		return strconv.FormatBool(value), true
+	}
+	return "", false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// getOdimMetricReport gets the metric report of a definition created through ODIM, the report of an
+// on request definition is computed on each request. false is returned when the report is not
+// of a definition created through ODIM.
+func (e *ExternalInterface) getOdimMetricReport(ctx context.Context, reportURI string) (map[string]interface{}, bool, error) {
+	definition, ok := e.getOdimMetricReportDefinition(ctx, metricReportDefinitionsURI+"/"+resourceID(reportURI))
+	if !ok {
+		return nil, false, nil
+	}
+	var data []byte
+	if definition.MetricReportDefinitionType == onRequestReport {
+		report, err := e.ComputeMetricReport(ctx, definition, time.Now())
+		if err != nil {
+			return nil, true, err
+		}
+		data, _ = json.Marshal(report)
+	} else {
+		stored, gerr := e.DB.GetResource(ctx, "MetricReports", reportURI, common.InMemory)
+		if gerr != nil {
+			return nil, true, fmt.Errorf("the metric report is not computed yet: %s", gerr.Error())
+		}
+		data = []byte(stored)
+	}
+	var report map[string]interface{}
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, true, err
+	}
+	return report, true, nil
+}
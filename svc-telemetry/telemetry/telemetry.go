@@ -244,6 +244,16 @@ func (e *ExternalInterface) GetMetricReportDefinition(ctx context.Context, req *
 // GetMetricReport is for to get metric report from southbound resource
 func (e *ExternalInterface) GetMetricReport(ctx context.Context, req *teleproto.TelemetryRequest) response.RPC {
 	var resp response.RPC
+	if report, ok, err := e.getOdimMetricReport(ctx, req.URL); ok {
+		if err != nil {
+			l.LogWithFields(ctx).Error(err.Error())
+			return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, err.Error(), []interface{}{"MetricReport", req.URL}, nil)
+		}
+		resp.Body = report
+		resp.StatusCode = http.StatusOK
+		resp.StatusMessage = response.Success
+		return resp
+	}
 	var getDeviceInfoRequest = tcommon.ResourceInfoRequest{
 		URL:                 req.URL,
 		ContactClient:       e.External.ContactClient,
@@ -259,6 +269,9 @@ func (e *ExternalInterface) GetMetricReport(ctx context.Context, req *teleproto.
 		l.LogWithFields(ctx).Error(err.Error())
 		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, err.Error(), []interface{}{"MetricReport", req.URL}, nil)
 	}
+	if err = e.IngestMetricReport(ctx, data); err != nil {
+		l.LogWithFields(ctx).Warn("unable to save the metric report into the metric history: " + err.Error())
+	}
 	var resource map[string]interface{}
 	json.Unmarshal(data, &resource)
 	resp.Body = resource
@@ -315,10 +328,3 @@ func (e *ExternalInterface) GetTrigger(ctx context.Context, req *teleproto.Telem
 	return resp
 
 }
-
-// UpdateTrigger ...
-func (e *ExternalInterface) UpdateTrigger(req *teleproto.TelemetryRequest) response.RPC {
-	var resp response.RPC
-	// Todo: code for update operation
-	return resp
-}
@@ -389,34 +389,3 @@ func TestExternalInterface_GetTrigger(t *testing.T) {
 		})
 	}
 }
-
-func TestExternalInterface_UpdateTrigger(t *testing.T) {
-	type args struct {
-		req *teleproto.TelemetryRequest
-	}
-	tests := []struct {
-		name string
-		e    *ExternalInterface
-		args args
-		want int
-	}{
-		{
-			name: "success",
-			e:    MockGetExternalInterface(),
-			args: args{
-				req: &teleproto.TelemetryRequest{
-					ResourceID:   "custom1",
-					SessionToken: "validToken",
-				},
-			},
-			want: 0, // as update trigger is not implemented. Need to be changed with http.StatusOK once update trigger operation is implemented
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := tt.e.UpdateTrigger(tt.args.req); int(got.StatusCode) != tt.want {
-				t.Errorf("ExternalInterface.UpdateTrigger() = %v, want %v", int(got.StatusCode), tt.want)
-			}
-		})
-	}
-}
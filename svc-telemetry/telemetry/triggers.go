@@ -0,0 +1,388 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/svc-telemetry/tcommon"
+	"github.com/google/uuid"
+)
+
+const (
+	// TriggerActionID action id for logging the evaluation of the triggers created through ODIM
+	TriggerActionID = "254"
+	// TriggerActionName action name for logging the evaluation of the triggers created through ODIM
+	TriggerActionName = "EvaluateTriggers"
+
+	// triggerEvaluationInterval is how often the metric properties of the triggers are read and evaluated
+	triggerEvaluationInterval = 10 * time.Second
+	telemetryMessageRegistry  = "TelemetryService.1.0.0."
+	triggerNumericNormal      = "TriggerNumericReadingNormal"
+	triggerDiscreteMet        = "TriggerDiscreteConditionMet"
+)
+
+// numericThresholds are the messages and the severities of the alerts raised when
+// a reading crosses the numeric thresholds, in the order they are evaluated
+var numericThresholds = []struct {
+	name      string
+	upper     bool
+	messageID string
+	severity  string
+	message   string
+}{
+	{"UpperCritical", true, "TriggerNumericAboveUpperCritical", "Critical", "above the upper critical threshold"},
+	{"UpperWarning", true, "TriggerNumericAboveUpperWarning", "Warning", "above the upper warning threshold"},
+	{"LowerWarning", false, "TriggerNumericBelowLowerWarning", "Warning", "below the lower warning threshold"},
+	{"LowerCritical", false, "TriggerNumericBelowLowerCritical", "Critical", "below the lower critical threshold"},
+}
+
+// triggerState is the state of a threshold or a discrete trigger of a metric property between the evaluations
+type triggerState struct {
+	// crossed is true when the reading is confirmed beyond the threshold or equal to the discrete value
+	crossed bool
+	// alerted is true when an alert is raised for the crossing of the threshold
+	alerted bool
+	// pendingSince is when the reading moved to the other side of the threshold, it is zero when it did not
+	pendingSince time.Time
+	// reading is the last reading of the metric property of a Changed discrete trigger
+	reading string
+	seen    bool
+}
+
+// PerformTriggerEvaluation evaluates the triggers created through ODIM against the metric
+// properties of the servers and raises the alerts of the triggers through the event service
+func (e *ExternalInterface) PerformTriggerEvaluation() {
+	ctx := tcommon.CreateContext(uuid.New().String(), TriggerActionID, TriggerActionName, "1", common.TelemetryService, podName)
+	l.LogWithFields(ctx).Info("trigger evaluation routine started")
+	states := make(map[string]*triggerState)
+	for {
+		states = e.evaluateTriggers(ctx, time.Now(), states)
+		time.Sleep(triggerEvaluationInterval)
+	}
+}
+
+// evaluateTriggers evaluates the enabled triggers once and performs their trigger actions,
+// the states of the metric properties of the enabled triggers are returned for the next evaluation
+func (e *ExternalInterface) evaluateTriggers(ctx context.Context, now time.Time, previous map[string]*triggerState) map[string]*triggerState {
+	states := make(map[string]*triggerState)
+	stateOf := func(key string) *triggerState {
+		state, ok := previous[key]
+		if !ok {
+			state = &triggerState{}
+		}
+		states[key] = state
+		return state
+	}
+	evaluated := make(map[string]bool)
+	for _, trigger := range e.getOdimTriggers(ctx) {
+		if trigger.Status.State != "" && trigger.Status.State != "Enabled" {
+			continue
+		}
+		evaluated[trigger.ODataID] = true
+		properties := e.expandMetricProperties(ctx, trigger.MetricProperties, trigger.Wildcards, trigger.Oem)
+		resources := e.getMetricResources(ctx, properties)
+		var events []common.Event
+		for _, property := range properties {
+			uri, pointer := splitMetricProperty(property)
+			resource, ok := resources[uri]
+			if !ok {
+				continue
+			}
+			reading, ok := metricPropertyValue(resource, pointer)
+			if !ok {
+				l.LogWithFields(ctx).Debugf("no value is found for the metric property %s", property)
+				continue
+			}
+			key := trigger.ODataID + " " + property
+			switch trigger.MetricType {
+			case numericTrigger:
+				value, err := strconv.ParseFloat(reading, 64)
+				if err != nil {
+					l.LogWithFields(ctx).Debugf("the metric property %s of the numeric trigger %s is not a number", property, trigger.ODataID)
+					continue
+				}
+				events = append(events, numericTriggerEvents(trigger, property, value, now, func(name string) *triggerState {
+					return stateOf(key + " " + name)
+				})...)
+			case discreteTrigger:
+				events = append(events, discreteTriggerEvents(trigger, property, reading, now, func(name string) *triggerState {
+					return stateOf(key + " " + name)
+				})...)
+			}
+		}
+		if len(events) > 0 {
+			e.performTriggerActions(ctx, trigger, events, now)
+		}
+	}
+	// the states of the metric properties which could not be read are kept until the trigger is removed
+	for key, state := range previous {
+		if _, ok := states[key]; !ok && evaluated[key[:strings.Index(key, " ")]] {
+			states[key] = state
+		}
+	}
+	return states
+}
+
+// getOdimTriggers reads the Triggers created through ODIM
+func (e *ExternalInterface) getOdimTriggers(ctx context.Context) []dmtf.Triggers {
+	keys, err := e.DB.GetAllKeysFromTable(ctx, "Triggers", common.InMemory)
+	if err != nil {
+		l.LogWithFields(ctx).Warn("unable to get the triggers: " + err.Error())
+		return nil
+	}
+	sort.Strings(keys)
+	var triggers []dmtf.Triggers
+	for _, key := range keys {
+		data, gerr := e.DB.GetResource(ctx, "Triggers", key, common.InMemory)
+		if gerr != nil {
+			continue
+		}
+		var body map[string]interface{}
+		if err := json.Unmarshal([]byte(data), &body); err != nil || !getOdimOem(body).Odim.ComputedByOdim {
+			continue
+		}
+		var trigger dmtf.Triggers
+		if err := json.Unmarshal([]byte(data), &trigger); err != nil {
+			l.LogWithFields(ctx).Warn("unable to read the trigger " + key + ": " + err.Error())
+			continue
+		}
+		triggers = append(triggers, trigger)
+	}
+	return triggers
+}
+
+// numericTriggerEvents evaluates the reading of a metric property against the numeric thresholds
+// of the trigger. An alert is raised when the reading crosses a threshold in the direction of
+// its Activation, and the reading normal event when the reading returns from an alerted threshold.
+func numericTriggerEvents(trigger dmtf.Triggers, property string, value float64, now time.Time, stateOf func(string) *triggerState) []common.Event {
+	thresholds := map[string]*dmtf.Threshold{
+		"LowerCritical": trigger.NumericThresholds.LowerCritical,
+		"LowerWarning":  trigger.NumericThresholds.LowerWarning,
+		"UpperCritical": trigger.NumericThresholds.UpperCritical,
+		"UpperWarning":  trigger.NumericThresholds.UpperWarning,
+	}
+	reading := strconv.FormatFloat(value, 'f', -1, 64)
+	var events []common.Event
+	for _, numeric := range numericThresholds {
+		threshold := thresholds[numeric.name]
+		if threshold == nil {
+			continue
+		}
+		state := stateOf(numeric.name)
+		if !state.crossThreshold(threshold, numeric.upper, value, now) {
+			continue
+		}
+		activation := threshold.Activation
+		if activation == "" {
+			activation = "Decreasing"
+			if numeric.upper {
+				activation = "Increasing"
+			}
+		}
+		increasing := state.crossed == numeric.upper
+		activated := activation == "Either" || (activation == "Increasing") == increasing
+		limit := strconv.FormatFloat(threshold.Reading, 'f', -1, 64)
+		args := []string{property, reading, limit, trigger.ID}
+		if state.crossed {
+			if !activated {
+				continue
+			}
+			state.alerted = true
+			message := fmt.Sprintf("Metric '%s' value of %s is %s of %s of trigger '%s'.", property, reading, numeric.message, limit, trigger.ID)
+			events = append(events, triggerEvent(numeric.messageID, message, numeric.severity, property, args, now))
+			continue
+		}
+		if state.alerted || activated {
+			state.alerted = false
+			message := fmt.Sprintf("Metric '%s' value of %s is back within the threshold of %s of trigger '%s'.", property, reading, limit, trigger.ID)
+			events = append(events, triggerEvent(triggerNumericNormal, message, "OK", property, args, now))
+		}
+	}
+	return events
+}
+
+// crossThreshold moves the state to the side of the threshold the reading has stayed on for the
+// dwell time, true is returned when the side is changed. The reading must pass the hysteresis
+// reading back from the threshold to return to the normal side.
+func (s *triggerState) crossThreshold(threshold *dmtf.Threshold, upper bool, value float64, now time.Time) bool {
+	var beyond, back bool
+	if upper {
+		beyond = value > threshold.Reading
+		back = value < threshold.Reading-threshold.HysteresisReading
+	} else {
+		beyond = value < threshold.Reading
+		back = value > threshold.Reading+threshold.HysteresisReading
+	}
+	if (!s.crossed && beyond) || (s.crossed && back) {
+		return s.dwell(threshold.DwellTime, now)
+	}
+	s.pendingSince = time.Time{}
+	return false
+}
+
+// dwell changes the side of the state once the reading has stayed on the other side for the dwell time
+func (s *triggerState) dwell(dwellTime string, now time.Time) bool {
+	if s.pendingSince.IsZero() {
+		s.pendingSince = now
+	}
+	dwell, _ := parseDuration(dwellTime)
+	if now.Sub(s.pendingSince) < dwell {
+		return false
+	}
+	s.crossed = !s.crossed
+	s.pendingSince = time.Time{}
+	return true
+}
+
+// discreteTriggerEvents evaluates the reading of a metric property against the discrete trigger condition.
+// A Changed trigger raises an alert on every change of the reading, a Specified trigger raises an alert
+// once when the reading has stayed equal to the value of a discrete trigger for its dwell time.
+func discreteTriggerEvents(trigger dmtf.Triggers, property, reading string, now time.Time, stateOf func(string) *triggerState) []common.Event {
+	var events []common.Event
+	if trigger.DiscreteTriggerCondition == "Changed" {
+		state := stateOf("Changed")
+		if state.seen && state.reading != reading {
+			message := fmt.Sprintf("Metric '%s' value changed from %s to %s in trigger '%s'.", property, state.reading, reading, trigger.ID)
+			args := []string{property, reading, "Changed", trigger.ID}
+			events = append(events, triggerEvent(triggerDiscreteMet, message, "Warning", property, args, now))
+		}
+		state.reading, state.seen = reading, true
+		return events
+	}
+	for i, discrete := range trigger.DiscreteTriggers {
+		state := stateOf("DiscreteTriggers/" + strconv.Itoa(i))
+		if reading != discrete.Value {
+			state.crossed, state.pendingSince = false, time.Time{}
+			continue
+		}
+		if state.crossed || !state.dwell(discrete.DwellTime, now) {
+			continue
+		}
+		name := discrete.Name
+		if name == "" {
+			name = discrete.Value
+		}
+		severity := discrete.Severity
+		if severity == "" {
+			severity = "Warning"
+		}
+		message := fmt.Sprintf("Metric '%s' value of %s meets the condition %s of trigger '%s'.", property, reading, name, trigger.ID)
+		args := []string{property, reading, name, trigger.ID}
+		events = append(events, triggerEvent(triggerDiscreteMet, message, severity, property, args, now))
+	}
+	return events
+}
+
+func triggerEvent(messageID, message, severity, property string, args []string, now time.Time) common.Event {
+	uri, _ := splitMetricProperty(property)
+	return common.Event{
+		EventType:         "Alert",
+		EventID:           uuid.New().String(),
+		Severity:          severity,
+		EventTimestamp:    now.UTC().Format(time.RFC3339),
+		Message:           message,
+		MessageArgs:       args,
+		MessageID:         telemetryMessageRegistry + messageID,
+		OriginOfCondition: &common.Link{Oid: uri},
+	}
+}
+
+// performTriggerActions publishes the alerts of the trigger when it has the RedfishEvent action,
+// and computes the metric reports of its linked definitions when it has the RedfishMetricReport
+// action and one of its conditions is met
+func (e *ExternalInterface) performTriggerActions(ctx context.Context, trigger dmtf.Triggers, events []common.Event, now time.Time) {
+	var conditionMet bool
+	for _, event := range events {
+		if event.MessageID != telemetryMessageRegistry+triggerNumericNormal {
+			conditionMet = true
+		}
+	}
+	for _, action := range trigger.TriggerActions {
+		switch action {
+		case redfishEvent:
+			e.publishTriggerEvents(ctx, trigger, events)
+		case redfishMetricReport:
+			if !conditionMet {
+				continue
+			}
+			for _, link := range trigger.Links.MetricReportDefinitions {
+				definition, ok := e.getOdimMetricReportDefinition(ctx, link.ODataID)
+				if !ok {
+					l.LogWithFields(ctx).Warn("unable to get the metric report definition " + link.ODataID + " of the trigger " + trigger.ODataID)
+					continue
+				}
+				if _, err := e.ComputeMetricReport(ctx, definition, now); err != nil {
+					l.LogWithFields(ctx).Error("unable to compute the metric report of " + definition.ODataID + " for the trigger " + trigger.ODataID + ": " + err.Error())
+				}
+			}
+		}
+	}
+}
+
+// publishTriggerEvents publishes the alerts of each server as if they were sent by its BMC, the event
+// service forwards them to the subscriptions of the server with the origin of condition of the server
+func (e *ExternalInterface) publishTriggerEvents(ctx context.Context, trigger dmtf.Triggers, events []common.Event) {
+	var servers []string
+	serverEvents := make(map[string][]common.Event)
+	for _, event := range events {
+		deviceUUID, origin := bmcResourceURI(event.OriginOfCondition.Oid)
+		if deviceUUID == "" {
+			continue
+		}
+		if _, ok := serverEvents[deviceUUID]; !ok {
+			servers = append(servers, deviceUUID)
+		}
+		event.OriginOfCondition = &common.Link{Oid: origin}
+		serverEvents[deviceUUID] = append(serverEvents[deviceUUID], event)
+	}
+	for _, deviceUUID := range servers {
+		target, gerr := e.External.GetTarget(ctx, deviceUUID)
+		if gerr != nil {
+			l.LogWithFields(ctx).Warn("unable to get the server " + deviceUUID + " of the trigger " + trigger.ODataID + ": " + gerr.Error())
+			continue
+		}
+		message := common.MessageData{
+			Name:      "Trigger Event",
+			Context:   "/redfish/v1/$metadata#Event.Event",
+			OdataType: common.EventType,
+			Events:    serverEvents[deviceUUID],
+		}
+		if err := e.External.PublishEvent(ctx, target.ManagerAddress, message); err != nil {
+			l.LogWithFields(ctx).Error("unable to publish the alerts of the trigger " + trigger.ODataID + " for the server " + deviceUUID + ": " + err.Error())
+		}
+	}
+}
+
+// bmcResourceURI splits the URI of a server resource into the UUID of the server and the URI of the resource on its BMC
+func bmcResourceURI(uri string) (string, string) {
+	segments := strings.Split(uri, "/")
+	if len(segments) < 5 || !strings.Contains(segments[4], ".") {
+		return "", uri
+	}
+	index := strings.Index(segments[4], ".")
+	deviceUUID := segments[4][:index]
+	segments[4] = segments[4][index+1:]
+	return deviceUUID, strings.Join(segments, "/")
+}
@@ -0,0 +1,215 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+)
+
+// published keeps the events published for each manager address
+type published map[string][]common.Event
+
+func (p published) messageIDs(managerAddress string) []string {
+	var ids []string
+	for _, event := range p[managerAddress] {
+		ids = append(ids, strings.TrimPrefix(event.MessageID, telemetryMessageRegistry))
+	}
+	return ids
+}
+
+func TestEvaluateNumericTrigger(t *testing.T) {
+	db := newFakeTelemetryDB()
+	e := db.reportInterface(t)
+	ctx := context.Background()
+	watts := "450"
+	e.External.ContactClient = func(ctx context.Context, url, method, token string, odataID string, body interface{}, loginCredential map[string]string) (*http.Response, error) {
+		if strings.HasSuffix(url, "/Chassis/1/Power") {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"PowerControl":[{"PowerConsumedWatts":` + watts + `}]}`)),
+			}, nil
+		}
+		return powerContactClient(ctx, url, method, token, odataID, body, loginCredential)
+	}
+	events := make(published)
+	e.External.PublishEvent = func(ctx context.Context, managerAddress string, message common.MessageData) error {
+		events[managerAddress] = append(events[managerAddress], message.Events...)
+		return nil
+	}
+	e.CreateMetricReportDefinition(ctx, telemetryRequest(metricReportDefinitionsURI, powerDefinition))
+	resp := e.CreateTrigger(ctx, telemetryRequest(triggersURI, `{"Id":"PowerLimit","MetricType":"Numeric",`+
+		`"NumericThresholds":{"UpperCritical":{"Reading":400,"DwellTime":"PT20S","HysteresisReading":20}},`+
+		`"TriggerActions":["RedfishEvent","RedfishMetricReport"],"Links":{"MetricReportDefinitions":[{"@odata.id":"/redfish/v1/TelemetryService/MetricReportDefinitions/Power"}]},`+
+		`"MetricProperties":["/redfish/v1/Chassis/uuid1.1/Power#/PowerControl/0/PowerConsumedWatts"]}`))
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("CreateTrigger() = %v: %v", resp.StatusCode, resp.Body)
+	}
+
+	now := time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC)
+	states := make(map[string]*triggerState)
+	states = e.evaluateTriggers(ctx, now, states)
+	states = e.evaluateTriggers(ctx, now.Add(10*time.Second), states)
+	if len(events) != 0 {
+		t.Fatalf("alert is raised before the dwell time: %v", events)
+	}
+	states = e.evaluateTriggers(ctx, now.Add(20*time.Second), states)
+	if got := events.messageIDs("10.0.0.1"); !reflect.DeepEqual(got, []string{"TriggerNumericAboveUpperCritical"}) {
+		t.Fatalf("published events = %v, want the upper critical alert", got)
+	}
+	alert := events["10.0.0.1"][0]
+	if alert.OriginOfCondition.Oid != "/redfish/v1/Chassis/1/Power" || alert.Severity != "Critical" {
+		t.Errorf("alert = %+v, want a critical alert of the power of the BMC", alert)
+	}
+	if _, ok := db["MetricReports"][metricReportsURI+"/Power"]; !ok {
+		t.Errorf("the metric report of the linked definition is not computed")
+	}
+
+	watts = "390"
+	states = e.evaluateTriggers(ctx, now.Add(30*time.Second), states)
+	watts = "370"
+	states = e.evaluateTriggers(ctx, now.Add(40*time.Second), states)
+	if len(events["10.0.0.1"]) != 1 {
+		t.Fatalf("reading normal is raised within the hysteresis or before the dwell time: %v", events.messageIDs("10.0.0.1"))
+	}
+	states = e.evaluateTriggers(ctx, now.Add(60*time.Second), states)
+	if got := events.messageIDs("10.0.0.1"); !reflect.DeepEqual(got, []string{"TriggerNumericAboveUpperCritical", "TriggerNumericReadingNormal"}) {
+		t.Errorf("published events = %v, want the reading normal event", got)
+	}
+
+	e.DeleteTrigger(ctx, telemetryRequest(triggersURI+"/PowerLimit", ""))
+	if states = e.evaluateTriggers(ctx, now.Add(70*time.Second), states); len(states) != 0 {
+		t.Errorf("the states of the deleted trigger are kept: %v", states)
+	}
+}
+
+func TestNumericTriggerActivation(t *testing.T) {
+	tests := []struct {
+		name       string
+		thresholds dmtf.NumericThresholds
+		readings   []float64
+		want       []string
+	}{
+		{
+			name:       "lower warning",
+			thresholds: dmtf.NumericThresholds{LowerWarning: &dmtf.Threshold{Reading: 10}},
+			readings:   []float64{20, 5, 15},
+			want:       []string{"TriggerNumericBelowLowerWarning", "TriggerNumericReadingNormal"},
+		},
+		{
+			name:       "upper threshold activated when decreasing",
+			thresholds: dmtf.NumericThresholds{UpperWarning: &dmtf.Threshold{Reading: 50, Activation: "Decreasing"}},
+			readings:   []float64{40, 60, 40},
+			want:       []string{"TriggerNumericReadingNormal"},
+		},
+		{
+			name: "warning and critical",
+			thresholds: dmtf.NumericThresholds{
+				UpperWarning:  &dmtf.Threshold{Reading: 50},
+				UpperCritical: &dmtf.Threshold{Reading: 80},
+			},
+			readings: []float64{60, 90, 60, 40},
+			want: []string{"TriggerNumericAboveUpperWarning", "TriggerNumericAboveUpperCritical",
+				"TriggerNumericReadingNormal", "TriggerNumericReadingNormal"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trigger := dmtf.Triggers{ID: "T1", MetricType: numericTrigger, NumericThresholds: tt.thresholds}
+			states := make(map[string]*triggerState)
+			stateOf := func(name string) *triggerState {
+				if states[name] == nil {
+					states[name] = &triggerState{}
+				}
+				return states[name]
+			}
+			var got []string
+			now := time.Now()
+			for i, reading := range tt.readings {
+				for _, event := range numericTriggerEvents(trigger, "/redfish/v1/Chassis/uuid1.1/Thermal#/Temperatures/0/ReadingCelsius", reading, now.Add(time.Duration(i)*time.Minute), stateOf) {
+					got = append(got, strings.TrimPrefix(event.MessageID, telemetryMessageRegistry))
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("numericTriggerEvents() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiscreteTriggerEvents(t *testing.T) {
+	tests := []struct {
+		name     string
+		trigger  dmtf.Triggers
+		readings []string
+		want     []string
+	}{
+		{
+			name:     "changed",
+			trigger:  dmtf.Triggers{DiscreteTriggerCondition: "Changed"},
+			readings: []string{"OK", "OK", "Warning", "OK"},
+			want:     []string{"Warning", "Warning"},
+		},
+		{
+			name: "specified with dwell time",
+			trigger: dmtf.Triggers{DiscreteTriggerCondition: "Specified", DiscreteTriggers: []dmtf.DiscreteTrigger{
+				{Value: "Critical", Severity: "Critical", DwellTime: "PT1M"},
+				{Value: "Warning"},
+			}},
+			readings: []string{"Critical", "Critical", "Critical", "Warning", "Warning", "Critical"},
+			want:     []string{"Critical", "Warning"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			states := make(map[string]*triggerState)
+			stateOf := func(name string) *triggerState {
+				if states[name] == nil {
+					states[name] = &triggerState{}
+				}
+				return states[name]
+			}
+			var got []string
+			now := time.Now()
+			for i, reading := range tt.readings {
+				for _, event := range discreteTriggerEvents(tt.trigger, "/redfish/v1/Systems/uuid1.1#/Status/Health", reading, now.Add(time.Duration(i)*time.Minute), stateOf) {
+					got = append(got, event.Severity)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("discreteTriggerEvents() severities = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBMCResourceURI(t *testing.T) {
+	deviceUUID, uri := bmcResourceURI("/redfish/v1/Chassis/6d5a4d3f-1.1/Thermal")
+	if deviceUUID != "6d5a4d3f-1" || uri != "/redfish/v1/Chassis/1/Thermal" {
+		t.Errorf("bmcResourceURI() = %v, %v", deviceUUID, uri)
+	}
+	if deviceUUID, _ := bmcResourceURI("/redfish/v1/TelemetryService"); deviceUUID != "" {
+		t.Errorf("bmcResourceURI() = %v, want no server", deviceUUID)
+	}
+}
@@ -0,0 +1,44 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package tlresponse
+
+// MetricHistory defines the metric values kept by ODIM for the resources in a time window
+type MetricHistory struct {
+	OdataID   string                `json:"@odata.id"`
+	OdataType string                `json:"@odata.type"`
+	ID        string                `json:"Id"`
+	Name      string                `json:"Name"`
+	StartTime string                `json:"StartTime"`
+	EndTime   string                `json:"EndTime"`
+	Series    []MetricHistorySeries `json:"Series"`
+}
+
+// MetricHistorySeries defines the values of a metric of a resource in the time window
+type MetricHistorySeries struct {
+	MetricReport   string                `json:"MetricReport"`
+	MetricID       string                `json:"MetricId"`
+	MetricProperty string                `json:"MetricProperty,omitempty"`
+	Samples        []MetricHistorySample `json:"Samples"`
+}
+
+// MetricHistorySample defines a metric value at a time. The samples older than the raw
+// retention period are downsampled, Value being the average of Count values.
+type MetricHistorySample struct {
+	Timestamp string   `json:"Timestamp"`
+	Value     string   `json:"Value"`
+	Min       *float64 `json:"Min,omitempty"`
+	Max       *float64 `json:"Max,omitempty"`
+	Count     int      `json:"Count,omitempty"`
+}
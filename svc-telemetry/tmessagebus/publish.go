@@ -0,0 +1,63 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package tmessagebus publishes the events of the telemetry service to the message bus
+package tmessagebus
+
+import (
+	"context"
+	"encoding/json"
+
+	dc "github.com/ODIM-Project/ODIM/lib-messagebus/datacommunicator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/lib-utilities/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Publish publishes the events of the server with the manager address to the message bus,
+// the events are forwarded by the event service as if they were sent by the server
+func Publish(ctx context.Context, managerAddress string, message common.MessageData) error {
+	ctx, span := tracing.StartSpan(ctx, "publish Alert", trace.SpanKindProducer,
+		attribute.String("messaging.system", config.Data.MessageBusConf.MessageBusType),
+		attribute.String("messaging.destination", config.Data.MessageBusConf.OdimControlMessageQueue))
+	defer span.End()
+	topicName := config.Data.MessageBusConf.OdimControlMessageQueue
+	k, err := dc.Communicator(config.Data.MessageBusConf.MessageBusType, config.Data.MessageBusConf.MessageBusConfigFilePath, topicName)
+	if err != nil {
+		l.LogWithFields(ctx).Error("Unable to connect to " + config.Data.MessageBusConf.MessageBusType + " " + err.Error())
+		tracing.RecordError(span, err)
+		return err
+	}
+	defer k.Close()
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	var mbevent = common.Events{
+		IP:          managerAddress,
+		Request:     data,
+		EventType:   "Alert",
+		TraceParent: tracing.TraceParent(ctx),
+	}
+	if err := k.Distribute(mbevent); err != nil {
+		l.LogWithFields(ctx).Error("unable to publish the event of " + managerAddress + " to message bus: " + err.Error())
+		tracing.RecordError(span, err)
+		return err
+	}
+	return nil
+}
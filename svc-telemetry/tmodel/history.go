@@ -0,0 +1,153 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package tmodel
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+)
+
+const (
+	// metricSeriesIndex is the redis set of all the metric series in the history
+	metricSeriesIndex = "MetricHistorySeries"
+	// metricHistoryTable prefixes the redis sorted sets having the samples of a series
+	metricHistoryTable = "MetricHistory:"
+)
+
+// MetricSeries identifies the values of a metric of a resource kept in the history
+type MetricSeries struct {
+	MetricReport   string `json:"MetricReport"`
+	MetricID       string `json:"MetricId"`
+	MetricProperty string `json:"MetricProperty,omitempty"`
+}
+
+// MetricSample is a value of a metric series at a time. The samples made by
+// downsampling numeric values have the average as Value and set Min, Max and
+// Count, the number of values aggregated.
+type MetricSample struct {
+	Timestamp int64    `json:"Timestamp"` // unix time in seconds
+	Value     string   `json:"Value"`
+	Min       *float64 `json:"Min,omitempty"`
+	Max       *float64 `json:"Max,omitempty"`
+	Count     int      `json:"Count,omitempty"`
+}
+
+func (s MetricSeries) key() string {
+	data, _ := json.Marshal(s)
+	return string(data)
+}
+
+// AddMetricSamples saves the samples of a series into the history
+func AddMetricSamples(ctx context.Context, series MetricSeries, samples []MetricSample) *errors.Error {
+	conn, err := GetDBConnectionFunc(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	members := make(map[string]float64, len(samples))
+	for _, sample := range samples {
+		data, jerr := json.Marshal(sample)
+		if jerr != nil {
+			return errors.PackError(errors.JSONUnmarshalFailed, jerr)
+		}
+		members[string(data)] = float64(sample.Timestamp)
+	}
+	if err = conn.AddMembersToSortedSet(metricHistoryTable+series.key(), members); err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to save metric samples: ", err.Error())
+	}
+	if err = conn.AddMemberToSet(metricSeriesIndex, series.key()); err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to index metric series: ", err.Error())
+	}
+	return nil
+}
+
+// GetMetricSamples fetches the samples of a series taken between start and end,
+// both given in unix time, ordered by their time
+func GetMetricSamples(ctx context.Context, series MetricSeries, start, end int64) ([]MetricSample, *errors.Error) {
+	conn, err := GetDBConnectionFunc(common.OnDisk)
+	if err != nil {
+		return nil, err
+	}
+	members, err := conn.GetSortedSetMembersByScore(metricHistoryTable+series.key(),
+		strconv.FormatInt(start, 10), strconv.FormatInt(end, 10))
+	if err != nil {
+		return nil, errors.PackError(err.ErrNo(), "error while trying to get metric samples: ", err.Error())
+	}
+	samples := make([]MetricSample, 0, len(members))
+	for _, member := range members {
+		var sample MetricSample
+		if jerr := json.Unmarshal([]byte(member), &sample); jerr != nil {
+			l.LogWithFields(ctx).Warnf("skipping invalid metric sample %s: %s", member, jerr.Error())
+			continue
+		}
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
+
+// RemoveMetricSamples deletes the samples of a series taken between start and end,
+// both given in unix time
+func RemoveMetricSamples(ctx context.Context, series MetricSeries, start, end int64) *errors.Error {
+	conn, err := GetDBConnectionFunc(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	if err = conn.RemoveSortedSetMembersByScore(metricHistoryTable+series.key(),
+		strconv.FormatInt(start, 10), strconv.FormatInt(end, 10)); err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to remove metric samples: ", err.Error())
+	}
+	return nil
+}
+
+// GetAllMetricSeries fetches all the series having samples in the history
+func GetAllMetricSeries(ctx context.Context) ([]MetricSeries, *errors.Error) {
+	conn, err := GetDBConnectionFunc(common.OnDisk)
+	if err != nil {
+		return nil, err
+	}
+	members, err := conn.GetAllMembersInSet(metricSeriesIndex)
+	if err != nil {
+		return nil, errors.PackError(err.ErrNo(), "error while trying to get metric series: ", err.Error())
+	}
+	seriesList := make([]MetricSeries, 0, len(members))
+	for _, member := range members {
+		var series MetricSeries
+		if jerr := json.Unmarshal([]byte(member), &series); jerr != nil {
+			l.LogWithFields(ctx).Warnf("skipping invalid metric series %s: %s", member, jerr.Error())
+			continue
+		}
+		seriesList = append(seriesList, series)
+	}
+	return seriesList, nil
+}
+
+// RemoveMetricSeries deletes a series and all its samples from the history
+func RemoveMetricSeries(ctx context.Context, series MetricSeries) *errors.Error {
+	conn, err := GetDBConnectionFunc(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	if err = conn.RemoveMemberFromSet(metricSeriesIndex, series.key()); err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to remove metric series: ", err.Error())
+	}
+	if err = conn.DeleteKey(metricHistoryTable + series.key()); err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to remove metric samples: ", err.Error())
+	}
+	return nil
+}
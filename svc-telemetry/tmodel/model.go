@@ -142,3 +142,44 @@ func GenericSave(ctx context.Context, body []byte, table string, key string) err
 	}
 	return nil
 }
+
+// Aggregate is the model for the servers of an aggregate
+type Aggregate struct {
+	Elements []OdataID `json:"Elements"`
+}
+
+// OdataID holds the link to a resource
+type OdataID struct {
+	OdataID string `json:"@odata.id"`
+}
+
+// DeleteResource deletes a resource from the in-memory database using table and key
+func DeleteResource(ctx context.Context, table, key string) *errors.Error {
+	conn, err := GetDBConnectionFunc(common.InMemory)
+	if err != nil {
+		return err
+	}
+	if err = conn.Delete(table, key); err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to delete resource: ", err.Error())
+	}
+	l.LogWithFields(ctx).Debugf("resource %s is deleted from %s", key, table)
+	return nil
+}
+
+// GetAggregate fetches the aggregate of the given URI
+func GetAggregate(ctx context.Context, aggregateURI string) (Aggregate, *errors.Error) {
+	var aggregate Aggregate
+	conn, err := GetDBConnectionFunc(common.OnDisk)
+	if err != nil {
+		return aggregate, err
+	}
+	data, err := conn.Read("Aggregate", aggregateURI)
+	if err != nil {
+		return aggregate, errors.PackError(err.ErrNo(), "error while trying to fetch aggregate data: ", err.Error())
+	}
+	if err := json.Unmarshal([]byte(data), &aggregate); err != nil {
+		return aggregate, errors.PackError(errors.JSONUnmarshalFailed, err)
+	}
+	l.LogWithFields(ctx).Debugf("servers of aggregate %s: %v", aggregateURI, aggregate.Elements)
+	return aggregate, nil
+}
@@ -287,3 +287,35 @@ func mockContext() context.Context {
 	ctx = context.WithValue(ctx, common.ProcessName, "xyz")
 	return ctx
 }
+
+func TestDeleteResource(t *testing.T) {
+	config.SetUpMockConfig(t)
+	ctx := mockContext()
+	defer func() {
+		common.TruncateDB(common.InMemory)
+	}()
+	mockData(t, common.InMemory, "Triggers", "/redfish/v1/TelemetryService/Triggers/InletHigh", "someData")
+	err := DeleteResource(ctx, "Triggers", "/redfish/v1/TelemetryService/Triggers/InletHigh")
+	assert.Nil(t, err, "There should be no error")
+	_, err = GetResource(ctx, "Triggers", "/redfish/v1/TelemetryService/Triggers/InletHigh", common.InMemory)
+	assert.NotNil(t, err, "The resource should be deleted")
+	err = DeleteResource(ctx, "Triggers", "/redfish/v1/TelemetryService/Triggers/InletHigh")
+	assert.Equal(t, errors.DBKeyNotFound, err.ErrNo(), "Deleting a missing resource should fail")
+}
+
+func TestGetAggregate(t *testing.T) {
+	config.SetUpMockConfig(t)
+	ctx := mockContext()
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+	}()
+	aggregate := Aggregate{
+		Elements: []OdataID{{OdataID: "/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1"}},
+	}
+	mockData(t, common.OnDisk, "Aggregate", "/redfish/v1/AggregationService/Aggregates/rack1", aggregate)
+	got, err := GetAggregate(ctx, "/redfish/v1/AggregationService/Aggregates/rack1")
+	assert.Nil(t, err, "There should be no error")
+	assert.Equal(t, aggregate, got, "The servers of the aggregate should be returned")
+	_, err = GetAggregate(ctx, "/redfish/v1/AggregationService/Aggregates/rack2")
+	assert.NotNil(t, err, "There should be an error for a missing aggregate")
+}
@@ -0,0 +1,167 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	updateproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/update"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+)
+
+// CreateFirmwareBaseline is an rpc handler which is invoked during POST on firmware baseline collection
+func (a *Updater) CreateFirmwareBaseline(ctx context.Context, req *updateproto.UpdateRequest) (*updateproto.UpdateResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.UpdateService, podName)
+	resp := &updateproto.UpdateResponse{}
+	if !a.isAuthorized(ctx, req, resp, common.PrivilegeConfigureComponents) {
+		return resp, nil
+	}
+	fillProtoResponse(ctx, resp, a.connector.CreateFirmwareBaseline(ctx, req))
+	l.LogWithFields(ctx).Debugf("final response for create firmware baseline request: %s", string(resp.Body))
+	return resp, nil
+}
+
+// GetFirmwareBaselineCollection is an rpc handler which is invoked during GET on firmware baseline collection
+func (a *Updater) GetFirmwareBaselineCollection(ctx context.Context, req *updateproto.UpdateRequest) (*updateproto.UpdateResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.UpdateService, podName)
+	resp := &updateproto.UpdateResponse{}
+	if !a.isAuthorized(ctx, req, resp, common.PrivilegeLogin) {
+		return resp, nil
+	}
+	fillProtoResponse(ctx, resp, a.connector.GetFirmwareBaselineCollection(ctx, req))
+	l.LogWithFields(ctx).Debugf("final response for get firmware baseline collection request: %s", string(resp.Body))
+	return resp, nil
+}
+
+// GetFirmwareBaseline is an rpc handler which is invoked during GET on firmware baseline
+func (a *Updater) GetFirmwareBaseline(ctx context.Context, req *updateproto.UpdateRequest) (*updateproto.UpdateResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.UpdateService, podName)
+	resp := &updateproto.UpdateResponse{}
+	if !a.isAuthorized(ctx, req, resp, common.PrivilegeLogin) {
+		return resp, nil
+	}
+	fillProtoResponse(ctx, resp, a.connector.GetFirmwareBaseline(ctx, req))
+	l.LogWithFields(ctx).Debugf("final response for get firmware baseline request: %s", string(resp.Body))
+	return resp, nil
+}
+
+// DeleteFirmwareBaseline is an rpc handler which is invoked during DELETE on firmware baseline
+func (a *Updater) DeleteFirmwareBaseline(ctx context.Context, req *updateproto.UpdateRequest) (*updateproto.UpdateResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.UpdateService, podName)
+	resp := &updateproto.UpdateResponse{}
+	if !a.isAuthorized(ctx, req, resp, common.PrivilegeConfigureComponents) {
+		return resp, nil
+	}
+	fillProtoResponse(ctx, resp, a.connector.DeleteFirmwareBaseline(ctx, req))
+	l.LogWithFields(ctx).Debugf("final response for delete firmware baseline request: %s", string(resp.Body))
+	return resp, nil
+}
+
+// GetFirmwareComplianceReport is an rpc handler which is invoked during GET on the compliance report of a firmware baseline
+func (a *Updater) GetFirmwareComplianceReport(ctx context.Context, req *updateproto.UpdateRequest) (*updateproto.UpdateResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.UpdateService, podName)
+	resp := &updateproto.UpdateResponse{}
+	if !a.isAuthorized(ctx, req, resp, common.PrivilegeLogin) {
+		return resp, nil
+	}
+	fillProtoResponse(ctx, resp, a.connector.GetFirmwareComplianceReport(ctx, req))
+	l.LogWithFields(ctx).Debugf("final response for get firmware compliance report request: %s", string(resp.Body))
+	return resp, nil
+}
+
+// RemediateFirmwareDrift is an rpc handler, it gets invoked during POST on the remediate action of a firmware baseline
+func (a *Updater) RemediateFirmwareDrift(ctx context.Context, req *updateproto.UpdateRequest) (*updateproto.UpdateResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.UpdateService, podName)
+	resp := &updateproto.UpdateResponse{}
+	if !a.isAuthorized(ctx, req, resp, common.PrivilegeConfigureComponents) {
+		return resp, nil
+	}
+	if checkResp, ok := a.connector.CheckFirmwareBaseline(ctx, req); !ok {
+		fillProtoResponse(ctx, resp, checkResp)
+		return resp, nil
+	}
+	sessionUserName, err := a.connector.External.GetSessionUserName(ctx, req.SessionToken)
+	if err != nil {
+		errMsg := "error while trying to get the session username: " + err.Error()
+		generateRPCResponse(common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Warn(errMsg)
+		return resp, nil
+	}
+	taskURI, err := a.connector.External.CreateTask(ctx, sessionUserName)
+	if err != nil {
+		errMsg := "error while trying to create task: " + err.Error()
+		generateRPCResponse(common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Warn(errMsg)
+		return resp, nil
+	}
+	strArray := strings.Split(taskURI, "/")
+	var taskID string
+	if strings.HasSuffix(taskURI, "/") {
+		taskID = strArray[len(strArray)-2]
+	} else {
+		taskID = strArray[len(strArray)-1]
+	}
+	err = a.connector.External.UpdateTask(ctx, common.TaskData{
+		TaskID:          taskID,
+		TargetURI:       taskURI,
+		TaskState:       common.Running,
+		TaskStatus:      common.OK,
+		PercentComplete: 0,
+		HTTPMethod:      http.MethodPost,
+	})
+	if err != nil {
+		l.LogWithFields(ctx).Warn("error while contacting task-service with UpdateTask RPC : " + err.Error())
+	}
+	var threadID int = 1
+	ctxt := context.WithValue(ctx, common.ThreadName, common.RemediateFirmwareDrift)
+	ctxt = context.WithValue(ctxt, common.ThreadID, strconv.Itoa(threadID))
+	go a.connector.RemediateFirmwareDrift(ctxt, taskID, sessionUserName, req)
+	// return 202 Accepted
+	var rpcResp = response.RPC{
+		StatusCode:    http.StatusAccepted,
+		StatusMessage: response.TaskStarted,
+		Header: map[string]string{
+			"Location": "/taskmon/" + taskID,
+		},
+	}
+	generateTaskRespone(taskID, taskURI, &rpcResp)
+	generateRPCResponse(rpcResp, resp)
+	l.LogWithFields(ctx).Debugf("final response for remediate firmware drift request: %s", string(resp.Body))
+	return resp, nil
+}
+
+// isAuthorized checks the session of the request has the privilege, filling the response when it does not
+func (a *Updater) isAuthorized(ctx context.Context, req *updateproto.UpdateRequest, resp *updateproto.UpdateResponse, privilege string) bool {
+	authResp, err := a.connector.External.Auth(ctx, req.SessionToken, []string{privilege}, []string{})
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		fillProtoResponse(ctx, resp, authResp)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,94 @@
+// (C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	updateproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/update"
+	"github.com/ODIM-Project/ODIM/svc-update/umodel"
+)
+
+func mockGetFirmwareBaseline(ctx context.Context, baselineID string) (umodel.FirmwareBaseline, *errors.Error) {
+	if baselineID != "b1" {
+		return umodel.FirmwareBaseline{}, errors.PackError(errors.DBKeyNotFound, "no data with the key found")
+	}
+	return umodel.FirmwareBaseline{ID: "b1", Name: "gen10", Components: []umodel.BaselineComponent{{Name: "iLO 5", Version: "2.0"}}}, nil
+}
+
+func TestUpdater_FirmwareBaseline(t *testing.T) {
+	config.SetUpMockConfig(t)
+	update := new(Updater)
+	update.connector = mockGetExternalInterface()
+	update.connector.DB.GetFirmwareBaseline = mockGetFirmwareBaseline
+	tests := []struct {
+		name       string
+		rpc        func(context.Context, *updateproto.UpdateRequest) (*updateproto.UpdateResponse, error)
+		req        *updateproto.UpdateRequest
+		wantStatus int32
+	}{
+		{
+			name:       "get baseline with invalid token",
+			rpc:        update.GetFirmwareBaseline,
+			req:        &updateproto.UpdateRequest{SessionToken: "invalidToken", ResourceID: "b1"},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "get baseline",
+			rpc:        update.GetFirmwareBaseline,
+			req:        &updateproto.UpdateRequest{SessionToken: "validToken", ResourceID: "b1"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "compliance report of unknown baseline",
+			rpc:        update.GetFirmwareComplianceReport,
+			req:        &updateproto.UpdateRequest{SessionToken: "validToken", ResourceID: "b2"},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "remediate with invalid token",
+			rpc:        update.RemediateFirmwareDrift,
+			req:        &updateproto.UpdateRequest{SessionToken: "invalidToken", ResourceID: "b1"},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "remediate unknown baseline",
+			rpc:        update.RemediateFirmwareDrift,
+			req:        &updateproto.UpdateRequest{SessionToken: "validToken", ResourceID: "b2"},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "remediate baseline",
+			rpc:        update.RemediateFirmwareDrift,
+			req:        &updateproto.UpdateRequest{SessionToken: "validToken", ResourceID: "b1"},
+			wantStatus: http.StatusAccepted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := tt.rpc(mockContext(), tt.req)
+			if err != nil {
+				t.Fatalf("rpc error = %v", err)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("rpc status = %v, want %v", resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
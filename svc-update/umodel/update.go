@@ -35,6 +35,43 @@ type Target struct {
 	PluginID       string `json:"PluginID"`
 }
 
+// Aggregate holds the servers of an aggregate
+type Aggregate struct {
+	Elements []OdataID `json:"Elements"`
+}
+
+// OdataID holds the link to a resource
+type OdataID struct {
+	OdataID string `json:"@odata.id"`
+}
+
+// FirmwareBaselineTable is the table of the firmware baselines
+const FirmwareBaselineTable = "FirmwareBaseline"
+
+// FirmwareBaseline is a named desired state of the firmware of servers
+type FirmwareBaseline struct {
+	ID          string `json:"Id"`
+	Name        string `json:"Name"`
+	Description string `json:"Description,omitempty"`
+	// ApplicableModels are the models of the servers the baseline applies to,
+	// it applies to all the servers when empty
+	ApplicableModels []string            `json:"ApplicableModels,omitempty"`
+	Components       []BaselineComponent `json:"Components"`
+}
+
+// BaselineComponent is the version a firmware component must have
+type BaselineComponent struct {
+	// Name or SoftwareID identifies the component in the FirmwareInventory,
+	// SoftwareID is used when both are given
+	Name       string `json:"Name,omitempty"`
+	SoftwareID string `json:"SoftwareId,omitempty"`
+	Version    string `json:"Version"`
+	// VersionMatch is Minimum, which is the default, or Exact
+	VersionMatch string `json:"VersionMatch,omitempty"`
+	// ImageURI is the image used to update the component when it has drifted
+	ImageURI string `json:"ImageURI,omitempty"`
+}
+
 // Plugin defines plugin configuration
 type Plugin struct {
 	IP                string
@@ -136,3 +173,62 @@ func GetPluginData(pluginID string) (Plugin, *errors.Error) {
 
 	return plugin, nil
 }
+
+// GetAggregate fetches the aggregate of the given URI
+func GetAggregate(ctx context.Context, aggregateURI string) (Aggregate, *errors.Error) {
+	var aggregate Aggregate
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return aggregate, err
+	}
+	data, err := conn.Read("Aggregate", aggregateURI)
+	if err != nil {
+		return aggregate, errors.PackError(err.ErrNo(), "error while trying to fetch aggregate data: ", err.Error())
+	}
+	if err := json.Unmarshal([]byte(data), &aggregate); err != nil {
+		return aggregate, errors.PackError(errors.JSONUnmarshalFailed, err)
+	}
+	l.LogWithFields(ctx).Debugf("servers of aggregate %s: %v", aggregateURI, aggregate.Elements)
+	return aggregate, nil
+}
+
+// CreateFirmwareBaseline saves a new firmware baseline
+func CreateFirmwareBaseline(ctx context.Context, baseline FirmwareBaseline) *errors.Error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	if err := conn.Create(FirmwareBaselineTable, baseline.ID, baseline); err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to save firmware baseline: ", err.Error())
+	}
+	return nil
+}
+
+// GetFirmwareBaseline fetches the firmware baseline of the given ID
+func GetFirmwareBaseline(ctx context.Context, baselineID string) (FirmwareBaseline, *errors.Error) {
+	var baseline FirmwareBaseline
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return baseline, err
+	}
+	data, err := conn.Read(FirmwareBaselineTable, baselineID)
+	if err != nil {
+		return baseline, errors.PackError(err.ErrNo(), "error while trying to fetch firmware baseline: ", err.Error())
+	}
+	if err := json.Unmarshal([]byte(data), &baseline); err != nil {
+		return baseline, errors.PackError(errors.JSONUnmarshalFailed, err)
+	}
+	return baseline, nil
+}
+
+// DeleteFirmwareBaseline deletes the firmware baseline of the given ID
+func DeleteFirmwareBaseline(ctx context.Context, baselineID string) *errors.Error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	if err := conn.Delete(FirmwareBaselineTable, baselineID); err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to delete firmware baseline: ", err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,613 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	updateproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/update"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-update/umodel"
+	"github.com/ODIM-Project/ODIM/svc-update/uresponse"
+	"github.com/google/uuid"
+)
+
+const (
+	firmwareBaselinesURI = "/redfish/v1/UpdateService/Oem/Odim/FirmwareBaselines"
+	versionMatchMinimum  = "Minimum"
+	versionMatchExact    = "Exact"
+	// driftStatusDrifted and driftStatusMissing are the statuses of the components of a server
+	// at another version than the baseline, and of the components the server does not have
+	driftStatusDrifted = "Drifted"
+	driftStatusMissing = "Missing"
+)
+
+// RemediateRequest defines the request body of the remediate action of a firmware baseline,
+// all the properties are optional
+type RemediateRequest struct {
+	BatchSize                 int `json:"BatchSize,omitempty"`
+	FailureThreshold          int `json:"FailureThreshold,omitempty"`
+	HealthCheckTimeoutSeconds int `json:"HealthCheckTimeoutSeconds,omitempty"`
+	// Targets limits the remediation to the given systems
+	Targets []string `json:"Targets,omitempty"`
+}
+
+// firmwareComponent is a firmware component stored in the FirmwareInventory of ODIM
+type firmwareComponent struct {
+	uri        string
+	Name       string `json:"Name"`
+	SoftwareID string `json:"SoftwareId"`
+	Version    string `json:"Version"`
+}
+
+// CreateFirmwareBaseline validates and saves a new firmware baseline
+func (e *ExternalInterface) CreateFirmwareBaseline(ctx context.Context, req *updateproto.UpdateRequest) response.RPC {
+	var baseline umodel.FirmwareBaseline
+	if err := json.Unmarshal(req.RequestBody, &baseline); err != nil {
+		errMsg := "unable to parse the create firmware baseline request: " + err.Error()
+		l.LogWithFields(ctx).Warn(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, nil)
+	}
+	invalidProperties, err := RequestParamsCaseValidatorFunc(req.RequestBody, baseline)
+	if err != nil {
+		errMsg := "Unable to validate request parameters: " + err.Error()
+		l.LogWithFields(ctx).Warn(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	} else if invalidProperties != "" {
+		errMsg := "One or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase "
+		l.LogWithFields(ctx).Warn(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errMsg, []interface{}{invalidProperties}, nil)
+	}
+	if resp, ok := validateFirmwareBaseline(ctx, &baseline); !ok {
+		return resp
+	}
+
+	ids, err := e.DB.GetAllKeysFromTable(ctx, umodel.FirmwareBaselineTable, common.OnDisk)
+	if err != nil {
+		errMsg := "unable to get the firmware baselines: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+	for _, id := range ids {
+		existing, gerr := e.DB.GetFirmwareBaseline(ctx, id)
+		if gerr != nil {
+			l.LogWithFields(ctx).Warn("unable to get the firmware baseline " + id + ": " + gerr.Error())
+			continue
+		}
+		if existing.Name == baseline.Name {
+			errMsg := "a firmware baseline with the name " + baseline.Name + " already exists"
+			l.LogWithFields(ctx).Warn(errMsg)
+			return common.GeneralError(http.StatusConflict, response.ResourceAlreadyExists, errMsg, []interface{}{"FirmwareBaseline", "Name", baseline.Name}, nil)
+		}
+	}
+
+	baseline.ID = uuid.NewString()
+	if gerr := e.DB.CreateFirmwareBaseline(ctx, baseline); gerr != nil {
+		errMsg := "unable to save the firmware baseline: " + gerr.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+	l.LogWithFields(ctx).Infof("firmware baseline %s is created with the name %s", baseline.ID, baseline.Name)
+	resp := firmwareBaselineResponse(baseline)
+	resp.StatusCode = http.StatusCreated
+	resp.Header = map[string]string{
+		"Location": firmwareBaselinesURI + "/" + baseline.ID,
+	}
+	return resp
+}
+
+// validateFirmwareBaseline checks the properties of a baseline to be created and sets the defaults
+func validateFirmwareBaseline(ctx context.Context, baseline *umodel.FirmwareBaseline) (response.RPC, bool) {
+	missing := func(property string) (response.RPC, bool) {
+		errMsg := "'" + property + "' parameter cannot be empty"
+		l.LogWithFields(ctx).Warn(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{property}, nil), false
+	}
+	if baseline.Name == "" {
+		return missing("Name")
+	}
+	if len(baseline.Components) == 0 {
+		return missing("Components")
+	}
+	for i := range baseline.Components {
+		component := &baseline.Components[i]
+		if component.Name == "" && component.SoftwareID == "" {
+			return missing("Components/" + strconv.Itoa(i) + "/Name")
+		}
+		if component.Version == "" {
+			return missing("Components/" + strconv.Itoa(i) + "/Version")
+		}
+		switch component.VersionMatch {
+		case "":
+			component.VersionMatch = versionMatchMinimum
+		case versionMatchMinimum, versionMatchExact:
+		default:
+			errMsg := "VersionMatch must be " + versionMatchMinimum + " or " + versionMatchExact
+			l.LogWithFields(ctx).Warn(errMsg)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errMsg, []interface{}{component.VersionMatch, "VersionMatch"}, nil), false
+		}
+	}
+	return response.RPC{}, true
+}
+
+// GetFirmwareBaselineCollection lists the firmware baselines
+func (e *ExternalInterface) GetFirmwareBaselineCollection(ctx context.Context, req *updateproto.UpdateRequest) response.RPC {
+	ids, err := e.DB.GetAllKeysFromTable(ctx, umodel.FirmwareBaselineTable, common.OnDisk)
+	if err != nil {
+		errMsg := "unable to get the firmware baselines: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+	sort.Strings(ids)
+	members := []dmtf.Link{}
+	for _, id := range ids {
+		members = append(members, dmtf.Link{Oid: firmwareBaselinesURI + "/" + id})
+	}
+	var resp response.RPC
+	resp.StatusCode = http.StatusOK
+	resp.StatusMessage = response.Success
+	resp.Body = uresponse.Collection{
+		OdataContext: "/redfish/v1/$metadata#FirmwareBaselineCollection.FirmwareBaselineCollection",
+		OdataID:      firmwareBaselinesURI,
+		OdataType:    "#FirmwareBaselineCollection.FirmwareBaselineCollection",
+		Description:  "Firmware baselines view",
+		Name:         "Firmware Baselines",
+		Members:      members,
+		MembersCount: len(members),
+	}
+	return resp
+}
+
+// GetFirmwareBaseline gets the firmware baseline of the request
+func (e *ExternalInterface) GetFirmwareBaseline(ctx context.Context, req *updateproto.UpdateRequest) response.RPC {
+	baseline, resp, ok := e.getFirmwareBaseline(ctx, req.ResourceID)
+	if !ok {
+		return resp
+	}
+	return firmwareBaselineResponse(baseline)
+}
+
+// DeleteFirmwareBaseline deletes the firmware baseline of the request
+func (e *ExternalInterface) DeleteFirmwareBaseline(ctx context.Context, req *updateproto.UpdateRequest) response.RPC {
+	if _, resp, ok := e.getFirmwareBaseline(ctx, req.ResourceID); !ok {
+		return resp
+	}
+	if gerr := e.DB.DeleteFirmwareBaseline(ctx, req.ResourceID); gerr != nil {
+		errMsg := "unable to delete the firmware baseline " + req.ResourceID + ": " + gerr.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+	l.LogWithFields(ctx).Infof("firmware baseline %s is deleted", req.ResourceID)
+	return response.RPC{
+		StatusCode:    http.StatusNoContent,
+		StatusMessage: response.ResourceRemoved,
+	}
+}
+
+// GetFirmwareComplianceReport reports how the firmware of the servers complies with the baseline of the request
+func (e *ExternalInterface) GetFirmwareComplianceReport(ctx context.Context, req *updateproto.UpdateRequest) response.RPC {
+	baseline, resp, ok := e.getFirmwareBaseline(ctx, req.ResourceID)
+	if !ok {
+		return resp
+	}
+	report, err := e.complianceReport(ctx, baseline)
+	if err != nil {
+		errMsg := "unable to evaluate the compliance with the firmware baseline " + baseline.ID + ": " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+	resp.StatusCode = http.StatusOK
+	resp.StatusMessage = response.Success
+	resp.Body = report
+	return resp
+}
+
+// CheckFirmwareBaseline tells whether the baseline of the request exists, so that a
+// remediation task is created only for an existing baseline
+func (e *ExternalInterface) CheckFirmwareBaseline(ctx context.Context, req *updateproto.UpdateRequest) (response.RPC, bool) {
+	_, resp, ok := e.getFirmwareBaseline(ctx, req.ResourceID)
+	return resp, ok
+}
+
+// RemediateFirmwareDrift updates the drifted firmware components of the servers to the
+// versions of the baseline. The servers are updated in batches, one component at a time, with
+// the image of the component in the baseline. The task is left suspended, without updating
+// the remaining components, when the rollout of a component is paused.
+func (e *ExternalInterface) RemediateFirmwareDrift(ctx context.Context, taskID, sessionUserName string, req *updateproto.UpdateRequest) {
+	targetURI := firmwareBaselinesURI + "/" + req.ResourceID + "/Actions/FirmwareBaseline.Remediate"
+	taskInfo := &common.TaskUpdateInfo{Context: ctx, TaskID: taskID, TargetURI: targetURI, UpdateTask: e.External.UpdateTask, TaskRequest: string(req.RequestBody)}
+
+	var remediateRequest RemediateRequest
+	if len(req.RequestBody) > 0 {
+		if err := json.Unmarshal(req.RequestBody, &remediateRequest); err != nil {
+			errMsg := "unable to parse the remediate request: " + err.Error()
+			l.LogWithFields(ctx).Warn(errMsg)
+			common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, taskInfo)
+			return
+		}
+		invalidProperties, err := RequestParamsCaseValidatorFunc(req.RequestBody, remediateRequest)
+		if err != nil {
+			errMsg := "Unable to validate request parameters: " + err.Error()
+			l.LogWithFields(ctx).Warn(errMsg)
+			common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+			return
+		} else if invalidProperties != "" {
+			errMsg := "One or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase "
+			l.LogWithFields(ctx).Warn(errMsg)
+			common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errMsg, []interface{}{invalidProperties}, taskInfo)
+			return
+		}
+	}
+	rollout := RolloutRequest{
+		BatchSize:                 remediateRequest.BatchSize,
+		FailureThreshold:          remediateRequest.FailureThreshold,
+		HealthCheckTimeoutSeconds: remediateRequest.HealthCheckTimeoutSeconds,
+	}
+	policy := common.RolloutPolicy{BatchSize: rollout.BatchSize, FailureThreshold: rollout.FailureThreshold}
+	if err := policy.Validate(); err != nil || rollout.HealthCheckTimeoutSeconds < 0 {
+		errMsg := "invalid rollout properties: BatchSize, FailureThreshold and HealthCheckTimeoutSeconds must not be negative"
+		l.LogWithFields(ctx).Warn(errMsg)
+		common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errMsg, []interface{}{fmt.Sprintf("%v", remediateRequest), "RequestBody"}, taskInfo)
+		return
+	}
+	targets := make(map[string]bool)
+	for _, target := range remediateRequest.Targets {
+		systemID, err := systemIDOf(target)
+		if err != nil {
+			l.LogWithFields(ctx).Warn(err.Error())
+			common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, err.Error(), []interface{}{target, "Targets"}, taskInfo)
+			return
+		}
+		targets[systemID] = true
+	}
+
+	baseline, gerr := e.DB.GetFirmwareBaseline(ctx, req.ResourceID)
+	if gerr != nil {
+		errMsg := "unable to get the firmware baseline " + req.ResourceID + ": " + gerr.Error()
+		l.LogWithFields(ctx).Warn(errMsg)
+		common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"FirmwareBaseline", req.ResourceID}, taskInfo)
+		return
+	}
+	report, err := e.complianceReport(ctx, baseline)
+	if err != nil {
+		errMsg := "unable to evaluate the compliance with the firmware baseline " + baseline.ID + ": " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+		return
+	}
+
+	// the drifted servers of each component, in the order of the components in the baseline
+	driftedServers := make([][]string, len(baseline.Components))
+	var total int
+	var withoutImage, missing []string
+	for _, system := range report.Systems {
+		systemID := system.System.Oid[strings.LastIndex(system.System.Oid, "/")+1:]
+		if len(targets) > 0 && !targets[systemID] {
+			continue
+		}
+		for _, drifted := range system.DriftedComponents {
+			i := baselineComponentIndex(baseline, drifted)
+			if i < 0 {
+				name := componentName(umodel.BaselineComponent{Name: drifted.Name, SoftwareID: drifted.SoftwareID})
+				l.LogWithFields(ctx).Warnf("drifted component %s of server %s is not in the baseline %s", name, systemID, baseline.ID)
+				continue
+			}
+			// an update would not install the component the server does not have
+			if drifted.Status == driftStatusMissing {
+				missing = append(missing, systemID+" "+componentName(baseline.Components[i]))
+				continue
+			}
+			if baseline.Components[i].ImageURI == "" {
+				withoutImage = append(withoutImage, systemID+" "+componentName(baseline.Components[i]))
+				continue
+			}
+			driftedServers[i] = append(driftedServers[i], systemID)
+			total++
+		}
+	}
+	note := ""
+	if len(withoutImage) > 0 {
+		note = fmt.Sprintf("the components %v are not remediated as the baseline has no image for them. ", withoutImage)
+	}
+	if len(missing) > 0 {
+		note += fmt.Sprintf("the components %v are not remediated as the servers do not have them. ", missing)
+	}
+	if total == 0 {
+		var result common.RolloutResult
+		e.completeRollout(ctx, taskID, taskInfo, result, rolloutProgress{total: 1}, note)
+		return
+	}
+
+	var result common.RolloutResult
+	for i, component := range baseline.Components {
+		servers := driftedServers[i]
+		if len(servers) == 0 {
+			continue
+		}
+		l.LogWithFields(ctx).Infof("remediating the firmware component %s of servers %v to version %s", componentName(component), servers, component.Version)
+		serverTargets := make(map[string][]string, len(servers))
+		for _, systemID := range servers {
+			serverTargets[systemID] = []string{"/redfish/v1/Systems/" + systemID}
+		}
+		rollout.ExpectedVersion = component.Version
+		rollout.ComponentName = component.Name
+		rollout.SoftwareID = component.SoftwareID
+		rollout.VersionMatch = component.VersionMatch
+		if rollout.VersionMatch == "" {
+			rollout.VersionMatch = versionMatchMinimum
+		}
+		updateRequest := SimpleUpdateRequest{ImageURI: component.ImageURI}
+		progress := rolloutProgress{done: result.Done(), total: total}
+		componentResult := e.rollOutUpdate(ctx, taskID, sessionUserName, updateRequest, rollout, servers, serverTargets, taskInfo, progress)
+		result.Succeeded = append(result.Succeeded, componentResult.Succeeded...)
+		result.Failed = append(result.Failed, componentResult.Failed...)
+		if componentResult.Paused() {
+			result.Skipped = append(result.Skipped, componentResult.Skipped...)
+			for _, remaining := range driftedServers[i+1:] {
+				result.Skipped = append(result.Skipped, remaining...)
+			}
+			break
+		}
+	}
+	e.completeRollout(ctx, taskID, taskInfo, result, rolloutProgress{total: total}, note)
+}
+
+// getFirmwareBaseline reads the baseline of the given ID, returning the error response when it fails
+func (e *ExternalInterface) getFirmwareBaseline(ctx context.Context, baselineID string) (umodel.FirmwareBaseline, response.RPC, bool) {
+	baseline, gerr := e.DB.GetFirmwareBaseline(ctx, baselineID)
+	if gerr != nil {
+		errMsg := "unable to get the firmware baseline " + baselineID + ": " + gerr.Error()
+		l.LogWithFields(ctx).Warn(errMsg)
+		if gerr.ErrNo() == errors.DBKeyNotFound {
+			return baseline, common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"FirmwareBaseline", baselineID}, nil), false
+		}
+		return baseline, common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil), false
+	}
+	return baseline, response.RPC{}, true
+}
+
+func firmwareBaselineResponse(baseline umodel.FirmwareBaseline) response.RPC {
+	baselineURI := firmwareBaselinesURI + "/" + baseline.ID
+	return response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+		Body: uresponse.FirmwareBaseline{
+			OdataContext:     "/redfish/v1/$metadata#FirmwareBaseline.FirmwareBaseline",
+			OdataID:          baselineURI,
+			OdataType:        "#FirmwareBaseline.v1_0_0.FirmwareBaseline",
+			ID:               baseline.ID,
+			Name:             baseline.Name,
+			Description:      baseline.Description,
+			ApplicableModels: baseline.ApplicableModels,
+			Components:       baseline.Components,
+			ComplianceReport: dmtf.Link{Oid: baselineURI + "/ComplianceReport"},
+			Actions: uresponse.FirmwareBaselineActions{
+				Remediate: uresponse.FirmwareBaselineRemediate{
+					Target: baselineURI + "/Actions/FirmwareBaseline.Remediate",
+				},
+			},
+		},
+	}
+}
+
+// complianceReport compares the firmware of the servers the baseline applies to, as stored in the
+// FirmwareInventory of ODIM, with the components of the baseline
+func (e *ExternalInterface) complianceReport(ctx context.Context, baseline umodel.FirmwareBaseline) (uresponse.FirmwareComplianceReport, error) {
+	baselineURI := firmwareBaselinesURI + "/" + baseline.ID
+	report := uresponse.FirmwareComplianceReport{
+		OdataID:   baselineURI + "/ComplianceReport",
+		OdataType: "#FirmwareComplianceReport.v1_0_0.FirmwareComplianceReport",
+		ID:        "ComplianceReport",
+		Name:      "Compliance report of firmware baseline " + baseline.Name,
+		Baseline:  dmtf.Link{Oid: baselineURI},
+		Systems:   []uresponse.SystemCompliance{},
+	}
+
+	firmwareKeys, err := e.DB.GetAllKeysFromTable(ctx, "FirmwareInventory", common.InMemory)
+	if err != nil {
+		return report, err
+	}
+	firmwareOfServer := make(map[string][]firmwareComponent)
+	for _, key := range firmwareKeys {
+		data, gerr := e.DB.GetResource(ctx, "FirmwareInventory", key, common.InMemory)
+		if gerr != nil {
+			l.LogWithFields(ctx).Warn("unable to get the firmware inventory " + key + ": " + gerr.Error())
+			continue
+		}
+		var firmware firmwareComponent
+		if err := json.Unmarshal([]byte(data), &firmware); err != nil {
+			l.LogWithFields(ctx).Warn("unable to parse the firmware inventory " + key + ": " + err.Error())
+			continue
+		}
+		firmware.uri = key
+		serverUUID := strings.SplitN(key[strings.LastIndex(key, "/")+1:], ".", 2)[0]
+		firmwareOfServer[serverUUID] = append(firmwareOfServer[serverUUID], firmware)
+	}
+
+	systemKeys, err := e.DB.GetAllKeysFromTable(ctx, "ComputerSystem", common.InMemory)
+	if err != nil {
+		return report, err
+	}
+	sort.Strings(systemKeys)
+	for _, key := range systemKeys {
+		systemID := key[strings.LastIndex(key, "/")+1:]
+		if !strings.Contains(systemID, ".") {
+			continue
+		}
+		data, gerr := e.DB.GetResource(ctx, "ComputerSystem", key, common.InMemory)
+		if gerr != nil {
+			l.LogWithFields(ctx).Warn("unable to get the system " + key + ": " + gerr.Error())
+			continue
+		}
+		var system struct {
+			Model string `json:"Model"`
+		}
+		if err := json.Unmarshal([]byte(data), &system); err != nil {
+			l.LogWithFields(ctx).Warn("unable to parse the system " + key + ": " + err.Error())
+			continue
+		}
+		if !isApplicableModel(baseline, system.Model) {
+			continue
+		}
+		compliance := uresponse.SystemCompliance{
+			System: dmtf.Link{Oid: key},
+			Model:  system.Model,
+		}
+		firmware := firmwareOfServer[strings.SplitN(systemID, ".", 2)[0]]
+		for _, component := range baseline.Components {
+			if drifted, ok := driftOf(component, firmware); ok {
+				compliance.DriftedComponents = append(compliance.DriftedComponents, drifted)
+			}
+		}
+		compliance.Compliant = len(compliance.DriftedComponents) == 0
+		if compliance.Compliant {
+			report.CompliantSystemsCount++
+		} else {
+			report.DriftedSystemsCount++
+		}
+		report.Systems = append(report.Systems, compliance)
+	}
+	return report, nil
+}
+
+func isApplicableModel(baseline umodel.FirmwareBaseline, model string) bool {
+	if len(baseline.ApplicableModels) == 0 {
+		return true
+	}
+	for _, applicableModel := range baseline.ApplicableModels {
+		if strings.EqualFold(applicableModel, model) {
+			return true
+		}
+	}
+	return false
+}
+
+// driftOf returns how the component of the server has drifted from the baseline component,
+// and false when it complies. The component complies when every FirmwareInventory resource
+// of the component complies with the version of the baseline component. A component without
+// FirmwareInventory resources is reported Missing.
+func driftOf(component umodel.BaselineComponent, firmware []firmwareComponent) (uresponse.DriftedComponent, bool) {
+	drifted := uresponse.DriftedComponent{
+		Name:            component.Name,
+		SoftwareID:      component.SoftwareID,
+		Status:          driftStatusDrifted,
+		ExpectedVersion: component.Version,
+		VersionMatch:    component.VersionMatch,
+	}
+	if drifted.VersionMatch == "" {
+		drifted.VersionMatch = versionMatchMinimum
+	}
+	found := false
+	for _, installed := range firmware {
+		if !isComponent(component.Name, component.SoftwareID, installed) {
+			continue
+		}
+		if !versionComplies(installed.Version, component.Version, drifted.VersionMatch) {
+			drifted.InstalledVersion = installed.Version
+			drifted.FirmwareInventory = &dmtf.Link{Oid: installed.uri}
+			return drifted, true
+		}
+		found = true
+	}
+	if !found {
+		drifted.Status = driftStatusMissing
+	}
+	return drifted, !found
+}
+
+// versionComplies tells whether the installed version complies with the expected version
+// under the version match rule, Minimum or Exact
+func versionComplies(installed, expected, versionMatch string) bool {
+	if versionMatch == versionMatchMinimum {
+		return compareVersions(installed, expected) >= 0
+	}
+	return installed == expected
+}
+
+// compareVersions compares two firmware versions token by token, the numeric tokens numerically
+// and the others lexically, and returns -1, 0 or 1 as a is lower than, equal to or higher than b
+func compareVersions(a, b string) int {
+	split := func(version string) []string {
+		return strings.FieldsFunc(version, func(r rune) bool {
+			return r == '.' || r == '-' || r == '_' || r == ' '
+		})
+	}
+	tokensA, tokensB := split(a), split(b)
+	for i := 0; i < len(tokensA) || i < len(tokensB); i++ {
+		var tokenA, tokenB string
+		if i < len(tokensA) {
+			tokenA = tokensA[i]
+		}
+		if i < len(tokensB) {
+			tokenB = tokensB[i]
+		}
+		numberA, errA := strconv.Atoi(tokenA)
+		numberB, errB := strconv.Atoi(tokenB)
+		if tokenA == "" && errB == nil {
+			numberA, errA = 0, nil
+		}
+		if tokenB == "" && errA == nil {
+			numberB, errB = 0, nil
+		}
+		switch {
+		case errA == nil && errB == nil:
+			if numberA != numberB {
+				if numberA < numberB {
+					return -1
+				}
+				return 1
+			}
+		case tokenA != tokenB:
+			if tokenA < tokenB {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// baselineComponentIndex returns the index of the baseline component the drifted component
+// is of, or -1 when the baseline has no such component
+func baselineComponentIndex(baseline umodel.FirmwareBaseline, drifted uresponse.DriftedComponent) int {
+	for i, component := range baseline.Components {
+		versionMatch := component.VersionMatch
+		if versionMatch == "" {
+			versionMatch = versionMatchMinimum
+		}
+		if component.Name == drifted.Name && component.SoftwareID == drifted.SoftwareID &&
+			component.Version == drifted.ExpectedVersion && versionMatch == drifted.VersionMatch {
+			return i
+		}
+	}
+	return -1
+}
+
+func componentName(component umodel.BaselineComponent) string {
+	if component.SoftwareID != "" {
+		return component.SoftwareID
+	}
+	return component.Name
+}
@@ -0,0 +1,415 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	updateproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/update"
+	"github.com/ODIM-Project/ODIM/svc-update/umodel"
+	"github.com/ODIM-Project/ODIM/svc-update/uresponse"
+)
+
+// fakeBaselineDB holds the firmware baselines and the inventory of a baseline test
+type fakeBaselineDB struct {
+	baselines map[string]umodel.FirmwareBaseline
+	// tables holds the resources of the in-memory tables by their keys
+	tables map[string]map[string]string
+}
+
+func (f *fakeBaselineDB) getAllKeysFromTable(ctx context.Context, table string, dbType common.DbType) ([]string, error) {
+	var keys []string
+	if table == umodel.FirmwareBaselineTable {
+		for id := range f.baselines {
+			keys = append(keys, id)
+		}
+		return keys, nil
+	}
+	for key := range f.tables[table] {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (f *fakeBaselineDB) getResource(ctx context.Context, table, key string, dbType common.DbType) (string, *errors.Error) {
+	data, ok := f.tables[table][key]
+	if !ok {
+		return "", errors.PackError(errors.DBKeyNotFound, "no data with the key found")
+	}
+	return data, nil
+}
+
+func (f *fakeBaselineDB) createFirmwareBaseline(ctx context.Context, baseline umodel.FirmwareBaseline) *errors.Error {
+	f.baselines[baseline.ID] = baseline
+	return nil
+}
+
+func (f *fakeBaselineDB) getFirmwareBaseline(ctx context.Context, baselineID string) (umodel.FirmwareBaseline, *errors.Error) {
+	baseline, ok := f.baselines[baselineID]
+	if !ok {
+		return baseline, errors.PackError(errors.DBKeyNotFound, "no data with the key found")
+	}
+	return baseline, nil
+}
+
+func (f *fakeBaselineDB) deleteFirmwareBaseline(ctx context.Context, baselineID string) *errors.Error {
+	delete(f.baselines, baselineID)
+	return nil
+}
+
+func (f *fakeBaselineDB) setDB(e *ExternalInterface) {
+	e.DB.GetAllKeysFromTable = f.getAllKeysFromTable
+	e.DB.GetResource = f.getResource
+	e.DB.CreateFirmwareBaseline = f.createFirmwareBaseline
+	e.DB.GetFirmwareBaseline = f.getFirmwareBaseline
+	e.DB.DeleteFirmwareBaseline = f.deleteFirmwareBaseline
+}
+
+// newFakeBaselineDB returns a fake DB with a server of the given model and BMC firmware
+// version for each of the given uuids
+func newFakeBaselineDB(servers map[string][2]string) *fakeBaselineDB {
+	f := &fakeBaselineDB{
+		baselines: map[string]umodel.FirmwareBaseline{
+			"b1": {
+				ID:               "b1",
+				Name:             "gen10",
+				ApplicableModels: []string{"ProLiant DL360 Gen10"},
+				Components: []umodel.BaselineComponent{
+					{Name: "iLO 5", Version: "2.0", VersionMatch: versionMatchMinimum, ImageURI: "http://images/ilo5.bin"},
+				},
+			},
+		},
+		tables: map[string]map[string]string{"ComputerSystem": {}, "FirmwareInventory": {}},
+	}
+	for uuid, server := range servers {
+		system, _ := json.Marshal(map[string]string{"Model": server[0]})
+		firmware, _ := json.Marshal(map[string]string{"Name": "iLO 5", "Version": server[1]})
+		f.tables["ComputerSystem"]["/redfish/v1/Systems/"+uuid+".1"] = string(system)
+		// the server does not have the BMC firmware without version
+		if server[1] != "" {
+			f.tables["FirmwareInventory"]["/redfish/v1/UpdateService/FirmwareInventory/"+uuid+".1"] = string(firmware)
+		}
+	}
+	return f
+}
+
+func TestCreateFirmwareBaseline(t *testing.T) {
+	config.SetUpMockConfig(t)
+	tests := []struct {
+		name       string
+		request    string
+		wantStatus int32
+	}{
+		{
+			name:       "valid baseline",
+			request:    `{"Name":"gen11","Components":[{"SoftwareId":"bios","Version":"1.2","VersionMatch":"Exact"}]}`,
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "name missing",
+			request:    `{"Components":[{"Name":"iLO 5","Version":"2.10"}]}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "components missing",
+			request:    `{"Name":"gen11"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "version missing",
+			request:    `{"Name":"gen11","Components":[{"Name":"iLO 5"}]}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid version match",
+			request:    `{"Name":"gen11","Components":[{"Name":"iLO 5","Version":"2.10","VersionMatch":"Maximum"}]}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "name already used",
+			request:    `{"Name":"gen10","Components":[{"Name":"iLO 5","Version":"2.10"}]}`,
+			wantStatus: http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newFakeBaselineDB(nil)
+			e := mockGetExternalInterface()
+			db.setDB(e)
+			resp := e.CreateFirmwareBaseline(mockContext(), &updateproto.UpdateRequest{RequestBody: []byte(tt.request)})
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("CreateFirmwareBaseline() status = %v, want %v", resp.StatusCode, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusCreated {
+				return
+			}
+			if len(db.baselines) != 2 {
+				t.Errorf("CreateFirmwareBaseline() baselines = %v, want 2", len(db.baselines))
+			}
+			baseline := resp.Body.(uresponse.FirmwareBaseline)
+			if resp.Header["Location"] != firmwareBaselinesURI+"/"+baseline.ID {
+				t.Errorf("CreateFirmwareBaseline() location = %v", resp.Header["Location"])
+			}
+		})
+	}
+}
+
+func TestGetAndDeleteFirmwareBaseline(t *testing.T) {
+	config.SetUpMockConfig(t)
+	db := newFakeBaselineDB(nil)
+	e := mockGetExternalInterface()
+	db.setDB(e)
+
+	resp := e.GetFirmwareBaselineCollection(mockContext(), &updateproto.UpdateRequest{})
+	if collection := resp.Body.(uresponse.Collection); collection.MembersCount != 1 || collection.Members[0].Oid != firmwareBaselinesURI+"/b1" {
+		t.Errorf("GetFirmwareBaselineCollection() = %v", collection)
+	}
+	resp = e.GetFirmwareBaseline(mockContext(), &updateproto.UpdateRequest{ResourceID: "b1"})
+	if resp.StatusCode != http.StatusOK || resp.Body.(uresponse.FirmwareBaseline).Name != "gen10" {
+		t.Errorf("GetFirmwareBaseline() = %v", resp)
+	}
+	resp = e.DeleteFirmwareBaseline(mockContext(), &updateproto.UpdateRequest{ResourceID: "b1"})
+	if resp.StatusCode != http.StatusNoContent || len(db.baselines) != 0 {
+		t.Errorf("DeleteFirmwareBaseline() status = %v, baselines = %v", resp.StatusCode, db.baselines)
+	}
+	resp = e.GetFirmwareBaseline(mockContext(), &updateproto.UpdateRequest{ResourceID: "b1"})
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("GetFirmwareBaseline() of deleted baseline status = %v, want %v", resp.StatusCode, http.StatusNotFound)
+	}
+}
+
+func TestGetFirmwareComplianceReport(t *testing.T) {
+	config.SetUpMockConfig(t)
+	db := newFakeBaselineDB(map[string][2]string{
+		"s1": {"ProLiant DL360 Gen10", "2.30"},
+		"s2": {"ProLiant DL360 Gen10", "1.10"},
+		"s3": {"ProLiant DL380 Gen9", "1.0"},
+	})
+	e := mockGetExternalInterface()
+	db.setDB(e)
+
+	resp := e.GetFirmwareComplianceReport(mockContext(), &updateproto.UpdateRequest{ResourceID: "b1"})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GetFirmwareComplianceReport() status = %v, want %v", resp.StatusCode, http.StatusOK)
+	}
+	report := resp.Body.(uresponse.FirmwareComplianceReport)
+	if report.CompliantSystemsCount != 1 || report.DriftedSystemsCount != 1 || len(report.Systems) != 2 {
+		t.Fatalf("GetFirmwareComplianceReport() = %+v", report)
+	}
+	drifted := report.Systems[1]
+	wantDrift := []uresponse.DriftedComponent{{Name: "iLO 5", Status: driftStatusDrifted, InstalledVersion: "1.10", ExpectedVersion: "2.0", VersionMatch: versionMatchMinimum}}
+	drifted.DriftedComponents[0].FirmwareInventory = nil
+	if drifted.System.Oid != "/redfish/v1/Systems/s2.1" || !reflect.DeepEqual(drifted.DriftedComponents, wantDrift) {
+		t.Errorf("GetFirmwareComplianceReport() drifted system = %+v", drifted)
+	}
+
+	resp = e.GetFirmwareComplianceReport(mockContext(), &updateproto.UpdateRequest{ResourceID: "b2"})
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("GetFirmwareComplianceReport() of unknown baseline status = %v, want %v", resp.StatusCode, http.StatusNotFound)
+	}
+}
+
+func TestRemediateFirmwareDrift(t *testing.T) {
+	config.SetUpMockConfig(t)
+	healthCheckInterval = time.Millisecond
+	defer func() { healthCheckInterval = 30 * time.Second }()
+
+	tests := []struct {
+		name        string
+		servers     map[string]*fakeServer
+		request     string
+		wantUpdated []string
+		wantState   string
+	}{
+		{
+			name: "drifted servers updated",
+			servers: map[string]*fakeServer{
+				"s1": {version: "2.10", powerState: "On"},
+				"s2": {version: "1.0", powerState: "On"},
+				"s3": {version: "1.0", powerState: "On"},
+			},
+			wantUpdated: []string{"s2", "s3"},
+			wantState:   common.Completed,
+		},
+		{
+			name: "remediation limited to the targets",
+			servers: map[string]*fakeServer{
+				"s1": {version: "1.0", powerState: "On"},
+				"s2": {version: "1.0", powerState: "On"},
+			},
+			request:     `{"Targets":["/redfish/v1/Systems/s2.1"]}`,
+			wantUpdated: []string{"s2"},
+			wantState:   common.Completed,
+		},
+		{
+			name: "servers updated above the minimum version",
+			servers: map[string]*fakeServer{
+				"s1": {version: "1.0", powerState: "On", updatedVersion: "2.30"},
+			},
+			wantUpdated: []string{"s1"},
+			wantState:   common.Completed,
+		},
+		{
+			name: "servers without the component not remediated",
+			servers: map[string]*fakeServer{
+				"s1": {version: "", powerState: "On"},
+				"s2": {version: "1.0", powerState: "On"},
+			},
+			wantUpdated: []string{"s2"},
+			wantState:   common.Completed,
+		},
+		{
+			name: "remediation paused by failed servers",
+			servers: map[string]*fakeServer{
+				"s1": {version: "1.0", powerState: "On", failUpdate: true},
+				"s2": {version: "1.0", powerState: "On"},
+			},
+			request:   `{"BatchSize":1,"FailureThreshold":1}`,
+			wantState: common.Suspended,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inventory := make(map[string][2]string)
+			for uuid, server := range tt.servers {
+				inventory[uuid] = [2]string{"ProLiant DL360 Gen10", server.version}
+			}
+			db := newFakeBaselineDB(inventory)
+			for _, server := range tt.servers {
+				// the version the plugin reports after the update
+				server.version = "1.0"
+			}
+			fleet := &fakeFleet{servers: tt.servers}
+			e := fleet.externalInterface()
+			db.setDB(e)
+			e.RemediateFirmwareDrift(mockContext(), "rollingTask", "admin", &updateproto.UpdateRequest{ResourceID: "b1", RequestBody: []byte(tt.request)})
+			// the servers of a batch are updated concurrently
+			sort.Strings(fleet.updated)
+			if !reflect.DeepEqual(fleet.updated, tt.wantUpdated) {
+				t.Errorf("RemediateFirmwareDrift() updated servers = %v, want %v", fleet.updated, tt.wantUpdated)
+			}
+			if fleet.taskState != tt.wantState {
+				t.Errorf("RemediateFirmwareDrift() task state = %v, want %v", fleet.taskState, tt.wantState)
+			}
+		})
+	}
+}
+
+func Test_compareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"2.10", "2.9", 1},
+		{"2.9", "2.10", -1},
+		{"1.2.0", "1.2", 0},
+		{"U30 v2.54", "U30 v2.54", 0},
+		{"1.0-a", "1.0-b", -1},
+	}
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareVersions(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func Test_driftOf(t *testing.T) {
+	component := umodel.BaselineComponent{Name: "NIC", Version: "2.0", VersionMatch: versionMatchMinimum}
+	tests := []struct {
+		name        string
+		component   umodel.BaselineComponent
+		firmware    []firmwareComponent
+		wantDrifted bool
+		wantStatus  string
+		wantVersion string
+	}{
+		{
+			name:      "all the resources of the component compliant",
+			component: component,
+			firmware: []firmwareComponent{
+				{uri: "nic1", Name: "NIC", Version: "2.0"},
+				{uri: "nic2", Name: "NIC", Version: "2.1"},
+			},
+		},
+		{
+			name:      "a resource of the component drifted",
+			component: component,
+			firmware: []firmwareComponent{
+				{uri: "nic1", Name: "NIC", Version: "2.0"},
+				{uri: "nic2", Name: "NIC", Version: "1.9"},
+			},
+			wantDrifted: true,
+			wantStatus:  driftStatusDrifted,
+			wantVersion: "1.9",
+		},
+		{
+			name:      "exact version expected",
+			component: umodel.BaselineComponent{Name: "NIC", Version: "2.0", VersionMatch: versionMatchExact},
+			firmware: []firmwareComponent{
+				{uri: "nic1", Name: "NIC", Version: "2.1"},
+			},
+			wantDrifted: true,
+			wantStatus:  driftStatusDrifted,
+			wantVersion: "2.1",
+		},
+		{
+			name:      "component identified by its software ID",
+			component: umodel.BaselineComponent{Name: "NIC", SoftwareID: "nic", Version: "2.0"},
+			firmware: []firmwareComponent{
+				{uri: "bmc", Name: "NIC", SoftwareID: "bmc", Version: "1.0"},
+				{uri: "nic1", Name: "Network adapter", SoftwareID: "nic", Version: "2.0"},
+			},
+		},
+		{
+			name:        "component not installed",
+			component:   component,
+			firmware:    []firmwareComponent{{uri: "bmc", Name: "iLO 5", Version: "2.0"}},
+			wantDrifted: true,
+			wantStatus:  driftStatusMissing,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			drifted, ok := driftOf(tt.component, tt.firmware)
+			if ok != tt.wantDrifted || ok && drifted.Status != tt.wantStatus || drifted.InstalledVersion != tt.wantVersion {
+				t.Errorf("driftOf() = %+v, %v, want drifted %v %v with installed version %v", drifted, ok, tt.wantDrifted, tt.wantStatus, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func Test_baselineComponentIndex(t *testing.T) {
+	baseline := umodel.FirmwareBaseline{
+		Components: []umodel.BaselineComponent{
+			{Name: "iLO 5", Version: "2.0", VersionMatch: versionMatchExact},
+			{Name: "iLO 5", Version: "2.0"},
+		},
+	}
+	if i := baselineComponentIndex(baseline, uresponse.DriftedComponent{Name: "iLO 5", ExpectedVersion: "2.0", VersionMatch: versionMatchMinimum}); i != 1 {
+		t.Errorf("baselineComponentIndex() = %v, want 1", i)
+	}
+	if i := baselineComponentIndex(baseline, uresponse.DriftedComponent{Name: "System ROM", ExpectedVersion: "2.0", VersionMatch: versionMatchMinimum}); i != -1 {
+		t.Errorf("baselineComponentIndex() of unknown component = %v, want -1", i)
+	}
+}
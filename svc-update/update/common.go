@@ -87,16 +87,44 @@ type responseStatus struct {
 type DB struct {
 	GetAllKeysFromTable func(context.Context, string, common.DbType) ([]string, error)
 	GetResource         func(context.Context, string, string, common.DbType) (string, *errors.Error)
+	GetAggregate        func(context.Context, string) (umodel.Aggregate, *errors.Error)
+	// CreateFirmwareBaseline, GetFirmwareBaseline and DeleteFirmwareBaseline operate on the firmware baselines
+	CreateFirmwareBaseline func(context.Context, umodel.FirmwareBaseline) *errors.Error
+	GetFirmwareBaseline    func(context.Context, string) (umodel.FirmwareBaseline, *errors.Error)
+	DeleteFirmwareBaseline func(context.Context, string) *errors.Error
 }
 
 // SimpleUpdateRequest struct defines the request body for update action
 type SimpleUpdateRequest struct {
-	ImageURI                  string   `json:"ImageURI"`
-	Password                  string   `json:"Password,omitempty"`
-	Targets                   []string `json:"Targets"`
-	TransferProtocol          string   `json:"TransferProtocol,omitempty"`
-	Username                  string   `json:"Username,omitempty"`
-	RedfishOperationApplyTime string   `json:"@Redfish.OperationApplyTime,omitempty"`
+	ImageURI                  string           `json:"ImageURI"`
+	Password                  string           `json:"Password,omitempty"`
+	Targets                   []string         `json:"Targets"`
+	TransferProtocol          string           `json:"TransferProtocol,omitempty"`
+	Username                  string           `json:"Username,omitempty"`
+	RedfishOperationApplyTime string           `json:"@Redfish.OperationApplyTime,omitempty"`
+	Oem                       *SimpleUpdateOem `json:"Oem,omitempty"`
+}
+
+// SimpleUpdateOem holds the Oem properties of the simple update request
+type SimpleUpdateOem struct {
+	Odim *RolloutRequest `json:"Odim,omitempty"`
+}
+
+// RolloutRequest holds how an update of many servers is rolled out in batches
+type RolloutRequest struct {
+	BatchSize        int `json:"BatchSize,omitempty"`
+	FailureThreshold int `json:"FailureThreshold,omitempty"`
+	// ExpectedVersion is the firmware version the component updated by the image
+	// must report in the FirmwareInventory of a server after the update
+	ExpectedVersion string `json:"ExpectedVersion,omitempty"`
+	// ComponentName or SoftwareID identifies the FirmwareInventory resource of the
+	// component updated by the image, SoftwareID is used when both are given
+	ComponentName string `json:"ComponentName,omitempty"`
+	SoftwareID    string `json:"SoftwareId,omitempty"`
+	// VersionMatch is Exact, which is the default, or Minimum for accepting
+	// the versions higher than ExpectedVersion
+	VersionMatch              string `json:"VersionMatch,omitempty"`
+	HealthCheckTimeoutSeconds int    `json:"HealthCheckTimeoutSeconds,omitempty"`
 }
 
 // monitorTaskRequest hold values required monitorTask function
@@ -129,8 +157,12 @@ func GetExternalInterface() *ExternalInterface {
 			GenericSave:        umodel.GenericSave,
 		},
 		DB: DB{
-			GetAllKeysFromTable: umodel.GetAllKeysFromTable,
-			GetResource:         umodel.GetResource,
+			GetAllKeysFromTable:    umodel.GetAllKeysFromTable,
+			GetResource:            umodel.GetResource,
+			GetAggregate:           umodel.GetAggregate,
+			CreateFirmwareBaseline: umodel.CreateFirmwareBaseline,
+			GetFirmwareBaseline:    umodel.GetFirmwareBaseline,
+			DeleteFirmwareBaseline: umodel.DeleteFirmwareBaseline,
 		},
 	}
 }
@@ -0,0 +1,401 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-update/ucommon"
+)
+
+const (
+	aggregateURIPrefix = "/redfish/v1/AggregationService/Aggregates/"
+	// defaultHealthCheckTimeout is how long a server is waited for to come back healthy
+	// after its update when the timeout is not given
+	defaultHealthCheckTimeout = 30 * time.Minute
+)
+
+// healthCheckInterval is the interval at which an updated server is checked for its health
+var healthCheckInterval = 30 * time.Second
+
+// isRollingUpdate tells whether the update is to be rolled out in batches, which is the case
+// when an aggregate is targeted or the rollout properties are given
+func isRollingUpdate(updateRequest SimpleUpdateRequest) bool {
+	if updateRequest.Oem != nil && updateRequest.Oem.Odim != nil {
+		return true
+	}
+	for _, target := range updateRequest.Targets {
+		if strings.HasPrefix(target, aggregateURIPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// rollingSimpleUpdate updates the servers targeted in batches. The servers of a batch are
+// updated only after the servers of the previous batch report PowerState On and the expected
+// firmware version of the component updated. The rollout is paused, leaving the task suspended, when the
+// number of failed servers reaches the failure threshold.
+func (e *ExternalInterface) rollingSimpleUpdate(ctx context.Context, taskID, sessionUserName string, updateRequest SimpleUpdateRequest, taskInfo *common.TaskUpdateInfo) {
+	var rollout RolloutRequest
+	if updateRequest.Oem != nil && updateRequest.Oem.Odim != nil {
+		rollout = *updateRequest.Oem.Odim
+	}
+	policy := common.RolloutPolicy{BatchSize: rollout.BatchSize, FailureThreshold: rollout.FailureThreshold}
+	if err := policy.Validate(); err != nil {
+		errMsg := "invalid rollout properties: " + err.Error()
+		l.LogWithFields(ctx).Warn(errMsg)
+		common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errMsg, []interface{}{fmt.Sprintf("%v", policy), "Oem/Odim"}, taskInfo)
+		return
+	}
+	if rollout.HealthCheckTimeoutSeconds < 0 {
+		errMsg := "HealthCheckTimeoutSeconds must not be negative"
+		l.LogWithFields(ctx).Warn(errMsg)
+		common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errMsg, []interface{}{fmt.Sprintf("%v", rollout.HealthCheckTimeoutSeconds), "HealthCheckTimeoutSeconds"}, taskInfo)
+		return
+	}
+	// without the version of the component updated, a server still running its update
+	// would pass the health check
+	if rollout.ExpectedVersion == "" {
+		errMsg := "ExpectedVersion is required to check the servers after their update"
+		l.LogWithFields(ctx).Warn(errMsg)
+		common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"Oem/Odim/ExpectedVersion"}, taskInfo)
+		return
+	}
+	if rollout.ComponentName == "" && rollout.SoftwareID == "" {
+		errMsg := "ComponentName or SoftwareId of the component updated is required to check the servers after their update"
+		l.LogWithFields(ctx).Warn(errMsg)
+		common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"Oem/Odim/ComponentName"}, taskInfo)
+		return
+	}
+	switch rollout.VersionMatch {
+	case "":
+		rollout.VersionMatch = versionMatchExact
+	case versionMatchMinimum, versionMatchExact:
+	default:
+		errMsg := "VersionMatch must be " + versionMatchMinimum + " or " + versionMatchExact
+		l.LogWithFields(ctx).Warn(errMsg)
+		common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errMsg, []interface{}{rollout.VersionMatch, "Oem/Odim/VersionMatch"}, taskInfo)
+		return
+	}
+	if updateRequest.RedfishOperationApplyTime == "OnStartUpdateRequest" {
+		errMsg := "the updates rolled out in batches are applied immediately"
+		l.LogWithFields(ctx).Warn(errMsg)
+		common.GeneralError(http.StatusBadRequest, response.PropertyValueConflict, errMsg, []interface{}{"@Redfish.OperationApplyTime", "Oem/Odim"}, taskInfo)
+		return
+	}
+
+	targets, ok := e.getRolloutTargets(ctx, updateRequest.Targets, taskInfo)
+	if !ok {
+		return
+	}
+	// the servers are updated in the order they are given, with all the targets of a server in one request
+	var servers []string
+	serverTargets := make(map[string][]string)
+	for _, target := range targets {
+		systemID, err := systemIDOf(target)
+		if err != nil {
+			l.LogWithFields(ctx).Warn(err.Error())
+			common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, err.Error(), []interface{}{target, "Targets"}, taskInfo)
+			return
+		}
+		if _, ok := serverTargets[systemID]; !ok {
+			servers = append(servers, systemID)
+		}
+		serverTargets[systemID] = append(serverTargets[systemID], target)
+	}
+	if len(servers) == 0 {
+		errMsg := "the aggregates targeted have no servers"
+		l.LogWithFields(ctx).Warn(errMsg)
+		common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errMsg, []interface{}{fmt.Sprintf("%v", updateRequest.Targets), "Targets"}, taskInfo)
+		return
+	}
+
+	updateRequest.Oem = nil
+	progress := rolloutProgress{total: len(servers)}
+	result := e.rollOutUpdate(ctx, taskID, sessionUserName, updateRequest, rollout, servers, serverTargets, taskInfo, progress)
+	e.completeRollout(ctx, taskID, taskInfo, result, progress, "")
+}
+
+// rolloutProgress holds the progress of a task rolling out updates, which may be made of
+// many rollouts
+type rolloutProgress struct {
+	// done is the number of servers the previous rollouts of the task were done on
+	done  int
+	total int
+}
+
+func (p rolloutProgress) percentComplete(result common.RolloutResult) int32 {
+	return int32((p.done + result.Done()) * 100 / p.total)
+}
+
+// rollOutUpdate updates the servers in batches as given in the rollout request. serverTargets
+// holds the targets of the update request for each server.
+func (e *ExternalInterface) rollOutUpdate(ctx context.Context, taskID, sessionUserName string, updateRequest SimpleUpdateRequest, rollout RolloutRequest,
+	servers []string, serverTargets map[string][]string, taskInfo *common.TaskUpdateInfo, progress rolloutProgress) common.RolloutResult {
+	healthCheckTimeout := defaultHealthCheckTimeout
+	if rollout.HealthCheckTimeoutSeconds > 0 {
+		healthCheckTimeout = time.Duration(rollout.HealthCheckTimeoutSeconds) * time.Second
+	}
+	operation := func(ctx context.Context, systemID string) bool {
+		if healthy, _ := e.isServerHealthy(ctx, systemID, rollout); healthy {
+			l.LogWithFields(ctx).Infof("skipping the update of server %s as it already has the firmware version %s (%s)", systemID, rollout.ExpectedVersion, rollout.VersionMatch)
+			return true
+		}
+		serverRequest := updateRequest
+		serverRequest.Targets = serverTargets[systemID]
+		marshalBody, err := JSONMarshalFunc(serverRequest)
+		if err != nil {
+			l.LogWithFields(ctx).Warn("Unable to parse the simple update request" + err.Error())
+			return false
+		}
+		subTaskChannel := make(chan int32, 1)
+		uuid := strings.SplitN(systemID, ".", 2)[0]
+		e.sendRequest(ctx, uuid, taskID, "/redfish/v1/Systems/"+systemID, string(marshalBody), "", subTaskChannel, sessionUserName)
+		if statusCode := <-subTaskChannel; statusCode != http.StatusOK && statusCode != http.StatusAccepted {
+			l.LogWithFields(ctx).Warnf("simple update of server %s failed with status %d", systemID, statusCode)
+			return false
+		}
+		return e.waitForHealthyServer(ctx, systemID, rollout, healthCheckTimeout)
+	}
+	batchDone := func(result common.RolloutResult) {
+		percentComplete := progress.percentComplete(result)
+		if percentComplete == 100 {
+			return
+		}
+		var resp response.RPC
+		resp.StatusCode = http.StatusOK
+		task := fillTaskData(taskID, taskInfo.TargetURI, taskInfo.TaskRequest, resp, common.Running, common.OK, percentComplete, http.MethodPost)
+		err := e.External.UpdateTask(ctx, task)
+		if err != nil && err.Error() == common.Cancelling {
+			task = fillTaskData(taskID, taskInfo.TargetURI, taskInfo.TaskRequest, resp, common.Cancelled, common.OK, percentComplete, http.MethodPost)
+			e.External.UpdateTask(ctx, task)
+			runtime.Goexit()
+		}
+	}
+	policy := common.RolloutPolicy{BatchSize: rollout.BatchSize, FailureThreshold: rollout.FailureThreshold}
+	return common.RollOut(ctx, servers, policy, operation, batchDone)
+}
+
+// completeRollout completes the task with the result of the rollout, leaving it suspended
+// when the rollout is paused. note is added to the message of the task.
+func (e *ExternalInterface) completeRollout(ctx context.Context, taskID string, taskInfo *common.TaskUpdateInfo, result common.RolloutResult, progress rolloutProgress, note string) {
+	if result.Paused() {
+		errMsg := fmt.Sprintf("the rollout is paused as the update of servers %v failed, servers %v are not updated. %sfor more information please check SubTasks in URI: /redfish/v1/TaskService/Tasks/%s",
+			result.Failed, result.Skipped, note, taskID)
+		l.LogWithFields(ctx).Warn(errMsg)
+		resp := common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+		task := fillTaskData(taskID, taskInfo.TargetURI, taskInfo.TaskRequest, resp, common.Suspended, common.Critical, progress.percentComplete(result), http.MethodPost)
+		e.External.UpdateTask(ctx, task)
+		return
+	}
+	if len(result.Failed) > 0 {
+		errMsg := fmt.Sprintf("the update of servers %v failed. %sfor more information please check SubTasks in URI: /redfish/v1/TaskService/Tasks/%s", result.Failed, note, taskID)
+		l.LogWithFields(ctx).Warn(errMsg)
+		common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+		return
+	}
+
+	l.LogWithFields(ctx).Info("All SimpleUpdate requests successfully completed. for more information please check SubTasks in URI: /redfish/v1/TaskService/Tasks/" + taskID)
+	var resp response.RPC
+	resp.StatusMessage = response.Success
+	resp.StatusCode = http.StatusOK
+	args := response.Args{
+		Code:    resp.StatusMessage,
+		Message: "Request completed successfully. " + note,
+	}
+	resp.Body = args.CreateGenericErrorResponse()
+	task := fillTaskData(taskID, taskInfo.TargetURI, taskInfo.TaskRequest, resp, common.Completed, common.OK, 100, http.MethodPost)
+	err := e.External.UpdateTask(ctx, task)
+	if err != nil && err.Error() == common.Cancelling {
+		task = fillTaskData(taskID, taskInfo.TargetURI, taskInfo.TaskRequest, resp, common.Cancelled, common.Critical, 100, http.MethodPost)
+		e.External.UpdateTask(ctx, task)
+		runtime.Goexit()
+	}
+}
+
+// getRolloutTargets returns the targets of the request with the aggregates replaced by their servers
+func (e *ExternalInterface) getRolloutTargets(ctx context.Context, requestTargets []string, taskInfo *common.TaskUpdateInfo) ([]string, bool) {
+	var targets []string
+	for _, target := range requestTargets {
+		if !strings.HasPrefix(target, aggregateURIPrefix) {
+			targets = append(targets, target)
+			continue
+		}
+		aggregate, err := e.DB.GetAggregate(ctx, target)
+		if err != nil {
+			errMsg := "unable to get the aggregate " + target + ": " + err.Error()
+			l.LogWithFields(ctx).Warn(errMsg)
+			common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"Aggregate", target}, taskInfo)
+			return nil, false
+		}
+		for _, element := range aggregate.Elements {
+			targets = append(targets, element.OdataID)
+		}
+	}
+	return targets, true
+}
+
+// systemIDOf returns the ID of the system, in the form uuid.id, the target belongs to
+func systemIDOf(target string) (string, error) {
+	parts := strings.Split(target, "/")
+	for i, part := range parts {
+		if part != "Systems" || i+1 == len(parts) {
+			continue
+		}
+		if ids := strings.SplitN(parts[i+1], ".", 2); len(ids) == 2 && ids[0] != "" && ids[1] != "" {
+			return parts[i+1], nil
+		}
+	}
+	return "", fmt.Errorf("the target %s of a rolling update is not a system", target)
+}
+
+// waitForHealthyServer waits until the server reports PowerState On and the expected firmware
+// version of the component updated, and returns false if it does not in the timeout
+func (e *ExternalInterface) waitForHealthyServer(ctx context.Context, systemID string, rollout RolloutRequest, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for {
+		healthy, err := e.isServerHealthy(ctx, systemID, rollout)
+		if healthy {
+			return true
+		}
+		if time.Now().After(deadline) {
+			if err != nil {
+				l.LogWithFields(ctx).Warnf("health check of server %s failed: %s", systemID, err.Error())
+			}
+			l.LogWithFields(ctx).Warnf("server %s did not come back healthy in %v after its update", systemID, timeout)
+			return false
+		}
+		time.Sleep(healthCheckInterval)
+	}
+}
+
+// isServerHealthy tells whether the server reports PowerState On and, in the FirmwareInventory
+// resources of the component updated, a version complying with the expected version
+func (e *ExternalInterface) isServerHealthy(ctx context.Context, systemID string, rollout RolloutRequest) (bool, error) {
+	ids := strings.SplitN(systemID, ".", 2)
+	uuid, id := ids[0], ids[1]
+	contactRequest, err := e.newDeviceContactRequest(ctx, uuid)
+	if err != nil {
+		return false, err
+	}
+	var system struct {
+		PowerState string `json:"PowerState"`
+	}
+	if err := e.getDeviceResource(ctx, contactRequest, "/redfish/v1/Systems/"+id, &system); err != nil {
+		return false, err
+	}
+	if system.PowerState != "On" {
+		return false, nil
+	}
+	var firmwareInventory struct {
+		Members []struct {
+			OdataID string `json:"@odata.id"`
+		} `json:"Members"`
+	}
+	if err := e.getDeviceResource(ctx, contactRequest, "/redfish/v1/UpdateService/FirmwareInventory", &firmwareInventory); err != nil {
+		return false, err
+	}
+	found := false
+	for _, member := range firmwareInventory.Members {
+		var firmware firmwareComponent
+		if err := e.getDeviceResource(ctx, contactRequest, member.OdataID, &firmware); err != nil {
+			return false, err
+		}
+		if !isComponent(rollout.ComponentName, rollout.SoftwareID, firmware) {
+			continue
+		}
+		if !versionComplies(firmware.Version, rollout.ExpectedVersion, rollout.VersionMatch) {
+			return false, nil
+		}
+		found = true
+	}
+	return found, nil
+}
+
+// isComponent tells whether the firmware is of the component of the given name or software ID,
+// the software ID is used when given
+func isComponent(name, softwareID string, firmware firmwareComponent) bool {
+	if softwareID != "" {
+		return firmware.SoftwareID == softwareID
+	}
+	return strings.EqualFold(firmware.Name, name)
+}
+
+// newDeviceContactRequest returns the request for reading the resources of the server through
+// its plugin, logged in to the plugin once for all the resources read with it
+func (e *ExternalInterface) newDeviceContactRequest(ctx context.Context, uuid string) (ucommon.PluginContactRequest, error) {
+	var contactRequest ucommon.PluginContactRequest
+	target, gerr := e.External.GetTarget(uuid)
+	if gerr != nil {
+		return contactRequest, gerr
+	}
+	password, err := e.External.DevicePassword(target.Password)
+	if err != nil {
+		return contactRequest, fmt.Errorf("unable to decrypt device password: %v", err)
+	}
+	plugin, gerr := e.External.GetPluginData(target.PluginID)
+	if gerr != nil {
+		return contactRequest, gerr
+	}
+	contactRequest.ContactClient = e.External.ContactClient
+	contactRequest.Plugin = plugin
+	if StringsEqualFoldFunc(plugin.PreferredAuthType, "XAuthToken") {
+		contactRequest.HTTPMethodType = http.MethodPost
+		contactRequest.DeviceInfo = map[string]interface{}{
+			"UserName": plugin.Username,
+			"Password": string(plugin.Password),
+		}
+		contactRequest.OID = "/ODIM/v1/Sessions"
+		_, token, _, _, err := e.External.ContactPlugin(ctx, contactRequest, "error while creating session with the plugin: ")
+		if err != nil {
+			return contactRequest, err
+		}
+		contactRequest.Token = token
+	} else {
+		contactRequest.BasicAuth = map[string]string{
+			"UserName": plugin.Username,
+			"Password": string(plugin.Password),
+		}
+	}
+	contactRequest.DeviceInfo = map[string]interface{}{
+		"ManagerAddress": target.ManagerAddress,
+		"UserName":       target.UserName,
+		"Password":       password,
+	}
+	return contactRequest, nil
+}
+
+// getDeviceResource reads the resource of the given URI from the server with the contact request
+// of newDeviceContactRequest
+func (e *ExternalInterface) getDeviceResource(ctx context.Context, contactRequest ucommon.PluginContactRequest,
+	uri string, resource interface{}) error {
+	contactRequest.OID = uri
+	contactRequest.HTTPMethodType = http.MethodGet
+	body, _, _, _, err := e.External.ContactPlugin(ctx, contactRequest, "error while getting the details "+uri+": ")
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, resource)
+}
@@ -0,0 +1,281 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	updateproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/update"
+	"github.com/ODIM-Project/ODIM/svc-update/ucommon"
+	"github.com/ODIM-Project/ODIM/svc-update/umodel"
+)
+
+// fakeServer is a server as seen through its plugin
+type fakeServer struct {
+	version    string
+	powerState string
+	failUpdate bool
+	// stayOff keeps the server powered off after its update
+	stayOff bool
+	// updatedVersion is the version reported after the update, 2.0 when empty
+	updatedVersion string
+}
+
+// fakeFleet holds the servers of a rolling update test and records what is done to them
+type fakeFleet struct {
+	lock      sync.Mutex
+	servers   map[string]*fakeServer
+	updated   []string
+	taskState string
+	// targetReads and sessions count the reads of the servers details and the plugin sessions
+	targetReads int
+	sessions    int
+}
+
+func (f *fakeFleet) getTarget(uuid string) (*umodel.Target, *errors.Error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.targetReads++
+	return &umodel.Target{ManagerAddress: uuid, UserName: "admin", Password: []byte("password"), PluginID: "GRF"}, nil
+}
+
+func (f *fakeFleet) getAggregate(ctx context.Context, aggregateURI string) (umodel.Aggregate, *errors.Error) {
+	if aggregateURI != "/redfish/v1/AggregationService/Aggregates/a1" {
+		return umodel.Aggregate{}, errors.PackError(errors.DBKeyNotFound, "no data with the key found")
+	}
+	var uuids []string
+	for uuid := range f.servers {
+		uuids = append(uuids, uuid)
+	}
+	sort.Strings(uuids)
+	var aggregate umodel.Aggregate
+	for _, uuid := range uuids {
+		aggregate.Elements = append(aggregate.Elements, umodel.OdataID{OdataID: "/redfish/v1/Systems/" + uuid + ".1"})
+	}
+	return aggregate, nil
+}
+
+func (f *fakeFleet) updateTask(ctx context.Context, task common.TaskData) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if task.TaskID == "rollingTask" {
+		f.taskState = task.TaskState
+	}
+	return nil
+}
+
+func (f *fakeFleet) contactPlugin(ctx context.Context, req ucommon.PluginContactRequest, errorMessage string) ([]byte, string, string, ucommon.ResponseStatus, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if req.OID == "/ODIM/v1/Sessions" {
+		f.sessions++
+		return nil, "token", "", ucommon.ResponseStatus{StatusCode: http.StatusCreated}, nil
+	}
+	var address string
+	switch device := req.DeviceInfo.(type) {
+	case *umodel.Target:
+		address = device.ManagerAddress
+	case map[string]interface{}:
+		address = device["ManagerAddress"].(string)
+	}
+	server := f.servers[address]
+	var body interface{}
+	switch req.OID {
+	case "/ODIM/v1/UpdateService/Actions/UpdateService.SimpleUpdate":
+		if server.failUpdate {
+			return nil, "", "", ucommon.ResponseStatus{StatusCode: http.StatusInternalServerError}, fmt.Errorf("%supdate failed", errorMessage)
+		}
+		f.updated = append(f.updated, address)
+		server.version = "2.0"
+		if server.updatedVersion != "" {
+			server.version = server.updatedVersion
+		}
+		if !server.stayOff {
+			server.powerState = "On"
+		}
+		return []byte(`{}`), "", "", ucommon.ResponseStatus{StatusCode: http.StatusOK}, nil
+	case "/redfish/v1/Systems/1":
+		body = map[string]string{"PowerState": server.powerState}
+	case "/redfish/v1/UpdateService/FirmwareInventory":
+		body = map[string]interface{}{"Members": []map[string]string{
+			{"@odata.id": "/redfish/v1/UpdateService/FirmwareInventory/BIOS"},
+			{"@odata.id": "/redfish/v1/UpdateService/FirmwareInventory/BMC"},
+		}}
+	case "/redfish/v1/UpdateService/FirmwareInventory/BIOS":
+		// another component already having the version expected of the BMC
+		body = map[string]string{"Name": "System ROM", "SoftwareId": "bios", "Version": "2.0"}
+	case "/redfish/v1/UpdateService/FirmwareInventory/BMC":
+		body = map[string]string{"Name": "iLO 5", "SoftwareId": "ilo", "Version": server.version}
+	}
+	data, _ := json.Marshal(body)
+	return data, "", "", ucommon.ResponseStatus{StatusCode: http.StatusOK}, nil
+}
+
+func (f *fakeFleet) externalInterface() *ExternalInterface {
+	e := mockGetExternalInterface()
+	e.External.GetTarget = f.getTarget
+	e.External.ContactPlugin = f.contactPlugin
+	e.External.UpdateTask = f.updateTask
+	e.DB.GetAggregate = f.getAggregate
+	return e
+}
+
+func TestSimpleUpdateOfAggregate(t *testing.T) {
+	config.SetUpMockConfig(t)
+	healthCheckInterval = time.Millisecond
+	defer func() { healthCheckInterval = 30 * time.Second }()
+
+	tests := []struct {
+		name        string
+		servers     map[string]*fakeServer
+		request     string
+		wantUpdated []string
+		wantState   string
+	}{
+		{
+			name: "rollout paused by a failed batch",
+			servers: map[string]*fakeServer{
+				"s1": {version: "1.0", powerState: "On"},
+				"s2": {version: "1.0", powerState: "On", failUpdate: true},
+				"s3": {version: "1.0", powerState: "On"},
+				"s4": {version: "1.0", powerState: "On"},
+			},
+			request:     `{"ImageURI":"abc","Targets":["/redfish/v1/AggregationService/Aggregates/a1"],"Oem":{"Odim":{"BatchSize":2,"ExpectedVersion":"2.0","SoftwareId":"ilo"}}}`,
+			wantUpdated: []string{"s1"},
+			wantState:   common.Suspended,
+		},
+		{
+			name: "servers having the expected version skipped",
+			servers: map[string]*fakeServer{
+				"s1": {version: "2.0", powerState: "On"},
+				"s2": {version: "1.0", powerState: "On"},
+				"s3": {version: "1.0", powerState: "On"},
+			},
+			request:     `{"ImageURI":"abc","Targets":["/redfish/v1/AggregationService/Aggregates/a1"],"Oem":{"Odim":{"BatchSize":1,"ExpectedVersion":"2.0","ComponentName":"iLO 5"}}}`,
+			wantUpdated: []string{"s2", "s3"},
+			wantState:   common.Completed,
+		},
+		{
+			name: "server not healthy after its update",
+			servers: map[string]*fakeServer{
+				"s1": {version: "1.0", powerState: "Off", stayOff: true},
+				"s2": {version: "1.0", powerState: "On"},
+			},
+			request:     `{"ImageURI":"abc","Targets":["/redfish/v1/AggregationService/Aggregates/a1"],"Oem":{"Odim":{"BatchSize":1,"FailureThreshold":2,"ExpectedVersion":"2.0","SoftwareId":"ilo","HealthCheckTimeoutSeconds":1}}}`,
+			wantUpdated: []string{"s1", "s2"},
+			wantState:   common.Exception,
+		},
+		{
+			name: "server not reporting the expected version of the component",
+			servers: map[string]*fakeServer{
+				"s1": {version: "1.0", powerState: "On", updatedVersion: "1.5"},
+				"s2": {version: "1.0", powerState: "On"},
+			},
+			request:     `{"ImageURI":"abc","Targets":["/redfish/v1/AggregationService/Aggregates/a1"],"Oem":{"Odim":{"BatchSize":1,"ExpectedVersion":"2.0","SoftwareId":"ilo","HealthCheckTimeoutSeconds":1}}}`,
+			wantUpdated: []string{"s1"},
+			wantState:   common.Suspended,
+		},
+		{
+			name:      "rollout without expected version",
+			servers:   map[string]*fakeServer{"s1": {version: "1.0", powerState: "On"}},
+			request:   `{"ImageURI":"abc","Targets":["/redfish/v1/AggregationService/Aggregates/a1"],"Oem":{"Odim":{"SoftwareId":"ilo"}}}`,
+			wantState: common.Exception,
+		},
+		{
+			name:      "rollout without component",
+			servers:   map[string]*fakeServer{"s1": {version: "1.0", powerState: "On"}},
+			request:   `{"ImageURI":"abc","Targets":["/redfish/v1/AggregationService/Aggregates/a1"],"Oem":{"Odim":{"ExpectedVersion":"2.0"}}}`,
+			wantState: common.Exception,
+		},
+		{
+			name:      "unknown aggregate",
+			servers:   map[string]*fakeServer{},
+			request:   `{"ImageURI":"abc","Targets":["/redfish/v1/AggregationService/Aggregates/a2"],"Oem":{"Odim":{"ExpectedVersion":"2.0","SoftwareId":"ilo"}}}`,
+			wantState: common.Exception,
+		},
+		{
+			name:      "rollout with the update applied later",
+			servers:   map[string]*fakeServer{"s1": {version: "1.0", powerState: "On"}},
+			request:   `{"ImageURI":"abc","Targets":["/redfish/v1/AggregationService/Aggregates/a1"],"Oem":{"Odim":{"ExpectedVersion":"2.0","SoftwareId":"ilo"}},"@Redfish.OperationApplyTime":"OnStartUpdateRequest"}`,
+			wantState: common.Exception,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fleet := &fakeFleet{servers: tt.servers}
+			e := fleet.externalInterface()
+			e.SimpleUpdate(mockContext(), "rollingTask", "admin", &updateproto.UpdateRequest{RequestBody: []byte(tt.request)})
+			if !reflect.DeepEqual(fleet.updated, tt.wantUpdated) {
+				t.Errorf("SimpleUpdate() updated servers = %v, want %v", fleet.updated, tt.wantUpdated)
+			}
+			if fleet.taskState != tt.wantState {
+				t.Errorf("SimpleUpdate() task state = %v, want %v", fleet.taskState, tt.wantState)
+			}
+		})
+	}
+}
+
+func Test_isServerHealthy(t *testing.T) {
+	config.SetUpMockConfig(t)
+	fleet := &fakeFleet{servers: map[string]*fakeServer{"s1": {version: "2.0", powerState: "On"}}}
+	e := fleet.externalInterface()
+	e.External.GetPluginData = func(pluginID string) (umodel.Plugin, *errors.Error) {
+		return umodel.Plugin{ID: pluginID, PreferredAuthType: "XAuthToken"}, nil
+	}
+	healthy, err := e.isServerHealthy(mockContext(), "s1.1", RolloutRequest{ExpectedVersion: "2.0", SoftwareID: "ilo"})
+	if err != nil || !healthy {
+		t.Fatalf("isServerHealthy() = %v, %v, want healthy", healthy, err)
+	}
+	// the system, the inventory and its two members are read with one session
+	if fleet.targetReads != 1 || fleet.sessions != 1 {
+		t.Errorf("isServerHealthy() read the server details %d times with %d sessions, want once with one session",
+			fleet.targetReads, fleet.sessions)
+	}
+}
+
+func Test_systemIDOf(t *testing.T) {
+	tests := []struct {
+		target  string
+		want    string
+		wantErr bool
+	}{
+		{target: "/redfish/v1/Systems/uuid.1", want: "uuid.1"},
+		{target: "/redfish/v1/Systems/uuid.1/Bios", want: "uuid.1"},
+		{target: "/redfish/v1/Systems/uuid", wantErr: true},
+		{target: "/redfish/v1/Managers/uuid.1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			got, err := systemIDOf(tt.target)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("systemIDOf() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("systemIDOf() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
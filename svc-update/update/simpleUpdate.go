@@ -83,6 +83,11 @@ func (e *ExternalInterface) SimpleUpdate(ctx context.Context, taskID string, ses
 		return
 	}
 
+	if isRollingUpdate(updateRequest) {
+		e.rollingSimpleUpdate(ctx, taskID, sessionUserName, updateRequest, taskInfo)
+		return
+	}
+
 	targetList, err := sortTargetList(ctx, updateRequest.Targets)
 	if err != nil {
 		errorMessage := "SystemUUID not found"
@@ -0,0 +1,76 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package uresponse
+
+import (
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/svc-update/umodel"
+)
+
+// FirmwareBaseline defines the response of a firmware baseline
+type FirmwareBaseline struct {
+	OdataContext     string                     `json:"@odata.context"`
+	OdataID          string                     `json:"@odata.id"`
+	OdataType        string                     `json:"@odata.type"`
+	ID               string                     `json:"Id"`
+	Name             string                     `json:"Name"`
+	Description      string                     `json:"Description,omitempty"`
+	ApplicableModels []string                   `json:"ApplicableModels,omitempty"`
+	Components       []umodel.BaselineComponent `json:"Components"`
+	ComplianceReport dmtf.Link                  `json:"ComplianceReport"`
+	Actions          FirmwareBaselineActions    `json:"Actions"`
+}
+
+// FirmwareBaselineActions defines the links to the actions available on a firmware baseline
+type FirmwareBaselineActions struct {
+	Remediate FirmwareBaselineRemediate `json:"#FirmwareBaseline.Remediate"`
+}
+
+// FirmwareBaselineRemediate defines the target of the remediate action
+type FirmwareBaselineRemediate struct {
+	Target string `json:"target"`
+}
+
+// FirmwareComplianceReport defines how the firmware of the servers complies with a baseline
+type FirmwareComplianceReport struct {
+	OdataID               string             `json:"@odata.id"`
+	OdataType             string             `json:"@odata.type"`
+	ID                    string             `json:"Id"`
+	Name                  string             `json:"Name"`
+	Baseline              dmtf.Link          `json:"Baseline"`
+	CompliantSystemsCount int                `json:"CompliantSystemsCount"`
+	DriftedSystemsCount   int                `json:"DriftedSystemsCount"`
+	Systems               []SystemCompliance `json:"Systems"`
+}
+
+// SystemCompliance defines how the firmware of a server complies with a baseline
+type SystemCompliance struct {
+	System            dmtf.Link          `json:"System"`
+	Model             string             `json:"Model,omitempty"`
+	Compliant         bool               `json:"Compliant"`
+	DriftedComponents []DriftedComponent `json:"DriftedComponents,omitempty"`
+}
+
+// DriftedComponent defines a firmware component of a server not at the version of the baseline.
+// Status is Drifted, or Missing with an empty InstalledVersion when the server does not have the component.
+type DriftedComponent struct {
+	Name              string     `json:"Name,omitempty"`
+	SoftwareID        string     `json:"SoftwareId,omitempty"`
+	Status            string     `json:"Status"`
+	InstalledVersion  string     `json:"InstalledVersion,omitempty"`
+	ExpectedVersion   string     `json:"ExpectedVersion"`
+	VersionMatch      string     `json:"VersionMatch"`
+	FirmwareInventory *dmtf.Link `json:"FirmwareInventory,omitempty"`
+}